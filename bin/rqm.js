@@ -87,7 +87,9 @@ if (!binaryPath || !fs.existsSync(binaryPath)) {
 }
 
 // Execute the binary with all arguments
+// argv0 carries the name we were invoked as (rqm or requim) to the binary
 const child = spawn(binaryPath, process.argv.slice(2), {
+  argv0: path.basename(process.argv[1], '.js'),
   stdio: 'inherit',
   env: process.env
 });
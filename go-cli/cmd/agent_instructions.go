@@ -8,13 +8,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	installFlag bool
+	installFlag    bool
+	installTargets []string
 )
 
 var agentInstructionsCmd = &cobra.Command{
@@ -25,16 +27,31 @@ on how to follow Requirement-Driven Development (RDD) workflow with RQM.
 
 Output can be copied into .github/copilot-instructions.md or similar files.
 
-With --install flag, automatically appends to .github/copilot-instructions.md if found.`,
+With --install, the instructions are written into the file each --target
+tool reads, which is created, with its directory, if missing:
+
+  copilot   .github/copilot-instructions.md (the default)
+  cursor    .cursorrules
+  claude    CLAUDE.md
+  aider     CONVENTIONS.md (load it with aider --read CONVENTIONS.md)
+  windsurf  .windsurfrules
+
+Any other target containing a dot or a slash is taken as the path of a
+custom file. The instructions go between rqm:agent-instructions markers,
+so installing again after an upgrade replaces them in place and leaves
+the rest of the file alone.`,
 	Example: `  rqm agent-instructions
   rqm agent-instructions > .github/rdd-workflow.md
-  rqm agent-instructions --install`,
+  rqm agent-instructions --install
+  rqm agent-instructions --install --target claude,cursor
+  rqm agent-instructions --install --target docs/agents.md`,
 	RunE: runAgentInstructions,
 }
 
 func init() {
 	rootCmd.AddCommand(agentInstructionsCmd)
-	agentInstructionsCmd.Flags().BoolVar(&installFlag, "install", false, "Install instructions to .github/copilot-instructions.md")
+	agentInstructionsCmd.Flags().BoolVar(&installFlag, "install", false, "Install the instructions into the file of each --target")
+	agentInstructionsCmd.Flags().StringSliceVar(&installTargets, "target", []string{"copilot"}, "Tools to install for ("+strings.Join(sortedKeys(agentTargets), ", ")+"), or file paths")
 }
 
 func runAgentInstructions(cmd *cobra.Command, args []string) error {
@@ -216,6 +233,12 @@ Acceptance test: tests/acceptance/test_auth.sh"
 **Claude Code**: Reference in project context
 **Cline/Aider**: Include in system prompts
 
+**MCP clients** (Claude, Cursor, ...): Register ` + "`rqm mcp`" + ` as an MCP server to list, get, validate, add requirements and update their status through tool calls instead of shelling out:
+
+` + "```json" + `
+{"mcpServers": {"rqm": {"command": "rqm", "args": ["mcp"]}}}
+` + "```" + `
+
 ## Learn More
 
 - Run ` + "`rqm --help`" + ` for all commands
@@ -230,50 +253,126 @@ Use ` + "`rqm validate`" + ` frequently. Follow acceptance criteria strictly.
 `
 }
 
-func installInstructions(instructions string) error {
-	// Look for .github/copilot-instructions.md
-	githubDir := ".github"
-	targetFile := filepath.Join(githubDir, "copilot-instructions.md")
+// agentTargets maps the --target names to the instructions file each
+// tool reads
+var agentTargets = map[string]string{
+	"copilot":  filepath.Join(".github", "copilot-instructions.md"),
+	"cursor":   ".cursorrules",
+	"claude":   "CLAUDE.md",
+	"aider":    "CONVENTIONS.md",
+	"windsurf": ".windsurfrules",
+}
 
-	// Check if .github directory exists
-	if _, err := os.Stat(githubDir); os.IsNotExist(err) {
-		return fmt.Errorf(".github directory not found - please create it first")
-	}
+// Markers delimit the installed instructions, so installing again
+// replaces them instead of adding a second copy
+const (
+	instructionsStart = "<!-- rqm:agent-instructions:start -->"
+	instructionsEnd   = "<!-- rqm:agent-instructions:end -->"
+)
 
-	// Check if file exists
-	if _, err := os.Stat(targetFile); os.IsNotExist(err) {
-		return fmt.Errorf("%s not found - please create the file first", targetFile)
+// agentTargetFile resolves a --target to the file to install into: a
+// tool name, or the path of a custom file
+func agentTargetFile(target string) (string, error) {
+	if file, ok := agentTargets[target]; ok {
+		return file, nil
 	}
-
-	// Read existing content
-	existingContent, err := os.ReadFile(targetFile)
-	if err != nil {
-		return fmt.Errorf("failed to read %s: %w", targetFile, err)
+	if strings.ContainsAny(target, `./\`) {
+		return target, nil
 	}
+	return "", fmt.Errorf("unknown target %q (expected %s, or a file path)", target, strings.Join(sortedKeys(agentTargets), ", "))
+}
 
-	existingStr := string(existingContent)
-
-	// Check if RDD instructions already exist
-	if strings.Contains(existingStr, "Requirement-Driven Development") ||
-		strings.Contains(existingStr, "RDD Workflow") ||
-		strings.Contains(existingStr, "rqm validate") ||
-		strings.Contains(existingStr, "rqm agent-instructions") {
-		fmt.Printf("✓ %s already contains RQM/RDD instructions\n", targetFile)
-		fmt.Println("No changes made (instructions already present)")
-		return nil
+// installInstructions writes the instructions into the file of each
+// target, creating it and its directory when missing
+func installInstructions(instructions string) error {
+	var files []string
+	for _, target := range installTargets {
+		file, err := agentTargetFile(target)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+	for _, file := range files {
+		if err := installInstructionsFile(file, instructions); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// Append instructions
-	separator := "\n\n---\n\n"
-	newContent := existingStr + separator + instructions
+// installInstructionsFile adds the instructions to file between markers,
+// or replaces the ones installed before
+func installInstructionsFile(file, instructions string) error {
+	block := instructionsStart + "\n" + instructions + instructionsEnd + "\n"
 
-	if err := os.WriteFile(targetFile, []byte(newContent), 0644); err != nil {
-		return fmt.Errorf("failed to write to %s: %w", targetFile, err)
+	existing, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	content := string(existing)
+	var updated string
+	start, end := markedBlock(content)
+	switch {
+	case len(existing) == 0:
+		updated = block
+	case start >= 0:
+		updated = content[:start] + block + content[end:]
+	default:
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		updated = content + "\n" + block
 	}
 
-	fmt.Printf("✓ Successfully added RDD instructions to %s\n", targetFile)
-	fmt.Printf("  Added %d lines of RQM workflow guidance\n", strings.Count(instructions, "\n"))
-	fmt.Println("\nAI agents will now follow Requirement-Driven Development workflow!")
+	if updated == content {
+		fmt.Printf("%s %s already has the current RDD instructions\n", okMark(), file)
+		return nil
+	}
+	if dir := filepath.Dir(file); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(file, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("failed to write to %s: %w", file, err)
+	}
 
+	switch {
+	case len(existing) == 0:
+		fmt.Printf("%s Created %s with the RDD instructions\n", okMark(), file)
+	case start >= 0:
+		fmt.Printf("%s Updated the RDD instructions in %s\n", okMark(), file)
+	default:
+		fmt.Printf("%s Added the RDD instructions to %s\n", okMark(), file)
+	}
 	return nil
 }
+
+// markedBlock returns the start and end offsets of the instructions
+// installed in content, including their final line break, or -1 when
+// there are none. Instructions installed before the markers existed are
+// found by their first and last lines.
+func markedBlock(content string) (int, int) {
+	for _, bounds := range [][2]string{
+		{instructionsStart, instructionsEnd},
+		{"# AI Agent Instructions: Requirement-Driven Development (RDD)", "Follow acceptance criteria strictly."},
+	} {
+		start := strings.Index(content, bounds[0])
+		if start < 0 {
+			continue
+		}
+		end := strings.Index(content[start:], bounds[1])
+		if end < 0 {
+			continue
+		}
+		end += start + len(bounds[1])
+		if strings.HasPrefix(content[end:], "\n") {
+			end++
+		}
+		return start, end
+	}
+	return -1, -1
+}
@@ -0,0 +1,85 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallInstructions(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("CLAUDE.md", []byte("# Project notes\n\nUse tabs."), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	installTargets = []string{"copilot", "claude", "docs/agents/rqm.md"}
+	t.Cleanup(func() { installTargets = []string{"copilot"} })
+
+	if err := installInstructions("v1 instructions\n"); err != nil {
+		t.Fatal(err)
+	}
+	want := instructionsStart + "\nv1 instructions\n" + instructionsEnd + "\n"
+	for _, file := range []string{filepath.Join(".github", "copilot-instructions.md"), filepath.Join("docs", "agents", "rqm.md")} {
+		if data, err := os.ReadFile(file); err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v", file, data, err)
+		}
+	}
+	claude, _ := os.ReadFile("CLAUDE.md")
+	if string(claude) != "# Project notes\n\nUse tabs.\n\n"+want {
+		t.Errorf("CLAUDE.md = %q", claude)
+	}
+
+	// Installing again replaces the block, leaving the rest alone
+	if err := os.WriteFile("CLAUDE.md", append(claude, "More notes.\n"...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := installInstructions("v2 instructions\n"); err != nil {
+		t.Fatal(err)
+	}
+	claude, _ = os.ReadFile("CLAUDE.md")
+	if got := string(claude); strings.Count(got, instructionsStart) != 1 || !strings.Contains(got, "v2 instructions\n"+instructionsEnd+"\nMore notes.\n") || strings.Contains(got, "v1") {
+		t.Errorf("CLAUDE.md after reinstalling = %q", got)
+	}
+}
+
+func TestInstallInstructionsReplacesUnmarkedInstall(t *testing.T) {
+	t.Chdir(t.TempDir())
+	file := filepath.Join(".github", "copilot-instructions.md")
+	if err := os.MkdirAll(".github", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	old := "Team rules\n\n---\n\n# AI Agent Instructions: Requirement-Driven Development (RDD)\n\nOld text.\nUse `rqm validate` frequently. Follow acceptance criteria strictly.\n"
+	if err := os.WriteFile(file, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	installTargets = []string{"copilot"}
+	if err := installInstructions(getInstructions()); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(file)
+	got := string(data)
+	if !strings.HasPrefix(got, "Team rules\n\n---\n\n"+instructionsStart) || strings.Contains(got, "Old text") || !strings.HasSuffix(got, instructionsEnd+"\n") {
+		t.Errorf("%s = %q", file, got)
+	}
+}
+
+func TestAgentTargetFile(t *testing.T) {
+	for target, want := range map[string]string{
+		"cursor":        ".cursorrules",
+		"windsurf":      ".windsurfrules",
+		"aider":         "CONVENTIONS.md",
+		"AGENTS.md":     "AGENTS.md",
+		"docs/rules.md": "docs/rules.md",
+	} {
+		if got, err := agentTargetFile(target); err != nil || got != want {
+			t.Errorf("agentTargetFile(%q) = %q, %v", target, got, err)
+		}
+	}
+	if _, err := agentTargetFile("vim"); err == nil {
+		t.Error("agentTargetFile(vim) succeeded")
+	}
+}
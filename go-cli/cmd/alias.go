@@ -0,0 +1,172 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"go.yaml.in/yaml/v3"
+)
+
+// programNames are the names rqm answers to; a binary or symlink called
+// requim shows that name in help and completion
+var programNames = []string{"rqm", "requim"}
+
+// programName is the name rqm was invoked as, from argv[0], or rqm when
+// that isn't one of programNames
+func programName(argv0 string) string {
+	name := strings.TrimSuffix(filepath.Base(argv0), ".exe")
+	for _, known := range programNames {
+		if name == known {
+			return name
+		}
+	}
+	return "rqm"
+}
+
+// aliasedArgs is args with a configured alias expanded. A config that
+// can't be read only costs the aliases, so rqm doctor still runs.
+func aliasedArgs(args []string) ([]string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = "."
+	}
+	aliases, err := commandAliases(cwd, configFlag(args))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring command aliases: %v\n", err)
+		return args, nil
+	}
+	return expandAliases(args, aliases)
+}
+
+// commandAliases reads the aliases: sections of .rqm/config.yml, found by
+// walking up from dir, and the user config, which wins when both define an
+// alias. Each maps an alias to the command line it stands for.
+func commandAliases(dir, userConfig string) (map[string]string, error) {
+	// loadProjectConfig looks up the config from a requirements file
+	project, err := loadProjectConfig(filepath.Join(dir, "requirements.yml"))
+	if err != nil {
+		return nil, err
+	}
+	aliases := make(map[string]string)
+	for name, expansion := range project.Aliases {
+		aliases[name] = expansion
+	}
+
+	if userConfig == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return aliases, nil
+		}
+		userConfig = filepath.Join(home, ".rqm.yaml")
+	}
+	user, err := readAliases(userConfig)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for name, expansion := range user {
+		aliases[name] = expansion
+	}
+	return aliases, nil
+}
+
+// readAliases reads the aliases: map of the user config
+func readAliases(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config struct {
+		Aliases map[string]string `yaml:"aliases"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return config.Aliases, nil
+}
+
+// expandAliases replaces an alias in the command position of args with its
+// expansion, keeping the arguments around it. Built-in commands can't be
+// aliased, and an expansion isn't expanded again.
+func expandAliases(args []string, aliases map[string]string) ([]string, error) {
+	i := commandIndex(args, rootCmd.PersistentFlags())
+	if i < 0 {
+		return args, nil
+	}
+	expansion, ok := aliases[args[i]]
+	if !ok || isBuiltinCommand(args[i]) {
+		return args, nil
+	}
+
+	words, err := splitCommandLine(expansion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alias %s: %w", args[i], err)
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("invalid alias %s: empty expansion", args[i])
+	}
+	expanded := append([]string{}, args[:i]...)
+	expanded = append(expanded, words...)
+	return append(expanded, args[i+1:]...), nil
+}
+
+// commandIndex finds the first argument that isn't a global flag or a
+// global flag's value, or -1 when there is none
+func commandIndex(args []string, flags *pflag.FlagSet) int {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return -1
+		case !strings.HasPrefix(arg, "-") || arg == "-":
+			return i
+		case strings.Contains(arg, "="):
+			continue
+		}
+
+		var flag *pflag.Flag
+		if strings.HasPrefix(arg, "--") {
+			flag = flags.Lookup(arg[2:])
+		} else if len(arg) == 2 {
+			flag = flags.ShorthandLookup(arg[1:])
+		}
+		// Flags that take a value consume the next argument
+		if flag != nil && flag.NoOptDefVal == "" {
+			i++
+		}
+	}
+	return -1
+}
+
+// isBuiltinCommand reports whether name is a command or command alias of rqm
+func isBuiltinCommand(name string) bool {
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help"
+}
+
+// configFlag is the value of --config in args, found before cobra parses
+// them so aliases can come from it
+func configFlag(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, "--config="); ok {
+			return value
+		}
+		if arg == "--config" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProgramName(t *testing.T) {
+	tests := map[string]string{
+		"rqm":                   "rqm",
+		"/usr/local/bin/requim": "requim",
+		"requim.exe":            "requim",
+		"rqm-linux-amd64":       "rqm",
+	}
+	for argv0, want := range tests {
+		if got := programName(argv0); got != want {
+			t.Errorf("programName(%q) = %q, want %q", argv0, got, want)
+		}
+	}
+}
+
+func TestExpandAliases(t *testing.T) {
+	aliases := map[string]string{
+		"ls":     "list --format table",
+		"drafts": `list --status draft --columns "id,summary"`,
+		"list":   "validate",
+		"bad":    `list "oops`,
+	}
+
+	tests := []struct {
+		args        []string
+		want        []string
+		errContains string
+	}{
+		{args: []string{"ls", "r.yml"}, want: []string{"list", "--format", "table", "r.yml"}},
+		{args: []string{"--color", "never", "-v", "ls"}, want: []string{"--color", "never", "-v", "list", "--format", "table"}},
+		{args: []string{"--config=x.yaml", "drafts"}, want: []string{"--config=x.yaml", "list", "--status", "draft", "--columns", "id,summary"}},
+		{args: []string{"list", "r.yml"}, want: []string{"list", "r.yml"}},
+		{args: []string{"validate", "ls"}, want: []string{"validate", "ls"}},
+		{args: []string{"--", "ls"}, want: []string{"--", "ls"}},
+		{args: []string{"bad"}, errContains: "invalid alias bad"},
+	}
+
+	for _, tt := range tests {
+		got, err := expandAliases(tt.args, aliases)
+		if tt.errContains != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("expandAliases(%q): expected error containing %q, got %v", tt.args, tt.errContains, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expandAliases(%q): %v", tt.args, err)
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("expandAliases(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestCommandAliases(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".rqm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	project := "aliases:\n  ls: list --format table\n  st: list --columns id,status\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".rqm", "config.yml"), []byte(project), 0644); err != nil {
+		t.Fatal(err)
+	}
+	user := filepath.Join(tmpDir, "user.yaml")
+	if err := os.WriteFile(user, []byte("verbose: true\naliases:\n  ls: list --format json\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	aliases, err := commandAliases(tmpDir, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aliases["ls"] != "list --format json" {
+		t.Errorf("Expected the user config to win, got %q", aliases["ls"])
+	}
+	if aliases["st"] != "list --columns id,status" {
+		t.Errorf("Expected the project alias, got %q", aliases["st"])
+	}
+
+	if _, err := commandAliases(tmpDir, filepath.Join(tmpDir, "missing.yaml")); err != nil {
+		t.Errorf("A missing user config should not be an error: %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// attachmentRoot is the directory the attachments of file must stay in:
+// its git repository, or its own directory outside one
+func attachmentRoot(file string) string {
+	dir := filepath.Dir(file)
+	if root, err := gitOutput("-C", dir, "rev-parse", "--show-toplevel"); err == nil {
+		return canonicalPath(root)
+	}
+	return canonicalPath(dir)
+}
+
+// attachmentPath resolves the path of an attachment, which is relative
+// to the requirements file. Absolute paths, .. and symlinks leaving root
+// are refused, so neither validate nor rqm serve reads files outside the
+// repository. The error completes "attaches <path>, which ...".
+func attachmentPath(file, root, path string) (string, error) {
+	if path == "" || filepath.IsAbs(path) || strings.HasPrefix(path, "/") || filepath.VolumeName(path) != "" {
+		return "", fmt.Errorf("isn't a path relative to the requirements file")
+	}
+	if slices.Contains(strings.Split(filepath.ToSlash(path), "/"), "..") {
+		return "", fmt.Errorf("leaves the directory of the requirements file")
+	}
+	full := filepath.Join(filepath.Dir(file), filepath.Clean(filepath.FromSlash(path)))
+	info, err := os.Stat(full)
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("doesn't exist")
+	case info.IsDir():
+		return "", fmt.Errorf("is a directory")
+	case !isWithin(canonicalPath(full), root):
+		return "", fmt.Errorf("is a link out of the repository")
+	}
+	return full, nil
+}
+
+// fileChecksum is the sha256:<hex> checksum of the file at path
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// attachmentErrors are the validation errors for attachments of
+// requirements in file: local paths that don't exist, and files whose
+// checksum no longer matches the one recorded. URLs aren't fetched.
+func attachmentErrors(file string, config *model.RequirementConfig) []string {
+	var errs []string
+	root := attachmentRoot(file)
+	for _, req := range config.Flatten() {
+		for _, a := range req.Attachments {
+			if a.Path == "" {
+				continue
+			}
+			path, err := attachmentPath(file, root, a.Path)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Requirement '%s' attaches %s, which %v", req.Summary, a.Path, err))
+				continue
+			}
+			if a.Checksum == "" {
+				continue
+			}
+			sum, err := fileChecksum(path)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Requirement '%s' attaches %s, which can't be read: %v", req.Summary, a.Path, err))
+				continue
+			}
+			if sum != a.Checksum {
+				errs = append(errs, fmt.Sprintf("Requirement '%s' attaches %s with checksum %s, but the file's is %s; review the change and update the checksum", req.Summary, a.Path, a.Checksum, sum))
+			}
+		}
+	}
+	return errs
+}
+
+// serveAttachment serves the attachment named by the path query
+// parameter. Only paths attached to a requirement in reqFile are served,
+// relative to baseFile and checked as validate checks them, so the
+// endpoint doesn't expose the rest of the repository or anything outside
+// it.
+func serveAttachment(w http.ResponseWriter, r *http.Request, reqFile, baseFile string) {
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		http.Error(w, "path parameter required", http.StatusBadRequest)
+		return
+	}
+	config, err := model.Load(reqFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, req := range config.Flatten() {
+		for _, a := range req.Attachments {
+			if a.Path == path {
+				full, err := attachmentPath(baseFile, attachmentRoot(baseFile), path)
+				if err != nil {
+					http.Error(w, fmt.Sprintf("%s %v", path, err), http.StatusForbidden)
+					return
+				}
+				http.ServeFile(w, r, full)
+				return
+			}
+		}
+	}
+	http.Error(w, fmt.Sprintf("no requirement attaches %s", path), http.StatusNotFound)
+}
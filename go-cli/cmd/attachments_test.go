@@ -0,0 +1,109 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestAttachmentErrors(t *testing.T) {
+	dir := t.TempDir()
+	os.Mkdir(filepath.Join(dir, "docs"), 0o755)
+	os.WriteFile(filepath.Join(dir, "docs", "flow.txt"), []byte("login flow\n"), 0o644)
+	outside := filepath.Join(t.TempDir(), "outside.txt")
+	os.WriteFile(outside, []byte("secret\n"), 0o644)
+	os.Symlink(outside, filepath.Join(dir, "docs", "link.txt"))
+	sum, err := fileChecksum(filepath.Join(dir, "docs", "flow.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "requirements.yml")
+	config, err := model.ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: Login
+    attachments:
+      - path: docs/flow.txt
+        title: Login flow
+        checksum: ` + sum + `
+      - path: docs/flow.txt
+        title: Stale copy
+        checksum: sha256:` + strings.Repeat("0", 64) + `
+      - path: docs/missing.pdf
+        title: Missing
+      - path: /etc/passwd
+        title: Absolute
+      - path: docs/../../outside.txt
+        title: Parent
+      - path: docs
+        title: Directory
+      - path: docs/link.txt
+        title: Link out
+      - url: https://example.com/spec.pdf
+        title: Spec
+        checksum: sha256:` + strings.Repeat("1", 64) + `
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := attachmentErrors(file, config)
+	want := []string{
+		"Requirement 'Login' attaches docs/flow.txt with checksum sha256:" + strings.Repeat("0", 64) + ", but the file's is " + sum + "; review the change and update the checksum",
+		"Requirement 'Login' attaches docs/missing.pdf, which doesn't exist",
+		"Requirement 'Login' attaches /etc/passwd, which isn't a path relative to the requirements file",
+		"Requirement 'Login' attaches docs/../../outside.txt, which leaves the directory of the requirements file",
+		"Requirement 'Login' attaches docs, which is a directory",
+		"Requirement 'Login' attaches docs/link.txt, which is a link out of the repository",
+	}
+	if strings.Join(errs, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s", strings.Join(errs, "\n"))
+	}
+}
+
+func TestServeAttachment(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "flow.txt"), []byte("login flow\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("not attached\n"), 0o644)
+	file := filepath.Join(dir, "requirements.yml")
+	os.WriteFile(file, []byte(`version: "1.0"
+requirements:
+  - summary: Login
+    attachments:
+      - path: flow.txt
+        title: Login flow
+      - path: /etc/passwd
+      - path: ../rqm/secret.txt
+`), 0o644)
+
+	rec := httptest.NewRecorder()
+	serveAttachment(rec, httptest.NewRequest("GET", "/api/attachment?path=flow.txt", nil), file, file)
+	if rec.Code != 200 || rec.Body.String() != "login flow\n" {
+		t.Errorf("attached file: %d %q", rec.Code, rec.Body.String())
+	}
+
+	// Only attached paths are served
+	for _, path := range []string{"secret.txt", "../secret.txt", "requirements.yml"} {
+		rec = httptest.NewRecorder()
+		serveAttachment(rec, httptest.NewRequest("GET", "/api/attachment?path="+path, nil), file, file)
+		if rec.Code != 404 {
+			t.Errorf("%s: %d %q", path, rec.Code, rec.Body.String())
+		}
+	}
+
+	// Attached paths outside the directory are refused
+	for _, path := range []string{"/etc/passwd", "../rqm/secret.txt"} {
+		rec = httptest.NewRecorder()
+		serveAttachment(rec, httptest.NewRequest("GET", "/api/attachment?path="+path, nil), file, file)
+		if rec.Code != 403 {
+			t.Errorf("%s: %d %q", path, rec.Code, rec.Body.String())
+		}
+	}
+}
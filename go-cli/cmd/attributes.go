@@ -0,0 +1,140 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// attributeTypes are the supported custom attribute types
+var attributeTypes = []string{"string", "number", "boolean", "date", "enum", "list"}
+
+// validateAttributeDefinitions checks the declarations in .rqm/config.yml
+func validateAttributeDefinitions(defs []AttributeDefinition) []string {
+	var errs []string
+	seen := make(map[string]bool)
+	for _, def := range defs {
+		if def.Name == "" {
+			errs = append(errs, "custom attribute declared without a name")
+			continue
+		}
+		if seen[def.Name] {
+			errs = append(errs, fmt.Sprintf("custom attribute '%s' is declared more than once", def.Name))
+		}
+		seen[def.Name] = true
+
+		if !slices.Contains(attributeTypes, def.Type) {
+			errs = append(errs, fmt.Sprintf("custom attribute '%s' has unknown type '%s' (expected one of: %s)",
+				def.Name, def.Type, strings.Join(attributeTypes, ", ")))
+		}
+		if def.Type == "enum" && len(def.Values) == 0 {
+			errs = append(errs, fmt.Sprintf("custom attribute '%s' is an enum but declares no values", def.Name))
+		}
+	}
+	return errs
+}
+
+// validateAttributes checks every requirement's attributes map against the
+// declared custom attributes
+func validateAttributes(config *model.RequirementConfig, defs []AttributeDefinition) []string {
+	byName := make(map[string]AttributeDefinition, len(defs))
+	for _, def := range defs {
+		byName[def.Name] = def
+	}
+
+	var errs []string
+	for _, req := range config.Flatten() {
+		for _, name := range sortedKeys(req.Attributes) {
+			def, ok := byName[name]
+			if !ok {
+				errs = append(errs, fmt.Sprintf("Requirement '%s' uses undeclared attribute '%s'", req.Summary, name))
+				continue
+			}
+			if msg := checkAttributeValue(def, req.Attributes[name]); msg != "" {
+				errs = append(errs, fmt.Sprintf("Requirement '%s' attribute '%s' %s", req.Summary, name, msg))
+			}
+		}
+
+		for _, def := range defs {
+			if _, ok := req.Attributes[def.Name]; def.Required && !ok {
+				errs = append(errs, fmt.Sprintf("Requirement '%s' is missing required attribute '%s'", req.Summary, def.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// checkAttributeValue returns a description of why value doesn't match def, or ""
+func checkAttributeValue(def AttributeDefinition, value interface{}) string {
+	switch def.Type {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return "must be a string"
+		}
+	case "number":
+		switch value.(type) {
+		case int, int64, float64:
+		default:
+			return "must be a number"
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return "must be true or false"
+		}
+	case "date":
+		switch v := value.(type) {
+		case time.Time:
+		case string:
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return fmt.Sprintf("must be a date (YYYY-MM-DD), got '%s'", v)
+			}
+		default:
+			return "must be a date (YYYY-MM-DD)"
+		}
+	case "enum":
+		s, ok := value.(string)
+		if !ok || !slices.Contains(def.Values, s) {
+			return fmt.Sprintf("must be one of: %s", strings.Join(def.Values, ", "))
+		}
+	case "list":
+		items, ok := value.([]interface{})
+		if !ok {
+			return "must be a list"
+		}
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				return "must be a list of strings"
+			}
+			if len(def.Values) > 0 && !slices.Contains(def.Values, s) {
+				return fmt.Sprintf("contains '%s', allowed values: %s", s, strings.Join(def.Values, ", "))
+			}
+		}
+	}
+	return ""
+}
+
+// formatAttribute renders an attribute value for table output
+func formatAttribute(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format("2006-01-02")
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,144 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestValidateAttributes(t *testing.T) {
+	defs := []AttributeDefinition{
+		{Name: "risk", Type: "enum", Values: []string{"low", "high"}, Required: true},
+		{Name: "effort", Type: "number"},
+		{Name: "due", Type: "date"},
+		{Name: "platforms", Type: "list", Values: []string{"linux", "windows"}},
+		{Name: "safety", Type: "boolean"},
+	}
+
+	tests := []struct {
+		name     string
+		content  string
+		wantErrs []string
+	}{
+		{
+			name: "valid attributes",
+			content: `version: "1.0"
+requirements:
+  - summary: A
+    attributes:
+      risk: high
+      effort: 3.5
+      due: 2025-06-01
+      platforms: [linux]
+      safety: true
+`,
+		},
+		{
+			name: "type mismatches",
+			content: `version: "1.0"
+requirements:
+  - summary: A
+    attributes:
+      risk: medium
+      effort: lots
+      due: "next week"
+      platforms: [macos]
+      safety: "yes"
+`,
+			wantErrs: []string{
+				"'risk' must be one of: low, high",
+				"'effort' must be a number",
+				"'due' must be a date",
+				"'platforms' contains 'macos'",
+				"'safety' must be true or false",
+			},
+		},
+		{
+			name: "missing and undeclared attributes",
+			content: `version: "1.0"
+requirements:
+  - summary: A
+    requirements:
+      - summary: B
+        attributes:
+          risk: low
+          colour: red
+`,
+			wantErrs: []string{
+				"Requirement 'A' is missing required attribute 'risk'",
+				"Requirement 'B' uses undeclared attribute 'colour'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := model.ParseYAML([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			errs := validateAttributes(config, defs)
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("Expected %d error(s), got %d: %v", len(tt.wantErrs), len(errs), errs)
+			}
+			joined := strings.Join(errs, "\n")
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(joined, want) {
+					t.Errorf("Expected error containing %q, got: %v", want, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAttributeDefinitions(t *testing.T) {
+	errs := validateAttributeDefinitions([]AttributeDefinition{
+		{Name: "risk", Type: "enum"},
+		{Name: "risk", Type: "string"},
+		{Name: "cost", Type: "money"},
+	})
+	if len(errs) != 3 {
+		t.Errorf("Expected 3 errors, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestLoadProjectConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	rqmDir := filepath.Join(tmpDir, ".rqm")
+	if err := os.MkdirAll(filepath.Join(tmpDir, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(rqmDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `project_prefix: REQ
+next_id: 4
+attributes:
+  - name: risk
+    type: enum
+    values: [low, high]
+`
+	if err := os.WriteFile(filepath.Join(rqmDir, "config.yml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range []string{
+		filepath.Join(rqmDir, "requirements.yml"),
+		filepath.Join(tmpDir, "docs", "requirements.yml"),
+	} {
+		config, err := loadProjectConfig(file)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if config.ProjectPrefix != "REQ" || len(config.Attributes) != 1 || config.Attributes[0].Name != "risk" {
+			t.Errorf("Unexpected config for %s: %+v", file, config)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	badgeMetric string
+	badgeLabel  string
+	badgeOutput string
+)
+
+// Badge colors, matching shields.io's named colors
+const (
+	badgeBrightGreen = "#4c1"
+	badgeGreen       = "#97ca00"
+	badgeYellow      = "#dfb317"
+	badgeOrange      = "#fe7d37"
+	badgeRed         = "#e05d44"
+	badgeBlue        = "#007ec6"
+	badgeGrey        = "#555"
+)
+
+// badge is the content of a two-part status badge
+type badge struct {
+	Label   string
+	Message string
+	Color   string
+}
+
+// badgeMetrics maps --metric values to the function computing the badge
+var badgeMetrics = map[string]func(file string) (badge, error){
+	"implemented-percent": func(file string) (badge, error) {
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return badge{}, err
+		}
+		done, total := 0, 0
+		for _, req := range config.Flatten() {
+			total++
+			if req.Status == "implemented" || req.Status == "verified" {
+				done++
+			}
+		}
+		return percentBadge("implemented", done, total), nil
+	},
+	"verified-percent": func(file string) (badge, error) {
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return badge{}, err
+		}
+		verified, total := verificationCoverage(config)
+		return percentBadge("verified", verified, total), nil
+	},
+	"validation": func(file string) (badge, error) {
+		result, err := validateFile(file)
+		if err != nil {
+			return badge{}, err
+		}
+		if !result.Valid {
+			return badge{Label: "requirements", Message: "failing", Color: badgeRed}, nil
+		}
+		return badge{Label: "requirements", Message: "passing", Color: badgeBrightGreen}, nil
+	},
+	"count": func(file string) (badge, error) {
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return badge{}, err
+		}
+		return badge{Label: "requirements", Message: strconv.Itoa(len(config.Flatten())), Color: badgeBlue}, nil
+	},
+}
+
+var badgeCmd = &cobra.Command{
+	Use:   "badge [file]",
+	Short: "Generate a shields.io-style SVG badge for a README",
+	Long: `Generate a shields.io-style SVG badge summarizing a requirements file.
+
+Metrics:
+  - implemented-percent  share of requirements implemented or verified
+  - verified-percent     share of requirements with a verification method,
+                         acceptance test or verified_by entry
+  - validation           passing or failing, as rqm validate reports
+  - count                number of requirements
+
+Percentages are colored from red to bright green. rqm serve offers the
+same badges at /badge.svg?metric=<metric> for the file it serves.`,
+	Example: `  rqm badge requirements.yml -o badge.svg
+  rqm badge requirements.yml --metric validation --label spec -o spec.svg`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svg, err := requirementsBadge(args[0], badgeMetric, badgeLabel)
+		if err != nil {
+			return err
+		}
+
+		if badgeOutput == "" {
+			fmt.Print(svg)
+			return nil
+		}
+		if err := os.WriteFile(badgeOutput, []byte(svg), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", badgeOutput, err)
+		}
+		fmt.Fprintf(os.Stderr, "%s Wrote %s badge to %s\n", okMark(), badgeMetric, badgeOutput)
+		return nil
+	},
+}
+
+// requirementsBadge computes metric for file and renders it as SVG.
+// A non-empty label replaces the metric's default label.
+func requirementsBadge(file, metric, label string) (string, error) {
+	compute, ok := badgeMetrics[metric]
+	if !ok {
+		return "", fmt.Errorf("unknown badge metric: %s (supported: %s)", metric, strings.Join(sortedKeys(badgeMetrics), ", "))
+	}
+	b, err := compute(file)
+	if err != nil {
+		return "", err
+	}
+	if label != "" {
+		b.Label = label
+	}
+	return renderBadge(b), nil
+}
+
+// percentBadge shows part/total as a percentage colored by how complete it is
+func percentBadge(label string, part, total int) badge {
+	if total == 0 {
+		return badge{Label: label, Message: "n/a", Color: badgeGrey}
+	}
+	percent := part * 100 / total
+	color := badgeRed
+	switch {
+	case percent >= 90:
+		color = badgeBrightGreen
+	case percent >= 75:
+		color = badgeGreen
+	case percent >= 50:
+		color = badgeYellow
+	case percent >= 25:
+		color = badgeOrange
+	}
+	return badge{Label: label, Message: fmt.Sprintf("%d%%", percent), Color: color}
+}
+
+// renderBadge draws b in the shields.io "flat" style
+func renderBadge(b badge) string {
+	const padding = 10
+	labelWidth := badgeTextWidth(b.Label) + padding
+	messageWidth := badgeTextWidth(b.Message) + padding
+	width := labelWidth + messageWidth
+	label, message := html.EscapeString(b.Label), html.EscapeString(b.Message)
+
+	var s strings.Builder
+	fmt.Fprintf(&s, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`, width, label, message)
+	fmt.Fprintf(&s, `<title>%s: %s</title>`, label, message)
+	s.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	fmt.Fprintf(&s, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, width)
+	fmt.Fprintf(&s, `<g clip-path="url(#r)"><rect width="%d" height="20" fill="%s"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>`,
+		labelWidth, badgeGrey, labelWidth, messageWidth, b.Color, width)
+	s.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	for _, text := range []struct {
+		x     float64
+		value string
+	}{{float64(labelWidth) / 2, label}, {float64(labelWidth) + float64(messageWidth)/2, message}} {
+		fmt.Fprintf(&s, `<text x="%g" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%g" y="14">%s</text>`,
+			text.x, text.value, text.x, text.value)
+	}
+	s.WriteString("</g></svg>\n")
+	return s.String()
+}
+
+// badgeTextWidth approximates the width in pixels of s in 11px Verdana
+func badgeTextWidth(s string) int {
+	width := 0.0
+	for _, r := range s {
+		switch {
+		case strings.ContainsRune("ijlI.,:;'!| ", r):
+			width += 3.5
+		case strings.ContainsRune("frt()[]-", r):
+			width += 4.5
+		case strings.ContainsRune("mwMW%", r):
+			width += 10.5
+		case r >= 'A' && r <= 'Z':
+			width += 7.5
+		default:
+			width += 6.8
+		}
+	}
+	return int(width + 0.5)
+}
+
+func init() {
+	rootCmd.AddCommand(badgeCmd)
+	badgeCmd.Flags().StringVar(&badgeMetric, "metric", "implemented-percent", "Metric to show ("+strings.Join(sortedKeys(badgeMetrics), ", ")+")")
+	badgeCmd.Flags().StringVar(&badgeLabel, "label", "", "Text for the left half of the badge (default depends on the metric)")
+	badgeCmd.Flags().StringVarP(&badgeOutput, "output", "o", "", "Write the SVG to this file instead of stdout")
+}
@@ -0,0 +1,67 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPercentBadge(t *testing.T) {
+	tests := []struct {
+		part, total int
+		message     string
+		color       string
+	}{
+		{9, 10, "90%", badgeBrightGreen},
+		{3, 4, "75%", badgeGreen},
+		{1, 2, "50%", badgeYellow},
+		{1, 4, "25%", badgeOrange},
+		{1, 10, "10%", badgeRed},
+		{0, 0, "n/a", badgeGrey},
+	}
+	for _, tt := range tests {
+		b := percentBadge("implemented", tt.part, tt.total)
+		if b.Message != tt.message || b.Color != tt.color {
+			t.Errorf("percentBadge(%d, %d) = %s %s, want %s %s", tt.part, tt.total, b.Message, b.Color, tt.message, tt.color)
+		}
+	}
+}
+
+func TestRenderBadge(t *testing.T) {
+	svg := renderBadge(badge{Label: "R&D", Message: "80%", Color: badgeGreen})
+
+	if err := xml.Unmarshal([]byte(svg), new(struct{})); err != nil {
+		t.Fatalf("Badge is not well-formed XML: %v\n%s", err, svg)
+	}
+	for _, want := range []string{`aria-label="R&amp;D: 80%"`, `fill="#97ca00"`, `>80%</text>`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Badge missing %q:\n%s", want, svg)
+		}
+	}
+
+	// Longer text makes a wider badge
+	if badgeTextWidth("requirements") <= badgeTextWidth("spec") {
+		t.Error("Expected longer labels to be wider")
+	}
+}
+
+func TestRequirementsBadgeUnknownMetric(t *testing.T) {
+	_, err := requirementsBadge("requirements.yml", "velocity", "")
+	if err == nil || !strings.Contains(err.Error(), "unknown badge metric: velocity") {
+		t.Errorf("Expected unknown metric error, got %v", err)
+	}
+}
+
+func TestServeBadge(t *testing.T) {
+	rec := httptest.NewRecorder()
+	serveBadge(rec, httptest.NewRequest("GET", "/badge.svg?metric=velocity", nil), "requirements.yml")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown metric, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,170 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bulkFilter     string
+	bulkSet        []string
+	bulkAddTags    []string
+	bulkRemoveTags []string
+	bulkDryRun     bool
+)
+
+// bulkFields are the fields --set can assign
+var bulkFields = []string{"status", "priority", "owner", "verification"}
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk [file]",
+	Short: "Update every requirement matching a filter at once",
+	Long: `Update every requirement matching --filter at once: --set assigns
+status, priority, owner or verification, and --add-tag and --remove-tag
+change tags. The changes are listed before they're written; --dry-run
+lists them and stops there.
+
+` + filterLanguage + `
+
+The file is edited in place with comments and key order preserved, and
+either every change is written or none is. Requirements from included
+files aren't changed; TOML and JSON files can't be edited.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm bulk --filter 'status==proposed && tag==payments' --set status=approved --dry-run
+  rqm bulk requirements.yml --filter 'owner==@bob' --set owner=@alice
+  rqm bulk --filter 'tag==v1' --remove-tag v1 --add-tag legacy`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := parseFilter(bulkFilter)
+		if err != nil {
+			return err
+		}
+		set, err := parseBulkSet(bulkSet)
+		if err != nil {
+			return err
+		}
+		if len(set)+len(bulkAddTags)+len(bulkRemoveTags) == 0 {
+			return fmt.Errorf("nothing to change: give --set, --add-tag or --remove-tag")
+		}
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		if err := editableFile(file); err != nil {
+			return err
+		}
+		config, err := model.LoadFile(file)
+		if err != nil {
+			return err
+		}
+
+		edits := bulkEdits(config, filter, set, bulkAddTags, bulkRemoveTags)
+		if len(edits) == 0 {
+			fmt.Println("No requirement matches the filter and needs changing")
+			return nil
+		}
+		writeBulkPreview(os.Stdout, config, edits)
+		if bulkDryRun {
+			fmt.Printf("\n%d requirement(s) would be updated (dry run, %s not changed)\n", len(edits), file)
+			return nil
+		}
+		if err := applyRequirementEdits(file, edits); err != nil {
+			return err
+		}
+		fmt.Printf("\n%s Updated %d requirement(s) in %s\n", okMark(), len(edits), file)
+		return nil
+	},
+}
+
+// parseBulkSet reads --set key=value pairs, checking the keys and the
+// values of fields with a fixed set of them
+func parseBulkSet(pairs []string) (map[string]string, error) {
+	allowed := map[string][]string{"status": requirementStatuses, "priority": priorityRanks, "verification": verificationMethods}
+	set := make(map[string]string)
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --set %q: expected field=value", pair)
+		}
+		key = strings.TrimSpace(key)
+		switch {
+		case !slices.Contains(bulkFields, key):
+			return nil, fmt.Errorf("--set can't assign %s (fields: %s)", key, strings.Join(bulkFields, ", "))
+		case allowed[key] != nil && !slices.Contains(allowed[key], value):
+			return nil, fmt.Errorf("invalid %s %q (expected one of %s)", key, value, strings.Join(allowed[key], ", "))
+		}
+		set[key] = value
+	}
+	return set, nil
+}
+
+// bulkEdits are the edits of the requirements filter matches that change
+// anything, in tree order
+func bulkEdits(config *model.RequirementConfig, filter requirementFilter, set map[string]string, addTags, removeTags []string) []requirementEdit {
+	var edits []requirementEdit
+	for _, req := range config.Flatten() {
+		if !filter(req) {
+			continue
+		}
+		edit := requirementEdit{Summary: req.Summary, Set: make(map[string]string)}
+		for key, value := range set {
+			if filterFieldValues(req, key)[0] != value {
+				edit.Set[key] = value
+			}
+		}
+		for _, tag := range addTags {
+			if !slices.Contains(req.Tags, tag) {
+				edit.AddTags = append(edit.AddTags, tag)
+			}
+		}
+		for _, tag := range removeTags {
+			if slices.Contains(req.Tags, tag) {
+				edit.RemoveTags = append(edit.RemoveTags, tag)
+			}
+		}
+		if len(edit.Set)+len(edit.AddTags)+len(edit.RemoveTags) > 0 {
+			edits = append(edits, edit)
+		}
+	}
+	return edits
+}
+
+// writeBulkPreview lists the changes edits make to config
+func writeBulkPreview(w io.Writer, config *model.RequirementConfig, edits []requirementEdit) {
+	for _, edit := range edits {
+		req, _ := config.Find(edit.Summary)
+		fmt.Fprintln(w, displayName(req))
+		for _, key := range sortedKeys(edit.Set) {
+			fmt.Fprintf(w, "    %s: %s → %s\n", key, orUnset(filterFieldValues(req, key)[0]), edit.Set[key])
+		}
+		for _, tag := range edit.AddTags {
+			fmt.Fprintf(w, "    %s tag %s\n", paint(ansiGreen, "+"), tag)
+		}
+		for _, tag := range edit.RemoveTags {
+			fmt.Fprintf(w, "    %s tag %s\n", paint(ansiRed, "-"), tag)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(bulkCmd)
+	bulkCmd.Flags().StringVar(&bulkFilter, "filter", "", "Filter expression selecting the requirements to update")
+	bulkCmd.Flags().StringArrayVar(&bulkSet, "set", nil, "Field to assign, as field=value (repeatable)")
+	bulkCmd.Flags().StringSliceVar(&bulkAddTags, "add-tag", nil, "Tags to add (comma-separated)")
+	bulkCmd.Flags().StringSliceVar(&bulkRemoveTags, "remove-tag", nil, "Tags to remove (comma-separated)")
+	bulkCmd.Flags().BoolVar(&bulkDryRun, "dry-run", false, "List the changes without writing them")
+	bulkCmd.MarkFlagRequired("filter")
+}
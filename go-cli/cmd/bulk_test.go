@@ -0,0 +1,70 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestBulkEdits(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	if err := os.WriteFile(file, []byte(filterYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := model.Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter, err := parseFilter("tag==payments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := parseBulkSet([]string{"status=approved"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Refunds is already approved and loses its only tag
+	edits := bulkEdits(config, filter, set, []string{"billing"}, []string{"payments"})
+	if len(edits) != 2 || len(edits[1].Set) != 0 || edits[1].RemoveTags[0] != "payments" {
+		t.Fatalf("edits = %+v", edits)
+	}
+	var preview strings.Builder
+	writeBulkPreview(&preview, config, edits)
+	if want := "[PAY-001] Card payments\n    status: proposed → approved\n    + tag billing\n    - tag payments\n"; !strings.HasPrefix(preview.String(), want) {
+		t.Errorf("preview:\n%s\nwant prefix:\n%s", preview.String(), want)
+	}
+
+	if err := applyRequirementEdits(file, edits); err != nil {
+		t.Fatal(err)
+	}
+	if config, err = model.Load(file); err != nil {
+		t.Fatal(err)
+	}
+	card, _ := config.Find("PAY-001")
+	refunds, _ := config.Find("PAY-002")
+	if card.Status != "approved" || strings.Join(card.Tags, ",") != "security,billing" {
+		t.Errorf("Card payments = %s %v", card.Status, card.Tags)
+	}
+	if strings.Join(refunds.Tags, ",") != "billing" {
+		t.Errorf("Refunds tags = %v", refunds.Tags)
+	}
+}
+
+func TestParseBulkSet(t *testing.T) {
+	for _, pairs := range [][]string{{"status=done"}, {"summary=x"}, {"owner"}, {"verification=guess"}} {
+		if _, err := parseBulkSet(pairs); err == nil {
+			t.Errorf("parseBulkSet(%v) succeeded", pairs)
+		}
+	}
+	if set, err := parseBulkSet([]string{"owner=@alice", "priority=high"}); err != nil || len(set) != 2 {
+		t.Errorf("parseBulkSet = %v, %v", set, err)
+	}
+}
@@ -0,0 +1,346 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	bundleOutput    string
+	bundleSchema    string
+	bundleValidator string
+)
+
+// bundleManifest lists the SHA-256 of every other file in a bundle, in
+// sha256sum format so it can also be checked with sha256sum -c
+const bundleManifest = "MANIFEST.sha256"
+
+// bundleFile is a file to put in a bundle, named relative to its top
+// directory
+type bundleFile struct {
+	name string
+	mode int64
+	data []byte
+}
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Package rqm for air-gapped installation",
+}
+
+var bundleCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Write a tarball with rqm and everything it needs offline",
+	Long: `Write a .tar.gz with everything rqm needs on a machine without network
+access:
+
+  bin/            this rqm binary and, unless it embeds the validator,
+                  rqm-validator
+  schema/         the requirements schema.json and the JSON output schemas
+  templates/      the message catalogs and the demo walkthrough
+  web/            the web UI that rqm serve embeds
+  rqm.yaml        a user config with offline: true, for ~/.rqm.yaml
+  MANIFEST.sha256 the SHA-256 of every other file
+
+Copy the tarball across, check it with 'rqm bundle verify' (or
+sha256sum -c MANIFEST.sha256 once extracted) and put bin/ on PATH.
+
+The bundle is for the platform rqm runs on. schema.json is found by
+walking up from the current directory and the validator; --schema and
+--validator name them explicitly.`,
+	Example: `  rqm bundle create
+  rqm bundle create -o rqm-offline.tar.gz --validator ./rqm-validator`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files, err := bundleFiles()
+		if err != nil {
+			return err
+		}
+
+		output := bundleOutput
+		if output == "" {
+			output = bundleName() + ".tar.gz"
+		}
+		if err := writeBundle(output, bundleName(), files); err != nil {
+			return err
+		}
+		fmt.Printf("%s Wrote %s (%d files)\n", okMark(), output, len(files)+1)
+		return nil
+	},
+}
+
+var bundleVerifyCmd = &cobra.Command{
+	Use:   "verify <bundle.tar.gz>",
+	Short: "Check a bundle's files against its manifest",
+	Long: `Check every file in a bundle against MANIFEST.sha256, reporting files that
+changed, are missing or aren't listed. Exits with 1 when any don't match.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problems, count, err := verifyBundle(args[0])
+		if err != nil {
+			return err
+		}
+		if len(problems) > 0 {
+			fmt.Printf("%s %s does not match its manifest:\n", failMark(), args[0])
+			for _, problem := range problems {
+				fmt.Printf("  - %s\n", problem)
+			}
+			return validationError("%d file(s) failed verification", len(problems))
+		}
+		fmt.Printf("%s %d file(s) match %s\n", okMark(), count, bundleManifest)
+		return nil
+	},
+}
+
+// bundleName is the top directory of the bundle, e.g. rqm-0.1.0-linux-amd64
+func bundleName() string {
+	return fmt.Sprintf("rqm-%s-%s-%s", rootCmd.Version, runtime.GOOS, runtime.GOARCH)
+}
+
+// bundleFiles collects the files of a bundle, sorted by name
+func bundleFiles() ([]bundleFile, error) {
+	exe := ""
+	if runtime.GOOS == "windows" {
+		exe = ".exe"
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate the rqm binary: %w", err)
+	}
+	files := []bundleFile{}
+	add := func(name, source string, mode int64) error {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", source, err)
+		}
+		files = append(files, bundleFile{name: name, mode: mode, data: data})
+		return nil
+	}
+	if err := add("bin/rqm"+exe, self, 0755); err != nil {
+		return nil, err
+	}
+
+	validator := bundleValidator
+	if validator == "" && validatorKind() != "embedded" {
+		if validator = findValidatorBinary(); validator == "" {
+			return nil, fmt.Errorf("rqm-validator binary not found\nBuild it with: cd rust-core && cargo build --release --bin rqm-validator, or pass --validator")
+		}
+	}
+	if validator != "" {
+		if err := add("bin/rqm-validator"+exe, validator, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	schema := bundleSchema
+	if schema == "" {
+		if schema = findSchemaFile(validator); schema == "" {
+			return nil, fmt.Errorf("schema.json not found; pass --schema")
+		}
+	}
+	if err := add("schema/schema.json", schema, 0644); err != nil {
+		return nil, err
+	}
+
+	files = append(files, bundleFile{name: "rqm.yaml", mode: 0644, data: []byte("# Copy to ~/.rqm.yaml to keep rqm from using the network\noffline: true\n")})
+
+	embedded := []struct {
+		fsys fs.FS
+		from string
+		to   string
+	}{
+		{outputSchemas, "schemas/output", "schema/output"},
+		{messageFiles, "messages", "templates/messages"},
+		{demoFiles, "demo", "templates/demo"},
+		{webUI, "web-dist", "web"},
+	}
+	for _, e := range embedded {
+		err := fs.WalkDir(e.fsys, e.from, func(name string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := fs.ReadFile(e.fsys, name)
+			if err != nil {
+				return err
+			}
+			files = append(files, bundleFile{name: path.Join(e.to, strings.TrimPrefix(name, e.from+"/")), mode: 0644, data: data})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read embedded %s: %w", e.from, err)
+		}
+	}
+
+	slices.SortFunc(files, func(a, b bundleFile) int { return strings.Compare(a.name, b.name) })
+	return files, nil
+}
+
+// findSchemaFile looks for schema.json walking up from the current
+// directory, then from the validator binary, which in a checkout sits in
+// rust-core/target/release
+func findSchemaFile(validator string) string {
+	starts := []string{"."}
+	if validator != "" {
+		starts = append(starts, filepath.Dir(validator))
+	}
+	for _, start := range starts {
+		dir, err := filepath.Abs(start)
+		if err != nil {
+			continue
+		}
+		for {
+			candidate := filepath.Join(dir, "schema.json")
+			if _, err := os.Stat(candidate); err == nil {
+				return candidate
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+	return ""
+}
+
+// writeBundle writes files and their manifest to a gzipped tarball under
+// the top directory
+func writeBundle(output, top string, files []bundleFile) error {
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", output, err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	now := time.Now().Truncate(time.Second)
+
+	var manifest strings.Builder
+	for _, file := range files {
+		sum := sha256.Sum256(file.data)
+		fmt.Fprintf(&manifest, "%s  %s\n", hex.EncodeToString(sum[:]), file.name)
+	}
+	files = append(files, bundleFile{name: bundleManifest, mode: 0644, data: []byte(manifest.String())})
+
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    top + "/" + file.name,
+			Mode:    file.mode,
+			Size:    int64(len(file.data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	return f.Close()
+}
+
+// verifyBundle checks the files of a bundle against its manifest and
+// returns what doesn't match, along with the number of files checked
+func verifyBundle(bundle string) ([]string, int, error) {
+	f, err := os.Open(bundle)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open %s: %w", bundle, err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read %s: %w", bundle, err)
+	}
+
+	sums := make(map[string]string)
+	var manifest []byte
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read %s: %w", bundle, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		// Names are relative to the bundle's top directory
+		_, name, _ := strings.Cut(header.Name, "/")
+		if name == bundleManifest {
+			if manifest, err = io.ReadAll(tr); err != nil {
+				return nil, 0, fmt.Errorf("failed to read %s: %w", bundle, err)
+			}
+			continue
+		}
+		hash := sha256.New()
+		if _, err := io.Copy(hash, tr); err != nil {
+			return nil, 0, fmt.Errorf("failed to read %s: %w", bundle, err)
+		}
+		sums[name] = hex.EncodeToString(hash.Sum(nil))
+	}
+	if manifest == nil {
+		return nil, 0, fmt.Errorf("%s has no %s", bundle, bundleManifest)
+	}
+
+	var problems []string
+	listed := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(string(manifest)))
+	for scanner.Scan() {
+		want, name, ok := strings.Cut(scanner.Text(), "  ")
+		if !ok {
+			continue
+		}
+		listed[name] = true
+		switch got, found := sums[name]; {
+		case !found:
+			problems = append(problems, name+": missing")
+		case got != want:
+			problems = append(problems, name+": checksum mismatch")
+		}
+	}
+	for _, name := range sortedKeys(sums) {
+		if !listed[name] {
+			problems = append(problems, name+": not in the manifest")
+		}
+	}
+	return problems, len(listed), nil
+}
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+	bundleCmd.AddCommand(bundleCreateCmd)
+	bundleCmd.AddCommand(bundleVerifyCmd)
+	bundleCreateCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "Tarball to write (default rqm-<version>-<os>-<arch>.tar.gz)")
+	bundleCreateCmd.Flags().StringVar(&bundleSchema, "schema", "", "Requirements schema.json to include (default: found from the current directory)")
+	bundleCreateCmd.Flags().StringVar(&bundleValidator, "validator", "", "rqm-validator binary to include (default: the one rqm validate uses)")
+}
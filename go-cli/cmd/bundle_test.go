@@ -0,0 +1,116 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBundleVerify(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := []bundleFile{
+		{name: "bin/rqm", mode: 0755, data: []byte("binary")},
+		{name: "schema/schema.json", mode: 0644, data: []byte("{}")},
+	}
+	bundle := filepath.Join(tmpDir, "rqm.tar.gz")
+	if err := writeBundle(bundle, "rqm-test", files); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, count, err := verifyBundle(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) > 0 || count != 2 {
+		t.Errorf("Expected 2 matching files, got %d and %v", count, problems)
+	}
+
+	// Rewrite the tarball with one file changed, one dropped and one added
+	tampered := filepath.Join(tmpDir, "tampered.tar.gz")
+	f, err := os.Create(tampered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	manifest := readBundleFile(t, bundle, "rqm-test/"+bundleManifest)
+	for name, data := range map[string]string{
+		"rqm-test/" + bundleManifest: manifest,
+		"rqm-test/bin/rqm":           "patched",
+		"rqm-test/extra":             "extra",
+	} {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data))})
+		tw.Write([]byte(data))
+	}
+	tw.Close()
+	gz.Close()
+	f.Close()
+
+	problems, _, err = verifyBundle(tampered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(problems, "\n")
+	for _, want := range []string{"bin/rqm: checksum mismatch", "schema/schema.json: missing", "extra: not in the manifest"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in problems, got:\n%s", want, got)
+		}
+	}
+}
+
+// readBundleFile returns one file of a bundle
+func readBundleFile(t *testing.T, bundle, name string) string {
+	t.Helper()
+	f, err := os.Open(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			t.Fatalf("%s not found in %s: %v", name, bundle, err)
+		}
+		if header.Name == name {
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return string(data)
+		}
+	}
+}
+
+func TestOfflineMode(t *testing.T) {
+	project := &ProjectConfig{Offline: true}
+
+	t.Setenv("RQM_OFFLINE", "")
+	if offlineMode(nil) {
+		t.Error("Expected online without any offline setting")
+	}
+	if err := requireOnline("--owners-active", project); err == nil || !strings.Contains(err.Error(), "--owners-active needs network access") {
+		t.Errorf("Expected the project config to force offline mode, got %v", err)
+	}
+
+	t.Setenv("RQM_OFFLINE", "1")
+	if !offlineMode(nil) {
+		t.Error("Expected RQM_OFFLINE=1 to turn on offline mode")
+	}
+	t.Setenv("RQM_OFFLINE", "0")
+	if offlineMode(project) {
+		t.Error("Expected RQM_OFFLINE=0 to override the project config")
+	}
+}
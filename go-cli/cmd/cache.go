@@ -0,0 +1,518 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	noCache     bool
+	cacheMaxAge time.Duration
+)
+
+const (
+	// maxCacheEntries bounds the cache; the least recently used entries
+	// are removed past it
+	maxCacheEntries = 256
+	// defaultMaxCacheSize bounds the total size of the entries, unless
+	// RQM_CACHE_MAX_SIZE sets another limit
+	defaultMaxCacheSize = 64 << 20
+	// cacheLockTimeout is how long to wait for another process holding
+	// the cache lock before giving up on the cache
+	cacheLockTimeout = 5 * time.Second
+	// staleTempAge is when a temporary file is left over from a write
+	// that never finished, rather than one in progress
+	staleTempAge = time.Hour
+)
+
+// validationCache keeps validator output in .rqm/cache, keyed by the
+// SHA-256 of the requirements file together with the validator that
+// produced the output and the form it was asked for. Edits, a rebuilt
+// validator or a new rqm all miss the cache instead of reading stale
+// results. A nil cache caches nothing.
+//
+// Keys depend on content alone, so RQM_CACHE_DIR can point several
+// projects or parallel CI jobs at one shared directory. Writers hold a
+// lock on the directory, and entries carry a checksum of their data so a
+// damaged entry is dropped instead of read.
+type validationCache struct {
+	dir string
+}
+
+// cacheEntry is the content of an entry file
+type cacheEntry struct {
+	SHA256 string          `json:"sha256"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// cacheDir is the cache directory for a requirements file: RQM_CACHE_DIR
+// when set, or cache in the nearest .rqm directory above the file. It is
+// empty outside a project with a .rqm directory.
+func cacheDir(file string) string {
+	if dir := os.Getenv("RQM_CACHE_DIR"); dir != "" {
+		return dir
+	}
+	// With an empty name findInRQMDir finds the .rqm directory itself
+	rqmDir := findInRQMDir(filepath.Dir(file), "")
+	if rqmDir == "" {
+		return ""
+	}
+	return filepath.Join(rqmDir, "cache")
+}
+
+// openValidationCache returns the cache for a requirements file, in
+// cacheDir. It is nil under --no-cache or without a cache directory.
+func openValidationCache(file string) *validationCache {
+	if noCache {
+		return nil
+	}
+	dir := cacheDir(file)
+	if dir == "" {
+		return nil
+	}
+	return &validationCache{dir: dir}
+}
+
+// maxCacheSize is the limit on the total size of the entries
+func maxCacheSize() (int64, error) {
+	value := os.Getenv("RQM_CACHE_MAX_SIZE")
+	if value == "" {
+		return defaultMaxCacheSize, nil
+	}
+	size, err := parseByteSize(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid RQM_CACHE_MAX_SIZE: %w", err)
+	}
+	return size, nil
+}
+
+// lock takes the lock on the cache directory, waiting up to
+// cacheLockTimeout for other processes to release it
+func (c *validationCache) lock() (func(), error) {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(c.dir, ".lock"), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(cacheLockTimeout)
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if locked {
+			return func() {
+				unlockFile(f)
+				f.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("timed out waiting for the lock on %s", c.dir)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// readEntry reads the entry file at path, failing for damaged entries
+func readEntry(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil, errCorruptEntry
+	}
+	sum := sha256.Sum256(entry.Data)
+	if entry.SHA256 != hex.EncodeToString(sum[:]) {
+		return nil, errCorruptEntry
+	}
+	return entry.Data, nil
+}
+
+// errCorruptEntry marks an entry whose data doesn't match its checksum
+var errCorruptEntry = errors.New("corrupt cache entry")
+
+// key names the cache entry for content checked by validator, an ID from
+// validatorID, with output in format
+func (c *validationCache) key(content []byte, validator, format string) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s\x00", rootCmd.Version, validator, format)
+	hash.Write(content)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// get returns the entry for key, if there is one. Reading it marks it as
+// recently used, and a damaged entry is removed.
+func (c *validationCache) get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	path := filepath.Join(c.dir, key+".json")
+	data, err := readEntry(path)
+	if errors.Is(err, errCorruptEntry) {
+		// Another process may have replaced it meanwhile, so check again
+		// under the lock
+		if unlock, err := c.lock(); err == nil {
+			if _, err := readEntry(path); errors.Is(err, errCorruptEntry) {
+				os.Remove(path)
+			}
+			unlock()
+		}
+		return nil, false
+	}
+	if err != nil {
+		return nil, false
+	}
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return data, true
+}
+
+// put stores data, which must be JSON, under key. The cache is only an
+// optimization, so failing to write it isn't an error.
+func (c *validationCache) put(key string, data []byte) {
+	if c == nil {
+		return
+	}
+	// The checksum is of the data as stored, which json.Marshal compacts
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return
+	}
+	sum := sha256.Sum256(compact.Bytes())
+	content, err := json.Marshal(cacheEntry{SHA256: hex.EncodeToString(sum[:]), Data: compact.Bytes()})
+	if err != nil {
+		return
+	}
+
+	unlock, err := c.lock()
+	if err != nil {
+		return
+	}
+	defer unlock()
+
+	// Keep the cache out of version control without touching .gitignore
+	ignore := filepath.Join(c.dir, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		os.WriteFile(ignore, []byte("*\n"), 0644)
+	}
+
+	// Write through a temporary file so readers, which don't take the
+	// lock, never see a partial entry
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(c.dir, key+".json"))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	c.evict(0, false)
+}
+
+// cacheFile is an entry of the cache, or a temporary file of a write
+type cacheFile struct {
+	name string
+	size int64
+	// used is when the entry was last written or read
+	used time.Time
+}
+
+// files lists the entries and the temporary files of the cache
+func (c *validationCache) files() (entries, temps []cacheFile, err error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	for _, e := range dirEntries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		f := cacheFile{name: e.Name(), size: info.Size(), used: info.ModTime()}
+		switch {
+		case strings.HasSuffix(f.name, ".json"):
+			entries = append(entries, f)
+		case strings.HasSuffix(f.name, ".tmp"):
+			temps = append(temps, f)
+		}
+	}
+	// Most recently used first
+	slices.SortFunc(entries, func(a, b cacheFile) int { return b.used.Compare(a.used) })
+	return entries, temps, nil
+}
+
+// evict removes temporary files left by writes that never finished, and
+// the least recently used entries beyond maxCacheEntries or the size
+// limit. With maxAge, entries unused for longer go too; with verify,
+// entries are read and damaged ones removed. The caller holds the lock.
+// It returns how many files were removed and their size.
+func (c *validationCache) evict(maxAge time.Duration, verify bool) (int, int64) {
+	entries, temps, err := c.files()
+	if err != nil {
+		return 0, 0
+	}
+	limit, err := maxCacheSize()
+	if err != nil {
+		limit = defaultMaxCacheSize
+	}
+
+	removed, freed := 0, int64(0)
+	remove := func(f cacheFile) {
+		if os.Remove(filepath.Join(c.dir, f.name)) == nil {
+			removed++
+			freed += f.size
+		}
+	}
+	for _, f := range temps {
+		if time.Since(f.used) > staleTempAge {
+			remove(f)
+		}
+	}
+
+	kept, size := 0, int64(0)
+	for _, f := range entries {
+		switch {
+		case maxAge > 0 && time.Since(f.used) > maxAge:
+			remove(f)
+		case verify && isCorrupt(filepath.Join(c.dir, f.name)):
+			remove(f)
+		case kept >= maxCacheEntries || size+f.size > limit:
+			remove(f)
+		default:
+			kept++
+			size += f.size
+		}
+	}
+	return removed, freed
+}
+
+// isCorrupt tells whether the entry at path is damaged
+func isCorrupt(path string) bool {
+	_, err := readEntry(path)
+	return errors.Is(err, errCorruptEntry)
+}
+
+// clear removes every entry and temporary file. The caller holds the
+// lock.
+func (c *validationCache) clear() (int, int64, error) {
+	entries, temps, err := c.files()
+	if err != nil {
+		return 0, 0, err
+	}
+	removed, freed := 0, int64(0)
+	for _, f := range append(entries, temps...) {
+		if err := os.Remove(filepath.Join(c.dir, f.name)); err != nil && !os.IsNotExist(err) {
+			return removed, freed, err
+		}
+		removed++
+		freed += f.size
+	}
+	return removed, freed, nil
+}
+
+// validatorID identifies the rqm-validator binary at path by its size and
+// modification time, so rebuilding it invalidates what it cached. It is
+// empty when the binary can't be read, and nothing is cached then.
+func validatorID(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %d %d", path, info.Size(), info.ModTime().UnixNano())
+}
+
+// formatByteSize shows n bytes in the largest binary unit below it
+func formatByteSize(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GiB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// commandCache opens the cache of the project around args' file, or the
+// current directory, for the cache commands; --no-cache doesn't apply
+func commandCache(args []string) (*validationCache, error) {
+	// cacheDir only looks at the directory of the file
+	file := filepath.Join(".", "requirements.yml")
+	if len(args) > 0 {
+		file = args[0]
+	}
+	dir := cacheDir(file)
+	if dir == "" {
+		return nil, fmt.Errorf("no cache: not inside a project with a .rqm directory, and RQM_CACHE_DIR isn't set")
+	}
+	return &validationCache{dir: dir}, nil
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and clean the validation cache",
+	Long: `Inspect and clean the cache of validator results, .rqm/cache by default.
+
+Set RQM_CACHE_DIR to share one cache between projects or parallel CI
+jobs: entries are keyed by content, writers lock the directory, and
+entries carry a checksum so damaged ones are dropped instead of read.
+The cache holds at most 256 entries and 64 MiB; RQM_CACHE_MAX_SIZE
+(e.g. 512MB) changes the size limit. The least recently used entries are
+removed first.`,
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status [file]",
+	Short: "Show the size and health of the cache",
+	Example: `  rqm cache status
+  RQM_CACHE_DIR=/ci/cache/rqm rqm cache status`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := commandCache(args)
+		if err != nil {
+			return err
+		}
+		limit, err := maxCacheSize()
+		if err != nil {
+			return err
+		}
+		entries, temps, err := cache.files()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", cache.dir, err)
+		}
+
+		source := ""
+		if os.Getenv("RQM_CACHE_DIR") != "" {
+			source = " (RQM_CACHE_DIR)"
+		}
+		fmt.Printf("Cache: %s%s\n", cache.dir, source)
+		size, corrupt := int64(0), 0
+		for _, f := range entries {
+			size += f.size
+			if isCorrupt(filepath.Join(cache.dir, f.name)) {
+				corrupt++
+			}
+		}
+		fmt.Printf("Entries: %d, %s (limits: %d entries, %s)\n", len(entries), formatByteSize(size), maxCacheEntries, formatByteSize(limit))
+		if len(entries) > 0 {
+			fmt.Printf("Last used: %s (oldest %s)\n", entries[0].used.Format(time.DateTime), entries[len(entries)-1].used.Format(time.DateTime))
+		}
+
+		stale := 0
+		for _, f := range temps {
+			if time.Since(f.used) > staleTempAge {
+				stale++
+			}
+		}
+		if corrupt > 0 {
+			fmt.Printf("%s %d corrupt entry file(s); rqm cache gc removes them\n", warnMark(), corrupt)
+		}
+		if stale > 0 {
+			fmt.Printf("%s %d unfinished write(s) older than an hour; rqm cache gc removes them\n", warnMark(), stale)
+		}
+		if corrupt == 0 && stale == 0 {
+			fmt.Printf("%s No corrupt entries\n", okMark())
+		}
+		return nil
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:     "clear [file]",
+	Short:   "Remove every entry of the cache",
+	Example: `  rqm cache clear`,
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := commandCache(args)
+		if err != nil {
+			return err
+		}
+		unlock, err := cache.lock()
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		removed, freed, err := cache.clear()
+		if err != nil {
+			return fmt.Errorf("failed to clear %s: %w", cache.dir, err)
+		}
+		fmt.Printf("%s Removed %d file(s), %s, from %s\n", okMark(), removed, formatByteSize(freed), cache.dir)
+		return nil
+	},
+}
+
+var cacheGCCmd = &cobra.Command{
+	Use:   "gc [file]",
+	Short: "Remove stale, damaged and excess entries from the cache",
+	Long: `Remove entries unused for longer than --max-age, damaged entries,
+temporary files of writes that never finished, and the least recently
+used entries beyond the cache's limits. Writes trim the cache to its
+limits as they go; gc is for scheduled clean-ups of a shared cache.`,
+	Example: `  rqm cache gc
+  rqm cache gc --max-age 168h`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := commandCache(args)
+		if err != nil {
+			return err
+		}
+		if _, err := maxCacheSize(); err != nil {
+			return err
+		}
+		unlock, err := cache.lock()
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		removed, freed := cache.evict(cacheMaxAge, true)
+		entries, _, err := cache.files()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", cache.dir, err)
+		}
+		size := int64(0)
+		for _, f := range entries {
+			size += f.size
+		}
+		fmt.Printf("%s Removed %d file(s), %s; %d entries, %s left\n", okMark(), removed, formatByteSize(freed), len(entries), formatByteSize(size))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatusCmd, cacheClearCmd, cacheGCCmd)
+	cacheGCCmd.Flags().DurationVar(&cacheMaxAge, "max-age", 30*24*time.Hour, "Remove entries unused for longer than this (0 keeps them)")
+}
@@ -0,0 +1,214 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidationCache(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake validator is a shell script")
+	}
+	if validatorKind() == "embedded" {
+		t.Skip("embedded validator doesn't run rqm-validator")
+	}
+
+	tmpDir := t.TempDir()
+	calls := filepath.Join(tmpDir, "calls")
+	validator := filepath.Join(tmpDir, "rqm-validator")
+	script := "#!/bin/sh\necho run >> " + calls + "\necho '{\"valid\":true,\"errors\":[],\"warnings\":[\"from the validator\"]}'\n"
+	if err := os.WriteFile(validator, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("RQM_VALIDATOR", validator)
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".rqm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(tmpDir, "requirements.yml")
+	if err := os.WriteFile(file, []byte("version: \"1.0\"\nrequirements: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runs := func() int {
+		data, _ := os.ReadFile(calls)
+		return strings.Count(string(data), "run")
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := validateFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Warnings) != 1 {
+			t.Errorf("Expected the validator's warning, got %v", result.Warnings)
+		}
+	}
+	if runs() != 1 {
+		t.Errorf("Expected the second validation to come from the cache, validator ran %d times", runs())
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".rqm", "cache", ".gitignore")); err != nil {
+		t.Errorf("Expected the cache to ignore itself: %v", err)
+	}
+
+	if err := os.WriteFile(file, []byte("version: \"1.0\"\nrequirements: []\n# edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := validateFile(file); err != nil {
+		t.Fatal(err)
+	}
+	if runs() != 2 {
+		t.Errorf("Expected an edit to miss the cache, validator ran %d times", runs())
+	}
+
+	noCache = true
+	defer func() { noCache = false }()
+	if _, err := validateFile(file); err != nil {
+		t.Fatal(err)
+	}
+	if runs() != 3 {
+		t.Errorf("Expected --no-cache to run the validator, validator ran %d times", runs())
+	}
+}
+
+func TestValidationCachePrune(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".rqm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if openValidationCache(filepath.Join(t.TempDir(), "requirements.yml")) != nil {
+		t.Error("Expected no cache outside a project with a .rqm directory")
+	}
+
+	cache := openValidationCache(filepath.Join(tmpDir, "requirements.yml"))
+	if cache == nil {
+		t.Fatal("Expected a cache in .rqm")
+	}
+	for i := 0; i < maxCacheEntries+5; i++ {
+		cache.put(cache.key([]byte{byte(i), byte(i >> 8)}, "test", "result"), []byte("{}"))
+	}
+	entries, err := filepath.Glob(filepath.Join(tmpDir, ".rqm", "cache", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != maxCacheEntries {
+		t.Errorf("Expected %d entries after pruning, got %d", maxCacheEntries, len(entries))
+	}
+}
+
+func TestValidationCacheCorruption(t *testing.T) {
+	cache := &validationCache{dir: t.TempDir()}
+	key := cache.key([]byte("content"), "test", "result")
+	cache.put(key, []byte(`{"valid": true}`))
+	if data, ok := cache.get(key); !ok || string(data) != `{"valid":true}` {
+		t.Fatalf("Expected the entry back, got %q, %v", data, ok)
+	}
+
+	// A bit flipped on disk no longer matches the checksum
+	path := filepath.Join(cache.dir, key+".json")
+	content, _ := os.ReadFile(path)
+	os.WriteFile(path, []byte(strings.Replace(string(content), "true", "fals", 1)), 0644)
+	if _, ok := cache.get(key); ok {
+		t.Error("Expected a damaged entry to miss")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the damaged entry to be removed, got %v", err)
+	}
+
+	// Entries written before checksums are dropped the same way
+	os.WriteFile(path, []byte(`{"valid": true}`), 0644)
+	if _, ok := cache.get(key); ok {
+		t.Error("Expected an entry without a checksum to miss")
+	}
+}
+
+func TestValidationCacheLimits(t *testing.T) {
+	shared := t.TempDir()
+	t.Setenv("RQM_CACHE_DIR", shared)
+	t.Setenv("RQM_CACHE_MAX_SIZE", "1KB")
+
+	// RQM_CACHE_DIR applies outside a project too
+	cache := openValidationCache(filepath.Join(t.TempDir(), "requirements.yml"))
+	if cache == nil || cache.dir != shared {
+		t.Fatalf("Expected the cache in RQM_CACHE_DIR, got %+v", cache)
+	}
+	for i := 0; i < 20; i++ {
+		cache.put(cache.key([]byte{byte(i)}, "test", "result"), []byte(`{"padding": "`+strings.Repeat("x", 100)+`"}`))
+	}
+	entries, _, err := cache.files()
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := int64(0)
+	for _, f := range entries {
+		size += f.size
+	}
+	if len(entries) == 0 || size > 1000 {
+		t.Errorf("Expected the cache trimmed to 1KB, got %d entries of %d bytes", len(entries), size)
+	}
+	if _, ok := cache.get(cache.key([]byte{19}, "test", "result")); !ok {
+		t.Error("Expected the newest entry to be kept")
+	}
+}
+
+func TestValidationCacheGC(t *testing.T) {
+	cache := &validationCache{dir: t.TempDir()}
+	fresh := cache.key([]byte("fresh"), "test", "result")
+	old := cache.key([]byte("old"), "test", "result")
+	cache.put(fresh, []byte(`{}`))
+	cache.put(old, []byte(`{}`))
+	os.WriteFile(filepath.Join(cache.dir, "damaged.json"), []byte(`{"sha256": "0", "data": {}}`), 0644)
+	os.WriteFile(filepath.Join(cache.dir, "crashed.123.tmp"), []byte(`{`), 0644)
+	os.WriteFile(filepath.Join(cache.dir, "writing.456.tmp"), []byte(`{`), 0644)
+	longAgo := time.Now().Add(-60 * 24 * time.Hour)
+	os.Chtimes(filepath.Join(cache.dir, old+".json"), longAgo, longAgo)
+	os.Chtimes(filepath.Join(cache.dir, "crashed.123.tmp"), longAgo, longAgo)
+
+	if removed, _ := cache.evict(30*24*time.Hour, true); removed != 3 {
+		t.Errorf("Expected the old entry, the damaged one and the crashed write removed, got %d", removed)
+	}
+	entries, temps, _ := cache.files()
+	if len(entries) != 1 || entries[0].name != fresh+".json" || len(temps) != 1 {
+		t.Errorf("Expected the fresh entry and the write in progress left, got %v and %v", entries, temps)
+	}
+
+	if removed, _, err := cache.clear(); err != nil || removed != 2 {
+		t.Errorf("Expected clear to remove 2 files, got %d, %v", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(cache.dir, ".gitignore")); err != nil {
+		t.Errorf("Expected clear to keep .gitignore: %v", err)
+	}
+}
+
+func TestValidationCacheLock(t *testing.T) {
+	if runtime.GOOS == "plan9" || runtime.GOOS == "js" || runtime.GOOS == "wasip1" {
+		t.Skip("no file locking")
+	}
+	cache := &validationCache{dir: t.TempDir()}
+	unlock, err := cache.lock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Locks belong to the open file, so a second open contends like
+	// another process would
+	f, err := os.OpenFile(filepath.Join(cache.dir, ".lock"), os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if locked, err := tryLockFile(f); err != nil || locked {
+		t.Errorf("Expected the lock to be held, got %v, %v", locked, err)
+	}
+	unlock()
+	if locked, err := tryLockFile(f); err != nil || !locked {
+		t.Errorf("Expected the lock once released, got %v, %v", locked, err)
+	}
+	unlockFile(f)
+}
@@ -0,0 +1,164 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	changelogSince  string
+	changelogUntil  string
+	changelogOutput string
+)
+
+// requirementChangelog is the difference between two versions of a file as
+// release notes present it
+type requirementChangelog struct {
+	New           []*model.RequirementDetail
+	Implemented   []requirementChange
+	Reprioritized []requirementChange
+	Removed       []*model.RequirementDetail
+}
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog [file]",
+	Short: "Write a Markdown requirements changelog between git refs for release notes",
+	Long: `Compare a requirements file at a git ref with a later version and write
+a Markdown changelog for release notes, with four sections:
+
+  - New: requirements added
+  - Implemented: requirements that reached implemented or verified
+  - Re-prioritized: requirements whose priority changed, as "low → high"
+  - Removed: requirements deleted from the file
+
+The later version is the working copy, or the git ref --until names.
+Requirements are matched as in rqm pr-summary, so a requirement with a uid
+or an ID that was renamed isn't reported as removed and new. Other
+changes, like reworded descriptions, are left to rqm diff. Empty
+sections are omitted.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm changelog --since v1.0.0
+  rqm changelog requirements.yml --since v1.0.0 --until v1.1.0 -o CHANGES.md`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		changes, err := changesBetween(changelogSince, changelogUntil, file)
+		if err != nil {
+			return err
+		}
+
+		var out bytes.Buffer
+		writeChangelog(&out, changelogSince, changelogUntil, newRequirementChangelog(changes))
+		if changelogOutput == "" {
+			_, err := io.Copy(os.Stdout, &out)
+			return err
+		}
+		if err := os.WriteFile(changelogOutput, out.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", changelogOutput, err)
+		}
+		fmt.Fprintf(os.Stderr, "%s Wrote changelog to %s\n", okMark(), changelogOutput)
+		return nil
+	},
+}
+
+// changesBetween compares file at the git ref since with its version at
+// until, or with the working copy when until is empty
+func changesBetween(since, until, file string) (requirementChanges, error) {
+	if until == "" {
+		return changesSince(since, file)
+	}
+	old, err := requirementsAtRef(since, file)
+	if err != nil {
+		return requirementChanges{}, err
+	}
+	current, err := requirementsAtRef(until, file)
+	if err != nil {
+		return requirementChanges{}, err
+	}
+	return diffRequirements(old, current), nil
+}
+
+// newRequirementChangelog sorts changes into the changelog's sections. A
+// changed requirement can be both implemented and re-prioritized.
+func newRequirementChangelog(changes requirementChanges) requirementChangelog {
+	log := requirementChangelog{New: changes.Added, Removed: changes.Removed}
+	for _, c := range changes.Changed {
+		if pastStatus(c.New.Status, "implemented") && !pastStatus(c.Old.Status, "implemented") {
+			log.Implemented = append(log.Implemented, c)
+		}
+		if c.Old.Priority != c.New.Priority {
+			log.Reprioritized = append(log.Reprioritized, c)
+		}
+	}
+	return log
+}
+
+// writeChangelog writes log as Markdown
+func writeChangelog(w io.Writer, since, until string, log requirementChangelog) {
+	span := "since " + since
+	if until != "" {
+		span = fmt.Sprintf("from %s to %s", since, until)
+	}
+	fmt.Fprintf(w, "## Requirements changes %s\n", span)
+	if len(log.New)+len(log.Implemented)+len(log.Reprioritized)+len(log.Removed) == 0 {
+		fmt.Fprint(w, "\nNo requirements were added, implemented, re-prioritized or removed.\n")
+		return
+	}
+
+	if len(log.New) > 0 {
+		fmt.Fprintf(w, "\n### New (%d)\n\n", len(log.New))
+		for _, req := range log.New {
+			fmt.Fprintf(w, "- %s\n", changelogLabel(req))
+		}
+	}
+	if len(log.Implemented) > 0 {
+		fmt.Fprintf(w, "\n### Implemented (%d)\n\n", len(log.Implemented))
+		for _, c := range log.Implemented {
+			fmt.Fprintf(w, "- %s\n", changelogLabel(c.New))
+		}
+	}
+	if len(log.Reprioritized) > 0 {
+		fmt.Fprintf(w, "\n### Re-prioritized (%d)\n\n", len(log.Reprioritized))
+		for _, c := range log.Reprioritized {
+			fmt.Fprintf(w, "- %s: %s → %s\n", changelogLabel(c.New), orNone(c.Old.Priority), orNone(c.New.Priority))
+		}
+	}
+	if len(log.Removed) > 0 {
+		fmt.Fprintf(w, "\n### Removed (%d)\n\n", len(log.Removed))
+		for _, req := range log.Removed {
+			fmt.Fprintf(w, "- %s\n", changelogLabel(req))
+		}
+	}
+}
+
+// changelogLabel is a requirement's ID in bold, when it has one, and
+// summary
+func changelogLabel(req *model.RequirementDetail) string {
+	if req.Name == "" {
+		return markdownCell(req.Summary)
+	}
+	return fmt.Sprintf("**%s** %s", markdownCell(req.Name), markdownCell(req.Summary))
+}
+
+func init() {
+	rootCmd.AddCommand(changelogCmd)
+	changelogCmd.Flags().StringVar(&changelogSince, "since", "", "Git ref of the previous release, e.g. v1.0.0")
+	changelogCmd.Flags().StringVar(&changelogUntil, "until", "", "Git ref of the new release (default: the working copy)")
+	changelogCmd.Flags().StringVarP(&changelogOutput, "output", "o", "", "Write the changelog to this file instead of stdout")
+	changelogCmd.MarkFlagRequired("since")
+}
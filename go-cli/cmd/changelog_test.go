@@ -0,0 +1,96 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestWriteChangelog(t *testing.T) {
+	base, _ := model.ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: approved
+    priority: medium
+  - summary: Password reset
+    name: AUTH-002
+    status: implemented
+    priority: low
+  - summary: Audit log
+`))
+	head, _ := model.ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: verified
+    priority: high
+  - summary: Reset a forgotten password
+    name: AUTH-002
+    status: verified
+    priority: low
+  - summary: Rate limiting
+    status: draft
+`))
+	log := newRequirementChangelog(diffRequirements(base, head))
+	// AUTH-002 was already implemented, so verifying it isn't news
+	if len(log.Implemented) != 1 || log.Implemented[0].New.Name != "AUTH-001" {
+		t.Errorf("Implemented = %v, want AUTH-001", log.Implemented)
+	}
+
+	var out strings.Builder
+	writeChangelog(&out, "v1.0.0", "", log)
+	want := `## Requirements changes since v1.0.0
+
+### New (1)
+
+- Rate limiting
+
+### Implemented (1)
+
+- **AUTH-001** Login
+
+### Re-prioritized (1)
+
+- **AUTH-001** Login: medium → high
+
+### Removed (1)
+
+- Audit log
+`
+	if out.String() != want {
+		t.Errorf("changelog:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	writeChangelog(&out, "v1.0.0", "v1.1.0", newRequirementChangelog(diffRequirements(base, base)))
+	if got := out.String(); !strings.HasPrefix(got, "## Requirements changes from v1.0.0 to v1.1.0\n") || !strings.Contains(got, "No requirements were added") {
+		t.Errorf("changelog without changes:\n%s", got)
+	}
+}
+
+func TestChangesBetween(t *testing.T) {
+	gitTestRepo(t)
+	gitCommitFiles(t, "v1", map[string]string{"requirements.yml": prSummaryBaseYAML})
+	mustGit(t, "tag", "v1")
+	gitCommitFiles(t, "v2", map[string]string{"requirements.yml": prSummaryHeadYAML})
+	mustGit(t, "tag", "v2")
+	if err := os.WriteFile("requirements.yml", []byte(prSummaryBaseYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := changesBetween("v1", "v2", "requirements.yml")
+	if err != nil || len(changes.Added) != 1 || len(changes.Removed) != 1 {
+		t.Errorf("v1 to v2 = %+v, %v", changes, err)
+	}
+	// Without --until the working copy, back at v1's content, is compared
+	if changes, err = changesBetween("v1", "", "requirements.yml"); err != nil || len(changes.Added)+len(changes.Changed)+len(changes.Removed) != 0 {
+		t.Errorf("v1 to the working copy = %+v, %v", changes, err)
+	}
+}
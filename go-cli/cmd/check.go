@@ -5,19 +5,30 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
+	"github.com/238855/rqm/go-cli/pkg/model"
 	"github.com/spf13/cobra"
 )
 
+var (
+	checkFormat       string
+	checkOwnersActive bool
+	checkCoverage     bool
+	checkExternal     bool
+	checkSchemaSync   bool
+	checkSchema       string
+	graphFormat       string
+	graphClusterBy    string
+)
+
 type CycleCheckResult struct {
-	HasCycles bool                `json:"has_cycles"`
-	Cycles    [][]string          `json:"cycles"`
-	Graph     map[string][]string `json:"graph"`
+	HasCycles bool                        `json:"has_cycles"`
+	Cycles    [][]string                  `json:"cycles"`
+	Graph     map[string][]string         `json:"graph"`
+	Relations map[string][]model.Relation `json:"relations,omitempty"`
 }
 
 var checkCmd = &cobra.Command{
@@ -30,62 +41,86 @@ Circular references occur when requirements form dependency loops:
   - A → B → C → A (complex cycle)
   - A → A (self-reference)
 
-This command uses graph traversal algorithms to detect all cycles.`,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		file := args[0]
+Typed relations of kind depends_on and blocks are part of the graph too,
+so "A depends_on B" while B contains A is reported as a cycle.
 
-		// Check if file exists
-		if _, err := os.Stat(file); os.IsNotExist(err) {
-			return fmt.Errorf("file does not exist: %s", file)
-		}
+Each cycle is reported once, as the group of requirements that lead back
+to each other, in file order. Cycles are found in-process, so check
+doesn't need the rqm-validator binary.
+With --owners-active, owner emails are also looked up in the directory
+(SCIM, Google Workspace or LDAP) configured under directory: in
+.rqm/config.yml, flagging requirements owned by people who have left.
 
-		// Find the rqm-validator binary
-		validatorPath := findValidatorBinary()
-		if validatorPath == "" {
-			return fmt.Errorf("rqm-validator binary not found")
-		}
+With --child-coverage, check also audits decomposition: every acceptance
+criterion of a requirement with children must be covered by one of them,
+and every criterion a child covers must exist on its parent:
 
-		// Call rust-core validator with --check-cycles flag
-		validatorCmd := exec.Command(validatorPath, file, "--check-cycles")
-		output, _ := validatorCmd.CombinedOutput()
+  - summary: Login
+    acceptance_criteria:
+      - id: AC1
+        criterion: Users sign in with email and password
+      - id: AC2
+        criterion: Accounts lock after five failed attempts
+    requirements:
+      - summary: Password sign-in
+        covers: [AC1]
 
-		// Parse the result
-		var result CycleCheckResult
-		if jsonErr := json.Unmarshal(output, &result); jsonErr != nil {
-			return fmt.Errorf("failed to parse cycle check result: %w\nOutput: %s", jsonErr, string(output))
-		}
+Here AC2 is reported as uncovered.
 
-		// Display results
-		fmt.Printf("Checking %s for circular references...\n\n", file)
+With --external, check also fetches the repositories external references
+point into and fails when a requirement they name doesn't exist there:
 
-		if !result.HasCycles {
-			fmt.Println("✓ No circular references detected")
-			fmt.Println("  The requirements graph is acyclic (DAG)")
-			return nil
-		}
+  requirements:
+    - external:
+        repo: github.com/acme/platform
+        id: PLAT-42
+        ref: v2.1
 
-		// Display cycles found
-		fmt.Printf("✗ Found %d circular reference(s):\n\n", len(result.Cycles))
-		for i, cycle := range result.Cycles {
-			fmt.Printf("Cycle %d:\n", i+1)
-			for j, node := range cycle {
-				if j == len(cycle)-1 {
-					fmt.Printf("  └─ %s → (back to %s)\n", node, cycle[0])
-				} else {
-					fmt.Printf("  ├─ %s\n", node)
-					if j < len(cycle)-2 {
-						fmt.Printf("  │  ↓\n")
-					}
-				}
-			}
-			fmt.Println()
-		}
+repo is a git repository, whose .rqm/requirements.yml is read at ref (its
+default branch when omitted), or a remote file such as an https:// URL.
+Each repository is fetched once and cached as remote files are; ones that
+can't be fetched are warnings.
+
+With --format json the result is printed as JSON; see
+'rqm schema output check' for its schema. --format github prints GitHub
+Actions workflow commands that annotate the requirements involved.
+
+A file of - checks the requirements read from stdin; --filename names
+them in messages, and its directory and extension give the
+.rqm/config.yml and format to use. Remote files, http(s) URLs or git::
+sources, are downloaded as for rqm validate.
 
-		fmt.Println("⚠ Circular references can cause infinite loops during traversal.")
-		fmt.Println("  Consider restructuring your requirements to remove cycles.")
+Exits with 2 when cycles are found, 1 for inactive owners, coverage gaps
+or missing external requirements, or 3 when the
+file can't be checked at all. Owners that can't be looked up and
+repositories that can't be fetched are warnings:
+--fail-on warning fails on them too, --fail-on none only reports.
 
-		return fmt.Errorf("circular references detected")
+With --schema-sync, check instead compares the files, directories or glob
+patterns given with the active schema: .rqm/schema.json, --schema, or the
+schema.json found from the current directory or the validator. Every
+field used must be declared in the schema, or it exits with 1; schema
+fields no file uses are warnings. Directories are searched as by
+validate -r, so a customized schema can be checked against every
+requirements file of a repository.`,
+	Example: `  rqm check requirements.yml
+  rqm check requirements.yml --child-coverage
+  rqm check requirements.yml --external
+  cat requirements.yml | rqm check - --filename requirements.yml
+  rqm check --schema-sync .
+  rqm check --schema-sync 'docs/**/*.yml' --schema .rqm/schema.json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if checkSchemaSync {
+			return cobra.MinimumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if checkSchemaSync {
+			return runSchemaSync(args)
+		}
+		return withFileArg(args[0], runCheck)
 	},
 }
 
@@ -95,8 +130,24 @@ var graphCmd = &cobra.Command{
 	Long: `Display the requirements dependency graph in various formats.
 	
 Shows the relationship between requirements and their dependencies.
-Useful for understanding the structure and detecting patterns.`,
-	Args: cobra.ExactArgs(1),
+Parent/child edges are listed first, followed by typed relations labeled
+with their kind (depends_on, blocks, relates_to, duplicates, derives_from).
+External references to requirements of other repositories are shown with
+⇢ and left out of the cycle check. Useful for understanding the structure and detecting patterns.
+
+--format dot and --format mermaid export the graph for Graphviz and
+Mermaid, with relations drawn dashed, external requirements as dashed
+nodes and deprecated requirements grayed out. Deprecated requirements are
+marked in text output too. --cluster-by groups the nodes of a
+large graph into labeled boxes:
+  - tag        the requirement's first tag
+  - component  the "component" custom attribute
+  - owner      the requirement's owner`,
+	Example: `  rqm graph requirements.yml
+  rqm graph requirements.yml --format dot --cluster-by component | dot -Tsvg > graph.svg
+  rqm graph requirements.yml --format mermaid --cluster-by tag`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		file := args[0]
 
@@ -105,23 +156,47 @@ Useful for understanding the structure and detecting patterns.`,
 			return fmt.Errorf("file does not exist: %s", file)
 		}
 
-		// Find the rqm-validator binary
-		validatorPath := findValidatorBinary()
-		if validatorPath == "" {
-			return fmt.Errorf("rqm-validator binary not found")
+		config, err := model.Load(file)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case graphFormat == "dot" || graphFormat == "mermaid":
+			export, err := newGraphExport(config, graphClusterBy)
+			if err != nil {
+				return err
+			}
+			if graphFormat == "dot" {
+				export.writeDOT(os.Stdout)
+			} else {
+				export.writeMermaid(os.Stdout)
+			}
+			return nil
+		case graphClusterBy != "":
+			return fmt.Errorf("--cluster-by requires --format dot or mermaid")
 		}
 
-		// Call rust-core validator with --graph flag
-		validatorCmd := exec.Command(validatorPath, file, "--graph")
-		output, _ := validatorCmd.CombinedOutput()
-		if validatorPath == "" {
-			return fmt.Errorf("failed to generate graph: %s", string(output))
+		result, err := newCycleCheckResult(config)
+		if err != nil {
+			return err
 		}
 
-		// Parse the result
-		var result CycleCheckResult
-		if jsonErr := json.Unmarshal(output, &result); jsonErr != nil {
-			return fmt.Errorf("failed to parse graph result: %w\nOutput: %s", jsonErr, string(output))
+		switch graphFormat {
+		case "json":
+			return writeJSON(GraphOutput{
+				SchemaVersion: apiVersion,
+				File:          file,
+				Graph:         result.Graph,
+				Relations:     result.Relations,
+				External:      externalReferences(config),
+				Deprecated:    deprecatedSummaries(config),
+				HasCycles:     result.HasCycles,
+				Cycles:        nonNil(result.Cycles),
+			})
+		case "text":
+		default:
+			return fmt.Errorf("unknown output format: %s", graphFormat)
 		}
 
 		// Display graph
@@ -132,27 +207,217 @@ Useful for understanding the structure and detecting patterns.`,
 			return nil
 		}
 
-		// Display each node and its dependencies
-		for node, deps := range result.Graph {
+		// Display each node and its dependencies, in file order
+		external := externalReferences(config)
+		notes := make(map[string]string)
+		for _, req := range config.Flatten() {
+			if note := deprecationNote(req); note != "" {
+				notes[req.Summary] = note
+			}
+		}
+		for _, node := range graphNodes(config) {
+			deps := result.Graph[node]
+			label := node
+			if note := notes[node]; note != "" {
+				label += " " + note
+			}
 			if len(deps) == 0 {
-				fmt.Printf("  %s → (no dependencies)\n", node)
+				fmt.Printf("  %s → (no dependencies)\n", label)
 			} else {
-				fmt.Printf("  %s → %s\n", node, strings.Join(deps, ", "))
+				fmt.Printf("  %s → %s\n", label, strings.Join(deps, ", "))
+			}
+			for _, rel := range result.Relations[node] {
+				fmt.Printf("  %s -[%s]→ %s\n", node, rel.Type, rel.Target)
+			}
+			for _, ext := range external[node] {
+				fmt.Printf("  %s ⇢ %s (external)\n", node, ext)
 			}
 		}
 
 		fmt.Println()
 		if result.HasCycles {
-			fmt.Printf("⚠ Warning: Graph contains %d cycle(s)\n", len(result.Cycles))
+			fmt.Printf("%s Warning: Graph contains %d cycle(s)\n", warnMark(), len(result.Cycles))
 		} else {
-			fmt.Println("✓ Graph is acyclic (DAG)")
+			fmt.Println(okMark(), "Graph is acyclic (DAG)")
 		}
 
 		return nil
 	},
 }
 
+// runCheck performs the check logic
+func runCheck(file string) error {
+	if err := checkFailOn(); err != nil {
+		return err
+	}
+
+	result, err := checkCycles(file)
+	if err != nil {
+		return err
+	}
+
+	var inactive []InactiveOwner
+	var unresolved []string
+	if checkOwnersActive {
+		var err error
+		if inactive, unresolved, err = findInactiveOwners(file); err != nil {
+			return err
+		}
+	}
+
+	var gaps []CoverageGap
+	if checkCoverage {
+		config, err := model.Load(file)
+		if err != nil {
+			return err
+		}
+		gaps = findCoverageGaps(config)
+	}
+
+	var broken, unreachable []ExternalProblem
+	if checkExternal {
+		config, err := model.Load(file)
+		if err != nil {
+			return err
+		}
+		broken, unreachable = findBrokenExternal(config)
+	}
+	warnings := len(unresolved) + len(unreachable)
+
+	switch checkFormat {
+	case "json":
+		if err := writeJSON(CheckOutput{
+			SchemaVersion:    apiVersion,
+			File:             fileLabel(file),
+			HasCycles:        result.HasCycles,
+			Cycles:           nonNil(result.Cycles),
+			InactiveOwners:   inactive,
+			CoverageGaps:     gaps,
+			ExternalProblems: append(broken, unreachable...),
+		}); err != nil {
+			return err
+		}
+		return checkFailure(result, inactive, gaps, broken, warnings)
+	case "github":
+		writeCheckAnnotations(os.Stdout, file, result, inactive, gaps, append(broken, unreachable...))
+		return checkFailure(result, inactive, gaps, broken, warnings)
+	case "text":
+	default:
+		return fmt.Errorf("unknown output format: %s", checkFormat)
+	}
+
+	displayCycleResult(file, result)
+	if checkOwnersActive {
+		displayInactiveOwners(inactive)
+	}
+	if checkCoverage {
+		displayCoverageGaps(gaps)
+	}
+	if checkExternal {
+		displayExternalProblems(broken, unreachable)
+	}
+	return checkFailure(result, inactive, gaps, broken, warnings)
+}
+
+// checkCycles loads file and finds its circular references in-process
+func checkCycles(file string) (*CycleCheckResult, error) {
+	// Check if file exists
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		return nil, fmt.Errorf("file does not exist: %s", file)
+	}
+
+	config, err := model.Load(file)
+	if err != nil {
+		return nil, err
+	}
+	return newCycleCheckResult(config)
+}
+
+// newCycleCheckResult runs cycle detection on config and collects the
+// graph check and graph report
+func newCycleCheckResult(config *model.RequirementConfig) (*CycleCheckResult, error) {
+	cycles, err := config.Cycles()
+	if err != nil {
+		return nil, err
+	}
+	return &CycleCheckResult{
+		HasCycles: len(cycles) > 0,
+		Cycles:    cycles,
+		Graph:     config.Adjacency(),
+		Relations: config.RelationMap(),
+	}, nil
+}
+
+// graphNodes lists the summaries of config in file order, once each
+func graphNodes(config *model.RequirementConfig) []string {
+	var nodes []string
+	seen := make(map[string]bool)
+	for _, req := range config.Flatten() {
+		if !seen[req.Summary] {
+			seen[req.Summary] = true
+			nodes = append(nodes, req.Summary)
+		}
+	}
+	return nodes
+}
+
+// displayCycleResult prints the cycle check for humans
+func displayCycleResult(file string, result *CycleCheckResult) {
+	fmt.Printf("Checking %s for circular references...\n\n", fileLabel(file))
+
+	if !result.HasCycles {
+		fmt.Println(okMark(), "No circular references detected")
+		fmt.Println("  The requirements graph is acyclic (DAG)")
+		return
+	}
+
+	// Display cycles found
+	fmt.Printf("%s Found %d circular reference(s):\n\n", failMark(), len(result.Cycles))
+	for i, cycle := range result.Cycles {
+		fmt.Printf("Cycle %d:\n", i+1)
+		for j, node := range cycle {
+			if j == len(cycle)-1 {
+				fmt.Printf("  └─ %s\n", node)
+			} else {
+				fmt.Printf("  ├─ %s\n", node)
+			}
+		}
+		fmt.Println()
+	}
+
+	fmt.Println(warnMark(), "Circular references can cause infinite loops during traversal.")
+	fmt.Println("  Consider restructuring your requirements to remove cycles.")
+}
+
+// checkFailure returns the error check exits with under --fail-on, or nil
+// when all checks passed. Cycles take precedence over inactive owners,
+// those over coverage gaps, and those over broken external references.
+func checkFailure(result *CycleCheckResult, inactive []InactiveOwner, gaps []CoverageGap, broken []ExternalProblem, warnings int) error {
+	var failure error
+	switch {
+	case result.HasCycles:
+		failure = cycleError("circular references detected")
+	case len(inactive) > 0:
+		failure = validationError("%d requirement(s) owned by people not active in the directory", len(inactive))
+	case len(gaps) > 0:
+		failure = validationError("%d problem(s) with child coverage", len(gaps))
+	case len(broken) > 0:
+		failure = validationError("%d external reference(s) to requirements that don't exist", len(broken))
+	}
+	return applyFailOn(failure, warnings)
+}
+
 func init() {
 	rootCmd.AddCommand(checkCmd)
 	rootCmd.AddCommand(graphCmd)
+	checkCmd.Flags().StringVarP(&checkFormat, "format", "f", "text", "Output format (text, json, github)")
+	checkCmd.Flags().StringVar(&stdinFilename, "filename", "", "Name to show for requirements read from stdin (-); its extension gives their format")
+	checkCmd.Flags().StringVar(&failOn, "fail-on", "error", "Lowest severity that fails the command (error, warning, none)")
+	checkCmd.Flags().BoolVar(&checkOwnersActive, "owners-active", false, "Also check owners exist in the directory configured in .rqm/config.yml")
+	checkCmd.Flags().BoolVar(&checkCoverage, "child-coverage", false, "Also check that children cover the acceptance criteria of their parent")
+	checkCmd.Flags().BoolVar(&checkExternal, "external", false, "Also fetch the repositories of external references and check the requirements exist")
+	checkCmd.Flags().BoolVar(&checkSchemaSync, "schema-sync", false, "Check that the fields used in the files match those declared in the schema")
+	checkCmd.Flags().StringVar(&checkSchema, "schema", "", "Schema for --schema-sync (default: the active schema.json)")
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", "text", "Output format (text, json, dot, mermaid)")
+	graphCmd.Flags().StringVar(&graphClusterBy, "cluster-by", "", "Group dot and mermaid nodes into clusters by tag, component or owner")
 }
@@ -6,6 +6,7 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
@@ -119,6 +120,43 @@ requirements:
 	}
 }
 
+func TestCheckCyclesWithoutValidator(t *testing.T) {
+	t.Setenv("RQM_VALIDATOR", filepath.Join(t.TempDir(), "missing"))
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(`version: "1.0"
+requirements:
+  - summary: Requirement A
+    name: REQ-A
+    requirements:
+      - summary: Requirement B
+        requirements:
+          - REQ-A
+  - summary: Requirement C
+    relations:
+      - type: relates_to
+        target: Requirement A
+`), 0644)
+
+	result, err := checkCycles(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.HasCycles || len(result.Cycles) != 1 || strings.Join(result.Cycles[0], ",") != "Requirement A,Requirement B" {
+		t.Errorf("Expected one cycle A → B, got %v", result.Cycles)
+	}
+	if got := result.Graph["Requirement A"]; len(got) != 1 || got[0] != "Requirement B" {
+		t.Errorf("Expected A → B in the graph, got %v", result.Graph)
+	}
+	if len(result.Relations["Requirement C"]) != 1 {
+		t.Errorf("Expected C's relation, got %v", result.Relations)
+	}
+
+	os.WriteFile(file, []byte("version: \"1.0\"\nrequirements:\n  - summary: A\n    requirements:\n      - Missing\n"), 0644)
+	if _, err := checkCycles(file); err == nil || !strings.Contains(err.Error(), "non-existent 'Missing'") {
+		t.Errorf("Expected a missing reference error, got %v", err)
+	}
+}
+
 func TestGraphCommand(t *testing.T) {
 	// Create temp directory for test files
 	tmpDir := t.TempDir()
@@ -223,3 +261,22 @@ func TestCycleCheckResult(t *testing.T) {
 		t.Errorf("Expected 3 nodes in graph, got %d", len(result.Graph))
 	}
 }
+
+func TestCycleCheckResultRelations(t *testing.T) {
+	data := `{
+  "has_cycles": false,
+  "cycles": [],
+  "graph": {"A": [], "B": []},
+  "relations": {"B": [{"type": "depends_on", "target": "A"}]}
+}`
+
+	var result CycleCheckResult
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	rels := result.Relations["B"]
+	if len(rels) != 1 || rels[0].Type != "depends_on" || rels[0].Target != "A" {
+		t.Errorf("Unexpected relations: %+v", result.Relations)
+	}
+}
@@ -0,0 +1,104 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// CoverageGap is a problem check --child-coverage found in how a
+// requirement is decomposed: an acceptance criterion of a parent that none
+// of its children covers, or a covers entry naming a criterion that no
+// parent of the requirement has
+type CoverageGap struct {
+	Summary string `json:"summary"`
+	Name    string `json:"name,omitempty"`
+	// Criterion is the ID of the criterion
+	Criterion string `json:"criterion"`
+	// Text is the criterion, for uncovered ones
+	Text string `json:"text,omitempty"`
+	// Reason is "uncovered" or "unknown_criterion"
+	Reason string `json:"reason"`
+}
+
+// findCoverageGaps checks that the acceptance criteria of every requirement
+// with children are covered by those children, and that what children
+// cover exists. A referenced child counts as a child of every requirement
+// that lists it. Requirements without children verify their criteria
+// themselves and are skipped.
+func findCoverageGaps(config *model.RequirementConfig) []CoverageGap {
+	parents := make(map[*model.RequirementDetail][]*model.RequirementDetail)
+	children := make(map[*model.RequirementDetail][]*model.RequirementDetail)
+	for _, req := range config.Flatten() {
+		for _, ref := range req.Requirements {
+			child := ref.Full
+			if child == nil {
+				var ok bool
+				if child, ok = config.Find(ref.Reference); !ok {
+					// Dangling references are the validator's to report
+					continue
+				}
+			}
+			if !slices.Contains(children[req], child) {
+				children[req] = append(children[req], child)
+				parents[child] = append(parents[child], req)
+			}
+		}
+	}
+
+	var gaps []CoverageGap
+	for _, req := range config.Flatten() {
+		if len(children[req]) > 0 {
+			for _, criterion := range req.AcceptanceCriteria {
+				covered := slices.ContainsFunc(children[req], func(child *model.RequirementDetail) bool {
+					return slices.Contains(child.Covers, criterion.ID)
+				})
+				if !covered {
+					gaps = append(gaps, CoverageGap{Summary: req.Summary, Name: req.Name, Criterion: criterion.ID, Text: criterion.Criterion, Reason: "uncovered"})
+				}
+			}
+		}
+
+		for _, id := range req.Covers {
+			known := slices.ContainsFunc(parents[req], func(parent *model.RequirementDetail) bool {
+				return slices.ContainsFunc(parent.AcceptanceCriteria, func(c model.Criterion) bool { return c.ID == id })
+			})
+			if !known {
+				gaps = append(gaps, CoverageGap{Summary: req.Summary, Name: req.Name, Criterion: id, Reason: "unknown_criterion"})
+			}
+		}
+	}
+	return gaps
+}
+
+// message describes the gap in a sentence
+func (g CoverageGap) message() string {
+	if g.Reason == "unknown_criterion" {
+		return fmt.Sprintf("'%s' covers %s, which none of its parents has as an acceptance criterion", g.Summary, g.Criterion)
+	}
+	return fmt.Sprintf("Acceptance criterion %s of '%s' is covered by none of its children: %s", g.Criterion, g.Summary, g.Text)
+}
+
+// displayCoverageGaps prints the result of check --child-coverage
+func displayCoverageGaps(gaps []CoverageGap) {
+	fmt.Println()
+	if len(gaps) == 0 {
+		fmt.Println(okMark(), "Every acceptance criterion of a decomposed requirement is covered by a child")
+		return
+	}
+
+	fmt.Printf("%s %d problem(s) with child coverage:\n", failMark(), len(gaps))
+	for _, gap := range gaps {
+		name := displayName(&model.RequirementDetail{Name: gap.Name, Summary: gap.Summary})
+		if gap.Reason == "unknown_criterion" {
+			fmt.Printf("  - %s covers %s, which none of its parents has as an acceptance criterion\n", name, gap.Criterion)
+		} else {
+			fmt.Printf("  - %s: %s \"%s\" has no covering child\n", name, gap.Criterion, gap.Text)
+		}
+	}
+}
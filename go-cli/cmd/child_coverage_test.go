@@ -0,0 +1,70 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const coverageYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    acceptance_criteria:
+      - id: AC1
+        criterion: Users sign in with email and password
+      - id: AC2
+        criterion: Accounts lock after five failed attempts
+      - id: AC3
+        criterion: Sign-ins are audited
+    requirements:
+      - summary: Password sign-in
+        covers: [AC1]
+      - summary: Lockout
+        covers: [AC2, AC9]
+      - Audit log
+  - summary: Audit log
+    covers: [AC3]
+  - summary: Session timeout
+    acceptance_criteria:
+      - id: AC1
+        criterion: Sessions end after 30 minutes idle
+`
+
+func TestFindCoverageGaps(t *testing.T) {
+	config, err := model.ParseYAML([]byte(coverageYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, gap := range findCoverageGaps(config) {
+		got = append(got, gap.Summary+" "+gap.Criterion+" "+gap.Reason)
+	}
+	// AC3 is covered through the reference; Session timeout has no
+	// children, so it isn't decomposed
+	want := []string{"Lockout AC9 unknown_criterion"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("findCoverageGaps() = %v, want %v", got, want)
+	}
+
+	// Without the reference, Audit log covers a criterion of no parent
+	login := &config.Requirements[0]
+	login.Requirements = login.Requirements[:2]
+	got = nil
+	gaps := findCoverageGaps(config)
+	for _, gap := range gaps {
+		got = append(got, gap.Summary+" "+gap.Criterion+" "+gap.Reason)
+	}
+	want = []string{"Login AC3 uncovered", "Lockout AC9 unknown_criterion", "Audit log AC3 unknown_criterion"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("findCoverageGaps() = %v, want %v", got, want)
+	}
+	if msg := gaps[0].message(); msg != "Acceptance criterion AC3 of 'Login' is covered by none of its children: Sign-ins are audited" {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
@@ -0,0 +1,364 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ciFormat      string
+	ciMinCoverage float64
+	ciNotify      bool
+)
+
+// ciStep is the outcome of one step of rqm ci
+type ciStep struct {
+	Name string
+	// Result is pass, fail, error or skipped
+	Result  string
+	Details string
+	// Requirements are those that made the step fail, where known
+	Requirements []string
+	// err is what the step contributes to the exit code
+	err error
+}
+
+var ciCmd = &cobra.Command{
+	Use:   "ci [file]",
+	Short: "Run every requirements check for a CI job and summarize the results",
+	Long: `Run validate, check and a verification coverage check on a requirements
+file and print a summary table for the job log.
+
+Coverage counts the requirements that say how they are verified: a
+verification method, an acceptance test, an acceptance_test_link or
+verified_by entries. It fails only below --min-coverage.
+
+With --format github (the default when GITHUB_ACTIONS is set) problems are
+also printed as workflow commands, so they show up as annotations on the
+pull request, and the summary is added to the job summary page when
+GITHUB_STEP_SUMMARY is set.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory. Every step runs even after a failure, and the exit
+code is the most severe result: 2 for cycles, 1 for validation errors or
+low coverage, 3 when a step can't run at all.
+
+With --notify, a failure is also reported to the destinations under
+notifications: in .rqm/config.yml, for scheduled runs nobody watches:
+
+  notifications:
+    - type: slack            # or teams, or webhook for the JSON payload
+      url_env: SLACK_WEBHOOK_URL
+      events: [gate_failed]    # all events when left out
+    - type: email
+      smtp: smtp.example.com:587
+      from: rqm@example.com
+      to: [requirements-team@example.com]
+      username_env: SMTP_USER
+      password_env: SMTP_PASSWORD
+
+Each notification names the failed steps and the requirements that made
+them fail. A notification that can't be delivered is reported as a
+warning and doesn't change the exit code. rqm notify reports requirement
+changes to the same destinations.`,
+	Example: `  rqm ci
+  rqm ci requirements.yml --min-coverage 80
+  rqm ci --format github
+  rqm ci --min-coverage 80 --notify`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	// Failures are check results; usage would only clutter the job log
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkFailOn(); err != nil {
+			return err
+		}
+		format := ciFormat
+		if format == "" {
+			format = "text"
+			if os.Getenv("GITHUB_ACTIONS") == "true" {
+				format = "github"
+			}
+		}
+		if format != "text" && format != "github" {
+			return fmt.Errorf("unknown output format: %s", format)
+		}
+
+		var file string
+		if len(args) > 0 {
+			file = args[0]
+		} else {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			if file = findRequirementsFile(cwd); file == "" {
+				return fmt.Errorf("no .rqm/requirements.yml found; pass the requirements file to check")
+			}
+		}
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist: %s", file)
+		}
+
+		var annotations io.Writer = io.Discard
+		if format == "github" {
+			annotations = os.Stdout
+		}
+		steps := runCISteps(file, annotations)
+
+		fmt.Printf("\nrqm ci: %s\n\n", file)
+		writeCISummary(os.Stdout, steps)
+		if format == "github" {
+			if path := os.Getenv("GITHUB_STEP_SUMMARY"); path != "" {
+				if err := appendCIStepSummary(path, file, steps); err != nil {
+					return err
+				}
+			}
+		}
+
+		failure := stepsFailure(steps)
+		if failure != nil && ciNotify {
+			notifyCIFailure(file, steps)
+		}
+		return failure
+	},
+}
+
+// runCISteps runs validate, check and coverage on file, writing any
+// workflow command annotations to annotations
+func runCISteps(file string, annotations io.Writer) []ciStep {
+	return []ciStep{
+		validateStep(file, annotations),
+		checkStep(file, annotations),
+		coverageStep(file, annotations, ciMinCoverage),
+	}
+}
+
+// validateStep validates file
+func validateStep(file string, annotations io.Writer) ciStep {
+	validation := ciStep{Name: "validate"}
+	result, err := validateFile(file)
+	if err != nil {
+		validation.Result, validation.Details, validation.err = "error", err.Error(), err
+		return validation
+	}
+	writeValidationAnnotations(annotations, file, result)
+	validation.err = validationFailure(result)
+	validation.Result = ciResult(validation.err)
+	validation.Details = fmt.Sprintf("%d error(s), %d warning(s)", len(result.Errors), len(result.Warnings))
+	if validation.err != nil {
+		validation.Requirements = validationRequirements(file, result)
+	}
+	if counts := formatWarningCounts(result.Categorized); counts != "" {
+		validation.Details += " (" + counts + ")"
+	}
+	return validation
+}
+
+// checkStep checks file for circular references
+func checkStep(file string, annotations io.Writer) ciStep {
+	cycles := ciStep{Name: "check"}
+	result, err := checkCycles(file)
+	if err != nil {
+		cycles.Result, cycles.Details, cycles.err = "error", err.Error(), err
+		return cycles
+	}
+	writeCheckAnnotations(annotations, file, result, nil, nil, nil)
+	cycles.err = checkFailure(result, nil, nil, nil, 0)
+	cycles.Result = ciResult(cycles.err)
+	cycles.Details = "no circular references"
+	if result.HasCycles {
+		cycles.Details = fmt.Sprintf("%d circular reference(s)", len(result.Cycles))
+		for _, cycle := range result.Cycles {
+			cycles.Requirements = appendUnique(cycles.Requirements, cycle...)
+		}
+	}
+	return cycles
+}
+
+// coverageStep fails when fewer than minCoverage percent of the
+// requirements of file are verified
+func coverageStep(file string, annotations io.Writer, minCoverage float64) ciStep {
+	coverage := ciStep{Name: "coverage"}
+	config, _, err := loadRequirements(file)
+	if err != nil {
+		// Parse errors are already reported by validate
+		coverage.Result, coverage.Details = "skipped", "requirements could not be loaded"
+		return coverage
+	}
+	verified, total := verificationCoverage(config)
+	percent := 100.0
+	if total > 0 {
+		percent = float64(verified) * 100 / float64(total)
+	}
+	coverage.Details = fmt.Sprintf("%d/%d requirement(s) verified (%.0f%%)", verified, total, percent)
+	if percent < minCoverage {
+		failure := validationError("verification coverage %.0f%% is below the minimum of %.0f%%", percent, minCoverage)
+		coverage.err = applyFailOn(failure, 0)
+		coverage.Details += fmt.Sprintf(", below %.0f%%", minCoverage)
+		coverage.Requirements = unverifiedRequirements(config)
+		writeAnnotation(annotations, "error", file, 0, failure.Error())
+	}
+	coverage.Result = ciResult(coverage.err)
+	return coverage
+}
+
+// stepsFailure is the most severe error of steps, which sets the exit code
+func stepsFailure(steps []ciStep) error {
+	var failure error
+	for _, step := range steps {
+		if exitCode(step.err) > exitCode(failure) {
+			failure = step.err
+		}
+	}
+	return failure
+}
+
+func ciResult(err error) string {
+	if err != nil {
+		return "fail"
+	}
+	return "pass"
+}
+
+// verificationCoverage counts the requirements that say how they are
+// verified, out of all requirements
+func verificationCoverage(config *model.RequirementConfig) (verified, total int) {
+	for _, req := range config.Flatten() {
+		total++
+		if isVerified(req) {
+			verified++
+		}
+	}
+	return verified, total
+}
+
+// unverifiedRequirements names the requirements verificationCoverage
+// doesn't count as verified
+func unverifiedRequirements(config *model.RequirementConfig) []string {
+	var names []string
+	for _, req := range config.Flatten() {
+		if !isVerified(req) {
+			names = append(names, requirementLabel(req))
+		}
+	}
+	return names
+}
+
+// isVerified reports whether a requirement says how it is verified
+func isVerified(req *model.RequirementDetail) bool {
+	return req.Verification != "" || req.AcceptanceTest != "" || req.AcceptanceTestLink != "" || len(req.VerifiedBy) > 0
+}
+
+// validationRequirements names the requirements a failed validation is
+// about: those of its errors and, under --fail-on warning, its warnings
+func validationRequirements(file string, result *ValidationResult) []string {
+	var names []string
+	for _, msg := range localizeValidationErrors(file, result) {
+		if msg.Summary != "" {
+			names = appendUnique(names, msg.Summary)
+		}
+	}
+	if failOn == "warning" {
+		for _, w := range result.Categorized {
+			if w.Requirement != "" {
+				names = appendUnique(names, w.Requirement)
+			}
+		}
+	}
+	return names
+}
+
+// appendUnique appends the values not already in list
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// notifyCIFailure sends the failed steps to the project's notifications
+func notifyCIFailure(file string, steps []ciStep) {
+	violations := []GateViolation{}
+	for _, step := range steps {
+		if step.Result == "fail" || step.Result == "error" {
+			violations = append(violations, GateViolation{
+				Check:        step.Name,
+				Result:       step.Result,
+				Details:      step.Details,
+				Requirements: step.Requirements,
+			})
+		}
+	}
+	notifyGateFailure("ci", file, violations)
+}
+
+// writeCISummary prints the summary table for the job log
+func writeCISummary(w io.Writer, steps []ciStep) {
+	width := 10
+	for _, step := range steps {
+		width = max(width, len(step.Name))
+	}
+	fmt.Fprintf(w, "%-*s %-8s %s\n", width, "Step", "Result", "Details")
+	fmt.Fprintln(w, strings.Repeat("-", 60))
+	for _, step := range steps {
+		mark := okMark()
+		switch step.Result {
+		case "fail", "error":
+			mark = failMark()
+		case "skipped":
+			mark = warnMark()
+		}
+		fmt.Fprintf(w, "%-*s %s %-6s %s\n", width, step.Name, mark, step.Result, step.Details)
+	}
+}
+
+// appendCIStepSummary adds the summary as a markdown table to the GitHub
+// job summary file
+func appendCIStepSummary(path, file string, steps []ciStep) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### rqm ci: `%s`\n\n| Step | Result | Details |\n|------|--------|---------|\n", file)
+	for _, step := range steps {
+		icon := "✅"
+		switch step.Result {
+		case "fail", "error":
+			icon = "❌"
+		case "skipped":
+			icon = "⚠️"
+		}
+		fmt.Fprintf(&b, "| %s | %s %s | %s |\n", step.Name, icon, step.Result, markdownCell(step.Details))
+	}
+	b.WriteString("\n")
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open job summary %s: %w", path, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(b.String()); err != nil {
+		return fmt.Errorf("failed to write job summary %s: %w", path, err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(ciCmd)
+	ciCmd.Flags().StringVarP(&ciFormat, "format", "f", "", "Output format (text, github; default github under GitHub Actions)")
+	ciCmd.Flags().Float64Var(&ciMinCoverage, "min-coverage", 0, "Fail when fewer than this percentage of requirements are verified")
+	ciCmd.Flags().BoolVar(&ciNotify, "notify", false, "Report a failure to the notifications configured in .rqm/config.yml")
+	ciCmd.Flags().StringVar(&failOn, "fail-on", "error", "Lowest severity that fails the command (error, warning, none)")
+	ciCmd.Flags().StringSliceVar(&warnCategories, "warn", nil, "Warning categories to enable (style, completeness, consistency, traceability, all)")
+	ciCmd.Flags().StringSliceVar(&noWarnCategories, "no-warn", nil, "Warning categories to disable (style, completeness, consistency, traceability, all)")
+}
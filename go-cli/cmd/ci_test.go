@@ -0,0 +1,58 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestVerificationCoverage(t *testing.T) {
+	config := &model.RequirementConfig{Requirements: []model.RequirementDetail{
+		{Summary: "A", Verification: "test", Requirements: []model.RequirementReference{
+			{Full: &model.RequirementDetail{Summary: "A.1", AcceptanceTest: "It works"}},
+			{Full: &model.RequirementDetail{Summary: "A.2"}},
+			{Reference: "B"},
+		}},
+		{Summary: "B", VerifiedBy: []string{"tests/b_test.go"}},
+	}}
+
+	verified, total := verificationCoverage(config)
+	if verified != 3 || total != 4 {
+		t.Errorf("verificationCoverage() = %d/%d, want 3/4", verified, total)
+	}
+}
+
+func TestCISummary(t *testing.T) {
+	steps := []ciStep{
+		{Name: "validate", Result: "pass", Details: "0 error(s), 1 warning(s)"},
+		{Name: "check", Result: "fail", Details: "1 circular reference(s)", err: cycleError("cycles")},
+		{Name: "coverage", Result: "skipped", Details: "requirements | could not be loaded"},
+	}
+
+	var b strings.Builder
+	writeCISummary(&b, steps)
+	for _, want := range []string{"validate   ✓ pass   0 error(s)", "check      ✗ fail   1 circular"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("Summary missing %q:\n%s", want, b.String())
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "summary.md")
+	os.WriteFile(path, []byte("# Earlier step\n"), 0644)
+	if err := appendCIStepSummary(path, "r.yml", steps); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{"# Earlier step\n", "| check | ❌ fail | 1 circular reference(s) |", `requirements \| could not be loaded`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Job summary missing %q:\n%s", want, data)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+var (
+	colorMode string
+
+	// colorEnabled is resolved from --color, NO_COLOR and the terminal
+	// before any command runs
+	colorEnabled bool
+)
+
+// ANSI SGR codes used for terminal output
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiDim    = "\033[2m"
+	ansiItalic = "\033[3m"
+	ansiUnder  = "\033[4m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiBlue   = "\033[34m"
+	ansiCyan   = "\033[36m"
+)
+
+// configureColor decides whether output is colored. In auto mode color is
+// used only when stdout is a terminal, NO_COLOR is unset and TERM isn't dumb.
+func configureColor(mode string) error {
+	switch mode {
+	case "always":
+		colorEnabled = true
+	case "never":
+		colorEnabled = false
+	case "auto", "":
+		colorEnabled = os.Getenv("NO_COLOR") == "" &&
+			os.Getenv("TERM") != "dumb" &&
+			stdoutTerminalWidth() > 0
+	default:
+		return fmt.Errorf("invalid --color value: %s (expected auto, always or never)", mode)
+	}
+	return nil
+}
+
+// paint wraps s in the given ANSI code when color is enabled
+func paint(code, s string) string {
+	if !colorEnabled || code == "" || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// okMark, failMark and warnMark are the result symbols used by validate and check
+func okMark() string   { return paint(ansiGreen, "✓") }
+func failMark() string { return paint(ansiRed, "✗") }
+func warnMark() string { return paint(ansiYellow, "⚠") }
+
+// statusColor returns the color for a requirement status
+func statusColor(status string) string {
+	switch status {
+	case "implemented", "verified":
+		return ansiGreen
+	case "approved":
+		return ansiCyan
+	case "proposed":
+		return ansiYellow
+	case "draft":
+		return ansiDim
+	default:
+		return ""
+	}
+}
+
+// priorityColor returns the color for a requirement priority
+func priorityColor(priority string) string {
+	switch priority {
+	case "critical":
+		return ansiBold + ansiRed
+	case "high":
+		return ansiRed
+	case "medium":
+		return ansiYellow
+	case "low":
+		return ansiBlue
+	default:
+		return ""
+	}
+}
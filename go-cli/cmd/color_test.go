@@ -0,0 +1,96 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestConfigureColor(t *testing.T) {
+	defer func() { colorEnabled = false }()
+
+	tests := []struct {
+		name    string
+		mode    string
+		noColor string
+		want    bool
+		wantErr bool
+	}{
+		{name: "always", mode: "always", want: true},
+		{name: "always ignores NO_COLOR", mode: "always", noColor: "1", want: true},
+		{name: "never", mode: "never", want: false},
+		// Test stdout is never a terminal, so auto resolves to off
+		{name: "auto without terminal", mode: "auto", want: false},
+		{name: "auto with NO_COLOR", mode: "auto", noColor: "1", want: false},
+		{name: "invalid", mode: "sometimes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			err := configureColor(tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("configureColor(%q) error = %v, wantErr %v", tt.mode, err, tt.wantErr)
+			}
+			if !tt.wantErr && colorEnabled != tt.want {
+				t.Errorf("configureColor(%q) enabled = %v, want %v", tt.mode, colorEnabled, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaint(t *testing.T) {
+	defer func() { colorEnabled = false }()
+
+	colorEnabled = false
+	if got := paint(ansiRed, "x"); got != "x" {
+		t.Errorf("paint with color disabled = %q, want plain", got)
+	}
+
+	colorEnabled = true
+	if got := paint(ansiRed, "x"); got != ansiRed+"x"+ansiReset {
+		t.Errorf("paint with color enabled = %q", got)
+	}
+	if got := paint("", "x"); got != "x" {
+		t.Errorf("paint without a code = %q, want plain", got)
+	}
+	if got := getPriorityIndicator("high"); got != ansiRed+"(high)"+ansiReset {
+		t.Errorf("getPriorityIndicator(high) = %q", got)
+	}
+}
+
+func TestTableColorKeepsAlignment(t *testing.T) {
+	defer func() { colorEnabled, listColumns = false, nil }()
+
+	colorEnabled = true
+	listColumns = []string{"status", "id"}
+	table, err := newRequirementTable()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	reqs := []*model.RequirementDetail{{Name: "A", Status: "implemented"}, {Name: "B", Status: "draft"}}
+	table.fit(reqs, 80)
+
+	var buf bytes.Buffer
+	for _, req := range reqs {
+		table.printRow(&buf, req)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	// The padding sits inside the color codes, so the id column lines up
+	// once the escape sequences are stripped
+	for i, line := range lines {
+		plain := line
+		for _, code := range []string{ansiReset, ansiGreen, ansiDim} {
+			plain = strings.ReplaceAll(plain, code, "")
+		}
+		if len(plain) != 13 || plain[12:] != reqs[i].Name {
+			t.Errorf("Misaligned row: %q", plain)
+		}
+	}
+}
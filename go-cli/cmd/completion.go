@@ -0,0 +1,74 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// completeRequirementIDs is a cobra ValidArgsFunction for commands that take
+// requirement IDs. Candidates come from the nearest .rqm/requirements.yml
+// and are offered as "ID<tab>summary" so shells can show what each is.
+// Requirements without an ID complete to their summary.
+func completeRequirementIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	file := findRequirementsFile(dir)
+	if file == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return requirementIDCandidates(file, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRequirementIDFlag completes a flag that names a requirement,
+// such as --parent or --root, from the requirements file given as the
+// first argument or with --file, or else the nearest .rqm/requirements.yml
+func completeRequirementIDFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return requirementIDCandidates(args[0], toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+	if flag := cmd.Flags().Lookup("file"); flag != nil && flag.Value.String() != "" {
+		return requirementIDCandidates(flag.Value.String(), toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeRequirementIDs(cmd, args, toComplete)
+}
+
+// requirementIDCandidates lists the requirements in file and the files it
+// includes whose ID or summary starts with prefix, ignoring case. The files
+// are parsed natively so completion stays fast and works without the
+// validator.
+func requirementIDCandidates(file, prefix string) []string {
+	config, err := model.Load(file)
+	if err != nil {
+		return nil
+	}
+
+	prefix = strings.ToLower(prefix)
+	var candidates []string
+	for _, req := range config.Flatten() {
+		if req.Name != "" && strings.HasPrefix(strings.ToLower(req.Name), prefix) {
+			candidates = append(candidates, fmt.Sprintf("%s\t%s", req.Name, req.Summary))
+		} else if req.Name == "" && strings.HasPrefix(strings.ToLower(req.Summary), prefix) {
+			candidates = append(candidates, req.Summary)
+		}
+	}
+	return candidates
+}
+
+// completeRequirementsFile is a cobra ValidArgsFunction for the [file]
+// argument, offering YAML files only
+func completeRequirementsFile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return []string{"yml", "yaml"}, cobra.ShellCompDirectiveFilterFileExt
+}
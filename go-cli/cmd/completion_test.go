@@ -0,0 +1,137 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCompleteRequirementIDs(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".rqm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `version: "1.0"
+requirements:
+  - summary: User Authentication
+    name: AUTH-001
+    requirements:
+      - summary: Password Hashing
+        name: AUTH-002
+  - summary: API Gateway
+    name: API-001
+  - summary: Audit logging
+`
+	if err := os.WriteFile(filepath.Join(root, ".rqm", "requirements.yml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(nested)
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"auth", []string{"AUTH-001\tUser Authentication", "AUTH-002\tPassword Hashing"}},
+		{"API", []string{"API-001\tAPI Gateway"}},
+		{"Au", []string{"AUTH-001\tUser Authentication", "AUTH-002\tPassword Hashing", "Audit logging"}},
+		{"zzz", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			got, directive := completeRequirementIDs(&cobra.Command{}, nil, tt.prefix)
+			if directive != cobra.ShellCompDirectiveNoFileComp {
+				t.Errorf("Unexpected directive %v", directive)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("completeRequirementIDs(%q) = %q, want %q", tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompleteRequirementIDsWithoutProject(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if got, _ := completeRequirementIDs(&cobra.Command{}, nil, ""); len(got) != 0 {
+		t.Errorf("Expected no candidates outside a project, got %q", got)
+	}
+}
+
+func TestCompleteCommand(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".rqm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `version: "1.0"
+includes:
+  - api.yml
+requirements:
+  - summary: User Authentication
+    name: AUTH-001
+`
+	included := `version: "1.0"
+requirements:
+  - summary: API Gateway
+    name: API-001
+`
+	other := `version: "1.0"
+requirements:
+  - summary: Audit logging
+    name: AUD-001
+`
+	for name, data := range map[string]string{".rqm/requirements.yml": content, ".rqm/api.yml": included, "other.yml": other} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(root)
+	defer func() { draftFile = "" }()
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"show", ""}, []string{"AUTH-001\tUser Authentication", "API-001\tAPI Gateway"}},
+		{[]string{"show", "AP"}, []string{"API-001\tAPI Gateway"}},
+		{[]string{"show", "AUTH-001", ""}, []string{"yml", "yaml"}},
+		{[]string{"hash", "other.yml", "A"}, []string{"AUD-001\tAudit logging"}},
+		{[]string{"list", "--root", "AU"}, []string{"AUTH-001\tUser Authentication"}},
+		{[]string{"list", "other.yml", "--root", "AU"}, []string{"AUD-001\tAudit logging"}},
+		{[]string{"review", "--requirement", "API"}, []string{"API-001\tAPI Gateway"}},
+		{[]string{"draft", "--file", "other.yml", "--parent", ""}, []string{"AUD-001\tAudit logging"}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetArgs(append([]string{"__complete"}, tt.args...))
+			defer func() {
+				rootCmd.SetOut(nil)
+				rootCmd.SetArgs(nil)
+			}()
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+
+			// The last line is the directive, as ":4"
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			got := lines[:len(lines)-1]
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unexpected completions %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ProjectConfig mirrors .rqm/config.yml. The ID fields are shared with the
+// Rust metadata store; the remaining sections are read by the Go CLI.
+type ProjectConfig struct {
+	ProjectPrefix   string                `yaml:"project_prefix,omitempty"`
+	NextID          int                   `yaml:"next_id,omitempty"`
+	Attributes      []AttributeDefinition `yaml:"attributes,omitempty"`
+	Directory       *DirectoryConfig      `yaml:"directory,omitempty"`
+	Warnings        map[string]bool       `yaml:"warnings,omitempty"`
+	Aliases         map[string]string     `yaml:"aliases,omitempty"`
+	Offline         bool                  `yaml:"offline,omitempty"`
+	Notifications   []NotificationConfig  `yaml:"notifications,omitempty"`
+	MaxRequirements int                   `yaml:"max_requirements_per_file,omitempty"`
+	Confluence      *ConfluenceConfig     `yaml:"confluence,omitempty"`
+	Baselines       map[string]string     `yaml:"baselines,omitempty"`
+	LLM             *LLMConfig            `yaml:"llm,omitempty"`
+	Tags            []string              `yaml:"tags,omitempty"`
+
+	// path is the file the config was read from, empty for defaults
+	path string
+}
+
+// AttributeDefinition declares a custom field allowed under a requirement's attributes map
+type AttributeDefinition struct {
+	Name        string   `yaml:"name"`
+	Type        string   `yaml:"type"`
+	Values      []string `yaml:"values,omitempty"`
+	Required    bool     `yaml:"required,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+}
+
+// findProjectConfig walks up from dir looking for .rqm/config.yml
+func findProjectConfig(dir string) string {
+	return findInRQMDir(dir, "config.yml")
+}
+
+// requirementsFileNames are the names a project's requirements file can
+// have in its .rqm directory, in order of preference
+var requirementsFileNames = []string{"requirements.yml", "requirements.toml", "requirements.json"}
+
+// findRequirementsFile walks up from dir looking for the nearest
+// .rqm/requirements.yml, or its TOML or JSON counterpart
+func findRequirementsFile(dir string) string {
+	return findInRQMDir(dir, requirementsFileNames...)
+}
+
+// findInRQMDir walks up from dir looking for .rqm/<name>, also matching
+// <name> directly when dir is itself the .rqm directory. With several
+// names, the first that exists in the nearest directory wins.
+func findInRQMDir(dir string, names ...string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		var candidates []string
+		for _, name := range names {
+			if filepath.Base(dir) == ".rqm" {
+				candidates = append(candidates, filepath.Join(dir, name))
+			}
+		}
+		for _, name := range names {
+			candidates = append(candidates, filepath.Join(dir, ".rqm", name))
+		}
+		for _, candidate := range candidates {
+			if _, err := os.Stat(candidate); err == nil {
+				return candidate
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// loadProjectConfig reads the project config that applies to a requirements
+// file. A missing config is not an error and yields an empty config.
+func loadProjectConfig(requirementsFile string) (*ProjectConfig, error) {
+	path := findProjectConfig(filepath.Dir(requirementsFile))
+	if path == "" {
+		return &ProjectConfig{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var config ProjectConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid project config %s: %w", path, err)
+	}
+	config.path = path
+
+	return &config, nil
+}
@@ -0,0 +1,599 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var confluenceDryRun bool
+
+// confluenceTimeout bounds each Confluence API call
+const confluenceTimeout = 30 * time.Second
+
+// confluenceMappingFile is where, in the .rqm directory, the pages pushed
+// are recorded
+const confluenceMappingFile = "confluence.yml"
+
+// ConfluenceConfig is the confluence: section of .rqm/config.yml used by
+// rqm confluence push
+type ConfluenceConfig struct {
+	// URL is the base URL of the wiki, e.g. https://acme.atlassian.net/wiki
+	URL   string `yaml:"url"`
+	Space string `yaml:"space"`
+	// Parent is the ID of the page the requirements go under, by default
+	// the top of the space
+	Parent string `yaml:"parent,omitempty"`
+	// Title is the title of the page for the file, by default
+	// "Requirements: <file name>"
+	Title string `yaml:"title,omitempty"`
+	// UserEnv names the variable holding the account email for a
+	// Confluence Cloud API token; without it the token is sent as a bearer
+	// token, as Data Center personal access tokens are
+	UserEnv  string `yaml:"user_env,omitempty"`
+	TokenEnv string `yaml:"token_env"`
+}
+
+// confluenceMapping is .rqm/confluence.yml: the pages pushed for each
+// requirements file, relative to the directory holding .rqm
+type confluenceMapping struct {
+	Files map[string]*confluenceFilePages `yaml:"files"`
+}
+
+// confluenceFilePages are the pages of one requirements file. Pages are
+// keyed by requirement ID, or summary for requirements without one.
+type confluenceFilePages struct {
+	Root  confluencePageRef            `yaml:"root"`
+	Pages map[string]confluencePageRef `yaml:"pages,omitempty"`
+}
+
+// confluencePageRef is a page pushed
+type confluencePageRef struct {
+	ID string `yaml:"id"`
+	// Hash is the SHA-256 of the title, parent and body last pushed, so an
+	// unchanged page doesn't get a new version
+	Hash string `yaml:"hash"`
+}
+
+// confluencePage is the content of a page to push
+type confluencePage struct {
+	title  string
+	parent string
+	body   string
+}
+
+// hash identifies the content of p for confluencePageRef.Hash
+func (p confluencePage) hash() string {
+	sum := sha256.Sum256([]byte(p.title + "\x00" + p.parent + "\x00" + p.body))
+	return hex.EncodeToString(sum[:])
+}
+
+// confluencePush counts what a push did to the pages of a file
+type confluencePush struct {
+	created, updated, unchanged int
+	// orphaned are the keys of pages pushed before for requirements the
+	// file no longer has
+	orphaned []string
+}
+
+var confluenceCmd = &cobra.Command{
+	Use:   "confluence",
+	Short: "Publish requirements to Confluence",
+}
+
+var confluencePushCmd = &cobra.Command{
+	Use:   "push [file]",
+	Short: "Create or update a Confluence page for every requirement",
+	Long: `Render a requirements file to Confluence storage format and publish it
+as a page hierarchy: a page for the file, with a page under it for every
+requirement, nested like the requirement tree. Each page shows the
+requirement's fields, its text and links to the requirements it relates
+to.
+
+The target is the confluence: section of .rqm/config.yml:
+
+  confluence:
+    url: https://acme.atlassian.net/wiki
+    space: REQ
+    parent: "123456"          # optional page to publish under
+    user_env: CONFLUENCE_USER # account email, for Confluence Cloud
+    token_env: CONFLUENCE_TOKEN
+
+The IDs of the pages created are kept in .rqm/confluence.yml, so a later
+push updates those pages instead of creating new ones; commit it alongside
+the requirements. Pages whose content hasn't changed are left alone, and
+a page deleted in Confluence is created again. Pages of requirements that
+were removed are reported but not deleted. Edits made in Confluence are
+overwritten by the next push.
+
+--dry-run lists the pages that would be created or updated without
+contacting Confluence.`,
+	Example: `  rqm confluence push
+  rqm confluence push requirements.yml --dry-run`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		project, err := loadProjectConfig(file)
+		if err != nil {
+			return err
+		}
+		if project.Confluence == nil {
+			return fmt.Errorf("no confluence: section in .rqm/config.yml")
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+
+		mappingPath, key := confluenceMappingLocation(file)
+		mapping, err := loadConfluenceMapping(mappingPath)
+		if err != nil {
+			return err
+		}
+		pages := mapping.Files[key]
+		if pages == nil {
+			pages = &confluenceFilePages{}
+			mapping.Files[key] = pages
+		}
+
+		if confluenceDryRun {
+			writeConfluencePlan(os.Stdout, project.Confluence, file, config, pages)
+			return nil
+		}
+		if err := requireOnline("confluence push", project); err != nil {
+			return err
+		}
+		client, err := newConfluenceClient(project.Confluence)
+		if err != nil {
+			return err
+		}
+
+		push, pushErr := pushToConfluence(context.Background(), client, project.Confluence, file, config, pages)
+		// Pages created before a failure are recorded, so the next push
+		// doesn't create them again
+		if err := saveConfluenceMapping(mappingPath, mapping); err != nil {
+			return err
+		}
+		if pushErr != nil {
+			return pushErr
+		}
+		fmt.Printf("%s Pushed %s to Confluence: %d created, %d updated, %d unchanged\n", okMark(), file, push.created, push.updated, push.unchanged)
+		if len(push.orphaned) > 0 {
+			fmt.Printf("%s %d page(s) of requirements no longer in %s were left in place: %s\n", warnMark(), len(push.orphaned), file, strings.Join(push.orphaned, ", "))
+		}
+		return nil
+	},
+}
+
+// confluenceClient calls the Confluence REST API
+type confluenceClient struct {
+	baseURL string
+	user    string
+	token   string
+	space   string
+	client  *http.Client
+}
+
+// newConfluenceClient builds the client for a confluence config
+func newConfluenceClient(config *ConfluenceConfig) (*confluenceClient, error) {
+	if config.URL == "" || config.Space == "" {
+		return nil, fmt.Errorf("confluence needs url and space")
+	}
+	if config.TokenEnv == "" {
+		return nil, fmt.Errorf("confluence needs token_env")
+	}
+	token := os.Getenv(config.TokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("confluence token variable %s is not set", config.TokenEnv)
+	}
+	user := ""
+	if config.UserEnv != "" {
+		if user = os.Getenv(config.UserEnv); user == "" {
+			return nil, fmt.Errorf("confluence user variable %s is not set", config.UserEnv)
+		}
+	}
+	return &confluenceClient{
+		baseURL: strings.TrimSuffix(config.URL, "/"),
+		user:    user,
+		token:   token,
+		space:   config.Space,
+		client:  &http.Client{Timeout: confluenceTimeout},
+	}, nil
+}
+
+// do sends a request with a JSON body, when body isn't nil, and decodes a
+// 2xx response into v, when v isn't nil. 404 is returned without error
+// so callers can treat it as a missing page.
+func (c *confluenceClient) do(ctx context.Context, method, path string, body, v interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("confluence request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return resp.StatusCode, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return resp.StatusCode, fmt.Errorf("confluence answered %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return resp.StatusCode, fmt.Errorf("invalid confluence response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// content is the API representation of p, at version when updating
+func (c *confluenceClient) content(p confluencePage, id string, version int) map[string]interface{} {
+	content := map[string]interface{}{
+		"type":  "page",
+		"title": p.title,
+		"space": map[string]string{"key": c.space},
+		"body": map[string]interface{}{
+			"storage": map[string]string{"value": p.body, "representation": "storage"},
+		},
+	}
+	if p.parent != "" {
+		content["ancestors"] = []map[string]string{{"id": p.parent}}
+	}
+	if id != "" {
+		content["id"] = id
+		content["version"] = map[string]int{"number": version}
+	}
+	return content
+}
+
+// pageVersion returns the current version of page id, or false when the
+// page no longer exists
+func (c *confluenceClient) pageVersion(ctx context.Context, id string) (int, bool, error) {
+	var page struct {
+		Version struct {
+			Number int `json:"number"`
+		} `json:"version"`
+	}
+	status, err := c.do(ctx, http.MethodGet, "/rest/api/content/"+id+"?expand=version", nil, &page)
+	if err != nil || status == http.StatusNotFound {
+		return 0, false, err
+	}
+	return page.Version.Number, true, nil
+}
+
+// createPage creates p and returns its ID
+func (c *confluenceClient) createPage(ctx context.Context, p confluencePage) (string, error) {
+	var created struct {
+		ID string `json:"id"`
+	}
+	if _, err := c.do(ctx, http.MethodPost, "/rest/api/content", c.content(p, "", 0), &created); err != nil {
+		return "", fmt.Errorf("failed to create page %q: %w", p.title, err)
+	}
+	return created.ID, nil
+}
+
+// updatePage replaces page id with p as the given version
+func (c *confluenceClient) updatePage(ctx context.Context, id string, version int, p confluencePage) error {
+	if _, err := c.do(ctx, http.MethodPut, "/rest/api/content/"+id, c.content(p, id, version), nil); err != nil {
+		return fmt.Errorf("failed to update page %q: %w", p.title, err)
+	}
+	return nil
+}
+
+// pushToConfluence creates or updates the page of file and of each of its
+// requirements, recording their IDs in pages as it goes
+func pushToConfluence(ctx context.Context, client *confluenceClient, target *ConfluenceConfig, file string, config *model.RequirementConfig, pages *confluenceFilePages) (*confluencePush, error) {
+	push := &confluencePush{}
+	ensure := func(ref *confluencePageRef, p confluencePage) error {
+		hash := p.hash()
+		if ref.ID != "" {
+			if ref.Hash == hash {
+				push.unchanged++
+				return nil
+			}
+			version, found, err := client.pageVersion(ctx, ref.ID)
+			if err != nil {
+				return err
+			}
+			if found {
+				if err := client.updatePage(ctx, ref.ID, version+1, p); err != nil {
+					return err
+				}
+				ref.Hash = hash
+				push.updated++
+				return nil
+			}
+		}
+		id, err := client.createPage(ctx, p)
+		if err != nil {
+			return err
+		}
+		ref.ID, ref.Hash = id, hash
+		push.created++
+		return nil
+	}
+
+	if err := ensure(&pages.Root, confluenceRootPage(target, file, target.Parent)); err != nil {
+		return push, err
+	}
+	if pages.Pages == nil {
+		pages.Pages = make(map[string]confluencePageRef)
+	}
+	seen := make(map[string]bool)
+	var walk func(reqs []*model.RequirementDetail, parent string) error
+	walk = func(reqs []*model.RequirementDetail, parent string) error {
+		for _, req := range reqs {
+			key := confluencePageKey(req)
+			seen[key] = true
+			ref := pages.Pages[key]
+			err := ensure(&ref, confluenceRequirementPage(config, req, parent))
+			if ref.ID != "" {
+				pages.Pages[key] = ref
+			}
+			if err != nil {
+				return err
+			}
+			if err := walk(req.Children(), ref.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(config.Roots(), pages.Root.ID); err != nil {
+		return push, err
+	}
+
+	for _, key := range sortedKeys(pages.Pages) {
+		if !seen[key] {
+			push.orphaned = append(push.orphaned, key)
+		}
+	}
+	return push, nil
+}
+
+// writeConfluencePlan is push --dry-run: what a push would do to each
+// page, as far as .rqm/confluence.yml tells
+func writeConfluencePlan(w io.Writer, target *ConfluenceConfig, file string, config *model.RequirementConfig, pages *confluenceFilePages) {
+	action := func(ref confluencePageRef, p confluencePage) string {
+		switch {
+		case ref.ID == "":
+			return "create"
+		case ref.Hash == p.hash():
+			return "unchanged"
+		}
+		return "update"
+	}
+	root := confluenceRootPage(target, file, target.Parent)
+	fmt.Fprintf(w, "%-9s  %s\n", action(pages.Root, root), root.title)
+
+	var walk func(reqs []*model.RequirementDetail, parent, indent string)
+	walk = func(reqs []*model.RequirementDetail, parent, indent string) {
+		for _, req := range reqs {
+			ref := pages.Pages[confluencePageKey(req)]
+			p := confluenceRequirementPage(config, req, parent)
+			fmt.Fprintf(w, "%-9s  %s%s\n", action(ref, p), indent, p.title)
+			walk(req.Children(), ref.ID, indent+"  ")
+		}
+	}
+	walk(config.Roots(), pages.Root.ID, "  ")
+}
+
+// confluencePageKey is the key of a requirement's page in the mapping
+func confluencePageKey(req *model.RequirementDetail) string {
+	if req.Name != "" {
+		return req.Name
+	}
+	return req.Summary
+}
+
+// confluenceRootPage is the page for file itself, which the requirement
+// pages go under
+func confluenceRootPage(target *ConfluenceConfig, file, parent string) confluencePage {
+	title := target.Title
+	if title == "" {
+		title = "Requirements: " + filepath.Base(file)
+	}
+	body := fmt.Sprintf("<p>Requirements from <code>%s</code>, published by rqm confluence push. Changes made here are overwritten by the next push.</p>\n", html.EscapeString(filepath.Base(file))) +
+		`<ac:structured-macro ac:name="children"><ac:parameter ac:name="depth">1</ac:parameter></ac:structured-macro>` + "\n"
+	return confluencePage{title: title, parent: parent, body: body}
+}
+
+// confluenceRequirementPage renders req as a page under parent, in
+// Confluence storage format
+func confluenceRequirementPage(config *model.RequirementConfig, req *model.RequirementDetail, parent string) confluencePage {
+	var b strings.Builder
+	rows := [][2]string{
+		{"ID", req.Name},
+		{"Status", req.Status},
+		{"Priority", req.Priority},
+		{"Owner", displayOwner(config.Aliases, req.Owner)},
+		{"Tags", strings.Join(req.Tags, ", ")},
+		{"Verification", req.Verification},
+		{"Verified by", strings.Join(req.VerifiedBy, ", ")},
+	}
+	for _, name := range sortedKeys(req.Attributes) {
+		rows = append(rows, [2]string{name, fmt.Sprint(req.Attributes[name])})
+	}
+	b.WriteString("<table><tbody>\n")
+	for _, row := range rows {
+		if row[1] != "" {
+			fmt.Fprintf(&b, "<tr><th>%s</th><td>%s</td></tr>\n", html.EscapeString(row[0]), html.EscapeString(row[1]))
+		}
+	}
+	b.WriteString("</tbody></table>\n")
+
+	for _, section := range []struct{ heading, text string }{
+		{"Description", req.Description},
+		{"Justification", req.Justification},
+		{"Acceptance test", req.AcceptanceTest},
+	} {
+		if section.text != "" {
+			fmt.Fprintf(&b, "<h2>%s</h2>\n%s", section.heading, confluenceParagraphs(section.text))
+		}
+	}
+	if req.AcceptanceTestLink != "" || len(req.FurtherInformation) > 0 {
+		b.WriteString("<h2>Links</h2>\n<ul>\n")
+		for _, link := range append([]string{req.AcceptanceTestLink}, req.FurtherInformation...) {
+			if link != "" {
+				fmt.Fprintf(&b, "<li>%s</li>\n", confluenceLink(link))
+			}
+		}
+		b.WriteString("</ul>\n")
+	}
+
+	// Related requirements link to their pages by title
+	var related []string
+	for _, rel := range req.Relations {
+		related = append(related, html.EscapeString(strings.ReplaceAll(rel.Type, "_", " "))+" "+confluencePageLink(config, rel.Target))
+	}
+	for _, child := range req.Requirements {
+		switch {
+		case child.External != nil:
+			related = append(related, "includes "+html.EscapeString(child.External.String()))
+		case child.Full == nil:
+			related = append(related, "includes "+confluencePageLink(config, child.Reference))
+		}
+	}
+	if len(related) > 0 {
+		b.WriteString("<h2>Related requirements</h2>\n<ul>\n")
+		for _, r := range related {
+			fmt.Fprintf(&b, "<li>%s</li>\n", r)
+		}
+		b.WriteString("</ul>\n")
+	}
+	if len(req.Children()) > 0 {
+		b.WriteString("<h2>Sub-requirements</h2>\n")
+		b.WriteString(`<ac:structured-macro ac:name="children"><ac:parameter ac:name="depth">1</ac:parameter></ac:structured-macro>` + "\n")
+	}
+	return confluencePage{title: displayName(req), parent: parent, body: b.String()}
+}
+
+// confluenceParagraphs renders text as paragraphs, one per block separated
+// by a blank line, keeping single line breaks
+func confluenceParagraphs(text string) string {
+	var b strings.Builder
+	for _, block := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if block = strings.TrimSpace(block); block != "" {
+			lines := strings.Split(block, "\n")
+			for i, line := range lines {
+				lines[i] = html.EscapeString(line)
+			}
+			fmt.Fprintf(&b, "<p>%s</p>\n", strings.Join(lines, "<br />"))
+		}
+	}
+	return b.String()
+}
+
+// confluenceLink renders a URL as a link, and anything else as code
+func confluenceLink(s string) string {
+	if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+		return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(s), html.EscapeString(s))
+	}
+	return "<code>" + html.EscapeString(s) + "</code>"
+}
+
+// confluencePageLink links to the page of the requirement ref names, or
+// shows ref as text when there is no such requirement
+func confluencePageLink(config *model.RequirementConfig, ref string) string {
+	req, ok := config.Find(ref)
+	if !ok {
+		return html.EscapeString(ref)
+	}
+	return fmt.Sprintf(`<ac:link><ri:page ri:content-title="%s" /></ac:link>`, html.EscapeString(displayName(req)))
+}
+
+// confluenceMappingLocation returns the mapping file for a requirements
+// file, in the nearest .rqm directory or a new one beside the file, and
+// the file's key in it
+func confluenceMappingLocation(file string) (string, string) {
+	rqmDir := findInRQMDir(filepath.Dir(file), "")
+	if rqmDir == "" {
+		rqmDir = filepath.Join(filepath.Dir(file), ".rqm")
+	}
+	key := filepath.Base(file)
+	if rel, err := filepath.Rel(filepath.Dir(canonicalPath(rqmDir)), canonicalPath(file)); err == nil {
+		key = filepath.ToSlash(rel)
+	}
+	return filepath.Join(rqmDir, confluenceMappingFile), key
+}
+
+// loadConfluenceMapping reads the mapping at path; a missing one is empty
+func loadConfluenceMapping(path string) (*confluenceMapping, error) {
+	mapping := &confluenceMapping{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if mapping.Files == nil {
+		mapping.Files = make(map[string]*confluenceFilePages)
+	}
+	return mapping, nil
+}
+
+// saveConfluenceMapping writes the mapping to path
+func saveConfluenceMapping(path string, mapping *confluenceMapping) error {
+	data, err := yaml.Marshal(mapping)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	header := "# Confluence pages written by rqm confluence push; commit this file so\n# later pushes update the same pages.\n"
+	if err := os.WriteFile(path, append([]byte(header), data...), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(confluenceCmd)
+	confluenceCmd.AddCommand(confluencePushCmd)
+	confluencePushCmd.Flags().BoolVar(&confluenceDryRun, "dry-run", false, "List the pages that would be created or updated without pushing")
+}
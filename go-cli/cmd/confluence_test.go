@@ -0,0 +1,189 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// fakeConfluence keeps pages in memory behind the content REST API
+type fakeConfluence struct {
+	pages map[string]*fakePage
+	// writes counts the pages created or updated
+	writes  int
+	created int
+}
+
+type fakePage struct {
+	title, parent, body string
+	version             int
+}
+
+func (f *fakeConfluence) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer secret" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	var content struct {
+		Title     string              `json:"title"`
+		Ancestors []map[string]string `json:"ancestors"`
+		Version   struct {
+			Number int `json:"number"`
+		} `json:"version"`
+		Body struct {
+			Storage struct {
+				Value string `json:"value"`
+			} `json:"storage"`
+		} `json:"body"`
+	}
+	if r.Method != http.MethodGet {
+		json.NewDecoder(r.Body).Decode(&content)
+	}
+	page := &fakePage{title: content.Title, body: content.Body.Storage.Value, version: 1}
+	if len(content.Ancestors) > 0 {
+		page.parent = content.Ancestors[0]["id"]
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/rest/api/content/")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/rest/api/content":
+		f.created++
+		id = fmt.Sprint(f.created + 100)
+		f.pages[id] = page
+		f.writes++
+		json.NewEncoder(w).Encode(map[string]string{"id": id})
+	case f.pages[id] == nil:
+		http.NotFound(w, r)
+	case r.Method == http.MethodGet:
+		fmt.Fprintf(w, `{"id":%q,"version":{"number":%d}}`, id, f.pages[id].version)
+	case r.Method == http.MethodPut:
+		if content.Version.Number != f.pages[id].version+1 {
+			http.Error(w, "version conflict", http.StatusConflict)
+			return
+		}
+		page.version = content.Version.Number
+		f.pages[id] = page
+		f.writes++
+	}
+}
+
+func TestPushToConfluence(t *testing.T) {
+	fake := &fakeConfluence{pages: make(map[string]*fakePage)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	t.Setenv("TEST_CONFLUENCE_TOKEN", "secret")
+	target := &ConfluenceConfig{URL: server.URL, Space: "REQ", Parent: "1", TokenEnv: "TEST_CONFLUENCE_TOKEN"}
+	client, err := newConfluenceClient(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	load := func(yaml string) *model.RequirementConfig {
+		config, err := model.ParseYAML([]byte(yaml))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return config
+	}
+	config := load(`version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    description: "Users sign in with <email> & password"
+    relations:
+      - type: depends_on
+        target: Password hashing
+    requirements:
+      - summary: Password hashing
+  - summary: Audit log
+`)
+	pages := &confluenceFilePages{}
+	push, err := pushToConfluence(context.Background(), client, target, "requirements.yml", config, pages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if push.created != 4 || len(fake.pages) != 4 {
+		t.Fatalf("Expected the file page and 3 requirement pages, got %+v", push)
+	}
+	root := fake.pages[pages.Root.ID]
+	login := fake.pages[pages.Pages["AUTH-001"].ID]
+	hashing := fake.pages[pages.Pages["Password hashing"].ID]
+	if root.title != "Requirements: requirements.yml" || root.parent != "1" {
+		t.Errorf("Unexpected file page %+v", root)
+	}
+	if login.title != "[AUTH-001] Login" || login.parent != pages.Root.ID || hashing.parent != pages.Pages["AUTH-001"].ID {
+		t.Errorf("Expected pages nested like the tree, got %+v and %+v", login, hashing)
+	}
+	for _, want := range []string{"&lt;email&gt; &amp; password", `depends on <ac:link><ri:page ri:content-title="Password hashing" /></ac:link>`, `ac:name="children"`} {
+		if !strings.Contains(login.body, want) {
+			t.Errorf("Expected %q in the page:\n%s", want, login.body)
+		}
+	}
+
+	// A second push only touches what changed
+	fake.writes = 0
+	config = load(`version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    description: "Users sign in with <email> & password"
+    relations:
+      - type: depends_on
+        target: Password hashing
+    requirements:
+      - summary: Password hashing
+        status: approved
+`)
+	push, err = pushToConfluence(context.Background(), client, target, "requirements.yml", config, pages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if push.updated != 1 || push.unchanged != 2 || fake.writes != 1 || hashing.version != 1 || fake.pages[pages.Pages["Password hashing"].ID].version != 2 {
+		t.Errorf("Expected only the edited page to get a new version, got %+v", push)
+	}
+	if strings.Join(push.orphaned, ",") != "Audit log" {
+		t.Errorf("Expected the removed requirement's page to be reported, got %v", push.orphaned)
+	}
+
+	// A page deleted in Confluence is created again
+	delete(fake.pages, pages.Pages["Password hashing"].ID)
+	pages.Pages["Password hashing"] = confluencePageRef{ID: pages.Pages["Password hashing"].ID}
+	if push, err = pushToConfluence(context.Background(), client, target, "requirements.yml", config, pages); err != nil || push.created != 1 {
+		t.Errorf("Expected the deleted page to be created again, got %+v, %v", push, err)
+	}
+}
+
+func TestConfluenceMapping(t *testing.T) {
+	dir := t.TempDir()
+	path, key := confluenceMappingLocation(filepath.Join(dir, "docs", "requirements.yml"))
+	if path != filepath.Join(dir, "docs", ".rqm", "confluence.yml") || key != "requirements.yml" {
+		t.Errorf("confluenceMappingLocation() = %s, %s", path, key)
+	}
+
+	mapping, err := loadConfluenceMapping(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping.Files[key] = &confluenceFilePages{Root: confluencePageRef{ID: "100", Hash: "abc"}, Pages: map[string]confluencePageRef{"AUTH-001": {ID: "101"}}}
+	if err := saveConfluenceMapping(path, mapping); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadConfluenceMapping(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pages := loaded.Files[key]; pages == nil || pages.Root.ID != "100" || pages.Pages["AUTH-001"].ID != "101" {
+		t.Errorf("Expected the mapping to round-trip, got %+v", loaded.Files)
+	}
+}
@@ -0,0 +1,294 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dedupeThreshold float64
+	dedupeSuggest   bool
+	dedupeFormat    string
+)
+
+// dedupeStopWords are left out of similarity: they appear in most
+// requirements and say nothing about what one is about
+var dedupeStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "can": true, "for": true, "from": true, "has": true, "have": true,
+	"in": true, "is": true, "it": true, "its": true, "must": true, "of": true, "on": true,
+	"or": true, "shall": true, "should": true, "that": true, "the": true, "their": true,
+	"this": true, "to": true, "when": true, "will": true, "with": true,
+}
+
+// DuplicatePair is two requirements whose summaries and descriptions are
+// at least --threshold similar
+type DuplicatePair struct {
+	A          DuplicateRequirement `json:"a"`
+	B          DuplicateRequirement `json:"b"`
+	Similarity float64              `json:"similarity"`
+	// Suggestion is the merge proposed with --suggest
+	Suggestion *MergeSuggestion `json:"suggestion,omitempty"`
+}
+
+// DuplicateRequirement identifies one requirement of a DuplicatePair
+type DuplicateRequirement struct {
+	Summary string `json:"summary"`
+	Name    string `json:"name,omitempty"`
+}
+
+// MergeSuggestion proposes keeping one requirement of a pair and
+// superseding the other with it
+type MergeSuggestion struct {
+	Keep      string `json:"keep"`
+	Supersede string `json:"supersede"`
+	// Move lists the fields the superseded requirement has that the kept
+	// one lacks
+	Move []string `json:"move,omitempty"`
+}
+
+var dedupeCmd = &cobra.Command{
+	Use:   "dedupe [file]",
+	Short: "Report requirements that are probably duplicates of each other",
+	Long: `Compare the summaries and descriptions of all requirements and report
+pairs that are at least --threshold similar, as probable duplicates.
+
+Similarity is the cosine of the requirements' word vectors: words are
+lowercased, common words such as "the" and "must" are dropped, and each
+word is weighted by how rare it is in the file (TF-IDF), so two
+requirements sharing "passkey" count for more than two sharing "user".
+Summary words count double. Everything runs locally; nothing is sent
+anywhere. Deprecated requirements are left out, as they have been dealt
+with already.
+
+With --suggest, each pair gets a merge proposal: keep the requirement
+further along its lifecycle (then the one with an ID, then the first in
+the file) and give the other superseded_by, moving over the fields only it
+has.`,
+	Example: `  rqm dedupe
+  rqm dedupe requirements.yml --threshold 0.6 --suggest
+  rqm dedupe requirements.yml --format json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		if dedupeThreshold <= 0 || dedupeThreshold > 1 {
+			return fmt.Errorf("invalid --threshold: %g (expected a similarity above 0, up to 1)", dedupeThreshold)
+		}
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist: %s", file)
+		}
+		config, err := model.Load(file)
+		if err != nil {
+			return err
+		}
+
+		pairs := findDuplicates(config.Flatten(), dedupeThreshold)
+		if dedupeSuggest {
+			for i := range pairs {
+				pairs[i].Suggestion = suggestMerge(config, pairs[i])
+			}
+		}
+
+		switch dedupeFormat {
+		case "json":
+			return writeJSON(DedupeOutput{
+				SchemaVersion: apiVersion,
+				File:          file,
+				Threshold:     dedupeThreshold,
+				Duplicates:    nonNil(pairs),
+			})
+		case "text":
+			displayDuplicates(file, pairs)
+			return nil
+		default:
+			return fmt.Errorf("unknown output format: %s", dedupeFormat)
+		}
+	},
+}
+
+// dedupeTokens splits text into lowercase words, without stop words and
+// with a plural s dropped so "requirement" and "requirements" match
+func dedupeTokens(text string) []string {
+	var tokens []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) < 2 || dedupeStopWords[word] {
+			continue
+		}
+		if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			word = word[:len(word)-1]
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// findDuplicates returns the pairs of active requirements at least
+// threshold similar, most similar first. Only requirements sharing a word
+// are compared, through an index of the requirements each word is in.
+func findDuplicates(reqs []*model.RequirementDetail, threshold float64) []DuplicatePair {
+	var docs []*model.RequirementDetail
+	var counts []map[string]float64
+	df := make(map[string]int)
+	for _, req := range reqs {
+		if req.IsDeprecated() {
+			continue
+		}
+		count := make(map[string]float64)
+		for _, t := range dedupeTokens(req.Summary) {
+			count[t] += 2
+		}
+		for _, t := range dedupeTokens(req.Description) {
+			count[t]++
+		}
+		if len(count) == 0 {
+			continue
+		}
+		for t := range count {
+			df[t]++
+		}
+		docs = append(docs, req)
+		counts = append(counts, count)
+	}
+
+	// Weigh words by TF-IDF and normalize, so dot products are cosines
+	type posting struct {
+		doc    int
+		weight float64
+	}
+	index := make(map[string][]posting)
+	for i, count := range counts {
+		var norm float64
+		for t, n := range count {
+			count[t] = n * (math.Log(float64(len(docs)+1)/float64(df[t]+1)) + 1)
+			norm += count[t] * count[t]
+		}
+		norm = math.Sqrt(norm)
+		for _, t := range sortedKeys(count) {
+			count[t] /= norm
+			index[t] = append(index[t], posting{i, count[t]})
+		}
+	}
+
+	var pairs []DuplicatePair
+	for i, count := range counts {
+		scores := make(map[int]float64)
+		for t, weight := range count {
+			for _, p := range index[t] {
+				if p.doc > i {
+					scores[p.doc] += weight * p.weight
+				}
+			}
+		}
+		for j, score := range scores {
+			if score >= threshold-1e-9 {
+				pairs = append(pairs, DuplicatePair{
+					A:          DuplicateRequirement{Summary: docs[i].Summary, Name: docs[i].Name},
+					B:          DuplicateRequirement{Summary: docs[j].Summary, Name: docs[j].Name},
+					Similarity: math.Round(math.Min(score, 1)*100) / 100,
+				})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		a, b := pairs[i], pairs[j]
+		if a.Similarity != b.Similarity {
+			return a.Similarity > b.Similarity
+		}
+		return a.A.Summary+"\x00"+a.B.Summary < b.A.Summary+"\x00"+b.B.Summary
+	})
+	return pairs
+}
+
+// suggestMerge proposes which requirement of a pair to keep: the one
+// further along its lifecycle, then the one with an ID, then A, the first
+// in the file
+func suggestMerge(config *model.RequirementConfig, pair DuplicatePair) *MergeSuggestion {
+	a, okA := config.Find(pair.A.Summary)
+	b, okB := config.Find(pair.B.Summary)
+	if !okA || !okB {
+		return nil
+	}
+	keep, drop := a, b
+	stageA, stageB := slices.Index(requirementLifecycle, a.Status), slices.Index(requirementLifecycle, b.Status)
+	if stageB > stageA || stageB == stageA && a.Name == "" && b.Name != "" {
+		keep, drop = b, a
+	}
+
+	var move []string
+	for _, f := range []struct {
+		name      string
+		keep, has bool
+	}{
+		{"description", keep.Description != "", drop.Description != ""},
+		{"owner", keep.Owner != "", drop.Owner != ""},
+		{"acceptance_test", keep.AcceptanceTest != "" || keep.AcceptanceTestLink != "", drop.AcceptanceTest != "" || drop.AcceptanceTestLink != ""},
+		{"acceptance_criteria", len(keep.AcceptanceCriteria) > 0, len(drop.AcceptanceCriteria) > 0},
+		{"verified_by", len(keep.VerifiedBy) > 0, len(drop.VerifiedBy) > 0},
+		{"relations", false, len(drop.Relations) > 0},
+	} {
+		if f.has && !f.keep {
+			move = append(move, f.name)
+		}
+	}
+	for _, tag := range drop.Tags {
+		if !slices.Contains(keep.Tags, tag) {
+			move = append(move, "tag "+tag)
+		}
+	}
+	return &MergeSuggestion{Keep: requirementRef(keep), Supersede: requirementRef(drop), Move: move}
+}
+
+// requirementRef is how a requirement is referenced in superseded_by and
+// relations: by its name, or its summary when it has none
+func requirementRef(req *model.RequirementDetail) string {
+	if req.Name != "" {
+		return req.Name
+	}
+	return req.Summary
+}
+
+// displayDuplicates prints the probable duplicates for humans
+func displayDuplicates(file string, pairs []DuplicatePair) {
+	fmt.Printf("Checking %s for probable duplicates (similarity ≥ %.0f%%)...\n\n", file, dedupeThreshold*100)
+	if len(pairs) == 0 {
+		fmt.Println(okMark(), "No probable duplicates")
+		return
+	}
+	fmt.Printf("%s %d probable duplicate pair(s):\n", warnMark(), len(pairs))
+	for _, p := range pairs {
+		fmt.Printf("\n  %3.0f%%  %s\n", p.Similarity*100, displayName(&model.RequirementDetail{Name: p.A.Name, Summary: p.A.Summary}))
+		fmt.Printf("        %s\n", displayName(&model.RequirementDetail{Name: p.B.Name, Summary: p.B.Summary}))
+		if s := p.Suggestion; s != nil {
+			fmt.Printf("        → keep %s; give %s superseded_by: %s", s.Keep, s.Supersede, s.Keep)
+			if len(s.Move) > 0 {
+				fmt.Printf(", moving over its %s", strings.Join(s.Move, ", "))
+			}
+			fmt.Println()
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(dedupeCmd)
+	dedupeCmd.Flags().Float64Var(&dedupeThreshold, "threshold", 0.8, "Similarity from 0 to 1 at which requirements are reported")
+	dedupeCmd.Flags().BoolVar(&dedupeSuggest, "suggest", false, "Propose which requirement of each pair to keep and how to merge the other")
+	dedupeCmd.Flags().StringVarP(&dedupeFormat, "format", "f", "text", "Output format (text, json)")
+}
@@ -0,0 +1,57 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const dedupeYAML = `version: "1.0"
+requirements:
+  - summary: Users sign in with a passkey
+    description: Passkeys replace passwords for signing in.
+  - summary: User signs in using passkeys
+    name: AUTH-007
+    status: approved
+    tags: [auth]
+    description: Signing in with passkeys replaces passwords.
+  - summary: Export audit log as CSV
+  - summary: Users sign in with passkeys (old)
+    deprecated: true
+`
+
+func TestDedupeTokens(t *testing.T) {
+	got := strings.Join(dedupeTokens("The user MUST sign in with Passkeys, not passwords."), " ")
+	if got != "user sign passkey not password" {
+		t.Errorf("dedupeTokens() = %q", got)
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	config, err := model.ParseYAML([]byte(dedupeYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs := findDuplicates(config.Flatten(), 0.8)
+	if len(pairs) != 1 {
+		t.Fatalf("findDuplicates() = %+v, want one pair", pairs)
+	}
+	p := pairs[0]
+	if p.A.Summary != "Users sign in with a passkey" || p.B.Name != "AUTH-007" || p.Similarity < 0.8 || p.Similarity > 1 {
+		t.Errorf("pair = %+v", p)
+	}
+
+	s := suggestMerge(config, p)
+	if s == nil || s.Keep != "AUTH-007" || s.Supersede != "Users sign in with a passkey" || len(s.Move) != 0 {
+		t.Errorf("suggestMerge() = %+v, want to keep the approved AUTH-007", s)
+	}
+
+	if pairs := findDuplicates(config.Flatten(), 1); len(pairs) != 0 {
+		t.Errorf("threshold 1 reported %+v", pairs)
+	}
+}
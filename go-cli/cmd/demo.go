@@ -0,0 +1,416 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	demoCast        string
+	demoTypingDelay time.Duration
+	demoKeepSandbox bool
+)
+
+// demoFiles holds the built-in walkthrough used when no script is given
+//
+//go:embed demo/*
+var demoFiles embed.FS
+
+// builtinDemoScript is the script in demoFiles run by default
+const builtinDemoScript = "walkthrough.yml"
+
+// Timing of recorded casts. Casts are written with synthetic timing so a
+// recording doesn't take as long as it plays.
+const (
+	castTypingDelay = 40 * time.Millisecond
+	castStepPause   = 1500 * time.Millisecond
+	castHeight      = 30
+)
+
+// demoScript is a scripted walkthrough of rqm commands
+type demoScript struct {
+	Title string `yaml:"title"`
+	// Sandbox lists files and directories, relative to the script, that are
+	// copied into the scratch directory the steps run in
+	Sandbox []string   `yaml:"sandbox"`
+	Steps   []demoStep `yaml:"steps"`
+}
+
+// demoStep runs one rqm command line and checks what it printed
+type demoStep struct {
+	// Say is narration shown before the command
+	Say string `yaml:"say,omitempty"`
+	// Run is the command line without the leading "rqm"
+	Run    string     `yaml:"run"`
+	Expect demoExpect `yaml:"expect,omitempty"`
+}
+
+// demoExpect is what a step must produce to pass
+type demoExpect struct {
+	Exit int `yaml:"exit,omitempty"`
+	// Output lists text that must appear in the combined stdout and stderr
+	Output []string `yaml:"output,omitempty"`
+}
+
+// demoRunner runs rqm with args in dir and returns its combined output and
+// exit code. Tests replace it to avoid spawning processes.
+var demoRunner = runRQMProcess
+
+var demoCmd = &cobra.Command{
+	Use:   "demo",
+	Short: "Scripted walkthroughs of rqm for demos and training",
+}
+
+var demoRecordCmd = &cobra.Command{
+	Use:   "record [script]",
+	Short: "Replay a scripted walkthrough against a sandbox copy of the requirements",
+	Long: `Replay a scripted sequence of rqm commands, printing each one as if it
+were typed, and check every command's exit code and output.
+
+The files listed under sandbox: are copied to a scratch directory first,
+so the walkthrough never touches the originals. Without a script, a
+built-in walkthrough of validate, list and check runs against a bundled
+example, which doubles as an end-to-end smoke test of the installation.
+
+Script format:
+
+  title: Onboarding
+  sandbox:
+    - requirements.yml
+  steps:
+    - say: Validate the requirements file
+      run: validate requirements.yml
+      expect:
+        exit: 0
+        output:
+          - Validation successful
+
+With --cast the session is also written as an asciinema v2 recording.
+
+Exits with 1 when a step doesn't produce what the script expects.`,
+	Example: `  rqm demo record
+  rqm demo record onboarding.yml --cast onboarding.cast
+  rqm demo record onboarding.yml --typing-delay 50ms`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var base fs.FS
+		name := builtinDemoScript
+		if len(args) == 0 {
+			sub, err := fs.Sub(demoFiles, "demo")
+			if err != nil {
+				return fmt.Errorf("failed to access built-in demo: %w", err)
+			}
+			base = sub
+		} else {
+			base = os.DirFS(filepath.Dir(args[0]))
+			name = filepath.Base(args[0])
+		}
+
+		script, err := loadDemoScript(base, name)
+		if err != nil {
+			return err
+		}
+
+		sandbox, err := prepareSandbox(base, script.Sandbox)
+		if err != nil {
+			return err
+		}
+		if demoKeepSandbox {
+			fmt.Fprintf(os.Stderr, "Sandbox kept at %s\n", sandbox)
+		} else {
+			defer os.RemoveAll(sandbox)
+		}
+
+		var cast *castRecorder
+		if demoCast != "" {
+			f, err := os.Create(demoCast)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", demoCast, err)
+			}
+			defer f.Close()
+			if cast, err = newCastRecorder(f, script.Title, terminalWidth(), time.Now()); err != nil {
+				return err
+			}
+		}
+
+		return runDemo(script, sandbox, os.Stdout, cast)
+	},
+}
+
+// loadDemoScript reads and checks a demo script
+func loadDemoScript(base fs.FS, name string) (*demoScript, error) {
+	data, err := fs.ReadFile(base, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read demo script: %w", err)
+	}
+
+	var script demoScript
+	if err := yaml.Unmarshal(data, &script); err != nil {
+		return nil, fmt.Errorf("invalid demo script %s: %w", name, err)
+	}
+	if len(script.Steps) == 0 {
+		return nil, fmt.Errorf("demo script %s has no steps", name)
+	}
+	for i, step := range script.Steps {
+		if strings.TrimSpace(step.Run) == "" {
+			return nil, fmt.Errorf("demo script %s: step %d has nothing to run", name, i+1)
+		}
+		if _, err := splitCommandLine(step.Run); err != nil {
+			return nil, fmt.Errorf("demo script %s: step %d: %w", name, i+1, err)
+		}
+	}
+	return &script, nil
+}
+
+// prepareSandbox copies the sandbox paths from base into a new temporary
+// directory and returns its path
+func prepareSandbox(base fs.FS, paths []string) (string, error) {
+	dir, err := os.MkdirTemp("", "rqm-demo-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create sandbox: %w", err)
+	}
+
+	for _, p := range paths {
+		err := fs.WalkDir(base, filepath.ToSlash(filepath.Clean(p)), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			target := filepath.Join(dir, filepath.FromSlash(path))
+			if d.IsDir() {
+				return os.MkdirAll(target, 0755)
+			}
+			data, err := fs.ReadFile(base, path)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			return os.WriteFile(target, data, 0644)
+		})
+		if err != nil {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("failed to copy %s into sandbox: %w", p, err)
+		}
+	}
+	return dir, nil
+}
+
+// runDemo plays every step in sandbox, writing the session to out and, when
+// cast is set, to the recording. It stops at the first step that doesn't
+// meet its expectations.
+func runDemo(script *demoScript, sandbox string, out io.Writer, cast *castRecorder) error {
+	emit := func(s string, typed bool) {
+		if typed && demoTypingDelay > 0 {
+			for _, r := range s {
+				fmt.Fprint(out, string(r))
+				time.Sleep(demoTypingDelay)
+			}
+		} else {
+			fmt.Fprint(out, s)
+		}
+		if cast != nil {
+			cast.write(s, typed)
+		}
+	}
+
+	if script.Title != "" {
+		emit(paint(ansiBold, script.Title)+"\n\n", false)
+	}
+
+	for i, step := range script.Steps {
+		if step.Say != "" {
+			emit(paint(ansiDim, "# "+step.Say)+"\n", false)
+		}
+		emit("$ ", false)
+		emit("rqm "+step.Run, true)
+		emit("\n", false)
+
+		args, _ := splitCommandLine(step.Run)
+		output, code, err := demoRunner(sandbox, args)
+		if err != nil {
+			return fmt.Errorf("step %d (%s): %w", i+1, step.Run, err)
+		}
+		emit(string(output), false)
+
+		if problems := checkDemoStep(step.Expect, output, code); len(problems) > 0 {
+			fmt.Fprintf(out, "\n%s Step %d (rqm %s) did not go as scripted:\n", failMark(), i+1, step.Run)
+			for _, problem := range problems {
+				fmt.Fprintf(out, "  - %s\n", problem)
+			}
+			return validationError("demo failed at step %d of %d", i+1, len(script.Steps))
+		}
+		emit("\n", false)
+		if cast != nil {
+			cast.pause(castStepPause)
+		}
+	}
+
+	fmt.Fprintf(out, "%s All %d step(s) ran as scripted\n", okMark(), len(script.Steps))
+	return nil
+}
+
+// checkDemoStep lists how a step's result differs from what was expected
+func checkDemoStep(expect demoExpect, output []byte, code int) []string {
+	var problems []string
+	if code != expect.Exit {
+		problems = append(problems, fmt.Sprintf("exit code %d, expected %d", code, expect.Exit))
+	}
+	for _, want := range expect.Output {
+		if !bytes.Contains(output, []byte(want)) {
+			problems = append(problems, fmt.Sprintf("output does not contain %q", want))
+		}
+	}
+	return problems
+}
+
+// runRQMProcess runs this rqm binary in dir. Color is turned off so output
+// can be matched, and the validator found from the current directory is
+// passed on since the sandbox is outside the source tree.
+func runRQMProcess(dir string, args []string) ([]byte, int, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to locate rqm: %w", err)
+	}
+
+	c := exec.Command(self, args...)
+	c.Dir = dir
+	c.Env = append(os.Environ(), "NO_COLOR=1")
+	if validator := findValidatorBinary(); validator != "" {
+		c.Env = append(c.Env, "RQM_VALIDATOR="+validator)
+	}
+
+	output, err := c.CombinedOutput()
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		return output, exit.ExitCode(), nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return output, 0, nil
+}
+
+// splitCommandLine splits a command line into arguments, honouring single
+// and double quotes and backslash escapes outside single quotes
+func splitCommandLine(s string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in %q", s)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// castRecorder writes an asciinema v2 recording
+// (https://docs.asciinema.org/manual/asciicast/v2/)
+type castRecorder struct {
+	w       io.Writer
+	elapsed time.Duration
+}
+
+func newCastRecorder(w io.Writer, title string, width int, now time.Time) (*castRecorder, error) {
+	header, err := json.Marshal(map[string]interface{}{
+		"version":   2,
+		"width":     width,
+		"height":    castHeight,
+		"timestamp": now.Unix(),
+		"title":     title,
+		"env":       map[string]string{"TERM": "xterm-256color", "SHELL": "/bin/sh"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", header); err != nil {
+		return nil, fmt.Errorf("failed to write cast: %w", err)
+	}
+	return &castRecorder{w: w}, nil
+}
+
+// write records s as output. Typed text is recorded one character at a time.
+func (c *castRecorder) write(s string, typed bool) {
+	if !typed {
+		c.event(s)
+		return
+	}
+	for _, r := range s {
+		c.elapsed += castTypingDelay
+		c.event(string(r))
+	}
+}
+
+// pause advances the recording clock without output
+func (c *castRecorder) pause(d time.Duration) {
+	c.elapsed += d
+}
+
+func (c *castRecorder) event(s string) {
+	if s == "" {
+		return
+	}
+	// Terminals need a carriage return to go back to the first column
+	s = strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\n", "\r\n")
+	data, _ := json.Marshal([]interface{}{c.elapsed.Seconds(), "o", s})
+	fmt.Fprintf(c.w, "%s\n", data)
+}
+
+func init() {
+	rootCmd.AddCommand(demoCmd)
+	demoCmd.AddCommand(demoRecordCmd)
+	demoRecordCmd.Flags().StringVar(&demoCast, "cast", "", "Also write the session as an asciinema v2 recording to this file")
+	demoRecordCmd.Flags().DurationVar(&demoTypingDelay, "typing-delay", 0, "Delay between typed characters when playing live (e.g. 50ms)")
+	demoRecordCmd.Flags().BoolVar(&demoKeepSandbox, "keep-sandbox", false, "Keep the sandbox directory after the run and print its path")
+}
@@ -0,0 +1,162 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"validate requirements.yml", []string{"validate", "requirements.yml"}},
+		{"list  -f table\t--columns id,status", []string{"list", "-f", "table", "--columns", "id,status"}},
+		{`report approvals-pending r.yml --approver "Alice Developer"`, []string{"report", "approvals-pending", "r.yml", "--approver", "Alice Developer"}},
+		{`import 'my file.csv' --from doors-csv`, []string{"import", "my file.csv", "--from", "doors-csv"}},
+		{`list my\ file.yml ""`, []string{"list", "my file.yml", ""}},
+	}
+
+	for _, tt := range tests {
+		got, err := splitCommandLine(tt.in)
+		if err != nil {
+			t.Errorf("splitCommandLine(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitCommandLine(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	if _, err := splitCommandLine(`list "unterminated`); err == nil {
+		t.Error("Expected an unterminated quote to be rejected")
+	}
+}
+
+func TestBuiltinDemoScript(t *testing.T) {
+	base, err := fs.Sub(demoFiles, "demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, err := loadDemoScript(base, builtinDemoScript)
+	if err != nil {
+		t.Fatalf("Built-in demo script is invalid: %v", err)
+	}
+
+	sandbox, err := prepareSandbox(base, script.Sandbox)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sandbox)
+	for _, p := range script.Sandbox {
+		if _, err := os.Stat(filepath.Join(sandbox, p)); err != nil {
+			t.Errorf("Sandbox is missing %s: %v", p, err)
+		}
+	}
+}
+
+func TestLoadDemoScriptErrors(t *testing.T) {
+	base := fstest.MapFS{
+		"empty.yml":   {Data: []byte("title: Empty\n")},
+		"norun.yml":   {Data: []byte("steps:\n  - say: hello\n")},
+		"quoting.yml": {Data: []byte("steps:\n  - run: list \"oops\n")},
+	}
+	for name, want := range map[string]string{
+		"empty.yml":   "has no steps",
+		"norun.yml":   "nothing to run",
+		"quoting.yml": "unterminated",
+		"missing.yml": "failed to read",
+	} {
+		if _, err := loadDemoScript(base, name); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected error containing %q, got %v", name, want, err)
+		}
+	}
+}
+
+func TestRunDemo(t *testing.T) {
+	defer func(r func(string, []string) ([]byte, int, error)) { demoRunner = r }(demoRunner)
+
+	var ran [][]string
+	demoRunner = func(dir string, args []string) ([]byte, int, error) {
+		ran = append(ran, args)
+		if args[0] == "check" {
+			return []byte("Found 1 circular reference(s)\n"), exitCycles, nil
+		}
+		return []byte("Validation successful!\n"), 0, nil
+	}
+
+	script := &demoScript{
+		Title: "Demo",
+		Steps: []demoStep{
+			{Say: "Validate", Run: "validate r.yml", Expect: demoExpect{Output: []string{"Validation successful"}}},
+			{Run: "check r.yml", Expect: demoExpect{Exit: exitCycles, Output: []string{"circular"}}},
+		},
+	}
+
+	var out strings.Builder
+	if err := runDemo(script, t.TempDir(), &out, nil); err != nil {
+		t.Fatalf("Expected the demo to pass, got %v\n%s", err, out.String())
+	}
+	if len(ran) != 2 || !reflect.DeepEqual(ran[1], []string{"check", "r.yml"}) {
+		t.Errorf("Unexpected commands run: %q", ran)
+	}
+	for _, want := range []string{"# Validate", "$ rqm validate r.yml", "All 2 step(s) ran as scripted"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	script.Steps[1].Expect = demoExpect{Output: []string{"No circular references"}}
+	out.Reset()
+	err := runDemo(script, t.TempDir(), &out, nil)
+	if exitCode(err) != exitValidation {
+		t.Fatalf("Expected a failed step to exit %d, got %v", exitValidation, err)
+	}
+	for _, want := range []string{"Step 2 (rqm check r.yml)", "exit code 2, expected 0", `output does not contain "No circular references"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCastRecorder(t *testing.T) {
+	var b strings.Builder
+	cast, err := newCastRecorder(&b, "Demo", 80, time.Unix(1700000000, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cast.write("$ ", false)
+	cast.write("rqm", true)
+	cast.pause(time.Second)
+	cast.write("ok\n", false)
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	var header map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("Header is not JSON: %v", err)
+	}
+	if header["version"] != float64(2) || header["width"] != float64(80) || header["title"] != "Demo" {
+		t.Errorf("Unexpected header: %v", header)
+	}
+
+	// "$ ", one event per typed character, then the output
+	if len(lines) != 1+1+3+1 {
+		t.Fatalf("Expected 6 lines, got %d:\n%s", len(lines), b.String())
+	}
+	var last []interface{}
+	json.Unmarshal([]byte(lines[len(lines)-1]), &last)
+	if last[0] != 1.12 || last[1] != "o" || last[2] != "ok\r\n" {
+		t.Errorf("Unexpected last event: %v", last)
+	}
+}
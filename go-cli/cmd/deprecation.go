@@ -0,0 +1,120 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// deprecationErrors checks superseded_by and that no active requirement
+// references a deprecated one, by a child reference or a relation. A
+// requirement may still relate to the ones it supersedes.
+func deprecationErrors(config *model.RequirementConfig) []string {
+	var errs []string
+	all := config.Flatten()
+	for _, req := range all {
+		if req.SupersededBy == "" {
+			continue
+		}
+		target, ok := config.Find(req.SupersededBy)
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Sprintf("Requirement '%s' is superseded by '%s', which doesn't exist", req.Summary, req.SupersededBy))
+		case target == req:
+			errs = append(errs, fmt.Sprintf("Requirement '%s' is superseded by itself", req.Summary))
+		}
+	}
+
+	for _, req := range all {
+		if req.IsDeprecated() {
+			continue
+		}
+		var refs []string
+		for _, child := range req.Requirements {
+			if child.Full == nil && child.External == nil {
+				refs = append(refs, child.Reference)
+			}
+		}
+		for _, rel := range req.Relations {
+			refs = append(refs, rel.Target)
+		}
+		for _, ref := range refs {
+			target, ok := config.Find(ref)
+			if !ok || !target.IsDeprecated() || supersedes(config, req, target) {
+				continue
+			}
+			msg := fmt.Sprintf("Requirement '%s' references '%s', which is deprecated", req.Summary, target.Summary)
+			if target.SupersededBy != "" {
+				msg += fmt.Sprintf("; reference '%s' instead", target.SupersededBy)
+			}
+			errs = append(errs, msg)
+		}
+	}
+	return errs
+}
+
+// supersedes reports whether target's superseded_by names req
+func supersedes(config *model.RequirementConfig, req, target *model.RequirementDetail) bool {
+	if target.SupersededBy == "" {
+		return false
+	}
+	replacement, ok := config.Find(target.SupersededBy)
+	return ok && replacement == req
+}
+
+// hideDeprecated removes deprecated requirements, with their
+// sub-requirements, from config for list without --include-deprecated
+func hideDeprecated(config *model.RequirementConfig) {
+	kept := config.Requirements[:0]
+	for i := range config.Requirements {
+		if !config.Requirements[i].IsDeprecated() {
+			hideDeprecatedChildren(&config.Requirements[i])
+			kept = append(kept, config.Requirements[i])
+		}
+	}
+	config.Requirements = kept
+}
+
+// hideDeprecatedChildren removes the deprecated inline sub-requirements of
+// req, recursively
+func hideDeprecatedChildren(req *model.RequirementDetail) {
+	kept := req.Requirements[:0]
+	for _, child := range req.Requirements {
+		if child.Full != nil {
+			if child.Full.IsDeprecated() {
+				continue
+			}
+			hideDeprecatedChildren(child.Full)
+		}
+		kept = append(kept, child)
+	}
+	req.Requirements = kept
+}
+
+// deprecatedSummaries lists the deprecated requirements of config
+func deprecatedSummaries(config *model.RequirementConfig) []string {
+	var summaries []string
+	for _, req := range config.Flatten() {
+		if req.IsDeprecated() {
+			summaries = append(summaries, req.Summary)
+		}
+	}
+	return summaries
+}
+
+// deprecationNote marks a deprecated requirement in listings and graphs:
+// "(superseded by ...)", "(deprecated)", or "" for an active one
+func deprecationNote(req *model.RequirementDetail) string {
+	switch {
+	case req.SupersededBy != "":
+		return "(superseded by " + req.SupersededBy + ")"
+	case req.IsDeprecated():
+		return "(deprecated)"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,114 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const deprecationYAML = `version: "1.0"
+requirements:
+  - summary: Password login
+    name: AUTH-001
+    superseded_by: AUTH-010
+    requirements:
+      - summary: Password rules
+  - summary: Passkey login
+    name: AUTH-010
+    relations:
+      - type: derives_from
+        target: AUTH-001
+  - summary: Account recovery
+    relations:
+      - type: depends_on
+        target: Password login
+    requirements:
+      - Legacy SMS codes
+      - summary: Recovery email
+  - summary: Legacy SMS codes
+    deprecated: true
+  - summary: Remember me
+    status: deprecated
+    superseded_by: AUTH-999
+`
+
+func TestDeprecationErrors(t *testing.T) {
+	config, err := model.ParseYAML([]byte(deprecationYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := deprecationErrors(config)
+	want := []string{
+		"Requirement 'Remember me' is superseded by 'AUTH-999', which doesn't exist",
+		"Requirement 'Account recovery' references 'Legacy SMS codes', which is deprecated",
+		"Requirement 'Account recovery' references 'Password login', which is deprecated; reference 'AUTH-010' instead",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("deprecationErrors() = %q, want %d errors", errs, len(want))
+	}
+	for _, w := range want {
+		found := false
+		for _, e := range errs {
+			found = found || e == w
+		}
+		if !found {
+			t.Errorf("missing error %q in %q", w, errs)
+		}
+	}
+}
+
+func TestHideDeprecated(t *testing.T) {
+	config, err := model.ParseYAML([]byte(deprecationYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hideDeprecated(config)
+
+	var listed []string
+	for _, req := range config.Flatten() {
+		listed = append(listed, req.Summary)
+	}
+	if got := strings.Join(listed, ", "); got != "Passkey login, Account recovery, Recovery email" {
+		t.Errorf("listed %s", got)
+	}
+	// String references are kept; they name a requirement, not list it
+	if refs := config.Requirements[1].Requirements; len(refs) != 2 || refs[0].Reference != "Legacy SMS codes" {
+		t.Errorf("references of Account recovery = %+v", refs)
+	}
+}
+
+func TestGraphExportDeprecated(t *testing.T) {
+	config, err := model.ParseYAML([]byte(deprecationYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := newGraphExport(config, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dot, mermaid strings.Builder
+	e.writeDOT(&dot)
+	e.writeMermaid(&mermaid)
+	for _, want := range []string{
+		`n0 [label="[AUTH-001] Password login (superseded by AUTH-010)", style="rounded,filled", fillcolor="#eeeeee", fontcolor="#888888"];`,
+		`n2 [label="[AUTH-010] Passkey login"];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT missing %q:\n%s", want, dot.String())
+		}
+	}
+	for _, want := range []string{
+		`n0["[AUTH-001] Password login (superseded by AUTH-010)"]:::deprecated`,
+		"classDef deprecated",
+	} {
+		if !strings.Contains(mermaid.String(), want) {
+			t.Errorf("Mermaid missing %q:\n%s", want, mermaid.String())
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffBase     string
+	diffBaseline string
+	diffFormat   string
+)
+
+// RequirementDiff is a changed requirement in /api/diff, with the
+// free-text fields that changed diffed word by word
+type RequirementDiff struct {
+	ID      string              `json:"id,omitempty"`
+	Summary string              `json:"summary"`
+	Fields  []string            `json:"fields"`
+	Text    map[string]TextDiff `json:"text,omitempty"`
+}
+
+// TextDiff is the word diff of a field, as runs and rendered as HTML with
+// <del> and <ins>
+type TextDiff struct {
+	Ops  []wordDiffOp `json:"ops"`
+	HTML string       `json:"html"`
+}
+
+// RequirementRef names an added or removed requirement in /api/diff
+type RequirementRef struct {
+	ID      string `json:"id,omitempty"`
+	Summary string `json:"summary"`
+}
+
+// DiffResponse is the payload of /api/diff and of rqm diff --format json
+type DiffResponse struct {
+	SchemaVersion int               `json:"schema_version"`
+	File          string            `json:"file"`
+	Base          string            `json:"base"`
+	Added         []RequirementRef  `json:"added"`
+	Changed       []RequirementDiff `json:"changed"`
+	Removed       []RequirementRef  `json:"removed"`
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [file]",
+	Short: "Show requirement changes against a git ref, word by word",
+	Long: `Compare a requirements file with its version at a git ref and show the
+requirements added, changed and removed.
+
+Changes to the summary, description, justification and acceptance_test are
+shown word by word like git diff --word-diff, [-removed-]{+added+}, so a
+reviewer sees exactly how the wording of a requirement changed. Status
+transitions are shown as "draft → implemented" and other changed fields
+are listed by name. Requirements are matched as in rqm pr-summary, by
+uid first, so a requirement whose ID changed is shown as renamed,
+"AUTH-1 → AUTH-001", rather than as removed and added.
+
+--baseline compares with a baseline named in .rqm/config.yml instead of
+a git ref.
+
+--format json prints the comparison as JSON, with each word diff as runs
+and rendered as HTML; rqm schema output diff describes it. rqm serve
+answers /api/diff?base=<ref> with the same payload.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm diff
+  rqm diff requirements.yml --base origin/main
+  rqm diff --baseline v1.0
+  rqm diff --format json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		base := diffBase
+		if diffBaseline != "" {
+			if cmd.Flags().Changed("base") {
+				return fmt.Errorf("--base and --baseline can't be used together")
+			}
+			if base, err = baselineRef(file, diffBaseline); err != nil {
+				return err
+			}
+		}
+		changes, err := changesSince(base, file)
+		if err != nil {
+			return err
+		}
+		switch diffFormat {
+		case "text":
+			writeRequirementDiff(os.Stdout, file, base, changes)
+			return nil
+		case "json":
+			return writeJSON(newDiffResponse(file, base, changes))
+		default:
+			return fmt.Errorf("unknown output format: %s", diffFormat)
+		}
+	},
+}
+
+// writeRequirementDiff writes changes for the terminal
+func writeRequirementDiff(w io.Writer, file, base string, changes requirementChanges) {
+	if len(changes.Added)+len(changes.Changed)+len(changes.Removed) == 0 {
+		fmt.Fprintf(w, "No requirement changes in %s compared with %s\n", file, base)
+		return
+	}
+	fmt.Fprintf(w, "%s compared with %s: %d added, %d changed, %d removed\n",
+		file, base, len(changes.Added), len(changes.Changed), len(changes.Removed))
+
+	for _, req := range changes.Added {
+		fmt.Fprintf(w, "\n%s %s\n", paint(ansiGreen, "+"), requirementLabel(req))
+	}
+	for _, c := range changes.Changed {
+		fmt.Fprintf(w, "\n%s %s\n", paint(ansiYellow, "~"), requirementLabel(c.New))
+		var others []string
+		for _, field := range c.Fields {
+			if ops, ok := textChange(c, field); ok {
+				text := strings.ReplaceAll(formatWordDiff(ops), "\n", "\n      ")
+				fmt.Fprintf(w, "    %s: %s\n", field, text)
+			} else if field == "status" {
+				fmt.Fprintf(w, "    status: %s → %s\n", orUnset(c.Old.Status), orUnset(c.New.Status))
+			} else if field == "name" {
+				fmt.Fprintf(w, "    renamed: %s → %s\n", orUnset(c.Old.Name), orUnset(c.New.Name))
+			} else {
+				others = append(others, field)
+			}
+		}
+		if len(others) > 0 {
+			fmt.Fprintf(w, "    also changed: %s\n", strings.Join(others, ", "))
+		}
+	}
+	for _, req := range changes.Removed {
+		fmt.Fprintf(w, "\n%s %s\n", paint(ansiRed, "-"), requirementLabel(req))
+	}
+}
+
+// requirementLabel is a requirement's ID, when it has one, and summary
+func requirementLabel(req *model.RequirementDetail) string {
+	if req.Name == "" {
+		return req.Summary
+	}
+	return req.Name + "  " + req.Summary
+}
+
+// orUnset shows an unset value in a status transition
+func orUnset(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// textChange is the word diff of field for a changed requirement, when
+// field is one of wordDiffFields
+func textChange(c requirementChange, field string) ([]wordDiffOp, bool) {
+	for _, f := range wordDiffFields {
+		if f.name == field {
+			return wordDiff(f.value(c.Old), f.value(c.New)), true
+		}
+	}
+	return nil, false
+}
+
+// newDiffResponse converts changes to the /api/diff payload
+func newDiffResponse(file, base string, changes requirementChanges) DiffResponse {
+	ref := func(req *model.RequirementDetail) RequirementRef {
+		return RequirementRef{ID: req.Name, Summary: req.Summary}
+	}
+	response := DiffResponse{SchemaVersion: apiVersion, File: file, Base: base, Added: []RequirementRef{}, Changed: []RequirementDiff{}, Removed: []RequirementRef{}}
+	for _, req := range changes.Added {
+		response.Added = append(response.Added, ref(req))
+	}
+	for _, c := range changes.Changed {
+		diff := RequirementDiff{ID: c.New.Name, Summary: c.New.Summary, Fields: c.Fields}
+		for _, field := range c.Fields {
+			if ops, ok := textChange(c, field); ok {
+				if diff.Text == nil {
+					diff.Text = make(map[string]TextDiff)
+				}
+				diff.Text[field] = TextDiff{Ops: ops, HTML: htmlWordDiff(ops)}
+			}
+		}
+		response.Changed = append(response.Changed, diff)
+	}
+	for _, req := range changes.Removed {
+		response.Removed = append(response.Removed, ref(req))
+	}
+	return response
+}
+
+// serveDiff answers /api/diff with the changes to reqFile since the ref
+// in the base query parameter, HEAD by default
+func serveDiff(w http.ResponseWriter, r *http.Request, reqFile string) {
+	base := r.URL.Query().Get("base")
+	if base == "" {
+		base = "HEAD"
+	}
+	// The ref is passed to git, which mustn't take it for an option
+	if strings.HasPrefix(base, "-") {
+		http.Error(w, fmt.Sprintf("invalid git ref: %s", base), http.StatusBadRequest)
+		return
+	}
+	changes, err := changesSince(base, reqFile)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "unknown git ref") {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	json.NewEncoder(w).Encode(newDiffResponse(reqFile, base, changes))
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVar(&diffBase, "base", "HEAD", "Git ref to compare against")
+	diffCmd.Flags().StringVar(&diffBaseline, "baseline", "", "Baseline in .rqm/config.yml to compare against, instead of --base")
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "f", "text", "Output format (text, json)")
+}
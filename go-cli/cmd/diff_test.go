@@ -0,0 +1,76 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestWriteRequirementDiff(t *testing.T) {
+	base, _ := model.ParseYAML([]byte(prSummaryBaseYAML))
+	head, _ := model.ParseYAML([]byte(prSummaryHeadYAML))
+
+	var out bytes.Buffer
+	writeRequirementDiff(&out, "requirements.yml", "main", diffRequirements(base, head))
+	for _, want := range []string{
+		"requirements.yml compared with main: 1 added, 2 changed, 1 removed",
+		"+ Rate limiting",
+		"~ AUTH-001  Login\n    status: draft → implemented",
+		"~ AUTH-002  Reset a forgotten password\n    summary: [-Password reset-]{+Reset a forgotten password+}",
+		"- Audit log",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestServeDiff(t *testing.T) {
+	gitTestRepo(t)
+	gitCommitFiles(t, "add requirements", map[string]string{"requirements.yml": `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    description: Users must log in with a password.
+`})
+	os.WriteFile("requirements.yml", []byte(`version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    description: Users must log in with a passkey.
+`), 0644)
+
+	rec := httptest.NewRecorder()
+	serveDiff(rec, httptest.NewRequest(http.MethodGet, "/api/diff", nil), "requirements.yml")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var response DiffResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.SchemaVersion != outputSchemaVersion || response.Base != "HEAD" || len(response.Changed) != 1 {
+		t.Fatalf("Expected one change since HEAD, got %+v", response)
+	}
+	if got := response.Changed[0].Text["description"].HTML; got != "Users must log in with a <del>password</del><ins>passkey</ins>." {
+		t.Errorf("Unexpected description diff %q", got)
+	}
+
+	for _, base := range []string{"no-such-branch", "--output=x"} {
+		rec = httptest.NewRecorder()
+		serveDiff(rec, httptest.NewRequest(http.MethodGet, "/api/diff?base="+base, nil), "requirements.yml")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for base %s, got %d", base, rec.Code)
+		}
+	}
+}
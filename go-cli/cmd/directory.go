@@ -0,0 +1,345 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// directoryTimeout bounds each directory lookup
+const directoryTimeout = 10 * time.Second
+
+// DirectoryConfig is the directory: section of .rqm/config.yml used by
+// check --owners-active
+type DirectoryConfig struct {
+	// Type is scim, google or ldap
+	Type string `yaml:"type"`
+	// URL is the SCIM base URL, the LDAP server URL, or an override for the
+	// Google Directory API endpoint
+	URL string `yaml:"url,omitempty"`
+	// TokenEnv names the environment variable holding a bearer token (SCIM, Google)
+	TokenEnv string `yaml:"token_env,omitempty"`
+
+	// LDAP settings
+	BaseDN      string `yaml:"base_dn,omitempty"`
+	BindDN      string `yaml:"bind_dn,omitempty"`
+	PasswordEnv string `yaml:"password_env,omitempty"`
+	// Filter is the LDAP search filter with %s for the escaped email,
+	// default (mail=%s)
+	Filter string `yaml:"filter,omitempty"`
+}
+
+// directoryStatus is the result of looking up one person
+type directoryStatus int
+
+const (
+	directoryActive directoryStatus = iota
+	directoryInactive
+	directoryNotFound
+)
+
+// directory looks people up by email in an organisation's user directory
+type directory interface {
+	Lookup(ctx context.Context, email string) (directoryStatus, error)
+}
+
+// InactiveOwner is a requirement whose owner isn't an active directory user
+type InactiveOwner struct {
+	Summary string `json:"summary"`
+	Name    string `json:"name,omitempty"`
+	Owner   string `json:"owner"`
+	Email   string `json:"email,omitempty"`
+	// Reason is not_found or inactive
+	Reason string `json:"reason"`
+}
+
+// newDirectory builds the client for a directory config
+func newDirectory(config *DirectoryConfig) (directory, error) {
+	token := ""
+	if config.TokenEnv != "" {
+		token = os.Getenv(config.TokenEnv)
+		if token == "" {
+			return nil, fmt.Errorf("directory token variable %s is not set", config.TokenEnv)
+		}
+	}
+	client := &http.Client{Timeout: directoryTimeout}
+
+	switch config.Type {
+	case "scim":
+		if config.URL == "" {
+			return nil, fmt.Errorf("scim directory needs a url")
+		}
+		return &scimDirectory{baseURL: strings.TrimSuffix(config.URL, "/"), token: token, client: client}, nil
+	case "google":
+		baseURL := config.URL
+		if baseURL == "" {
+			baseURL = "https://admin.googleapis.com/admin/directory/v1"
+		}
+		return &googleDirectory{baseURL: strings.TrimSuffix(baseURL, "/"), token: token, client: client}, nil
+	case "ldap":
+		if config.URL == "" || config.BaseDN == "" {
+			return nil, fmt.Errorf("ldap directory needs url and base_dn")
+		}
+		return &ldapDirectory{config: config}, nil
+	case "":
+		return nil, fmt.Errorf("directory type is not set (expected scim, google or ldap)")
+	default:
+		return nil, fmt.Errorf("unknown directory type: %s (expected scim, google or ldap)", config.Type)
+	}
+}
+
+// scimDirectory queries a SCIM 2.0 /Users endpoint
+type scimDirectory struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func (d *scimDirectory) Lookup(ctx context.Context, email string) (directoryStatus, error) {
+	quoted := strings.ReplaceAll(email, `"`, `\"`)
+	filter := fmt.Sprintf(`userName eq "%s" or emails.value eq "%s"`, quoted, quoted)
+
+	var body struct {
+		TotalResults int `json:"totalResults"`
+		Resources    []struct {
+			Active *bool `json:"active"`
+		} `json:"Resources"`
+	}
+	status, err := getJSON(ctx, d.client, d.baseURL+"/Users?filter="+url.QueryEscape(filter), d.token, &body)
+	if err != nil {
+		return 0, err
+	}
+	if status == http.StatusNotFound || body.TotalResults == 0 || len(body.Resources) == 0 {
+		return directoryNotFound, nil
+	}
+	// SCIM users are active unless the directory says otherwise
+	if active := body.Resources[0].Active; active != nil && !*active {
+		return directoryInactive, nil
+	}
+	return directoryActive, nil
+}
+
+// googleDirectory queries the Google Workspace Admin SDK Directory API
+type googleDirectory struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func (d *googleDirectory) Lookup(ctx context.Context, email string) (directoryStatus, error) {
+	var body struct {
+		Suspended bool `json:"suspended"`
+		Archived  bool `json:"archived"`
+	}
+	status, err := getJSON(ctx, d.client, d.baseURL+"/users/"+url.PathEscape(email), d.token, &body)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case status == http.StatusNotFound:
+		return directoryNotFound, nil
+	case body.Suspended || body.Archived:
+		return directoryInactive, nil
+	default:
+		return directoryActive, nil
+	}
+}
+
+// ldapDirectory searches an LDAP server; a person counts as active when the
+// filter matches an entry
+type ldapDirectory struct {
+	config *DirectoryConfig
+}
+
+func (d *ldapDirectory) Lookup(ctx context.Context, email string) (directoryStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	conn, err := ldap.DialURL(d.config.URL, ldap.DialWithDialer(&net.Dialer{Timeout: directoryTimeout}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to %s: %w", d.config.URL, err)
+	}
+	defer conn.Close()
+	conn.SetTimeout(directoryTimeout)
+
+	if d.config.BindDN != "" {
+		if err := conn.Bind(d.config.BindDN, os.Getenv(d.config.PasswordEnv)); err != nil {
+			return 0, fmt.Errorf("ldap bind failed: %w", err)
+		}
+	}
+
+	filter := d.config.Filter
+	if filter == "" {
+		filter = "(mail=%s)"
+	}
+	result, err := conn.Search(ldap.NewSearchRequest(
+		d.config.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 1, int(directoryTimeout.Seconds()), false,
+		fmt.Sprintf(filter, ldap.EscapeFilter(email)), []string{"dn"}, nil,
+	))
+	if err != nil {
+		return 0, fmt.Errorf("ldap search failed: %w", err)
+	}
+	if len(result.Entries) == 0 {
+		return directoryNotFound, nil
+	}
+	return directoryActive, nil
+}
+
+// getJSON fetches url and decodes a 2xx body into v, returning the status code.
+// 404 is returned without error so callers can treat it as "not found".
+func getJSON(ctx context.Context, client *http.Client, rawURL, token string, v interface{}) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("directory lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return resp.StatusCode, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("directory lookup failed: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return resp.StatusCode, fmt.Errorf("invalid directory response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// ownerEmail resolves an owner reference to an email address: emails are
+// used as-is and aliases (with or without @) map to their email. GitHub
+// handles without an alias can't be resolved and return "".
+func ownerEmail(config *model.RequirementConfig, owner string) string {
+	if strings.Contains(owner, "@") && !strings.HasPrefix(owner, "@") {
+		return owner
+	}
+	alias, _ := config.ResolveOwner(owner)
+	return alias.Email
+}
+
+// ownerCheck is the outcome of checking every owner in a file
+type ownerCheck struct {
+	inactive []InactiveOwner
+	// unresolved lists owners with no email to look up
+	unresolved []string
+}
+
+// checkOwners looks up each distinct owner email once
+func checkOwners(ctx context.Context, config *model.RequirementConfig, dir directory) (*ownerCheck, error) {
+	check := &ownerCheck{}
+	statuses := make(map[string]directoryStatus)
+	seenUnresolved := make(map[string]bool)
+
+	for _, req := range config.Flatten() {
+		if req.Owner == "" {
+			continue
+		}
+		email := ownerEmail(config, req.Owner)
+		if email == "" {
+			if !seenUnresolved[req.Owner] {
+				seenUnresolved[req.Owner] = true
+				check.unresolved = append(check.unresolved, req.Owner)
+			}
+			continue
+		}
+
+		status, ok := statuses[email]
+		if !ok {
+			var err error
+			if status, err = dir.Lookup(ctx, email); err != nil {
+				return nil, fmt.Errorf("failed to look up %s: %w", email, err)
+			}
+			statuses[email] = status
+		}
+
+		reason := ""
+		switch status {
+		case directoryNotFound:
+			reason = "not_found"
+		case directoryInactive:
+			reason = "inactive"
+		}
+		if reason != "" {
+			check.inactive = append(check.inactive, InactiveOwner{
+				Summary: req.Summary, Name: req.Name, Owner: req.Owner, Email: email, Reason: reason,
+			})
+		}
+	}
+	return check, nil
+}
+
+// findInactiveOwners runs the --owners-active check for a requirements file.
+// Owners that can't be resolved to an email are returned as unresolved and
+// reported as a warning on stderr.
+func findInactiveOwners(file string) ([]InactiveOwner, []string, error) {
+	project, err := loadProjectConfig(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	if project.Directory == nil {
+		return nil, nil, fmt.Errorf("--owners-active needs a directory section in .rqm/config.yml")
+	}
+	if err := requireOnline("--owners-active", project); err != nil {
+		return nil, nil, err
+	}
+	dir, err := newDirectory(project.Directory)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config, _, err := loadRequirements(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	check, err := checkOwners(ctx, config, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(check.unresolved) > 0 {
+		fmt.Fprintf(os.Stderr, "%s Could not check owner(s) without an email: %s\n", warnMark(), strings.Join(check.unresolved, ", "))
+	}
+	return check.inactive, check.unresolved, nil
+}
+
+// displayInactiveOwners prints the --owners-active result for humans
+func displayInactiveOwners(inactive []InactiveOwner) {
+	fmt.Println()
+	if len(inactive) == 0 {
+		fmt.Println(okMark(), "All owners are active in the directory")
+		return
+	}
+
+	fmt.Printf("%s %d requirement(s) owned by people not active in the directory:\n", failMark(), len(inactive))
+	for _, owner := range inactive {
+		reason := "not found"
+		if owner.Reason == "inactive" {
+			reason = "inactive"
+		}
+		fmt.Printf("  - %s: %s <%s> (%s)\n", displayName(&model.RequirementDetail{Name: owner.Name, Summary: owner.Summary}), owner.Owner, owner.Email, reason)
+	}
+}
@@ -0,0 +1,159 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// directoryUsers maps email to active flag for the fake directory servers
+var directoryUsers = map[string]bool{
+	"alice@example.com": true,
+	"bob@example.com":   false,
+}
+
+func TestSCIMDirectory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		filter := r.URL.Query().Get("filter")
+		for email, active := range directoryUsers {
+			if strings.Contains(filter, `"`+email+`"`) {
+				fmt.Fprintf(w, `{"totalResults":1,"Resources":[{"userName":%q,"active":%v}]}`, email, active)
+				return
+			}
+		}
+		fmt.Fprint(w, `{"totalResults":0,"Resources":[]}`)
+	}))
+	defer server.Close()
+
+	t.Setenv("SCIM_TOKEN", "secret")
+	dir, err := newDirectory(&DirectoryConfig{Type: "scim", URL: server.URL + "/scim/v2/", TokenEnv: "SCIM_TOKEN"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLookups(t, dir)
+}
+
+func TestGoogleDirectory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		email := strings.TrimPrefix(r.URL.Path, "/users/")
+		active, ok := directoryUsers[email]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"primaryEmail":%q,"suspended":%v}`, email, !active)
+	}))
+	defer server.Close()
+
+	dir, err := newDirectory(&DirectoryConfig{Type: "google", URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLookups(t, dir)
+}
+
+func assertLookups(t *testing.T, dir directory) {
+	t.Helper()
+	want := map[string]directoryStatus{
+		"alice@example.com": directoryActive,
+		"bob@example.com":   directoryInactive,
+		"carol@example.com": directoryNotFound,
+	}
+	for email, wantStatus := range want {
+		got, err := dir.Lookup(context.Background(), email)
+		if err != nil {
+			t.Fatalf("Lookup(%s): %v", email, err)
+		}
+		if got != wantStatus {
+			t.Errorf("Lookup(%s) = %v, want %v", email, got, wantStatus)
+		}
+	}
+}
+
+func TestNewDirectoryErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config DirectoryConfig
+		want   string
+	}{
+		{"missing type", DirectoryConfig{}, "type is not set"},
+		{"unknown type", DirectoryConfig{Type: "nis"}, "unknown directory type"},
+		{"scim without url", DirectoryConfig{Type: "scim"}, "needs a url"},
+		{"ldap without base", DirectoryConfig{Type: "ldap", URL: "ldap://localhost"}, "needs url and base_dn"},
+		{"unset token", DirectoryConfig{Type: "google", TokenEnv: "RQM_TEST_UNSET_TOKEN"}, "is not set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newDirectory(&tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// countingDirectory answers from directoryUsers and counts lookups
+type countingDirectory struct {
+	lookups int
+}
+
+func (d *countingDirectory) Lookup(ctx context.Context, email string) (directoryStatus, error) {
+	d.lookups++
+	active, ok := directoryUsers[email]
+	switch {
+	case !ok:
+		return directoryNotFound, nil
+	case !active:
+		return directoryInactive, nil
+	}
+	return directoryActive, nil
+}
+
+func TestCheckOwners(t *testing.T) {
+	config := &model.RequirementConfig{
+		Aliases: []model.PersonAlias{
+			{Alias: "alice", Email: "alice@example.com"},
+			{Alias: "bob", Email: "bob@example.com", GitHub: "bobeng"},
+		},
+		Requirements: []model.RequirementDetail{
+			{Summary: "A", Owner: "alice"},
+			{Summary: "B", Name: "B-1", Owner: "@bobeng"},
+			{Summary: "C", Owner: "carol@example.com"},
+			{Summary: "D", Owner: "@alice"},
+			{Summary: "E", Owner: "@octocat"},
+			{Summary: "F"},
+		},
+	}
+
+	dir := &countingDirectory{}
+	check, err := checkOwners(context.Background(), config, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dir.lookups != 3 {
+		t.Errorf("Expected each email to be looked up once (3), got %d", dir.lookups)
+	}
+	if len(check.inactive) != 2 ||
+		check.inactive[0].Summary != "B" || check.inactive[0].Reason != "inactive" ||
+		check.inactive[1].Summary != "C" || check.inactive[1].Reason != "not_found" {
+		t.Errorf("Unexpected inactive owners: %+v", check.inactive)
+	}
+	if len(check.unresolved) != 1 || check.unresolved[0] != "@octocat" {
+		t.Errorf("Unexpected unresolved owners: %v", check.unresolved)
+	}
+}
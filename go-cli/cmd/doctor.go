@@ -0,0 +1,376 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// supportedSchema is the schema.json version this build reads. Files with
+// the same major version and a newer minor one are read on a best-effort basis.
+const supportedSchema = "1.0"
+
+// doctorCheck is the outcome of one rqm doctor check
+type doctorCheck struct {
+	Name string
+	// Status is ok, warn, fail or skip
+	Status string
+	Detail string
+	// Fix says how to resolve a warning or failure
+	Fix string
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [file]",
+	Short: "Check the rqm installation and project setup",
+	Long: `Check the pieces rqm depends on and print a fix for anything missing:
+
+  - validator       the embedded validator (CGO builds) or a working
+                    rqm-validator binary
+  - schema version  the requirements file's version is one this build reads
+  - project config  .rqm/config.yml parses and its attributes are valid
+  - user config     $HOME/.rqm.yaml (or --config) parses
+  - web UI          rqm serve has a built web UI embedded
+  - git hooks       the hooks from rqm hooks install are in place
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory. The exit code is 1 when any check fails; warnings
+don't fail.`,
+	Example: `  rqm doctor
+  rqm doctor requirements.yml`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	// Failures are diagnoses; usage would only hide them
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		file := findRequirementsFile(cwd)
+		if len(args) > 0 {
+			file = args[0]
+		}
+
+		checks := []doctorCheck{
+			doctorValidator(),
+			doctorSchemaVersion(file),
+			doctorProjectConfig(file, cwd),
+			doctorUserConfig(),
+			doctorAliases(cwd),
+			doctorWebUI(webUI),
+			doctorGitHooks(),
+		}
+		writeDoctorReport(os.Stdout, checks)
+
+		failed := 0
+		for _, check := range checks {
+			if check.Status == "fail" {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return validationError("%d check(s) failed", failed)
+		}
+		return nil
+	},
+}
+
+// doctorValidator checks that validation can run at all, by validating a
+// small sample with the validator rqm would use
+func doctorValidator() doctorCheck {
+	check := doctorCheck{Name: "validator"}
+	if validatorKind() == "embedded" {
+		check.Status, check.Detail = "ok", "embedded (CGO build)"
+		return check
+	}
+
+	path := findValidatorBinary()
+	if path == "" {
+		check.Status, check.Detail = "fail", "no embedded validator and rqm-validator not found"
+		check.Fix = "build it with: cd rust-core && cargo build --release --bin rqm-validator, or set RQM_VALIDATOR to its path"
+		return check
+	}
+	if _, err := os.Stat(path); err != nil {
+		check.Status, check.Detail = "fail", fmt.Sprintf("RQM_VALIDATOR points at %s, which does not exist", path)
+		check.Fix = "set RQM_VALIDATOR to the rqm-validator binary, or unset it to search the usual locations"
+		return check
+	}
+
+	sample, err := os.CreateTemp("", "rqm-doctor-*.yml")
+	if err != nil {
+		check.Status, check.Detail = "fail", err.Error()
+		return check
+	}
+	defer os.Remove(sample.Name())
+	sample.WriteString("version: \"" + supportedSchema + "\"\nrequirements:\n  - summary: Doctor sample\n")
+	sample.Close()
+
+	if result, err := runExternalValidation(sample.Name()); err != nil || !result.Valid {
+		detail := fmt.Sprintf("%s did not validate a sample file", path)
+		if err != nil {
+			detail += ": " + err.Error()
+		}
+		check.Status, check.Detail = "fail", detail
+		check.Fix = "rebuild it with: cd rust-core && cargo build --release --bin rqm-validator"
+		return check
+	}
+	check.Status, check.Detail = "ok", "external: "+path
+	return check
+}
+
+// doctorSchemaVersion checks the version field of the requirements file
+func doctorSchemaVersion(file string) doctorCheck {
+	check := doctorCheck{Name: "schema version"}
+	if file == "" {
+		check.Status, check.Detail = "skip", "no requirements file found"
+		return check
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		check.Status, check.Detail = "fail", err.Error()
+		return check
+	}
+	var header struct {
+		Version string `yaml:"version"`
+	}
+	data, err = model.ToYAML(file, data)
+	if err == nil {
+		err = yaml.Unmarshal(data, &header)
+	}
+	if err != nil {
+		check.Status, check.Detail = "fail", fmt.Sprintf("%s is not valid %s: %v", file, model.FormatOf(file), err)
+		check.Fix = "run rqm validate " + file + " for details"
+		return check
+	}
+
+	major, minor, _ := strings.Cut(header.Version, ".")
+	supportedMajor, supportedMinor, _ := strings.Cut(supportedSchema, ".")
+	newer, _ := strconv.Atoi(minor)
+	current, _ := strconv.Atoi(supportedMinor)
+	switch {
+	case header.Version == "":
+		check.Status, check.Detail = "fail", file+" has no version field"
+		check.Fix = fmt.Sprintf("add version: \"%s\" at the top of the file", supportedSchema)
+	case major != supportedMajor && slices.Contains(schemaVersions, header.Version):
+		check.Status, check.Detail = "fail", fmt.Sprintf("%s uses schema %s; this rqm reads %s.x", file, header.Version, supportedMajor)
+		check.Fix = "run rqm migrate " + file
+	case major != supportedMajor:
+		check.Status, check.Detail = "fail", fmt.Sprintf("%s uses schema %s; this rqm reads %s.x", file, header.Version, supportedMajor)
+		check.Fix = "install an rqm release that supports schema " + header.Version
+	case newer > current:
+		check.Status, check.Detail = "warn", fmt.Sprintf("%s uses schema %s, newer than %s; new fields may be ignored", file, header.Version, supportedSchema)
+		check.Fix = "upgrade rqm"
+	default:
+		check.Status, check.Detail = "ok", fmt.Sprintf("%s (%s)", header.Version, file)
+	}
+	return check
+}
+
+// doctorProjectConfig checks the .rqm/config.yml that applies to file, or
+// to the current directory without one
+func doctorProjectConfig(file, cwd string) doctorCheck {
+	check := doctorCheck{Name: "project config"}
+	// loadProjectConfig looks up the config from a requirements file
+	if file == "" {
+		file = filepath.Join(cwd, "requirements.yml")
+	}
+	path := findProjectConfig(filepath.Dir(file))
+	if path == "" {
+		check.Status, check.Detail = "ok", "none (using defaults)"
+		return check
+	}
+
+	config, err := loadProjectConfig(file)
+	if err != nil {
+		check.Status, check.Detail = "fail", err.Error()
+		check.Fix = "fix the YAML syntax in " + path
+		return check
+	}
+	if errs := validateAttributeDefinitions(config.Attributes); len(errs) > 0 {
+		check.Status, check.Detail = "fail", fmt.Sprintf("%s: %s", path, strings.Join(errs, "; "))
+		check.Fix = "correct the attributes section of " + path
+		return check
+	}
+	for category := range config.Warnings {
+		if !slices.Contains(warningCategories, category) {
+			check.Status, check.Detail = "fail", fmt.Sprintf("%s: unknown warning category: %s", path, category)
+			check.Fix = "use " + strings.Join(warningCategories, ", ") + " under warnings: in " + path
+			return check
+		}
+	}
+	for _, notification := range config.Notifications {
+		if err := checkNotificationConfig(notification); err != nil {
+			check.Status, check.Detail = "fail", fmt.Sprintf("%s: %v", path, err)
+			check.Fix = "correct the notifications section of " + path
+			return check
+		}
+	}
+	check.Status, check.Detail = "ok", path
+	return check
+}
+
+// doctorUserConfig checks the viper config file, --config or ~/.rqm.yaml
+func doctorUserConfig() doctorCheck {
+	check := doctorCheck{Name: "user config"}
+	path := cfgFile
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			check.Status, check.Detail = "skip", "no home directory"
+			return check
+		}
+		path = filepath.Join(home, ".rqm.yaml")
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err) && cfgFile == "":
+		check.Status, check.Detail = "ok", "none"
+		return check
+	case err != nil:
+		check.Status, check.Detail = "fail", err.Error()
+		check.Fix = "check the path given to --config"
+		return check
+	}
+	var settings map[string]interface{}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		check.Status, check.Detail = "fail", fmt.Sprintf("%s is not valid YAML: %v", path, err)
+		check.Fix = "fix or remove " + path
+		return check
+	}
+	check.Status, check.Detail = "ok", path
+	return check
+}
+
+// doctorAliases checks the command aliases: each must split into a command
+// line, and one named after a built-in command is never used
+func doctorAliases(cwd string) doctorCheck {
+	check := doctorCheck{Name: "command aliases"}
+	aliases, err := commandAliases(cwd, cfgFile)
+	if err != nil {
+		check.Status, check.Detail = "fail", err.Error()
+		check.Fix = "fix the YAML syntax of the aliases section"
+		return check
+	}
+	if len(aliases) == 0 {
+		check.Status, check.Detail = "ok", "none"
+		return check
+	}
+
+	var invalid, shadowed []string
+	for _, name := range sortedKeys(aliases) {
+		if words, err := splitCommandLine(aliases[name]); err != nil || len(words) == 0 {
+			invalid = append(invalid, name)
+		}
+		if isBuiltinCommand(name) {
+			shadowed = append(shadowed, name)
+		}
+	}
+	switch {
+	case len(invalid) > 0:
+		check.Status, check.Detail = "fail", "invalid expansion for "+strings.Join(invalid, ", ")
+		check.Fix = "close quotes in the alias and give it a command to run"
+	case len(shadowed) > 0:
+		check.Status, check.Detail = "warn", strings.Join(shadowed, ", ")+" shadow built-in commands and are ignored"
+		check.Fix = "rename the alias"
+	default:
+		check.Status, check.Detail = "ok", fmt.Sprintf("%d alias(es)", len(aliases))
+	}
+	return check
+}
+
+// doctorWebUI checks that a built web UI was embedded for rqm serve. Builds
+// without it embed a placeholder page with no script.
+func doctorWebUI(web fs.FS) doctorCheck {
+	check := doctorCheck{Name: "web UI"}
+	index, err := fs.ReadFile(web, "web-dist/index.html")
+	switch {
+	case err != nil:
+		check.Status, check.Detail = "fail", "index.html is missing from the embedded web UI"
+	case !strings.Contains(string(index), "<script"):
+		check.Status, check.Detail = "warn", "only a placeholder page is embedded; rqm serve will show a blank page"
+	default:
+		check.Status, check.Detail = "ok", "embedded"
+		return check
+	}
+	check.Fix = "build rqm with scripts/build-rqm.sh, which builds web-ui and embeds it"
+	return check
+}
+
+// doctorGitHooks checks the hooks rqm hooks install writes
+func doctorGitHooks() doctorCheck {
+	check := doctorCheck{Name: "git hooks"}
+	dir, err := gitOutput("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		check.Status, check.Detail = "skip", "not in a git repository"
+		return check
+	}
+
+	var installed, missing, foreign []string
+	for _, hook := range sortedKeys(gitHooks) {
+		path := filepath.Join(dir, hook)
+		script, err := os.ReadFile(path)
+		switch {
+		case err != nil:
+			missing = append(missing, hook)
+		case !strings.Contains(string(script), "rqm"):
+			foreign = append(foreign, hook)
+		default:
+			if info, err := os.Stat(path); err == nil && info.Mode().Perm()&0111 == 0 {
+				check.Status, check.Detail = "fail", path+" is not executable, so git skips it"
+				check.Fix = "chmod +x " + path
+				return check
+			}
+			installed = append(installed, hook)
+		}
+	}
+
+	switch {
+	case len(foreign) > 0:
+		check.Status, check.Detail = "warn", strings.Join(foreign, ", ")+" hook(s) exist but don't run rqm"
+		check.Fix = "add 'rqm hooks run --staged' to them, or replace them with rqm hooks install --force"
+	case len(missing) > 0:
+		check.Status, check.Detail = "warn", strings.Join(missing, ", ")+" hook(s) not installed"
+		check.Fix = "rqm hooks install"
+	default:
+		check.Status, check.Detail = "ok", strings.Join(installed, ", ")
+	}
+	return check
+}
+
+// writeDoctorReport prints one line per check, with the fix underneath
+func writeDoctorReport(w io.Writer, checks []doctorCheck) {
+	for _, check := range checks {
+		mark := okMark()
+		switch check.Status {
+		case "fail":
+			mark = failMark()
+		case "warn":
+			mark = warnMark()
+		case "skip":
+			mark = "-"
+		}
+		fmt.Fprintf(w, "%s %-15s %s\n", mark, check.Name, check.Detail)
+		if check.Fix != "" {
+			fmt.Fprintf(w, "  %-15s fix: %s\n", "", check.Fix)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
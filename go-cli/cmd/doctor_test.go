@@ -0,0 +1,108 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDoctorSchemaVersion(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		content string
+		status  string
+	}{
+		{"version: \"1.0\"\nrequirements: []\n", "ok"},
+		{"version: \"1.3\"\nrequirements: []\n", "warn"},
+		{"version: \"2.0\"\nrequirements: []\n", "fail"},
+		{"requirements: []\n", "fail"},
+		{"version: [\n", "fail"},
+	}
+	for i, tt := range tests {
+		file := filepath.Join(dir, "requirements.yml")
+		os.WriteFile(file, []byte(tt.content), 0644)
+		if check := doctorSchemaVersion(file); check.Status != tt.status {
+			t.Errorf("case %d: status = %s (%s), want %s", i, check.Status, check.Detail, tt.status)
+		}
+	}
+	if check := doctorSchemaVersion(""); check.Status != "skip" {
+		t.Errorf("Expected skip without a file, got %s", check.Status)
+	}
+}
+
+func TestDoctorProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "requirements.yml")
+	if check := doctorProjectConfig(file, dir); check.Status != "ok" {
+		t.Errorf("Expected ok without a config, got %s: %s", check.Status, check.Detail)
+	}
+
+	os.MkdirAll(filepath.Join(dir, ".rqm"), 0755)
+	config := filepath.Join(dir, ".rqm", "config.yml")
+	os.WriteFile(config, []byte("attributes:\n  - name: risk\n    type: colour\n"), 0644)
+	check := doctorProjectConfig(file, dir)
+	if check.Status != "fail" || !strings.Contains(check.Detail, "unknown type 'colour'") || check.Fix == "" {
+		t.Errorf("Expected attribute failure with a fix, got %+v", check)
+	}
+
+	os.WriteFile(config, []byte("attributes: [\n"), 0644)
+	if check := doctorProjectConfig("", dir); check.Status != "fail" {
+		t.Errorf("Expected invalid YAML to fail, got %+v", check)
+	}
+}
+
+func TestDoctorWebUI(t *testing.T) {
+	tests := []struct {
+		files  fstest.MapFS
+		status string
+	}{
+		{fstest.MapFS{"web-dist/index.html": {Data: []byte(`<html><script src="app.js"></script></html>`)}}, "ok"},
+		{fstest.MapFS{"web-dist/index.html": {Data: []byte(`<html></html>`)}}, "warn"},
+		{fstest.MapFS{"web-dist/app.js": {}}, "fail"},
+	}
+	for i, tt := range tests {
+		if check := doctorWebUI(tt.files); check.Status != tt.status {
+			t.Errorf("case %d: status = %s, want %s", i, check.Status, tt.status)
+		}
+	}
+}
+
+func TestDoctorGitHooks(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if check := doctorGitHooks(); check.Status != "skip" {
+		t.Errorf("Expected skip outside a repository, got %+v", check)
+	}
+
+	if _, err := gitOutput("init", "-q"); err != nil {
+		t.Fatal(err)
+	}
+	if check := doctorGitHooks(); check.Status != "warn" || check.Fix != "rqm hooks install" {
+		t.Errorf("Expected missing hooks warning, got %+v", check)
+	}
+
+	hooks := filepath.Join(dir, ".git", "hooks")
+	for hook := range gitHooks {
+		if _, err := installGitHook(hooks, hook, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if check := doctorGitHooks(); check.Status != "ok" {
+		t.Errorf("Expected installed hooks to pass, got %+v", check)
+	}
+
+	os.Chmod(filepath.Join(hooks, "pre-push"), 0644)
+	if check := doctorGitHooks(); check.Status != "fail" || !strings.Contains(check.Fix, "chmod +x") {
+		t.Errorf("Expected non-executable hook to fail, got %+v", check)
+	}
+}
@@ -0,0 +1,240 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	draftFile   string
+	draftParent string
+	draftYes    bool
+)
+
+// draftSystemPrompt tells the model what a drafted requirement looks like
+const draftSystemPrompt = `You write software requirements. Turn the user's idea into one requirement and answer with a JSON object with these keys:
+- "summary": a short title, under 80 characters, without a trailing period
+- "description": what the system must do, in two to four sentences
+- "justification": why it is needed, in one or two sentences
+- "acceptance_test": Given/When/Then steps, one per line, each line starting with Given, When, Then or And
+- "priority": one of critical, high, medium, low
+- "tags": up to three lowercase tags, reusing the project's existing tags where they fit
+Describe observable behavior; avoid vague words such as "fast", "easy" or "user-friendly" without a measurable criterion.`
+
+// draftedRequirement is the JSON object the model answers draft with
+type draftedRequirement struct {
+	Summary        string   `json:"summary"`
+	Description    string   `json:"description"`
+	Justification  string   `json:"justification"`
+	AcceptanceTest string   `json:"acceptance_test"`
+	Priority       string   `json:"priority"`
+	Tags           []string `json:"tags"`
+}
+
+var draftCmd = &cobra.Command{
+	Use:   "draft <idea>",
+	Short: "Draft a structured requirement from a one-line idea with an LLM",
+	Long: `Send a one-line idea to an LLM and turn its answer into a structured
+requirement: summary, description, justification, Given/When/Then
+acceptance test, priority and tags. The draft is shown, and inserted with
+status draft into the requirements file once you confirm.
+
+The LLM is any OpenAI-compatible chat completions endpoint, configured
+under llm: in ~/.rqm.yaml:
+
+  llm:
+    url: https://api.openai.com/v1   # the default
+    model: gpt-4o-mini
+    api_key_env: OPENAI_API_KEY      # the default
+
+A project's .rqm/config.yml may set llm: model: for its members, but not
+the url or api_key_env, so a cloned repository can't send your keys
+elsewhere.
+
+The idea and the project's existing tags are sent to the endpoint; nothing
+else from the file is. Offline mode disables draft.
+
+The requirement is added at the end of the file, or under --parent (a
+summary or ID). --yes inserts it without asking.`,
+	Example: `  rqm draft "users must be able to reset passwords"
+  rqm draft "lock accounts after repeated failed logins" --parent AUTH-001
+  rqm draft "export audit logs as CSV" --file docs/requirements.yml --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := draftFile
+		if file == "" {
+			var err error
+			if file, err = requirementsFileArg(nil); err != nil {
+				return err
+			}
+		}
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist: %s", file)
+		}
+		if err := editableFile(file); err != nil {
+			return err
+		}
+		config, err := model.LoadFile(file)
+		if err != nil {
+			return err
+		}
+		if draftParent != "" {
+			if _, ok := config.Find(draftParent); !ok {
+				return fmt.Errorf("requirement not found: %s", draftParent)
+			}
+		}
+		project, err := loadProjectConfig(file)
+		if err != nil {
+			return err
+		}
+		client, err := newLLMClient("rqm draft", project)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, "Drafting...")
+		req, err := draftRequirement(cmd.Context(), client, args[0], config)
+		if err != nil {
+			return err
+		}
+
+		out, err := yaml.Marshal([]model.RequirementDetail{*req})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\n%s\n", out)
+		if !draftYes && !confirm(os.Stdin, fmt.Sprintf("Insert it as a draft into %s? [y/N] ", file)) {
+			fmt.Println("Not inserted")
+			return nil
+		}
+		if err := insertRequirement(file, draftParent, req); err != nil {
+			return err
+		}
+		fmt.Printf("%s Added '%s' to %s\n", okMark(), req.Summary, file)
+		return nil
+	},
+}
+
+// draftRequirement asks the model to structure idea, and checks its answer
+// fits into config
+func draftRequirement(ctx context.Context, client *llmClient, idea string, config *model.RequirementConfig) (*model.RequirementDetail, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tags := make(map[string]bool)
+	for _, req := range config.Flatten() {
+		for _, tag := range req.Tags {
+			tags[tag] = true
+		}
+	}
+	prompt := "Idea: " + idea
+	if len(tags) > 0 {
+		prompt += "\nExisting tags: " + strings.Join(sortedKeys(tags), ", ")
+	}
+
+	var d draftedRequirement
+	if err := client.completeJSON(ctx, draftSystemPrompt, prompt, &d); err != nil {
+		return nil, err
+	}
+	req := &model.RequirementDetail{
+		Summary:        strings.TrimSuffix(strings.TrimSpace(d.Summary), "."),
+		Description:    strings.TrimSpace(d.Description),
+		Justification:  strings.TrimSpace(d.Justification),
+		AcceptanceTest: strings.TrimSpace(d.AcceptanceTest),
+		Status:         "draft",
+		Tags:           d.Tags,
+	}
+	if req.AcceptanceTest != "" {
+		req.AcceptanceTest += "\n"
+	}
+	if slices.Contains([]string{"critical", "high", "medium", "low"}, d.Priority) {
+		req.Priority = d.Priority
+	}
+	if req.Summary == "" {
+		return nil, fmt.Errorf("the llm drafted a requirement without a summary")
+	}
+	for _, other := range config.Flatten() {
+		if other.Summary == req.Summary {
+			return nil, fmt.Errorf("the drafted summary '%s' is already used by a requirement", req.Summary)
+		}
+	}
+	return req, nil
+}
+
+// confirm asks question on stderr and reports whether the answer read
+// from in is yes
+func confirm(in io.Reader, question string) bool {
+	fmt.Fprint(os.Stderr, question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// insertRequirement appends req to the requirements of file, or to those
+// of the requirement parent names when it isn't empty. The file is edited
+// as a YAML node tree so comments and key order survive.
+func insertRequirement(file, parent string, req *model.RequirementDetail) error {
+	doc, err := loadYAMLDocument(file)
+	if err != nil {
+		return err
+	}
+	if err := appendRequirement(doc, parent, req); err != nil {
+		return err
+	}
+	return doc.save()
+}
+
+// appendRequirement adds req to the requirements of doc, or to those of
+// the requirement parent names when it isn't empty
+func appendRequirement(doc *yamlDocument, parent string, req *model.RequirementDetail) error {
+	target := doc.root()
+	if parent != "" {
+		bySummary := doc.requirementNodes()
+		config, err := model.Parse(doc.file, doc.data)
+		if err != nil {
+			return err
+		}
+		p, ok := config.Find(parent)
+		if !ok || bySummary[p.Summary] == nil {
+			return fmt.Errorf("requirement not found: %s", parent)
+		}
+		target = bySummary[p.Summary]
+	}
+
+	var node yaml.Node
+	if err := node.Encode(req); err != nil {
+		return fmt.Errorf("failed to encode the requirement: %w", err)
+	}
+	if v := mappingValue(&node, "acceptance_test"); v != nil {
+		v.Style = yaml.LiteralStyle
+	}
+	list := mappingValue(target, "requirements")
+	if list == nil {
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		target.Content = append(target.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "requirements"}, list)
+	}
+	list.Style = 0
+	list.Content = append(list.Content, &node)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(draftCmd)
+	draftCmd.Flags().StringVar(&draftFile, "file", "", "Requirements file to add the draft to (default: the nearest .rqm/requirements.yml)")
+	draftCmd.Flags().StringVar(&draftParent, "parent", "", "Summary or ID of the requirement to add the draft under")
+	draftCmd.RegisterFlagCompletionFunc("parent", completeRequirementIDFlag)
+	draftCmd.Flags().BoolVarP(&draftYes, "yes", "y", false, "Insert the draft without asking")
+}
@@ -0,0 +1,186 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/viper"
+)
+
+// userLLMConfig sets llm: in the user config for the test
+func userLLMConfig(t *testing.T, config LLMConfig) {
+	t.Helper()
+	viper.Set("llm", map[string]interface{}{"url": config.URL, "model": config.Model, "api_key_env": config.APIKeyEnv})
+	t.Cleanup(func() { viper.Set("llm", nil) })
+}
+
+// llmServer answers every chat completion with content, and records the
+// last request body
+func llmServer(t *testing.T, content string, got *map[string]interface{}) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" || r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		if got != nil {
+			json.NewDecoder(r.Body).Decode(got)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{"message": map[string]string{"role": "assistant", "content": content}}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewLLMClient(t *testing.T) {
+	t.Setenv("RQM_OFFLINE", "")
+	t.Setenv("TEST_LLM_KEY", "")
+	t.Setenv("OPENAI_API_KEY", "")
+
+	if _, err := newLLMClient("rqm draft", &ProjectConfig{}); err == nil || !strings.Contains(err.Error(), "needs a model") {
+		t.Errorf("without a model: %v", err)
+	}
+	userLLMConfig(t, LLMConfig{URL: "http://localhost:11434/v1", Model: "llama3", APIKeyEnv: "TEST_LLM_KEY"})
+	if _, err := newLLMClient("rqm draft", &ProjectConfig{}); err == nil || !strings.Contains(err.Error(), "TEST_LLM_KEY is not set") {
+		t.Errorf("without the key: %v", err)
+	}
+	userLLMConfig(t, LLMConfig{URL: "http://localhost:11434/v1", Model: "llama3"})
+	if _, err := newLLMClient("rqm draft", &ProjectConfig{}); err != nil {
+		t.Errorf("a local endpoint needs no key: %v", err)
+	}
+
+	// The project picks the model, but not where requests go or which
+	// variable is sent as the key
+	t.Setenv("TEST_LLM_KEY", "secret")
+	project := &ProjectConfig{LLM: &LLMConfig{URL: "https://attacker.example/v1", Model: "llama3.1", APIKeyEnv: "TEST_LLM_KEY"}}
+	client, err := newLLMClient("rqm draft", project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.url != "http://localhost:11434/v1/chat/completions" || client.key != "" || client.model != "llama3.1" {
+		t.Errorf("project settings: url %s, key %q, model %s", client.url, client.key, client.model)
+	}
+
+	t.Setenv("RQM_OFFLINE", "1")
+	if _, err := newLLMClient("rqm draft", &ProjectConfig{}); err == nil || !strings.Contains(err.Error(), "offline") {
+		t.Errorf("offline: %v", err)
+	}
+}
+
+func TestDraftRequirement(t *testing.T) {
+	t.Setenv("RQM_OFFLINE", "")
+	t.Setenv("TEST_LLM_KEY", "secret")
+	var body map[string]interface{}
+	srv := llmServer(t, "```json\n"+`{
+  "summary": "Password reset.",
+  "description": "Users can reset a forgotten password through a link sent to their email address.",
+  "justification": "Locked-out users otherwise need support to regain access.",
+  "acceptance_test": "Given a registered user\nWhen they request a password reset\nThen they receive a reset link by email",
+  "priority": "urgent",
+  "tags": ["auth"]
+}`+"\n```", &body)
+
+	config, err := model.ParseYAML([]byte("version: \"1.0\"\nrequirements:\n  - summary: Login\n    tags: [auth, security]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	userLLMConfig(t, LLMConfig{URL: srv.URL + "/v1/", Model: "test-model", APIKeyEnv: "TEST_LLM_KEY"})
+	client, err := newLLMClient("rqm draft", &ProjectConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := draftRequirement(context.Background(), client, "users must be able to reset passwords", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req.Summary != "Password reset" || req.Status != "draft" || req.Priority != "" || len(req.Tags) != 1 {
+		t.Errorf("draft = %+v", req)
+	}
+	if !strings.HasPrefix(req.AcceptanceTest, "Given a registered user\n") {
+		t.Errorf("acceptance_test = %q", req.AcceptanceTest)
+	}
+	if body["model"] != "test-model" {
+		t.Errorf("model = %v", body["model"])
+	}
+	messages, _ := body["messages"].([]interface{})
+	if len(messages) != 2 || !strings.Contains(messages[1].(map[string]interface{})["content"].(string), "Existing tags: auth, security") {
+		t.Errorf("messages = %v", messages)
+	}
+
+	config.Requirements[0].Summary = "Password reset"
+	if _, err := draftRequirement(context.Background(), client, "reset passwords", config); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Errorf("duplicate summary: %v", err)
+	}
+}
+
+func TestInsertRequirement(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	original := `version: "1.0"
+# Authentication
+requirements:
+  - summary: Login
+    name: AUTH-001
+    requirements:
+      - summary: Login form
+  - summary: Audit log
+`
+	if err := os.WriteFile(file, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	req := &model.RequirementDetail{
+		Summary:        "Password reset",
+		Description:    "Users can reset a forgotten password.",
+		AcceptanceTest: "Given a registered user\nWhen they request a reset\nThen they receive a link\n",
+		Status:         "draft",
+	}
+	if err := insertRequirement(file, "AUTH-001", req); err != nil {
+		t.Fatal(err)
+	}
+	if err := insertRequirement(file, "", &model.RequirementDetail{Summary: "Session timeout", Status: "draft"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := insertRequirement(file, "AUTH-404", req); err == nil {
+		t.Error("inserting under an unknown parent succeeded")
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "# Authentication") || !strings.Contains(string(data), "acceptance_test: |\n") {
+		t.Errorf("file lost its comment or block style:\n%s", data)
+	}
+	config, err := model.Parse(file, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	login := config.Requirements[0]
+	if len(login.Requirements) != 2 || login.Requirements[1].Full == nil || login.Requirements[1].Full.Summary != "Password reset" {
+		t.Errorf("sub-requirements of Login = %+v", login.Requirements)
+	}
+	if got := config.Requirements[len(config.Requirements)-1]; got.Summary != "Session timeout" || got.Status != "draft" {
+		t.Errorf("last requirement = %+v", got)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	for answer, want := range map[string]bool{"y\n": true, "Yes\n": true, "n\n": false, "\n": false, "": false} {
+		if got := confirm(strings.NewReader(answer), ""); got != want {
+			t.Errorf("confirm(%q) = %v", answer, got)
+		}
+	}
+}
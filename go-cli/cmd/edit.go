@@ -0,0 +1,89 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// requirementEdit is a change to a single requirement, identified by summary
+type requirementEdit struct {
+	Summary string
+	// Set assigns scalar fields such as status or owner
+	Set map[string]string
+	// AddTags appends tags that aren't already present
+	AddTags []string
+	// RemoveTags removes tags, and the tags key once none are left
+	RemoveTags []string
+}
+
+// applyRequirementEdits writes edits to a requirements file. The file is
+// edited through yamlDocument so comments, key order and layout survive,
+// and all edits are applied before anything is written: either every edit
+// lands or the file is left untouched.
+func applyRequirementEdits(file string, edits []requirementEdit) error {
+	doc, err := loadYAMLDocument(file)
+	if err != nil {
+		return err
+	}
+	bySummary := doc.requirementNodes()
+
+	for _, edit := range edits {
+		node, ok := bySummary[edit.Summary]
+		if !ok {
+			return fmt.Errorf("requirement not found: %s", edit.Summary)
+		}
+		for _, key := range sortedKeys(edit.Set) {
+			setMappingScalar(node, key, edit.Set[key])
+		}
+		for _, tag := range edit.AddTags {
+			addSequenceValue(node, "tags", tag)
+		}
+		for _, tag := range edit.RemoveTags {
+			removeSequenceValue(node, "tags", tag)
+		}
+	}
+	return doc.save()
+}
+
+// editableFile rejects TOML and JSON requirements files for edits. They
+// are generated by other tools, so a change belongs in their source.
+func editableFile(file string) error {
+	if format := model.FormatOf(file); format != model.FormatYAML {
+		return fmt.Errorf("%s is a %s file; rqm only edits YAML requirements files, so change what generates it instead", file, format)
+	}
+	return nil
+}
+
+// writeFileAtomic replaces file via a temporary file in the same directory
+// so readers never see a partially written file
+func writeFileAtomic(file string, data []byte) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return os.Rename(tmp.Name(), file)
+}
@@ -0,0 +1,85 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const editTestContent = `version: "1.0"
+# Top-level requirements
+requirements:
+  - summary: Parent
+    name: P-1
+    status: draft # set by the import
+    tags: [api]
+    requirements:
+      - summary: Child
+        name: P-1.1
+`
+
+func writeEditTestFile(t *testing.T) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	if err := os.WriteFile(file, []byte(editTestContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestApplyRequirementEdits(t *testing.T) {
+	file := writeEditTestFile(t)
+
+	err := applyRequirementEdits(file, []requirementEdit{
+		{Summary: "Parent", Set: map[string]string{"status": "approved"}, AddTags: []string{"api", "security"}},
+		{Summary: "Child", Set: map[string]string{"owner": "alice"}, AddTags: []string{"db"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(file)
+	config, err := model.ParseYAML(data)
+	if err != nil {
+		t.Fatalf("Edited file no longer parses: %v\n%s", err, data)
+	}
+
+	parent := config.Requirements[0]
+	if parent.Status != "approved" || strings.Join(parent.Tags, ",") != "api,security" {
+		t.Errorf("Unexpected parent after edit: status %q, tags %v", parent.Status, parent.Tags)
+	}
+	child := parent.Requirements[0].Full
+	if child.Owner != "alice" || strings.Join(child.Tags, ",") != "db" {
+		t.Errorf("Unexpected child after edit: owner %q, tags %v", child.Owner, child.Tags)
+	}
+
+	for _, comment := range []string{"# Top-level requirements", "# set by the import"} {
+		if !strings.Contains(string(data), comment) {
+			t.Errorf("Expected comment %q to survive the edit:\n%s", comment, data)
+		}
+	}
+}
+
+func TestApplyRequirementEditsIsAllOrNothing(t *testing.T) {
+	file := writeEditTestFile(t)
+
+	err := applyRequirementEdits(file, []requirementEdit{
+		{Summary: "Parent", Set: map[string]string{"status": "approved"}},
+		{Summary: "Missing", Set: map[string]string{"status": "approved"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "requirement not found: Missing") {
+		t.Fatalf("Expected not found error, got %v", err)
+	}
+
+	data, _ := os.ReadFile(file)
+	if string(data) != editTestContent {
+		t.Errorf("File changed despite failed edit:\n%s", data)
+	}
+}
@@ -0,0 +1,392 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	envMatrixResults []string
+	envMatrixFormat  string
+)
+
+// envMatrixRow is the verification of one requirement per environment
+type envMatrixRow struct {
+	req *model.RequirementDetail
+	// results maps each environment with results for the requirement to
+	// pass, fail or skip
+	results map[string]string
+	// targets are the environments req must be verified on: its
+	// environments field, or every environment with results when that's
+	// empty
+	targets []string
+}
+
+// missing are the targets req isn't verified on
+func (r envMatrixRow) missing() []string {
+	var missing []string
+	for _, env := range r.targets {
+		if r.results[env] != "pass" {
+			missing = append(missing, env)
+		}
+	}
+	return missing
+}
+
+// partial tells whether req is verified on some of its targets but not all
+func (r envMatrixRow) partial() bool {
+	missing := r.missing()
+	return len(missing) > 0 && len(missing) < len(r.targets)
+}
+
+var envMatrixCmd = &cobra.Command{
+	Use:   "envmatrix [file]",
+	Short: "Show which environments each requirement is verified on",
+	Long: `Combine the test results of several environments into a matrix of
+requirements by environment, and list requirements verified on some
+platforms but not others.
+
+Results are JUnit XML files written by rqm test --format junit, one per
+environment, given with --results. The environment of a file is the one
+rqm test recorded; ENV=FILE sets it for files from other tools, whose
+test cases are matched to requirements by name.
+
+A requirement must be verified on the environments its environments field
+lists, or on every environment with results when it lists none:
+
+  - summary: Native installer
+    environments: [linux, windows, arm64]
+
+A failing test is an error. A requirement verified on some of its
+environments but missing or failing on others is a warning, which fails
+the command only with --fail-on warning.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm envmatrix --results junit-linux.xml --results junit-windows.xml
+  rqm envmatrix requirements.yml --results arm64=results/arm64.xml --format markdown
+  rqm envmatrix --results 'junit-*.xml' --format csv > envmatrix.csv`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkFailOn(); err != nil {
+			return err
+		}
+		if len(envMatrixResults) == 0 {
+			return fmt.Errorf("no test results: give JUnit files with --results")
+		}
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+
+		results := make(map[string]map[*model.RequirementDetail]string)
+		for _, spec := range envMatrixResults {
+			if err := loadEnvResults(config, spec, results); err != nil {
+				return err
+			}
+		}
+		envs, rows := buildEnvMatrix(config, results)
+
+		switch envMatrixFormat {
+		case "text":
+			writeEnvMatrix(os.Stdout, envs, rows)
+		case "markdown":
+			writeEnvMatrixMarkdown(os.Stdout, envs, rows)
+		case "csv":
+			if err := writeEnvMatrixCSV(os.Stdout, envs, rows); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown output format: %s", envMatrixFormat)
+		}
+
+		failed, partial := 0, 0
+		for _, row := range rows {
+			for _, result := range row.results {
+				if result == "fail" {
+					failed++
+					break
+				}
+			}
+			if row.partial() {
+				partial++
+			}
+		}
+		var failure error
+		if failed > 0 {
+			failure = validationError("%d requirement(s) with failing tests", failed)
+		}
+		return applyFailOn(failure, partial)
+	},
+}
+
+// loadEnvResults reads the JUnit files of spec, FILE or ENV=FILE where
+// FILE may be a glob, into results by environment and requirement
+func loadEnvResults(config *model.RequirementConfig, spec string, results map[string]map[*model.RequirementDetail]string) error {
+	env, pattern := "", spec
+	if i := strings.Index(spec, "="); i > 0 {
+		env, pattern = spec[:i], spec[i+1:]
+	}
+	files, err := expandGlob(pattern)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("file does not exist: %s", path)
+			}
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		suites, err := parseJUnit(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for _, suite := range suites {
+			suiteEnv := env
+			if suiteEnv == "" {
+				suiteEnv = propertyValue(suite.Properties, "rqm.environment")
+			}
+			if suiteEnv == "" {
+				return fmt.Errorf("%s doesn't record its environment: give it as ENV=%s", path, path)
+			}
+			if results[suiteEnv] == nil {
+				results[suiteEnv] = make(map[*model.RequirementDetail]string)
+			}
+			for _, tc := range suite.Cases {
+				ref := propertyValue(tc.Properties, "rqm.requirement")
+				if ref == "" {
+					ref = tc.Name
+				}
+				req, ok := config.Find(ref)
+				if !ok {
+					continue
+				}
+				results[suiteEnv][req] = combineResults(results[suiteEnv][req], tc.result())
+			}
+		}
+	}
+	return nil
+}
+
+// expandGlob returns the files matching pattern, or pattern itself when
+// it has no glob characters
+func expandGlob(pattern string) ([]string, error) {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{pattern}, nil
+	}
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match %s", pattern)
+	}
+	return files, nil
+}
+
+// parseJUnit reads the suites of a JUnit file, whose root is either
+// testsuites or a single testsuite
+func parseJUnit(data []byte) ([]junitTestSuite, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	switch root.XMLName.Local {
+	case "testsuites":
+		var suites junitTestSuites
+		err := xml.Unmarshal(data, &suites)
+		return suites.Suites, err
+	case "testsuite":
+		var suite junitTestSuite
+		err := xml.Unmarshal(data, &suite)
+		return []junitTestSuite{suite}, err
+	default:
+		return nil, fmt.Errorf("not JUnit XML: root element is %s", root.XMLName.Local)
+	}
+}
+
+// result is pass, fail or skip; errors count as failures
+func (tc junitTestCase) result() string {
+	switch {
+	case tc.Failure != nil || tc.Error != nil:
+		return "fail"
+	case tc.Skipped != nil:
+		return "skip"
+	}
+	return "pass"
+}
+
+// combineResults merges two results of a requirement in one environment:
+// any failure fails it, and a pass beats a skip
+func combineResults(a, b string) string {
+	for _, result := range []string{"fail", "pass", "skip"} {
+		if a == result || b == result {
+			return result
+		}
+	}
+	return ""
+}
+
+// buildEnvMatrix lists the environments, those with results and those
+// requirements name, and a row for every requirement with results or
+// environments, in tree order
+func buildEnvMatrix(config *model.RequirementConfig, results map[string]map[*model.RequirementDetail]string) ([]string, []envMatrixRow) {
+	var tested []string
+	for env := range results {
+		tested = append(tested, env)
+	}
+	sort.Strings(tested)
+	envs := slices.Clone(tested)
+
+	var rows []envMatrixRow
+	for _, req := range config.Flatten() {
+		row := envMatrixRow{req: req, results: make(map[string]string), targets: req.Environments}
+		if len(row.targets) == 0 {
+			row.targets = tested
+		}
+		for env, byReq := range results {
+			if result, ok := byReq[req]; ok {
+				row.results[env] = result
+			}
+		}
+		for _, env := range req.Environments {
+			if !slices.Contains(envs, env) {
+				envs = append(envs, env)
+			}
+		}
+		if len(row.results) > 0 || len(req.Environments) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	sort.Strings(envs)
+	return envs, rows
+}
+
+// envCell shows the result of row in env: pass, fail, skip, "missing" for
+// a target without results, or "-" where the requirement doesn't apply
+func envCell(row envMatrixRow, env string) string {
+	if result, ok := row.results[env]; ok {
+		return result
+	}
+	if slices.Contains(row.targets, env) {
+		return "missing"
+	}
+	return "-"
+}
+
+// writeEnvMatrix prints the matrix as a table, then the requirements
+// verified on only some of their environments
+func writeEnvMatrix(w io.Writer, envs []string, rows []envMatrixRow) {
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No test results for any requirement")
+		return
+	}
+	lines := [][]string{append([]string{"REQUIREMENT"}, upperAll(envs)...)}
+	for _, row := range rows {
+		cells := []string{displayName(row.req)}
+		for _, env := range envs {
+			cells = append(cells, envCell(row, env))
+		}
+		lines = append(lines, cells)
+	}
+	widths := make([]int, len(lines[0]))
+	for _, cells := range lines {
+		for i, cell := range cells {
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+	for _, cells := range lines {
+		for i, cell := range cells {
+			if i < len(cells)-1 {
+				cell += blanks(widths[i] - len([]rune(cell)) + 2)
+			}
+			fmt.Fprint(w, cell)
+		}
+		fmt.Fprintln(w)
+	}
+
+	partial := 0
+	for _, row := range rows {
+		if row.partial() {
+			if partial == 0 {
+				fmt.Fprintln(w)
+			}
+			partial++
+			fmt.Fprintf(w, "%s %s is not verified on %s\n", warnMark(), displayName(row.req), strings.Join(row.missing(), ", "))
+		}
+	}
+	fmt.Fprintf(w, "\n%d requirement(s) across %d environment(s); %d verified on some but not all\n", len(rows), len(envs), partial)
+}
+
+// writeEnvMatrixMarkdown writes the matrix as a markdown table
+func writeEnvMatrixMarkdown(w io.Writer, envs []string, rows []envMatrixRow) {
+	fmt.Fprint(w, "# Verification by environment\n\n")
+	fmt.Fprintf(w, "| Requirement | %s |\n|-------------|", strings.Join(envs, " | "))
+	for range envs {
+		fmt.Fprint(w, "---|")
+	}
+	fmt.Fprintln(w)
+	for _, row := range rows {
+		cells := []string{markdownCell(displayName(row.req))}
+		for _, env := range envs {
+			cells = append(cells, envCell(row, env))
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+	}
+}
+
+// writeEnvMatrixCSV writes the matrix with ID and summary columns
+func writeEnvMatrixCSV(out io.Writer, envs []string, rows []envMatrixRow) error {
+	w := csv.NewWriter(out)
+	w.Write(append([]string{"ID", "Summary"}, envs...))
+	for _, row := range rows {
+		record := []string{row.req.Name, row.req.Summary}
+		for _, env := range envs {
+			record = append(record, envCell(row, env))
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// upperAll upper-cases each of s, for table headers
+func upperAll(s []string) []string {
+	upper := make([]string, len(s))
+	for i, v := range s {
+		upper[i] = strings.ToUpper(v)
+	}
+	return upper
+}
+
+func init() {
+	rootCmd.AddCommand(envMatrixCmd)
+	envMatrixCmd.Flags().StringArrayVar(&envMatrixResults, "results", nil, "JUnit XML results of an environment, as FILE or ENV=FILE; FILE may be a glob (repeatable)")
+	envMatrixCmd.Flags().StringVarP(&envMatrixFormat, "format", "f", "text", "Output format (text, markdown, csv)")
+	envMatrixCmd.Flags().StringVar(&failOn, "fail-on", "error", "Lowest severity that fails the command (error, warning, none)")
+}
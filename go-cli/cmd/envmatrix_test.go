@@ -0,0 +1,102 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const envMatrixYAML = `version: "1.0"
+requirements:
+  - summary: Installer
+    name: INST-001
+    environments: [linux, windows, arm64]
+  - summary: Config file
+    name: CONF-001
+  - summary: Tray icon
+    environments: [windows]
+`
+
+// Results of a tool other than rqm test, matched by test case name
+const foreignJUnit = `<testsuite name="win" tests="2">
+  <testcase name="INST-001" time="1"><failure message="exit 1"/></testcase>
+  <testcase name="Tray icon" time="1"/>
+  <testcase name="Unrelated" time="1"/>
+</testsuite>
+`
+
+func TestEnvMatrix(t *testing.T) {
+	config, err := model.ParseYAML([]byte(envMatrixYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flat := config.Flatten()
+
+	// Linux results as rqm test writes them
+	dir := t.TempDir()
+	var linux bytes.Buffer
+	writeJUnit(&linux, "requirements.yml", "linux", []acceptanceResult{
+		{req: flat[0], Result: "pass", Duration: time.Second},
+		{req: flat[1], Result: "pass"},
+		{req: flat[2], Result: "skip", Message: "not required on linux"},
+	})
+	os.WriteFile(filepath.Join(dir, "junit-linux.xml"), linux.Bytes(), 0644)
+	os.WriteFile(filepath.Join(dir, "win.xml"), []byte(foreignJUnit), 0644)
+
+	results := make(map[string]map[*model.RequirementDetail]string)
+	if err := loadEnvResults(config, filepath.Join(dir, "junit-*.xml"), results); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadEnvResults(config, filepath.Join(dir, "win.xml"), results); err == nil || !strings.Contains(err.Error(), "doesn't record its environment") {
+		t.Errorf("Expected an error for results without an environment, got %v", err)
+	}
+	if err := loadEnvResults(config, "windows="+filepath.Join(dir, "win.xml"), results); err != nil {
+		t.Fatal(err)
+	}
+
+	envs, rows := buildEnvMatrix(config, results)
+	if strings.Join(envs, ",") != "arm64,linux,windows" || len(rows) != 3 {
+		t.Fatalf("Unexpected environments %v and %d rows", envs, len(rows))
+	}
+	var got []string
+	for _, row := range rows {
+		var cells []string
+		for _, env := range envs {
+			cells = append(cells, envCell(row, env))
+		}
+		got = append(got, strings.Join(cells, " "))
+	}
+	want := []string{
+		"missing pass fail",
+		"- pass missing",
+		"- skip pass",
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("envCell() = %q, want %q", got, want)
+	}
+	if !rows[0].partial() || !rows[1].partial() || rows[2].partial() {
+		t.Errorf("Expected Installer and Config file verified on only some environments")
+	}
+
+	var text bytes.Buffer
+	writeEnvMatrix(&text, envs, rows)
+	for _, want := range []string{
+		"REQUIREMENT             ARM64    LINUX  WINDOWS",
+		"[INST-001] Installer    missing  pass   fail",
+		"[INST-001] Installer is not verified on windows, arm64",
+		"3 requirement(s) across 3 environment(s); 2 verified on some but not all",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Expected %q in:\n%s", want, text.String())
+		}
+	}
+}
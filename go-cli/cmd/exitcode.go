@@ -0,0 +1,81 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exit codes shared by every command. Anything that isn't a check result
+// (bad flags, unreadable files, a missing validator) is a tool error.
+const (
+	exitValidation = 1
+	exitCycles     = 2
+	exitToolError  = 3
+)
+
+// failOnValues are the severity thresholds --fail-on accepts
+var failOnValues = []string{"error", "warning", "none"}
+
+// failOn is the --fail-on threshold of validate, check, ci and trace
+var failOn string
+
+// exitError is a check result that should end the process with code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// validationError reports requirements that failed validation (exit code 1)
+func validationError(format string, args ...interface{}) error {
+	return &exitError{code: exitValidation, err: fmt.Errorf(format, args...)}
+}
+
+// cycleError reports circular references (exit code 2)
+func cycleError(format string, args ...interface{}) error {
+	return &exitError{code: exitCycles, err: fmt.Errorf(format, args...)}
+}
+
+// exitCode maps the error a command returned to the process exit code
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exit *exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	return exitToolError
+}
+
+// checkFailOn rejects unknown --fail-on values before any work is done
+func checkFailOn() error {
+	for _, v := range failOnValues {
+		if failOn == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown --fail-on value: %s (expected error, warning or none)", failOn)
+}
+
+// applyFailOn turns a command's outcome into its result under --fail-on:
+// failure is the error for the errors found (nil when there were none) and
+// warnings the number of warnings. With "none" only tool errors fail.
+func applyFailOn(failure error, warnings int) error {
+	switch failOn {
+	case "none":
+		return nil
+	case "warning":
+		if failure == nil && warnings > 0 {
+			return validationError("%d warning(s) with --fail-on warning", warnings)
+		}
+	}
+	return failure
+}
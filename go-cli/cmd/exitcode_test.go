@@ -0,0 +1,83 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"success", nil, 0},
+		{"validation", validationError("validation failed"), exitValidation},
+		{"cycles", cycleError("circular references detected"), exitCycles},
+		{"wrapped", fmt.Errorf("check: %w", cycleError("cycles")), exitCycles},
+		{"tool error", fmt.Errorf("file does not exist: x.yml"), exitToolError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailOn(t *testing.T) {
+	tests := []struct {
+		failOn   string
+		result   ValidationResult
+		wantCode int
+	}{
+		{"error", ValidationResult{Valid: true, Warnings: []string{"w"}}, 0},
+		{"error", ValidationResult{Valid: false, Errors: []string{"e"}}, exitValidation},
+		{"warning", ValidationResult{Valid: true, Warnings: []string{"w"}}, exitValidation},
+		{"warning", ValidationResult{Valid: true}, 0},
+		{"none", ValidationResult{Valid: false, Errors: []string{"e"}, Warnings: []string{"w"}}, 0},
+	}
+
+	defer func() { failOn = "error" }()
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%v/%d", tt.failOn, tt.result.Valid, len(tt.result.Warnings)), func(t *testing.T) {
+			failOn = tt.failOn
+			if got := exitCode(validationFailure(&tt.result)); got != tt.wantCode {
+				t.Errorf("Exit code %d, want %d", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCheckFailure(t *testing.T) {
+	failOn = "error"
+	inactive := []InactiveOwner{{Summary: "A", Owner: "bob", Reason: "inactive"}}
+
+	if got := exitCode(checkFailure(&CycleCheckResult{HasCycles: true}, inactive, nil, nil, 0)); got != exitCycles {
+		t.Errorf("Expected cycles to take precedence (exit %d), got %d", exitCycles, got)
+	}
+	if got := exitCode(checkFailure(&CycleCheckResult{}, inactive, nil, nil, 0)); got != exitValidation {
+		t.Errorf("Expected inactive owners to exit %d, got %d", exitValidation, got)
+	}
+	if err := checkFailure(&CycleCheckResult{}, nil, nil, nil, 1); err != nil {
+		t.Errorf("Expected unresolved owners to pass with --fail-on error, got %v", err)
+	}
+}
+
+func TestCheckFailOn(t *testing.T) {
+	defer func() { failOn = "error" }()
+	failOn = "warnings"
+	if err := checkFailOn(); err == nil {
+		t.Error("Expected an unknown --fail-on value to be rejected")
+	}
+	failOn = "none"
+	if err := checkFailOn(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
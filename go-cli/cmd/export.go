@@ -0,0 +1,158 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat string
+	exportOutput string
+	exportTitle  string
+	// exportNumbering is how the sections of a document are numbered
+	exportNumbering string
+	// exportTemplate is the Word template of a docx export
+	exportTemplate string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export requirements to other formats",
+	Long: `Export requirements to formats other tools consume.
+
+Formats:
+  asciidoc an AsciiDoc document for Asciidoctor or an Antora component: a
+           section per requirement, nested as the requirements are, with
+           a table of its metadata and its text fields. Each section is
+           anchored at the requirement's ID, so other pages cross-reference
+           it as <<AUTH-001>>; IDs AsciiDoc doesn't accept as anchors,
+           such as ones starting with a digit, are prefixed with req-.
+           --output is the file to write (default requirements.adoc).
+  docx     a Word document for review: a heading per requirement, nested
+           as the requirements are, with a table of its metadata and its
+           description, justification and acceptance test. --template
+           bases it on a .dotx or .docx file, keeping the template's
+           styles, page setup, headers and footers; the requirements
+           replace its paragraph reading {{requirements}}, or else its
+           whole body. Status and priority values are set in the
+           character style named after them, such as "Status Draft" or
+           "Priority Critical", which the template can define; styles it
+           lacks are added. --output is the file to write (default
+           requirements.docx).
+  gherkin  one .feature file per requirement whose acceptance_test is
+           written as Given/When/Then steps, tagged with the requirement
+           ID (@AUTH-001; spaces, @ and # become underscores), for godog
+           or cucumber to run. --output is the directory to write to
+           (default features). rqm trace features checks the tags of
+           feature files against the requirements.
+  html     a standalone HTML page: a heading per requirement, nested as the
+           requirements are and anchored at its ID, with a table of its
+           metadata and its text fields. --output is the file to write
+           (default requirements.html).
+  markdown a Markdown document laid out as the html one, for a wiki or a
+           docs site. --output is the file to write (default
+           requirements.md). It is meant for reading: rqm import --from
+           markdown would take its title for a requirement and its
+           metadata tables for descriptions, so it doesn't import back.
+  pdf      a paginated requirements specification for sign-off: a cover
+           page with the document's title (--title) and an approval
+           table, a table of contents, and a section per requirement with
+           a table of its metadata followed by its description,
+           justification and acceptance test. Sections are numbered
+           unless --numbering is none. --output is the file to write
+           (default requirements.pdf).
+  site     the web UI as a static site, with the requirements, their
+           graph and its layout pre-rendered into data.json, for GitHub
+           Pages or any static host, so the requirements can be browsed
+           without rqm serve. The site is read-only: editing, comments
+           and server-side search need rqm serve. --output (or --out) is
+           the directory to write to (default public).
+
+--numbering hierarchical numbers the sections of an asciidoc, docx, html,
+markdown or pdf document by their place in the requirements tree, 1, 1.1,
+1.2, 2 and so on, and ends the document with a table mapping each number
+to the requirement's ID and summary, so a number quoted in a review can be
+traced back to its requirement. Numbers change as requirements are added
+or moved; IDs don't.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm export --format gherkin
+  rqm export requirements.yml --format gherkin -o test/features
+  rqm export requirements.yml --format asciidoc -o docs/modules/ROOT/pages/requirements.adoc
+  rqm export requirements.yml --format docx --template corp-spec.dotx -o spec.docx
+  rqm export requirements.yml --format markdown --numbering hierarchical -o docs/requirements.md
+  rqm export requirements.yml --format pdf --title "Payments Platform SRS" -o srs.pdf
+  rqm export requirements.yml --format site --out ./public`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		numbered, err := parseNumbering(exportNumbering, exportFormat)
+		if err != nil {
+			return err
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+
+		switch exportFormat {
+		case "gherkin":
+			dir := exportOutput
+			if dir == "" {
+				dir = "features"
+			}
+			return runGherkinExport(config, file, dir)
+		case "asciidoc":
+			return writeExportFile(cmp.Or(exportOutput, "requirements.adoc"), exportAsciiDoc(config, exportTitle, numbered))
+		case "docx":
+			// A template has a title page of its own, unless --title asks
+			// for one
+			title := exportTitle
+			if exportTemplate != "" && !cmd.Flags().Changed("title") {
+				title = ""
+			}
+			return runDOCXExport(config, cmp.Or(exportOutput, "requirements.docx"), title, numbered, exportTemplate)
+		case "html":
+			return writeExportFile(cmp.Or(exportOutput, "requirements.html"), exportHTMLDocument(config, exportTitle, numbered))
+		case "markdown":
+			return writeExportFile(cmp.Or(exportOutput, "requirements.md"), exportMarkdownDocument(config, exportTitle, numbered))
+		case "pdf":
+			return runPDFExport(config, file, cmp.Or(exportOutput, "requirements.pdf"), exportTitle, numbered)
+		case "site":
+			dir := exportOutput
+			if dir == "" {
+				dir = "public"
+			}
+			webFS, err := fs.Sub(webUI, "web-dist")
+			if err != nil {
+				return fmt.Errorf("failed to access embedded web UI: %w", err)
+			}
+			return runSiteExport(config, file, dir, webFS)
+		default:
+			return fmt.Errorf("unknown output format: %s", exportFormat)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "", "Export format (asciidoc, docx, gherkin, html, markdown, pdf, site)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Where to write the export (a directory, default features for gherkin and public for site; a file, default requirements.adoc, .docx, .html, .md or .pdf for the document formats)")
+	exportCmd.Flags().StringVar(&exportOutput, "out", "", "Same as --output")
+	exportCmd.Flags().StringVar(&exportTitle, "title", "Requirements Specification", "Title of the document (asciidoc, docx, html, markdown, pdf)")
+	exportCmd.Flags().StringVar(&exportNumbering, "numbering", "", "Section numbering of the document (asciidoc, docx, html, markdown, pdf): hierarchical or none (default none, hierarchical for pdf)")
+	exportCmd.Flags().StringVar(&exportTemplate, "template", "", "Word template (.dotx or .docx) to base the document on (docx)")
+	exportCmd.MarkFlagRequired("format")
+}
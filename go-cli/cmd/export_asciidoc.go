@@ -0,0 +1,103 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+var (
+	// asciidocIDPattern matches the IDs Asciidoctor accepts as anchors
+	asciidocIDPattern = regexp.MustCompile(`^[\pL_:][\pL\pN_:.-]*$`)
+	// asciidocIDInvalid matches what an anchor can't have
+	asciidocIDInvalid = regexp.MustCompile(`[^\pL\pN_:.-]+`)
+)
+
+// asciidocAnchor is the anchor of a requirement: its ID, for <<AUTH-001>>
+// cross references, or for an ID Asciidoctor doesn't accept, such as one
+// starting with a digit, the ID prefixed with req-. It is empty for a
+// requirement without an ID.
+func asciidocAnchor(req *model.RequirementDetail) string {
+	if req.Name == "" || asciidocIDPattern.MatchString(req.Name) {
+		return req.Name
+	}
+	return "req-" + asciidocIDInvalid.ReplaceAllString(req.Name, "-")
+}
+
+// asciidocCell escapes a table cell's text
+func asciidocCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// asciidocRef is a cross reference to the requirement ref names, or ref
+// as it is when it names no requirement with an anchor
+func asciidocRef(config *model.RequirementConfig, ref string) string {
+	if target, ok := config.Find(ref); ok {
+		if anchor := asciidocAnchor(target); anchor != "" {
+			return "<<" + anchor + ">>"
+		}
+	}
+	return ref
+}
+
+// exportAsciiDoc renders config as an AsciiDoc document: a section per
+// requirement, nested as the requirements are, anchored at the
+// requirement's ID with the ID and summary as its reference text
+func exportAsciiDoc(config *model.RequirementConfig, title string, numbered bool) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "= %s\n:toc:\n", title)
+
+	sections := specSections(config)
+	for _, s := range sections {
+		req := s.req
+		b.WriteString("\n")
+		if anchor := asciidocAnchor(req); anchor != "" {
+			fmt.Fprintf(&b, "[#%s,reftext=\"%s\"]\n", anchor, strings.ReplaceAll(req.Name+" "+req.Summary, `"`, `\"`))
+		}
+		// Asciidoctor has five levels of sections below the title
+		fmt.Fprintf(&b, "%s %s\n", strings.Repeat("=", min(s.depth, 4)+2), s.heading(numbered))
+
+		if rows := specMetadata(config, req); len(rows) > 0 {
+			b.WriteString("\n[cols=\"1,3\"]\n|===\n")
+			for _, row := range rows {
+				value := asciidocCell(row[1])
+				switch row[0] {
+				case "Relations":
+					var relations []string
+					for _, rel := range req.Relations {
+						relations = append(relations, strings.ReplaceAll(rel.Type, "_", " ")+" "+asciidocRef(config, rel.Target))
+					}
+					value = asciidocCell(strings.Join(relations, "; "))
+				case "Superseded by":
+					value = asciidocCell(asciidocRef(config, req.SupersededBy))
+				}
+				fmt.Fprintf(&b, "|%s |%s\n", asciidocCell(row[0]), value)
+			}
+			b.WriteString("|===\n")
+		}
+
+		// An open block keeps a field of several paragraphs under its title
+		for _, field := range specFields(req) {
+			fmt.Fprintf(&b, "\n.%s\n--\n%s\n--\n", field[0], field[1])
+		}
+	}
+
+	if numbered {
+		fmt.Fprintf(&b, "\n== %s\n\n[cols=\"1,1,3\",options=\"header\"]\n|===\n|Number |ID |Summary\n", specNumbersTitle)
+		for _, s := range sections {
+			id := asciidocCell(s.req.Name)
+			if anchor := asciidocAnchor(s.req); anchor != "" {
+				id = "<<" + anchor + "," + id + ">>"
+			}
+			fmt.Fprintf(&b, "|%s |%s |%s\n", s.number, id, asciidocCell(s.req.Summary))
+		}
+		b.WriteString("|===\n")
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,108 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"go.yaml.in/yaml/v3"
+)
+
+func TestExportAsciiDoc(t *testing.T) {
+	var config model.RequirementConfig
+	err := yaml.Unmarshal([]byte(`version: "1.0"
+requirements:
+  - summary: Authentication
+    name: AUTH-1
+    status: approved
+    description: "Users sign in.\n\nWith SSO | password."
+    relations:
+      - type: depends_on
+        target: Password login
+      - type: relates_to
+        target: Billing
+    requirements:
+      - summary: Password login
+        name: 2.1
+        acceptance_test: A wrong password is rejected
+      - summary: Lockout
+`), &config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `= Auth Spec
+:toc:
+
+[#AUTH-1,reftext="AUTH-1 Authentication"]
+== Authentication
+
+[cols="1,3"]
+|===
+|ID |AUTH-1
+|Status |approved
+|Relations |depends on <<req-2.1>>; relates to Billing
+|===
+
+.Description
+--
+Users sign in.
+
+With SSO | password.
+--
+
+[#req-2.1,reftext="2.1 Password login"]
+=== Password login
+
+[cols="1,3"]
+|===
+|ID |2.1
+|===
+
+.Acceptance test
+--
+A wrong password is rejected
+--
+
+=== Lockout
+`
+	if got := string(exportAsciiDoc(&config, "Auth Spec", false)); got != want {
+		t.Errorf("Unexpected AsciiDoc:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestAsciiDocAnchor(t *testing.T) {
+	for name, want := range map[string]string{
+		"AUTH-001": "AUTH-001",
+		"sec.4_2":  "sec.4_2",
+		"42":       "req-42",
+		"R 1/2":    "req-R-1-2",
+		"":         "",
+	} {
+		if got := asciidocAnchor(&model.RequirementDetail{Name: name}); got != want {
+			t.Errorf("asciidocAnchor(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestExportAsciiDocNumbered(t *testing.T) {
+	var config model.RequirementConfig
+	if err := yaml.Unmarshal([]byte(pdfRequirements), &config); err != nil {
+		t.Fatal(err)
+	}
+	got := string(exportAsciiDoc(&config, "Auth Spec", true))
+	for _, want := range []string{
+		"\n== 1 Authentication\n",
+		"\n=== 1.2 Lockout\n",
+		"== Requirement numbers\n\n[cols=\"1,1,3\",options=\"header\"]\n|===\n|Number |ID |Summary\n|1 |<<AUTH-1,AUTH-1>> |Authentication\n",
+		"|1.2 | |Lockout\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+}
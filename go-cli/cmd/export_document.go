@@ -0,0 +1,136 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/render"
+)
+
+// exportMarkdownDocument renders config as a Markdown specification: a
+// heading per requirement, nested as the requirements are, with a table
+// of its metadata and its text fields
+func exportMarkdownDocument(config *model.RequirementConfig, title string, numbered bool) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", title)
+
+	sections := specSections(config)
+	headings := make(map[*model.RequirementDetail]string, len(sections))
+	for _, s := range sections {
+		headings[s.req] = s.heading(numbered)
+	}
+	table := func(rows [][]string, header []string) string {
+		var t strings.Builder
+		t.WriteString("|")
+		for _, cell := range header {
+			fmt.Fprintf(&t, " %s |", markdownCell(cell))
+		}
+		t.WriteString("\n|" + strings.Repeat("---|", len(header)) + "\n")
+		for _, row := range rows {
+			t.WriteString("|")
+			for _, cell := range row {
+				fmt.Fprintf(&t, " %s |", markdownCell(cell))
+			}
+			t.WriteString("\n")
+		}
+		return t.String()
+	}
+
+	config.Walk(&render.Markdown[*model.RequirementDetail]{
+		W:       &b,
+		Level:   2,
+		Heading: func(req *model.RequirementDetail) string { return headings[req] },
+		Body: func(req *model.RequirementDetail) string {
+			// Each part is a block of its own, so a label doesn't run on
+			// from the text above it
+			var parts []string
+			if rows := specMetadata(config, req); len(rows) > 0 {
+				parts = append(parts, strings.TrimSuffix(table(rows, []string{"Field", "Value"}), "\n"))
+			}
+			for _, field := range specFields(req) {
+				parts = append(parts, "**"+field[0]+"**\n\n"+field[1])
+			}
+			return strings.Join(parts, "\n\n")
+		},
+	})
+
+	if numbered {
+		rows := specNumberRows(sections)
+		fmt.Fprintf(&b, "## %s\n\n%s", specNumbersTitle, table(rows[1:], rows[0]))
+	}
+	return []byte(b.String())
+}
+
+// htmlDocumentStyle is the stylesheet of an HTML specification
+const htmlDocumentStyle = `body{font-family:system-ui,sans-serif;max-width:60em;margin:2em auto;padding:0 1em;line-height:1.45}` +
+	`table{border-collapse:collapse;margin:.5em 0}th,td{border:1px solid #ccc;padding:4px 8px;text-align:left;vertical-align:top}` +
+	`th{background:#f3f3f3}.field{font-weight:bold;margin-bottom:0}` +
+	`.status-draft,.priority-low{color:#777}.status-approved,.status-verified{color:#2e7d32;font-weight:bold}` +
+	`.status-deprecated{color:#777;text-decoration:line-through}.priority-critical{color:#c00;font-weight:bold}.priority-high{color:#c55a11}`
+
+// htmlAnchor is the anchor of a requirement's heading: its ID, or for a
+// requirement without one, its number in the document
+func htmlAnchor(s *specSection) string {
+	if s.req.Name != "" {
+		return s.req.Name
+	}
+	return "req-" + s.number
+}
+
+// exportHTMLDocument renders config as a standalone HTML specification: a
+// heading per requirement, nested as the requirements are and anchored at
+// its ID, with a table of its metadata and its text fields
+func exportHTMLDocument(config *model.RequirementConfig, title string, numbered bool) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>%s</style>\n</head>\n<body>\n<h1>%[1]s</h1>\n",
+		html.EscapeString(title), htmlDocumentStyle)
+
+	sections := specSections(config)
+	for _, s := range sections {
+		level := min(s.depth+2, 6)
+		fmt.Fprintf(&b, "<h%d id=\"%s\">%s</h%[1]d>\n", level, html.EscapeString(htmlAnchor(s)), html.EscapeString(s.heading(numbered)))
+		if rows := specMetadata(config, s.req); len(rows) > 0 {
+			b.WriteString("<table>\n")
+			for _, row := range rows {
+				// Status and priority values can be styled by value
+				class := ""
+				switch row[0] {
+				case "Status", "Priority":
+					class = fmt.Sprintf(" class=\"%s-%s\"", strings.ToLower(row[0]), html.EscapeString(row[1]))
+				}
+				fmt.Fprintf(&b, "<tr><th>%s</th><td%s>%s</td></tr>\n", html.EscapeString(row[0]), class, html.EscapeString(row[1]))
+			}
+			b.WriteString("</table>\n")
+		}
+		for _, field := range specFields(s.req) {
+			fmt.Fprintf(&b, "<p class=\"field\">%s</p>\n%s", html.EscapeString(field[0]), confluenceParagraphs(field[1]))
+		}
+	}
+
+	if numbered {
+		fmt.Fprintf(&b, "<h2 id=\"requirement-numbers\">%s</h2>\n<table>\n<tr><th>Number</th><th>ID</th><th>Summary</th></tr>\n", specNumbersTitle)
+		for _, s := range sections {
+			fmt.Fprintf(&b, "<tr><td><a href=\"#%s\">%s</a></td><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(htmlAnchor(s)), html.EscapeString(s.number), html.EscapeString(s.req.Name), html.EscapeString(s.req.Summary))
+		}
+		b.WriteString("</table>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return []byte(b.String())
+}
+
+// writeExportFile writes a document export to output
+func writeExportFile(output string, data []byte) error {
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	fmt.Printf("%s Wrote %s\n", okMark(), output)
+	return nil
+}
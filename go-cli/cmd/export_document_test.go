@@ -0,0 +1,115 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"go.yaml.in/yaml/v3"
+)
+
+func TestExportMarkdownDocument(t *testing.T) {
+	var config model.RequirementConfig
+	if err := yaml.Unmarshal([]byte(pdfRequirements), &config); err != nil {
+		t.Fatal(err)
+	}
+	config.Requirements[0].Justification = "Accounts are personal.\n"
+
+	want := `# Auth Spec
+
+## 1 Authentication
+
+| Field | Value |
+|---|---|
+| ID | AUTH-1 |
+| Status | approved |
+| Owner | Alice Smith (@alice) |
+
+**Description**
+
+Users sign in.
+
+**Justification**
+
+Accounts are personal.
+
+### 1.1 Password login
+
+| Field | Value |
+|---|---|
+| ID | AUTH-2 |
+
+**Acceptance test**
+
+A wrong password is rejected
+
+### 1.2 Lockout
+
+## 2 Reporting
+
+## Requirement numbers
+
+| Number | ID | Summary |
+|---|---|---|
+| 1 | AUTH-1 | Authentication |
+| 1.1 | AUTH-2 | Password login |
+| 1.2 |  | Lockout |
+| 2 |  | Reporting |
+`
+	if got := string(exportMarkdownDocument(&config, "Auth Spec", true)); got != want {
+		t.Errorf("Unexpected Markdown:\n%s\nwant:\n%s", got, want)
+	}
+
+	got := string(exportMarkdownDocument(&config, "Auth Spec", false))
+	if !strings.Contains(got, "\n## Authentication\n") || strings.Contains(got, specNumbersTitle) {
+		t.Errorf("Expected no numbers without numbering:\n%s", got)
+	}
+}
+
+func TestExportHTMLDocument(t *testing.T) {
+	var config model.RequirementConfig
+	if err := yaml.Unmarshal([]byte(pdfRequirements), &config); err != nil {
+		t.Fatal(err)
+	}
+	config.Requirements[1].Description = "Totals <per> month.\n\nExported as CSV."
+
+	got := string(exportHTMLDocument(&config, "Auth & Billing", true))
+	for _, want := range []string{
+		"<title>Auth &amp; Billing</title>",
+		`<h2 id="AUTH-1">1 Authentication</h2>`,
+		`<h3 id="AUTH-2">1.1 Password login</h3>`,
+		`<h3 id="req-1.2">1.2 Lockout</h3>`,
+		`<tr><th>Status</th><td class="status-approved">approved</td></tr>`,
+		"<p>Totals &lt;per&gt; month.</p>\n<p>Exported as CSV.</p>",
+		`<tr><td><a href="#AUTH-2">1.1</a></td><td>AUTH-2</td><td>Password login</td></tr>`,
+		`<tr><td><a href="#req-2">2</a></td><td></td><td>Reporting</td></tr>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+
+	got = string(exportHTMLDocument(&config, "Auth", false))
+	if !strings.Contains(got, `<h2 id="AUTH-1">Authentication</h2>`) || strings.Contains(got, specNumbersTitle) {
+		t.Errorf("Expected no numbers without numbering:\n%s", got)
+	}
+}
+
+func TestWriteExportFile(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "requirements.md")
+	if err := writeExportFile(output, []byte("# Spec\n")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(output); string(data) != "# Spec\n" {
+		t.Errorf("Unexpected file content: %q", data)
+	}
+	if err := writeExportFile(filepath.Join(output, "nested.md"), nil); err == nil {
+		t.Error("Expected an error writing below a file")
+	}
+}
@@ -0,0 +1,350 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// docxPlaceholder is the paragraph of a template the requirements
+// replace
+const docxPlaceholder = "{{requirements}}"
+
+const (
+	docxDocumentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"
+	docxTemplateType = "application/vnd.openxmlformats-officedocument.wordprocessingml.template.main+xml"
+)
+
+// docxStyleDefaults are the styles the export uses, by name, with the
+// definition added to the document when the template lacks them. %s is
+// the style's ID. Status and priority values are set in the character
+// style named after them, so a template restyles them by defining "Status
+// Draft" or "Priority Critical".
+var docxStyleDefaults = map[string]string{
+	"Title":              `<w:style w:type="paragraph" w:styleId="%s"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:qFormat/><w:pPr><w:spacing w:after="240"/></w:pPr><w:rPr><w:b/><w:sz w:val="48"/></w:rPr></w:style>`,
+	"Table Grid":         `<w:style w:type="table" w:styleId="%s"><w:name w:val="Table Grid"/><w:tblPr><w:tblBorders><w:top w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:left w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:bottom w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:right w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:insideH w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:insideV w:val="single" w:sz="4" w:space="0" w:color="auto"/></w:tblBorders><w:tblCellMar><w:left w:w="108" w:type="dxa"/><w:right w:w="108" w:type="dxa"/></w:tblCellMar></w:tblPr></w:style>`,
+	"Status Draft":       docxCharacterStyle("Status Draft", `<w:color w:val="7F7F7F"/>`),
+	"Status Proposed":    docxCharacterStyle("Status Proposed", `<w:color w:val="2E74B5"/>`),
+	"Status Approved":    docxCharacterStyle("Status Approved", `<w:b/><w:color w:val="538135"/>`),
+	"Status Implemented": docxCharacterStyle("Status Implemented", `<w:color w:val="375623"/>`),
+	"Status Verified":    docxCharacterStyle("Status Verified", `<w:b/><w:color w:val="375623"/>`),
+	"Status Deprecated":  docxCharacterStyle("Status Deprecated", `<w:strike/><w:color w:val="7F7F7F"/>`),
+	"Priority Critical":  docxCharacterStyle("Priority Critical", `<w:b/><w:color w:val="C00000"/>`),
+	"Priority High":      docxCharacterStyle("Priority High", `<w:color w:val="C55A11"/>`),
+	"Priority Medium":    docxCharacterStyle("Priority Medium", ``),
+	"Priority Low":       docxCharacterStyle("Priority Low", `<w:color w:val="7F7F7F"/>`),
+}
+
+func init() {
+	// Word's built-in names for headings are lowercase
+	for level := 1; level <= 9; level++ {
+		docxStyleDefaults[fmt.Sprintf("heading %d", level)] = fmt.Sprintf(`<w:style w:type="paragraph" w:styleId="%%s"><w:name w:val="heading %d"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="240" w:after="80"/><w:outlineLvl w:val="%d"/></w:pPr><w:rPr><w:b/><w:sz w:val="%d"/></w:rPr></w:style>`,
+			level, level-1, max(32-4*(level-1), 22))
+	}
+}
+
+func docxCharacterStyle(name, rPr string) string {
+	return `<w:style w:type="character" w:styleId="%s"><w:name w:val="` + name + `"/><w:rPr>` + rPr + `</w:rPr></w:style>`
+}
+
+// docxStyles resolves the styles of a document by name, adding the ones
+// it lacks
+type docxStyles struct {
+	ids   map[string]string
+	added []string
+}
+
+var docxStylePattern = regexp.MustCompile(`(?s)<w:style\b[^>]*\bw:styleId="([^"]*)"[^>]*>.*?<w:name w:val="([^"]*)"`)
+
+// newDocxStyles reads the styles defined in styles.xml
+func newDocxStyles(styles []byte) *docxStyles {
+	s := &docxStyles{ids: make(map[string]string)}
+	for _, match := range docxStylePattern.FindAllSubmatch(styles, -1) {
+		s.ids[strings.ToLower(string(match[2]))] = string(match[1])
+	}
+	return s
+}
+
+// id is the ID of the style named name, adding the style from
+// docxStyleDefaults when the document doesn't have it. It is empty for
+// a style the export has no definition of.
+func (s *docxStyles) id(name string) string {
+	if id, ok := s.ids[strings.ToLower(name)]; ok {
+		return id
+	}
+	definition, ok := docxStyleDefaults[name]
+	if !ok {
+		return ""
+	}
+	var id strings.Builder
+	for _, word := range strings.Fields(name) {
+		id.WriteString(capitalize(word))
+	}
+	s.ids[strings.ToLower(name)] = id.String()
+	s.added = append(s.added, fmt.Sprintf(definition, id.String()))
+	return id.String()
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// docxEscape escapes text for an element of document.xml
+func docxEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// docxRun is a run of text in the character style style, if any; line
+// breaks in text become breaks within the paragraph
+func docxRun(text, style string, bold bool) string {
+	var b strings.Builder
+	b.WriteString("<w:r>")
+	if style != "" || bold {
+		b.WriteString("<w:rPr>")
+		if style != "" {
+			fmt.Fprintf(&b, `<w:rStyle w:val="%s"/>`, style)
+		}
+		if bold {
+			b.WriteString("<w:b/>")
+		}
+		b.WriteString("</w:rPr>")
+	}
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			b.WriteString("<w:br/>")
+		}
+		fmt.Fprintf(&b, `<w:t xml:space="preserve">%s</w:t>`, docxEscape(line))
+	}
+	b.WriteString("</w:r>")
+	return b.String()
+}
+
+// docxParagraph is a paragraph of runs in the paragraph style style, if
+// any
+func docxParagraph(style string, runs ...string) string {
+	if style == "" {
+		return "<w:p>" + strings.Join(runs, "") + "</w:p>"
+	}
+	return fmt.Sprintf(`<w:p><w:pPr><w:pStyle w:val="%s"/></w:pPr>%s</w:p>`, style, strings.Join(runs, ""))
+}
+
+// docxBody renders the requirements of config as WordprocessingML
+// paragraphs and tables, in the styles of styles
+func docxBody(config *model.RequirementConfig, title string, numbered bool, styles *docxStyles) string {
+	var b strings.Builder
+	if title != "" {
+		b.WriteString(docxParagraph(styles.id("Title"), docxRun(title, "", false)))
+	}
+	sections := specSections(config)
+	for _, s := range sections {
+		req := s.req
+		b.WriteString(docxParagraph(styles.id(fmt.Sprintf("heading %d", min(s.depth+1, 9))), docxRun(s.heading(numbered), "", false)))
+
+		if rows := specMetadata(config, req); len(rows) > 0 {
+			fmt.Fprintf(&b, `<w:tbl><w:tblPr><w:tblStyle w:val="%s"/><w:tblW w:w="5000" w:type="pct"/></w:tblPr><w:tblGrid><w:gridCol w:w="2268"/><w:gridCol w:w="6804"/></w:tblGrid>`, styles.id("Table Grid"))
+			for _, row := range rows {
+				// Status and priority values take the style of their value
+				valueStyle := ""
+				switch row[0] {
+				case "Status", "Priority":
+					valueStyle = styles.id(row[0] + " " + capitalize(row[1]))
+				}
+				fmt.Fprintf(&b, `<w:tr><w:tc><w:tcPr><w:tcW w:w="2268" w:type="dxa"/><w:shd w:val="clear" w:color="auto" w:fill="EDEDED"/></w:tcPr>%s</w:tc><w:tc>%s</w:tc></w:tr>`,
+					docxParagraph("", docxRun(row[0], "", true)), docxParagraph("", docxRun(row[1], valueStyle, false)))
+			}
+			b.WriteString("</w:tbl>")
+		}
+
+		for _, field := range specFields(req) {
+			b.WriteString(docxParagraph("", docxRun(field[0], "", true)))
+			// Blank lines separate paragraphs; single line breaks stay
+			// within one
+			for _, paragraph := range docxBlankLinePattern.Split(field[1], -1) {
+				b.WriteString(docxParagraph("", docxRun(strings.TrimSpace(paragraph), "", false)))
+			}
+		}
+	}
+
+	if numbered {
+		b.WriteString(docxParagraph(styles.id("heading 1"), docxRun(specNumbersTitle, "", false)))
+		fmt.Fprintf(&b, `<w:tbl><w:tblPr><w:tblStyle w:val="%s"/><w:tblW w:w="5000" w:type="pct"/></w:tblPr><w:tblGrid><w:gridCol w:w="1134"/><w:gridCol w:w="2268"/><w:gridCol w:w="5670"/></w:tblGrid>`, styles.id("Table Grid"))
+		for i, row := range specNumberRows(sections) {
+			// The header row repeats on every page the table spans
+			b.WriteString("<w:tr>")
+			if i == 0 {
+				b.WriteString("<w:trPr><w:tblHeader/></w:trPr>")
+			}
+			for _, cell := range row {
+				b.WriteString("<w:tc>" + docxParagraph("", docxRun(cell, "", i == 0)) + "</w:tc>")
+			}
+			b.WriteString("</w:tr>")
+		}
+		b.WriteString("</w:tbl>")
+	}
+	return b.String()
+}
+
+var (
+	docxBodyPattern      = regexp.MustCompile(`(?s)<w:body>(.*)</w:body>`)
+	docxParagraphPattern = regexp.MustCompile(`(?s)<w:p\b(?:[^>]*[^/])?>.*?</w:p>`)
+	docxTagPattern       = regexp.MustCompile(`<[^>]*>`)
+	docxStylesEndPattern = regexp.MustCompile(`</w:styles>\s*$`)
+	docxBlankLinePattern = regexp.MustCompile(`\n\s*\n`)
+)
+
+// docxDefaultSection is the page setup of a document without a
+// template: A4 with 2.5 cm margins
+const docxDefaultSection = `<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1417" w:right="1417" w:bottom="1134" w:left="1417" w:header="708" w:footer="708" w:gutter="0"/></w:sectPr>`
+
+// exportDOCX renders config as a Word document. With a template, a .dotx
+// or .docx file, the document keeps the template's styles, page setup,
+// headers and footers: the requirements replace the template's paragraph
+// reading {{requirements}}, or else its whole body.
+func exportDOCX(config *model.RequirementConfig, title string, numbered bool, template []byte) ([]byte, error) {
+	parts := map[string][]byte{}
+	var order []string
+	if template != nil {
+		archive, err := zip.NewReader(bytes.NewReader(template), int64(len(template)))
+		if err != nil {
+			return nil, fmt.Errorf("not a Word template (.dotx or .docx): %w", err)
+		}
+		for _, f := range archive.File {
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			data, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return nil, err
+			}
+			parts[f.Name] = data
+			order = append(order, f.Name)
+		}
+		for _, name := range []string{"[Content_Types].xml", "word/document.xml", "word/styles.xml"} {
+			if parts[name] == nil {
+				return nil, fmt.Errorf("not a Word template: %s is missing", name)
+			}
+		}
+	} else {
+		parts = docxSkeleton(title)
+		order = sortedKeys(parts)
+	}
+
+	styles := newDocxStyles(parts["word/styles.xml"])
+	body := docxBody(config, title, numbered, styles)
+
+	document := string(parts["word/document.xml"])
+	match := docxBodyPattern.FindStringSubmatchIndex(document)
+	if match == nil {
+		return nil, fmt.Errorf("not a Word template: word/document.xml has no body")
+	}
+	content := document[match[2]:match[3]]
+	// The body ends with the page setup of its last section
+	section := docxDefaultSection
+	if i := strings.LastIndex(content, "<w:sectPr"); i >= 0 && strings.HasSuffix(strings.TrimSpace(content), "</w:sectPr>") {
+		section = content[i:]
+	}
+	replaced := false
+	content = docxParagraphPattern.ReplaceAllStringFunc(content, func(p string) string {
+		if !replaced && strings.TrimSpace(docxTagPattern.ReplaceAllString(p, "")) == docxPlaceholder {
+			replaced = true
+			return body
+		}
+		return p
+	})
+	if !replaced {
+		content = body + section
+	}
+	parts["word/document.xml"] = []byte(document[:match[2]] + content + document[match[3]:])
+
+	if len(styles.added) > 0 {
+		parts["word/styles.xml"] = docxStylesEndPattern.ReplaceAll(parts["word/styles.xml"], []byte(strings.Join(styles.added, "")+"</w:styles>"))
+	}
+	// A template opens as a new document based on it; the export is the
+	// document itself
+	parts["[Content_Types].xml"] = bytes.ReplaceAll(parts["[Content_Types].xml"], []byte(docxTemplateType), []byte(docxDocumentType))
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range order {
+		f, err := w.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write(parts[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// docxSkeleton is the parts of an empty Word document, for an export
+// without a template
+func docxSkeleton(title string) map[string][]byte {
+	return map[string][]byte{
+		"[Content_Types].xml": []byte(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/word/document.xml" ContentType="` + docxDocumentType + `"/>` +
+			`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>` +
+			`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+			`</Types>`),
+		"_rels/.rels": []byte(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>` +
+			`</Relationships>`),
+		"docProps/core.xml": []byte(xml.Header + `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">` +
+			`<dc:title>` + docxEscape(title) + `</dc:title></cp:coreProperties>`),
+		"word/_rels/document.xml.rels": []byte(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+			`</Relationships>`),
+		"word/document.xml": []byte(xml.Header + `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body></w:body></w:document>`),
+		"word/styles.xml": []byte(xml.Header + `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+			`<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:cs="Calibri"/><w:sz w:val="22"/></w:rPr></w:rPrDefault>` +
+			`<w:pPrDefault><w:pPr><w:spacing w:after="120"/></w:pPr></w:pPrDefault></w:docDefaults>` +
+			`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>` +
+			`</w:styles>`),
+	}
+}
+
+// runDOCXExport writes config as a Word document to output, based on the
+// template file when one is given
+func runDOCXExport(config *model.RequirementConfig, output, title string, numbered bool, template string) error {
+	var templateData []byte
+	if template != "" {
+		data, err := os.ReadFile(template)
+		if err != nil {
+			return fmt.Errorf("failed to read template: %w", err)
+		}
+		templateData = data
+	}
+	data, err := exportDOCX(config, title, numbered, templateData)
+	if err != nil {
+		if template != "" {
+			return fmt.Errorf("%s: %w", template, err)
+		}
+		return err
+	}
+	return writeExportFile(output, data)
+}
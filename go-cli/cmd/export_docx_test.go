@@ -0,0 +1,204 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"go.yaml.in/yaml/v3"
+)
+
+// docxParts unpacks a Word document, checking every XML part is well
+// formed
+func docxParts(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Not a zip file: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		dec := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s is not well-formed XML: %v", f.Name, err)
+			}
+		}
+		parts[f.Name] = string(content)
+	}
+	return parts
+}
+
+func TestExportDOCX(t *testing.T) {
+	var config model.RequirementConfig
+	if err := yaml.Unmarshal([]byte(pdfRequirements), &config); err != nil {
+		t.Fatal(err)
+	}
+	data, err := exportDOCX(&config, "Auth <Spec>", false, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	parts := docxParts(t, data)
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "docProps/core.xml", "word/_rels/document.xml.rels", "word/document.xml", "word/styles.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("Expected part %s", name)
+		}
+	}
+
+	document := parts["word/document.xml"]
+	for _, want := range []string{
+		`<w:pStyle w:val="Title"/></w:pPr><w:r><w:t xml:space="preserve">Auth &lt;Spec&gt;</w:t>`,
+		`<w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t xml:space="preserve">Authentication</w:t>`,
+		`<w:pStyle w:val="Heading2"/></w:pPr><w:r><w:t xml:space="preserve">Lockout</w:t>`,
+		`<w:rStyle w:val="StatusApproved"/></w:rPr><w:t xml:space="preserve">approved</w:t>`,
+		`<w:t xml:space="preserve">Alice Smith (@alice)</w:t>`,
+		`<w:t xml:space="preserve">A wrong password is rejected</w:t>`,
+		`<w:pgSz w:w="11906" w:h="16838"/>`,
+	} {
+		if !strings.Contains(document, want) {
+			t.Errorf("Expected %q in document.xml:\n%s", want, document)
+		}
+	}
+	// Only the styles in use are added
+	styles := parts["word/styles.xml"]
+	for _, want := range []string{`w:styleId="Heading2"`, `w:styleId="StatusApproved"`, `w:styleId="TableGrid"`} {
+		if !strings.Contains(styles, want) {
+			t.Errorf("Expected %s in styles.xml", want)
+		}
+	}
+	if strings.Contains(styles, `w:styleId="Heading3"`) || strings.Contains(styles, `w:styleId="StatusDraft"`) {
+		t.Errorf("Expected no unused styles, got:\n%s", styles)
+	}
+}
+
+func TestExportDOCXNumbered(t *testing.T) {
+	var config model.RequirementConfig
+	if err := yaml.Unmarshal([]byte(pdfRequirements), &config); err != nil {
+		t.Fatal(err)
+	}
+	data, err := exportDOCX(&config, "Auth", true, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	document := docxParts(t, data)["word/document.xml"]
+	for _, want := range []string{
+		`<w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t xml:space="preserve">1 Authentication</w:t>`,
+		`<w:pStyle w:val="Heading2"/></w:pPr><w:r><w:t xml:space="preserve">1.2 Lockout</w:t>`,
+		`<w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t xml:space="preserve">Requirement numbers</w:t>`,
+		`<w:tr><w:trPr><w:tblHeader/></w:trPr>`,
+		`<w:tc><w:p><w:r><w:t xml:space="preserve">1.1</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t xml:space="preserve">AUTH-2</w:t></w:r></w:p></w:tc>`,
+	} {
+		if !strings.Contains(document, want) {
+			t.Errorf("Expected %q in document.xml:\n%s", want, document)
+		}
+	}
+}
+
+func TestExportDOCXTemplate(t *testing.T) {
+	template := zipFixture(t, map[string]string{
+		"[Content_Types].xml": `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.template.main+xml"/></Types>`,
+		"word/styles.xml": `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+			`<w:style w:type="paragraph" w:styleId="berschrift1"><w:name w:val="heading 1"/></w:style>` +
+			`<w:style w:type="character" w:styleId="Freigegeben"><w:name w:val="Status Approved"/></w:style></w:styles>`,
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t>ACME Corp</w:t></w:r></w:p>` +
+			`<w:p w:rsidR="00A1"><w:r><w:t>{{require</w:t></w:r><w:r><w:t>ments}}</w:t></w:r></w:p>` +
+			`<w:p><w:r><w:t>End of specification</w:t></w:r></w:p>` +
+			`<w:sectPr><w:headerReference w:type="default" r:id="rId9" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"/><w:pgSz w:w="12240" w:h="15840"/></w:sectPr>` +
+			`</w:body></w:document>`,
+		"word/header1.xml": `<w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"/>`,
+	})
+
+	var config model.RequirementConfig
+	if err := yaml.Unmarshal([]byte(pdfRequirements), &config); err != nil {
+		t.Fatal(err)
+	}
+	data, err := exportDOCX(&config, "", false, template)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	parts := docxParts(t, data)
+	if _, ok := parts["word/header1.xml"]; !ok {
+		t.Error("Expected the template's parts kept")
+	}
+	if !strings.Contains(parts["[Content_Types].xml"], "wordprocessingml.document.main+xml") {
+		t.Errorf("Expected a document, not a template: %s", parts["[Content_Types].xml"])
+	}
+
+	document := parts["word/document.xml"]
+	before := strings.Index(document, "ACME Corp")
+	heading := strings.Index(document, `<w:pStyle w:val="berschrift1"/></w:pPr><w:r><w:t xml:space="preserve">Authentication</w:t>`)
+	after := strings.Index(document, "End of specification")
+	if before < 0 || heading < before || after < heading {
+		t.Errorf("Expected the requirements in place of the placeholder:\n%s", document)
+	}
+	if strings.Contains(document, "{{require") || strings.Contains(document, `w:val="Title"`) {
+		t.Errorf("Expected the placeholder replaced and no title:\n%s", document)
+	}
+	if !strings.Contains(document, `<w:rStyle w:val="Freigegeben"/>`) || !strings.Contains(document, `<w:pgSz w:w="12240" w:h="15840"/>`) {
+		t.Errorf("Expected the template's styles and page setup:\n%s", document)
+	}
+	// Heading 2 isn't in the template, so it's added
+	if styles := parts["word/styles.xml"]; !strings.Contains(styles, `w:styleId="Heading2"`) || strings.Contains(styles, `w:styleId="Heading1"`) {
+		t.Errorf("Expected only the missing styles added:\n%s", styles)
+	}
+}
+
+func TestExportDOCXTemplateWithoutPlaceholder(t *testing.T) {
+	template := zipFixture(t, map[string]string{
+		"[Content_Types].xml": `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"/>`,
+		"word/styles.xml":     `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"></w:styles>`,
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t>Sample text</w:t></w:r></w:p><w:sectPr><w:pgSz w:w="12240" w:h="15840"/></w:sectPr></w:body></w:document>`,
+	})
+	config := &model.RequirementConfig{Version: "1.0", Requirements: []model.RequirementDetail{{Summary: "Login"}}}
+	data, err := exportDOCX(config, "", false, template)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	document := docxParts(t, data)["word/document.xml"]
+	if strings.Contains(document, "Sample text") || !strings.Contains(document, "Login") || !strings.Contains(document, `<w:pgSz w:w="12240" w:h="15840"/></w:sectPr></w:body>`) {
+		t.Errorf("Expected the body replaced, keeping its page setup:\n%s", document)
+	}
+
+	if _, err := exportDOCX(config, "", false, []byte("not a zip")); err == nil || !strings.Contains(err.Error(), "not a Word template") {
+		t.Errorf("Expected an error for a file that isn't a template, got %v", err)
+	}
+	if _, err := exportDOCX(config, "", false, zipFixture(t, map[string]string{"word/document.xml": "<w:document/>"})); err == nil || !strings.Contains(err.Error(), "[Content_Types].xml is missing") {
+		t.Errorf("Expected an error for a template without content types, got %v", err)
+	}
+}
+
+// zipFixture packs parts into a zip file
+func zipFixture(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range sortedKeys(parts) {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(parts[name]))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
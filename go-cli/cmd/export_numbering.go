@@ -0,0 +1,89 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/render"
+)
+
+// specSection is a requirement of a specification document with its
+// clause number
+type specSection struct {
+	// number is the requirement's place in the tree, 1.2.3 for the
+	// third child of the second child of the first requirement
+	number string
+	depth  int
+	req    *model.RequirementDetail
+}
+
+// specSections lists the requirements of config in document order,
+// numbered by their place in the tree: 1, 1.1, 1.2, 2 and so on
+func specSections(config *model.RequirementConfig) []*specSection {
+	var sections []*specSection
+	var numbers []string
+	config.Walk(render.VisitorFunc[*model.RequirementDetail](func(req *model.RequirementDetail, pos render.Position) error {
+		numbers = append(numbers[:pos.Depth], strconv.Itoa(pos.Index+1))
+		sections = append(sections, &specSection{number: strings.Join(numbers, "."), depth: pos.Depth, req: req})
+		return nil
+	}))
+	return sections
+}
+
+// heading is the section's heading: the requirement's summary, after its
+// number when the document is numbered
+func (s *specSection) heading(numbered bool) string {
+	summary := strings.Join(strings.Fields(s.req.Summary), " ")
+	if !numbered {
+		return summary
+	}
+	return s.number + " " + summary
+}
+
+// specNumbersTitle is the heading of the table mapping the numbers of a
+// numbered document to requirements
+const specNumbersTitle = "Requirement numbers"
+
+// specNumberRows is the table mapping the numbers of a numbered document
+// to requirements: number, ID and summary
+func specNumberRows(sections []*specSection) [][]string {
+	rows := [][]string{{"Number", "ID", "Summary"}}
+	for _, s := range sections {
+		rows = append(rows, []string{s.number, s.req.Name, s.req.Summary})
+	}
+	return rows
+}
+
+// numberedFormats are the export formats --numbering applies to, with
+// whether they are numbered by default
+var numberedFormats = map[string]bool{
+	"asciidoc": false,
+	"docx":     false,
+	"html":     false,
+	"markdown": false,
+	"pdf":      true,
+}
+
+// parseNumbering reports whether --numbering, numbering, numbers the
+// sections of an export in format
+func parseNumbering(numbering, format string) (bool, error) {
+	numbered, ok := numberedFormats[format]
+	switch {
+	case numbering == "":
+		return numbered, nil
+	case !ok:
+		return false, fmt.Errorf("--numbering does not apply to the %s format", format)
+	case numbering == "hierarchical":
+		return true, nil
+	case numbering == "none":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown numbering: %q (supported: hierarchical, none)", numbering)
+	}
+}
@@ -0,0 +1,64 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"go.yaml.in/yaml/v3"
+)
+
+func TestSpecSections(t *testing.T) {
+	var config model.RequirementConfig
+	if err := yaml.Unmarshal([]byte(pdfRequirements), &config); err != nil {
+		t.Fatal(err)
+	}
+	var numbered, plain []string
+	for _, section := range specSections(&config) {
+		numbered = append(numbered, section.heading(true))
+		plain = append(plain, section.heading(false))
+	}
+	if strings.Join(numbered, ", ") != "1 Authentication, 1.1 Password login, 1.2 Lockout, 2 Reporting" {
+		t.Errorf("Unexpected numbering: %q", numbered)
+	}
+	if strings.Join(plain, ", ") != "Authentication, Password login, Lockout, Reporting" {
+		t.Errorf("Unexpected headings: %q", plain)
+	}
+
+	rows := specNumberRows(specSections(&config))
+	if len(rows) != 5 || strings.Join(rows[2], "|") != "1.1|AUTH-2|Password login" {
+		t.Errorf("Unexpected number table: %q", rows)
+	}
+}
+
+func TestParseNumbering(t *testing.T) {
+	tests := []struct {
+		numbering, format string
+		want              bool
+		err               string
+	}{
+		{"", "markdown", false, ""},
+		{"", "pdf", true, ""},
+		{"", "gherkin", false, ""},
+		{"hierarchical", "docx", true, ""},
+		{"none", "pdf", false, ""},
+		{"hierarchical", "site", false, "does not apply to the site format"},
+		{"decimal", "html", false, `unknown numbering: "decimal"`},
+	}
+	for _, tt := range tests {
+		got, err := parseNumbering(tt.numbering, tt.format)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("parseNumbering(%q, %q): expected error %q, got %v", tt.numbering, tt.format, tt.err, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseNumbering(%q, %q) = %v, %v, want %v", tt.numbering, tt.format, got, err, tt.want)
+		}
+	}
+}
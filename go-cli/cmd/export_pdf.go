@@ -0,0 +1,351 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/pdf"
+)
+
+// Page geometry of the PDF export, in points
+const (
+	pdfMargin      = 56
+	pdfTop         = 72
+	pdfBottom      = 64
+	pdfBodySize    = 10
+	pdfBodyLeading = 13
+	pdfLabelWidth  = 110
+	pdfTOCLeading  = 16
+)
+
+// pdfEntry is an entry of the contents of the PDF export, located once
+// it is laid out
+type pdfEntry struct {
+	title string
+	depth int
+	page  *pdf.Page
+	top   float64
+}
+
+// pdfLayout lays a requirements specification out page by page, moving
+// on to a new page when the next block doesn't fit
+type pdfLayout struct {
+	doc  *pdf.Document
+	page *pdf.Page
+	y    float64
+}
+
+// width is the width of the text column
+func (l *pdfLayout) width() float64 {
+	return l.doc.Size().Width - 2*pdfMargin
+}
+
+func (l *pdfLayout) newPage() {
+	l.page = l.doc.AddPage()
+	l.y = pdfTop
+}
+
+// ensure starts a new page unless height fits on the current one
+func (l *pdfLayout) ensure(height float64) {
+	if l.y+height > l.doc.Size().Height-pdfBottom {
+		l.newPage()
+	}
+}
+
+// paragraph writes text wrapped to the text column, indented by indent
+func (l *pdfLayout) paragraph(font pdf.Font, text string, indent float64) {
+	for _, line := range pdf.Wrap(font, pdfBodySize, text, l.width()-indent) {
+		l.ensure(pdfBodyLeading)
+		l.y += pdfBodyLeading
+		l.page.Text(pdfMargin+indent, l.y-3, font, pdfBodySize, line)
+	}
+}
+
+// field writes a labelled block of text under a requirement's table
+func (l *pdfLayout) field(label, text string) {
+	l.ensure(pdfBodyLeading*2 + 8)
+	l.y += 8
+	l.paragraph(pdf.HelveticaBold, label, 0)
+	l.paragraph(pdf.Helvetica, text, 0)
+}
+
+// table writes rows as a table with columns of the given widths, the
+// last taking the rest of the text column. The first column is shaded,
+// as is the first row when header is set. A row never breaks across
+// pages.
+func (l *pdfLayout) table(rows [][]string, widths []float64, header bool) {
+	widths = append(widths, l.width()-sum(widths))
+	for r, row := range rows {
+		cells := make([][]string, len(row))
+		lines := 1
+		for c, text := range row {
+			cells[c] = pdf.Wrap(pdf.Helvetica, 9, text, widths[c]-12)
+			lines = max(lines, len(cells[c]))
+		}
+		height := float64(lines)*12 + 6
+		l.ensure(height)
+		shaded := widths[0]
+		if header && r == 0 {
+			shaded = l.width()
+		}
+		l.page.FillRect(pdfMargin, l.y, shaded, height, pdf.Gray(0.93))
+		l.page.StrokeRect(pdfMargin, l.y, l.width(), height, 0.5)
+		x := pdfMargin + 0.0
+		for c, cell := range cells {
+			if c > 0 {
+				l.page.Line(x, l.y, x, l.y+height, 0.5)
+			}
+			font := pdf.Helvetica
+			if c == 0 || (header && r == 0) {
+				font = pdf.HelveticaBold
+			}
+			for i, line := range cell {
+				l.page.Text(x+6, l.y+12+float64(i)*12, font, 9, line)
+			}
+			x += widths[c]
+		}
+		l.y += height
+	}
+}
+
+// sum adds up values
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// specMetadata is the metadata table of a requirement in a specification
+// document, PDF or Word: the fields it has set, as label and value
+func specMetadata(config *model.RequirementConfig, req *model.RequirementDetail) [][]string {
+	var rows [][]string
+	add := func(label, value string) {
+		if value != "" {
+			rows = append(rows, []string{label, value})
+		}
+	}
+	add("ID", req.Name)
+	add("Status", req.Status)
+	add("Priority", req.Priority)
+	owner := req.Owner
+	if alias, ok := config.ResolveOwner(req.Owner); ok && alias.Name != "" {
+		owner = fmt.Sprintf("%s (%s)", alias.Name, req.Owner)
+	}
+	add("Owner", owner)
+	add("Verification", req.Verification)
+	if req.Estimate != 0 {
+		add("Estimate", strconv.FormatFloat(req.Estimate, 'f', -1, 64))
+	}
+	add("Tags", strings.Join(req.Tags, ", "))
+	add("Superseded by", req.SupersededBy)
+	add("Verified by", strings.Join(req.VerifiedBy, ", "))
+
+	var relations, refs, approvals []string
+	for _, rel := range req.Relations {
+		relations = append(relations, strings.ReplaceAll(rel.Type, "_", " ")+" "+rel.Target)
+	}
+	add("Relations", strings.Join(relations, "; "))
+	for _, ref := range req.ExternalRefs {
+		refs = append(refs, ref.System+" "+ref.ID)
+	}
+	add("External refs", strings.Join(refs, ", "))
+	for _, approval := range req.Approvals {
+		approvals = append(approvals, approval.Approver+": "+cmp.Or(approval.Status, "pending"))
+	}
+	add("Approvals", strings.Join(approvals, ", "))
+	for _, key := range sortedKeys(req.Attributes) {
+		add(key, fmt.Sprint(req.Attributes[key]))
+	}
+	return rows
+}
+
+// specFields are the text fields of a requirement in a specification
+// document, as label and text, leaving out empty ones
+func specFields(req *model.RequirementDetail) [][2]string {
+	var criteria, questions []string
+	for _, criterion := range req.AcceptanceCriteria {
+		criteria = append(criteria, criterion.ID+"  "+criterion.Criterion)
+	}
+	for _, question := range req.OpenQuestions {
+		questions = append(questions, "- "+question.Question)
+	}
+	var fields [][2]string
+	for _, field := range [][2]string{
+		{"Description", req.Description},
+		{"Justification", req.Justification},
+		{"Acceptance test", req.AcceptanceTest},
+		{"Acceptance criteria", strings.Join(criteria, "\n")},
+		{"Open questions", strings.Join(questions, "\n")},
+	} {
+		if text := strings.TrimSpace(field[1]); text != "" {
+			fields = append(fields, [2]string{field[0], text})
+		}
+	}
+	return fields
+}
+
+// exportPDF renders config as a paginated requirements specification: a
+// cover page with a sign-off table, a table of contents, then a section
+// per requirement with its metadata table and text fields. Numbered, the
+// sections have their number and the document ends with a table mapping
+// numbers to requirements.
+func exportPDF(config *model.RequirementConfig, file, title string, numbered bool, generated time.Time) ([]byte, int, error) {
+	doc := pdf.New(pdf.A4)
+	doc.Title = title
+	doc.Subject = "Requirements specification"
+	sections := specSections(config)
+	l := &pdfLayout{doc: doc}
+	size := doc.Size()
+
+	// Cover page
+	l.newPage()
+	cover := l.page
+	for i, line := range pdf.Wrap(pdf.HelveticaBold, 26, title, l.width()) {
+		cover.Text(pdfMargin, 240+float64(i)*32, pdf.HelveticaBold, 26, line)
+	}
+	cover.SetColor(pdf.Gray(0.35))
+	cover.Text(pdfMargin, 320, pdf.Helvetica, 12, filepath.Base(file))
+	cover.Text(pdfMargin, 338, pdf.Helvetica, 12, fmt.Sprintf("%d requirement(s)", len(sections)))
+	cover.Text(pdfMargin, 356, pdf.Helvetica, 12, "Generated "+generated.Format("2 January 2006"))
+	cover.SetColor(pdf.Black)
+	l.y = 560
+	l.paragraph(pdf.HelveticaBold, "Approval", 0)
+	l.y += 6
+	columns := []float64{0, 90, 230, 380, l.width()}
+	for i, row := range [][]string{{"Role", "Name", "Signature", "Date"}, {"Author"}, {"Reviewer"}, {"Approver"}} {
+		height := 18.0
+		if i > 0 {
+			height = 30
+		} else {
+			cover.FillRect(pdfMargin, l.y, l.width(), height, pdf.Gray(0.93))
+		}
+		cover.StrokeRect(pdfMargin, l.y, l.width(), height, 0.5)
+		for c, label := range row {
+			cover.Text(pdfMargin+columns[c]+6, l.y+12, pdf.HelveticaBold, 9, label)
+		}
+		for _, x := range columns[1 : len(columns)-1] {
+			cover.Line(pdfMargin+x, l.y, pdfMargin+x, l.y+height, 0.5)
+		}
+		l.y += height
+	}
+
+	// The contents take a line per requirement, and the numbers table, so
+	// their pages are set aside now and filled in once the sections have
+	// page numbers
+	entries := len(sections)
+	if numbered {
+		entries++
+	}
+	firstTOC := pdfTop + 40.0
+	tocPages := 1
+	if rest := entries - int((size.Height-pdfBottom-firstTOC)/pdfTOCLeading); rest > 0 {
+		perPage := int((size.Height - pdfBottom - pdfTop) / pdfTOCLeading)
+		tocPages += (rest + perPage - 1) / perPage
+	}
+	var toc []*pdf.Page
+	for range tocPages {
+		toc = append(toc, doc.AddPage())
+	}
+
+	// Sections
+	var contents []pdfEntry
+	heading := func(text string, depth int) {
+		headingSize := []float64{15, 13, 11}[min(depth, 2)]
+		if len(contents) > 0 {
+			l.y += 14
+		}
+		// Keep the heading with the start of its table
+		l.ensure(headingSize + 50)
+		contents = append(contents, pdfEntry{title: text, depth: depth, page: l.page, top: l.y})
+		doc.Bookmark(text, depth, l.page, l.y)
+		for _, line := range pdf.Wrap(pdf.HelveticaBold, headingSize, text, l.width()) {
+			l.y += headingSize * 1.35
+			l.page.Text(pdfMargin, l.y-4, pdf.HelveticaBold, headingSize, line)
+		}
+		l.y += 6
+	}
+	l.newPage()
+	for _, section := range sections {
+		heading(section.heading(numbered), section.depth)
+		l.table(specMetadata(config, section.req), []float64{pdfLabelWidth}, false)
+		for _, field := range specFields(section.req) {
+			l.field(field[0], field[1])
+		}
+	}
+	if numbered {
+		heading(specNumbersTitle, 0)
+		l.table(specNumberRows(sections), []float64{70, pdfLabelWidth}, true)
+	}
+
+	// Contents
+	y := firstTOC
+	toc[0].Text(pdfMargin, pdfTop+16, pdf.HelveticaBold, 18, "Contents")
+	page := 0
+	for _, entry := range contents {
+		if y+pdfTOCLeading > size.Height-pdfBottom {
+			page++
+			y = pdfTop
+		}
+		y += pdfTOCLeading
+		indent := float64(min(entry.depth, 4)) * 14
+		number := strconv.Itoa(entry.page.Number)
+		font := pdf.Helvetica
+		if entry.depth == 0 {
+			font = pdf.HelveticaBold
+		}
+		text := entry.title
+		available := l.width() - indent - pdf.Width(font, 10, number) - 16
+		if pdf.Width(font, 10, text) > available {
+			for pdf.Width(font, 10, text+"…") > available && text != "" {
+				text = string([]rune(text)[:len([]rune(text))-1])
+			}
+			text += "…"
+		}
+		toc[page].Text(pdfMargin+indent, y-4, font, 10, text)
+		toc[page].Text(size.Width-pdfMargin-pdf.Width(font, 10, number), y-4, font, 10, number)
+		toc[page].Link(pdfMargin, y-pdfTOCLeading, l.width(), pdfTOCLeading, entry.page, entry.top)
+	}
+
+	// Running header and footer on every page but the cover
+	pages := doc.Pages()
+	for _, p := range pages[1:] {
+		p.SetColor(pdf.Gray(0.4))
+		p.Text(pdfMargin, 40, pdf.Helvetica, 8, title)
+		p.Line(pdfMargin, 46, size.Width-pdfMargin, 46, 0.5)
+		footer := fmt.Sprintf("Page %d of %d", p.Number, len(pages))
+		p.Text(pdfMargin, size.Height-36, pdf.Helvetica, 8, filepath.Base(file))
+		p.Text(size.Width-pdfMargin-pdf.Width(pdf.Helvetica, 8, footer), size.Height-36, pdf.Helvetica, 8, footer)
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), len(pages), nil
+}
+
+// runPDFExport writes config as a PDF specification to output
+func runPDFExport(config *model.RequirementConfig, file, output, title string, numbered bool) error {
+	data, pages, err := exportPDF(config, file, title, numbered, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to render PDF: %w", err)
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	fmt.Printf("%s Wrote %s (%d page(s))\n", okMark(), output, pages)
+	return nil
+}
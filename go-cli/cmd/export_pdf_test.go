@@ -0,0 +1,123 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"go.yaml.in/yaml/v3"
+)
+
+const pdfRequirements = `version: "1.0"
+aliases:
+  - alias: alice
+    name: Alice Smith
+requirements:
+  - summary: Authentication
+    name: AUTH-1
+    owner: "@alice"
+    status: approved
+    description: Users sign in.
+    requirements:
+      - summary: Password login
+        name: AUTH-2
+        acceptance_test: A wrong password is rejected
+      - summary: Lockout
+  - summary: Reporting
+`
+
+func TestSpecMetadata(t *testing.T) {
+	var config model.RequirementConfig
+	if err := yaml.Unmarshal([]byte(pdfRequirements), &config); err != nil {
+		t.Fatal(err)
+	}
+	rows := specMetadata(&config, &config.Requirements[0])
+	if fmt.Sprint(rows) != "[[ID AUTH-1] [Status approved] [Owner Alice Smith (@alice)]]" {
+		t.Errorf("Unexpected metadata table: %v", rows)
+	}
+}
+
+func TestExportPDF(t *testing.T) {
+	var config model.RequirementConfig
+	if err := yaml.Unmarshal([]byte(pdfRequirements), &config); err != nil {
+		t.Fatal(err)
+	}
+	data, pages, err := exportPDF(&config, "requirements.yml", "Auth SRS", true, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The cover, the contents and one page of sections
+	if pages != 3 {
+		t.Errorf("Expected 3 pages, got %d", pages)
+	}
+	for _, want := range []string{"/Title (Auth SRS)", "/Title (1 Authentication)", "/Title (1.2 Lockout)", "/Title (2 Reporting)", "/Title (Requirement numbers)"} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("Expected %q in the PDF", want)
+		}
+	}
+	// A contents entry per requirement, and one for the table of numbers,
+	// links to its section
+	if links := bytes.Count(data, []byte("/Subtype /Link")); links != 5 {
+		t.Errorf("Expected 5 links, got %d", links)
+	}
+
+	data, _, err = exportPDF(&config, "requirements.yml", "Auth SRS", false, time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Contains(data, []byte("/Title (Authentication)")) || bytes.Contains(data, []byte("/Title (Requirement numbers)")) {
+		t.Error("Expected no numbers without numbering")
+	}
+}
+
+func TestExportPDFContentsPages(t *testing.T) {
+	config := &model.RequirementConfig{Version: "1.0"}
+	for i := range 120 {
+		config.Requirements = append(config.Requirements, model.RequirementDetail{Summary: fmt.Sprintf("Requirement %d", i+1)})
+	}
+	data, pages, err := exportPDF(config, "requirements.yml", "Big SRS", false, time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Three pages of contents hold 120 entries, and every entry links to
+	// a page after them
+	dests := regexp.MustCompile(`/Subtype /Link .*? /Dest \[(\d+) 0 R`).FindAllSubmatch(data, -1)
+	if len(dests) != 120 {
+		t.Fatalf("Expected 120 links, got %d", len(dests))
+	}
+	if pages < 6 {
+		t.Errorf("Expected the cover, 3 pages of contents and the sections, got %d page(s)", pages)
+	}
+	for _, dest := range dests {
+		// Page objects start at 8, two objects per page
+		obj, _ := strconv.Atoi(string(dest[1]))
+		if page := (obj-8)/2 + 1; page <= 4 {
+			t.Errorf("Link to page %d, before the sections", page)
+		}
+	}
+}
+
+func TestRunPDFExport(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "srs.pdf")
+	config := &model.RequirementConfig{Version: "1.0", Requirements: []model.RequirementDetail{{Summary: "Login"}}}
+	if err := runPDFExport(config, "requirements.yml", output, "SRS", true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		t.Errorf("Expected a PDF file, got %q", data[:10])
+	}
+}
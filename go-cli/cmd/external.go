@@ -0,0 +1,124 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// ExternalProblem is an external reference check --external couldn't
+// confirm
+type ExternalProblem struct {
+	Summary string `json:"summary"`
+	Name    string `json:"name,omitempty"`
+	Repo    string `json:"repo"`
+	ID      string `json:"id"`
+	Ref     string `json:"ref,omitempty"`
+	// Reason is not_found, or unreachable when the repository couldn't be
+	// fetched
+	Reason string `json:"reason"`
+	Error  string `json:"error,omitempty"`
+}
+
+// message describes the problem for annotations
+func (p ExternalProblem) message() string {
+	ext := model.ExternalReference{Repo: p.Repo, ID: p.ID, Ref: p.Ref}
+	if p.Reason == "unreachable" {
+		return fmt.Sprintf("'%s' references %s, which couldn't be fetched: %s", p.Summary, ext, p.Error)
+	}
+	return fmt.Sprintf("'%s' references %s, which doesn't exist", p.Summary, ext)
+}
+
+// externalSource is the remote file an external reference points into: its
+// repo when that's already a remote file argument, or else the
+// .rqm/requirements.yml of the git repository at its ref
+func externalSource(ext *model.ExternalReference) string {
+	if isRemoteFile(ext.Repo) {
+		return ext.Repo
+	}
+	source := "git::" + ext.Repo + "//.rqm/requirements.yml"
+	if ext.Ref != "" {
+		source += "?ref=" + url.QueryEscape(ext.Ref)
+	}
+	return source
+}
+
+// externalReferences maps the summaries of the requirements of config to
+// their external references, in file order
+func externalReferences(config *model.RequirementConfig) map[string][]model.ExternalReference {
+	refs := make(map[string][]model.ExternalReference)
+	for _, req := range config.Flatten() {
+		for _, child := range req.Requirements {
+			if child.External != nil {
+				refs[req.Summary] = append(refs[req.Summary], *child.External)
+			}
+		}
+	}
+	return refs
+}
+
+// findBrokenExternal fetches the files the external references of config
+// point into, each once, and returns the references to requirements they
+// don't have, and those whose file couldn't be fetched or parsed, which are
+// also reported on stderr
+func findBrokenExternal(config *model.RequirementConfig) (broken, unreachable []ExternalProblem) {
+	type loaded struct {
+		config *model.RequirementConfig
+		err    error
+	}
+	sources := make(map[string]loaded)
+	for _, req := range config.Flatten() {
+		for _, child := range req.Requirements {
+			if child.External == nil {
+				continue
+			}
+			source := externalSource(child.External)
+			l, ok := sources[source]
+			if !ok {
+				var path string
+				if path, l.err = fetchRemoteFile(source); l.err == nil {
+					l.config, l.err = model.Load(path)
+				}
+				sources[source] = l
+			}
+
+			problem := ExternalProblem{Summary: req.Summary, Name: req.Name, Repo: child.External.Repo, ID: child.External.ID, Ref: child.External.Ref}
+			if l.err != nil {
+				problem.Reason, problem.Error = "unreachable", l.err.Error()
+				unreachable = append(unreachable, problem)
+				fmt.Fprintf(os.Stderr, "%s %s\n", warnMark(), problem.message())
+				continue
+			}
+			if _, ok := l.config.Find(child.External.ID); !ok {
+				problem.Reason = "not_found"
+				broken = append(broken, problem)
+			}
+		}
+	}
+	return broken, unreachable
+}
+
+// displayExternalProblems prints the result of check --external
+func displayExternalProblems(broken, unreachable []ExternalProblem) {
+	fmt.Println()
+	switch {
+	case len(broken) == 0 && len(unreachable) > 0:
+		fmt.Printf("%s %d external reference(s) couldn't be checked\n", warnMark(), len(unreachable))
+		return
+	case len(broken) == 0:
+		fmt.Println(okMark(), "Every external reference names an existing requirement")
+		return
+	}
+
+	fmt.Printf("%s %d external reference(s) to requirements that don't exist:\n", failMark(), len(broken))
+	for _, p := range broken {
+		name := displayName(&model.RequirementDetail{Name: p.Name, Summary: p.Summary})
+		fmt.Printf("  - %s: %s\n", name, model.ExternalReference{Repo: p.Repo, ID: p.ID, Ref: p.Ref})
+	}
+}
@@ -0,0 +1,131 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// isGlob reports whether arg is a pattern rather than a file name
+func isGlob(arg string) bool {
+	return !isRemoteFile(arg) && strings.ContainsAny(arg, "*?[")
+}
+
+// expandFileArgs expands the glob patterns among args, keeping other
+// arguments as they are. A file named by several arguments is only
+// listed once, and a pattern that matches nothing is an error, so a typo
+// doesn't pass as a clean run.
+func expandFileArgs(args []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var files []string
+	for _, arg := range args {
+		matches := []string{arg}
+		if isRemoteFile(arg) {
+			path, err := fetchRemoteFile(arg)
+			if err != nil {
+				return nil, err
+			}
+			matches = []string{path}
+		} else if isGlob(arg) {
+			var err error
+			if matches, err = globFiles(arg); err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %s", arg)
+			}
+		}
+		for _, file := range matches {
+			if !seen[filepath.Clean(file)] {
+				seen[filepath.Clean(file)] = true
+				files = append(files, file)
+			}
+		}
+	}
+	return files, nil
+}
+
+// globFiles returns the files matching pattern in lexical order. Besides
+// the syntax of filepath.Match, a ** path element matches any number of
+// directories, so docs/**/*.rqm.yml finds requirements files at every
+// depth under docs. Quote such patterns so the shell leaves them to rqm.
+func globFiles(pattern string) ([]string, error) {
+	slashed := filepath.ToSlash(pattern)
+	if !strings.Contains(slashed, "**") {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+		return matches, nil
+	}
+	if _, err := path.Match(strings.ReplaceAll(slashed, "**", "*"), ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+
+	// Walk from the longest directory prefix without wildcards
+	elems := strings.Split(slashed, "/")
+	base := 0
+	for base < len(elems)-1 && !isGlob(elems[base]) {
+		base++
+	}
+	root := filepath.FromSlash(strings.Join(elems[:base], "/"))
+	if root == "" {
+		root = "."
+	}
+
+	var matches []string
+	err := filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if name == root {
+				return err
+			}
+			// Skip what can't be read, as the shell's globbing would
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(root, name)
+		if err == nil && matchGlob(elems[base:], strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, name)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to search %s: %w", root, err)
+	}
+	return matches, nil
+}
+
+// matchGlob matches path elements against pattern elements, where **
+// matches zero or more whole elements
+func matchGlob(pattern, elems []string) bool {
+	if len(pattern) == 0 {
+		return len(elems) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(elems); i++ {
+			if matchGlob(pattern[1:], elems[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(elems) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], elems[0]); !ok {
+		return false
+	}
+	return matchGlob(pattern[1:], elems[1:])
+}
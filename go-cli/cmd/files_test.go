@@ -0,0 +1,79 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGlobFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{
+		"docs/top.rqm.yml",
+		"docs/api/auth.rqm.yml",
+		"docs/api/v2/tokens.rqm.yml",
+		"docs/api/notes.md",
+		"other/skip.rqm.yml",
+	} {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"docs/**/*.rqm.yml", []string{"docs/api/auth.rqm.yml", "docs/api/v2/tokens.rqm.yml", "docs/top.rqm.yml"}},
+		{"docs/*.rqm.yml", []string{"docs/top.rqm.yml"}},
+		{"**/v2/*", []string{"docs/api/v2/tokens.rqm.yml"}},
+		{"docs/api/**", []string{"docs/api/auth.rqm.yml", "docs/api/notes.md", "docs/api/v2/tokens.rqm.yml"}},
+		{"missing/**/*.yml", nil},
+	}
+	for _, tt := range tests {
+		matches, err := globFiles(filepath.Join(tmpDir, tt.pattern))
+		if err != nil {
+			t.Fatalf("globFiles(%q): %v", tt.pattern, err)
+		}
+		var got []string
+		for _, match := range matches {
+			rel, _ := filepath.Rel(tmpDir, match)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("globFiles(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestExpandFileArgs(t *testing.T) {
+	tmpDir := t.TempDir()
+	a := filepath.Join(tmpDir, "a.yml")
+	b := filepath.Join(tmpDir, "b.yml")
+	for _, file := range []string{a, b} {
+		if err := os.WriteFile(file, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := expandFileArgs([]string{b, filepath.Join(tmpDir, "*.yml"), "missing.yml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{b, a, "missing.yml"}; strings.Join(files, " ") != strings.Join(want, " ") {
+		t.Errorf("expandFileArgs = %v, want %v", files, want)
+	}
+
+	if _, err := expandFileArgs([]string{filepath.Join(tmpDir, "**", "*.yaml")}); err == nil || !strings.Contains(err.Error(), "no files match") {
+		t.Errorf("Expected an error for a pattern without matches, got %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// requirementFilter tells whether a requirement matches a filter
+// expression
+type requirementFilter func(req *model.RequirementDetail) bool
+
+// filterFields are the fields a filter can compare, besides
+// attributes.<name>; id and tags are aliases of name and tag
+var filterFields = []string{"summary", "name", "id", "uid", "description", "status", "priority", "owner", "tag", "tags", "verification", "environment", "estimate"}
+
+// priorityRanks orders priorities from least to most urgent, for < and >
+var priorityRanks = []string{"low", "medium", "high", "critical"}
+
+// filterLanguage documents the filter expressions of bulk and search for
+// their help text
+const filterLanguage = `A filter compares fields with ==, !=, ~ (contains, ignoring case), <, <=,
+> and >=, and combines comparisons with &&, || and !, grouped with
+parentheses. Values are bare words or quoted, and "" is the empty value:
+
+  status==proposed && tag==payments
+  (priority>=high || owner==@alice) && !(tag==legacy)
+  estimate>3 && status<implemented
+  summary~"password reset" || attributes.risk==high
+
+Fields are summary, name (or id), uid, description, status, priority,
+owner, tag, verification, environment, estimate and attributes.<name>.
+tag and environment match when any of the requirement's values does, and
+!= when none does. status is ordered by the lifecycle draft, proposed,
+approved, implemented, verified, priority from low to critical, and
+estimate as a number.`
+
+// filterToken is a token of a filter expression: an operator or
+// parenthesis in op, or a value in text
+type filterToken struct {
+	op   string
+	text string
+	pos  int
+}
+
+// parseFilter compiles a filter expression
+func parseFilter(expr string) (requirementFilter, error) {
+	tokens, err := lexFilter(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("invalid filter: empty expression")
+	}
+	p := &filterParser{tokens: tokens}
+	filter, err := p.or()
+	if err == nil && p.i < len(p.tokens) {
+		err = fmt.Errorf("unexpected %s", p.describe())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	return filter, nil
+}
+
+// filterOperators are the operators of the language, longest first so
+// <= isn't read as <
+var filterOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "~", "!", "(", ")"}
+
+// lexFilter splits expr into tokens
+func lexFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+			continue
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote at position %d", i+1)
+			}
+			tokens = append(tokens, filterToken{text: expr[i+1 : i+1+end], pos: i + 1})
+			i += end + 2
+			continue
+		}
+		matched := false
+		for _, op := range filterOperators {
+			if strings.HasPrefix(expr[i:], op) {
+				tokens = append(tokens, filterToken{op: op, pos: i + 1})
+				i += len(op)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		start := i
+		for i < len(expr) && !strings.ContainsRune(" \t\n\"'&|=!<>~()", rune(expr[i])) {
+			i++
+		}
+		if i == start {
+			return nil, fmt.Errorf("unexpected %q at position %d", expr[i], i+1)
+		}
+		tokens = append(tokens, filterToken{text: expr[start:i], pos: start + 1})
+	}
+	return tokens, nil
+}
+
+// filterParser parses tokens by recursive descent: || binds looser than
+// &&, which binds looser than !
+type filterParser struct {
+	tokens []filterToken
+	i      int
+}
+
+func (p *filterParser) peek(op string) bool {
+	return p.i < len(p.tokens) && p.tokens[p.i].op == op
+}
+
+// describe names the current token for errors
+func (p *filterParser) describe() string {
+	if p.i >= len(p.tokens) {
+		return "end of filter"
+	}
+	t := p.tokens[p.i]
+	if t.op != "" {
+		return fmt.Sprintf("'%s' at position %d", t.op, t.pos)
+	}
+	return fmt.Sprintf("'%s' at position %d", t.text, t.pos)
+}
+
+func (p *filterParser) or() (requirementFilter, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("||") {
+		p.i++
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(req *model.RequirementDetail) bool { return l(req) || right(req) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) and() (requirementFilter, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("&&") {
+		p.i++
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(req *model.RequirementDetail) bool { return l(req) && right(req) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) unary() (requirementFilter, error) {
+	switch {
+	case p.peek("!"):
+		p.i++
+		inner, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(req *model.RequirementDetail) bool { return !inner(req) }, nil
+	case p.peek("("):
+		p.i++
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peek(")") {
+			return nil, fmt.Errorf("expected ')' instead of %s", p.describe())
+		}
+		p.i++
+		return inner, nil
+	}
+	return p.comparison()
+}
+
+// comparison parses field op value
+func (p *filterParser) comparison() (requirementFilter, error) {
+	if p.i >= len(p.tokens) || p.tokens[p.i].op != "" {
+		return nil, fmt.Errorf("expected a field instead of %s", p.describe())
+	}
+	field := p.tokens[p.i]
+	name := strings.ToLower(field.text)
+	if !slices.Contains(filterFields, name) && !strings.HasPrefix(name, "attributes.") {
+		return nil, fmt.Errorf("unknown field '%s' at position %d (fields: %s, attributes.<name>)", field.text, field.pos, strings.Join(filterFields, ", "))
+	}
+	p.i++
+	if p.i >= len(p.tokens) || !slices.Contains([]string{"==", "!=", "~", "<", "<=", ">", ">="}, p.tokens[p.i].op) {
+		return nil, fmt.Errorf("expected a comparison after '%s' instead of %s", field.text, p.describe())
+	}
+	op := p.tokens[p.i].op
+	p.i++
+	if p.i >= len(p.tokens) || p.tokens[p.i].op != "" {
+		return nil, fmt.Errorf("expected a value after '%s' instead of %s", op, p.describe())
+	}
+	value := p.tokens[p.i]
+	p.i++
+
+	values := func(req *model.RequirementDetail) []string { return filterFieldValues(req, name) }
+	switch op {
+	case "==":
+		return func(req *model.RequirementDetail) bool { return slices.Contains(values(req), value.text) }, nil
+	case "!=":
+		return func(req *model.RequirementDetail) bool { return !slices.Contains(values(req), value.text) }, nil
+	case "~":
+		needle := strings.ToLower(value.text)
+		return func(req *model.RequirementDetail) bool {
+			return slices.ContainsFunc(values(req), func(v string) bool { return strings.Contains(strings.ToLower(v), needle) })
+		}, nil
+	}
+
+	rank, err := filterRank(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s can't be compared with %s at position %d", field.text, op, field.pos)
+	}
+	want, ok := rank(value.text)
+	if !ok {
+		return nil, fmt.Errorf("'%s' at position %d isn't a value %s can be ordered by", value.text, value.pos, field.text)
+	}
+	return func(req *model.RequirementDetail) bool {
+		for _, v := range values(req) {
+			if got, ok := rank(v); ok && compareRanks(got, op, want) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// filterFieldValues are the values of field for req; empty fields have
+// the single value ""
+func filterFieldValues(req *model.RequirementDetail, field string) []string {
+	var values []string
+	switch field {
+	case "summary":
+		values = []string{req.Summary}
+	case "name", "id":
+		values = []string{req.Name}
+	case "uid":
+		values = []string{req.UID}
+	case "description":
+		values = []string{req.Description}
+	case "status":
+		values = []string{req.Status}
+	case "priority":
+		values = []string{req.Priority}
+	case "owner":
+		values = []string{req.Owner}
+	case "verification":
+		values = []string{req.Verification}
+	case "estimate":
+		values = []string{formatOptionalEstimate(req.Estimate)}
+	case "tag", "tags":
+		values = req.Tags
+	case "environment":
+		values = req.Environments
+	default:
+		attr, ok := req.Attributes[strings.TrimPrefix(field, "attributes.")]
+		if ok {
+			values = []string{formatAttribute(attr)}
+		}
+	}
+	if len(values) == 0 {
+		return []string{""}
+	}
+	return values
+}
+
+// filterRank returns how the values of field are ordered, for < and >
+func filterRank(field string) (func(string) (float64, bool), error) {
+	index := func(order []string) func(string) (float64, bool) {
+		return func(v string) (float64, bool) {
+			i := slices.Index(order, v)
+			return float64(i), i >= 0
+		}
+	}
+	switch field {
+	case "status":
+		return index(requirementLifecycle), nil
+	case "priority":
+		return index(priorityRanks), nil
+	case "estimate":
+		return parseFilterNumber, nil
+	}
+	if strings.HasPrefix(field, "attributes.") {
+		return parseFilterNumber, nil
+	}
+	return nil, fmt.Errorf("unordered field %s", field)
+}
+
+// parseFilterNumber reads a number; the empty value of an unset field
+// isn't one
+func parseFilterNumber(v string) (float64, bool) {
+	n, err := strconv.ParseFloat(v, 64)
+	return n, err == nil
+}
+
+// compareRanks applies an ordering operator
+func compareRanks(got float64, op string, want float64) bool {
+	switch op {
+	case "<":
+		return got < want
+	case "<=":
+		return got <= want
+	case ">":
+		return got > want
+	default:
+		return got >= want
+	}
+}
@@ -0,0 +1,89 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const filterYAML = `version: "1.0"
+requirements:
+  - summary: Card payments
+    name: PAY-001
+    status: proposed
+    priority: high
+    owner: "@alice"
+    estimate: 5
+    tags: [payments, security]
+    requirements:
+      - summary: Refunds
+        name: PAY-002
+        status: approved
+        priority: low
+        tags: [payments]
+        attributes:
+          risk: high
+  - summary: Password reset
+    status: implemented
+    priority: critical
+    estimate: 2
+    tags: [auth]
+`
+
+func TestParseFilter(t *testing.T) {
+	config, err := model.ParseYAML([]byte(filterYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for expr, want := range map[string]string{
+		"status==proposed && tag==payments":         "Card payments",
+		"tag==payments":                             "Card payments, Refunds",
+		"tag!=payments":                             "Password reset",
+		"owner==\"\"":                               "Refunds, Password reset",
+		"summary~'PASSWORD'":                        "Password reset",
+		"priority>=high && !(tag==auth)":            "Card payments",
+		"status<implemented || estimate<3":          "Card payments, Refunds, Password reset",
+		"estimate>3":                                "Card payments",
+		"attributes.risk==high":                     "Refunds",
+		"(id==PAY-001 || id==PAY-002) && status!=x": "Card payments, Refunds",
+		"owner==@alice||status==implemented":        "Card payments, Password reset",
+	} {
+		filter, err := parseFilter(expr)
+		if err != nil {
+			t.Errorf("parseFilter(%q): %v", expr, err)
+			continue
+		}
+		var matched []string
+		for _, req := range config.Flatten() {
+			if filter(req) {
+				matched = append(matched, req.Summary)
+			}
+		}
+		if got := strings.Join(matched, ", "); got != want {
+			t.Errorf("%s matched %s, want %s", expr, got, want)
+		}
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	for expr, want := range map[string]string{
+		"":                     "empty expression",
+		"colour==red":          "unknown field 'colour' at position 1",
+		"status":               "expected a comparison after 'status' instead of end of filter",
+		"status==":             "expected a value after '==' instead of end of filter",
+		"(status==draft":       "expected ')' instead of end of filter",
+		"status==draft tag==x": "unexpected 'tag' at position 15",
+		"summary>b":            "summary can't be compared with >",
+		"priority>urgent":      "'urgent' at position 10 isn't a value priority can be ordered by",
+		"summary=='unfinished": "unterminated quote at position 10",
+	} {
+		if _, err := parseFilter(expr); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseFilter(%q) error = %v, want %q", expr, err, want)
+		}
+	}
+}
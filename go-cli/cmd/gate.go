@@ -0,0 +1,193 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gateMilestone     string
+	gateRequireStatus string
+	gateResults       []string
+	gateNotify        bool
+)
+
+// milestoneTagPrefix starts the tag that puts a requirement in a
+// milestone: milestone:2.0
+const milestoneTagPrefix = "milestone:"
+
+// gateBlocker is a requirement of the milestone that isn't done, with
+// why
+type gateBlocker struct {
+	req     *model.RequirementDetail
+	reasons []string
+}
+
+var gateCmd = &cobra.Command{
+	Use:   "gate [file]",
+	Short: "Fail unless every requirement of a milestone is done, to block releases in CI",
+	Long: `Check that every requirement tagged for a milestone is done, and exit
+with code 1 listing the ones that aren't, so a release job can be blocked
+until the milestone is complete.
+
+A requirement belongs to milestone 2.0 when it's tagged milestone:2.0:
+
+  - summary: Export to PDF
+    status: implemented
+    tags: [export, milestone:2.0]
+
+It's done when its status is at or past --require-status in the lifecycle
+draft, proposed, approved, implemented, verified; implemented by default,
+which verified requirements meet too. Deprecated requirements don't block
+a release.
+
+With --results, given JUnit files from rqm test --format junit (FILE,
+ENV=FILE or a glob, as for rqm envmatrix), a requirement must also have a
+passing acceptance test: at least one pass and no failure in any
+environment.
+
+A milestone no requirement is tagged for is an error (exit code 3) rather
+than a pass, so a mistyped milestone doesn't let a release through.
+
+With --notify, a blocked milestone is also reported to the destinations
+under notifications: in .rqm/config.yml, as for rqm ci --notify, naming
+each requirement that isn't done and why.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm gate --milestone 2.0
+  rqm gate requirements.yml --milestone 2.0 --require-status verified
+  rqm test --format junit -o rqm-junit.xml; rqm gate --milestone 2.0 --results rqm-junit.xml
+  rqm gate --milestone 2.0 --notify`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	// Blocking requirements are results; usage would only hide them
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !slices.Contains(requirementLifecycle, gateRequireStatus) {
+			return fmt.Errorf("invalid --require-status %q (expected one of %s)", gateRequireStatus, strings.Join(requirementLifecycle, ", "))
+		}
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+
+		var results map[*model.RequirementDetail]string
+		if len(gateResults) > 0 {
+			byEnv := make(map[string]map[*model.RequirementDetail]string)
+			for _, spec := range gateResults {
+				// Results from other tools are fine without an environment
+				if !strings.Contains(spec, "=") {
+					spec = "default=" + spec
+				}
+				if err := loadEnvResults(config, spec, byEnv); err != nil {
+					return err
+				}
+			}
+			results = make(map[*model.RequirementDetail]string)
+			for _, envResults := range byEnv {
+				for req, result := range envResults {
+					results[req] = combineResults(results[req], result)
+				}
+			}
+		}
+
+		members, blockers := checkMilestone(config, gateMilestone, gateRequireStatus, results)
+		if len(members) == 0 {
+			err := fmt.Errorf("no requirement is tagged %s%s", milestoneTagPrefix, gateMilestone)
+			if gateNotify {
+				notifyGateFailure("gate", file, []GateViolation{{Check: "milestone", Result: "error", Details: err.Error()}})
+			}
+			return err
+		}
+		writeGateResult(os.Stdout, gateMilestone, members, blockers)
+		if len(blockers) > 0 {
+			if gateNotify {
+				notifyGateFailure("gate", file, []GateViolation{gateViolation(gateMilestone, members, blockers)})
+			}
+			return validationError("milestone %s is blocked by %d requirement(s)", gateMilestone, len(blockers))
+		}
+		return nil
+	},
+}
+
+// checkMilestone returns the requirements of milestone in config that
+// aren't deprecated and those of them that aren't done: below status, or
+// without a passing test when results isn't nil
+func checkMilestone(config *model.RequirementConfig, milestone, status string, results map[*model.RequirementDetail]string) ([]*model.RequirementDetail, []gateBlocker) {
+	var members []*model.RequirementDetail
+	var blockers []gateBlocker
+	for _, req := range config.Flatten() {
+		if req.IsDeprecated() || !slices.Contains(req.Tags, milestoneTagPrefix+milestone) {
+			continue
+		}
+		members = append(members, req)
+
+		var reasons []string
+		if !pastStatus(req.Status, status) {
+			reasons = append(reasons, fmt.Sprintf("status is %s, not %s", statusOrNone(req.Status), status))
+		}
+		if results != nil {
+			switch results[req] {
+			case "pass":
+			case "fail":
+				reasons = append(reasons, "acceptance test fails")
+			default:
+				reasons = append(reasons, "no passing acceptance test")
+			}
+		}
+		if len(reasons) > 0 {
+			blockers = append(blockers, gateBlocker{req: req, reasons: reasons})
+		}
+	}
+	return members, blockers
+}
+
+// gateViolation describes a blocked milestone for notifications: the
+// requirements that aren't done, each with why
+func gateViolation(milestone string, members []*model.RequirementDetail, blockers []gateBlocker) GateViolation {
+	v := GateViolation{
+		Check:   "milestone",
+		Result:  "fail",
+		Details: fmt.Sprintf("milestone %s: %d of %d requirement(s) not done", milestone, len(blockers), len(members)),
+	}
+	for _, b := range blockers {
+		v.Requirements = append(v.Requirements, requirementLabel(b.req)+": "+strings.Join(b.reasons, "; "))
+	}
+	return v
+}
+
+// writeGateResult reports the requirements blocking milestone
+func writeGateResult(w io.Writer, milestone string, members []*model.RequirementDetail, blockers []gateBlocker) {
+	if len(blockers) == 0 {
+		fmt.Fprintf(w, "%s Milestone %s: all %d requirement(s) done\n", okMark(), milestone, len(members))
+		return
+	}
+	fmt.Fprintf(w, "%s Milestone %s: %d of %d requirement(s) not done\n\n", failMark(), milestone, len(blockers), len(members))
+	for _, b := range blockers {
+		fmt.Fprintf(w, "  %s: %s\n", displayName(b.req), strings.Join(b.reasons, "; "))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(gateCmd)
+	gateCmd.Flags().StringVar(&gateMilestone, "milestone", "", "Milestone whose requirements must be done, as tagged milestone:<name>")
+	gateCmd.Flags().StringVar(&gateRequireStatus, "require-status", "implemented", "Lowest status that counts as done (draft, proposed, approved, implemented, verified)")
+	gateCmd.Flags().StringArrayVar(&gateResults, "results", nil, "JUnit results from rqm test whose acceptance tests must pass: FILE, ENV=FILE or a glob (repeatable)")
+	gateCmd.Flags().BoolVar(&gateNotify, "notify", false, "Report a blocked milestone to the notifications configured in .rqm/config.yml")
+	gateCmd.MarkFlagRequired("milestone")
+}
@@ -0,0 +1,94 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const gateYAML = `version: "1.0"
+requirements:
+  - summary: PDF export
+    name: EXP-001
+    status: verified
+    tags: [export, milestone:2.0]
+  - summary: DOCX export
+    name: EXP-002
+    status: approved
+    tags: [milestone:2.0]
+    requirements:
+      - summary: Page numbers
+        status: implemented
+        tags: [milestone:2.0]
+  - summary: Legacy export
+    status: draft
+    deprecated: true
+    tags: [milestone:2.0]
+  - summary: Dark mode
+    status: draft
+    tags: [milestone:3.0]
+`
+
+func TestCheckMilestone(t *testing.T) {
+	config, err := model.ParseYAML([]byte(gateYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	members, blockers := checkMilestone(config, "2.0", "implemented", nil)
+	if len(members) != 3 {
+		t.Errorf("milestone 2.0 has %d requirements, want 3 without the deprecated one", len(members))
+	}
+	if len(blockers) != 1 || blockers[0].req.Name != "EXP-002" || blockers[0].reasons[0] != "status is approved, not implemented" {
+		t.Errorf("blockers = %+v, want EXP-002", blockers)
+	}
+
+	if _, blockers = checkMilestone(config, "2.0", "verified", nil); len(blockers) != 2 {
+		t.Errorf("requiring verified, %d blockers, want 2", len(blockers))
+	}
+
+	pdf, _ := config.Find("EXP-001")
+	page, _ := config.Find("Page numbers")
+	results := map[*model.RequirementDetail]string{pdf: "pass", page: "fail"}
+	_, blockers = checkMilestone(config, "2.0", "implemented", results)
+	var got []string
+	for _, b := range blockers {
+		got = append(got, displayName(b.req)+": "+strings.Join(b.reasons, "; "))
+	}
+	want := "[EXP-002] DOCX export: status is approved, not implemented; no passing acceptance test, " +
+		"Page numbers: acceptance test fails"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("blockers with results = %s", strings.Join(got, ", "))
+	}
+
+	if members, _ = checkMilestone(config, "2.1", "implemented", nil); len(members) != 0 {
+		t.Errorf("milestone 2.1 has %d requirements", len(members))
+	}
+}
+
+func TestWriteGateResult(t *testing.T) {
+	config, _ := model.ParseYAML([]byte(gateYAML))
+	members, blockers := checkMilestone(config, "2.0", "implemented", nil)
+	var out strings.Builder
+	writeGateResult(&out, "2.0", members, blockers)
+	if got := out.String(); !strings.Contains(got, "Milestone 2.0: 1 of 3 requirement(s) not done") || !strings.Contains(got, "  [EXP-002] DOCX export: status is approved, not implemented\n") {
+		t.Errorf("gate result:\n%s", got)
+	}
+}
+
+func TestGateViolation(t *testing.T) {
+	config, _ := model.ParseYAML([]byte(gateYAML))
+	members, blockers := checkMilestone(config, "2.0", "implemented", nil)
+	v := gateViolation("2.0", members, blockers)
+	if v.Check != "milestone" || v.Result != "fail" || v.Details != "milestone 2.0: 1 of 3 requirement(s) not done" {
+		t.Errorf("Unexpected violation %+v", v)
+	}
+	if len(v.Requirements) != 1 || v.Requirements[0] != "EXP-002  DOCX export: status is approved, not implemented" {
+		t.Errorf("Unexpected requirements %q", v.Requirements)
+	}
+}
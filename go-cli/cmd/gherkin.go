@@ -0,0 +1,296 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// gherkinBlock matches the lines of an acceptance test that open a
+// scenario or its parts, and sit one level above steps
+var gherkinBlock = regexp.MustCompile(`^(Scenario|Scenario Outline|Scenario Template|Example|Background|Examples|Scenarios|Rule):`)
+
+var (
+	// gherkinRequirementTag matches the tags of feature files that look
+	// like requirement IDs, such as @AUTH-001 or @SEC-AUTH-001
+	gherkinRequirementTag = regexp.MustCompile(`^@\pL[\pL\pN_.-]*-\pN+$`)
+	// gherkinTagInvalid matches what can't be part of a tag: whitespace
+	// ends a tag, and @ and # start another tag or a comment
+	gherkinTagInvalid = regexp.MustCompile(`[\s@#]+`)
+)
+
+// gherkinTag is the tag of the feature exported for a requirement named
+// name, with what can't be part of a tag replaced by underscores
+func gherkinTag(name string) string {
+	return "@" + gherkinTagInvalid.ReplaceAllString(strings.TrimSpace(name), "_")
+}
+
+// isGherkin tells whether an acceptance test is written as Given/When/Then
+// steps
+func isGherkin(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "Given ") {
+			return true
+		}
+	}
+	return false
+}
+
+// featureFile renders req as a feature tagged with its ID. The acceptance
+// test becomes the scenarios of the feature: as written when it has
+// Scenario lines of its own, or as one scenario named after the
+// requirement.
+func featureFile(req *model.RequirementDetail, source string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by rqm export --format gherkin from %s; edit the requirement instead\n", source)
+	fmt.Fprintf(&b, "%s\nFeature: %s\n", gherkinTag(req.Name), req.Summary)
+	if description := strings.TrimSpace(req.Description); description != "" {
+		for _, line := range strings.Split(description, "\n") {
+			fmt.Fprintf(&b, "  %s\n", strings.TrimSpace(line))
+		}
+	}
+	b.WriteString("\n")
+
+	var lines []string
+	ownScenarios := false
+	for _, line := range strings.Split(strings.TrimSpace(req.AcceptanceTest), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Feature:") {
+			continue
+		}
+		if gherkinBlock.MatchString(line) {
+			ownScenarios = true
+		}
+		lines = append(lines, line)
+	}
+	if !ownScenarios {
+		fmt.Fprintf(&b, "  Scenario: %s\n", req.Summary)
+	}
+	for _, line := range lines {
+		switch {
+		case line == "":
+			b.WriteString("\n")
+		case gherkinBlock.MatchString(line):
+			fmt.Fprintf(&b, "  %s\n", line)
+		default:
+			fmt.Fprintf(&b, "    %s\n", line)
+		}
+	}
+	return b.String()
+}
+
+// runGherkinExport writes a feature file into dir for each requirement of
+// config with a Given/When/Then acceptance test
+func runGherkinExport(config *model.RequirementConfig, file, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	written, unnamed, other := 0, 0, 0
+	used := make(map[string]bool)
+	for _, req := range config.Flatten() {
+		if !isGherkin(req.AcceptanceTest) {
+			if req.AcceptanceTest != "" {
+				other++
+			}
+			continue
+		}
+		if req.Name == "" {
+			// The feature's tag is what ties it to the requirement
+			fmt.Printf("%s %s has Given/When/Then steps but no name to tag its feature with\n", warnMark(), displayName(req))
+			unnamed++
+			continue
+		}
+
+		base := splitFileName(req)
+		name := base
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s-%d", base, i)
+		}
+		used[name] = true
+		path := filepath.Join(dir, name+".feature")
+		if err := os.WriteFile(path, []byte(featureFile(req, filepath.Base(file))), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written++
+	}
+
+	fmt.Printf("%s Wrote %d feature file(s) to %s\n", okMark(), written, dir)
+	if other > 0 {
+		fmt.Printf("  %d acceptance test(s) aren't Given/When/Then steps and were skipped\n", other)
+	}
+	if unnamed > 0 {
+		fmt.Printf("  %d requirement(s) without a name were skipped\n", unnamed)
+	}
+	return nil
+}
+
+// featureTag is a requirement tag found in a feature file
+type featureTag struct {
+	path string
+	line int
+	tag  string
+}
+
+// featureTagIndex maps the tags rqm export --format gherkin writes to the
+// requirements of config they stand for
+func featureTagIndex(config *model.RequirementConfig) map[string]*model.RequirementDetail {
+	index := make(map[string]*model.RequirementDetail)
+	for _, req := range config.Flatten() {
+		if req.Name != "" {
+			index[gherkinTag(req.Name)] = req
+		}
+	}
+	return index
+}
+
+// scanFeatureTags collects the requirement tags of the .feature files
+// under dir: those the export writes for a requirement of config, and
+// others that look like IDs
+func scanFeatureTags(dir string, config *model.RequirementConfig) ([]featureTag, int, error) {
+	index := featureTagIndex(config)
+	var tags []featureTag
+	files := 0
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".feature" {
+			return nil
+		}
+		files++
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for n := 1; scanner.Scan(); n++ {
+			line := strings.TrimSpace(scanner.Text())
+			if !strings.HasPrefix(line, "@") {
+				continue
+			}
+			for _, tag := range strings.Fields(line) {
+				if strings.HasPrefix(tag, "#") {
+					break
+				}
+				if index[tag] != nil || gherkinRequirementTag.MatchString(tag) {
+					tags = append(tags, featureTag{path: path, line: n, tag: tag})
+				}
+			}
+		}
+		return scanner.Err()
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read feature files in %s: %w", dir, err)
+	}
+	return tags, files, nil
+}
+
+var traceFeaturesCmd = &cobra.Command{
+	Use:   "features <dir> [file]",
+	Short: "Check that feature file tags name existing requirements",
+	Long: `Check the .feature files under dir for tags naming requirements, such
+as those rqm export --format gherkin writes, and report tags that name no
+requirement of the file. The export tags each feature with its
+requirement's ID, spaces and the characters @ and # replaced by
+underscores. Tags count as requirement tags when they are such a tag or
+look like an ID, starting with a letter and ending with a dash and digits
+(@AUTH-001, @SEC-AUTH-001); others such as @smoke are left alone.
+
+Requirements with Given/When/Then acceptance tests that no feature file
+tags are listed as warnings, which fail the command only with
+--fail-on warning.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm trace features features
+  rqm trace features test/features requirements.yml --fail-on warning`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkFailOn(); err != nil {
+			return err
+		}
+		file, err := requirementsFileArg(args[1:])
+		if err != nil {
+			return err
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+		tags, files, err := scanFeatureTags(args[0], config)
+		if err != nil {
+			return err
+		}
+
+		unknown, untagged := traceFeatures(config, tags)
+		writeFeatureTrace(os.Stdout, tags, files, unknown, untagged, file)
+
+		var failure error
+		if len(unknown) > 0 {
+			failure = validationError("%d feature tag(s) name no requirement in %s", len(unknown), file)
+		}
+		return applyFailOn(failure, len(untagged))
+	},
+}
+
+// traceFeatures returns the tags naming no requirement of config, and the
+// requirements with Given/When/Then acceptance tests no tag names
+func traceFeatures(config *model.RequirementConfig, tags []featureTag) ([]featureTag, []*model.RequirementDetail) {
+	index := featureTagIndex(config)
+	var unknown []featureTag
+	tagged := make(map[*model.RequirementDetail]bool)
+	for _, tag := range tags {
+		req, ok := index[tag.tag]
+		if !ok {
+			if req, ok = config.Find(strings.TrimPrefix(tag.tag, "@")); !ok {
+				unknown = append(unknown, tag)
+				continue
+			}
+		}
+		tagged[req] = true
+	}
+	sort.SliceStable(unknown, func(i, j int) bool {
+		return unknown[i].path < unknown[j].path
+	})
+
+	var untagged []*model.RequirementDetail
+	for _, req := range config.Flatten() {
+		if isGherkin(req.AcceptanceTest) && !tagged[req] {
+			untagged = append(untagged, req)
+		}
+	}
+	return unknown, untagged
+}
+
+// writeFeatureTrace reports unknown tags and untagged requirements
+func writeFeatureTrace(w io.Writer, tags []featureTag, files int, unknown []featureTag, untagged []*model.RequirementDetail, file string) {
+	for _, tag := range unknown {
+		fmt.Fprintf(w, "%s %s:%d: %s names no requirement in %s\n", failMark(), tag.path, tag.line, tag.tag, file)
+	}
+	for _, req := range untagged {
+		fmt.Fprintf(w, "%s %s has Given/When/Then steps but no feature is tagged with it\n", warnMark(), displayName(req))
+	}
+	if len(unknown) == 0 && len(untagged) == 0 {
+		fmt.Fprintf(w, "%s Every requirement tag names a requirement\n", okMark())
+	}
+	fmt.Fprintf(w, "\n%d feature file(s), %d requirement tag(s): %d unknown; %d Given/When/Then requirement(s) untagged\n", files, len(tags), len(unknown), len(untagged))
+}
+
+func init() {
+	traceCmd.AddCommand(traceFeaturesCmd)
+	traceFeaturesCmd.Flags().StringVar(&failOn, "fail-on", "error", "Lowest severity that fails the command (error, warning, none)")
+}
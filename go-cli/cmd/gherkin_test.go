@@ -0,0 +1,118 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const gherkinYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    description: Users sign in with their email address.
+    acceptance_test: |
+      Given a registered user
+      When they sign in with the right password
+      Then they see their dashboard
+  - summary: Lockout
+    name: AUTH-002
+    acceptance_test: |
+      Scenario: Too many attempts
+        Given a registered user
+        When they enter a wrong password 5 times
+        Then the account is locked
+  - summary: Audit
+    name: AUTH-003
+    acceptance_test: Sign-ins appear in the audit log
+  - summary: Password reset
+    acceptance_test: |
+      Given a user who forgot their password
+      Then they can reset it
+  - summary: Single sign-on
+    name: SEC-AUTH-001
+    acceptance_test: |
+      Given a user of the identity provider
+      Then they are signed in
+  - summary: Sessions
+    name: "Session timeout #2"
+    acceptance_test: |
+      Given an idle session
+      Then it expires
+`
+
+func TestFeatureFile(t *testing.T) {
+	config, err := model.ParseYAML([]byte(gherkinYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flat := config.Flatten()
+
+	want := `# Generated by rqm export --format gherkin from requirements.yml; edit the requirement instead
+@AUTH-001
+Feature: Login
+  Users sign in with their email address.
+
+  Scenario: Login
+    Given a registered user
+    When they sign in with the right password
+    Then they see their dashboard
+`
+	if got := featureFile(flat[0], "requirements.yml"); got != want {
+		t.Errorf("featureFile() =\n%s\nwant\n%s", got, want)
+	}
+	if got := featureFile(flat[1], "requirements.yml"); !strings.Contains(got, "\n  Scenario: Too many attempts\n    Given a registered user\n") || strings.Contains(got, "Scenario: Lockout") {
+		t.Errorf("Expected the acceptance test's own scenario, got:\n%s", got)
+	}
+	if got := featureFile(flat[5], "requirements.yml"); !strings.Contains(got, "\n@Session_timeout_2\nFeature: Sessions\n") {
+		t.Errorf("Expected the name made into one tag, got:\n%s", got)
+	}
+	if isGherkin(flat[2].AcceptanceTest) {
+		t.Errorf("Expected a plain acceptance test not to count as Gherkin")
+	}
+}
+
+func TestTraceFeatures(t *testing.T) {
+	config, err := model.ParseYAML([]byte(gherkinYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "login.feature"), []byte("@AUTH-001 @smoke\nFeature: Login\n"), 0644)
+	os.MkdirAll(filepath.Join(dir, "old"), 0755)
+	os.WriteFile(filepath.Join(dir, "old", "sso.feature"), []byte("# SSO\n@wip @AUTH-404 # removed\nFeature: SSO\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("@AUTH-405\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "sso.feature"), []byte("@SEC-AUTH-001\nFeature: Single sign-on\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "sessions.feature"), []byte("@Session_timeout_2\nFeature: Sessions\n"), 0644)
+
+	tags, files, err := scanFeatureTags(dir, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files != 4 || len(tags) != 4 {
+		t.Fatalf("Expected 4 requirement tags in 4 files, got %+v in %d", tags, files)
+	}
+
+	unknown, untagged := traceFeatures(config, tags)
+	if len(unknown) != 1 || unknown[0].tag != "@AUTH-404" || unknown[0].line != 2 {
+		t.Errorf("Expected @AUTH-404 on line 2 unknown, got %+v", unknown)
+	}
+	// Password reset has steps too, but no name to be tagged with
+	if len(untagged) != 2 || untagged[0].Name != "AUTH-002" || untagged[1].Summary != "Password reset" {
+		t.Errorf("Expected Lockout and Password reset untagged, got %v", untagged)
+	}
+
+	var out bytes.Buffer
+	writeFeatureTrace(&out, tags, files, unknown, untagged, "requirements.yml")
+	if !strings.Contains(out.String(), "4 feature file(s), 4 requirement tag(s): 1 unknown; 2 Given/When/Then requirement(s) untagged") {
+		t.Errorf("Unexpected output:\n%s", out.String())
+	}
+}
@@ -0,0 +1,163 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// messageQuotedPattern finds the requirement a project check's message
+// names, as in "Requirement 'Login' uses undeclared attribute"
+var messageQuotedPattern = regexp.MustCompile(`'([^']+)'`)
+
+// annotationLocator finds the line in a requirements file that a message
+// refers to, so GitHub can attach the annotation to it
+type annotationLocator struct {
+	// lines maps summaries and IDs to the lines of the requirements using them
+	lines map[string][]int
+}
+
+func newAnnotationLocator(file string) *annotationLocator {
+	l := &annotationLocator{lines: make(map[string][]int)}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return l
+	}
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) != nil || len(doc.Content) == 0 {
+		return l
+	}
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		list := mappingValue(node, "requirements")
+		if list == nil || list.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, item := range list.Content {
+			for _, key := range []string{"summary", "name"} {
+				if value := mappingValue(item, key); value != nil && value.Kind == yaml.ScalarNode {
+					l.lines[value.Value] = append(l.lines[value.Value], item.Line)
+				}
+			}
+			walk(item)
+		}
+	}
+	walk(doc.Content[0])
+	return l
+}
+
+// requirementLine returns the line of the requirement with the given
+// summary or ID. When several share it, as with duplicate summaries, the
+// last one is the one to point at.
+func (l *annotationLocator) requirementLine(ref string) int {
+	lines := l.lines[ref]
+	if len(lines) == 0 {
+		return 0
+	}
+	return lines[len(lines)-1]
+}
+
+// messageLine finds the requirement a project check's message names,
+// and gives its line, or 0
+func (l *annotationLocator) messageLine(msg string) int {
+	for _, m := range messageQuotedPattern.FindAllStringSubmatch(msg, -1) {
+		if line := l.requirementLine(m[1]); line > 0 {
+			return line
+		}
+	}
+	return 0
+}
+
+// writeAnnotation prints a GitHub Actions workflow command such as
+// ::error file=r.yml,line=3::message. level is error, warning or notice.
+func writeAnnotation(w io.Writer, level, file string, line int, msg string) {
+	props := "file=" + escapeAnnotationProperty(fileLabel(file))
+	if line > 0 {
+		props += ",line=" + strconv.Itoa(line)
+	}
+	fmt.Fprintf(w, "::%s %s::%s\n", level, props, escapeAnnotationData(msg))
+}
+
+// escapeAnnotationData escapes a workflow command message
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a workflow command property value
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// writeValidationAnnotations prints validate results as workflow commands,
+// with the messages localizeValidationErrors gives
+func writeValidationAnnotations(w io.Writer, file string, result *ValidationResult) {
+	l := newAnnotationLocator(file)
+	for _, msg := range localizeValidationErrors(file, result) {
+		writeAnnotation(w, "error", file, l.friendlyMessageLine(msg), msg.annotation())
+	}
+	uncategorized := result.Warnings[:len(result.Warnings)-len(result.Categorized)]
+	for _, msg := range plainMessages(uncategorized) {
+		writeAnnotation(w, "warning", file, l.friendlyMessageLine(msg), msg.annotation())
+	}
+	for _, warning := range result.Categorized {
+		writeAnnotation(w, "warning", file, l.requirementLine(warning.Requirement), "["+warning.Category+"] "+warning.Message)
+	}
+}
+
+// friendlyMessageLine gives the line the validator reports for a
+// message, or else that of the requirement it is about
+func (l *annotationLocator) friendlyMessageLine(msg friendlyMessage) int {
+	if msg.Line > 0 {
+		return msg.Line
+	}
+	if msg.Summary != "" {
+		return l.requirementLine(msg.Summary)
+	}
+	return l.messageLine(msg.Raw)
+}
+
+// annotation is the message with its fix on a second line
+func (msg friendlyMessage) annotation() string {
+	if msg.Fix == "" {
+		return msg.Text
+	}
+	return msg.Text + "\n" + msg.FixLabel + ": " + msg.Fix
+}
+
+// writeCheckAnnotations prints cycles, inactive owners, coverage gaps and
+// external reference problems as workflow commands, each on the first
+// requirement involved
+func writeCheckAnnotations(w io.Writer, file string, result *CycleCheckResult, inactive []InactiveOwner, gaps []CoverageGap, external []ExternalProblem) {
+	l := newAnnotationLocator(file)
+	for _, cycle := range result.Cycles {
+		if len(cycle) == 0 {
+			continue
+		}
+		msg := "Circular reference among " + strings.Join(cycle, ", ")
+		writeAnnotation(w, "error", file, l.requirementLine(cycle[0]), msg)
+	}
+	for _, owner := range inactive {
+		line := l.requirementLine(owner.Summary)
+		writeAnnotation(w, "error", file, line, fmt.Sprintf("Owner %s of '%s' is %s in the directory",
+			owner.Owner, owner.Summary, strings.ReplaceAll(owner.Reason, "_", " ")))
+	}
+	for _, gap := range gaps {
+		writeAnnotation(w, "error", file, l.requirementLine(gap.Summary), gap.message())
+	}
+	for _, p := range external {
+		level := "error"
+		if p.Reason == "unreachable" {
+			level = "warning"
+		}
+		writeAnnotation(w, level, file, l.requirementLine(p.Summary), p.message())
+	}
+}
@@ -0,0 +1,107 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/rqmcore"
+)
+
+const annotationTestYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    requirements:
+      - summary: Password policy
+        name: AUTH-002
+  - summary: Login
+    name: AUTH-003
+`
+
+func TestAnnotationLocator(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(annotationTestYAML), 0644)
+	l := newAnnotationLocator(file)
+
+	tests := []struct {
+		msg  string
+		want int
+	}{
+		{"Requirement 'Password policy' uses undeclared attribute 'risk'", 6},
+		{"Requirement 'Unknown' is missing required attribute 'risk'", 0},
+		{"Schema validation failed", 0},
+	}
+	for _, tt := range tests {
+		if got := l.messageLine(tt.msg); got != tt.want {
+			t.Errorf("messageLine(%q) = %d, want %d", tt.msg, got, tt.want)
+		}
+	}
+	if got := l.requirementLine("AUTH-002"); got != 6 {
+		t.Errorf("requirementLine(AUTH-002) = %d, want 6", got)
+	}
+
+	// The validator's line wins, then the requirement's
+	for _, tt := range []struct {
+		msg  friendlyMessage
+		want int
+	}{
+		{friendlyMessage{Line: 4, Summary: "Login"}, 4},
+		{friendlyMessage{Summary: "Login"}, 8},
+		{friendlyMessage{Raw: "Requirement 'Password policy' uses undeclared attribute 'risk'"}, 6},
+	} {
+		if got := l.friendlyMessageLine(tt.msg); got != tt.want {
+			t.Errorf("friendlyMessageLine(%+v) = %d, want %d", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestWriteValidationAnnotations(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(annotationTestYAML), 0644)
+
+	t.Setenv("RQM_LANG", "en")
+	var b strings.Builder
+	writeValidationAnnotations(&b, file, &ValidationResult{
+		Errors:   []string{"Duplicate summary: Login"},
+		Details:  []rqmcore.ErrorDetail{{Code: "duplicate_summary", Message: "Duplicate summary: Login", Summary: "Login"}},
+		Warnings: []string{"100% of\nrequirements lack tests"},
+	})
+
+	want := "::error file=" + file + ",line=8::More than one requirement has the summary 'Login'" +
+		"%0AFix: summaries identify requirements, so reword one of them, or replace the copy with a reference to the original\n" +
+		"::warning file=" + file + "::100%25 of%0Arequirements lack tests\n"
+	if b.String() != want {
+		t.Errorf("Got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestEscapeAnnotationProperty(t *testing.T) {
+	if got := escapeAnnotationProperty("dir,x/a:b.yml"); got != "dir%2Cx/a%3Ab.yml" {
+		t.Errorf("Got %q", got)
+	}
+}
+
+func TestWriteCheckAnnotations(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(annotationTestYAML), 0644)
+
+	var b strings.Builder
+	writeCheckAnnotations(&b, file, &CycleCheckResult{HasCycles: true, Cycles: [][]string{{"AUTH-002", "AUTH-001"}}},
+		[]InactiveOwner{{Summary: "Password policy", Owner: "bob", Reason: "not_found"}}, nil, nil)
+
+	out := b.String()
+	for _, want := range []string{
+		",line=6::Circular reference among AUTH-002, AUTH-001\n",
+		",line=6::Owner bob of 'Password policy' is not found in the directory\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/rqm/graph"
+)
+
+// graphClusterKeys maps --cluster-by values to the function giving a
+// requirement's cluster. Requirements with an empty key are left outside
+// every cluster. A node can only be drawn in one cluster, so tag uses the
+// first tag.
+var graphClusterKeys = map[string]func(req *model.RequirementDetail) string{
+	"tag": func(req *model.RequirementDetail) string {
+		if len(req.Tags) == 0 {
+			return ""
+		}
+		return req.Tags[0]
+	},
+	"component": func(req *model.RequirementDetail) string {
+		if value, ok := req.Attributes["component"]; ok && value != nil {
+			return fmt.Sprint(value)
+		}
+		return ""
+	},
+	"owner": func(req *model.RequirementDetail) string {
+		return req.Owner
+	},
+}
+
+// graphExport is a requirement graph prepared for DOT or Mermaid output:
+// nodes get short identifiers and are grouped into clusters
+type graphExport struct {
+	g *graph.Graph
+	// ids maps summaries to node identifiers n0, n1, ...
+	ids    map[string]string
+	labels map[string]string
+	// deprecated holds the summaries of deprecated requirements, grayed out
+	deprecated map[string]bool
+	// clusters lists cluster names in order of first appearance, members
+	// the summaries in each; unclustered holds the rest
+	clusters    []string
+	members     map[string][]string
+	unclustered []string
+	// external lists the external references, drawn as distinct nodes
+	// x0, x1, ... outside every cluster
+	external []externalEdge
+}
+
+// externalEdge is a child reference from a requirement to a requirement
+// of another repository
+type externalEdge struct {
+	from  string
+	id    string
+	label string
+}
+
+// newGraphExport builds the graph of config, clustered by clusterBy (tag,
+// component, owner) or not at all when it is empty
+func newGraphExport(config *model.RequirementConfig, clusterBy string) (*graphExport, error) {
+	var key func(*model.RequirementDetail) string
+	if clusterBy != "" {
+		var ok bool
+		if key, ok = graphClusterKeys[clusterBy]; !ok {
+			return nil, fmt.Errorf("unknown cluster key: %s (supported: %s)", clusterBy, strings.Join(sortedKeys(graphClusterKeys), ", "))
+		}
+	}
+
+	e := &graphExport{
+		g:          requirementGraph(config),
+		ids:        make(map[string]string),
+		labels:     make(map[string]string),
+		deprecated: make(map[string]bool),
+		members:    make(map[string][]string),
+	}
+	details := make(map[string]*model.RequirementDetail)
+	for _, req := range config.Flatten() {
+		if _, ok := details[req.Summary]; !ok {
+			details[req.Summary] = req
+		}
+		for _, child := range req.Requirements {
+			if child.External != nil {
+				e.external = append(e.external, externalEdge{
+					from:  req.Summary,
+					id:    fmt.Sprintf("x%d", len(e.external)),
+					label: child.External.String(),
+				})
+			}
+		}
+	}
+
+	for i, node := range e.g.Nodes() {
+		e.ids[node.ID] = fmt.Sprintf("n%d", i)
+		req := details[node.ID]
+		e.labels[node.ID] = displayName(req)
+		if req.IsDeprecated() {
+			e.deprecated[node.ID] = true
+			e.labels[node.ID] += " " + deprecationNote(req)
+		}
+
+		cluster := ""
+		if key != nil {
+			cluster = key(req)
+		}
+		if cluster == "" {
+			e.unclustered = append(e.unclustered, node.ID)
+			continue
+		}
+		if _, ok := e.members[cluster]; !ok {
+			e.clusters = append(e.clusters, cluster)
+		}
+		e.members[cluster] = append(e.members[cluster], node.ID)
+	}
+	return e, nil
+}
+
+// writeDOT writes the graph in Graphviz DOT. Clusters become
+// cluster_ subgraphs, which dot draws as labeled boxes.
+func (e *graphExport) writeDOT(w io.Writer) {
+	fmt.Fprintln(w, "digraph requirements {")
+	fmt.Fprintln(w, `  node [shape=box, style=rounded];`)
+	for i, cluster := range e.clusters {
+		fmt.Fprintf(w, "  subgraph cluster_%d {\n    label=%s;\n", i, dotQuote(cluster))
+		for _, id := range e.members[cluster] {
+			fmt.Fprintf(w, "    %s;\n", e.dotNode(id))
+		}
+		fmt.Fprintln(w, "  }")
+	}
+	for _, id := range e.unclustered {
+		fmt.Fprintf(w, "  %s;\n", e.dotNode(id))
+	}
+	for _, x := range e.external {
+		fmt.Fprintf(w, "  %s [label=%s, style=\"rounded,dashed\"];\n", x.id, dotQuote(x.label))
+	}
+	for _, edge := range e.g.Edges() {
+		if edge.Kind == graph.KindChild {
+			fmt.Fprintf(w, "  %s -> %s;\n", e.ids[edge.From], e.ids[edge.To])
+			continue
+		}
+		fmt.Fprintf(w, "  %s -> %s [style=dashed, label=%s];\n", e.ids[edge.From], e.ids[edge.To], dotQuote(string(edge.Kind)))
+	}
+	for _, x := range e.external {
+		fmt.Fprintf(w, "  %s -> %s;\n", e.ids[x.from], x.id)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// writeMermaid writes the graph as a Mermaid flowchart, with clusters as
+// subgraphs
+func (e *graphExport) writeMermaid(w io.Writer) {
+	fmt.Fprintln(w, "flowchart TD")
+	for i, cluster := range e.clusters {
+		fmt.Fprintf(w, "  subgraph c%d[%s]\n", i, mermaidQuote(cluster))
+		for _, id := range e.members[cluster] {
+			fmt.Fprintf(w, "    %s\n", e.mermaidNode(id))
+		}
+		fmt.Fprintln(w, "  end")
+	}
+	for _, id := range e.unclustered {
+		fmt.Fprintf(w, "  %s\n", e.mermaidNode(id))
+	}
+	for _, x := range e.external {
+		fmt.Fprintf(w, "  %s>%s]:::external\n", x.id, mermaidQuote(x.label))
+	}
+	for _, edge := range e.g.Edges() {
+		if edge.Kind == graph.KindChild {
+			fmt.Fprintf(w, "  %s --> %s\n", e.ids[edge.From], e.ids[edge.To])
+			continue
+		}
+		fmt.Fprintf(w, "  %s -.->|%s| %s\n", e.ids[edge.From], mermaidQuote(string(edge.Kind)), e.ids[edge.To])
+	}
+	for _, x := range e.external {
+		fmt.Fprintf(w, "  %s --> %s\n", e.ids[x.from], x.id)
+	}
+	if len(e.external) > 0 {
+		fmt.Fprintln(w, "  classDef external stroke-dasharray: 5 5")
+	}
+	if len(e.deprecated) > 0 {
+		fmt.Fprintln(w, "  classDef deprecated fill:#eee,color:#888")
+	}
+}
+
+// dotNode is the DOT statement of a requirement's node, filled gray when
+// it is deprecated
+func (e *graphExport) dotNode(id string) string {
+	if e.deprecated[id] {
+		return fmt.Sprintf(`%s [label=%s, style="rounded,filled", fillcolor="#eeeeee", fontcolor="#888888"]`, e.ids[id], dotQuote(e.labels[id]))
+	}
+	return fmt.Sprintf("%s [label=%s]", e.ids[id], dotQuote(e.labels[id]))
+}
+
+// mermaidNode is the Mermaid node of a requirement, of the deprecated
+// class when it is deprecated
+func (e *graphExport) mermaidNode(id string) string {
+	if e.deprecated[id] {
+		return fmt.Sprintf("%s[%s]:::deprecated", e.ids[id], mermaidQuote(e.labels[id]))
+	}
+	return fmt.Sprintf("%s[%s]", e.ids[id], mermaidQuote(e.labels[id]))
+}
+
+// dotQuote returns s as a DOT string literal
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// mermaidQuote returns s as a quoted Mermaid label
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s) + `"`
+}
@@ -0,0 +1,111 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const graphExportYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    tags: [auth]
+    attributes:
+      component: identity
+    requirements:
+      - summary: Password "hashing"
+        tags: [auth, crypto]
+        attributes:
+          component: identity
+      - external:
+          repo: github.com/acme/platform
+          id: PLAT-42
+    relations:
+      - type: depends_on
+        target: Audit log
+  - summary: Audit log
+    owner: ops@example.com
+`
+
+func newTestGraphExport(t *testing.T, clusterBy string) *graphExport {
+	t.Helper()
+	config, err := model.ParseYAML([]byte(graphExportYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := newGraphExport(config, clusterBy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestGraphExportClusters(t *testing.T) {
+	tests := []struct {
+		clusterBy   string
+		clusters    []string
+		unclustered int
+	}{
+		{"", nil, 3},
+		{"tag", []string{"auth"}, 1},
+		{"component", []string{"identity"}, 1},
+		{"owner", []string{"ops@example.com"}, 2},
+	}
+	for _, tt := range tests {
+		e := newTestGraphExport(t, tt.clusterBy)
+		if strings.Join(e.clusters, ",") != strings.Join(tt.clusters, ",") || len(e.unclustered) != tt.unclustered {
+			t.Errorf("--cluster-by %q: clusters %v with %d unclustered, want %v with %d",
+				tt.clusterBy, e.clusters, len(e.unclustered), tt.clusters, tt.unclustered)
+		}
+	}
+
+	config, _ := model.ParseYAML([]byte(graphExportYAML))
+	if _, err := newGraphExport(config, "priority"); err == nil || !strings.Contains(err.Error(), "unknown cluster key: priority") {
+		t.Errorf("Expected unknown cluster key error, got %v", err)
+	}
+}
+
+func TestGraphExportDOT(t *testing.T) {
+	var out strings.Builder
+	newTestGraphExport(t, "component").writeDOT(&out)
+	dot := out.String()
+	for _, want := range []string{
+		"digraph requirements {",
+		"subgraph cluster_0 {\n    label=\"identity\";\n    n0 [label=\"[AUTH-001] Login\"];\n    n1 [label=\"Password \\\"hashing\\\"\"];\n  }",
+		"  n2 [label=\"Audit log\"];",
+		"  n0 -> n1;",
+		"  n0 -> n2 [style=dashed, label=\"depends_on\"];",
+		"  x0 [label=\"PLAT-42@github.com/acme/platform\", style=\"rounded,dashed\"];",
+		"  n0 -> x0;",
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT missing %q:\n%s", want, dot)
+		}
+	}
+}
+
+func TestGraphExportMermaid(t *testing.T) {
+	var out strings.Builder
+	newTestGraphExport(t, "tag").writeMermaid(&out)
+	mermaid := out.String()
+	for _, want := range []string{
+		"flowchart TD\n",
+		"  subgraph c0[\"auth\"]\n    n0[\"[AUTH-001] Login\"]\n    n1[\"Password #quot;hashing#quot;\"]\n  end",
+		"  n2[\"Audit log\"]",
+		"  n0 --> n1",
+		"  n0 -.->|\"depends_on\"| n2",
+		"  x0>\"PLAT-42@github.com/acme/platform\"]:::external",
+		"  n0 --> x0",
+		"  classDef external stroke-dasharray: 5 5",
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Mermaid missing %q:\n%s", want, mermaid)
+		}
+	}
+}
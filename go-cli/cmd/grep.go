@@ -0,0 +1,271 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	grepIgnoreCase   bool
+	grepFixedStrings bool
+	grepFields       []string
+)
+
+// grepMatch is a line of a requirements file with a match of rqm grep's
+// pattern
+type grepMatch struct {
+	file string
+	// line and column are 1-based; column counts bytes, as ripgrep's do
+	line, column int
+	text         string
+	// start and end are the byte offsets of the match in text, or both
+	// 0 when it isn't on the line as written
+	start, end int
+}
+
+var grepCmd = &cobra.Command{
+	Use:   "grep PATTERN [file]",
+	Short: "Search the text of requirements, printing file:line:column locations",
+	Long: `Search every text field of every requirement (summary, name, description,
+justification, acceptance test, tags, owner, relations and the rest) for
+PATTERN, a regular expression, and print each matching line of the
+requirements file as ripgrep does with --vimgrep:
+
+  requirements.yml:42:18:    description: Users sign in with a password
+
+so the results load straight into an editor's quickfix list (Vim's
+:cexpr, Emacs' grep-mode, VS Code's problem matchers) and jump to the
+definition of the requirement. A match in a multi-line description is
+reported on the line it is on. Keys and comments aren't searched, and
+files the requirements file includes are searched too, each reported
+under its own path.
+
+--field limits the search to some fields, by their YAML key, and can be
+repeated. rqm grep reads the file itself, so it works on files that don't
+validate; TOML requirements files have no YAML lines to point at and
+aren't supported.
+
+The exit code is 0 when something matched and 1 when nothing did, as
+with grep.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm grep password
+  rqm grep -i 'single sign-on|sso' requirements.yml
+  rqm grep -F 'p99 < 200ms' --field acceptance_test
+  vim -q <(rqm grep TODO)`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pattern, err := grepPattern(args[0], grepFixedStrings, grepIgnoreCase)
+		if err != nil {
+			return err
+		}
+		file, err := requirementsFileArg(args[1:])
+		if err != nil {
+			return err
+		}
+		matches, err := grepFile(file, pattern, grepFields, nil)
+		if err != nil {
+			return err
+		}
+		writeGrepMatches(os.Stdout, matches)
+		if len(matches) == 0 {
+			return validationError("no requirement text matches %s", args[0])
+		}
+		return nil
+	},
+}
+
+// grepPattern compiles rqm grep's pattern, quoting it first for
+// --fixed-strings
+func grepPattern(pattern string, fixed, ignoreCase bool) (*regexp.Regexp, error) {
+	if fixed {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return re, nil
+}
+
+// grepFile finds the lines of file, and of the files it includes, where a
+// text field of a requirement matches pattern. fields, when set, are the
+// keys of the fields to search. stack holds the files being searched, to
+// catch a file that includes itself.
+func grepFile(file string, pattern *regexp.Regexp, fields []string, stack []string) ([]grepMatch, error) {
+	if model.FormatOf(file) == model.FormatTOML {
+		return nil, fmt.Errorf("rqm grep doesn't support TOML requirements files: %s", fileLabel(file))
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	// JSON is YAML as far as the parser is concerned, lines and all
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", fileLabel(file), err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	// A value runs until the next node starts
+	var starts []int
+	var collect func(node *yaml.Node)
+	collect = func(node *yaml.Node) {
+		starts = append(starts, node.Line)
+		for _, child := range node.Content {
+			collect(child)
+		}
+	}
+	collect(root)
+	sort.Ints(starts)
+	end := func(node *yaml.Node) int {
+		i := sort.SearchInts(starts, node.Line+1)
+		if i == len(starts) {
+			return len(lines)
+		}
+		return starts[i] - 1
+	}
+
+	var matches []grepMatch
+	seen := make(map[int]bool)
+	add := func(m grepMatch) {
+		if !seen[m.line] {
+			seen[m.line] = true
+			matches = append(matches, m)
+		}
+	}
+	search := func(value *yaml.Node) {
+		if !pattern.MatchString(value.Value) {
+			return
+		}
+		found := false
+		for n := value.Line; n <= end(value) && n <= len(lines); n++ {
+			text := lines[n-1]
+			from := 0
+			if n == value.Line {
+				// The key before the value isn't part of it
+				from = byteOffset(text, value.Column-1)
+			}
+			if loc := pattern.FindStringIndex(text[from:]); loc != nil {
+				add(grepMatch{line: n, column: from + loc[0] + 1, text: text, start: from + loc[0], end: from + loc[1]})
+				found = true
+			}
+		}
+		// The match spans lines, or escapes hide it
+		if !found && value.Line <= len(lines) {
+			text := lines[value.Line-1]
+			add(grepMatch{line: value.Line, column: byteOffset(text, value.Column-1) + 1, text: text})
+		}
+	}
+	var scalars func(node *yaml.Node)
+	scalars = func(node *yaml.Node) {
+		switch node.Kind {
+		case yaml.ScalarNode:
+			search(node)
+		case yaml.SequenceNode:
+			for _, child := range node.Content {
+				scalars(child)
+			}
+		case yaml.MappingNode:
+			for i := 1; i < len(node.Content); i += 2 {
+				scalars(node.Content[i])
+			}
+		}
+	}
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		list := mappingValue(node, "requirements")
+		if list == nil || list.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, item := range list.Content {
+			if item.Kind != yaml.MappingNode {
+				continue
+			}
+			for i := 0; i+1 < len(item.Content); i += 2 {
+				key := item.Content[i].Value
+				if key == "requirements" || len(fields) > 0 && !slices.Contains(fields, key) {
+					continue
+				}
+				scalars(item.Content[i+1])
+			}
+			walk(item)
+		}
+	}
+	walk(root)
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].line < matches[j].line })
+	for i := range matches {
+		matches[i].file = fileLabel(file)
+	}
+
+	stack = append(stack, canonicalPath(file))
+	if includes := mappingValue(root, "includes"); includes != nil && includes.Kind == yaml.SequenceNode {
+		for _, include := range includes.Content {
+			path := filepath.Join(filepath.Dir(file), filepath.FromSlash(include.Value))
+			if slices.Contains(stack, canonicalPath(path)) {
+				return nil, fmt.Errorf("%s includes %s, which includes it back", fileLabel(file), include.Value)
+			}
+			included, err := grepFile(path, pattern, fields, stack)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search %s, included by %s: %w", include.Value, fileLabel(file), err)
+			}
+			matches = append(matches, included...)
+		}
+	}
+	return matches, nil
+}
+
+// byteOffset is the byte offset of the rune at index runes of s, or the
+// length of s when s is shorter
+func byteOffset(s string, runes int) int {
+	for i := range s {
+		if runes == 0 {
+			return i
+		}
+		runes--
+	}
+	return len(s)
+}
+
+// writeGrepMatches prints matches as file:line:column:text, the match
+// highlighted when color is enabled
+func writeGrepMatches(w io.Writer, matches []grepMatch) {
+	for _, m := range matches {
+		text := m.text
+		if m.end > m.start {
+			text = text[:m.start] + paint(ansiBold+ansiRed, text[m.start:m.end]) + text[m.end:]
+		}
+		fmt.Fprintf(w, "%s:%s:%d:%s\n", paint(ansiCyan, m.file), paint(ansiGreen, fmt.Sprint(m.line)), m.column, text)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(grepCmd)
+	grepCmd.Flags().BoolVarP(&grepIgnoreCase, "ignore-case", "i", false, "Match case-insensitively")
+	grepCmd.Flags().BoolVarP(&grepFixedStrings, "fixed-strings", "F", false, "Treat PATTERN as a literal string, not a regular expression")
+	grepCmd.Flags().StringSliceVar(&grepFields, "field", nil, "Only search these fields, by YAML key (e.g. description,acceptance_test)")
+}
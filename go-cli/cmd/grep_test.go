@@ -0,0 +1,104 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const grepRequirements = `version: "1.0"
+includes:
+  - billing.yml
+requirements:
+  - summary: Password login
+    name: AUTH-1
+    description: |
+      Users sign in with their email
+      and a password of 12 characters.
+    tags: [security, password]
+    relations:
+      - type: depends_on
+        target: Session store
+    requirements:
+      - summary: "Lockout after five wrong passwords"
+        acceptance_test: >-
+          Five wrong passwords
+          lock the account
+  - summary: Session store # sessions survive a password change
+`
+
+func TestGrepFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "requirements.yml")
+	os.WriteFile(file, []byte(grepRequirements), 0644)
+	os.WriteFile(filepath.Join(dir, "billing.yml"), []byte("version: \"1.0\"\nrequirements:\n  - summary: Invoices\n    description: No password needed\n"), 0644)
+
+	pattern, err := grepPattern("password", false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches, err := grepFile(file, pattern, nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	writeGrepMatches(&buf, matches)
+	billing := filepath.Join(dir, "billing.yml")
+	want := strings.Join([]string{
+		file + ":5:14:  - summary: Password login",
+		file + ":9:13:      and a password of 12 characters.",
+		file + ":10:22:    tags: [security, password]",
+		file + ":15:44:      - summary: \"Lockout after five wrong passwords\"",
+		file + ":17:22:          Five wrong passwords",
+		billing + ":4:21:    description: No password needed",
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected matches:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	// Comments, keys and other fields are left out
+	pattern, _ = grepPattern("session|target|wrong passwords lock", false, true)
+	matches, err = grepFile(file, pattern, []string{"acceptance_test", "summary"}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var got []int
+	for _, m := range matches {
+		got = append(got, m.line)
+	}
+	// The acceptance test matches across its folded lines, so it's
+	// reported where it starts
+	if len(got) != 2 || got[0] != 16 || got[1] != 19 {
+		t.Errorf("Expected matches on lines 16 and 19, got %v", got)
+	}
+}
+
+func TestGrepFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	pattern, _ := grepPattern("x", false, false)
+
+	file := filepath.Join(dir, "requirements.toml")
+	os.WriteFile(file, []byte("version = \"1.0\"\n"), 0644)
+	if _, err := grepFile(file, pattern, nil, nil); err == nil || !strings.Contains(err.Error(), "TOML") {
+		t.Errorf("Expected an error for a TOML file, got %v", err)
+	}
+
+	file = filepath.Join(dir, "requirements.yml")
+	os.WriteFile(file, []byte("version: \"1.0\"\nincludes: [requirements.yml]\n"), 0644)
+	if _, err := grepFile(file, pattern, nil, nil); err == nil || !strings.Contains(err.Error(), "includes it back") {
+		t.Errorf("Expected an error for a file including itself, got %v", err)
+	}
+
+	if _, err := grepPattern("(", false, false); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+	if re, _ := grepPattern("a.b(", true, false); !re.MatchString("xa.b(") || re.MatchString("axb(") {
+		t.Error("Expected --fixed-strings to match literally")
+	}
+}
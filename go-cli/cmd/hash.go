@@ -0,0 +1,203 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hashFormat string
+	hashShort  bool
+)
+
+// shortHashLength is the number of hex digits printed by --short
+const shortHashLength = 12
+
+// RequirementHash is the canonical hash of one requirement
+type RequirementHash struct {
+	ID      string `json:"id,omitempty"`
+	Summary string `json:"summary"`
+	Hash    string `json:"hash"`
+}
+
+var hashCmd = &cobra.Command{
+	Use:   "hash [file] [id...]",
+	Short: "Print a content hash of each requirement",
+	Long: `Print a SHA-256 hash of each requirement's normalized content.
+
+The hash changes only when the requirement itself changes, so external
+systems can store it and tell exactly which requirements were edited
+without diffing files. Before hashing:
+  - text fields are trimmed and line endings normalized, so reflowing a
+    YAML block scalar doesn't count as a change
+  - tags, verified_by, further_information, relations and approvals are
+    sorted, since their order carries no meaning
+  - sub-requirements contribute only their ID (or summary), so editing a
+    child changes the child's hash but not its parent's
+
+Pass IDs or summaries after the file to hash only those requirements.
+With --format json the result is printed as JSON; see
+'rqm schema output hash' for its schema.`,
+	Example: `  rqm hash requirements.yml
+  rqm hash requirements.yml AUTH-001 --short
+  rqm hash requirements.yml --format json`,
+	Args: cobra.MinimumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeRequirementsFile(cmd, args, toComplete)
+		}
+		return requirementIDCandidates(args[0], toComplete), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := args[0]
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+
+		hashes, err := hashRequirements(config, args[1:])
+		if err != nil {
+			return err
+		}
+
+		switch hashFormat {
+		case "json":
+			return writeJSON(HashOutput{
+				SchemaVersion: apiVersion,
+				File:          file,
+				Algorithm:     "sha256",
+				Requirements:  nonNil(hashes),
+			})
+		case "text":
+			for _, h := range hashes {
+				hash := h.Hash
+				if hashShort {
+					hash = hash[:shortHashLength]
+				}
+				fmt.Printf("%s  %s\n", hash, displayName(&model.RequirementDetail{Name: h.ID, Summary: h.Summary}))
+			}
+			return nil
+		default:
+			return fmt.Errorf("unknown output format: %s", hashFormat)
+		}
+	},
+}
+
+// hashRequirements hashes every requirement in tree order, or only those
+// whose ID or summary is in ids
+func hashRequirements(config *model.RequirementConfig, ids []string) ([]RequirementHash, error) {
+	found := make(map[string]bool)
+	var hashes []RequirementHash
+	for _, req := range config.Flatten() {
+		if len(ids) > 0 {
+			if !slices.Contains(ids, req.Name) && !slices.Contains(ids, req.Summary) {
+				continue
+			}
+			found[req.Name] = true
+			found[req.Summary] = true
+		}
+		hashes = append(hashes, RequirementHash{ID: req.Name, Summary: req.Summary, Hash: requirementHash(req)})
+	}
+
+	for _, id := range ids {
+		if !found[id] {
+			return nil, fmt.Errorf("requirement not found: %s", id)
+		}
+	}
+	return hashes, nil
+}
+
+// requirementHash returns the hex SHA-256 of req's canonical form
+func requirementHash(req *model.RequirementDetail) string {
+	sum := sha256.Sum256(canonicalRequirement(req))
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalRequirement encodes req in the normalized form that is hashed.
+// Struct fields are encoded in declaration order and map keys sorted, so
+// the encoding is deterministic.
+func canonicalRequirement(req *model.RequirementDetail) []byte {
+	c := *req
+	c.Summary = normalizeText(c.Summary)
+	c.Name = normalizeText(c.Name)
+	c.Description = normalizeText(c.Description)
+	c.Justification = normalizeText(c.Justification)
+	c.AcceptanceTest = normalizeText(c.AcceptanceTest)
+	c.AcceptanceTestLink = strings.TrimSpace(c.AcceptanceTestLink)
+	c.Owner = strings.TrimSpace(c.Owner)
+	c.SupersededBy = normalizeText(c.SupersededBy)
+	// The uid is identity, not content: assigning one leaves the hash alone
+	c.UID = ""
+
+	c.Tags = sortedCopy(c.Tags)
+	c.VerifiedBy = sortedCopy(c.VerifiedBy)
+	c.Environments = sortedCopy(c.Environments)
+	c.FurtherInformation = sortedCopy(c.FurtherInformation)
+
+	c.Relations = append([]model.Relation(nil), c.Relations...)
+	sort.Slice(c.Relations, func(i, j int) bool {
+		a, b := c.Relations[i], c.Relations[j]
+		return a.Type < b.Type || a.Type == b.Type && a.Target < b.Target
+	})
+	c.Approvals = append([]model.Approval(nil), c.Approvals...)
+	sort.Slice(c.Approvals, func(i, j int) bool {
+		a, b := c.Approvals[i], c.Approvals[j]
+		return a.Approver < b.Approver || a.Approver == b.Approver && a.Due < b.Due
+	})
+
+	c.Requirements = nil
+	for _, child := range req.Requirements {
+		ref := child.Reference
+		if child.External != nil {
+			ref = "external:" + child.External.String()
+		}
+		if child.Full != nil {
+			ref = child.Full.Name
+			if ref == "" {
+				ref = child.Full.Summary
+			}
+		}
+		c.Requirements = append(c.Requirements, model.RequirementReference{Reference: normalizeText(ref)})
+	}
+
+	data, _ := json.Marshal(c)
+	return data
+}
+
+// normalizeText trims trailing whitespace from every line and surrounding
+// blank lines, and converts CRLF line endings
+func normalizeText(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n ")
+}
+
+// sortedCopy returns a sorted copy of s, nil when s is empty
+func sortedCopy(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	c := append([]string(nil), s...)
+	sort.Strings(c)
+	return c
+}
+
+func init() {
+	rootCmd.AddCommand(hashCmd)
+	hashCmd.Flags().StringVarP(&hashFormat, "format", "f", "text", "Output format (text, json)")
+	hashCmd.Flags().BoolVar(&hashShort, "short", false, "Print the first 12 hex digits of each hash")
+}
@@ -0,0 +1,96 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func hashTestRequirement() *model.RequirementDetail {
+	return &model.RequirementDetail{
+		Summary:     "Login",
+		Name:        "AUTH-001",
+		Description: "Users sign in\nwith a password.\n",
+		Tags:        []string{"security", "auth"},
+		Relations:   []model.Relation{{Type: "depends_on", Target: "B"}, {Type: "blocks", Target: "C"}},
+		Requirements: []model.RequirementReference{
+			{Full: &model.RequirementDetail{Summary: "Hashing", Name: "AUTH-002", Status: "draft"}},
+			{Reference: "AUTH-003"},
+		},
+	}
+}
+
+func TestRequirementHashIgnoresFormatting(t *testing.T) {
+	base := requirementHash(hashTestRequirement())
+	if len(base) != 64 {
+		t.Fatalf("Expected a hex SHA-256, got %q", base)
+	}
+
+	same := map[string]func(r *model.RequirementDetail){
+		"trailing whitespace": func(r *model.RequirementDetail) { r.Description = "Users sign in  \r\nwith a password." },
+		"tag order":           func(r *model.RequirementDetail) { r.Tags = []string{"auth", "security"} },
+		"relation order":      func(r *model.RequirementDetail) { r.Relations[0], r.Relations[1] = r.Relations[1], r.Relations[0] },
+		"child content":       func(r *model.RequirementDetail) { r.Requirements[0].Full.Status = "implemented" },
+	}
+	for name, edit := range same {
+		req := hashTestRequirement()
+		edit(req)
+		if got := requirementHash(req); got != base {
+			t.Errorf("%s: hash changed", name)
+		}
+	}
+
+	changed := map[string]func(r *model.RequirementDetail){
+		"description": func(r *model.RequirementDetail) { r.Description = "Users sign in with a passkey." },
+		"status":      func(r *model.RequirementDetail) { r.Status = "approved" },
+		"new tag":     func(r *model.RequirementDetail) { r.Tags = append(r.Tags, "mvp") },
+		"child order": func(r *model.RequirementDetail) {
+			r.Requirements[0], r.Requirements[1] = r.Requirements[1], r.Requirements[0]
+		},
+		"attribute": func(r *model.RequirementDetail) { r.Attributes = map[string]interface{}{"risk": "high"} },
+	}
+	for name, edit := range changed {
+		req := hashTestRequirement()
+		edit(req)
+		if got := requirementHash(req); got == base {
+			t.Errorf("%s: hash did not change", name)
+		}
+	}
+}
+
+func TestRequirementHashDoesNotModifyInput(t *testing.T) {
+	req := hashTestRequirement()
+	requirementHash(req)
+	if req.Tags[0] != "security" || req.Requirements[0].Full == nil {
+		t.Errorf("requirementHash modified its input: %+v", req)
+	}
+}
+
+func TestHashRequirements(t *testing.T) {
+	config := &model.RequirementConfig{Requirements: []model.RequirementDetail{*hashTestRequirement(), {Summary: "Audit"}}}
+
+	all, err := hashRequirements(config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 || all[1].ID != "AUTH-002" || all[2].Summary != "Audit" {
+		t.Errorf("Unexpected hashes: %+v", all)
+	}
+
+	some, err := hashRequirements(config, []string{"Audit", "AUTH-002"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(some) != 2 || some[0].Hash != all[1].Hash {
+		t.Errorf("Expected AUTH-002 and Audit in tree order, got %+v", some)
+	}
+
+	if _, err := hashRequirements(config, []string{"NOPE-1"}); err == nil || !strings.Contains(err.Error(), "requirement not found: NOPE-1") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
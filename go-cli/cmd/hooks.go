@@ -0,0 +1,363 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	hooksInstall   []string
+	hooksForce     bool
+	hooksFramework string
+	hooksStaged    bool
+	hooksAll       bool
+)
+
+// hookMarker identifies hook scripts written by rqm, which may be replaced
+// without --force
+const hookMarker = "# Installed by rqm hooks install"
+
+// gitHooks maps the hooks rqm can install to the files they check:
+// staged requirements files before a commit, every tracked one before a push
+var gitHooks = map[string]string{
+	"pre-commit": "--staged",
+	"pre-push":   "--all",
+}
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Run rqm from git hooks",
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install git hooks that validate and check requirements files",
+	Long: `Install git hooks that run rqm validate and rqm check on requirements files.
+
+  - pre-commit checks the requirements files being committed
+  - pre-push checks every tracked requirements file
+
+A requirements file is any tracked .yml/.yaml file with a top-level
+requirements: key. Existing hooks are left alone unless they were
+installed by rqm or --force is given. The hooks call rqm from PATH; set
+RQM to use another binary.
+
+With --framework pre-commit nothing is installed; instead the hook
+definitions for the pre-commit framework (https://pre-commit.com) are
+printed, ready for .pre-commit-hooks.yaml or a local repo entry in
+.pre-commit-config.yaml.`,
+	Example: `  rqm hooks install
+  rqm hooks install --hook pre-commit
+  rqm hooks install --framework pre-commit >> .pre-commit-hooks.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch hooksFramework {
+		case "pre-commit":
+			return writePreCommitHooks()
+		case "git":
+		default:
+			return fmt.Errorf("unknown hook framework: %s (expected git or pre-commit)", hooksFramework)
+		}
+
+		for _, hook := range hooksInstall {
+			if _, ok := gitHooks[hook]; !ok {
+				return fmt.Errorf("unknown hook: %s (supported: %s)", hook, strings.Join(sortedKeys(gitHooks), ", "))
+			}
+		}
+
+		dir, err := gitOutput("rev-parse", "--git-path", "hooks")
+		if err != nil {
+			return fmt.Errorf("not a git repository: %w", err)
+		}
+		for _, hook := range hooksInstall {
+			path, err := installGitHook(dir, hook, hooksForce)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s Installed %s hook: %s\n", okMark(), hook, path)
+		}
+		return nil
+	},
+}
+
+var hooksRunCmd = &cobra.Command{
+	Use:   "run [file...]",
+	Short: "Validate and check requirements files (used by the installed hooks)",
+	Long: `Run rqm validate and rqm check on each requirements file.
+
+Files come from the arguments, or with --staged from the files staged for
+commit and with --all from every tracked file. With --staged the content
+staged for commit is checked, not the working tree, so an edit that isn't
+staged can't hide a broken commit; files it includes are read from the
+working tree. Only YAML files with a top-level requirements: key are
+checked, so other YAML in the repository can be passed safely. Every file
+is checked even after a failure, and the exit code is the most severe one.`,
+	// Failures are check results; usage would only clutter commit output
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var files []string
+		for _, arg := range args {
+			// Missing files are passed on so validate reports them
+			if _, err := os.Stat(arg); err != nil || isRequirementsFile(arg) {
+				files = append(files, arg)
+			}
+		}
+		if hooksStaged || hooksAll {
+			if len(args) > 0 {
+				return fmt.Errorf("file arguments cannot be combined with --staged or --all")
+			}
+			var err error
+			if files, err = gitRequirementsFiles(hooksStaged); err != nil {
+				return err
+			}
+		}
+
+		var failure error
+		for _, file := range files {
+			for _, run := range []func(string) error{runValidation, runCheck} {
+				var err error
+				if hooksStaged {
+					err = withStagedFile(file, run)
+				} else {
+					err = run(file)
+				}
+				if err != nil && exitCode(err) > exitCode(failure) {
+					failure = err
+				}
+			}
+		}
+		return failure
+	},
+}
+
+// installGitHook writes the script for hook into dir and returns its path.
+// A hook not written by rqm is only replaced when force is set.
+func installGitHook(dir, hook string, force bool) (string, error) {
+	path := filepath.Join(dir, hook)
+	if existing, err := os.ReadFile(path); err == nil && !force && !strings.Contains(string(existing), hookMarker) {
+		return "", fmt.Errorf("%s already exists and was not installed by rqm (use --force to replace it)", path)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := os.WriteFile(path, []byte(gitHookScript(hook)), 0755); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0755); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// gitHookScript is the shell script installed for hook
+func gitHookScript(hook string) string {
+	return fmt.Sprintf(`#!/bin/sh
+%s (rerunning it replaces this file)
+exec "${RQM:-rqm}" hooks run %s
+`, hookMarker, gitHooks[hook])
+}
+
+// preCommitHook is an entry of a pre-commit framework .pre-commit-hooks.yaml
+type preCommitHook struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Entry       string `yaml:"entry"`
+	Language    string `yaml:"language"`
+	Files       string `yaml:"files"`
+}
+
+// writePreCommitHooks prints the hook definitions for the pre-commit framework
+func writePreCommitHooks() error {
+	hooks := []preCommitHook{{
+		ID:          "rqm",
+		Name:        "rqm validate and check",
+		Description: "Validate requirements files and check them for circular references",
+		Entry:       "rqm hooks run",
+		Language:    "system",
+		Files:       `\.ya?ml$`,
+	}}
+	data, err := yaml.Marshal(hooks)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(data))
+	return nil
+}
+
+// gitRequirementsFiles lists the requirements files staged for commit, or
+// every tracked one when staged is false, relative to the current
+// directory. Staged files are told apart by their staged content.
+func gitRequirementsFiles(staged bool) ([]string, error) {
+	args := []string{"ls-files", "-z", "--full-name"}
+	if staged {
+		args = []string{"diff", "--cached", "--name-only", "-z", "--diff-filter=ACMR"}
+	}
+	out, err := gitOutput(args...)
+	if err != nil {
+		return nil, err
+	}
+	root, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	// git reports the top level with symlinks resolved
+	if resolved, err := filepath.EvalSymlinks(cwd); err == nil {
+		cwd = resolved
+	}
+
+	var files []string
+	for _, name := range strings.Split(out, "\x00") {
+		if name == "" {
+			continue
+		}
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if rel, err := filepath.Rel(cwd, path); err == nil {
+			path = rel
+		}
+		if staged {
+			data, err := stagedContent(path)
+			if err != nil {
+				return nil, err
+			}
+			if !isRequirementsData(path, data) {
+				continue
+			}
+		} else if !isRequirementsFile(path) {
+			continue
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// isRequirementsFile reports whether path is a YAML, TOML or JSON file
+// with a top-level requirements key
+func isRequirementsFile(path string) bool {
+	if !model.IsRequirementsExt(filepath.Ext(path)) {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return isRequirementsData(path, data)
+}
+
+// isRequirementsData reports whether data, the content of path, has a
+// top-level requirements key
+func isRequirementsData(path string, data []byte) bool {
+	if !model.IsRequirementsExt(filepath.Ext(path)) {
+		return false
+	}
+	var top map[string]yaml.Node
+	converted, err := model.ToYAML(path, data)
+	if err == nil {
+		err = yaml.Unmarshal(converted, &top)
+	}
+	if err != nil {
+		// A broken requirements file should still fail the hook
+		return requirementsFileContent.Match(data)
+	}
+	_, ok := top["requirements"]
+	return ok
+}
+
+// stagedContent returns the content of file, a path relative to the
+// current directory, as staged for commit
+func stagedContent(file string) ([]byte, error) {
+	// :./path and :../path name the staged blob relative to the current
+	// directory, where :path would be relative to the top level
+	spec := filepath.ToSlash(file)
+	if !strings.HasPrefix(spec, "../") {
+		spec = "./" + spec
+	}
+	return gitBytes("show", ":"+spec)
+}
+
+// withStagedFile runs fn on a copy of the staged content of file, shown
+// as file. Like a copy of stdin, it is a hidden file next to file, so
+// .rqm/config.yml and includes resolve as they would for file.
+func withStagedFile(file string, fn func(string) error) error {
+	data, err := stagedContent(file)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".rqm-staged-*"+filepath.Ext(file))
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	path := tmp.Name()
+	defer os.Remove(path)
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	fileLabels[path] = file
+	defer delete(fileLabels, path)
+	if err := fn(path); err != nil {
+		return &labeledError{err: err, path: path, label: file}
+	}
+	return nil
+}
+
+// gitOutput runs git with args and returns its trimmed stdout
+func gitOutput(args ...string) (string, error) {
+	out, err := gitBytes(args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// gitBytes runs git with args and returns its stdout as is
+func gitBytes(args ...string) ([]byte, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		// Errors name the subcommand, after any -C <dir>
+		name := args[0]
+		if name == "-C" && len(args) > 2 {
+			name = args[2]
+		}
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && len(exit.Stderr) > 0 {
+			return nil, fmt.Errorf("git %s: %s", name, strings.TrimSpace(string(exit.Stderr)))
+		}
+		return nil, fmt.Errorf("git %s: %w", name, err)
+	}
+	return out, nil
+}
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksRunCmd)
+	hooksInstallCmd.Flags().StringSliceVar(&hooksInstall, "hook", []string{"pre-commit", "pre-push"}, "Hooks to install (pre-commit, pre-push)")
+	hooksInstallCmd.Flags().BoolVar(&hooksForce, "force", false, "Replace existing hooks not installed by rqm")
+	hooksInstallCmd.Flags().StringVar(&hooksFramework, "framework", "git", "Hook framework: git installs hook scripts, pre-commit prints .pre-commit-hooks.yaml entries")
+	hooksRunCmd.Flags().BoolVar(&hooksStaged, "staged", false, "Check the requirements files staged for commit")
+	hooksRunCmd.Flags().BoolVar(&hooksAll, "all", false, "Check every tracked requirements file")
+	hooksRunCmd.MarkFlagsMutuallyExclusive("staged", "all")
+}
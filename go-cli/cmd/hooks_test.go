@@ -0,0 +1,185 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// gitTestRepo makes a new temporary directory the current one and a git
+// repository with a committer set, skipping the test without git
+func gitTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	t.Chdir(dir)
+	mustGit(t, "init", "-q")
+	mustGit(t, "config", "user.email", "test@example.com")
+	mustGit(t, "config", "user.name", "Test")
+	return dir
+}
+
+// gitCommitFiles writes files, by path relative to the current directory,
+// and commits them with message; without files the commit is empty
+func gitCommitFiles(t *testing.T, message string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		os.MkdirAll(filepath.Dir(name), 0755)
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		mustGit(t, "add", name)
+	}
+	mustGit(t, "commit", "-q", "--allow-empty", "-m", message)
+}
+
+// mustGit runs git, failing the test when it fails
+func mustGit(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := gitOutput(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestInstallGitHook(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+
+	path, err := installGitHook(dir, "pre-commit", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected hook to be executable, mode %v", info.Mode())
+	}
+	script, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(script), "#!/bin/sh\n") || !strings.Contains(string(script), `hooks run --staged`) {
+		t.Errorf("Unexpected hook script:\n%s", script)
+	}
+
+	// Reinstalling over our own hook is fine
+	if _, err := installGitHook(dir, "pre-commit", false); err != nil {
+		t.Errorf("Expected rqm's own hook to be replaced, got %v", err)
+	}
+
+	foreign := filepath.Join(dir, "pre-push")
+	os.WriteFile(foreign, []byte("#!/bin/sh\nmake lint\n"), 0644)
+	if _, err := installGitHook(dir, "pre-push", false); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("Expected a foreign hook to be kept, got %v", err)
+	}
+	if _, err := installGitHook(dir, "pre-push", true); err != nil {
+		t.Fatalf("Unexpected error with force: %v", err)
+	}
+	script, _ = os.ReadFile(foreign)
+	if !strings.Contains(string(script), "hooks run --all") {
+		t.Errorf("Expected --force to replace the hook, got:\n%s", script)
+	}
+	if info, _ := os.Stat(foreign); info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected replaced hook to be executable, mode %v", info.Mode())
+	}
+}
+
+func TestIsRequirementsFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"requirements.yml": "version: \"1.0\"\nrequirements:\n  - summary: A\n",
+		"broken.yaml":      "version: \"1.0\"\nrequirements:\n  - summary: [\n",
+		"workflow.yml":     "name: CI\non: push\n",
+		"notes.txt":        "requirements:\n",
+		"reqs.toml":        "version = \"1.0\"\n\n[[requirements]]\nsummary = \"A\"\n",
+		"reqs.json":        `{"version": "1.0", "requirements": []}`,
+		"package.json":     `{"name": "web"}`,
+	}
+	for name, content := range files {
+		os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+	}
+
+	for name, want := range map[string]bool{
+		"requirements.yml": true,
+		"broken.yaml":      true,
+		"workflow.yml":     false,
+		"notes.txt":        false,
+		"reqs.toml":        true,
+		"reqs.json":        true,
+		"package.json":     false,
+	} {
+		if got := isRequirementsFile(filepath.Join(dir, name)); got != want {
+			t.Errorf("isRequirementsFile(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestGitRequirementsFiles(t *testing.T) {
+	dir := gitTestRepo(t)
+	os.MkdirAll(filepath.Join(dir, ".rqm"), 0755)
+	os.WriteFile(filepath.Join(dir, ".rqm", "requirements.yml"), []byte("requirements: []\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "other.yml"), []byte("requirements: []\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "ci.yml"), []byte("jobs: {}\n"), 0644)
+	mustGit(t, "add", ".rqm/requirements.yml", "ci.yml")
+
+	staged, err := gitRequirementsFiles(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{filepath.Join(".rqm", "requirements.yml")}; !reflect.DeepEqual(staged, want) {
+		t.Errorf("Staged files = %v, want %v", staged, want)
+	}
+
+	// Paths are relative to the current directory
+	t.Chdir(filepath.Join(dir, ".rqm"))
+	tracked, err := gitRequirementsFiles(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"requirements.yml"}; !reflect.DeepEqual(tracked, want) {
+		t.Errorf("Tracked files = %v, want %v", tracked, want)
+	}
+}
+
+func TestWithStagedFile(t *testing.T) {
+	dir := gitTestRepo(t)
+	os.MkdirAll(filepath.Join(dir, ".rqm"), 0755)
+	file := filepath.Join(".rqm", "requirements.yml")
+	staged := "version: \"1.0\"\nrequirements:\n  - summary: [\n"
+	os.WriteFile(file, []byte(staged), 0644)
+	mustGit(t, "add", file)
+	// Fixed in the working tree but not staged again
+	os.WriteFile(file, []byte("version: \"1.0\"\nrequirements:\n  - summary: A\n"), 0644)
+
+	files, err := gitRequirementsFiles(true)
+	if err != nil || len(files) != 1 || files[0] != file {
+		t.Fatalf("Expected %s staged, got %v, %v", file, files, err)
+	}
+
+	t.Chdir(filepath.Join(dir, ".rqm"))
+	err = withStagedFile("requirements.yml", func(path string) error {
+		if data, _ := os.ReadFile(path); string(data) != staged {
+			t.Errorf("Expected the staged content, got %q", data)
+		}
+		if filepath.Dir(path) != "." || fileLabel(path) != "requirements.yml" {
+			t.Errorf("Expected a copy next to the file shown as it, got %s shown as %s", path, fileLabel(path))
+		}
+		return fmt.Errorf("invalid %s", path)
+	})
+	if err == nil || err.Error() != "invalid requirements.yml" {
+		t.Errorf("Expected the error to name the file, got %v", err)
+	}
+	if entries, _ := os.ReadDir("."); len(entries) != 1 {
+		t.Errorf("Expected the copy removed, found %d file(s)", len(entries))
+	}
+}
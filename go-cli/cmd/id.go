@@ -0,0 +1,161 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var idDryRun bool
+
+// minUIDLength is the length of an assigned uid: a prefix of the content
+// hash, lengthened only when two requirements would share it
+const minUIDLength = 12
+
+var idCmd = &cobra.Command{
+	Use:   "id",
+	Short: "Manage the stable identities of requirements",
+}
+
+var idAssignCmd = &cobra.Command{
+	Use:   "assign [file]",
+	Short: "Give every requirement without one a stable uid",
+	Long: `Give every requirement without a uid one derived from its content: the
+first 12 hex digits of its rqm hash, more when two requirements would
+otherwise share one.
+
+A uid is assigned once and never changes, however the requirement's ID,
+summary or content is edited later, so rqm diff, rqm pr-summary and
+list --changed match the requirement across renames and report it as
+changed rather than removed and added. Requirements that already have a
+uid keep it, so running assign again only covers new requirements.
+
+The uid is written after the requirement's name, or its summary, with
+comments and key order preserved. TOML and JSON files can't be edited.`,
+	Example: `  rqm id assign
+  rqm id assign requirements.yml --dry-run`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist: %s", file)
+		}
+		assigned, err := assignUIDs(file, idDryRun)
+		if err != nil {
+			return err
+		}
+		if len(assigned) == 0 {
+			fmt.Println(okMark(), "Every requirement already has a uid")
+			return nil
+		}
+		for _, summary := range sortedKeys(assigned) {
+			fmt.Printf("  %s  %s\n", assigned[summary], summary)
+		}
+		if idDryRun {
+			fmt.Printf("\n%d uid(s) would be assigned (dry run, %s not changed)\n", len(assigned), file)
+		} else {
+			fmt.Printf("\n%s Assigned %d uid(s) in %s\n", okMark(), len(assigned), file)
+		}
+		return nil
+	},
+}
+
+// assignUIDs gives each requirement in file without a uid one derived from
+// its content hash and returns them by summary. With dryRun the file is
+// left untouched.
+func assignUIDs(file string, dryRun bool) (map[string]string, error) {
+	doc, err := loadYAMLDocument(file)
+	if err != nil {
+		return nil, err
+	}
+	config, err := model.Parse(file, doc.data)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := make(map[string]bool)
+	for _, req := range config.Flatten() {
+		if req.UID != "" {
+			taken[req.UID] = true
+		}
+	}
+	assigned := make(map[string]string)
+	for _, req := range config.Flatten() {
+		if req.UID != "" {
+			continue
+		}
+		uid := newUID(requirementHash(req), taken)
+		if uid == "" {
+			return nil, fmt.Errorf("can't assign a uid to '%s': its content hash is already in use", req.Summary)
+		}
+		taken[uid] = true
+		assigned[req.Summary] = uid
+	}
+	if len(assigned) == 0 || dryRun {
+		return assigned, nil
+	}
+
+	walkRequirementNodes(doc.root(), func(node *yaml.Node) {
+		uid, ok := assigned[mappingValue(node, "summary").Value]
+		if !ok || mappingValue(node, "uid") != nil {
+			return
+		}
+		after := "summary"
+		if mappingValue(node, "name") != nil {
+			after = "name"
+		}
+		insertAfterKey(node, after,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "uid"},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: uid})
+	})
+	return assigned, doc.save()
+}
+
+// newUID is the shortest prefix of hash, from minUIDLength digits, that
+// isn't taken, or empty when even the whole hash is
+func newUID(hash string, taken map[string]bool) string {
+	for n := minUIDLength; n <= len(hash); n++ {
+		if !taken[hash[:n]] {
+			return hash[:n]
+		}
+	}
+	return ""
+}
+
+// uidErrors reports uids shared by more than one requirement, which would
+// make diff match the wrong requirements
+func uidErrors(config *model.RequirementConfig) []string {
+	byUID := make(map[string][]string)
+	for _, req := range config.Flatten() {
+		if req.UID != "" {
+			byUID[req.UID] = append(byUID[req.UID], req.Summary)
+		}
+	}
+	var errs []string
+	for _, uid := range sortedKeys(byUID) {
+		if summaries := byUID[uid]; len(summaries) > 1 {
+			sort.Strings(summaries)
+			errs = append(errs, fmt.Sprintf("uid %s is used by more than one requirement: '%s'", uid, strings.Join(summaries, "', '")))
+		}
+	}
+	return errs
+}
+
+func init() {
+	rootCmd.AddCommand(idCmd)
+	idCmd.AddCommand(idAssignCmd)
+	idAssignCmd.Flags().BoolVar(&idDryRun, "dry-run", false, "Show the uids that would be assigned without changing the file")
+}
@@ -0,0 +1,92 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestAssignUIDs(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	original := `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: draft # not yet
+    requirements:
+      - summary: Login form
+        uid: 0123456789ab
+  - summary: Logout
+`
+	if err := os.WriteFile(file, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	assigned, err := assignUIDs(file, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assigned) != 2 || len(assigned["Login"]) != minUIDLength {
+		t.Errorf("dry run assigned %v", assigned)
+	}
+	if data, _ := os.ReadFile(file); string(data) != original {
+		t.Errorf("dry run changed the file:\n%s", data)
+	}
+
+	if assigned, err = assignUIDs(file, false); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(file)
+	if want := "    name: AUTH-001\n    uid: " + assigned["Login"] + "\n    status: draft # not yet\n"; !strings.Contains(string(data), want) {
+		t.Errorf("file missing %q:\n%s", want, data)
+	}
+	config, err := model.Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req, _ := config.Find("Logout"); req.UID != assigned["Logout"] {
+		t.Errorf("Logout uid = %q, want %q", req.UID, assigned["Logout"])
+	}
+	if req, _ := config.Find("Login form"); req.UID != "0123456789ab" {
+		t.Errorf("Login form's uid changed to %q", req.UID)
+	}
+
+	// Assigned uids are kept however the requirement changes later
+	if assigned, err = assignUIDs(file, false); err != nil || len(assigned) != 0 {
+		t.Errorf("assigning again = %v, %v", assigned, err)
+	}
+}
+
+func TestNewUID(t *testing.T) {
+	hash := "15c615d9ea3a7f0e"
+	if got := newUID(hash, map[string]bool{}); got != "15c615d9ea3a" {
+		t.Errorf("newUID = %s", got)
+	}
+	if got := newUID(hash, map[string]bool{"15c615d9ea3a": true}); got != "15c615d9ea3a7" {
+		t.Errorf("newUID with the prefix taken = %s", got)
+	}
+}
+
+func TestUIDErrors(t *testing.T) {
+	config, _ := model.ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: Login
+    uid: 15c615d9ea3a
+    requirements:
+      - summary: Login form
+        uid: 15c615d9ea3a
+  - summary: Logout
+    uid: 96b775ef9dc8
+`))
+	errs := uidErrors(config)
+	if len(errs) != 1 || errs[0] != "uid 15c615d9ea3a is used by more than one requirement: 'Login', 'Login form'" {
+		t.Errorf("uidErrors = %v", errs)
+	}
+}
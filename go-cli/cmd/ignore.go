@@ -0,0 +1,204 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// ignoreFileName holds gitignore-style patterns for paths the recursive
+// modes skip. One can sit in any directory and applies below it.
+const ignoreFileName = ".rqmignore"
+
+// requirementsFileContent spots a requirements file by its top-level
+// requirements key: requirements: in YAML, requirements = or
+// [[requirements]] in TOML and "requirements": in JSON
+var requirementsFileContent = regexp.MustCompile(`(?m)^(requirements:|requirements\s*=|\[\[requirements\]\])|"requirements"\s*:`)
+
+// ignoreRule is one line of a .rqmignore
+type ignoreRule struct {
+	// elems are the path elements of the pattern, relative to the
+	// directory of the .rqmignore; unanchored patterns start with **
+	elems   []string
+	negate  bool
+	dirOnly bool
+}
+
+// ignoreRules are the rules of one .rqmignore, with its directory
+type ignoreRules struct {
+	dir   string
+	rules []ignoreRule
+}
+
+// parseIgnoreRules reads the patterns of a .rqmignore in dir. As in
+// .gitignore, # starts a comment, ! re-includes what an earlier pattern
+// excluded, a trailing / only matches directories, and a pattern with a /
+// elsewhere is relative to dir while one without matches at any depth.
+func parseIgnoreRules(dir string, data []byte) ignoreRules {
+	rules := ignoreRules{dir: dir}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var rule ignoreRule
+		if rule.negate = strings.HasPrefix(line, "!"); rule.negate {
+			line = line[1:]
+		}
+		line = strings.TrimPrefix(line, `\`)
+		if rule.dirOnly = strings.HasSuffix(line, "/"); rule.dirOnly {
+			line = strings.TrimSuffix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		anchored := strings.Contains(line, "/")
+		rule.elems = strings.Split(strings.TrimPrefix(line, "/"), "/")
+		if !anchored {
+			rule.elems = append([]string{"**"}, rule.elems...)
+		}
+		rules.rules = append(rules.rules, rule)
+	}
+	return rules
+}
+
+// match reports whether the rules decide about path, and whether they
+// ignore it; the last matching rule wins
+func (r ignoreRules) match(path string, isDir bool) (matched, ignored bool) {
+	rel, err := filepath.Rel(r.dir, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false, false
+	}
+	elems := strings.Split(filepath.ToSlash(rel), "/")
+	for _, rule := range r.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if matchGlob(rule.elems, elems) {
+			matched, ignored = true, !rule.negate
+		}
+	}
+	return matched, ignored
+}
+
+// isValidationCacheDir reports whether dir is the .rqm/cache directory of
+// openValidationCache
+func isValidationCacheDir(dir string) bool {
+	return filepath.Base(dir) == "cache" && filepath.Base(filepath.Dir(dir)) == ".rqm"
+}
+
+// findRequirementsFiles walks root for requirements files: YAML, TOML
+// and JSON files with a top-level requirements key. Paths matched by a .rqmignore are
+// skipped, as are .git and the validation cache.
+func findRequirementsFiles(root string) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("directory does not exist: %s", root)
+		}
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", root)
+	}
+
+	// ignores holds the rules of the directories on the current path,
+	// outermost first
+	var ignores []ignoreRules
+	ignored := func(path string, isDir bool) bool {
+		skip := false
+		for _, rules := range ignores {
+			if matched, ignored := rules.match(path, isDir); matched {
+				skip = ignored
+			}
+		}
+		return skip
+	}
+
+	var files []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			for len(ignores) > 0 && !isWithin(path, ignores[len(ignores)-1].dir) {
+				ignores = ignores[:len(ignores)-1]
+			}
+			// .rqm/cache holds validator output, which looks like JSON
+			// requirements files
+			if d.Name() == ".git" || isValidationCacheDir(path) || path != root && ignored(path, true) {
+				return filepath.SkipDir
+			}
+			if data, err := os.ReadFile(filepath.Join(path, ignoreFileName)); err == nil {
+				ignores = append(ignores, parseIgnoreRules(path, data))
+			}
+			return nil
+		}
+
+		if !model.IsRequirementsExt(filepath.Ext(path)) || ignored(path, false) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			// A dangling symlink isn't a file to search
+			if d.Type()&fs.ModeSymlink != 0 && os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if requirementsFileContent.Match(data) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s: %w", root, err)
+	}
+	return files, nil
+}
+
+// isWithin reports whether path is dir or below it
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// recursiveFileArgs expands the directories among args to the
+// requirements files below them, and the other arguments as
+// expandFileArgs does
+func recursiveFileArgs(args []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var files []string
+	for _, arg := range args {
+		var found []string
+		if info, err := os.Stat(arg); err == nil && info.IsDir() {
+			if found, err = findRequirementsFiles(arg); err != nil {
+				return nil, err
+			}
+			if len(found) == 0 {
+				return nil, fmt.Errorf("no requirements files found under %s", arg)
+			}
+		} else if found, err = expandFileArgs([]string{arg}); err != nil {
+			return nil, err
+		}
+		for _, file := range found {
+			if !seen[filepath.Clean(file)] {
+				seen[filepath.Clean(file)] = true
+				files = append(files, file)
+			}
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,95 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIgnoreRulesMatch(t *testing.T) {
+	rules := parseIgnoreRules("root", []byte(`# generated content
+vendor
+/build/
+*.gen.yml
+!keep.gen.yml
+docs/drafts
+`))
+
+	tests := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"root/vendor", true, true},
+		{"root/third_party/vendor", true, true},
+		{"root/build", true, true},
+		{"root/build", false, false},
+		{"root/src/build", true, false},
+		{"root/api.gen.yml", false, true},
+		{"root/docs/keep.gen.yml", false, false},
+		{"root/docs/drafts", true, true},
+		{"root/other/docs/drafts", true, false},
+		{"root/requirements.yml", false, false},
+	}
+	for _, tt := range tests {
+		_, ignored := rules.match(filepath.FromSlash(tt.path), tt.isDir)
+		if ignored != tt.ignored {
+			t.Errorf("match(%q) ignored = %v, want %v", tt.path, ignored, tt.ignored)
+		}
+	}
+}
+
+func TestFindRequirementsFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	requirements := "version: \"1.0\"\nrequirements:\n  - summary: Example\n"
+	for name, content := range map[string]string{
+		"requirements.yml":                 requirements,
+		"docs/api.rqm.yaml":                requirements,
+		"docs/.rqmignore":                  "drafts/\n",
+		"docs/drafts/idea.yml":             requirements,
+		"docs/settings.yml":                "theme: dark\n",
+		"vendor/lib/requirements.yml":      requirements,
+		"services/auth/requirements.yml":   requirements,
+		"services/auth/generated/out.yml":  requirements,
+		"services/.rqmignore":              "generated\n",
+		".rqmignore":                       "vendor/\n",
+		"notes/requirements.md":            requirements,
+		".git/requirements.yml":            requirements,
+		"services/auth/generated/keep.yml": requirements,
+		"api/requirements.toml":            "version = \"1.0\"\n\n[[requirements]]\nsummary = \"Example\"\n",
+		"api/requirements.json":            `{"version": "1.0", "requirements": [{"summary": "Example"}]}`,
+		"web/package.json":                 `{"name": "web"}`,
+		".rqm/cache/0a1b.json":             `{"version": "1.0", "requirements": []}`,
+	} {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := findRequirementsFiles(tmpDir)
+	if err != nil {
+		t.Fatalf("findRequirementsFiles: %v", err)
+	}
+	var got []string
+	for _, file := range files {
+		rel, _ := filepath.Rel(tmpDir, file)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	want := []string{"api/requirements.json", "api/requirements.toml", "docs/api.rqm.yaml", "requirements.yml", "services/auth/requirements.yml"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("findRequirementsFiles = %v, want %v", got, want)
+	}
+
+	if _, err := recursiveFileArgs([]string{filepath.Join(tmpDir, "notes")}); err == nil || !strings.Contains(err.Error(), "no requirements files found") {
+		t.Errorf("Expected an error for a directory without requirements files, got %v", err)
+	}
+}
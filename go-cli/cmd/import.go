@@ -0,0 +1,264 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/render"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	importFrom   string
+	importOutput string
+	importVerify bool
+	importDryRun bool
+	// importMap is --map, the columns of a spreadsheet to import
+	importMap string
+)
+
+// importer converts an external export into a requirements config and can
+// render it back into the source format for round-trip verification
+type importer struct {
+	parse func(r io.Reader) (*model.RequirementConfig, error)
+	// export renders a config in the source format
+	export func(config *model.RequirementConfig) ([]byte, error)
+	// compare lists what differs between the original input and a re-export
+	compare func(original, exported []byte) ([]string, error)
+	// preview, when set, describes how the input is read for --dry-run
+	preview func(original []byte) ([]string, error)
+}
+
+// importers maps --from values to their implementation
+var importers = map[string]importer{
+	"csv":       tableImporter(readCSVTable),
+	"doors-csv": {parse: importDOORSCSV, export: exportDOORSCSV, compare: compareDOORSCSV},
+	"jira-xml":  {parse: importJiraXML, export: exportJiraXML, compare: compareJiraXML},
+	"markdown":  {parse: importMarkdown, export: exportMarkdown, compare: compareMarkdown},
+	"xlsx":      tableImporter(readXLSXTable),
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import [file...]",
+	Short: "Import requirements from another tool's export",
+	Long: `Import requirements from an external export and convert them into
+an RQM requirements YAML file.
+
+Supported sources:
+  - csv         Spreadsheet saved as CSV, a requirement per row
+  - doors-csv   DOORS classic module export (CSV with Object Heading/Object Text)
+  - jira-xml    Jira XML export of an issue search, a requirement per issue
+  - markdown    Markdown documents, a requirement per heading
+  - xlsx        Excel workbook, a requirement per row of its first sheet
+
+Several files, or glob patterns, are imported into one requirements file,
+each file's requirements after the previous file's.
+
+A Markdown document's headings become the requirements tree: each heading
+is a requirement nested under the closest heading above it of a higher
+level, with the text below it as its description. A trailing {#ID}
+attribute, as in "## Login {#AUTH-1}", becomes the requirement's ID, and
+sub-headings named "Acceptance criteria" or "Rationale" fill in the
+acceptance_test or justification of the requirement above them. This is
+not the layout of rqm export --format markdown, whose title, metadata
+tables and field labels would be imported as requirements and text. YAML
+front matter sets owner, priority, status, tags and verification for every
+requirement of the document:
+
+  ---
+  owner: "@alice"
+  status: draft
+  tags: [auth]
+  ---
+
+Requirements imported from DOORS or Jira keep their Object Identifier or
+issue key as their ID, and record it in external_refs so they can be
+traced back to the legacy tool. Jira sub-tasks are nested under their
+parent and stories under their epic, when it is part of the export, and
+Blocks, Cloners, Depends, Duplicate and Relates links become relations.
+
+For csv and xlsx, --map names the column of each field as Column=field
+pairs:
+
+  --map 'Title=summary,ID=name,Owner=owner,Parent=parent,Team=attributes.team'
+
+The fields are summary, name, description, justification,
+acceptance_test, owner, priority, status, verification, tags (split at
+commas and semicolons; several columns add up) and parent, plus
+attributes.<key> for a custom attribute. Column names are matched
+ignoring case. A row is nested under the row whose ID, or else summary,
+its parent column holds, wherever that row is in the sheet. Without
+--map, columns named after a field are imported, with ID for name and
+Title for summary.
+
+The result is written to stdout unless --output is given. --dry-run
+writes nothing and previews the import instead: how each column is
+mapped, for a spreadsheet, and the requirements tree.
+
+With --verify nothing is written; instead the imported requirements are
+exported back to the source format and compared with the input, reporting
+every field or column that would be lost in the migration.`,
+	Example: `  rqm import --from doors-csv module.csv
+  rqm import --from doors-csv module.csv -o .rqm/requirements.yml
+  rqm import --from doors-csv module.csv --verify
+  rqm import --from jira-xml SearchRequest.xml -o .rqm/requirements.yml
+  rqm import --from markdown specs/*.md -o .rqm/requirements.yml
+  rqm import --from markdown specs/login.md --verify
+  rqm import --from csv reqs.csv --map 'Title=summary,ID=name,Owner=owner,Parent=parent' --dry-run
+  rqm import --from xlsx reqs.xlsx -o .rqm/requirements.yml`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(args)
+	},
+}
+
+func runImport(args []string) error {
+	imp, ok := importers[importFrom]
+	if !ok {
+		return fmt.Errorf("unknown import source: %q (supported: %s)", importFrom, strings.Join(importSources(), ", "))
+	}
+	files, err := expandFileArgs(args)
+	if err != nil {
+		return err
+	}
+
+	config := &model.RequirementConfig{Version: "1.0"}
+	differences := 0
+	for _, file := range files {
+		original, err := os.ReadFile(file)
+		if os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist: %s", file)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+
+		imported, err := imp.parse(bytes.NewReader(original))
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", file, err)
+		}
+
+		if importDryRun && imp.preview != nil {
+			lines, err := imp.preview(original)
+			if err != nil {
+				return fmt.Errorf("failed to import %s: %w", file, err)
+			}
+			fmt.Printf("%s:\n%s\n\n", file, strings.Join(lines, "\n"))
+		}
+		if importVerify {
+			found, err := verifyImport(imp, file, original, imported)
+			if err != nil {
+				return err
+			}
+			differences += found
+			continue
+		}
+		config.Requirements = append(config.Requirements, imported.Requirements...)
+	}
+
+	if importVerify {
+		if differences > 0 {
+			return validationError("round-trip verification found %d difference(s)", differences)
+		}
+		return nil
+	}
+	// Summaries must be unique across the files too
+	uniquifySummaries(config)
+	if importDryRun {
+		previewImport(config)
+		return nil
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode requirements: %w", err)
+	}
+
+	if importOutput == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(importOutput, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", importOutput, err)
+	}
+
+	fmt.Printf("%s Imported %d requirement(s) from %s into %s\n", okMark(), len(config.Flatten()), strings.Join(files, ", "), importOutput)
+	return nil
+}
+
+// verifyImport re-exports config and reports what the import would lose,
+// returning the number of differences found
+func verifyImport(imp importer, file string, original []byte, config *model.RequirementConfig) (int, error) {
+	exported, err := imp.export(config)
+	if err != nil {
+		return 0, fmt.Errorf("failed to re-export %s: %w", file, err)
+	}
+
+	findings, err := imp.compare(original, exported)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compare round trip: %w", err)
+	}
+
+	fmt.Printf("Round-trip verification of %s (%s)...\n", file, importFrom)
+	fmt.Printf("  %d requirement(s) imported\n\n", len(config.Flatten()))
+
+	if len(findings) == 0 {
+		fmt.Println(okMark(), "No data lost: re-export matches the input")
+		return 0, nil
+	}
+
+	fmt.Println(failMark(), "Data that would not survive the import:")
+	for _, finding := range findings {
+		fmt.Printf("  - %s\n", finding)
+	}
+
+	return len(findings), nil
+}
+
+// previewImport prints the requirements tree config would be written as
+func previewImport(config *model.RequirementConfig) {
+	fmt.Println("Requirements:")
+	line := func(req *model.RequirementDetail, pos render.Position) error {
+		fmt.Printf("  %s%s\n", strings.Repeat("  ", pos.Depth), requirementLabel(req))
+		return nil
+	}
+	render.Walk(config.Roots(), (*model.RequirementDetail).Children, render.VisitorFunc[*model.RequirementDetail](line))
+	fmt.Printf("\n%d requirement(s) would be imported (dry run, nothing written)\n", len(config.Flatten()))
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// importSources returns the supported --from values in sorted order
+func importSources() []string {
+	return sortedKeys(importers)
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().StringVar(&importFrom, "from", "", "Source format ("+strings.Join(importSources(), ", ")+")")
+	importCmd.Flags().StringVarP(&importOutput, "output", "o", "", "Write the imported YAML to this file instead of stdout")
+	importCmd.Flags().BoolVar(&importVerify, "verify", false, "Re-export to the source format and report anything the import would lose")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Preview the column mapping and requirements tree without writing anything")
+	importCmd.Flags().StringVar(&importMap, "map", "", "Columns of a csv or xlsx import, as Column=field pairs such as 'Title=summary,ID=name,Parent=parent'")
+	importCmd.MarkFlagRequired("from")
+	importCmd.MarkFlagsMutuallyExclusive("dry-run", "verify")
+}
@@ -0,0 +1,356 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/render"
+)
+
+// maxSummaryLength mirrors the maxLength of summary in schema.json
+const maxSummaryLength = 200
+
+// doorsColumns lists the accepted header spellings for each DOORS attribute
+var doorsColumns = map[string][]string{
+	"heading":  {"object heading", "heading"},
+	"text":     {"object text", "text"},
+	"level":    {"object level", "level"},
+	"number":   {"object number", "section number"},
+	"id":       {"absolute number", "object identifier", "id", "identifier"},
+	"priority": {"priority"},
+	"status":   {"status"},
+	"owner":    {"owner"},
+}
+
+// headingNumberPattern matches a leading section number such as "1.2.3 " in a heading
+var headingNumberPattern = regexp.MustCompile(`^(\d+(?:\.\d+)*)\.?\s+`)
+
+// importNode holds an imported object while the hierarchy is being rebuilt
+type importNode struct {
+	level    int
+	req      *model.RequirementDetail
+	children []*importNode
+}
+
+// doorsRow is one non-empty object of a DOORS export with its resolved level
+type doorsRow struct {
+	level    int
+	id       string
+	heading  string
+	text     string
+	priority string
+	status   string
+	owner    string
+	extra    map[string]string
+}
+
+// importDOORSCSV converts a DOORS classic module CSV export into a requirements
+// config. Hierarchy comes from the Object Level column when present, otherwise
+// from the Object Number or the section number prefixed to the heading.
+func importDOORSCSV(r io.Reader) (*model.RequirementConfig, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := readDOORSRows(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var roots []*importNode
+	var stack []*importNode
+	for _, row := range rows {
+		node := &importNode{level: row.level, req: doorsRequirement(row.heading, row.text)}
+		node.req.Name = row.id
+		if row.id != "" {
+			node.req.ExternalRefs = []model.ExternalRef{{System: "doors", ID: row.id}}
+		}
+		node.req.Owner = row.owner
+		node.req.Priority = normalizeEnum(row.priority, "critical", "high", "medium", "low")
+		node.req.Status = normalizeEnum(row.status, "draft", "proposed", "approved", "implemented", "verified", "deprecated")
+
+		for len(stack) > 0 && stack[len(stack)-1].level >= row.level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, node)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, node)
+		}
+		stack = append(stack, node)
+	}
+
+	config := &model.RequirementConfig{Version: "1.0"}
+	for _, root := range roots {
+		config.Requirements = append(config.Requirements, *root.build())
+	}
+	uniquifySummaries(config)
+
+	return config, nil
+}
+
+// readDOORSRows parses a DOORS CSV export into objects with resolved levels.
+// Columns RQM does not understand are kept in each row's extra map.
+func readDOORSRows(data []byte) ([]doorsRow, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = sniffDelimiter(data)
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty CSV export")
+	}
+
+	header := records[0]
+	columns := mapDOORSColumns(header)
+	if _, ok := columns["heading"]; !ok {
+		if _, ok := columns["text"]; !ok {
+			return nil, fmt.Errorf("no Object Heading or Object Text column found")
+		}
+	}
+	known := make(map[int]bool)
+	for _, idx := range columns {
+		known[idx] = true
+	}
+
+	var rows []doorsRow
+	headingLevel := 0
+
+	for _, record := range records[1:] {
+		field := func(key string) string {
+			idx, ok := columns[key]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		heading := field("heading")
+		text := field("text")
+		if heading == "" && text == "" {
+			continue
+		}
+
+		number := field("number")
+		if m := headingNumberPattern.FindStringSubmatch(heading); m != nil {
+			if number == "" {
+				number = m[1]
+			}
+			heading = strings.TrimSpace(heading[len(m[0]):])
+		}
+
+		level, _ := strconv.Atoi(field("level"))
+		if level <= 0 && number != "" {
+			level = strings.Count(strings.TrimSuffix(number, "."), ".") + 1
+		}
+		if level <= 0 {
+			// Without any level information, headings sit at the top and
+			// text objects belong to the most recent heading
+			if heading != "" {
+				level = 1
+			} else {
+				level = headingLevel + 1
+			}
+		}
+		if heading != "" {
+			headingLevel = level
+		}
+
+		row := doorsRow{
+			level:    level,
+			id:       field("id"),
+			heading:  heading,
+			text:     text,
+			priority: field("priority"),
+			status:   field("status"),
+			owner:    field("owner"),
+			extra:    make(map[string]string),
+		}
+		for i, value := range record {
+			if !known[i] && i < len(header) && strings.TrimSpace(value) != "" {
+				row.extra[strings.TrimSpace(header[i])] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// exportDOORSCSV renders a config back into a DOORS CSV export
+func exportDOORSCSV(config *model.RequirementConfig) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"Absolute Number", "Object Level", "Object Heading", "Object Text", "Priority", "Status", "Owner"})
+
+	row := func(req *model.RequirementDetail, pos render.Position) error {
+		heading, text := req.Summary, req.Description
+		// Text objects were imported with their first line as summary
+		if text != "" && strings.HasPrefix(text, strings.TrimSuffix(heading, "...")) {
+			heading = ""
+		}
+		return w.Write([]string{req.Name, strconv.Itoa(pos.Depth + 1), heading, text, req.Priority, req.Status, req.Owner})
+	}
+	render.Walk(config.Roots(), (*model.RequirementDetail).Children, render.VisitorFunc[*model.RequirementDetail](row))
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// compareDOORSCSV reports what an import of original would not carry over
+func compareDOORSCSV(original, exported []byte) ([]string, error) {
+	before, err := readDOORSRows(original)
+	if err != nil {
+		return nil, err
+	}
+	after, err := readDOORSRows(exported)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []string
+	if len(before) != len(after) {
+		findings = append(findings, fmt.Sprintf("%d object(s) in the export but %d after import", len(before), len(after)))
+	}
+
+	dropped := make(map[string]int)
+	for i := 0; i < len(before) && i < len(after); i++ {
+		b, a := before[i], after[i]
+		label := fmt.Sprintf("object %d", i+1)
+		if b.id != "" {
+			label = fmt.Sprintf("object %s", b.id)
+		}
+
+		if doorsContent(b) != doorsContent(a) {
+			findings = append(findings, fmt.Sprintf("%s: text changed from %q to %q", label, doorsContent(b), doorsContent(a)))
+		}
+		if b.level != a.level {
+			findings = append(findings, fmt.Sprintf("%s: level %d becomes %d", label, b.level, a.level))
+		}
+		if b.priority != "" && !strings.EqualFold(b.priority, a.priority) {
+			findings = append(findings, fmt.Sprintf("%s: priority %q is not recognized and would be lost", label, b.priority))
+		}
+		if b.status != "" && !strings.EqualFold(b.status, a.status) {
+			findings = append(findings, fmt.Sprintf("%s: status %q is not recognized and would be lost", label, b.status))
+		}
+		for column := range b.extra {
+			dropped[column]++
+		}
+	}
+
+	for _, column := range sortedKeys(dropped) {
+		findings = append(findings, fmt.Sprintf("column %q is not imported (%d value(s) would be lost)", column, dropped[column]))
+	}
+
+	return findings, nil
+}
+
+// doorsContent joins heading and text the way they appear in the document
+func doorsContent(row doorsRow) string {
+	return strings.TrimSpace(row.heading + "\n" + row.text)
+}
+
+// build converts the node and its descendants into a requirement
+func (n *importNode) build() *model.RequirementDetail {
+	for _, child := range n.children {
+		n.req.Requirements = append(n.req.Requirements, model.RequirementReference{Full: child.build()})
+	}
+	return n.req
+}
+
+// doorsRequirement derives summary and description from a DOORS object
+func doorsRequirement(heading, text string) *model.RequirementDetail {
+	if heading != "" {
+		return &model.RequirementDetail{Summary: truncateSummary(heading), Description: text}
+	}
+
+	firstLine := strings.TrimSpace(strings.SplitN(text, "\n", 2)[0])
+	req := &model.RequirementDetail{Summary: truncateSummary(firstLine)}
+	if req.Summary != text {
+		req.Description = text
+	}
+	return req
+}
+
+// truncateSummary shortens s to maxSummaryLength characters, counted as
+// schema.json counts them, in runes, so a multi-byte character isn't cut
+func truncateSummary(s string) string {
+	runes := []rune(s)
+	if len(runes) <= maxSummaryLength {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:maxSummaryLength-3])) + "..."
+}
+
+// mapDOORSColumns resolves header names to column indexes
+func mapDOORSColumns(header []string) map[string]int {
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for key, aliases := range doorsColumns {
+			if _, seen := columns[key]; seen {
+				continue
+			}
+			for _, alias := range aliases {
+				if name == alias {
+					columns[key] = i
+				}
+			}
+		}
+	}
+	return columns
+}
+
+// sniffDelimiter picks the most frequent delimiter on the header line
+func sniffDelimiter(data []byte) rune {
+	line, _ := bufio.NewReader(bytes.NewReader(data)).ReadString('\n')
+	best, bestCount := ',', strings.Count(line, ",")
+	for _, d := range []rune{';', '\t'} {
+		if c := strings.Count(line, string(d)); c > bestCount {
+			best, bestCount = d, c
+		}
+	}
+	return best
+}
+
+// normalizeEnum lowercases value and returns it if allowed, otherwise ""
+func normalizeEnum(value string, allowed ...string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, a := range allowed {
+		if value == a {
+			return value
+		}
+	}
+	return ""
+}
+
+// uniquifySummaries appends a counter to repeated summaries, since the
+// schema requires every summary in a file to be unique
+func uniquifySummaries(config *model.RequirementConfig) {
+	seen := make(map[string]int)
+	for _, req := range config.Flatten() {
+		seen[req.Summary]++
+		if n := seen[req.Summary]; n > 1 {
+			req.Summary = fmt.Sprintf("%s (%d)", req.Summary, n)
+		}
+	}
+}
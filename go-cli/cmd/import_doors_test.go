@@ -0,0 +1,232 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"go.yaml.in/yaml/v3"
+)
+
+func TestImportDOORSCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantErr   bool
+		checkTree func(t *testing.T, config *model.RequirementConfig)
+	}{
+		{
+			name: "hierarchy from object level",
+			input: "Absolute Number,Object Level,Object Heading,Object Text\n" +
+				"1,1,Authentication,\n" +
+				"2,2,,The system shall support password login\n" +
+				"3,2,,The system shall lock accounts after 5 failures\n" +
+				"4,1,Reporting,\n",
+			checkTree: func(t *testing.T, config *model.RequirementConfig) {
+				if len(config.Requirements) != 2 {
+					t.Fatalf("Expected 2 top-level requirements, got %d", len(config.Requirements))
+				}
+				auth := config.Requirements[0]
+				if auth.Summary != "Authentication" || auth.Name != "1" {
+					t.Errorf("Unexpected first requirement: %+v", auth)
+				}
+				if len(auth.ExternalRefs) != 1 || auth.ExternalRefs[0].System != "doors" || auth.ExternalRefs[0].ID != "1" {
+					t.Errorf("Expected the object identifier in external_refs, got %+v", auth.ExternalRefs)
+				}
+				if len(auth.Requirements) != 2 {
+					t.Fatalf("Expected 2 children, got %d", len(auth.Requirements))
+				}
+				if auth.Requirements[0].Full.Summary != "The system shall support password login" {
+					t.Errorf("Unexpected child summary: %s", auth.Requirements[0].Full.Summary)
+				}
+			},
+		},
+		{
+			name: "hierarchy from heading numbers",
+			input: "\xef\xbb\xbfObject Heading;Object Text\n" +
+				"1 Scope;\n" +
+				"1.1 Interfaces;Interfaces to external systems\n" +
+				"1.1.1 REST API;\n" +
+				"2 Safety;\n",
+			checkTree: func(t *testing.T, config *model.RequirementConfig) {
+				if len(config.Requirements) != 2 {
+					t.Fatalf("Expected 2 top-level requirements, got %d", len(config.Requirements))
+				}
+				iface := config.Requirements[0].Requirements[0].Full
+				if iface.Summary != "Interfaces" || iface.Description != "Interfaces to external systems" {
+					t.Errorf("Unexpected nested requirement: %+v", iface)
+				}
+				if len(iface.Requirements) != 1 || iface.Requirements[0].Full.Summary != "REST API" {
+					t.Errorf("Expected REST API under Interfaces, got %+v", iface.Requirements)
+				}
+			},
+		},
+		{
+			name: "text objects attach to last heading without levels",
+			input: "Object Heading,Object Text,Priority,Status\n" +
+				"Login,,High,Approved\n" +
+				",Passwords are hashed,,\n" +
+				",Passwords are hashed,,\n",
+			checkTree: func(t *testing.T, config *model.RequirementConfig) {
+				login := config.Requirements[0]
+				if login.Priority != "high" || login.Status != "approved" {
+					t.Errorf("Expected normalized priority/status, got %q/%q", login.Priority, login.Status)
+				}
+				if len(login.Requirements) != 2 {
+					t.Fatalf("Expected 2 children, got %d", len(login.Requirements))
+				}
+				if login.Requirements[1].Full.Summary != "Passwords are hashed (2)" {
+					t.Errorf("Expected duplicate summary to be made unique, got %q", login.Requirements[1].Full.Summary)
+				}
+			},
+		},
+		{
+			name:    "missing required columns",
+			input:   "Foo,Bar\n1,2\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := importDOORSCSV(strings.NewReader(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			tt.checkTree(t, config)
+		})
+	}
+}
+
+func TestTruncateSummary(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Login", "Login"},
+		{strings.Repeat("é", maxSummaryLength), strings.Repeat("é", maxSummaryLength)},
+		{strings.Repeat("é", maxSummaryLength+1), strings.Repeat("é", maxSummaryLength-3) + "..."},
+		{strings.Repeat("日本", maxSummaryLength), strings.Repeat("日本", (maxSummaryLength-3)/2) + "日..."},
+	}
+	for _, tt := range tests {
+		got := truncateSummary(tt.input)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("truncateSummary(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRunImportWritesYAML(t *testing.T) {
+	tmpDir := t.TempDir()
+	input := filepath.Join(tmpDir, "module.csv")
+	output := filepath.Join(tmpDir, "requirements.yml")
+	content := "Object Level,Object Heading,Object Text\n1,Parent,\n2,Child,Child text\n"
+	if err := os.WriteFile(input, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	importFrom, importOutput = "doors-csv", output
+	defer func() { importFrom, importOutput = "", "" }()
+
+	if err := runImport([]string{input}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	var config model.RequirementConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("Output is not valid YAML: %v", err)
+	}
+	if config.Version != "1.0" || len(config.Requirements) != 1 {
+		t.Fatalf("Unexpected config: %+v", config)
+	}
+	if child := config.Requirements[0].Requirements[0].Full; child == nil || child.Summary != "Child" {
+		t.Errorf("Expected nested child in output, got: %s", string(data))
+	}
+}
+
+func TestRunImportUnknownSource(t *testing.T) {
+	importFrom = "nope"
+	defer func() { importFrom = "" }()
+
+	err := runImport([]string{"whatever.csv"})
+	if err == nil || !strings.Contains(err.Error(), "unknown import source") {
+		t.Errorf("Expected unknown import source error, got: %v", err)
+	}
+}
+
+func TestDOORSRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantFindings []string
+	}{
+		{
+			name: "lossless export",
+			input: "Absolute Number,Object Level,Object Heading,Object Text,Status\n" +
+				"1,1,Authentication,Login and sessions,approved\n" +
+				"2,2,,Passwords are hashed,\n",
+		},
+		{
+			name: "unknown columns and values are reported",
+			input: "Absolute Number,Object Level,Object Heading,Object Text,Status,Created By\n" +
+				"1,1,Authentication,,In Review,jdoe\n" +
+				"2,3,,Passwords are hashed,,jdoe\n",
+			wantFindings: []string{
+				`status "In Review" is not recognized`,
+				"level 3 becomes 2",
+				`column "Created By" is not imported (2 value(s)`,
+			},
+		},
+		{
+			name: "renamed duplicates are reported",
+			input: "Object Heading,Object Text\n" +
+				"Login,\n" +
+				"Login,\n",
+			wantFindings: []string{`text changed from "Login" to "Login (2)"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := importDOORSCSV(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Unexpected import error: %v", err)
+			}
+			exported, err := exportDOORSCSV(config)
+			if err != nil {
+				t.Fatalf("Unexpected export error: %v", err)
+			}
+			findings, err := compareDOORSCSV([]byte(tt.input), exported)
+			if err != nil {
+				t.Fatalf("Unexpected compare error: %v", err)
+			}
+
+			if len(findings) != len(tt.wantFindings) {
+				t.Fatalf("Expected %d finding(s), got %d: %v", len(tt.wantFindings), len(findings), findings)
+			}
+			joined := strings.Join(findings, "\n")
+			for _, want := range tt.wantFindings {
+				if !strings.Contains(joined, want) {
+					t.Errorf("Expected finding containing %q, got: %v", want, findings)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/render"
+)
+
+// jiraRSS is a Jira XML export: the issue navigator's "Export XML", an
+// RSS feed with an item per issue
+type jiraRSS struct {
+	XMLName xml.Name   `xml:"rss"`
+	Items   []jiraItem `xml:"channel>item"`
+}
+
+// jiraItem is an issue of a Jira XML export
+type jiraItem struct {
+	Link        string `xml:"link"`
+	Key         string `xml:"key"`
+	Summary     string `xml:"summary"`
+	Description string `xml:"description"`
+	Type        string `xml:"type"`
+	Priority    string `xml:"priority"`
+	Status      string `xml:"status"`
+	// StatusCategory is new, indeterminate or done, whatever the
+	// workflow calls the status
+	StatusCategory struct {
+		Key string `xml:"key,attr"`
+	} `xml:"statusCategory"`
+	Assignee struct {
+		Username string `xml:"username,attr"`
+		Name     string `xml:",chardata"`
+	} `xml:"assignee"`
+	Labels       []string          `xml:"labels>label"`
+	Parent       string            `xml:"parent"`
+	IssueLinks   []jiraLinkType    `xml:"issuelinks>issuelinktype"`
+	CustomFields []jiraCustomField `xml:"customfields>customfield"`
+	Comments     []string          `xml:"comments>comment"`
+	Attachments  []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"attachments>attachment"`
+}
+
+// jiraLinkType is the links of an issue of one type, such as Blocks
+type jiraLinkType struct {
+	Name    string   `xml:"name"`
+	Outward []string `xml:"outwardlinks>issuelink>issuekey"`
+	Inward  []string `xml:"inwardlinks>issuelink>issuekey"`
+}
+
+// jiraCustomField is a custom field of an issue
+type jiraCustomField struct {
+	Key    string   `xml:"key,attr"`
+	Name   string   `xml:"customfieldname"`
+	Values []string `xml:"customfieldvalues>customfieldvalue"`
+}
+
+// jiraParentFields are the custom fields holding an issue's parent besides
+// <parent>, which only sub-tasks have: the epic of a story, and the
+// parent of an epic in Advanced Roadmaps
+var jiraParentFields = []string{"com.pyxis.greenhopper.jira:gh-epic-link", "com.atlassian.jpo:jpo-custom-field-parent"}
+
+// jiraRelations maps the outward direction of Jira's link types to
+// relations. Inward links are the same links seen from the other issue,
+// which has them as outward links.
+var jiraRelations = map[string]string{
+	"blocks":     "blocks",
+	"cloners":    "derives_from",
+	"dependency": "depends_on",
+	"depends":    "depends_on",
+	"duplicate":  "duplicates",
+	"relates":    "relates_to",
+}
+
+// jiraPriorities maps Jira's priority schemes onto the schema's
+var jiraPriorities = map[string]string{
+	"highest": "critical", "blocker": "critical", "critical": "critical",
+	"high": "high", "major": "high",
+	"medium": "medium",
+	"low":    "low", "lowest": "low", "minor": "low", "trivial": "low",
+}
+
+// jiraStatusCategories maps status categories, which every workflow's
+// statuses belong to, onto statuses
+var jiraStatusCategories = map[string]string{
+	"new":           "proposed",
+	"indeterminate": "approved",
+	"done":          "implemented",
+}
+
+// jiraStatuses maps the statuses of Jira's default workflows, for exports
+// without status categories
+var jiraStatuses = map[string]string{
+	"backlog": "proposed", "open": "proposed", "to do": "proposed", "new": "proposed", "reopened": "proposed",
+	"selected for development": "approved", "in progress": "approved", "in review": "approved",
+	"done": "implemented", "resolved": "implemented", "closed": "implemented",
+}
+
+// readJiraXML parses a Jira XML export
+func readJiraXML(data []byte) ([]jiraItem, error) {
+	var rss jiraRSS
+	if err := xml.Unmarshal(data, &rss); err != nil {
+		return nil, fmt.Errorf("invalid Jira XML export: %w", err)
+	}
+	if len(rss.Items) == 0 {
+		return nil, fmt.Errorf("no issues found in the Jira XML export")
+	}
+	return rss.Items, nil
+}
+
+// importJiraXML converts a Jira XML export into a requirements config.
+// Sub-tasks are nested under their parent and stories under their epic,
+// when it is in the export; issue links become relations, and every
+// requirement keeps its issue key as its ID and in external_refs.
+func importJiraXML(r io.Reader) (*model.RequirementConfig, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	items, err := readJiraXML(data)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*importNode, len(items))
+	for _, item := range items {
+		key := strings.TrimSpace(item.Key)
+		if key == "" {
+			return nil, fmt.Errorf("issue %q has no key", item.Summary)
+		}
+		req := doorsRequirement(strings.TrimSpace(item.Summary), jiraText(item.Description))
+		req.Name = key
+		req.Priority = jiraPriorities[strings.ToLower(strings.TrimSpace(item.Priority))]
+		req.Status = jiraStatus(item)
+		req.Owner = jiraOwner(item)
+		req.Tags = item.Labels
+		req.ExternalRefs = []model.ExternalRef{{System: "jira", ID: key, URL: strings.TrimSpace(item.Link)}}
+		nodes[key] = &importNode{req: req}
+	}
+
+	var roots []*importNode
+	for _, item := range items {
+		node := nodes[strings.TrimSpace(item.Key)]
+		for _, link := range item.IssueLinks {
+			relation, ok := jiraRelations[strings.ToLower(strings.TrimSpace(link.Name))]
+			if !ok {
+				continue
+			}
+			for _, target := range link.Outward {
+				if _, ok := nodes[strings.TrimSpace(target)]; ok {
+					node.req.Relations = append(node.req.Relations, model.Relation{Type: relation, Target: strings.TrimSpace(target)})
+				}
+			}
+		}
+		if parent, ok := nodes[jiraParent(item)]; ok && parent != node {
+			parent.children = append(parent.children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	// A parent loop, which Jira itself doesn't allow, would leave its
+	// issues out of the tree
+	if reachable := countImportNodes(roots); reachable != len(items) {
+		return nil, fmt.Errorf("%d issue(s) are their own ancestors through parents or epics", len(items)-reachable)
+	}
+
+	config := &model.RequirementConfig{Version: "1.0"}
+	for _, root := range roots {
+		config.Requirements = append(config.Requirements, *root.build())
+	}
+	uniquifySummaries(config)
+
+	return config, nil
+}
+
+// countImportNodes counts nodes and their descendants
+func countImportNodes(nodes []*importNode) int {
+	n := len(nodes)
+	for _, node := range nodes {
+		n += countImportNodes(node.children)
+	}
+	return n
+}
+
+// jiraParent is the key of the issue's parent: its parent task, epic or
+// Advanced Roadmaps parent, empty when it has none
+func jiraParent(item jiraItem) string {
+	if parent := strings.TrimSpace(item.Parent); parent != "" {
+		return parent
+	}
+	for _, field := range item.CustomFields {
+		for _, key := range jiraParentFields {
+			if field.Key == key && len(field.Values) > 0 {
+				return strings.TrimSpace(field.Values[0])
+			}
+		}
+	}
+	return ""
+}
+
+// jiraStatus is the status of an issue, from its status category when
+// the export has it
+func jiraStatus(item jiraItem) string {
+	if status, ok := jiraStatusCategories[item.StatusCategory.Key]; ok {
+		return status
+	}
+	return jiraStatuses[strings.ToLower(strings.TrimSpace(item.Status))]
+}
+
+// jiraOwner is the owner of an issue: its assignee, as @username where
+// the export has usernames
+func jiraOwner(item jiraItem) string {
+	if item.Assignee.Username != "" && item.Assignee.Username != "-1" {
+		return "@" + item.Assignee.Username
+	}
+	if name := strings.TrimSpace(item.Assignee.Name); name != "Unassigned" {
+		return name
+	}
+	return ""
+}
+
+var (
+	jiraBlockEnd = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|h[1-6]|li|tr|pre|blockquote)>`)
+	jiraListItem = regexp.MustCompile(`(?i)<li[^>]*>`)
+	jiraTag      = regexp.MustCompile(`<[^>]*>`)
+	jiraBlank    = regexp.MustCompile(`\n[ \t]*\n(\s*\n)+`)
+)
+
+// jiraText turns the HTML Jira renders descriptions as into plain text,
+// keeping paragraphs and list items on lines of their own
+func jiraText(description string) string {
+	text := jiraListItem.ReplaceAllString(description, "- ")
+	text = jiraBlockEnd.ReplaceAllString(text, "\n")
+	text = html.UnescapeString(jiraTag.ReplaceAllString(text, ""))
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r", ""), "\n") {
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	return strings.TrimSpace(jiraBlank.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// exportJiraXML renders a config back into a Jira XML export, an item per
+// requirement that came from Jira
+func exportJiraXML(config *model.RequirementConfig) ([]byte, error) {
+	type exportedLinkType struct {
+		Name    string   `xml:"name"`
+		Outward []string `xml:"outwardlinks>issuelink>issuekey"`
+	}
+	type exportedItem struct {
+		Link        string             `xml:"link,omitempty"`
+		Key         string             `xml:"key"`
+		Summary     string             `xml:"summary"`
+		Description string             `xml:"description,omitempty"`
+		Priority    string             `xml:"priority,omitempty"`
+		Status      string             `xml:"status,omitempty"`
+		Assignee    string             `xml:"assignee,omitempty"`
+		Labels      []string           `xml:"labels>label,omitempty"`
+		Parent      string             `xml:"parent,omitempty"`
+		IssueLinks  []exportedLinkType `xml:"issuelinks>issuelinktype,omitempty"`
+	}
+	var items []exportedItem
+	linkNames := make(map[string]string, len(jiraRelations))
+	for name, relation := range jiraRelations {
+		if current, ok := linkNames[relation]; !ok || name < current {
+			linkNames[relation] = name
+		}
+	}
+
+	var path []*model.RequirementDetail
+	item := func(req *model.RequirementDetail, pos render.Position) error {
+		path = append(path[:pos.Depth], req)
+		ref := jiraRef(req)
+		if ref == nil {
+			return nil
+		}
+		exported := exportedItem{
+			Link:        ref.URL,
+			Key:         ref.ID,
+			Summary:     req.Summary,
+			Description: html.EscapeString(req.Description),
+			Priority:    req.Priority,
+			Status:      req.Status,
+			Assignee:    req.Owner,
+			Labels:      req.Tags,
+		}
+		if pos.Depth > 0 {
+			if parent := jiraRef(path[pos.Depth-1]); parent != nil {
+				exported.Parent = parent.ID
+			}
+		}
+		for _, relation := range req.Relations {
+			exported.IssueLinks = append(exported.IssueLinks, exportedLinkType{Name: linkNames[relation.Type], Outward: []string{relation.Target}})
+		}
+		items = append(items, exported)
+		return nil
+	}
+	render.Walk(config.Roots(), (*model.RequirementDetail).Children, render.VisitorFunc[*model.RequirementDetail](item))
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	err := enc.Encode(struct {
+		XMLName xml.Name       `xml:"rss"`
+		Items   []exportedItem `xml:"channel>item"`
+	}{Items: items})
+	return buf.Bytes(), err
+}
+
+// jiraRef is the Jira issue a requirement came from, nil for one that
+// didn't
+func jiraRef(req *model.RequirementDetail) *model.ExternalRef {
+	for i, ref := range req.ExternalRefs {
+		if ref.System == "jira" {
+			return &req.ExternalRefs[i]
+		}
+	}
+	return nil
+}
+
+// compareJiraXML reports what an import of original would not carry over
+func compareJiraXML(original, exported []byte) ([]string, error) {
+	before, err := readJiraXML(original)
+	if err != nil {
+		return nil, err
+	}
+	after, err := readJiraXML(exported)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]jiraItem, len(after))
+	for _, item := range after {
+		byKey[item.Key] = item
+	}
+
+	var findings []string
+	dropped := make(map[string]int)
+	for _, b := range before {
+		key := strings.TrimSpace(b.Key)
+		a, ok := byKey[key]
+		if !ok {
+			findings = append(findings, fmt.Sprintf("%s is missing after import", key))
+			continue
+		}
+		if strings.TrimSpace(b.Summary) != a.Summary {
+			findings = append(findings, fmt.Sprintf("%s: summary changed from %q to %q", key, strings.TrimSpace(b.Summary), a.Summary))
+		}
+		if b.Priority != "" && a.Priority == "" {
+			findings = append(findings, fmt.Sprintf("%s: priority %q is not recognized and would be lost", key, b.Priority))
+		}
+		if b.Status != "" && a.Status == "" {
+			findings = append(findings, fmt.Sprintf("%s: status %q is not recognized and would be lost", key, b.Status))
+		}
+		if parent := jiraParent(b); parent != "" && a.Parent != parent {
+			findings = append(findings, fmt.Sprintf("%s: parent %s is not in the export, so the issue is imported at the top level", key, parent))
+		}
+		kept := make(map[string]bool)
+		for _, link := range a.IssueLinks {
+			for _, target := range link.Outward {
+				kept[target] = true
+			}
+		}
+		for _, link := range b.IssueLinks {
+			for _, target := range link.Outward {
+				switch _, known := jiraRelations[strings.ToLower(strings.TrimSpace(link.Name))]; {
+				case !known:
+					findings = append(findings, fmt.Sprintf("%s: %s link to %s is not imported (unknown link type)", key, link.Name, target))
+				case !kept[strings.TrimSpace(target)]:
+					findings = append(findings, fmt.Sprintf("%s: %s link to %s is not imported (%s is not in the export)", key, link.Name, target, target))
+				}
+			}
+		}
+		if b.Type != "" {
+			dropped["issue types"]++
+		}
+		for _, field := range b.CustomFields {
+			if field.Key != jiraParentFields[0] && field.Key != jiraParentFields[1] && len(field.Values) > 0 {
+				dropped[fmt.Sprintf("custom field %q", field.Name)]++
+			}
+		}
+		if len(b.Comments) > 0 {
+			dropped["comments"] += len(b.Comments)
+		}
+		if len(b.Attachments) > 0 {
+			dropped["attachments"] += len(b.Attachments)
+		}
+	}
+
+	for _, what := range sortedKeys(dropped) {
+		findings = append(findings, fmt.Sprintf("%s: %d value(s) would be lost, as they are not imported", what, dropped[what]))
+	}
+
+	return findings, nil
+}
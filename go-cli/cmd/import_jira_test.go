@@ -0,0 +1,171 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+const jiraExport = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="0.92">
+<channel>
+<title>Jira</title>
+<item>
+  <link>https://jira.example.com/browse/AUTH-2</link>
+  <key id="10002">AUTH-2</key>
+  <summary>Password login</summary>
+  <type id="10001">Story</type>
+  <description>&lt;p&gt;Users sign in with a password.&lt;/p&gt;&lt;ul&gt;&lt;li&gt;Rejects &amp;quot;wrong&amp;quot; ones&lt;/li&gt;&lt;/ul&gt;</description>
+  <priority id="2">High</priority>
+  <status id="3">In Progress</status>
+  <statusCategory id="4" key="indeterminate" colorName="yellow"/>
+  <assignee username="alice">Alice Smith</assignee>
+  <labels><label>security</label></labels>
+  <issuelinks>
+    <issuelinktype id="1">
+      <name>Blocks</name>
+      <outwardlinks description="blocks"><issuelink><issuekey id="10003">AUTH-3</issuekey></issuelink></outwardlinks>
+    </issuelinktype>
+  </issuelinks>
+  <customfields>
+    <customfield id="customfield_10008" key="com.pyxis.greenhopper.jira:gh-epic-link">
+      <customfieldname>Epic Link</customfieldname>
+      <customfieldvalues><customfieldvalue>AUTH-1</customfieldvalue></customfieldvalues>
+    </customfield>
+  </customfields>
+</item>
+<item>
+  <link>https://jira.example.com/browse/AUTH-1</link>
+  <key id="10001">AUTH-1</key>
+  <summary>Authentication</summary>
+  <type id="10000">Epic</type>
+  <priority id="1">Blocker</priority>
+  <status id="1">Open</status>
+  <assignee username="-1">Unassigned</assignee>
+</item>
+<item>
+  <link>https://jira.example.com/browse/AUTH-3</link>
+  <key id="10003">AUTH-3</key>
+  <summary>Lockout</summary>
+  <type id="10002">Sub-task</type>
+  <parent id="10002">AUTH-2</parent>
+  <status id="6">Done</status>
+  <statusCategory id="3" key="done" colorName="green"/>
+</item>
+</channel>
+</rss>
+`
+
+func TestImportJiraXML(t *testing.T) {
+	config, err := importJiraXML(strings.NewReader(jiraExport))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(config.Requirements) != 1 {
+		t.Fatalf("Expected the epic as the only top-level requirement, got %d", len(config.Requirements))
+	}
+	epic := config.Requirements[0]
+	if epic.Name != "AUTH-1" || epic.Priority != "critical" || epic.Status != "proposed" || epic.Owner != "" {
+		t.Errorf("Unexpected epic: %+v", epic)
+	}
+	if len(epic.Requirements) != 1 {
+		t.Fatalf("Expected the story nested under its epic, got %+v", epic.Requirements)
+	}
+
+	story := epic.Requirements[0].Full
+	if story.Summary != "Password login" || story.Priority != "high" || story.Status != "approved" || story.Owner != "@alice" {
+		t.Errorf("Unexpected story: %+v", story)
+	}
+	if story.Description != "Users sign in with a password.\n- Rejects \"wrong\" ones" {
+		t.Errorf("Expected the description as text, got %q", story.Description)
+	}
+	if len(story.ExternalRefs) != 1 || story.ExternalRefs[0].System != "jira" || story.ExternalRefs[0].ID != "AUTH-2" || story.ExternalRefs[0].URL != "https://jira.example.com/browse/AUTH-2" {
+		t.Errorf("Expected the issue key in external_refs, got %+v", story.ExternalRefs)
+	}
+	if len(story.Relations) != 1 || story.Relations[0].Type != "blocks" || story.Relations[0].Target != "AUTH-3" {
+		t.Errorf("Expected the Blocks link as a relation, got %+v", story.Relations)
+	}
+	if len(story.Requirements) != 1 || story.Requirements[0].Full.Name != "AUTH-3" || story.Requirements[0].Full.Status != "implemented" {
+		t.Errorf("Expected the sub-task nested under its parent, got %+v", story.Requirements)
+	}
+}
+
+func TestImportJiraXMLErrors(t *testing.T) {
+	for input, want := range map[string]string{
+		"Key,Summary\n":                  "invalid Jira XML export",
+		"<rss><channel></channel></rss>": "no issues found",
+		"<rss><channel><item><summary>Login</summary></item></channel></rss>": `issue "Login" has no key`,
+	} {
+		if _, err := importJiraXML(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q for %q, got %v", want, input, err)
+		}
+	}
+}
+
+func TestJiraXMLRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantFindings []string
+	}{
+		{
+			name:  "lossless export",
+			input: "<rss><channel><item><key>A-1</key><summary>Login</summary><priority>Low</priority></item></channel></rss>",
+		},
+		{
+			name:  "issue types, custom fields and links are reported",
+			input: jiraExport,
+			wantFindings: []string{
+				"issue types: 3 value(s) would be lost",
+			},
+		},
+		{
+			name: "unknown values, parents and links are reported",
+			input: `<rss><channel><item><key>A-1</key><summary>Login</summary><priority>P1</priority><status>Triage</status>
+<parent>A-0</parent><issuelinks><issuelinktype><name>Causes</name><outwardlinks><issuelink><issuekey>A-2</issuekey></issuelink></outwardlinks></issuelinktype>
+<issuelinktype><name>Relates</name><outwardlinks><issuelink><issuekey>B-1</issuekey></issuelink></outwardlinks></issuelinktype></issuelinks>
+<customfields><customfield key="x"><customfieldname>Story Points</customfieldname><customfieldvalues><customfieldvalue>3</customfieldvalue></customfieldvalues></customfield></customfields>
+<comments><comment>LGTM</comment></comments></item>
+<item><key>A-2</key><summary>Logout</summary></item></channel></rss>`,
+			wantFindings: []string{
+				`A-1: priority "P1" is not recognized`,
+				`A-1: status "Triage" is not recognized`,
+				"A-1: parent A-0 is not in the export",
+				"A-1: Causes link to A-2 is not imported (unknown link type)",
+				"A-1: Relates link to B-1 is not imported (B-1 is not in the export)",
+				"comments: 1 value(s) would be lost",
+				`custom field "Story Points": 1 value(s) would be lost`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := importJiraXML(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Unexpected import error: %v", err)
+			}
+			exported, err := exportJiraXML(config)
+			if err != nil {
+				t.Fatalf("Unexpected export error: %v", err)
+			}
+			findings, err := compareJiraXML([]byte(tt.input), exported)
+			if err != nil {
+				t.Fatalf("Unexpected compare error: %v", err)
+			}
+
+			if len(findings) != len(tt.wantFindings) {
+				t.Fatalf("Expected %d finding(s), got %d: %v\n%s", len(tt.wantFindings), len(findings), findings, exported)
+			}
+			joined := strings.Join(findings, "\n")
+			for _, want := range tt.wantFindings {
+				if !strings.Contains(joined, want) {
+					t.Errorf("Expected finding containing %q, got: %v", want, findings)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/render"
+	"go.yaml.in/yaml/v3"
+)
+
+// markdownHeadingPattern matches an ATX heading, # to ######
+var markdownHeadingPattern = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?[ \t]*$`)
+
+// markdownHeadingID matches a trailing {#ID} heading attribute, as Pandoc
+// and most static site generators write it
+var markdownHeadingID = regexp.MustCompile(`[ \t]*\{#([^}\s]+)\}$`)
+
+// markdownClosingSequence matches the optional closing #s of an ATX
+// heading, which aren't part of its text
+var markdownClosingSequence = regexp.MustCompile(`(^|[ \t])#+$`)
+
+// markdownFrontMatterEnd matches the line closing YAML front matter
+var markdownFrontMatterEnd = regexp.MustCompile(`(?m)^(?:---|\.\.\.)[ \t]*$`)
+
+// markdownFieldHeadings are the sub-headings that fill a field of the
+// requirement above them rather than becoming requirements
+var markdownFieldHeadings = map[string]string{
+	"acceptance criteria": "acceptance_test",
+	"acceptance test":     "acceptance_test",
+	"acceptance tests":    "acceptance_test",
+	"rationale":           "justification",
+	"justification":       "justification",
+}
+
+// markdownFrontMatterKeys are the front-matter fields applied to every
+// requirement of the document
+var markdownFrontMatterKeys = []string{"owner", "priority", "status", "tags", "verification"}
+
+// markdownSection is a heading and the text up to the next heading
+type markdownSection struct {
+	level   int
+	heading string
+	id      string
+	body    string
+}
+
+// markdownDocument is a Markdown file split at its headings
+type markdownDocument struct {
+	frontMatter map[string]interface{}
+	// preamble is the text before the first heading
+	preamble string
+	sections []markdownSection
+}
+
+// importMarkdown converts a Markdown document into a requirements config.
+// Every heading becomes a requirement, nested under the closest heading
+// above it of a higher level, with the text below it as its description.
+func importMarkdown(r io.Reader) (*model.RequirementConfig, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := readMarkdownDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(doc.sections) == 0 {
+		return nil, fmt.Errorf("no headings found")
+	}
+
+	var roots []*importNode
+	var stack []*importNode
+	for _, section := range doc.sections {
+		for len(stack) > 0 && stack[len(stack)-1].level >= section.level {
+			stack = stack[:len(stack)-1]
+		}
+		if field, ok := markdownFieldHeadings[strings.ToLower(section.heading)]; ok && len(stack) > 0 {
+			parent := stack[len(stack)-1].req
+			switch field {
+			case "acceptance_test":
+				parent.AcceptanceTest = section.body
+			case "justification":
+				parent.Justification = section.body
+			}
+			continue
+		}
+
+		node := &importNode{level: section.level, req: &model.RequirementDetail{
+			Summary:     truncateSummary(section.heading),
+			Name:        section.id,
+			Description: section.body,
+		}}
+		applyMarkdownFrontMatter(node.req, doc.frontMatter)
+		if len(stack) == 0 {
+			roots = append(roots, node)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, node)
+		}
+		stack = append(stack, node)
+	}
+
+	config := &model.RequirementConfig{Version: "1.0"}
+	for _, root := range roots {
+		config.Requirements = append(config.Requirements, *root.build())
+	}
+	uniquifySummaries(config)
+
+	return config, nil
+}
+
+// readMarkdownDocument splits data into its front matter and the sections
+// of its headings. Lines in fenced code blocks are never headings.
+func readMarkdownDocument(data []byte) (*markdownDocument, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	doc := &markdownDocument{}
+	if rest, ok := strings.CutPrefix(text, "---\n"); ok {
+		end := markdownFrontMatterEnd.FindStringIndex(rest)
+		if end == nil {
+			return nil, fmt.Errorf("front matter is not closed with ---")
+		}
+		if err := yaml.Unmarshal([]byte(rest[:end[0]]), &doc.frontMatter); err != nil {
+			return nil, fmt.Errorf("invalid front matter: %w", err)
+		}
+		text = strings.TrimPrefix(rest[end[1]:], "\n")
+	}
+
+	var body []string
+	flush := func() {
+		content := strings.TrimSpace(strings.Join(body, "\n"))
+		if len(doc.sections) == 0 {
+			doc.preamble = content
+		} else {
+			doc.sections[len(doc.sections)-1].body = content
+		}
+		body = nil
+	}
+	fence := ""
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```"), strings.HasPrefix(trimmed, "~~~"):
+			fence = trimmed[:3]
+		default:
+			m := markdownHeadingPattern.FindStringSubmatch(line)
+			if m != nil {
+				m[2] = strings.TrimSpace(markdownClosingSequence.ReplaceAllString(m[2], ""))
+			}
+			// An empty heading has nothing to name a requirement with, so
+			// it stays in the text
+			if m != nil && m[2] != "" {
+				flush()
+				section := markdownSection{level: len(m[1]), heading: m[2]}
+				if id := markdownHeadingID.FindStringSubmatch(section.heading); id != nil {
+					section.id = id[1]
+					section.heading = strings.TrimSpace(section.heading[:len(section.heading)-len(id[0])])
+				}
+				doc.sections = append(doc.sections, section)
+				continue
+			}
+		}
+		body = append(body, line)
+	}
+	flush()
+	return doc, nil
+}
+
+// applyMarkdownFrontMatter gives req the metadata of the document's front
+// matter. Values the schema doesn't allow are left out.
+func applyMarkdownFrontMatter(req *model.RequirementDetail, frontMatter map[string]interface{}) {
+	value := func(key string) string {
+		if v, ok := frontMatter[key]; ok && v != nil {
+			return strings.TrimSpace(fmt.Sprint(v))
+		}
+		return ""
+	}
+	req.Owner = value("owner")
+	req.Priority = normalizeEnum(value("priority"), "critical", "high", "medium", "low")
+	req.Status = normalizeEnum(value("status"), "draft", "proposed", "approved", "implemented", "verified", "deprecated")
+	req.Verification = normalizeEnum(value("verification"), "test", "analysis", "inspection", "demonstration")
+	req.Tags = markdownTags(frontMatter["tags"])
+}
+
+// markdownTags reads tags from front matter, as a list or a comma
+// separated string
+func markdownTags(v interface{}) []string {
+	var tags []string
+	switch v := v.(type) {
+	case []interface{}:
+		for _, tag := range v {
+			tags = append(tags, fmt.Sprint(tag))
+		}
+	case string:
+		tags = strings.Split(v, ",")
+	}
+	var cleaned []string
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			cleaned = append(cleaned, tag)
+		}
+	}
+	return cleaned
+}
+
+// exportMarkdown renders a config back into a Markdown document, a heading
+// per requirement
+func exportMarkdown(config *model.RequirementConfig) ([]byte, error) {
+	var b strings.Builder
+	section := func(level int, heading, body string) {
+		fmt.Fprintf(&b, "%s %s\n\n", strings.Repeat("#", min(level, 6)), heading)
+		if body != "" {
+			fmt.Fprintf(&b, "%s\n\n", body)
+		}
+	}
+	write := func(req *model.RequirementDetail, pos render.Position) error {
+		heading := req.Summary
+		if req.Name != "" {
+			heading += " {#" + req.Name + "}"
+		}
+		section(pos.Depth+1, heading, req.Description)
+		if req.AcceptanceTest != "" {
+			section(pos.Depth+2, "Acceptance criteria", req.AcceptanceTest)
+		}
+		if req.Justification != "" {
+			section(pos.Depth+2, "Rationale", req.Justification)
+		}
+		return nil
+	}
+	render.Walk(config.Roots(), (*model.RequirementDetail).Children, render.VisitorFunc[*model.RequirementDetail](write))
+	return []byte(b.String()), nil
+}
+
+// compareMarkdown reports what an import of original would not carry over
+func compareMarkdown(original, exported []byte) ([]string, error) {
+	before, err := readMarkdownDocument(original)
+	if err != nil {
+		return nil, err
+	}
+	after, err := readMarkdownDocument(exported)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []string
+	if before.preamble != "" {
+		findings = append(findings, "the text before the first heading is not imported")
+	}
+	for _, key := range sortedKeys(before.frontMatter) {
+		switch value := fmt.Sprint(before.frontMatter[key]); {
+		case !slices.Contains(markdownFrontMatterKeys, key):
+			findings = append(findings, fmt.Sprintf("front matter %q is not imported", key))
+		case key == "priority" && normalizeEnum(value, "critical", "high", "medium", "low") == "",
+			key == "status" && normalizeEnum(value, "draft", "proposed", "approved", "implemented", "verified", "deprecated") == "",
+			key == "verification" && normalizeEnum(value, "test", "analysis", "inspection", "demonstration") == "":
+			findings = append(findings, fmt.Sprintf("front matter %s %q is not recognized and would be lost", key, value))
+		}
+	}
+
+	if len(before.sections) != len(after.sections) {
+		findings = append(findings, fmt.Sprintf("%d heading(s) in the document but %d after import", len(before.sections), len(after.sections)))
+	}
+	beforeDepths, afterDepths := markdownDepths(before.sections), markdownDepths(after.sections)
+	for i := 0; i < len(before.sections) && i < len(after.sections); i++ {
+		b, a := before.sections[i], after.sections[i]
+		label := fmt.Sprintf("heading %q", b.heading)
+		if !sameMarkdownHeading(b.heading, a.heading) {
+			findings = append(findings, fmt.Sprintf("%s becomes %q", label, a.heading))
+		}
+		if b.id != a.id {
+			findings = append(findings, fmt.Sprintf("%s: ID %q becomes %q", label, b.id, a.id))
+		}
+		if beforeDepths[i] != afterDepths[i] {
+			findings = append(findings, fmt.Sprintf("%s: nesting depth %d becomes %d", label, beforeDepths[i]+1, afterDepths[i]+1))
+		}
+		if b.body != a.body {
+			findings = append(findings, fmt.Sprintf("%s: text changed from %q to %q", label, b.body, a.body))
+		}
+	}
+
+	return findings, nil
+}
+
+// markdownDepths is how deeply each section is nested, which unlike its
+// heading level doesn't count skipped levels
+func markdownDepths(sections []markdownSection) []int {
+	depths := make([]int, len(sections))
+	var stack []int
+	for i, section := range sections {
+		for len(stack) > 0 && stack[len(stack)-1] >= section.level {
+			stack = stack[:len(stack)-1]
+		}
+		depths[i] = len(stack)
+		stack = append(stack, section.level)
+	}
+	return depths
+}
+
+// sameMarkdownHeading reports whether two headings are the same, counting
+// the spellings of a field heading as one
+func sameMarkdownHeading(a, b string) bool {
+	fieldA, okA := markdownFieldHeadings[strings.ToLower(a)]
+	fieldB, okB := markdownFieldHeadings[strings.ToLower(b)]
+	if okA && okB {
+		return fieldA == fieldB
+	}
+	return a == b
+}
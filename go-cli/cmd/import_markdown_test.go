@@ -0,0 +1,182 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"go.yaml.in/yaml/v3"
+)
+
+const markdownSpec = `---
+owner: "@alice"
+status: Draft
+tags: [auth, security]
+---
+
+# Authentication {#AUTH}
+
+Everything about signing in.
+
+## Password login {#AUTH-1}
+
+Users sign in with their email and a password.
+
+### Acceptance criteria
+
+- A wrong password is rejected
+
+### Rationale
+
+Most users expect it.
+
+## Lockout
+
+` + "```sh\n# not a heading\n```" + `
+
+#### Lockout notice ##
+
+Locked out users are told when to retry.
+
+# Reporting
+`
+
+func TestImportMarkdown(t *testing.T) {
+	config, err := importMarkdown(strings.NewReader(markdownSpec))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(config.Requirements) != 2 {
+		t.Fatalf("Expected 2 top-level requirements, got %d", len(config.Requirements))
+	}
+	auth := config.Requirements[0]
+	if auth.Summary != "Authentication" || auth.Name != "AUTH" || auth.Description != "Everything about signing in." {
+		t.Errorf("Unexpected first requirement: %+v", auth)
+	}
+	if auth.Owner != "@alice" || auth.Status != "draft" || strings.Join(auth.Tags, ",") != "auth,security" {
+		t.Errorf("Expected the front matter applied, got %+v", auth)
+	}
+	if len(auth.Requirements) != 2 {
+		t.Fatalf("Expected 2 children, got %d", len(auth.Requirements))
+	}
+
+	login := auth.Requirements[0].Full
+	if login.Summary != "Password login" || login.Name != "AUTH-1" {
+		t.Errorf("Unexpected child: %+v", login)
+	}
+	if login.AcceptanceTest != "- A wrong password is rejected" || login.Justification != "Most users expect it." {
+		t.Errorf("Expected field sub-headings to fill fields, got %+v", login)
+	}
+	if len(login.Requirements) != 0 {
+		t.Errorf("Expected no sub-requirements for field headings, got %d", len(login.Requirements))
+	}
+
+	lockout := auth.Requirements[1].Full
+	if !strings.Contains(lockout.Description, "# not a heading") {
+		t.Errorf("Expected fenced code kept in the description, got %q", lockout.Description)
+	}
+	if len(lockout.Requirements) != 1 || lockout.Requirements[0].Full.Summary != "Lockout notice" {
+		t.Errorf("Expected the #### heading nested under Lockout, got %+v", lockout.Requirements)
+	}
+}
+
+func TestImportMarkdownErrors(t *testing.T) {
+	for input, want := range map[string]string{
+		"Just some text\n":                  "no headings found",
+		"---\nowner: [\n---\n# Title\n":     "invalid front matter",
+		"---\nowner: \"@alice\"\n# Title\n": "front matter is not closed",
+	} {
+		if _, err := importMarkdown(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q for %q, got %v", want, input, err)
+		}
+	}
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantFindings []string
+	}{
+		{
+			name:  "lossless document",
+			input: "---\nstatus: approved\n---\n# Authentication {#AUTH}\n\nSign in.\n\n## Login\n\n### Acceptance Criteria\n\nIt works.\n",
+		},
+		{
+			name:  "skipped levels are only a different nesting",
+			input: "## Authentication\n\n#### Login\n",
+		},
+		{
+			name:  "unknown front matter, preamble and duplicates are reported",
+			input: "---\ntitle: Spec\nstatus: WIP\n---\nIntro\n\n# Login\n\n# Login\n",
+			wantFindings: []string{
+				"text before the first heading is not imported",
+				`front matter status "WIP" is not recognized`,
+				`front matter "title" is not imported`,
+				`heading "Login" becomes "Login (2)"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := importMarkdown(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Unexpected import error: %v", err)
+			}
+			exported, err := exportMarkdown(config)
+			if err != nil {
+				t.Fatalf("Unexpected export error: %v", err)
+			}
+			findings, err := compareMarkdown([]byte(tt.input), exported)
+			if err != nil {
+				t.Fatalf("Unexpected compare error: %v", err)
+			}
+
+			if len(findings) != len(tt.wantFindings) {
+				t.Fatalf("Expected %d finding(s), got %d: %v", len(tt.wantFindings), len(findings), findings)
+			}
+			joined := strings.Join(findings, "\n")
+			for _, want := range tt.wantFindings {
+				if !strings.Contains(joined, want) {
+					t.Errorf("Expected finding containing %q, got: %v", want, findings)
+				}
+			}
+		})
+	}
+}
+
+func TestRunImportMarkdownFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "a-login.md"), []byte("# Overview\n\n# Login\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "b-billing.md"), []byte("# Overview\n\n# Invoices\n"), 0644)
+	output := filepath.Join(tmpDir, "requirements.yml")
+
+	importFrom, importOutput = "markdown", output
+	defer func() { importFrom, importOutput = "", "" }()
+
+	if err := runImport([]string{filepath.Join(tmpDir, "*.md")}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	var config model.RequirementConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("Output is not valid YAML: %v", err)
+	}
+	var summaries []string
+	for _, req := range config.Requirements {
+		summaries = append(summaries, req.Summary)
+	}
+	if got := strings.Join(summaries, ", "); got != "Overview, Login, Overview (2), Invoices" {
+		t.Errorf("Expected the files in order with unique summaries, got %s", got)
+	}
+}
@@ -0,0 +1,472 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/render"
+)
+
+// tableFields are the requirement fields a column can be mapped to with
+// --map, besides attributes.<key>
+var tableFields = []string{
+	"summary", "name", "description", "justification", "acceptance_test",
+	"owner", "priority", "status", "verification", "tags", "parent",
+}
+
+// tableFieldAliases are the column headings mapped without --map to a
+// field other than the one they spell
+var tableFieldAliases = map[string]string{
+	"id":    "name",
+	"title": "summary",
+}
+
+// columnMapping maps a column of a spreadsheet to a requirement field
+type columnMapping struct {
+	column string
+	field  string
+}
+
+// tableReader reads the rows of a spreadsheet, its header first
+type tableReader func(data []byte) ([][]string, error)
+
+// tableImporter is the importer of a spreadsheet format, with --map
+// choosing the columns
+func tableImporter(read tableReader) importer {
+	return importer{
+		parse: func(r io.Reader) (*model.RequirementConfig, error) {
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return nil, err
+			}
+			return importTable(read, data, importMap)
+		},
+		export: func(config *model.RequirementConfig) ([]byte, error) {
+			return exportTable(config, importMap, importExportColumns)
+		},
+		compare: func(original, exported []byte) ([]string, error) {
+			return compareTable(read, original, exported, importMap)
+		},
+		preview: func(original []byte) ([]string, error) {
+			return previewTableMapping(read, original, importMap)
+		},
+	}
+}
+
+// parseColumnMap parses --map, Column=field pairs separated by commas such
+// as 'Title=summary,ID=name,Parent=parent'
+func parseColumnMap(spec string) ([]columnMapping, error) {
+	var mappings []columnMapping
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		column, field, ok := strings.Cut(pair, "=")
+		column, field = strings.TrimSpace(column), strings.ToLower(strings.TrimSpace(field))
+		if !ok || column == "" || field == "" {
+			return nil, fmt.Errorf("invalid --map entry %q: write Column=field", pair)
+		}
+		if !slices.Contains(tableFields, field) && !strings.HasPrefix(field, "attributes.") {
+			return nil, fmt.Errorf("unknown field %q in --map (fields: %s, attributes.<key>)", field, strings.Join(tableFields, ", "))
+		}
+		mappings = append(mappings, columnMapping{column: column, field: field})
+	}
+	return mappings, nil
+}
+
+// resolveColumns finds the fields of the header's columns, by index. With
+// no --map, columns named after a field (or ID and Title) are mapped.
+func resolveColumns(header []string, spec string) (map[int]string, error) {
+	columns := make(map[int]string)
+	normalized := make([]string, len(header))
+	for i, name := range header {
+		normalized[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	if strings.TrimSpace(spec) == "" {
+		for i, name := range normalized {
+			field := strings.ReplaceAll(name, " ", "_")
+			if alias, ok := tableFieldAliases[field]; ok {
+				field = alias
+			}
+			if slices.Contains(tableFields, field) && !slices.Contains(mapValues(columns), field) {
+				columns[i] = field
+			}
+		}
+	} else {
+		mappings, err := parseColumnMap(spec)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range mappings {
+			i := slices.Index(normalized, strings.ToLower(m.column))
+			if i < 0 {
+				return nil, fmt.Errorf("column %q not found (columns: %s)", m.column, strings.Join(header, ", "))
+			}
+			columns[i] = m.field
+		}
+	}
+
+	fields := mapValues(columns)
+	if !slices.Contains(fields, "summary") && !slices.Contains(fields, "description") {
+		return nil, fmt.Errorf("no column is mapped to summary or description; map one with --map 'Title=summary'")
+	}
+	return columns, nil
+}
+
+// mapValues returns the values of m
+func mapValues[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// tableRow is a row of a spreadsheet as the fields it maps to
+type tableRow struct {
+	// line is the row's number in the spreadsheet, the header being 1
+	line   int
+	fields map[string]string
+	// tags collects every column mapped to tags
+	tags []string
+}
+
+// readTableRows reads the non-empty rows of a spreadsheet by field,
+// counting the values of the columns no field is mapped to
+func readTableRows(read tableReader, data []byte, spec string) (rows []tableRow, unmapped map[string]int, err error) {
+	records, err := read(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("empty spreadsheet")
+	}
+	header := records[0]
+	columns, err := resolveColumns(header, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unmapped = make(map[string]int)
+	for n, record := range records[1:] {
+		row := tableRow{line: n + 2, fields: make(map[string]string)}
+		empty := true
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			empty = false
+			field, ok := columns[i]
+			switch {
+			case !ok:
+				if i < len(header) {
+					unmapped[strings.TrimSpace(header[i])]++
+				}
+			case field == "tags":
+				row.tags = append(row.tags, splitTags(value)...)
+			default:
+				row.fields[field] = value
+			}
+		}
+		if !empty {
+			rows = append(rows, row)
+		}
+	}
+	return rows, unmapped, nil
+}
+
+// splitTags splits a cell of tags, separated by commas or semicolons
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// importTable converts the rows of a spreadsheet into a requirements
+// config, nesting each row under the row its parent column names by ID
+// or summary
+func importTable(read tableReader, data []byte, spec string) (*model.RequirementConfig, error) {
+	rows, _, err := readTableRows(read, data, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*importNode, len(rows))
+	byKey := make(map[string]int)
+	for i, row := range rows {
+		req := doorsRequirement(row.fields["summary"], row.fields["description"])
+		if req.Summary == "" {
+			return nil, fmt.Errorf("row %d has no summary", row.line)
+		}
+		req.Name = row.fields["name"]
+		req.Justification = row.fields["justification"]
+		req.AcceptanceTest = row.fields["acceptance_test"]
+		req.Owner = row.fields["owner"]
+		req.Priority = normalizeEnum(row.fields["priority"], "critical", "high", "medium", "low")
+		req.Status = normalizeEnum(row.fields["status"], "draft", "proposed", "approved", "implemented", "verified", "deprecated")
+		req.Verification = normalizeEnum(row.fields["verification"], "test", "analysis", "inspection", "demonstration")
+		req.Tags = row.tags
+		for field, value := range row.fields {
+			if key, ok := strings.CutPrefix(field, "attributes."); ok {
+				if req.Attributes == nil {
+					req.Attributes = make(map[string]interface{})
+				}
+				req.Attributes[key] = value
+			}
+		}
+		nodes[i] = &importNode{req: req}
+
+		// Parents are found by ID first, then by summary
+		if req.Name != "" {
+			byKey["name:"+req.Name] = i
+		}
+		if _, seen := byKey["summary:"+req.Summary]; !seen {
+			byKey["summary:"+req.Summary] = i
+		}
+	}
+
+	parents := make([]int, len(rows))
+	for i, row := range rows {
+		parents[i] = -1
+		ref := row.fields["parent"]
+		if ref == "" {
+			continue
+		}
+		parent, ok := byKey["name:"+ref]
+		if !ok {
+			parent, ok = byKey["summary:"+ref]
+		}
+		if !ok {
+			return nil, fmt.Errorf("row %d: parent %q is not the ID or summary of any row", row.line, ref)
+		}
+		parents[i] = parent
+	}
+	for i := range rows {
+		seen := map[int]bool{i: true}
+		for p := parents[i]; p >= 0; p = parents[p] {
+			if seen[p] {
+				return nil, fmt.Errorf("row %d: its parents lead back to it", rows[i].line)
+			}
+			seen[p] = true
+		}
+	}
+
+	var roots []*importNode
+	for i, node := range nodes {
+		if parents[i] < 0 {
+			roots = append(roots, node)
+		} else {
+			parent := nodes[parents[i]]
+			parent.children = append(parent.children, node)
+		}
+	}
+
+	config := &model.RequirementConfig{Version: "1.0"}
+	for _, root := range roots {
+		config.Requirements = append(config.Requirements, *root.build())
+	}
+	uniquifySummaries(config)
+
+	return config, nil
+}
+
+// importExportColumns is the layout exportTable writes without --map: a
+// column for every field, so it reads back as the columns mapped by name
+const importExportColumns = "ID=name,Summary=summary,Description=description,Justification=justification,Acceptance Test=acceptance_test," +
+	"Owner=owner,Priority=priority,Status=status,Verification=verification,Tags=tags,Parent=parent"
+
+// exportTable renders a config back into CSV, with the columns of spec or,
+// without one, of fallback. The parent column names a requirement's
+// parent by ID, or by summary when it has none.
+func exportTable(config *model.RequirementConfig, spec, fallback string) ([]byte, error) {
+	if strings.TrimSpace(spec) == "" {
+		spec = fallback
+	}
+	mappings, err := parseColumnMap(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := make([]string, len(mappings))
+	for i, m := range mappings {
+		header[i] = m.column
+	}
+	w.Write(header)
+
+	var path []*model.RequirementDetail
+	row := func(req *model.RequirementDetail, pos render.Position) error {
+		path = append(path[:pos.Depth], req)
+		record := make([]string, len(mappings))
+		for i, m := range mappings {
+			switch m.field {
+			case "parent":
+				if pos.Depth > 0 {
+					parent := path[pos.Depth-1]
+					record[i] = cmp.Or(parent.Name, parent.Summary)
+				}
+			case "tags":
+				record[i] = strings.Join(req.Tags, ", ")
+			default:
+				record[i] = tableFieldValue(req, m.field)
+			}
+		}
+		return w.Write(record)
+	}
+	render.Walk(config.Roots(), (*model.RequirementDetail).Children, render.VisitorFunc[*model.RequirementDetail](row))
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// tableFieldValue is the value of a field of req as a cell
+func tableFieldValue(req *model.RequirementDetail, field string) string {
+	if key, ok := strings.CutPrefix(field, "attributes."); ok {
+		if v, ok := req.Attributes[key]; ok {
+			return fmt.Sprint(v)
+		}
+		return ""
+	}
+	switch field {
+	case "summary":
+		return req.Summary
+	case "name":
+		return req.Name
+	case "description":
+		return req.Description
+	case "justification":
+		return req.Justification
+	case "acceptance_test":
+		return req.AcceptanceTest
+	case "owner":
+		return req.Owner
+	case "priority":
+		return req.Priority
+	case "status":
+		return req.Status
+	case "verification":
+		return req.Verification
+	}
+	return ""
+}
+
+// compareTable reports what an import of original would not carry over.
+// Rows are matched by ID, or by summary when they have none. Parents
+// aren't compared: the import fails on any it can't resolve.
+func compareTable(read tableReader, original, exported []byte, spec string) ([]string, error) {
+	before, unmapped, err := readTableRows(read, original, spec)
+	if err != nil {
+		return nil, err
+	}
+	after, _, err := readTableRows(readCSVTable, exported, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []string
+	if len(before) != len(after) {
+		findings = append(findings, fmt.Sprintf("%d row(s) in the spreadsheet but %d after import", len(before), len(after)))
+	}
+
+	key := func(row tableRow) string {
+		return cmp.Or(row.fields["name"], row.fields["summary"])
+	}
+	remaining := make(map[string][]tableRow)
+	for _, row := range after {
+		remaining[key(row)] = append(remaining[key(row)], row)
+	}
+	for _, b := range before {
+		label := fmt.Sprintf("row %d", b.line)
+		matches := remaining[key(b)]
+		if len(matches) == 0 {
+			findings = append(findings, fmt.Sprintf("%s: %q isn't found after import (renamed or dropped)", label, key(b)))
+			continue
+		}
+		a := matches[0]
+		remaining[key(b)] = matches[1:]
+
+		for _, field := range sortedKeys(b.fields) {
+			if field == "parent" {
+				continue
+			}
+			switch before, after := b.fields[field], a.fields[field]; {
+			case before == after:
+			case slices.Contains([]string{"priority", "status", "verification"}, field) && after == "":
+				findings = append(findings, fmt.Sprintf("%s: %s %q is not recognized and would be lost", label, field, before))
+			case !strings.EqualFold(before, after):
+				findings = append(findings, fmt.Sprintf("%s: %s changed from %q to %q", label, field, before, after))
+			}
+		}
+		if strings.Join(b.tags, ",") != strings.Join(a.tags, ",") {
+			findings = append(findings, fmt.Sprintf("%s: tags changed from %q to %q", label, strings.Join(b.tags, ", "), strings.Join(a.tags, ", ")))
+		}
+	}
+
+	for _, column := range sortedKeys(unmapped) {
+		findings = append(findings, fmt.Sprintf("column %q is not imported (%d value(s) would be lost)", column, unmapped[column]))
+	}
+
+	return findings, nil
+}
+
+// previewTableMapping lists the field each column of the spreadsheet is
+// imported into, for --dry-run
+func previewTableMapping(read tableReader, data []byte, spec string) ([]string, error) {
+	records, err := read(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty spreadsheet")
+	}
+	header := records[0]
+	columns, err := resolveColumns(header, spec)
+	if err != nil {
+		return nil, err
+	}
+	width := 0
+	for _, name := range header {
+		width = max(width, len(strings.TrimSpace(name)))
+	}
+	lines := []string{"Column mapping:"}
+	for i, name := range header {
+		field, ok := columns[i]
+		if !ok {
+			field = "(not imported)"
+		}
+		lines = append(lines, fmt.Sprintf("  %-*s → %s", width, strings.TrimSpace(name), field))
+	}
+	return lines, nil
+}
+
+// readCSVTable reads the rows of a CSV file, with its delimiter sniffed
+// from the header
+func readCSVTable(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = sniffDelimiter(data)
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	return records, nil
+}
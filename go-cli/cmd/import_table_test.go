@@ -0,0 +1,212 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+const tableCSV = "Key,Title,Notes,Owner,Prio,Parent,Labels,Team\n" +
+	"AUTH-2,Lockout,After 5 failures,@bob,High,AUTH-1,security,identity\n" +
+	"AUTH-1,Authentication,,@alice,critical,,\"auth, security\",identity\n" +
+	"RPT-1,Reporting,,,,,,\n" +
+	",Export CSV,,,,Reporting,,\n"
+
+const tableMap = "Key=name,Title=summary,Notes=description,Owner=owner,Prio=priority,Parent=parent,Labels=tags,Team=attributes.team"
+
+func TestImportTable(t *testing.T) {
+	config, err := importTable(readCSVTable, []byte(tableCSV), tableMap)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(config.Requirements) != 2 {
+		t.Fatalf("Expected 2 top-level requirements, got %d", len(config.Requirements))
+	}
+	auth := config.Requirements[0]
+	if auth.Summary != "Authentication" || auth.Name != "AUTH-1" || auth.Priority != "critical" || strings.Join(auth.Tags, ",") != "auth,security" {
+		t.Errorf("Unexpected first requirement: %+v", auth)
+	}
+	if auth.Attributes["team"] != "identity" {
+		t.Errorf("Expected the team attribute, got %v", auth.Attributes)
+	}
+	// The child comes before its parent in the sheet
+	if len(auth.Requirements) != 1 {
+		t.Fatalf("Expected Lockout nested under its parent, got %+v", auth.Requirements)
+	}
+	if lockout := auth.Requirements[0].Full; lockout.Summary != "Lockout" || lockout.Priority != "high" || lockout.Description != "After 5 failures" {
+		t.Errorf("Unexpected child: %+v", lockout)
+	}
+	// A parent is found by summary when it has no ID
+	if report := config.Requirements[1]; len(report.Requirements) != 1 || report.Requirements[0].Full.Summary != "Export CSV" {
+		t.Errorf("Expected Export CSV nested under Reporting, got %+v", report.Requirements)
+	}
+}
+
+func TestImportTableDefaultColumns(t *testing.T) {
+	config, err := importTable(readCSVTable, []byte("ID;Title;Acceptance Test;Comment\nR-1;Login;It works;x\n"), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if req := config.Requirements[0]; req.Name != "R-1" || req.Summary != "Login" || req.AcceptanceTest != "It works" {
+		t.Errorf("Expected columns mapped by name, got %+v", req)
+	}
+}
+
+func TestImportTableErrors(t *testing.T) {
+	tests := []struct {
+		name, input, spec, want string
+	}{
+		{"unknown field", "Title\nLogin\n", "Title=headline", `unknown field "headline"`},
+		{"malformed map", "Title\nLogin\n", "Title", `invalid --map entry "Title"`},
+		{"missing column", "Title\nLogin\n", "Name=summary", `column "Name" not found (columns: Title)`},
+		{"nothing to summarize", "Owner\n@alice\n", "", "no column is mapped to summary"},
+		{"row without summary", "Title,Owner\nLogin,\n,@alice\n", "", "row 3 has no summary"},
+		{"unknown parent", "Title,Parent\nLogin,Auth\n", "", `row 2: parent "Auth" is not the ID or summary of any row`},
+		{"parent cycle", "ID,Title,Parent\nA,Login,B\nB,Logout,A\n", "", "row 2: its parents lead back to it"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := importTable(readCSVTable, []byte(tt.input), tt.spec); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestTableRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		spec         string
+		wantFindings []string
+	}{
+		{name: "lossless sheet", input: tableCSV, spec: tableMap},
+		{name: "columns mapped by name", input: "ID,Summary,Status,Parent\nA,Login,approved,\nB,Lockout,,A\n"},
+		{
+			name:  "unmapped columns and values are reported",
+			input: "Title,Status,Created By\nLogin,In Review,jdoe\nLogin,,jdoe\n",
+			wantFindings: []string{
+				`row 2: status "In Review" is not recognized`,
+				`row 3: "Login" isn't found after import`,
+				`column "Created By" is not imported (2 value(s)`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := importTable(readCSVTable, []byte(tt.input), tt.spec)
+			if err != nil {
+				t.Fatalf("Unexpected import error: %v", err)
+			}
+			exported, err := exportTable(config, tt.spec, importExportColumns)
+			if err != nil {
+				t.Fatalf("Unexpected export error: %v", err)
+			}
+			findings, err := compareTable(readCSVTable, []byte(tt.input), exported, tt.spec)
+			if err != nil {
+				t.Fatalf("Unexpected compare error: %v", err)
+			}
+
+			if len(findings) != len(tt.wantFindings) {
+				t.Fatalf("Expected %d finding(s), got %d: %v\n%s", len(tt.wantFindings), len(findings), findings, exported)
+			}
+			joined := strings.Join(findings, "\n")
+			for _, want := range tt.wantFindings {
+				if !strings.Contains(joined, want) {
+					t.Errorf("Expected finding containing %q, got: %v", want, findings)
+				}
+			}
+		})
+	}
+}
+
+func TestPreviewTableMapping(t *testing.T) {
+	lines, err := previewTableMapping(readCSVTable, []byte("Title,Comment\nLogin,x\n"), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := strings.Join(lines, "\n"); got != "Column mapping:\n  Title   → summary\n  Comment → (not imported)" {
+		t.Errorf("Unexpected preview:\n%s", got)
+	}
+}
+
+// xlsxFixture builds a workbook whose first sheet has a shared string
+// header, an inline string, a number and a skipped cell and row
+func xlsxFixture(t *testing.T) []byte {
+	t.Helper()
+	return zipFixture(t, xlsxParts())
+}
+
+// xlsxParts are the parts of xlsxFixture's workbook
+func xlsxParts() map[string]string {
+	return map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Requirements" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>ID</t></si><si><t>Title</t></si><si><r><t>Pri</t></r><r><t>ority</t></r></si><si><t>Login</t></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>
+<row r="2"><c r="A2"><v>42</v></c><c r="B2" t="s"><v>3</v></c></row>
+<row r="4"><c r="B4" t="inlineStr"><is><t>Logout</t></is></c><c r="C4" t="inlineStr"><is><t>high</t></is></c></row>
+</sheetData></worksheet>`,
+	}
+}
+
+func TestReadXLSXTable(t *testing.T) {
+	records, err := readXLSXTable(xlsxFixture(t))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := [][]string{{"ID", "Title", "Priority"}, {"42", "Login"}, nil, {"", "Logout", "high"}}
+	if len(records) != len(want) {
+		t.Fatalf("Expected %d rows, got %q", len(want), records)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("Row %d: expected %q, got %q", i+1, want[i], records[i])
+		}
+	}
+
+	config, err := importTable(readXLSXTable, xlsxFixture(t), "")
+	if err != nil {
+		t.Fatalf("Unexpected import error: %v", err)
+	}
+	var got []string
+	for _, req := range config.Flatten() {
+		got = append(got, requirementLabel(req)+" "+req.Priority)
+	}
+	if strings.Join(got, ", ") != "42  Login , Logout high" {
+		t.Errorf("Unexpected requirements: %q", got)
+	}
+
+	if _, err := readXLSXTable([]byte("Title\nLogin\n")); err == nil || !strings.Contains(err.Error(), "not an Excel workbook") {
+		t.Errorf("Expected an error for a CSV file, got %v", err)
+	}
+}
+
+func TestReadXLSXTableLimits(t *testing.T) {
+	for row, want := range map[string]string{
+		`<row r="99999999"><c r="A99999999"><v>1</v></c></row>`:                 "more than 1048576 rows",
+		`<row r="1"><c r="` + strings.Repeat("Z", 40) + `1"><v>1</v></c></row>`: "more than 16384 columns",
+		`<row r="1"><c r="XFE1"><v>1</v></c></row>`:                             "more than 16384 columns",
+	} {
+		parts := xlsxParts()
+		parts["xl/worksheets/sheet1.xml"] = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + row + `</sheetData></worksheet>`
+		if _, err := readXLSXTable(zipFixture(t, parts)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q for %s, got %v", want, row, err)
+		}
+	}
+
+	parts := xlsxParts()
+	parts["xl/worksheets/sheet1.xml"] = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1048576"><c r="XFD1048576"><v>1</v></c></row></sheetData></worksheet>`
+	records, err := readXLSXTable(zipFixture(t, parts))
+	if err != nil || len(records) != xlsxMaxRows || len(records[xlsxMaxRows-1]) != xlsxMaxColumns {
+		t.Errorf("Expected the last cell of a sheet read, got %d row(s), %v", len(records), err)
+	}
+}
@@ -0,0 +1,193 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// Excel's sheet size limits, which bound the table a worksheet can
+// make, and the largest uncompressed workbook part read, which bounds the
+// memory a compressed one can expand to
+const (
+	xlsxMaxRows     = 1 << 20
+	xlsxMaxColumns  = 1 << 14
+	xlsxMaxPartSize = 256 << 20
+)
+
+// xlsxWorkbook is the sheet list of xl/workbook.xml
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		// RID is the relationship naming the sheet's part
+		RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxRelationships is xl/_rels/workbook.xml.rels
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText is rich or plain text, of a shared string or an inline one
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	var b strings.Builder
+	b.WriteString(t.T)
+	for _, run := range t.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+// xlsxSheet is the cell data of a worksheet
+type xlsxSheet struct {
+	Rows []struct {
+		// Number is the row's 1-based number; rows without cells are
+		// left out
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSXTable reads the rows of the first worksheet of an Excel
+// workbook. Cells hold what Excel stores: the text of strings, and
+// numbers, dates included, as numbers.
+func readXLSXTable(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not an Excel workbook (.xlsx): %w", err)
+	}
+	part := func(name string, v interface{}) error {
+		f, err := archive.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		content, err := io.ReadAll(io.LimitReader(f, xlsxMaxPartSize+1))
+		if err != nil {
+			return err
+		}
+		if len(content) > xlsxMaxPartSize {
+			return fmt.Errorf("%s is larger than %d MB", name, xlsxMaxPartSize>>20)
+		}
+		return xml.Unmarshal(content, v)
+	}
+
+	var workbook xlsxWorkbook
+	if err := part("xl/workbook.xml", &workbook); err != nil {
+		return nil, fmt.Errorf("invalid workbook: %w", err)
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, fmt.Errorf("the workbook has no sheets")
+	}
+	var rels xlsxRelationships
+	if err := part("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, fmt.Errorf("invalid workbook: %w", err)
+	}
+	sheetPart := ""
+	for _, rel := range rels.Relationships {
+		if rel.ID == workbook.Sheets[0].RID {
+			// Targets are relative to xl/, or absolute within the package
+			if strings.HasPrefix(rel.Target, "/") {
+				sheetPart = strings.TrimPrefix(rel.Target, "/")
+			} else {
+				sheetPart = path.Join("xl", rel.Target)
+			}
+		}
+	}
+	if sheetPart == "" {
+		return nil, fmt.Errorf("invalid workbook: sheet %q has no part", workbook.Sheets[0].Name)
+	}
+
+	// Workbooks without any strings have no shared strings part
+	var shared struct {
+		Items []xlsxText `xml:"si"`
+	}
+	if err := part("xl/sharedStrings.xml", &shared); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("invalid shared strings: %w", err)
+	}
+
+	var sheet xlsxSheet
+	if err := part(sheetPart, &sheet); err != nil {
+		return nil, fmt.Errorf("invalid sheet %q: %w", workbook.Sheets[0].Name, err)
+	}
+	var records [][]string
+	for _, row := range sheet.Rows {
+		if row.Number > xlsxMaxRows || len(records) >= xlsxMaxRows {
+			return nil, fmt.Errorf("invalid sheet %q: more than %d rows", workbook.Sheets[0].Name, xlsxMaxRows)
+		}
+		for row.Number > len(records)+1 {
+			records = append(records, nil)
+		}
+		var record []string
+		for i, cell := range row.Cells {
+			// Empty cells are left out, so the column comes from the
+			// cell's reference when it has one
+			col := xlsxColumn(cell.Ref)
+			if col < 0 {
+				col = i
+			}
+			if col >= xlsxMaxColumns {
+				return nil, fmt.Errorf("invalid sheet %q: more than %d columns", workbook.Sheets[0].Name, xlsxMaxColumns)
+			}
+			for len(record) <= col {
+				record = append(record, "")
+			}
+			switch cell.Type {
+			case "s":
+				var index int
+				if _, err := fmt.Sscan(cell.Value, &index); err == nil && index >= 0 && index < len(shared.Items) {
+					record[col] = shared.Items[index].String()
+				}
+			case "inlineStr":
+				record[col] = cell.Inline.String()
+			default:
+				record[col] = cell.Value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// xlsxColumn is the zero-based column of a cell reference such as AB12,
+// -1 for a reference without one. Columns past Excel's last are all
+// xlsxMaxColumns.
+func xlsxColumn(ref string) int {
+	col := 0
+	for _, r := range strings.ToUpper(ref) {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A') + 1
+		if col > xlsxMaxColumns {
+			return xlsxMaxColumns
+		}
+	}
+	return col - 1
+}
@@ -0,0 +1,91 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// resolveIncludes adds the requirements and aliases of the files that
+// file includes to config, loading each through the validator like file
+// itself
+func resolveIncludes(file string, config *model.RequirementConfig) error {
+	return model.ResolveIncludes(file, config, func(path string) (*model.RequirementConfig, error) {
+		included, _, err := loadRequirementsFile(path)
+		return included, err
+	})
+}
+
+// requirementsTreeHash is the SHA-256 of file and the files it includes,
+// which is what the requirements model.Load gives for file depend on
+func requirementsTreeHash(file string) (string, error) {
+	included, err := model.IncludedFiles(file)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, path := range append([]string{file}, included...) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.ToSlash(path), len(data))
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// requirementFile returns the file of the tree of file, itself or one it
+// includes, that holds the requirement ref names, so an edit goes to the
+// file the requirement is written in
+func requirementFile(file, ref string) (string, *model.RequirementDetail, error) {
+	included, err := model.IncludedFiles(file)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, path := range append([]string{file}, included...) {
+		config, err := model.LoadFile(path)
+		if err != nil {
+			return "", nil, err
+		}
+		if req, ok := config.Find(ref); ok {
+			return path, req, nil
+		}
+	}
+	return "", nil, fmt.Errorf("requirement not found: %s", ref)
+}
+
+// includeErrors checks the whole tree of a file with includes for what the
+// validator, which reads one file, can't see: summaries used in two files,
+// and references that don't resolve or go round in a cycle through them
+func includeErrors(file string) []string {
+	merged, err := model.Load(file)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var errs []string
+	seen := make(map[string]bool)
+	for _, req := range merged.Flatten() {
+		if seen[req.Summary] {
+			errs = append(errs, fmt.Sprintf("summary '%s' is used by more than one requirement of the included files", req.Summary))
+		}
+		seen[req.Summary] = true
+	}
+	cycles, err := merged.Cycles()
+	if err != nil {
+		return append(errs, err.Error())
+	}
+	for _, cycle := range cycles {
+		errs = append(errs, "circular reference through the included files among "+strings.Join(cycle, ", "))
+	}
+	return errs
+}
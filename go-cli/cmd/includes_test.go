@@ -0,0 +1,48 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeIncludeTree writes main.yml, which includes part.yml, to a
+// temporary directory, with one requirement in each, and returns the path
+// of main.yml
+func writeIncludeTree(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"main.yml": "version: \"1.0\"\nincludes: [part.yml]\nrequirements:\n  - summary: Login\n    name: REQ-1\n",
+		"part.yml": "version: \"1.0\"\naliases:\n  - alias: bob\n    name: Bob\n    email: bob@example.com\nrequirements:\n  - summary: Logout\n    name: REQ-2\n    owner: \"@bob\"\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return filepath.Join(dir, "main.yml")
+}
+
+func TestRequirementsTreeHash(t *testing.T) {
+	file := writeIncludeTree(t)
+	before, err := requirementsTreeHash(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := requirementsTreeHash(file); again != before {
+		t.Errorf("Expected the same hash for unchanged files, got %s and %s", before, again)
+	}
+
+	// Editing only the included file changes the hash
+	part := filepath.Join(filepath.Dir(file), "part.yml")
+	if err := os.WriteFile(part, []byte("version: \"1.0\"\nrequirements:\n  - summary: Sign out\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if after, err := requirementsTreeHash(file); err != nil || after == before {
+		t.Errorf("Expected a new hash after editing the included file, got %s, %v", after, err)
+	}
+}
@@ -0,0 +1,154 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/rqm/graph"
+	"github.com/238855/rqm/go-cli/pkg/rqmcore"
+)
+
+// LayoutNode is a positioned requirement in a /api/graph/layout response
+type LayoutNode struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name,omitempty"`
+	Status string  `json:"status,omitempty"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+}
+
+// LayoutEdge is a connection in a /api/graph/layout response
+type LayoutEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// GraphLayout is the /api/graph/layout response
+type GraphLayout struct {
+	Algorithm string       `json:"algorithm"`
+	Hash      string       `json:"hash"`
+	Nodes     []LayoutNode `json:"nodes"`
+	Edges     []LayoutEdge `json:"edges"`
+}
+
+// layoutAlgorithms are the values of the algorithm query parameter
+var layoutAlgorithms = map[string]bool{"force": true, "layered": true}
+
+// incrementalIterations is the number of force simulation steps used when
+// most nodes keep their position from the previous layout
+const incrementalIterations = 30
+
+// layoutCache keeps the last layout per algorithm. A request for an
+// unchanged file is answered from the cache; after an edit the force
+// layout restarts from the cached positions, so only new and moved
+// requirements shift.
+type layoutCache struct {
+	mu      sync.Mutex
+	layouts map[string]*GraphLayout
+}
+
+func newLayoutCache() *layoutCache {
+	return &layoutCache{layouts: make(map[string]*GraphLayout)}
+}
+
+// layout returns the layout of file with algorithm, computing it only when
+// the file changed since the last call
+func (c *layoutCache) layout(file, algorithm string) (*GraphLayout, error) {
+	hash, err := requirementsTreeHash(file)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	previous := c.layouts[algorithm]
+	if previous != nil && previous.Hash == hash {
+		return previous, nil
+	}
+
+	config, err := model.Load(file)
+	if err != nil {
+		return nil, err
+	}
+	result := graphLayout(config, algorithm, previous)
+	result.Hash = hash
+	c.layouts[algorithm] = result
+	return result, nil
+}
+
+// graphLayout lays out the requirement graph of config with algorithm.
+// The force layout starts from the positions of previous, when given.
+func graphLayout(config *model.RequirementConfig, algorithm string, previous *GraphLayout) *GraphLayout {
+	g := requirementGraph(config)
+
+	var positions map[string]graph.Point
+	switch algorithm {
+	case "layered":
+		positions = g.LayeredLayout()
+	default:
+		opts := graph.LayoutOptions{}
+		if previous != nil {
+			opts.Initial = make(map[string]graph.Point, len(previous.Nodes))
+			for _, node := range previous.Nodes {
+				opts.Initial[node.ID] = graph.Point{X: node.X, Y: node.Y}
+			}
+			opts.Iterations = incrementalIterations
+		}
+		positions = g.ForceLayout(opts)
+	}
+
+	result := &GraphLayout{Algorithm: algorithm, Nodes: []LayoutNode{}, Edges: []LayoutEdge{}}
+	status := make(map[string]string)
+	for _, req := range config.Flatten() {
+		status[req.Summary] = req.Status
+	}
+	for _, node := range g.Nodes() {
+		p := positions[node.ID]
+		result.Nodes = append(result.Nodes, LayoutNode{ID: node.ID, Name: node.Name, Status: status[node.ID], X: p.X, Y: p.Y})
+	}
+	for _, edge := range g.Edges() {
+		result.Edges = append(result.Edges, LayoutEdge{From: edge.From, To: edge.To, Kind: string(edge.Kind)})
+	}
+	return result
+}
+
+// requirementGraph builds the requirement graph of config: parents point
+// at their sub-requirements, including string references, and relations
+// keep their type. References to unknown requirements are left out.
+func requirementGraph(config *model.RequirementConfig) *graph.Graph {
+	return rqmcore.Graph(config)
+}
+
+// serveGraphLayout answers /api/graph/layout?algorithm=force|layered
+func serveGraphLayout(w http.ResponseWriter, r *http.Request, cache *layoutCache, reqFile string) {
+	algorithm := r.URL.Query().Get("algorithm")
+	if algorithm == "" {
+		algorithm = "force"
+	}
+	if !layoutAlgorithms[algorithm] {
+		http.Error(w, fmt.Sprintf("unknown layout algorithm: %s (expected force or layered)", algorithm), http.StatusBadRequest)
+		return
+	}
+
+	layout, err := cache.layout(reqFile, algorithm)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	etag := `"` + layout.Algorithm + "-" + layout.Hash + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(layout)
+}
@@ -0,0 +1,151 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const layoutYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: implemented
+    requirements:
+      - summary: Password hashing
+      - Session timeout
+    relations:
+      - type: depends_on
+        target: AUTH-003
+  - summary: Session timeout
+    name: AUTH-003
+`
+
+func TestRequirementGraph(t *testing.T) {
+	config, err := model.ParseYAML([]byte(layoutYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := requirementGraph(config)
+
+	if len(g.Nodes()) != 3 {
+		t.Fatalf("got %d nodes, want 3", len(g.Nodes()))
+	}
+	var got []LayoutEdge
+	for _, e := range g.Edges() {
+		got = append(got, LayoutEdge{From: e.From, To: e.To, Kind: string(e.Kind)})
+	}
+	want := []LayoutEdge{
+		{From: "Login", To: "Password hashing", Kind: "child"},
+		{From: "Login", To: "Session timeout", Kind: "child"},
+		{From: "Login", To: "Session timeout", Kind: "depends_on"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("edges = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("edge %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLayoutCache(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	if err := os.WriteFile(file, []byte(layoutYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache := newLayoutCache()
+
+	first, err := cache.layout(file, "force")
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := cache.layout(file, "force")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != again {
+		t.Error("Expected an unchanged file to be served from the cache")
+	}
+
+	// Adding a requirement relays out incrementally and keeps the rest
+	if err := os.WriteFile(file, []byte(layoutYAML+"  - summary: Audit log\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := cache.layout(file, "force")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed.Hash == first.Hash || len(changed.Nodes) != 4 {
+		t.Fatalf("Expected a new layout with 4 nodes, got hash %s with %d nodes", changed.Hash, len(changed.Nodes))
+	}
+	if changed.Nodes[0].Status != "implemented" || changed.Nodes[0].Name != "AUTH-001" {
+		t.Errorf("Expected node details to be included, got %+v", changed.Nodes[0])
+	}
+
+	layered, err := cache.layout(file, "layered")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if layered.Algorithm != "layered" || layered.Nodes[0].Y != 0 || layered.Nodes[1].Y == 0 {
+		t.Errorf("Expected Login above its children, got %+v", layered.Nodes)
+	}
+}
+
+func TestLayoutCacheIncludes(t *testing.T) {
+	file := writeIncludeTree(t)
+	cache := newLayoutCache()
+	first, err := cache.layout(file, "layered")
+	if err != nil || len(first.Nodes) != 2 {
+		t.Fatalf("Expected nodes from both files, got %+v, %v", first, err)
+	}
+	os.WriteFile(filepath.Join(filepath.Dir(file), "part.yml"), []byte("version: \"1.0\"\nrequirements: []\n"), 0644)
+	if changed, err := cache.layout(file, "layered"); err != nil || len(changed.Nodes) != 1 {
+		t.Errorf("Expected a new layout after the included file changed, got %+v, %v", changed, err)
+	}
+}
+
+func TestServeGraphLayout(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	if err := os.WriteFile(file, []byte(layoutYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache := newLayoutCache()
+
+	rec := httptest.NewRecorder()
+	serveGraphLayout(rec, httptest.NewRequest("GET", "/api/graph/layout", nil), cache, file)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var layout GraphLayout
+	if err := json.Unmarshal(rec.Body.Bytes(), &layout); err != nil {
+		t.Fatal(err)
+	}
+	if layout.Algorithm != "force" || len(layout.Nodes) != 3 || len(layout.Edges) != 3 {
+		t.Errorf("unexpected layout: %+v", layout)
+	}
+
+	req := httptest.NewRequest("GET", "/api/graph/layout", nil)
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+	rec = httptest.NewRecorder()
+	serveGraphLayout(rec, req, cache, file)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want 304 for a matching ETag", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	serveGraphLayout(rec, httptest.NewRequest("GET", "/api/graph/layout?algorithm=circle", nil), cache, file)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400 for an unknown algorithm", rec.Code)
+	}
+}
@@ -5,282 +5,706 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"os/exec"
 	"strings"
 
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/render"
 	"github.com/spf13/cobra"
 )
 
 var (
-	outputFormat string
-	showDetails  bool
+	outputFormat   string
+	showDetails    bool
+	listAttributes []string
+	listDepth      int
+	// listRoot is --root, the requirement whose subtree is listed
+	listRoot      string
+	listPage      int
+	listPageSize  int
+	listRecursive bool
+	// listDeprecated is --include-deprecated
+	listDeprecated bool
 )
 
 var listCmd = &cobra.Command{
-	Use:   "list [file]",
+	Use:   "list [file]...",
 	Short: "List all requirements from a YAML file",
 	Long: `List all requirements from a YAML file in various formats.
 	
 Displays requirements in a tree structure by default, showing:
   - Summary
   - Name/ID
-  - Owner
+  - Owner, shown as the name and email of the alias it refers to
   - Status
-  - Priority`,
-	Args: cobra.ExactArgs(1),
+  - Priority
+
+With --format table, --columns picks the columns to show (id, summary,
+description, owner, priority, status, tags, estimate, verification, hash,
+or the name of a custom attribute such as due_date) and --attributes appends
+custom attribute columns. Columns are sized to their content and shrunk to fit
+the terminal; --no-truncate prints full values instead.
+
+For large files, --depth N shows only the top N levels of the tree and
+table (1 for top-level requirements alone); deeper requirements aren't
+expanded, and the tree shows how many are hidden under each requirement
+at the last level. --root lists only the subtree of one requirement,
+named by its ID or summary, in any format, so a huge tree can be explored
+a level at a time. --page N shows the Nth page of --page-size top-level
+requirements with their sub-requirements, in any format.
+
+With --max-memory, requirements are streamed from the parser and printed
+one top-level requirement at a time instead of loading the whole file.
+
+With --changed, only the requirements added or modified since the git
+ref given by --git (HEAD by default) are shown, marked + when added, ~
+when modified and » when their status changed, under their unchanged
+parents for context. Requirements removed since are listed at the end.
+
+Deprecated requirements, those marked deprecated: true, with the
+deprecated status or a superseded_by, are hidden along with their
+sub-requirements; --include-deprecated shows them, marked.
+
+With -r, directories are searched for requirements files, skipping paths
+matched by any .rqmignore, and every file found is listed under a
+"==> file <==" header, or as one element of a JSON array.
+
+A file of - lists the requirements read from stdin; --filename gives their
+format by its extension (YAML by default). Remote files, http(s) URLs or
+git:: sources, are downloaded as for rqm validate.`,
+	Example: `  rqm list requirements.yml --depth 1
+  rqm list requirements.yml --root REQ-003 --depth 2
+  rqm list requirements.yml -f table --page 3 --page-size 50
+  rqm list -r .
+  rqm list requirements.yml --changed --git origin/main
+  generate-requirements | rqm list - -f table`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if listRecursive {
+			return cobra.MinimumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: completeRequirementsFile,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		file := args[0]
+		if cmd.Flags().Changed("git") {
+			listChanged = true
+		}
+		if err := checkListOptions(); err != nil {
+			return err
+		}
+		if listChanged && args[0] == stdinArg {
+			return fmt.Errorf("--changed compares a file with git and can't read stdin")
+		}
 
-		// Check if file exists
-		if _, err := os.Stat(file); os.IsNotExist(err) {
-			return fmt.Errorf("file does not exist: %s", file)
+		// Large files print tens of thousands of lines, which written one
+		// at a time take longer than rendering them
+		out := bufio.NewWriterSize(os.Stdout, 64*1024)
+		var err error
+		if listRecursive {
+			err = runListFiles(out, args)
+		} else {
+			err = withFileArg(args[0], func(file string) error {
+				return runList(out, file)
+			})
+		}
+		if flushErr := out.Flush(); err == nil && flushErr != nil {
+			err = fmt.Errorf("failed to write output: %w", flushErr)
 		}
+		return err
+	},
+}
+
+// checkListOptions rejects --depth and --page values list can't honour
+func checkListOptions() error {
+	switch {
+	case listDepth < 0:
+		return fmt.Errorf("invalid --depth: %d", listDepth)
+	case listPage < 0:
+		return fmt.Errorf("invalid --page: %d", listPage)
+	case listPageSize < 1:
+		return fmt.Errorf("invalid --page-size: %d", listPageSize)
+	case listDepth > 0 && outputFormat == "json":
+		return fmt.Errorf("--depth applies to tree and table output, not json")
+	case listChanged && outputFormat != "tree":
+		return fmt.Errorf("--changed applies to tree output only")
+	case listRoot != "" && listChanged:
+		return fmt.Errorf("--root can't be combined with --changed")
+	case listRoot != "" && listRecursive:
+		return fmt.Errorf("--root selects a requirement of one file and can't be combined with -r")
+	}
+	return nil
+}
 
-		// Find the rqm-validator binary
-		validatorPath := findValidatorBinary()
-		if validatorPath == "" {
-			return fmt.Errorf("rqm-validator binary not found")
+// runList loads file and writes it to w in the --format
+func runList(w io.Writer, file string) error {
+	if listChanged {
+		return displayChanged(w, file)
+	}
+	if memoryBudget > 0 {
+		return listLowMemory(w, file)
+	}
+
+	config, _, err := loadRequirements(file)
+	if err != nil {
+		return err
+	}
+	if !listDeprecated {
+		hideDeprecated(config)
+	}
+	if listRoot != "" {
+		req, ok := config.Find(listRoot)
+		if !ok {
+			return fmt.Errorf("requirement not found: %s", listRoot)
 		}
+		config.Requirements = []model.RequirementDetail{*req}
+	}
 
-		// Call rust-core validator with --format json-full flag
-		validatorCmd := exec.Command(validatorPath, file, "--format", "json-full")
-		output, _ := validatorCmd.CombinedOutput()
-		if validatorPath == "" {
-			return fmt.Errorf("failed to parse requirements: %s", string(output))
+	switch outputFormat {
+	case "json":
+		output, err := listOutput(config)
+		if err != nil {
+			return err
 		}
+		return writeJSON(output)
+	case "tree":
+		return displayTree(w, config, showDetails)
+	case "table":
+		return displayTable(w, config)
+	default:
+		return fmt.Errorf("unknown output format: %s", outputFormat)
+	}
+}
+
+// listOutput is the JSON payload for config, limited to --page
+func listOutput(config *model.RequirementConfig) (ListOutput, error) {
+	start, end, err := pageBounds(len(config.Requirements))
+	if err != nil {
+		return ListOutput{}, err
+	}
+	return ListOutput{
+		SchemaVersion: apiVersion,
+		Version:       config.Version,
+		Aliases:       config.Aliases,
+		Requirements:  nonNil(config.Requirements[start:end]),
+	}, nil
+}
 
-		// Parse the requirements
-		var config RequirementConfig
-		if jsonErr := json.Unmarshal(output, &config); jsonErr != nil {
-			return fmt.Errorf("failed to parse requirements JSON: %w", jsonErr)
+// runListFiles is list -r: the requirements files under the directories
+// in args, each under a header, or together as a JSON array
+func runListFiles(w io.Writer, args []string) error {
+	files, err := recursiveFileArgs(args)
+	if err != nil {
+		return err
+	}
+	if outputFormat == "json" {
+		outputs := []ListOutput{}
+		for _, file := range files {
+			config, _, err := loadRequirements(file)
+			if err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+			if !listDeprecated {
+				hideDeprecated(config)
+			}
+			output, err := listOutput(config)
+			if err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+			output.File = file
+			outputs = append(outputs, output)
 		}
+		return writeJSON(outputs)
+	}
 
-		// Display based on format
-		switch outputFormat {
-		case "json":
-			fmt.Println(string(output))
-		case "tree":
-			displayTree(&config, showDetails)
-		case "table":
-			displayTable(&config)
-		default:
-			return fmt.Errorf("unknown output format: %s", outputFormat)
+	for i, file := range files {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "==> %s <==\n", file)
+		if err := runList(w, file); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
 		}
+	}
+	return nil
+}
 
-		return nil
-	},
+// pageBounds returns the range of the total top-level requirements that
+// --page selects, or all of them without --page
+func pageBounds(total int) (start, end int, err error) {
+	if listPage == 0 {
+		return 0, total, nil
+	}
+	if pages := pageCount(total); listPage > pages {
+		return 0, 0, fmt.Errorf("page %d is past the last page (%d)", listPage, pages)
+	}
+	start = (listPage - 1) * listPageSize
+	return start, min(start+listPageSize, total), nil
 }
 
-type RequirementConfig struct {
-	Version      string              `json:"version"`
-	Aliases      []PersonAlias       `json:"aliases,omitempty"`
-	Requirements []RequirementDetail `json:"requirements"`
+// pageCount is the number of --page-size pages of total top-level
+// requirements; an empty file still has one, empty page
+func pageCount(total int) int {
+	return max((total+listPageSize-1)/listPageSize, 1)
 }
 
-type PersonAlias struct {
-	Alias  string `json:"alias"`
-	Name   string `json:"name"`
-	Email  string `json:"email"`
-	GitHub string `json:"github,omitempty"`
+// writePageFooter says which part of the file a --page listing showed
+func writePageFooter(w io.Writer, start, end, total int) {
+	if listPage == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nPage %d of %d (top-level requirements %d-%d of %d)\n", listPage, pageCount(total), min(start+1, end), end, total)
 }
 
-type RequirementDetail struct {
-	Summary            string                 `json:"summary"`
-	Name               string                 `json:"name,omitempty"`
-	Description        string                 `json:"description,omitempty"`
-	Justification      string                 `json:"justification,omitempty"`
-	AcceptanceTest     string                 `json:"acceptance_test,omitempty"`
-	AcceptanceTestLink string                 `json:"acceptance_test_link,omitempty"`
-	Owner              string                 `json:"owner,omitempty"`
-	Priority           string                 `json:"priority,omitempty"`
-	Status             string                 `json:"status,omitempty"`
-	Tags               []string               `json:"tags,omitempty"`
-	FurtherInformation []string               `json:"further_information,omitempty"`
-	Requirements       []RequirementReference `json:"requirements,omitempty"`
+// listedRequirements returns roots and their descendants down to --depth,
+// parents first
+func listedRequirements(roots []*model.RequirementDetail) []*model.RequirementDetail {
+	var reqs []*model.RequirementDetail
+	collect := render.VisitorFunc[*model.RequirementDetail](func(req *model.RequirementDetail, _ render.Position) error {
+		reqs = append(reqs, req)
+		return nil
+	})
+	render.Walk(roots, (*model.RequirementDetail).Children, render.MaxDepth[*model.RequirementDetail](collect, listDepth))
+	return reqs
 }
 
-// RequirementReference can be either a full requirement or a string reference
-type RequirementReference struct {
-	Full      *RequirementDetail
-	Reference string
+// loadRequirements parses a requirements file and the files it includes
+// through the rqm-validator binary, and returns the config along with the
+// JSON it stands for
+func loadRequirements(file string) (*model.RequirementConfig, []byte, error) {
+	config, output, err := loadRequirementsFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := resolveIncludes(file, config); err != nil {
+		return nil, nil, err
+	}
+	if len(config.Includes) > 0 {
+		if output, err = json.Marshal(config); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode requirements: %w", err)
+		}
+	}
+	return config, output, nil
 }
 
-// UnmarshalJSON handles both full requirements and string references
-func (r *RequirementReference) UnmarshalJSON(data []byte) error {
-	// Try to unmarshal as string first
-	var str string
-	if err := json.Unmarshal(data, &str); err == nil {
-		r.Reference = str
-		return nil
+// loadRequirementsFile parses a single requirements file through the
+// rqm-validator binary and returns the config along with the raw JSON it
+// produced. The JSON is cached in .rqm/cache like validation results.
+func loadRequirementsFile(file string) (*model.RequirementConfig, []byte, error) {
+	// Check if file exists
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("file does not exist: %s", file)
 	}
 
-	// Otherwise, unmarshal as full requirement
-	var req RequirementDetail
-	if err := json.Unmarshal(data, &req); err != nil {
-		return err
+	// Find the rqm-validator binary
+	validatorPath := findValidatorBinary()
+	if validatorPath == "" {
+		return nil, nil, fmt.Errorf("rqm-validator binary not found")
 	}
-	r.Full = &req
-	return nil
-}
 
-func displayTree(config *RequirementConfig, details bool) {
-	fmt.Printf("Requirements (v%s)\n", config.Version)
-	if len(config.Aliases) > 0 {
-		fmt.Printf("\nAliases:\n")
-		for _, alias := range config.Aliases {
-			fmt.Printf("  @%s → %s <%s>\n", alias.Alias, alias.Name, alias.Email)
+	// Reuse the validator's output for content it has already seen
+	cache := openValidationCache(file)
+	var key string
+	if cache != nil {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		key = cache.key(content, validatorID(validatorPath), "json-full")
+		if output, ok := cache.get(key); ok {
+			if config, err := model.ParseJSON(output); err == nil {
+				return config, output, nil
+			}
 		}
 	}
-	fmt.Printf("\nRequirements:\n")
-	for _, req := range config.Requirements {
-		displayRequirement(&req, "", details)
+
+	input, cleanup, err := validatorInput(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse requirements: %w", err)
 	}
+	defer cleanup()
+
+	// Call rust-core validator with --format json-full flag
+	validatorCmd := exec.Command(validatorPath, input, "--format", "json-full")
+	output, runErr := validatorCmd.CombinedOutput()
+	if runErr != nil {
+		// Parse failures are reported as a validation result
+		var result ValidationResult
+		if json.Unmarshal(output, &result) == nil && len(result.Errors) > 0 {
+			return nil, nil, fmt.Errorf("failed to parse requirements: %s", strings.Join(result.Errors, "; "))
+		}
+		return nil, nil, fmt.Errorf("failed to parse requirements: %s", string(output))
+	}
+
+	// Parse the requirements
+	config, jsonErr := model.ParseJSON(output)
+	if jsonErr != nil {
+		return nil, nil, fmt.Errorf("failed to parse requirements JSON: %w", jsonErr)
+	}
+	cache.put(key, output)
+
+	return config, output, nil
 }
 
-func displayRequirement(req *RequirementDetail, prefix string, details bool) {
-	// Display summary and basic info
-	name := req.Name
-	if name == "" {
-		name = "unnamed"
+// listPager picks out the top-level requirements --page selects while
+// they stream past, counting them all
+type listPager struct {
+	start, end, total int
+}
+
+func newListPager() *listPager {
+	if listPage == 0 {
+		return &listPager{end: math.MaxInt}
 	}
+	start := (listPage - 1) * listPageSize
+	return &listPager{start: start, end: start + listPageSize}
+}
 
-	statusSymbol := getStatusSymbol(req.Status)
-	priorityColor := getPriorityIndicator(req.Priority)
+// next counts another top-level requirement and reports whether it is on
+// the page
+func (p *listPager) next() bool {
+	p.total++
+	return p.total > p.start && p.total <= p.end
+}
 
-	fmt.Printf("%s%s [%s] %s %s\n", prefix, statusSymbol, name, req.Summary, priorityColor)
+// footer checks that the page existed and writes the page footer
+func (p *listPager) footer(w io.Writer) error {
+	start, end, err := pageBounds(p.total)
+	if err != nil {
+		return err
+	}
+	writePageFooter(w, start, end, p.total)
+	return nil
+}
 
-	if details {
-		if req.Owner != "" {
-			fmt.Printf("%s  Owner: %s\n", prefix, req.Owner)
+// listLowMemory is the --max-memory variant of list: requirements are
+// printed as they are decoded instead of loading the whole file first
+func listLowMemory(w io.Writer, file string) error {
+	pager := newListPager()
+	root := &streamedRoot{ref: listRoot}
+	switch outputFormat {
+	case "json":
+		if err := streamListJSON(w, file, pager, root); err != nil {
+			return err
 		}
-		if req.Description != "" {
-			desc := strings.Split(strings.TrimSpace(req.Description), "\n")[0]
-			if len(desc) > 80 {
-				desc = desc[:77] + "..."
+		if err := root.check(); err != nil {
+			return err
+		}
+		_, _, err := pageBounds(pager.total)
+		return err
+	case "tree":
+		err := streamRequirementsFile(file, func(version string, aliases []model.PersonAlias) {
+			ownerAliases = aliases
+			displayTreeHeader(w, version, aliases)
+		}, func(req *model.RequirementDetail) error {
+			if !listedStreamed(req) {
+				return nil
+			}
+			if req = root.pick(req); req == nil || !pager.next() {
+				return nil
 			}
-			fmt.Printf("%s  Description: %s\n", prefix, desc)
+			return displayRequirement(w, req, showDetails)
+		})
+		if err != nil {
+			return err
 		}
-		if len(req.Tags) > 0 {
-			fmt.Printf("%s  Tags: %s\n", prefix, strings.Join(req.Tags, ", "))
+		if err := root.check(); err != nil {
+			return err
 		}
-	}
-
-	// Display sub-requirements
-	for i, childRef := range req.Requirements {
-		// Skip string references for now
-		if childRef.Full == nil {
-			continue
+		return pager.footer(w)
+	case "table":
+		// Rows aren't known up front, so columns keep their preferred widths
+		table, err := newRequirementTable()
+		if err != nil {
+			return err
 		}
-		child := childRef.Full
-
-		isLast := i == len(req.Requirements)-1
-		var newPrefix string
-		if isLast {
-			newPrefix = prefix + "  └─ "
-		} else {
-			newPrefix = prefix + "  ├─ "
+		table.fit(nil, terminalWidth())
+		err = streamRequirementsFile(file, func(_ string, aliases []model.PersonAlias) {
+			ownerAliases = aliases
+			table.printHeader(w)
+		}, func(req *model.RequirementDetail) error {
+			if !listedStreamed(req) {
+				return nil
+			}
+			if req = root.pick(req); req == nil || !pager.next() {
+				return nil
+			}
+			for _, r := range listedRequirements([]*model.RequirementDetail{req}) {
+				table.printRow(w, r)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
-		childPrefix := prefix + "     "
-		if !isLast {
-			childPrefix = prefix + "  │  "
+		if err := root.check(); err != nil {
+			return err
 		}
-
-		// Adjust prefix for recursion
-		displayRequirementWithPrefix(child, newPrefix, childPrefix, details)
+		return pager.footer(w)
+	default:
+		return fmt.Errorf("unknown output format: %s", outputFormat)
 	}
 }
 
-func displayRequirementWithPrefix(req *RequirementDetail, linePrefix, childPrefix string, details bool) {
-	name := req.Name
-	if name == "" {
-		name = "unnamed"
+// listedStreamed reports whether a streamed top-level requirement is
+// listed, hiding deprecated ones and their sub-requirements unless
+// --include-deprecated is given
+func listedStreamed(req *model.RequirementDetail) bool {
+	if listDeprecated {
+		return true
+	}
+	if req.IsDeprecated() {
+		return false
 	}
+	hideDeprecatedChildren(req)
+	return true
+}
+
+// streamedRoot finds the --root requirement among streamed top-level
+// requirements. Each is searched by ID, then by summary, as it arrives;
+// unlike config.Find, an ID later in the file doesn't win over a summary
+// matched earlier.
+type streamedRoot struct {
+	ref   string
+	found bool
+}
 
-	statusSymbol := getStatusSymbol(req.Status)
-	priorityColor := getPriorityIndicator(req.Priority)
+// pick returns what to list of a streamed top-level requirement: itself
+// without --root, the --root requirement when it is in req's subtree, or
+// nil
+func (r *streamedRoot) pick(req *model.RequirementDetail) *model.RequirementDetail {
+	if r.ref == "" {
+		return req
+	}
+	if r.found {
+		return nil
+	}
+	var bySummary *model.RequirementDetail
+	for _, candidate := range req.Flatten() {
+		if candidate.Name == r.ref {
+			bySummary = candidate
+			break
+		}
+		if candidate.Summary == r.ref && bySummary == nil {
+			bySummary = candidate
+		}
+	}
+	r.found = bySummary != nil
+	return bySummary
+}
 
-	fmt.Printf("%s%s [%s] %s %s\n", linePrefix, statusSymbol, name, req.Summary, priorityColor)
+// check reports a --root that no streamed requirement matched
+func (r *streamedRoot) check() error {
+	if r.ref != "" && !r.found {
+		return fmt.Errorf("requirement not found: %s", r.ref)
+	}
+	return nil
+}
 
-	if details {
-		if req.Owner != "" {
-			fmt.Printf("%s  Owner: %s\n", childPrefix, req.Owner)
+// streamListJSON writes the same payload as list --format json, encoding
+// one top-level requirement at a time
+func streamListJSON(w io.Writer, file string, pager *listPager, root *streamedRoot) error {
+	first := true
+	err := streamRequirementsFile(file, func(version string, aliases []model.PersonAlias) {
+		header, _ := json.Marshal(struct {
+			SchemaVersion int                 `json:"schema_version"`
+			Version       string              `json:"version"`
+			Aliases       []model.PersonAlias `json:"aliases,omitempty"`
+		}{apiVersion, version, aliases})
+		// Reopen the header object to append the requirements array
+		fmt.Fprintf(w, "%s,\"requirements\":[", header[:len(header)-1])
+	}, func(req *model.RequirementDetail) error {
+		if !listedStreamed(req) {
+			return nil
 		}
-		if req.Description != "" {
-			desc := strings.Split(strings.TrimSpace(req.Description), "\n")[0]
-			if len(desc) > 80 {
-				desc = desc[:77] + "..."
-			}
-			fmt.Printf("%s  Description: %s\n", childPrefix, desc)
+		if req = root.pick(req); req == nil || !pager.next() {
+			return nil
+		}
+		data, err := json.Marshal(req)
+		if err != nil {
+			return err
 		}
-		if len(req.Tags) > 0 {
-			fmt.Printf("%s  Tags: %s\n", childPrefix, strings.Join(req.Tags, ", "))
+		if !first {
+			fmt.Fprint(w, ",")
 		}
+		first = false
+		_, err = w.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
 	}
+	fmt.Fprintln(w, "]}")
+	return nil
+}
 
-	// Display sub-requirements recursively
-	for i, childRef := range req.Requirements {
-		// Skip string references for now
-		if childRef.Full == nil {
-			continue
-		}
-		child := childRef.Full
+// displayTree writes the --page of config as a tree, down to --depth
+func displayTree(w io.Writer, config *model.RequirementConfig, details bool) error {
+	roots := config.Roots()
+	start, end, err := pageBounds(len(roots))
+	if err != nil {
+		return err
+	}
 
-		isLast := i == len(req.Requirements)-1
-		var newLinePrefix, newChildPrefix string
-		if isLast {
-			newLinePrefix = childPrefix + "└─ "
-			newChildPrefix = childPrefix + "   "
-		} else {
-			newLinePrefix = childPrefix + "├─ "
-			newChildPrefix = childPrefix + "│  "
+	ownerAliases = config.Aliases
+	displayTreeHeader(w, config.Version, config.Aliases)
+	tree := depthLimited(requirementTree(w, details))
+	if err := render.Walk(roots[start:end], (*model.RequirementDetail).Children, tree); err != nil {
+		return err
+	}
+	writePageFooter(w, start, end, len(roots))
+	return nil
+}
+
+func displayTreeHeader(w io.Writer, version string, aliases []model.PersonAlias) {
+	fmt.Fprintf(w, "Requirements (v%s)\n", version)
+	if len(aliases) > 0 {
+		fmt.Fprintf(w, "\nAliases:\n")
+		for _, alias := range aliases {
+			fmt.Fprintf(w, "  @%s → %s <%s>\n", alias.Alias, alias.Name, alias.Email)
 		}
-		displayRequirementWithPrefix(child, newLinePrefix, newChildPrefix, details)
 	}
+	fmt.Fprintf(w, "\nRequirements:\n")
 }
 
-func displayTable(config *RequirementConfig) {
-	fmt.Printf("%-20s %-50s %-15s %-12s %-15s\n", "ID", "Summary", "Owner", "Priority", "Status")
-	fmt.Println(strings.Repeat("-", 115))
+// displayRequirement writes req and its sub-requirements, down to --depth,
+// as a tree
+func displayRequirement(w io.Writer, req *model.RequirementDetail, details bool) error {
+	tree := depthLimited(requirementTree(w, details))
+	return render.Walk([]*model.RequirementDetail{req}, (*model.RequirementDetail).Children, tree)
+}
 
-	for _, req := range config.Requirements {
-		displayRequirementRow(&req)
+// depthLimited limits tree to --depth levels, noting on each requirement
+// of the last level how many requirements below it are hidden
+func depthLimited(tree *render.Tree[*model.RequirementDetail]) render.Visitor[*model.RequirementDetail] {
+	if listDepth <= 0 {
+		return tree
+	}
+	hidden := 0
+	line := tree.Line
+	// The wrapped Line replaces the faster AppendLine
+	tree.AppendLine = nil
+	tree.Line = func(req *model.RequirementDetail) string {
+		if hidden == 0 {
+			return line(req)
+		}
+		return strings.TrimRight(line(req), " ") + " " + paint(ansiDim, fmt.Sprintf("(+%d hidden)", hidden))
 	}
+	return render.VisitorFunc[*model.RequirementDetail](func(req *model.RequirementDetail, pos render.Position) error {
+		hidden = 0
+		last := pos.Depth >= listDepth-1
+		if last {
+			hidden = len(req.Flatten()) - 1
+		}
+		if err := tree.Visit(req, pos); err != nil || !last {
+			return err
+		}
+		return render.SkipChildren
+	})
 }
 
-func displayRequirementRow(req *RequirementDetail) {
+// requirementTree is the list --format tree renderer; details adds the
+// owner, description, tags and relations under each requirement
+func requirementTree(w io.Writer, details bool) *render.Tree[*model.RequirementDetail] {
+	tree := &render.Tree[*model.RequirementDetail]{
+		W: w,
+		Line: func(req *model.RequirementDetail) string {
+			return string(appendRequirementLine(nil, req))
+		},
+		AppendLine: appendRequirementLine,
+	}
+	if details {
+		tree.Details = requirementDetailLines
+	}
+	return tree
+}
+
+// appendRequirementLine appends req's line of the tree to dst: its status
+// symbol, ID, summary and priority
+func appendRequirementLine(dst []byte, req *model.RequirementDetail) []byte {
 	name := req.Name
 	if name == "" {
-		name = "-"
+		name = "unnamed"
 	}
-	summary := req.Summary
-	if len(summary) > 48 {
-		summary = summary[:45] + "..."
+	dst = append(dst, paint(statusColor(req.Status), getStatusSymbol(req.Status))...)
+	dst = append(dst, " ["...)
+	dst = append(dst, name...)
+	dst = append(dst, "] "...)
+	dst = append(dst, req.Summary...)
+	dst = append(dst, ' ')
+	dst = append(dst, getPriorityIndicator(req.Priority)...)
+	if note := deprecationNote(req); note != "" {
+		dst = append(dst, ' ')
+		dst = append(dst, paint(ansiDim, note)...)
 	}
-	owner := req.Owner
-	if owner == "" {
-		owner = "-"
+	return dst
+}
+
+// requirementDetailLines are the lines list --details prints under a requirement
+func requirementDetailLines(req *model.RequirementDetail) []string {
+	var lines []string
+	if req.Owner != "" {
+		lines = append(lines, "Owner: "+displayOwner(ownerAliases, req.Owner))
 	}
-	priority := req.Priority
-	if priority == "" {
-		priority = "-"
+	if req.Description != "" {
+		desc := strings.Split(strings.TrimSpace(req.Description), "\n")[0]
+		if len(desc) > 80 {
+			desc = desc[:77] + "..."
+		}
+		lines = append(lines, "Description: "+desc)
 	}
-	status := req.Status
-	if status == "" {
-		status = "-"
+	if len(req.Tags) > 0 {
+		lines = append(lines, "Tags: "+strings.Join(req.Tags, ", "))
 	}
+	for _, rel := range req.Relations {
+		lines = append(lines, fmt.Sprintf("%s: %s", rel.Type, rel.Target))
+	}
+	return lines
+}
 
-	fmt.Printf("%-20s %-50s %-15s %-12s %-15s\n", name, summary, owner, priority, status)
+// displayTable writes the --page of config as a table, down to --depth
+func displayTable(w io.Writer, config *model.RequirementConfig) error {
+	table, err := newRequirementTable()
+	if err != nil {
+		return err
+	}
+	roots := config.Roots()
+	start, end, err := pageBounds(len(roots))
+	if err != nil {
+		return err
+	}
+	ownerAliases = config.Aliases
+	reqs := listedRequirements(roots[start:end])
+	table.fit(reqs, terminalWidth())
 
-	// Display sub-requirements
-	for _, childRef := range req.Requirements {
-		// Skip string references for now
-		if childRef.Full == nil {
-			continue
+	table.printHeader(w)
+	for _, req := range reqs {
+		table.printRow(w, req)
+	}
+	// Only --depth hides requirements, so without it there's nothing to count
+	if listDepth > 0 {
+		if hidden := len(render.Flatten(roots[start:end], (*model.RequirementDetail).Children)) - len(reqs); hidden > 0 {
+			fmt.Fprintf(w, "\n%d requirement(s) below --depth %d hidden\n", hidden, listDepth)
 		}
-		displayRequirementRow(childRef.Full)
+	}
+	writePageFooter(w, start, end, len(roots))
+	return nil
+}
+
+// displayRequirementRow writes req and its sub-requirements using the
+// preferred column widths
+func displayRequirementRow(w io.Writer, req *model.RequirementDetail) {
+	table, err := newRequirementTable()
+	if err != nil {
+		return
+	}
+	for _, r := range req.Flatten() {
+		table.printRow(w, r)
 	}
 }
 
@@ -299,16 +723,12 @@ func getStatusSymbol(status string) string {
 	}
 }
 
+// getPriorityIndicator returns the priority as a label, colored when color
+// output is enabled, so it stays readable in plain logs
 func getPriorityIndicator(priority string) string {
 	switch priority {
-	case "critical":
-		return "🔴"
-	case "high":
-		return "🟠"
-	case "medium":
-		return "🟡"
-	case "low":
-		return "🟢"
+	case "critical", "high", "medium", "low":
+		return paint(priorityColor(priority), "("+priority+")")
 	default:
 		return ""
 	}
@@ -318,4 +738,17 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.Flags().StringVarP(&outputFormat, "format", "f", "tree", "Output format (tree, table, json)")
 	listCmd.Flags().BoolVarP(&showDetails, "details", "d", false, "Show detailed information")
+	listCmd.Flags().StringSliceVar(&listAttributes, "attributes", nil, "Custom attributes to add as table columns (comma-separated)")
+	listCmd.Flags().StringSliceVar(&listColumns, "columns", nil, "Table columns to show (default id,summary,owner,priority,status)")
+	listCmd.Flags().BoolVar(&noTruncate, "no-truncate", false, "Show full values in table output instead of fitting the terminal")
+	listCmd.Flags().IntVar(&listDepth, "depth", 0, "Show only this many levels of the tree (0 for all)")
+	listCmd.Flags().StringVar(&listRoot, "root", "", "List only this requirement, by ID or summary, and its sub-requirements")
+	listCmd.RegisterFlagCompletionFunc("root", completeRequirementIDFlag)
+	listCmd.Flags().IntVar(&listPage, "page", 0, "Show only this page of top-level requirements (from 1)")
+	listCmd.Flags().IntVar(&listPageSize, "page-size", 100, "Top-level requirements per --page")
+	listCmd.Flags().BoolVar(&listChanged, "changed", false, "Show only the requirements changed since the --git ref")
+	listCmd.Flags().StringVar(&listGitRef, "git", "HEAD", "Git ref --changed compares against")
+	listCmd.Flags().StringVar(&stdinFilename, "filename", "", "Name to show for requirements read from stdin (-); its extension gives their format")
+	listCmd.Flags().BoolVar(&listDeprecated, "include-deprecated", false, "Also list deprecated and superseded requirements")
+	listCmd.Flags().BoolVarP(&listRecursive, "recursive", "r", false, "List the requirements files found under directory arguments, honoring .rqmignore")
 }
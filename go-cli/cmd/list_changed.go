@@ -0,0 +1,159 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/render"
+)
+
+var (
+	listChanged bool
+	listGitRef  string
+)
+
+// changeMarkers are the list --changed markers, in the order of the legend
+var changeMarkers = []struct {
+	change, symbol, color string
+}{
+	{"added", "+", ansiGreen},
+	{"modified", "~", ansiYellow},
+	{"status changed", "»", ansiCyan},
+}
+
+// changedTree narrows the requirement tree to what changed since a ref:
+// the requirements touched and, for context, their unchanged ancestors
+type changedTree struct {
+	// change is added, modified or status changed for each touched
+	// requirement
+	change map[*model.RequirementDetail]string
+	// transition is "old → new" for requirements whose status changed
+	transition map[*model.RequirementDetail]string
+	// touched holds the touched requirements and their ancestors
+	touched map[*model.RequirementDetail]bool
+}
+
+// newChangedTree marks the requirements of current that changes added or
+// changed
+func newChangedTree(current *model.RequirementConfig, changes requirementChanges) *changedTree {
+	t := &changedTree{
+		change:     make(map[*model.RequirementDetail]string),
+		transition: make(map[*model.RequirementDetail]string),
+		touched:    make(map[*model.RequirementDetail]bool),
+	}
+	for _, req := range changes.Added {
+		t.change[req] = "added"
+	}
+	for _, c := range changes.Changed {
+		t.change[c.New] = "modified"
+		if slices.Contains(c.Fields, "status") {
+			t.change[c.New] = "status changed"
+			t.transition[c.New] = orUnset(c.Old.Status) + " → " + orUnset(c.New.Status)
+		}
+	}
+
+	var mark func(req *model.RequirementDetail) bool
+	mark = func(req *model.RequirementDetail) bool {
+		touched := t.change[req] != ""
+		for _, child := range req.Children() {
+			// Every child is visited so that all touched ones are marked
+			if mark(child) {
+				touched = true
+			}
+		}
+		t.touched[req] = touched
+		return touched
+	}
+	for _, root := range current.Roots() {
+		mark(root)
+	}
+	return t
+}
+
+// filter keeps the requirements of reqs that are or contain a change
+func (t *changedTree) filter(reqs []*model.RequirementDetail) []*model.RequirementDetail {
+	var kept []*model.RequirementDetail
+	for _, req := range reqs {
+		if t.touched[req] {
+			kept = append(kept, req)
+		}
+	}
+	return kept
+}
+
+// children is the children function for render.Walk over the narrowed tree
+func (t *changedTree) children(req *model.RequirementDetail) []*model.RequirementDetail {
+	return t.filter(req.Children())
+}
+
+// marker is the change marker of req, blank for an unchanged ancestor
+func (t *changedTree) marker(req *model.RequirementDetail) string {
+	for _, m := range changeMarkers {
+		if m.change == t.change[req] {
+			return paint(m.color, m.symbol)
+		}
+	}
+	return " "
+}
+
+// displayChanged is list --changed: the tree narrowed to the requirements
+// of file changed since listGitRef, each marked with the kind of change,
+// and the requirements removed since
+func displayChanged(w io.Writer, file string) error {
+	current, changes, err := compareWithRef(listGitRef, file)
+	if err != nil {
+		return err
+	}
+	ownerAliases = current.Aliases
+
+	fmt.Fprintf(w, "Requirements changed since %s: %d added, %d modified, %d removed\n",
+		listGitRef, len(changes.Added), len(changes.Changed), len(changes.Removed))
+	if len(changes.Added)+len(changes.Changed)+len(changes.Removed) == 0 {
+		return nil
+	}
+	fmt.Fprint(w, "\n")
+	for i, m := range changeMarkers {
+		if i > 0 {
+			fmt.Fprint(w, "  ")
+		}
+		fmt.Fprintf(w, "%s %s", paint(m.color, m.symbol), m.change)
+	}
+	fmt.Fprint(w, "\n\n")
+
+	changed := newChangedTree(current, changes)
+	roots := changed.filter(current.Roots())
+	start, end, err := pageBounds(len(roots))
+	if err != nil {
+		return err
+	}
+	tree := requirementTree(w, showDetails)
+	line := tree.Line
+	// The wrapped Line replaces the faster AppendLine
+	tree.AppendLine = nil
+	tree.Line = func(req *model.RequirementDetail) string {
+		s := changed.marker(req) + " " + line(req)
+		if transition := changed.transition[req]; transition != "" {
+			s += " (" + transition + ")"
+		}
+		return s
+	}
+	visitor := render.MaxDepth[*model.RequirementDetail](tree, listDepth)
+	if err := render.Walk(roots[start:end], changed.children, visitor); err != nil {
+		return err
+	}
+	writePageFooter(w, start, end, len(roots))
+
+	if len(changes.Removed) > 0 {
+		fmt.Fprintf(w, "\nRemoved:\n")
+		for _, req := range changes.Removed {
+			fmt.Fprintf(w, "%s %s\n", paint(ansiRed, "-"), displayName(req))
+		}
+	}
+	return nil
+}
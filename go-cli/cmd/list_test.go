@@ -7,11 +7,16 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/render"
+	"go.yaml.in/yaml/v3"
 )
 
 func TestListCommand(t *testing.T) {
@@ -107,7 +112,7 @@ requirements:
 			format:      "json",
 			expectError: false,
 			checkOutput: func(t *testing.T, output string) {
-				var config RequirementConfig
+				var config model.RequirementConfig
 				if err := json.Unmarshal([]byte(output), &config); err != nil {
 					t.Errorf("Expected valid JSON output, got error: %v", err)
 				}
@@ -192,10 +197,10 @@ func TestGetPriorityIndicator(t *testing.T) {
 		priority string
 		expected string
 	}{
-		{"critical", "🔴"},
-		{"high", "🟠"},
-		{"medium", "🟡"},
-		{"low", "🟢"},
+		{"critical", "(critical)"},
+		{"high", "(high)"},
+		{"medium", "(medium)"},
+		{"low", "(low)"},
 		{"unknown", ""},
 		{"", ""},
 	}
@@ -241,7 +246,7 @@ func TestRequirementReference_UnmarshalJSON(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var ref RequirementReference
+			var ref model.RequirementReference
 			err := json.Unmarshal([]byte(tt.json), &ref)
 
 			if tt.expectError {
@@ -268,9 +273,9 @@ func TestRequirementReference_UnmarshalJSON(t *testing.T) {
 }
 
 func TestDisplayTree(t *testing.T) {
-	config := &RequirementConfig{
+	config := &model.RequirementConfig{
 		Version: "1.0",
-		Requirements: []RequirementDetail{
+		Requirements: []model.RequirementDetail{
 			{
 				Summary: "Parent Requirement",
 				Name:    "PARENT-001",
@@ -285,7 +290,7 @@ func TestDisplayTree(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	displayTree(config, false)
+	displayTree(os.Stdout, config, false)
 
 	w.Close()
 	os.Stdout = old
@@ -306,16 +311,16 @@ func TestDisplayTree(t *testing.T) {
 }
 
 func TestDisplayTreeWithAliases(t *testing.T) {
-	config := &RequirementConfig{
+	config := &model.RequirementConfig{
 		Version: "1.0",
-		Aliases: []PersonAlias{
+		Aliases: []model.PersonAlias{
 			{
 				Alias: "dev",
 				Name:  "Developer",
 				Email: "dev@example.com",
 			},
 		},
-		Requirements: []RequirementDetail{},
+		Requirements: []model.RequirementDetail{},
 	}
 
 	// Capture stdout
@@ -323,7 +328,7 @@ func TestDisplayTreeWithAliases(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	displayTree(config, false)
+	displayTree(os.Stdout, config, false)
 
 	w.Close()
 	os.Stdout = old
@@ -344,7 +349,7 @@ func TestDisplayTreeWithAliases(t *testing.T) {
 }
 
 func TestDisplayRequirement(t *testing.T) {
-	req := &RequirementDetail{
+	req := &model.RequirementDetail{
 		Summary:     "Test Requirement",
 		Name:        "TEST-001",
 		Description: "This is a test requirement with a description",
@@ -359,7 +364,7 @@ func TestDisplayRequirement(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	displayRequirement(req, "", true)
+	displayRequirement(os.Stdout, req, true)
 
 	w.Close()
 	os.Stdout = old
@@ -383,7 +388,7 @@ func TestDisplayRequirement(t *testing.T) {
 }
 
 func TestDisplayRequirementWithoutDetails(t *testing.T) {
-	req := &RequirementDetail{
+	req := &model.RequirementDetail{
 		Summary:     "Test Requirement",
 		Name:        "TEST-001",
 		Description: "This is a test requirement",
@@ -395,7 +400,7 @@ func TestDisplayRequirementWithoutDetails(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	displayRequirement(req, "", false)
+	displayRequirement(os.Stdout, req, false)
 
 	w.Close()
 	os.Stdout = old
@@ -413,8 +418,8 @@ func TestDisplayRequirementWithoutDetails(t *testing.T) {
 }
 
 func TestDisplayTable(t *testing.T) {
-	config := &RequirementConfig{
-		Requirements: []RequirementDetail{
+	config := &model.RequirementConfig{
+		Requirements: []model.RequirementDetail{
 			{
 				Summary:  "Test Requirement",
 				Name:     "TEST-001",
@@ -430,7 +435,7 @@ func TestDisplayTable(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	displayTable(config)
+	displayTable(os.Stdout, config)
 
 	w.Close()
 	os.Stdout = old
@@ -454,7 +459,7 @@ func TestDisplayTable(t *testing.T) {
 }
 
 func TestDisplayRequirementRow(t *testing.T) {
-	req := &RequirementDetail{
+	req := &model.RequirementDetail{
 		Summary:  "Test Requirement with a very long summary that should be truncated to fit in the table column width",
 		Name:     "TEST-001",
 		Owner:    "test@example.com",
@@ -467,7 +472,7 @@ func TestDisplayRequirementRow(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	displayRequirementRow(req)
+	displayRequirementRow(os.Stdout, req)
 
 	w.Close()
 	os.Stdout = old
@@ -490,7 +495,7 @@ func TestDisplayRequirementRow(t *testing.T) {
 }
 
 func TestDisplayRequirementRowWithMissingFields(t *testing.T) {
-	req := &RequirementDetail{
+	req := &model.RequirementDetail{
 		Summary: "Minimal Requirement",
 		// All other fields empty
 	}
@@ -500,7 +505,7 @@ func TestDisplayRequirementRowWithMissingFields(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	displayRequirementRow(req)
+	displayRequirementRow(os.Stdout, req)
 
 	w.Close()
 	os.Stdout = old
@@ -514,3 +519,348 @@ func TestDisplayRequirementRowWithMissingFields(t *testing.T) {
 		t.Errorf("Expected '-' for missing fields, got: %s", output)
 	}
 }
+
+func TestListDepthAndPage(t *testing.T) {
+	defer func() { listDepth, listPage, listPageSize = 0, 0, 100 }()
+	config := benchmarkConfig(120)
+
+	listDepth, listPage, listPageSize = 2, 2, 1
+	var tree bytes.Buffer
+	if err := displayTree(&tree, config, false); err != nil {
+		t.Fatal(err)
+	}
+	output := tree.String()
+	for _, want := range []string{"[REQ-00041] Requirement 41", "[REQ-00042]", "Page 2 of 3 (top-level requirements 2-2 of 3)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+	if !strings.Contains(output, "Requirement 42 (high) (+12 hidden)") {
+		t.Errorf("Expected the hidden count of REQ-00042:\n%s", output)
+	}
+	// The first page, and grandchildren below --depth, are left out
+	for _, unwanted := range []string{"[REQ-00001]", "[REQ-00043]"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("Unexpected %q in output:\n%s", unwanted, output)
+		}
+	}
+
+	listDepth, listPage, listPageSize = 1, 1, 2
+	var table bytes.Buffer
+	if err := displayTable(&table, config); err != nil {
+		t.Fatal(err)
+	}
+	// Header, separator, two rows, then the hidden count and the page
+	// footer, each after a blank line
+	if lines := strings.Split(strings.TrimSpace(table.String()), "\n"); len(lines) != 8 || lines[5] != "78 requirement(s) below --depth 1 hidden" {
+		t.Errorf("Expected two rows, got:\n%s", table.String())
+	}
+
+	listPage = 3
+	if err := displayTable(io.Discard, config); err == nil || !strings.Contains(err.Error(), "past the last page (2)") {
+		t.Errorf("Expected a past-the-last-page error, got %v", err)
+	}
+}
+
+// benchmarkConfig builds n requirements in trees of 40 (a root, 3
+// children, 9 grandchildren and 27 great-grandchildren)
+func benchmarkConfig(n int) *model.RequirementConfig {
+	config := &model.RequirementConfig{Version: "1.0"}
+	count := 0
+	var build func(depth int) model.RequirementDetail
+	build = func(depth int) model.RequirementDetail {
+		count++
+		req := model.RequirementDetail{
+			Summary:     fmt.Sprintf("Requirement %d", count),
+			Name:        fmt.Sprintf("REQ-%05d", count),
+			Owner:       "alice@example.com",
+			Priority:    "high",
+			Status:      "implemented",
+			Description: "The system shall handle this requirement.",
+		}
+		for i := 0; depth < 3 && i < 3; i++ {
+			child := build(depth + 1)
+			req.Requirements = append(req.Requirements, model.RequirementReference{Full: &child})
+		}
+		return req
+	}
+	for count < n {
+		config.Requirements = append(config.Requirements, build(0))
+	}
+	return config
+}
+
+// benchmarkListFile writes the 50k requirements of benchmarkConfig to a
+// requirements file and points stdout at a pipe whose other end is
+// drained, as when list output goes to less or a file. The validator
+// cache is off, so every run parses the file.
+func benchmarkListFile(b *testing.B) string {
+	validator := findValidatorBinary()
+	if validator == "" {
+		b.Skip("rqm-validator not built")
+	}
+	b.Setenv("RQM_VALIDATOR", validator)
+
+	data, err := yaml.Marshal(benchmarkConfig(50000))
+	if err != nil {
+		b.Fatal(err)
+	}
+	file := filepath.Join(b.TempDir(), "requirements.yml")
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		b.Fatal(err)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		b.Fatal(err)
+	}
+	go io.Copy(io.Discard, r)
+	stdout := os.Stdout
+	os.Stdout, noCache = w, true
+	b.Cleanup(func() {
+		os.Stdout, noCache = stdout, false
+		w.Close()
+	})
+	return file
+}
+
+// benchmarkList runs rqm list --format format end to end on the 50k
+// requirements, and the implementation it replaced on the same file
+func benchmarkList(b *testing.B, format string, previous func(file string) error) {
+	file := benchmarkListFile(b)
+	outputFormat = format
+	b.Cleanup(func() { outputFormat = "tree" })
+
+	b.Run("list", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := listCmd.RunE(listCmd, []string{file}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("previous", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := previous(file); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// On one machine, a run of list took 2.06s for the tree and 2.23s for the
+// table, against 2.15s and 2.49s the previous way: loading the file,
+// through the validator and then decoding its JSON, takes most of the time.
+func BenchmarkListTree(b *testing.B) {
+	benchmarkList(b, "tree", previousListTree)
+}
+
+func BenchmarkListTable(b *testing.B) {
+	benchmarkList(b, "table", previousListTable)
+}
+
+// previousListTree is list --format tree as it was before its output was
+// buffered: the file loaded the same way, then every line formatted with
+// fmt and written to stdout on its own
+func previousListTree(file string) error {
+	config, _, err := loadRequirements(file)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Requirements (v%s)\n", config.Version)
+	if len(config.Aliases) > 0 {
+		fmt.Printf("\nAliases:\n")
+		for _, alias := range config.Aliases {
+			fmt.Printf("  @%s → %s <%s>\n", alias.Alias, alias.Name, alias.Email)
+		}
+	}
+	fmt.Printf("\nRequirements:\n")
+	return render.Walk(config.Roots(), (*model.RequirementDetail).Children, render.VisitorFunc[*model.RequirementDetail](
+		func(req *model.RequirementDetail, pos render.Position) error {
+			guide, _ := pos.Guides()
+			name := req.Name
+			if name == "" {
+				name = "unnamed"
+			}
+			statusSymbol := paint(statusColor(req.Status), getStatusSymbol(req.Status))
+			line := fmt.Sprintf("%s [%s] %s %s", statusSymbol, name, req.Summary, getPriorityIndicator(req.Priority))
+			_, err := fmt.Fprintf(os.Stdout, "%s%s\n", guide, line)
+			return err
+		}))
+}
+
+// previousListTable is list --format table as it was before its output
+// was buffered: the file loaded the same way, then every cell padded,
+// colored and written to stdout with fmt on its own
+func previousListTable(file string) error {
+	config, _, err := loadRequirements(file)
+	if err != nil {
+		return err
+	}
+	table, err := newRequirementTable()
+	if err != nil {
+		return err
+	}
+	reqs := config.Flatten()
+	table.fit(reqs, terminalWidth())
+
+	printCells := func(cells, colors []string) {
+		for i, cell := range cells {
+			if table.truncate {
+				cell = truncateCell(cell, table.widths[i])
+			}
+			if i > 0 {
+				fmt.Fprint(os.Stdout, " ")
+			}
+			if i < len(cells)-1 {
+				cell += strings.Repeat(" ", max(table.widths[i]-len([]rune(cell)), 0))
+			}
+			if colors != nil {
+				cell = paint(colors[i], cell)
+			}
+			fmt.Fprint(os.Stdout, cell)
+		}
+		fmt.Fprintln(os.Stdout)
+	}
+	headers := make([]string, len(table.columns))
+	for i, column := range table.columns {
+		headers[i] = column.header
+	}
+	printCells(headers, nil)
+	fmt.Fprintln(os.Stdout, strings.Repeat("-", table.totalWidth()))
+	for _, req := range reqs {
+		cells := make([]string, len(table.columns))
+		colors := make([]string, len(table.columns))
+		for i, column := range table.columns {
+			cells[i] = cellValue(column, req)
+			if column.color != nil {
+				colors[i] = column.color(req)
+			}
+		}
+		printCells(cells, colors)
+	}
+	return nil
+}
+
+func TestDisplayChanged(t *testing.T) {
+	gitTestRepo(t)
+	gitCommitFiles(t, "add requirements", map[string]string{"requirements.yml": `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: draft
+    requirements:
+      - summary: Password hashing
+        name: AUTH-002
+        status: draft
+      - summary: Session timeout
+        name: AUTH-003
+  - summary: Audit log
+    name: AUD-001
+  - summary: Backups
+    name: OPS-001
+`})
+	os.WriteFile("requirements.yml", []byte(`version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: draft
+    requirements:
+      - summary: Password hashing
+        name: AUTH-002
+        status: implemented
+      - summary: Session timeout
+        name: AUTH-003
+        description: After 30 minutes
+      - summary: Remember me
+        name: AUTH-004
+  - summary: Backups
+    name: OPS-001
+`), 0644)
+
+	var out bytes.Buffer
+	if err := displayChanged(&out, "requirements.yml"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		// Login's list of sub-requirements changed too
+		"Requirements changed since HEAD: 1 added, 3 modified, 1 removed",
+		"~ ◯ [AUTH-001] Login",
+		"├─ » ✓ [AUTH-002] Password hashing  (draft → implemented)",
+		"├─ ~ · [AUTH-003] Session timeout",
+		"└─ + · [AUTH-004] Remember me",
+		"Removed:\n- [AUD-001] Audit log",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Backups") {
+		t.Errorf("Expected unchanged requirements to be left out:\n%s", out.String())
+	}
+}
+
+func TestListRoot(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "requirements.yml")
+	content := `version: "1.0"
+requirements:
+  - summary: Auth
+    name: REQ-001
+    requirements:
+      - summary: Login
+        requirements:
+          - summary: Login form
+  - summary: Billing
+    name: REQ-003
+    requirements:
+      - summary: Invoices
+`
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { listRoot, outputFormat = "", "tree" }()
+
+	for _, budget := range []int64{0, 64 << 20} {
+		memoryBudget = budget
+		listRoot, outputFormat = "Login", "tree"
+		var out bytes.Buffer
+		if err := runList(&out, file); err != nil {
+			t.Fatal(err)
+		}
+		got := out.String()
+		if !strings.Contains(got, "[unnamed] Login") || !strings.Contains(got, "Login form") || strings.Contains(got, "Auth") || strings.Contains(got, "Billing") {
+			t.Errorf("--root Login with a memory budget of %d listed:\n%s", budget, got)
+		}
+
+		listRoot = "REQ-404"
+		if err := runList(io.Discard, file); err == nil || !strings.Contains(err.Error(), "requirement not found: REQ-404") {
+			t.Errorf("--root REQ-404 with a memory budget of %d: %v", budget, err)
+		}
+	}
+	memoryBudget = 0
+}
+
+func TestListIncludesLowMemory(t *testing.T) {
+	file := writeIncludeTree(t)
+	defer func() { outputFormat = "tree" }()
+
+	for _, format := range []string{"json", "tree"} {
+		outputFormat = format
+		var outputs []string
+		for _, budget := range []int64{0, 64 << 20} {
+			memoryBudget = budget
+			var out bytes.Buffer
+			if err := runList(&out, file); err != nil {
+				t.Fatal(err)
+			}
+			outputs = append(outputs, out.String())
+		}
+		memoryBudget = 0
+		if !strings.Contains(outputs[1], "REQ-2") || !strings.Contains(outputs[1], "bob") {
+			t.Errorf("--format %s with a memory budget left out the included file:\n%s", format, outputs[1])
+		}
+		if format == "tree" && outputs[0] != outputs[1] {
+			t.Errorf("--format tree with a memory budget listed:\n%s\nwithout:\n%s", outputs[1], outputs[0])
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// llmTimeout bounds a single completion request
+const llmTimeout = 120 * time.Second
+
+// defaultLLMURL and defaultLLMKeyEnv are used when llm: leaves them out
+const (
+	defaultLLMURL    = "https://api.openai.com/v1"
+	defaultLLMKeyEnv = "OPENAI_API_KEY"
+)
+
+// LLMConfig is the llm: section of ~/.rqm.yaml: an OpenAI-compatible chat
+// completions endpoint used by draft and review. .rqm/config.yml may only
+// pick the model: a cloned repository mustn't choose where requests, and
+// which environment variable as their API key, are sent.
+type LLMConfig struct {
+	// URL is the API base URL, to which /chat/completions is appended
+	URL   string `yaml:"url,omitempty" mapstructure:"url"`
+	Model string `yaml:"model,omitempty" mapstructure:"model"`
+	// APIKeyEnv names the variable holding the API key; endpoints without
+	// authentication, such as a local server, work with it unset
+	APIKeyEnv string `yaml:"api_key_env,omitempty" mapstructure:"api_key_env"`
+}
+
+// llmClient sends chat completion requests
+type llmClient struct {
+	url    string
+	model  string
+	key    string
+	client *http.Client
+}
+
+// newLLMClient builds the client for the llm: settings of ~/.rqm.yaml and
+// the model of the project config, falling back to the defaults
+func newLLMClient(feature string, project *ProjectConfig) (*llmClient, error) {
+	if err := requireOnline(feature, project); err != nil {
+		return nil, err
+	}
+	var config LLMConfig
+	if err := viper.UnmarshalKey("llm", &config); err != nil {
+		return nil, fmt.Errorf("invalid llm settings in %s: %w", viper.ConfigFileUsed(), err)
+	}
+	if project != nil && project.LLM != nil {
+		p := project.LLM
+		if p.Model != "" {
+			config.Model = p.Model
+		}
+		if p.URL != "" || p.APIKeyEnv != "" {
+			fmt.Fprintln(os.Stderr, "Warning: llm url and api_key_env in .rqm/config.yml are ignored; set them in ~/.rqm.yaml")
+		}
+	}
+	if config.Model == "" {
+		return nil, fmt.Errorf("%s needs a model: set llm: model: in ~/.rqm.yaml or .rqm/config.yml", feature)
+	}
+	if config.URL == "" {
+		config.URL = defaultLLMURL
+	}
+	keyEnv := config.APIKeyEnv
+	if keyEnv == "" {
+		keyEnv = defaultLLMKeyEnv
+	}
+	key := os.Getenv(keyEnv)
+	if key == "" && config.APIKeyEnv != "" {
+		return nil, fmt.Errorf("llm API key variable %s is not set", config.APIKeyEnv)
+	}
+	if key == "" && config.URL == defaultLLMURL {
+		return nil, fmt.Errorf("llm API key variable %s is not set", keyEnv)
+	}
+	return &llmClient{
+		url:    strings.TrimSuffix(config.URL, "/") + "/chat/completions",
+		model:  config.Model,
+		key:    key,
+		client: &http.Client{Timeout: llmTimeout},
+	}, nil
+}
+
+// completeJSON sends the system and user messages and decodes the JSON
+// object the model answers with into v
+func (c *llmClient) completeJSON(ctx context.Context, system, user string, v interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"model": c.model,
+		"messages": []map[string]string{
+			{"role": "system", "content": system},
+			{"role": "user", "content": user},
+		},
+		"temperature":     0.2,
+		"response_format": map[string]string{"type": "json_object"},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid llm url: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.key != "" {
+		req.Header.Set("Authorization", "Bearer "+c.key)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("llm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return fmt.Errorf("llm request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("llm request failed: %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	var completion struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(data, &completion); err != nil || len(completion.Choices) == 0 {
+		return fmt.Errorf("unexpected llm response: %s", strings.TrimSpace(string(data)))
+	}
+	content := strings.TrimSpace(completion.Choices[0].Message.Content)
+	// Some models fence their JSON despite response_format
+	content = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(content, "```json"), "```"), "```")
+	if err := json.Unmarshal([]byte(content), v); err != nil {
+		return fmt.Errorf("llm answered with invalid JSON: %w", err)
+	}
+	return nil
+}
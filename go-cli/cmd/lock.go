@@ -0,0 +1,104 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// editLockTimeout is how long a command waits for another one editing the
+// same requirements file to finish; a variable so tests can shorten it
+var editLockTimeout = 10 * time.Second
+
+// errFileChanged means a requirements file changed between a command
+// reading it and writing its edits back
+var errFileChanged = errors.New("changed on disk since it was read")
+
+// editLockHolder is what a lock file says about the process holding it
+type editLockHolder struct {
+	PID     int       `json:"pid"`
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+}
+
+// rqmStateDir is the .rqm directory holding the history and locks of a
+// requirements file: the nearest one above it, or a new one next to it
+func rqmStateDir(file string) string {
+	// With an empty name findInRQMDir finds the .rqm directory itself
+	if dir := findInRQMDir(filepath.Dir(file), ""); dir != "" {
+		return dir
+	}
+	return filepath.Join(filepath.Dir(file), ".rqm")
+}
+
+// editLockPath is the lock file of a requirements file, in .rqm/locks.
+// Files with the same name in different directories share a lock, which
+// only makes their edits wait for each other.
+func editLockPath(file string) string {
+	return filepath.Join(rqmStateDir(file), "locks", filepath.Base(file)+".lock")
+}
+
+// lockRequirementsFile takes the advisory lock on editing file, waiting
+// up to editLockTimeout for another rqm process holding it. The lock file
+// records the holder's PID, start time and command for the error the
+// waiting process reports. The returned function releases the lock.
+func lockRequirementsFile(file string) (func(), error) {
+	path := editLockPath(file)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	// Keep the locks out of version control without touching .gitignore
+	ignore := filepath.Join(filepath.Dir(path), ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		os.WriteFile(ignore, []byte("*\n"), 0644)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock %s: %w", path, err)
+	}
+	deadline := time.Now().Add(editLockTimeout)
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("%s is being edited by %s; try again once it finishes", file, describeLockHolder(path))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// The lock lives as long as the file is open, so a crashed process
+	// never leaves it held; the content is only for messages
+	holder, _ := json.Marshal(editLockHolder{PID: os.Getpid(), Time: time.Now().UTC(), Command: commandLine()})
+	f.Truncate(0)
+	f.WriteAt(append(holder, '\n'), 0)
+	return func() {
+		f.Truncate(0)
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// describeLockHolder names the process holding the lock file at path
+func describeLockHolder(path string) string {
+	data, err := os.ReadFile(path)
+	var holder editLockHolder
+	if err != nil || json.Unmarshal(data, &holder) != nil {
+		return "another process"
+	}
+	return fmt.Sprintf("%s (pid %d, since %s)", holder.Command, holder.PID, holder.Time.Local().Format("15:04:05"))
+}
@@ -0,0 +1,64 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLockRequirementsFile(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("advisory locks are only enforced on unix here")
+	}
+	defer func(timeout time.Duration) { editLockTimeout = timeout }(editLockTimeout)
+	editLockTimeout = 100 * time.Millisecond
+
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	unlock, err := lockRequirementsFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = lockRequirementsFile(file)
+	if err == nil || !strings.Contains(err.Error(), "is being edited by rqm") || !strings.Contains(err.Error(), "pid ") {
+		t.Errorf("second lock: %v", err)
+	}
+
+	unlock()
+	unlock, err = lockRequirementsFile(file)
+	if err != nil {
+		t.Fatalf("lock after release: %v", err)
+	}
+	unlock()
+	if _, err := os.Stat(filepath.Join(filepath.Dir(file), ".rqm", "locks", ".gitignore")); err != nil {
+		t.Errorf("no .gitignore: %v", err)
+	}
+}
+
+func TestWriteRequirementsFileDetectsChange(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	original := []byte("version: \"1.0\"\nrequirements:\n  - summary: Login\n")
+	edited := []byte("version: \"1.0\"\nrequirements:\n  - summary: Login\n  - summary: Logout\n")
+	if err := os.WriteFile(file, edited, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Read as original, but someone else wrote edited since
+	err := writeRequirementsFile(file, original, []byte("version: \"1.0\"\nrequirements: []\n"))
+	if !errors.Is(err, errFileChanged) {
+		t.Fatalf("err = %v, want errFileChanged", err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != string(edited) {
+		t.Errorf("file overwritten:\n%s", data)
+	}
+	if entries, _ := readHistory(historyDir(file)); len(entries) != 0 {
+		t.Errorf("%d history entries for a refused write", len(entries))
+	}
+}
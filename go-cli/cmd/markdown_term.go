@@ -0,0 +1,236 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// defaultMarkdownWidth is the width Markdown is wrapped to when stdout
+// isn't a terminal
+const defaultMarkdownWidth = 80
+
+var (
+	markdownHeading  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownListItem = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	markdownRule     = regexp.MustCompile(`^\s*(-\s*){3,}$|^\s*(\*\s*){3,}$|^\s*(_\s*){3,}$`)
+	markdownCodeSpan = regexp.MustCompile("`([^`]+)`")
+	markdownLink     = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]+)\)`)
+	// requirementIDLike is a fragment shaped like a requirement ID, such as
+	// AUTH-001, rather than an in-page anchor such as #usage
+	requirementIDLike = regexp.MustCompile(`^\p{Lu}[\p{Lu}\pN_.-]*-\pN+$`)
+	markdownAutolink  = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	markdownBold      = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownItalic    = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+	ansiSequence      = regexp.MustCompile("\033\\[[0-9;]*m")
+)
+
+// renderMarkdown renders Markdown for the terminal, glamour-style:
+// headings in bold, emphasis and code spans styled, lists bulleted, quotes
+// barred and paragraphs wrapped to width. Links keep their target after
+// the text; a link to #AUTH-001 is a link to that requirement. Without
+// color only the layout remains.
+func renderMarkdown(text string, width int) []string {
+	var out []string
+	var paragraph []string
+	blank := false
+	emit := func(lines ...string) {
+		if blank && len(out) > 0 {
+			out = append(out, "")
+		}
+		blank = false
+		out = append(out, lines...)
+	}
+	flush := func() {
+		if len(paragraph) > 0 {
+			emit(wrapStyled(renderInline(strings.Join(paragraph, " ")), width)...)
+			paragraph = nil
+		}
+	}
+
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+			blank = true
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			flush()
+			fence := trimmed[:3]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, "  "+paint(ansiCyan, lines[i]))
+			}
+			emit(code...)
+		case markdownHeading.MatchString(trimmed):
+			flush()
+			m := markdownHeading.FindStringSubmatch(trimmed)
+			style := ansiBold
+			if len(m[1]) == 1 {
+				style += ansiUnder
+			}
+			emit(paint(style, renderInline(m[2])))
+		case markdownRule.MatchString(line):
+			flush()
+			emit(paint(ansiDim, strings.Repeat("─", min(width, 40))))
+		case markdownListItem.MatchString(line):
+			flush()
+			m := markdownListItem.FindStringSubmatch(line)
+			item := m[3]
+			// Lines indented under the item, up to a blank line or the
+			// next item, continue it
+			for i+1 < len(lines) && strings.HasPrefix(lines[i+1], " ") && strings.TrimSpace(lines[i+1]) != "" && !markdownListItem.MatchString(lines[i+1]) {
+				i++
+				item += " " + strings.TrimSpace(lines[i])
+			}
+			marker := m[2]
+			if !strings.ContainsAny(marker, "0123456789") {
+				marker = "•"
+			}
+			indent := strings.Repeat(" ", len(m[1])/2*2)
+			hang := indent + strings.Repeat(" ", len([]rune(marker))+1)
+			wrapped := wrapStyled(renderInline(item), width-len(hang))
+			for j, l := range wrapped {
+				if j == 0 {
+					wrapped[j] = indent + marker + " " + l
+				} else {
+					wrapped[j] = hang + l
+				}
+			}
+			emit(wrapped...)
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote = append(quote, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")))
+			}
+			i--
+			wrapped := wrapStyled(renderInline(strings.Join(quote, " ")), width-2)
+			for j, l := range wrapped {
+				wrapped[j] = paint(ansiDim, "│") + " " + paint(ansiItalic, l)
+			}
+			emit(wrapped...)
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+	return out
+}
+
+// renderInline styles the code spans, links, bold and italic text of a
+// line of Markdown
+func renderInline(s string) string {
+	// Code spans go first so nothing inside them is taken for markup;
+	// their content is held back until the end
+	var spans []string
+	s = markdownCodeSpan.ReplaceAllStringFunc(s, func(m string) string {
+		spans = append(spans, paint(ansiCyan, m[1:len(m)-1]))
+		return fmt.Sprintf("\x00%d\x00", len(spans)-1)
+	})
+	s = markdownLink.ReplaceAllStringFunc(s, func(m string) string {
+		parts := markdownLink.FindStringSubmatch(m)
+		text, target := parts[1], parts[2]
+		if ref, ok := requirementLinkTarget(target); ok {
+			if text == ref {
+				return paint(ansiBold, text)
+			}
+			return paint(ansiBold, text) + paint(ansiDim, " (→ "+ref+")")
+		}
+		if text == "" || text == target {
+			return paint(ansiUnder, target)
+		}
+		return paint(ansiUnder, text) + paint(ansiDim, " ("+target+")")
+	})
+	s = markdownAutolink.ReplaceAllString(s, paint(ansiUnder, "$1"))
+	s = markdownBold.ReplaceAllStringFunc(s, func(m string) string {
+		return paint(ansiBold, m[2:len(m)-2])
+	})
+	s = markdownItalic.ReplaceAllStringFunc(s, func(m string) string {
+		return paint(ansiItalic, m[1:len(m)-1])
+	})
+	for i, span := range spans {
+		s = strings.Replace(s, fmt.Sprintf("\x00%d\x00", i), span, 1)
+	}
+	return s
+}
+
+// wrapStyled wraps styled text to width columns, not counting the ANSI
+// codes styling it. Words longer than width get a line of their own.
+func wrapStyled(s string, width int) []string {
+	width = max(width, 20)
+	var lines []string
+	line, lineWidth := "", 0
+	for _, word := range strings.Fields(s) {
+		w := len([]rune(ansiSequence.ReplaceAllString(word, "")))
+		switch {
+		case line == "":
+			line, lineWidth = word, w
+		case lineWidth+1+w <= width:
+			line += " " + word
+			lineWidth += 1 + w
+		default:
+			lines = append(lines, line)
+			line, lineWidth = word, w
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// requirementLinkTarget returns the requirement a link target such as
+// #AUTH-001 points to: a fragment, which names a requirement by ID or,
+// URL-encoded, by summary
+func requirementLinkTarget(target string) (string, bool) {
+	ref, ok := strings.CutPrefix(target, "#")
+	if !ok || ref == "" {
+		return "", false
+	}
+	if unescaped, err := url.PathUnescape(ref); err == nil {
+		ref = unescaped
+	}
+	return ref, true
+}
+
+// brokenLinkWarnings flags links in the Markdown fields of requirements
+// to requirements that don't exist, such as [login](#AUTH-01) when the ID
+// is AUTH-001. Other fragments may be anchors of the page the text is
+// shown in, so only those shaped like an ID are checked.
+func brokenLinkWarnings(config *model.RequirementConfig, all []*model.RequirementDetail) []ValidationWarning {
+	var warnings []ValidationWarning
+	for _, req := range all {
+		for _, field := range []struct{ name, text string }{
+			{"description", req.Description},
+			{"justification", req.Justification},
+			{"further_information", strings.Join(req.FurtherInformation, "\n")},
+		} {
+			// Code spans show Markdown rather than link anything
+			text := markdownCodeSpan.ReplaceAllString(field.text, "")
+			for _, m := range markdownLink.FindAllStringSubmatch(text, -1) {
+				ref, ok := requirementLinkTarget(m[2])
+				if !ok || !requirementIDLike.MatchString(ref) {
+					continue
+				}
+				if _, found := config.Find(ref); !found {
+					warnings = append(warnings, ValidationWarning{
+						Category:    "consistency",
+						Requirement: req.Summary,
+						Message:     fmt.Sprintf("Requirement '%s' links to #%s in its %s, but no requirement has that ID", req.Summary, ref, field.name),
+					})
+				}
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,96 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	text := `# Sessions
+
+Users stay signed in for **30 minutes** of inactivity, as
+agreed with [security](#SEC-001). See ` + "`session.go`" + `.
+
+- Sliding expiry, reset on
+  every request
+- Logout ends the session at once
+1. First
+> Quoted advice
+> over two lines
+
+` + "```" + `
+timeout: 30m
+` + "```" + `
+---
+Docs: [RFC 6265](https://www.rfc-editor.org/rfc/rfc6265)`
+
+	got := strings.Join(renderMarkdown(text, 40), "\n")
+	want := `Sessions
+
+Users stay signed in for 30 minutes of
+inactivity, as agreed with security (→
+SEC-001). See session.go.
+
+• Sliding expiry, reset on every request
+• Logout ends the session at once
+1. First
+│ Quoted advice over two lines
+
+  timeout: 30m
+────────────────────────────────────────
+Docs: RFC 6265
+(https://www.rfc-editor.org/rfc/rfc6265)`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderInlineColor(t *testing.T) {
+	colorEnabled = true
+	defer func() { colorEnabled = false }()
+
+	got := renderInline("**bold** and *it* with `**not bold**` and [AUTH-001](#AUTH-001)")
+	want := ansiBold + "bold" + ansiReset + " and " + ansiItalic + "it" + ansiReset + " with " +
+		ansiCyan + "**not bold**" + ansiReset + " and " + ansiBold + "AUTH-001" + ansiReset
+	if got != want {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+	// Wrapping doesn't count the codes
+	if lines := wrapStyled(got, 30); len(lines) != 2 {
+		t.Errorf("wrapped to %q", lines)
+	}
+}
+
+func TestBrokenLinkWarnings(t *testing.T) {
+	config, err := model.ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    description: Uses [sessions](#SESS-001) and [logout](#Log%20out), not ` + "`[x](#NOPE-1)`" + `; see [usage](#usage), [step 2](#step-2) and [lost](#Lost%20summary).
+    further_information:
+      - "[old login](#AUTH-000)"
+  - summary: Log out
+    justification: See [login](#AUTH-001) and [docs](https://example.com).
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings := brokenLinkWarnings(config, config.Flatten())
+	var got []string
+	for _, w := range warnings {
+		got = append(got, w.Message)
+	}
+	want := []string{
+		"Requirement 'Login' links to #SESS-001 in its description, but no requirement has that ID",
+		"Requirement 'Login' links to #AUTH-000 in its further_information, but no requirement has that ID",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s", strings.Join(got, "\n"))
+	}
+}
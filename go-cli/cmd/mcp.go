@@ -0,0 +1,409 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// mcpProtocolVersion is the Model Context Protocol revision rqm mcp speaks
+const mcpProtocolVersion = "2024-11-05"
+
+// JSON-RPC error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// rpcRequest is a JSON-RPC 2.0 request, or a notification when ID is
+// missing
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is the error of a failed JSON-RPC request
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// mcpTool is a tool rqm mcp offers, with the JSON Schema of its arguments
+type mcpTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+	call        func(file string, args mcpArguments) (interface{}, error)
+}
+
+// mcpArguments are the arguments of a tool call
+type mcpArguments struct {
+	Requirement    string   `json:"requirement"`
+	Status         string   `json:"status"`
+	Tag            string   `json:"tag"`
+	Owner          string   `json:"owner"`
+	Summary        string   `json:"summary"`
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	Justification  string   `json:"justification"`
+	AcceptanceTest string   `json:"acceptance_test"`
+	Priority       string   `json:"priority"`
+	Tags           []string `json:"tags"`
+	Parent         string   `json:"parent"`
+}
+
+// mcpListedRequirement is a requirement as list_requirements returns it
+type mcpListedRequirement struct {
+	Summary  string   `json:"summary"`
+	Name     string   `json:"name,omitempty"`
+	Status   string   `json:"status,omitempty"`
+	Priority string   `json:"priority,omitempty"`
+	Owner    string   `json:"owner,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Parent   string   `json:"parent,omitempty"`
+}
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp [file]",
+	Short: "Serve a requirements file to AI agents over the Model Context Protocol",
+	Long: `Run a Model Context Protocol (MCP) server on stdin and stdout, so AI
+agents such as Claude or Cursor can read and change requirements through
+tool calls instead of shelling out to rqm:
+
+  list_requirements   requirements with their status, priority, owner and
+                      tags, optionally filtered by status, tag or owner
+  get_requirement     every field of one requirement, by summary or ID
+  validate            validate the file, as rqm validate --format json
+  update_status       set the status of a requirement
+  add_requirement     add a requirement, at the top level or under a parent
+
+The server works on one requirements file, read afresh for every call.
+Changes are written like other rqm edits: comments and key order are
+kept, and JSON and TOML files are read-only. Register it with your agent,
+for example in .mcp.json or .cursor/mcp.json:
+
+  {"mcpServers": {"rqm": {"command": "rqm", "args": ["mcp"]}}}`,
+	Example: `  rqm mcp
+  rqm mcp docs/requirements.yml`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist: %s", file)
+		}
+		fmt.Fprintf(os.Stderr, "rqm MCP server for %s on stdio\n", file)
+		return serveMCP(os.Stdin, os.Stdout, file)
+	},
+}
+
+// serveMCP answers the MCP requests read from in, one JSON message per
+// line, on out until in is closed
+func serveMCP(in io.Reader, out io.Writer, file string) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+	enc := json.NewEncoder(out)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var req rpcRequest
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			if err := enc.Encode(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{rpcParseError, "invalid JSON: " + err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+		result, rerr := handleMCP(file, req)
+		// Notifications get no response
+		if len(req.ID) == 0 {
+			continue
+		}
+		if err := enc.Encode(rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rerr}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// handleMCP answers a single request
+func handleMCP(file string, req rpcRequest) (interface{}, *rpcError) {
+	if req.JSONRPC != "2.0" {
+		return nil, &rpcError{rpcInvalidRequest, "expected a JSON-RPC 2.0 request"}
+	}
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "rqm", "version": rootCmd.Version},
+			"instructions":    "Tools to read, validate and edit the requirements in " + file + ". Reference requirements by summary or ID.",
+		}, nil
+	case "ping", "notifications/initialized", "notifications/cancelled":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": mcpTools}, nil
+	case "tools/call":
+		var params struct {
+			Name      string       `json:"name"`
+			Arguments mcpArguments `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{rpcInvalidParams, "invalid tool call: " + err.Error()}
+		}
+		i := slices.IndexFunc(mcpTools, func(t mcpTool) bool { return t.Name == params.Name })
+		if i < 0 {
+			return nil, &rpcError{rpcInvalidParams, "unknown tool: " + params.Name}
+		}
+		return mcpToolResult(mcpTools[i].call(file, params.Arguments)), nil
+	default:
+		return nil, &rpcError{rpcMethodNotFound, "method not found: " + req.Method}
+	}
+}
+
+// mcpToolResult wraps what a tool returned as MCP text content: a message
+// string as is, anything else as indented JSON. A failed tool call is a
+// result with isError, so the agent sees why.
+func mcpToolResult(v interface{}, err error) map[string]interface{} {
+	if err != nil {
+		return map[string]interface{}{
+			"content": []map[string]string{{"type": "text", "text": err.Error()}},
+			"isError": true,
+		}
+	}
+	text, ok := v.(string)
+	if !ok {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return mcpToolResult(nil, err)
+		}
+		text = string(data)
+	}
+	return map[string]interface{}{
+		"content": []map[string]string{{"type": "text", "text": text}},
+	}
+}
+
+// mcpObject is the JSON Schema of an object with the given string
+// properties, of which required must be present
+func mcpObject(properties map[string]string, required ...string) map[string]interface{} {
+	props := make(map[string]interface{})
+	for name, description := range properties {
+		props[name] = map[string]string{"type": "string", "description": description}
+	}
+	schema := map[string]interface{}{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// mcpTools are the tools rqm mcp offers
+var mcpTools []mcpTool
+
+func init() {
+	addSchema := mcpObject(map[string]string{
+		"summary":         "Short title, unique in the file",
+		"name":            "Optional ID, such as AUTH-003",
+		"description":     "What the system must do",
+		"justification":   "Why it is needed",
+		"acceptance_test": "Given/When/Then steps, one per line",
+		"priority":        "critical, high, medium or low",
+		"status":          "Lifecycle status (default draft)",
+		"parent":          "Summary or ID of the requirement to add it under; the top level when empty",
+	}, "summary")
+	addSchema["properties"].(map[string]interface{})["tags"] = map[string]interface{}{
+		"type": "array", "items": map[string]string{"type": "string"},
+	}
+
+	mcpTools = []mcpTool{
+		{
+			Name:        "list_requirements",
+			Description: "List the requirements with their status, priority, owner, tags and parent. Filters are optional and combine.",
+			InputSchema: mcpObject(map[string]string{
+				"status": "Only requirements with this status",
+				"tag":    "Only requirements with this tag",
+				"owner":  "Only requirements with this owner",
+			}),
+			call: mcpListRequirements,
+		},
+		{
+			Name:        "get_requirement",
+			Description: "Get every field of a requirement, including its sub-requirements.",
+			InputSchema: mcpObject(map[string]string{"requirement": "Summary or ID of the requirement"}, "requirement"),
+			call:        mcpGetRequirement,
+		},
+		{
+			Name:        "validate",
+			Description: "Validate the requirements file against the schema and the project checks, and return its errors and warnings.",
+			InputSchema: mcpObject(nil),
+			call:        mcpValidate,
+		},
+		{
+			Name:        "update_status",
+			Description: "Set the status of a requirement: " + strings.Join(requirementStatuses, ", ") + ".",
+			InputSchema: mcpObject(map[string]string{
+				"requirement": "Summary or ID of the requirement",
+				"status":      "New status",
+			}, "requirement", "status"),
+			call: mcpUpdateStatus,
+		},
+		{
+			Name:        "add_requirement",
+			Description: "Add a requirement to the file, at the top level or under a parent.",
+			InputSchema: addSchema,
+			call:        mcpAddRequirement,
+		},
+	}
+
+	rootCmd.AddCommand(mcpCmd)
+}
+
+func mcpListRequirements(file string, args mcpArguments) (interface{}, error) {
+	config, err := model.Load(file)
+	if err != nil {
+		return nil, err
+	}
+	listed := []mcpListedRequirement{}
+	var walk func(reqs []*model.RequirementDetail, parent string)
+	walk = func(reqs []*model.RequirementDetail, parent string) {
+		for _, req := range reqs {
+			if (args.Status == "" || req.Status == args.Status) &&
+				(args.Tag == "" || slices.Contains(req.Tags, args.Tag)) &&
+				(args.Owner == "" || req.Owner == args.Owner) {
+				listed = append(listed, mcpListedRequirement{
+					Summary:  req.Summary,
+					Name:     req.Name,
+					Status:   req.Status,
+					Priority: req.Priority,
+					Owner:    req.Owner,
+					Tags:     req.Tags,
+					Parent:   parent,
+				})
+			}
+			walk(req.Children(), req.Summary)
+		}
+	}
+	walk(config.Roots(), "")
+	return listed, nil
+}
+
+func mcpGetRequirement(file string, args mcpArguments) (interface{}, error) {
+	config, err := model.Load(file)
+	if err != nil {
+		return nil, err
+	}
+	req, ok := config.Find(args.Requirement)
+	if !ok {
+		return nil, fmt.Errorf("requirement not found: %s", args.Requirement)
+	}
+	return req, nil
+}
+
+func mcpValidate(file string, _ mcpArguments) (interface{}, error) {
+	result, err := validateFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return validateOutput(file, result), nil
+}
+
+func mcpUpdateStatus(file string, args mcpArguments) (interface{}, error) {
+	if !slices.Contains(requirementStatuses, args.Status) {
+		return nil, fmt.Errorf("invalid status %q (expected one of %s)", args.Status, strings.Join(requirementStatuses, ", "))
+	}
+	// The requirement may be written in a file that file includes
+	owner, req, err := requirementFile(file, args.Requirement)
+	if err != nil {
+		return nil, err
+	}
+	if err := editableFile(owner); err != nil {
+		return nil, err
+	}
+	if err := applyRequirementEdits(owner, []requirementEdit{{Summary: req.Summary, Set: map[string]string{"status": args.Status}}}); err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Set the status of '%s' to %s in %s", req.Summary, args.Status, owner), nil
+}
+
+func mcpAddRequirement(file string, args mcpArguments) (interface{}, error) {
+	if err := editableFile(file); err != nil {
+		return nil, err
+	}
+	req := &model.RequirementDetail{
+		Summary:        strings.TrimSpace(args.Summary),
+		Name:           args.Name,
+		Description:    args.Description,
+		Justification:  args.Justification,
+		AcceptanceTest: args.AcceptanceTest,
+		Priority:       args.Priority,
+		Status:         args.Status,
+		Tags:           args.Tags,
+	}
+	if req.Summary == "" {
+		return nil, fmt.Errorf("a requirement needs a summary")
+	}
+	if req.Status == "" {
+		req.Status = "draft"
+	}
+	if !slices.Contains(requirementStatuses, req.Status) {
+		return nil, fmt.Errorf("invalid status %q (expected one of %s)", req.Status, strings.Join(requirementStatuses, ", "))
+	}
+	if req.Priority != "" && !slices.Contains([]string{"critical", "high", "medium", "low"}, req.Priority) {
+		return nil, fmt.Errorf("invalid priority %q (expected critical, high, medium or low)", req.Priority)
+	}
+	if req.AcceptanceTest != "" && !strings.HasSuffix(req.AcceptanceTest, "\n") {
+		req.AcceptanceTest += "\n"
+	}
+	config, err := model.Load(file)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range []string{req.Summary, req.Name} {
+		if _, taken := config.Find(ref); ref != "" && taken {
+			return nil, fmt.Errorf("'%s' is already used by a requirement", ref)
+		}
+	}
+	// A sub-requirement goes in the file its parent is written in, which
+	// may be one file includes
+	target := file
+	if args.Parent != "" {
+		if target, _, err = requirementFile(file, args.Parent); err != nil {
+			return nil, err
+		}
+		if err := editableFile(target); err != nil {
+			return nil, err
+		}
+	}
+	if err := insertRequirement(target, args.Parent, req); err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Added '%s' to %s", req.Summary, target), nil
+}
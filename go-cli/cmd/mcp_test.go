@@ -0,0 +1,167 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// mcpSession sends requests to serveMCP and returns its responses by id
+func mcpSession(t *testing.T, file string, requests ...string) map[string]rpcResponse {
+	t.Helper()
+	var out bytes.Buffer
+	if err := serveMCP(strings.NewReader(strings.Join(requests, "\n")), &out, file); err != nil {
+		t.Fatal(err)
+	}
+	responses := make(map[string]rpcResponse)
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp rpcResponse
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		responses[string(resp.ID)] = resp
+	}
+	return responses
+}
+
+// toolText returns the text content of a tools/call result, failing the
+// test if the call failed unless wantError is set
+func toolText(t *testing.T, resp rpcResponse, wantError bool) string {
+	t.Helper()
+	if resp.Error != nil {
+		t.Fatalf("error response: %+v", resp.Error)
+	}
+	result := resp.Result.(map[string]interface{})
+	if isError, _ := result["isError"].(bool); isError != wantError {
+		t.Errorf("isError = %v in %v", isError, result)
+	}
+	content := result["content"].([]interface{})
+	return content[0].(map[string]interface{})["text"].(string)
+}
+
+func TestServeMCP(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	original := `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: approved
+    tags: [auth]
+    requirements:
+      - summary: Login form
+        status: draft
+`
+	if err := os.WriteFile(file, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	responses := mcpSession(t, file,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"list_requirements","arguments":{"status":"draft"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"get_requirement","arguments":{"requirement":"AUTH-001"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"update_status","arguments":{"requirement":"Login form","status":"implemented"}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"add_requirement","arguments":{"summary":"Logout","parent":"AUTH-001","tags":["auth"],"acceptance_test":"Given a session\nWhen the user logs out\nThen the session ends"}}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"update_status","arguments":{"requirement":"Login","status":"done"}}}`,
+		`{"jsonrpc":"2.0","id":8,"method":"tools/call","params":{"name":"add_requirement","arguments":{"summary":"Login"}}}`,
+		`{"jsonrpc":"2.0","id":9,"method":"resources/list"}`,
+		`not json`,
+	)
+	if len(responses) != 10 {
+		t.Fatalf("got %d responses, want 10 (none for the notification): %v", len(responses), responses)
+	}
+
+	info := responses["1"].Result.(map[string]interface{})
+	if info["protocolVersion"] != mcpProtocolVersion || info["serverInfo"].(map[string]interface{})["name"] != "rqm" {
+		t.Errorf("initialize = %v", info)
+	}
+	var names []string
+	for _, tool := range responses["2"].Result.(map[string]interface{})["tools"].([]interface{}) {
+		names = append(names, tool.(map[string]interface{})["name"].(string))
+	}
+	if got := strings.Join(names, ","); got != "list_requirements,get_requirement,validate,update_status,add_requirement" {
+		t.Errorf("tools = %s", got)
+	}
+
+	var listed []mcpListedRequirement
+	if err := json.Unmarshal([]byte(toolText(t, responses["3"], false)), &listed); err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].Summary != "Login form" || listed[0].Parent != "Login" {
+		t.Errorf("list_requirements = %+v", listed)
+	}
+	if text := toolText(t, responses["4"], false); !strings.Contains(text, `"summary": "Login form"`) {
+		t.Errorf("get_requirement = %s", text)
+	}
+	toolText(t, responses["5"], false)
+	toolText(t, responses["6"], false)
+	if text := toolText(t, responses["7"], true); !strings.Contains(text, `invalid status "done"`) {
+		t.Errorf("update_status with an unknown status = %s", text)
+	}
+	if text := toolText(t, responses["8"], true); !strings.Contains(text, "already used") {
+		t.Errorf("add_requirement with a taken summary = %s", text)
+	}
+	if e := responses["9"].Error; e == nil || e.Code != rpcMethodNotFound {
+		t.Errorf("resources/list error = %+v", e)
+	}
+	if e := responses["null"].Error; e == nil || e.Code != rpcParseError {
+		t.Errorf("invalid JSON error = %+v", e)
+	}
+
+	config, err := model.Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req, _ := config.Find("Login form"); req.Status != "implemented" {
+		t.Errorf("status of Login form = %s", req.Status)
+	}
+	if req, ok := config.Find("Logout"); !ok || req.Status != "draft" || !strings.HasSuffix(req.AcceptanceTest, "ends\n") {
+		t.Errorf("Logout = %+v", req)
+	}
+	if children := config.Requirements[0].Children(); len(children) != 2 {
+		t.Errorf("Login has %d sub-requirements, want 2", len(children))
+	}
+}
+
+func TestServeMCPIncludes(t *testing.T) {
+	file := writeIncludeTree(t)
+	part := filepath.Join(filepath.Dir(file), "part.yml")
+
+	responses := mcpSession(t, file,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"update_status","arguments":{"requirement":"REQ-2","status":"implemented"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"add_requirement","arguments":{"summary":"Logout everywhere","parent":"Logout"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"add_requirement","arguments":{"summary":"Logout"}}}`,
+	)
+	if text := toolText(t, responses["1"], false); !strings.HasSuffix(text, "in "+part) {
+		t.Errorf("update_status = %s", text)
+	}
+	if text := toolText(t, responses["2"], false); !strings.HasSuffix(text, "to "+part) {
+		t.Errorf("add_requirement = %s", text)
+	}
+	if text := toolText(t, responses["3"], true); !strings.Contains(text, "already used") {
+		t.Errorf("add_requirement with a summary taken in an included file = %s", text)
+	}
+
+	config, err := model.LoadFile(part)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logout, _ := config.Find("REQ-2")
+	if logout.Status != "implemented" || len(logout.Children()) != 1 {
+		t.Errorf("Logout in %s = %+v", part, logout)
+	}
+	if data, _ := os.ReadFile(file); strings.Contains(string(data), "Logout") {
+		t.Errorf("Unexpected edit of the main file:\n%s", data)
+	}
+}
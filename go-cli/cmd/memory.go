@@ -0,0 +1,307 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	maxMemoryFlag string
+
+	// memoryBudget is the parsed --max-memory value in bytes, 0 when unlimited
+	memoryBudget int64
+)
+
+// sizeUnits maps accepted --max-memory suffixes to multipliers
+var sizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"GIB", 1 << 30}, {"MIB", 1 << 20}, {"KIB", 1 << 10},
+	{"GB", 1000 * 1000 * 1000}, {"MB", 1000 * 1000}, {"KB", 1000},
+	{"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10},
+	{"B", 1},
+}
+
+// parseByteSize parses sizes such as "512MB", "1GiB" or "268435456"
+func parseByteSize(s string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q (examples: 512MB, 1GiB)", s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// lowMemoryCommands stream requirements under --max-memory. Other
+// commands need the whole file in memory, so they reject the flag rather
+// than ignore it.
+var lowMemoryCommands = []string{"list", "stats"}
+
+// applyMemoryBudget enables low-memory mode when --max-memory is set. The
+// limit is handed to the Go runtime as a soft limit so the GC works harder
+// before the process grows past it.
+func applyMemoryBudget(cmd *cobra.Command) error {
+	if maxMemoryFlag == "" {
+		return nil
+	}
+	if cmd.Parent() != cmd.Root() || !slices.Contains(lowMemoryCommands, cmd.Name()) {
+		return fmt.Errorf("--max-memory is not supported by rqm %s, only by %s", cmd.Name(), strings.Join(lowMemoryCommands, " and "))
+	}
+
+	budget, err := parseByteSize(maxMemoryFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --max-memory: %w", err)
+	}
+	memoryBudget = budget
+	debug.SetMemoryLimit(budget)
+	return nil
+}
+
+// streamRequirementsFile streams the requirements of file, and then those
+// of the files it includes, in the order loadRequirements gives them.
+// onHeader is called once, with file's version and the aliases of every
+// file, before the first requirement.
+func streamRequirementsFile(file string, onHeader func(version string, aliases []model.PersonAlias), onRequirement func(*model.RequirementDetail) error) error {
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		return fmt.Errorf("file does not exist: %s", file)
+	}
+	files, aliases, err := streamedFiles(file)
+	if err != nil {
+		return err
+	}
+	for i, path := range files {
+		var header func(string, []model.PersonAlias)
+		if i == 0 && onHeader != nil {
+			header = func(version string, _ []model.PersonAlias) { onHeader(version, aliases) }
+		}
+		if err := streamValidatorOutput(path, header, onRequirement); err != nil {
+			if i > 0 {
+				return fmt.Errorf("failed to load %s, included by %s: %w", path, file, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// streamedFiles returns file and the files it includes, directly or not,
+// in the order model.ResolveIncludes adds them, and the aliases of all of
+// them, an including file's winning
+func streamedFiles(file string) ([]string, []model.PersonAlias, error) {
+	var files []string
+	var aliases []model.PersonAlias
+	var walk func(path string, stack []string) error
+	walk = func(path string, stack []string) error {
+		includes, own, err := fileDirectives(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, path)
+		for _, alias := range own {
+			if !slices.ContainsFunc(aliases, func(a model.PersonAlias) bool { return a.Alias == alias.Alias }) {
+				aliases = append(aliases, alias)
+			}
+		}
+		abs, _ := filepath.Abs(path)
+		stack = append(stack, abs)
+		for _, include := range includes {
+			included := filepath.Join(filepath.Dir(path), filepath.FromSlash(include))
+			if abs, _ := filepath.Abs(included); slices.Contains(stack, abs) {
+				return fmt.Errorf("%s includes %s, which includes it back", path, include)
+			}
+			if err := walk(included, stack); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(file, nil); err != nil {
+		return nil, nil, err
+	}
+	return files, aliases, nil
+}
+
+// fileDirectives returns the includes and aliases of the requirements file
+// at path. They are read from a YAML file's top-level lines without
+// parsing the rest, which is what --max-memory avoids; TOML and JSON are
+// converted whole anyway.
+func fileDirectives(path string) ([]string, []model.PersonAlias, error) {
+	var directives struct {
+		Includes []string            `yaml:"includes"`
+		Aliases  []model.PersonAlias `yaml:"aliases"`
+	}
+	var data []byte
+	if model.FormatOf(path) == model.FormatYAML {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 16<<20)
+		keep := false
+		for scanner.Scan() {
+			line := scanner.Text()
+			// A line starting in the first column begins a top-level key;
+			// the lines of its value are indented
+			if line != "" && !strings.ContainsAny(line[:1], " \t#") {
+				key, _, _ := strings.Cut(line, ":")
+				key = strings.Trim(key, `"'`)
+				keep = key == "includes" || key == "aliases"
+			}
+			if keep {
+				data = append(append(data, line...), '\n')
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, nil, fmt.Errorf("failed to read file: %w", err)
+		}
+	} else {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		if data, err = model.ToYAML(path, content); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	if err := yaml.Unmarshal(data, &directives); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return directives.Includes, directives.Aliases, nil
+}
+
+// streamValidatorOutput runs the validator on file alone and streams its
+// json-full output through streamRequirements
+func streamValidatorOutput(file string, onHeader func(version string, aliases []model.PersonAlias), onRequirement func(*model.RequirementDetail) error) error {
+	validatorPath := findValidatorBinary()
+	if validatorPath == "" {
+		return fmt.Errorf("rqm-validator binary not found")
+	}
+
+	input, cleanup, err := validatorInput(file)
+	if err != nil {
+		return fmt.Errorf("failed to parse requirements: %w", err)
+	}
+	defer cleanup()
+
+	validatorCmd := exec.Command(validatorPath, input, "--format", "json-full")
+	stdout, err := validatorCmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to start validator: %w", err)
+	}
+	if err := validatorCmd.Start(); err != nil {
+		return fmt.Errorf("failed to start validator: %w", err)
+	}
+
+	streamErr := streamRequirements(stdout, onHeader, onRequirement)
+	// Drain anything left so the validator can exit
+	io.Copy(io.Discard, stdout)
+	waitErr := validatorCmd.Wait()
+
+	if streamErr != nil {
+		return streamErr
+	}
+	if waitErr != nil {
+		return fmt.Errorf("failed to parse requirements: %w", waitErr)
+	}
+	return nil
+}
+
+// streamRequirements decodes a json-full document one top-level requirement
+// at a time, so only a single subtree is held in memory. onHeader is called
+// once the version and aliases are known, before the first requirement.
+func streamRequirements(r io.Reader, onHeader func(version string, aliases []model.PersonAlias), onRequirement func(*model.RequirementDetail) error) error {
+	dec := json.NewDecoder(r)
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("failed to parse requirements JSON: expected object")
+	}
+
+	var version string
+	var aliases []model.PersonAlias
+	headerSent := false
+	sendHeader := func() {
+		if !headerSent && onHeader != nil {
+			onHeader(version, aliases)
+		}
+		headerSent = true
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to parse requirements JSON: %w", err)
+		}
+		key, _ := tok.(string)
+
+		switch key {
+		case "version":
+			err = dec.Decode(&version)
+		case "aliases":
+			err = dec.Decode(&aliases)
+		case "errors":
+			// The validator reports parse failures as a validation result
+			var errs []string
+			if err := dec.Decode(&errs); err == nil && len(errs) > 0 {
+				return fmt.Errorf("failed to parse requirements: %s", strings.Join(errs, "; "))
+			}
+		case "requirements":
+			sendHeader()
+			err = streamRequirementArray(dec, onRequirement)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse requirements JSON: %w", err)
+		}
+	}
+
+	sendHeader()
+	return nil
+}
+
+func streamRequirementArray(dec *json.Decoder, onRequirement func(*model.RequirementDetail) error) error {
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return fmt.Errorf("expected requirements array")
+	}
+	for dec.More() {
+		var req model.RequirementDetail
+		if err := dec.Decode(&req); err != nil {
+			return err
+		}
+		if err := onRequirement(&req); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
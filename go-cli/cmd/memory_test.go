@@ -0,0 +1,161 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"1024", 1024, false},
+		{"512MB", 512 * 1000 * 1000, false},
+		{"256MiB", 256 << 20, false},
+		{"1GiB", 1 << 30, false},
+		{"64m", 64 << 20, false},
+		{"1.5K", 1536, false},
+		{"", 0, true},
+		{"lots", 0, true},
+		{"-5MB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseByteSize(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseByteSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseByteSize(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyMemoryBudget(t *testing.T) {
+	t.Cleanup(func() {
+		maxMemoryFlag, memoryBudget = "", 0
+		debug.SetMemoryLimit(math.MaxInt64)
+	})
+
+	maxMemoryFlag = "64MB"
+	for _, tt := range []struct {
+		command string
+		want    string
+	}{
+		{"list", ""},
+		{"stats", ""},
+		{"export", "--max-memory is not supported by rqm export, only by list and stats"},
+		{"hooks install", "--max-memory is not supported by rqm install"},
+	} {
+		cmd, _, err := rootCmd.Find(strings.Fields(tt.command))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = applyMemoryBudget(cmd)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("rqm %s: unexpected error %v", tt.command, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("rqm %s: expected error %q, got %v", tt.command, tt.want, err)
+		}
+	}
+	if memoryBudget != 64*1000*1000 {
+		t.Errorf("memoryBudget = %d", memoryBudget)
+	}
+}
+
+func TestStreamRequirements(t *testing.T) {
+	input := `{"version":"1.0","aliases":[{"alias":"jdoe","name":"J Doe"}],"requirements":[
+{"summary":"A","requirements":[{"summary":"B"}]},{"summary":"C"}],"extra":{"ignored":true}}`
+
+	var version string
+	var summaries []string
+	err := streamRequirements(strings.NewReader(input), func(v string, aliases []model.PersonAlias) {
+		version = v
+		if len(aliases) != 1 || aliases[0].Alias != "jdoe" {
+			t.Errorf("Unexpected aliases: %+v", aliases)
+		}
+	}, func(req *model.RequirementDetail) error {
+		summaries = append(summaries, req.Summary)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if version != "1.0" || strings.Join(summaries, ",") != "A,C" {
+		t.Errorf("Got version %q, summaries %v", version, summaries)
+	}
+
+	err = streamRequirements(strings.NewReader(`{"valid":false,"errors":["bad indent"]}`), nil,
+		func(*model.RequirementDetail) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "bad indent") {
+		t.Errorf("Expected validator error to be surfaced, got %v", err)
+	}
+}
+
+// TestStreamRequirementsUnderMemoryLimit streams a document far larger than
+// the configured memory limit and checks the live heap stays bounded
+func TestStreamRequirementsUnderMemoryLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large stream in short mode")
+	}
+
+	const count = 20000
+	const limit = 32 << 20
+	description := strings.Repeat("x", 2048)
+
+	previous := debug.SetMemoryLimit(limit)
+	defer debug.SetMemoryLimit(previous)
+	runtime.GC()
+
+	pr, pw := io.Pipe()
+	go func() {
+		fmt.Fprint(pw, `{"version":"1.0","requirements":[`)
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				fmt.Fprint(pw, ",")
+			}
+			fmt.Fprintf(pw, `{"summary":"Requirement %d","description":"%s"}`, i, description)
+		}
+		fmt.Fprint(pw, `]}`)
+		pw.Close()
+	}()
+
+	var seen int
+	var peak uint64
+	var stats runtime.MemStats
+	err := streamRequirements(pr, nil, func(req *model.RequirementDetail) error {
+		seen++
+		if seen%1000 == 0 {
+			runtime.ReadMemStats(&stats)
+			peak = max(peak, stats.HeapAlloc)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if seen != count {
+		t.Errorf("Expected %d requirements, got %d", count, seen)
+	}
+
+	// The full document is ~40MB; streaming should keep the heap well under the limit
+	if peak >= limit {
+		t.Errorf("Peak heap %.1fMB exceeded the %dMB limit", float64(peak)/math.Pow(2, 20), limit>>20)
+	}
+}
@@ -0,0 +1,395 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"embed"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/rqmcore"
+	"go.yaml.in/yaml/v3"
+)
+
+//go:embed messages/*.yml
+var messageFiles embed.FS
+
+// messageTemplate is a catalog entry: what went wrong and how to fix it
+type messageTemplate struct {
+	Message string `yaml:"message"`
+	Fix     string `yaml:"fix"`
+}
+
+// messageCatalog holds the templates of one language, keyed by message code
+type messageCatalog struct {
+	Labels   map[string]string          `yaml:"labels"`
+	Messages map[string]messageTemplate `yaml:"messages"`
+}
+
+// friendlyMessage is a validator message rewritten for people. Raw is the
+// validator's own text, which --format json keeps reporting.
+type friendlyMessage struct {
+	Text     string
+	Fix      string
+	FixLabel string
+	// Summary is the requirement the message is about, when known
+	Summary string
+	// Line is where in the file the problem is, when the validator says
+	Line int
+	Raw  string
+}
+
+// validatorMessage is a validator message with its catalog code and the
+// values its template fills in. Messages that weren't recognized have no
+// code.
+type validatorMessage struct {
+	raw     string
+	code    string
+	summary string
+	line    int
+	params  map[string]string
+}
+
+var (
+	// serdeIndexPattern splits a path segment such as requirements[2]
+	serdeIndexPattern = regexp.MustCompile(`\[(\d+)\]`)
+	backtickPattern   = regexp.MustCompile("`([^`]*)`")
+
+	serdeUnknownVariant = regexp.MustCompile("^unknown variant `([^`]*)`, expected (.*)$")
+	serdeMissingField   = regexp.MustCompile("^missing field `([^`]*)`")
+	serdeUnknownField   = regexp.MustCompile("^unknown field `([^`]*)`")
+	serdeInvalidType    = regexp.MustCompile(`^invalid type: (.*), expected (.*)$`)
+)
+
+// localizeValidationErrors rewrites the errors of result about file in
+// the language messageLocale picks, naming the requirement involved and a
+// fix. The validator's error is rewritten from its details, which say
+// where each problem is; errors without details, such as those of the
+// project checks or of a validator too old to give them, are kept as they
+// are.
+func localizeValidationErrors(file string, result *ValidationResult) []friendlyMessage {
+	catalog := loadMessageCatalog(messageLocale())
+	errs := result.Errors
+	var out []friendlyMessage
+	if len(result.Details) > 0 && len(errs) > 0 {
+		// The validator stops at its first error, which comes before the
+		// project checks' errors
+		index := newRequirementIndex(file)
+		for _, detail := range result.Details {
+			out = append(out, catalog.render(classifyDetail(detail, index)))
+		}
+		errs = errs[1:]
+	}
+	return append(out, plainMessages(errs)...)
+}
+
+// plainMessages gives messages that have no details as they are
+func plainMessages(msgs []string) []friendlyMessage {
+	var out []friendlyMessage
+	for _, msg := range msgs {
+		out = append(out, friendlyMessage{Text: msg, Raw: msg})
+	}
+	return out
+}
+
+// messageLocale returns the language for messages from RQM_LANG, or the
+// usual locale variables, such as de for LANG=de_DE.UTF-8
+func messageLocale() string {
+	for _, name := range []string{"RQM_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		lang, _, _ := strings.Cut(value, ".")
+		lang, _, _ = strings.Cut(lang, "_")
+		lang, _, _ = strings.Cut(lang, "-")
+		lang = strings.ToLower(lang)
+		if lang == "c" || lang == "posix" {
+			return "en"
+		}
+		return lang
+	}
+	return "en"
+}
+
+// loadMessageCatalog returns the catalog for lang, with English filling in
+// languages and entries that don't exist
+func loadMessageCatalog(lang string) *messageCatalog {
+	catalog := &messageCatalog{}
+	for _, name := range []string{"en", lang} {
+		data, err := messageFiles.ReadFile("messages/" + name + ".yml")
+		if err != nil {
+			continue
+		}
+		var overlay messageCatalog
+		if yaml.Unmarshal(data, &overlay) != nil {
+			continue
+		}
+		if catalog.Labels == nil {
+			catalog.Labels = make(map[string]string)
+			catalog.Messages = make(map[string]messageTemplate)
+		}
+		for key, label := range overlay.Labels {
+			catalog.Labels[key] = label
+		}
+		for code, entry := range overlay.Messages {
+			catalog.Messages[code] = entry
+		}
+	}
+	return catalog
+}
+
+// render fills in the template for m, or keeps the raw message when m has
+// no code or its template is missing
+func (c *messageCatalog) render(m validatorMessage) friendlyMessage {
+	plain := friendlyMessage{Text: m.raw, Raw: m.raw, Summary: m.summary, Line: m.line}
+	entry, ok := c.Messages[m.code]
+	if !ok {
+		return plain
+	}
+
+	data := map[string]string{"Summary": m.summary}
+	for key, value := range m.params {
+		data[key] = value
+	}
+	requirement := c.Labels["some_requirement"]
+	if m.summary != "" {
+		requirement = expandTemplate(c.Labels["requirement"], data)
+	}
+	data["Requirement"] = requirement
+
+	text := expandTemplate(entry.Message, data)
+	if text == "" {
+		return plain
+	}
+	return friendlyMessage{
+		Text:     text,
+		Fix:      expandTemplate(entry.Fix, data),
+		FixLabel: c.Labels["fix"],
+		Summary:  m.summary,
+		Line:     m.line,
+		Raw:      m.raw,
+	}
+}
+
+// expandTemplate executes a catalog template, giving "" when it is broken
+func expandTemplate(text string, data map[string]string) string {
+	tmpl, err := template.New("").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	if tmpl.Execute(&b, data) != nil {
+		return ""
+	}
+	return b.String()
+}
+
+// classifyDetail recognizes one problem the validator reports. The
+// requirement it is about is found from its path, or else its line.
+func classifyDetail(detail rqmcore.ErrorDetail, index *requirementIndex) validatorMessage {
+	m := validatorMessage{raw: detail.Message, summary: detail.Summary, line: detail.Line, params: map[string]string{}}
+	if m.summary == "" {
+		m.summary = index.atPath(detail.Path)
+	}
+	// A format error's line is the TOML or JSON file's own. The
+	// validator's lines are those of the YAML form such a file is
+	// converted to: they place the requirement, but aren't the file's.
+	if detail.Code != "format_error" {
+		if m.summary == "" && detail.Line > 0 {
+			m.summary = index.atLine(detail.Line)
+		}
+		if index.converted {
+			m.line = 0
+		}
+	}
+
+	switch detail.Code {
+	case "parse_error":
+		classifyParseError(&m, detail)
+	case "format_error":
+		m.code = "format_error"
+		m.params["Format"], m.params["Detail"] = detail.Params["format"], detail.Params["detail"]
+		if detail.Line > 0 {
+			m.params["Line"] = strconv.Itoa(detail.Line)
+		}
+	case "schema":
+		classifySchemaError(&m, detail)
+	case "duplicate_summary":
+		m.code = detail.Code
+	case "invalid_owner":
+		m.code, m.params["Owner"] = detail.Code, detail.Params["owner"]
+	case "circular_reference":
+		m.code, m.params["Cycle"] = detail.Code, detail.Params["cycle"]
+	case "requirement_not_found", "invalid_reference":
+		m.code, m.params["Reference"] = detail.Code, detail.Params["reference"]
+	}
+	return m
+}
+
+// classifyParseError recognizes an error from deserializing the file. The
+// deserializer's message, without its path and location, tells what kind
+// of error it is.
+func classifyParseError(m *validatorMessage, detail rqmcore.ErrorDetail) {
+	text := detail.Params["detail"]
+	m.code, m.params["Detail"] = "parse_error", text
+	if detail.Line > 0 {
+		m.params["Line"] = strconv.Itoa(detail.Line)
+	}
+	m.params["Field"] = pathField(detail.Path)
+
+	switch {
+	case serdeUnknownVariant.MatchString(text):
+		match := serdeUnknownVariant.FindStringSubmatch(text)
+		m.code, m.params["Value"] = "invalid_value", match[1]
+		m.params["Allowed"] = joinQuoted(backtickPattern, match[2])
+	case serdeMissingField.MatchString(text) && detail.Path != "":
+		// Without a path it is a top-level field such as version
+		m.code, m.params["Field"] = "missing_field", serdeMissingField.FindStringSubmatch(text)[1]
+	case serdeUnknownField.MatchString(text):
+		m.code, m.params["Field"] = "unknown_field", serdeUnknownField.FindStringSubmatch(text)[1]
+	case serdeInvalidType.MatchString(text) && m.params["Field"] != "":
+		match := serdeInvalidType.FindStringSubmatch(text)
+		m.code, m.params["Got"], m.params["Expected"] = "invalid_type", match[1], match[2]
+	case strings.Contains(text, "untagged enum RequirementReference"):
+		m.code = "invalid_sub_requirement"
+	}
+}
+
+// classifySchemaError recognizes a JSON schema violation by the keyword
+// it violates. Violations of the document root, such as a missing
+// version, are kept as they are.
+func classifySchemaError(m *validatorMessage, detail rqmcore.ErrorDetail) {
+	if detail.Path == "" {
+		return
+	}
+	m.params["Field"] = pathField(detail.Path)
+	switch detail.Keyword {
+	case "enum":
+		m.code, m.params["Value"], m.params["Allowed"] = "invalid_value", detail.Params["value"], detail.Params["options"]
+	case "required":
+		m.code, m.params["Field"] = "missing_field", detail.Params["property"]
+	case "additionalProperties":
+		m.code, m.params["Field"] = "unknown_field", detail.Params["unexpected"]
+	case "pattern":
+		m.code, m.params["Value"], m.params["Pattern"] = "invalid_format", detail.Params["value"], detail.Params["pattern"]
+	case "minLength":
+		m.code = "empty_value"
+	case "type":
+		m.code, m.params["Got"], m.params["Expected"] = "invalid_type", detail.Params["value"], detail.Params["expected"]
+	}
+}
+
+// pathField returns the field a deserializer path ends in, such as status
+// for requirements[0].status or tags for requirements[0].tags[1]
+func pathField(path string) string {
+	segments := strings.Split(path, ".")
+	return serdeIndexPattern.ReplaceAllString(segments[len(segments)-1], "")
+}
+
+// joinQuoted lists the quoted words pattern finds in s
+func joinQuoted(pattern *regexp.Regexp, s string) string {
+	var words []string
+	for _, match := range pattern.FindAllStringSubmatch(s, -1) {
+		words = append(words, match[1])
+	}
+	if len(words) == 0 {
+		return s
+	}
+	return strings.Join(words, ", ")
+}
+
+// requirementIndex finds requirements in a file's YAML tree, which works
+// even when the file doesn't parse into requirements
+type requirementIndex struct {
+	root *yaml.Node
+	// converted is set for TOML and JSON files, whose YAML form the
+	// index holds
+	converted bool
+	// requirements are the requirement mappings in file order
+	requirements []*yaml.Node
+}
+
+func newRequirementIndex(file string) *requirementIndex {
+	index := &requirementIndex{converted: model.FormatOf(file) != model.FormatYAML}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return index
+	}
+	// Validator errors on a TOML or JSON file refer to its YAML form
+	if data, err = model.ToYAML(file, data); err != nil {
+		return index
+	}
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) != nil || len(doc.Content) == 0 {
+		return index
+	}
+	index.root = doc.Content[0]
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		list := mappingValue(node, "requirements")
+		if list == nil || list.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, item := range list.Content {
+			if item.Kind == yaml.MappingNode {
+				index.requirements = append(index.requirements, item)
+				walk(item)
+			}
+		}
+	}
+	walk(index.root)
+	return index
+}
+
+// atPath returns the summary of the requirement a deserializer path such
+// as requirements[0].relations[1].type points into
+func (x *requirementIndex) atPath(path string) string {
+	if x.root == nil || path == "" {
+		return ""
+	}
+	node, summary := x.root, ""
+	for _, segment := range strings.Split(path, ".") {
+		name, _, _ := strings.Cut(segment, "[")
+		if node = mappingValue(node, name); node == nil {
+			break
+		}
+		for _, match := range serdeIndexPattern.FindAllStringSubmatch(segment, -1) {
+			i, _ := strconv.Atoi(match[1])
+			if node.Kind != yaml.SequenceNode || i >= len(node.Content) {
+				return summary
+			}
+			node = node.Content[i]
+		}
+		if s := requirementSummary(node); s != "" {
+			summary = s
+		}
+	}
+	return summary
+}
+
+// atLine returns the summary of the last requirement starting at or
+// before line
+func (x *requirementIndex) atLine(line int) string {
+	summary := ""
+	for _, req := range x.requirements {
+		if req.Line <= line {
+			summary = requirementSummary(req)
+		}
+	}
+	return summary
+}
+
+// requirementSummary returns the summary of a requirement mapping, or ""
+func requirementSummary(node *yaml.Node) string {
+	if value := mappingValue(node, "summary"); value != nil && value.Kind == yaml.ScalarNode {
+		return value.Value
+	}
+	return ""
+}
@@ -0,0 +1,219 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/rqmcore"
+)
+
+const messagesTestYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    owner: ghost
+    requirements:
+      - summary: Password policy
+        priority: urgent
+  - summary: Logout
+    status: done
+  - description: No summary here
+`
+
+func TestLocalizeValidationErrors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(messagesTestYAML), 0644)
+	t.Setenv("RQM_LANG", "en")
+
+	tests := []struct {
+		detail  rqmcore.ErrorDetail
+		text    string
+		fix     string
+		summary string
+	}{
+		{
+			detail: rqmcore.ErrorDetail{
+				Code:    "parse_error",
+				Message: "YAML parsing error: requirements[1].status: unknown variant `done`, expected one of `draft`, `proposed` at line 9 column 13",
+				Path:    "requirements[1].status", Line: 9, Column: 13,
+				Params: map[string]string{"detail": "unknown variant `done`, expected one of `draft`, `proposed`"},
+			},
+			text:    "Requirement 'Logout' has status 'done', which is not a valid value",
+			fix:     "use one of: draft, proposed",
+			summary: "Logout",
+		},
+		{
+			detail: rqmcore.ErrorDetail{
+				Code:    "parse_error",
+				Message: "YAML parsing error: requirements[2]: missing field `summary` at line 10 column 5",
+				Path:    "requirements[2]", Line: 10, Column: 5,
+				Params: map[string]string{"detail": "missing field `summary`"},
+			},
+			text: "A requirement has no summary",
+			fix:  "add a summary field; every requirement needs one",
+		},
+		{
+			detail: rqmcore.ErrorDetail{
+				Code:    "parse_error",
+				Message: "YAML parsing error: did not find expected key at line 4 column 3",
+				Line:    4, Column: 3,
+				Params: map[string]string{"detail": "did not find expected key"},
+			},
+			text:    "The file is not valid YAML (line 4): did not find expected key",
+			fix:     "check the indentation (two spaces, no tabs), colons after field names and closing quotes",
+			summary: "Login",
+		},
+		{
+			detail: rqmcore.ErrorDetail{
+				Code:    "format_error",
+				Message: "Parse error: invalid TOML at line 3: expected ']]'",
+				Line:    3,
+				Params:  map[string]string{"format": "TOML", "detail": "expected ']]'"},
+			},
+			text: "The file is not valid TOML (line 3): expected ']]'",
+			fix:  "correct the syntax, or the tool that generates the file",
+		},
+		{
+			detail: rqmcore.ErrorDetail{
+				Code:    "schema",
+				Message: `"urgent" is not one of ["low","medium","high"]`,
+				Path:    "requirements[0].requirements[0].priority",
+				Keyword: "enum",
+				Params:  map[string]string{"value": "urgent", "options": "low, medium, high"},
+			},
+			text:    "Requirement 'Password policy' has priority 'urgent', which is not a valid value",
+			fix:     "use one of: low, medium, high",
+			summary: "Password policy",
+		},
+		{
+			detail:  rqmcore.ErrorDetail{Code: "duplicate_summary", Message: "Duplicate summary: Login", Summary: "Login"},
+			text:    "More than one requirement has the summary 'Login'",
+			fix:     "summaries identify requirements, so reword one of them, or replace the copy with a reference to the original",
+			summary: "Login",
+		},
+		{
+			detail: rqmcore.ErrorDetail{
+				Code:    "invalid_owner",
+				Message: "Invalid owner reference: 'ghost' is not a valid email, GitHub username, or defined alias",
+				Summary: "Login",
+				Params:  map[string]string{"owner": "ghost"},
+			},
+			text:    "Requirement 'Login' has owner 'ghost', which is not an email address, a GitHub username or a defined alias",
+			fix:     "use an email address or @username, or add 'ghost' to the aliases section",
+			summary: "Login",
+		},
+	}
+	for _, tt := range tests {
+		result := &ValidationResult{Errors: []string{tt.detail.Message}, Details: []rqmcore.ErrorDetail{tt.detail}}
+		msgs := localizeValidationErrors(file, result)
+		if len(msgs) != 1 {
+			t.Errorf("%q gave %d messages, want 1", tt.detail.Message, len(msgs))
+			continue
+		}
+		got := msgs[0]
+		if got.Text != tt.text || got.Fix != tt.fix || got.Summary != tt.summary || got.Line != tt.detail.Line || got.Raw != tt.detail.Message {
+			t.Errorf("%q gave\n%+v\nwant text %q, fix %q, summary %q", tt.detail.Message, got, tt.text, tt.fix, tt.summary)
+		}
+	}
+}
+
+func TestLocalizeSchemaErrors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(messagesTestYAML), 0644)
+	t.Setenv("RQM_LANG", "en")
+
+	// One message per problem; problems of the document root, and errors
+	// without details, stay as they are
+	result := &ValidationResult{
+		Errors: []string{
+			`JSON schema validation error: "summary" is a required property; Additional properties are not allowed ('colour' was unexpected)`,
+			"Requirement 'Login' uses undeclared attribute 'risk'",
+		},
+		Details: []rqmcore.ErrorDetail{
+			{Code: "schema", Message: `"summary" is a required property`, Path: "requirements[2]", Keyword: "required", Params: map[string]string{"property": "summary"}},
+			{Code: "schema", Message: "Additional properties are not allowed ('colour' was unexpected)", Keyword: "additionalProperties", Params: map[string]string{"unexpected": "colour"}},
+		},
+	}
+	msgs := localizeValidationErrors(file, result)
+	want := []string{
+		"A requirement has no summary",
+		"Additional properties are not allowed ('colour' was unexpected)",
+		"Requirement 'Login' uses undeclared attribute 'risk'",
+	}
+	if len(msgs) != len(want) {
+		t.Fatalf("Got %d messages, want %d: %+v", len(msgs), len(want), msgs)
+	}
+	for i, msg := range msgs {
+		if msg.Text != want[i] {
+			t.Errorf("Message %d = %q, want %q", i, msg.Text, want[i])
+		}
+	}
+
+	// A validator without details gives its errors as they are
+	raw := "Duplicate summary: Login"
+	if msgs := localizeValidationErrors(file, &ValidationResult{Errors: []string{raw}}); len(msgs) != 1 || msgs[0].Text != raw {
+		t.Errorf("Got %+v, want %q as it is", msgs, raw)
+	}
+}
+
+func TestLocalizeValidationErrorsGerman(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(messagesTestYAML), 0644)
+	t.Setenv("RQM_LANG", "")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+
+	msgs := localizeValidationErrors(file, &ValidationResult{
+		Errors:  []string{"Duplicate summary: Login"},
+		Details: []rqmcore.ErrorDetail{{Code: "duplicate_summary", Message: "Duplicate summary: Login", Summary: "Login"}},
+	})
+	if len(msgs) != 1 || msgs[0].Text != "Mehrere Anforderungen haben die Zusammenfassung 'Login'" || msgs[0].FixLabel != "Lösung" {
+		t.Errorf("Got %+v", msgs)
+	}
+}
+
+func TestMessageLocale(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want string
+	}{
+		{map[string]string{}, "en"},
+		{map[string]string{"LANG": "de_DE.UTF-8"}, "de"},
+		{map[string]string{"LANG": "C"}, "en"},
+		{map[string]string{"LANG": "de_DE.UTF-8", "LC_ALL": "fr_FR"}, "fr"},
+		{map[string]string{"LANG": "de_DE.UTF-8", "RQM_LANG": "en"}, "en"},
+	}
+	for _, tt := range tests {
+		for _, name := range []string{"RQM_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+			t.Setenv(name, tt.env[name])
+		}
+		if got := messageLocale(); got != tt.want {
+			t.Errorf("messageLocale() with %v = %q, want %q", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestMessageCatalogsComplete(t *testing.T) {
+	entries, err := messageFiles.ReadDir("messages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	english := loadMessageCatalog("en")
+	for _, entry := range entries {
+		lang := entry.Name()[:len(entry.Name())-len(filepath.Ext(entry.Name()))]
+		catalog := loadMessageCatalog(lang)
+		for code, tmpl := range catalog.Messages {
+			if _, ok := english.Messages[code]; !ok {
+				t.Errorf("%s: %s is not an English message code", lang, code)
+			}
+			if expandTemplate(tmpl.Message, map[string]string{}) == "" || expandTemplate(tmpl.Fix, map[string]string{}) == "" {
+				t.Errorf("%s: %s has a broken template", lang, code)
+			}
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	migrateTo     string
+	migrateFrom   string
+	migrateDryRun bool
+)
+
+// schemaMigration upgrades a requirements file to the schema version to
+// from the version before it
+type schemaMigration struct {
+	to string
+	// apply rewrites the document's root mapping in place and describes
+	// every transformation it made, one per line
+	apply func(root *yaml.Node) []string
+}
+
+// schemaVersions are the released schema versions, oldest first; the last
+// is supportedSchema
+var schemaVersions = []string{"1.0"}
+
+// schemaMigrations are applied in order, each to files older than its
+// version. 1.0 is the first released schema, so there are none yet; a
+// schema change that renames or restructures fields adds its step here
+// along with its version in schemaVersions and the new supportedSchema.
+var schemaMigrations []schemaMigration
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [file]",
+	Short: "Upgrade a requirements file to the current schema version",
+	Long: `Upgrade a requirements file written for an older schema version to the
+current one, or to --to, applying each version's field renames and
+structural changes in turn and listing every transformation made.
+
+The file is edited as YAML nodes, so comments and key order survive, and
+its original content is kept next to it as <file>.bak. Nothing is written
+with --dry-run, or when the migrated file wouldn't load.
+
+The only schema version so far is 1.0, so migrating a 1.0 file changes
+nothing; a file that declares no version gets version: "1.0" added.
+
+The version a file declares decides which migrations apply. --from
+overrides it for a file that declares no version, or one it doesn't
+follow.`,
+	Example: `  rqm migrate
+  rqm migrate requirements.yml --dry-run
+  rqm migrate unversioned.yml --from 1.0`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		return runMigrate(file)
+	},
+}
+
+// runMigrate migrates file from its schema version, or --from, to --to
+func runMigrate(file string) error {
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		return fmt.Errorf("file does not exist: %s", file)
+	}
+	to := migrateTo
+	if to == "" {
+		to = supportedSchema
+	}
+	if !slices.Contains(schemaVersions, to) {
+		return unknownSchemaError(to)
+	}
+
+	doc, err := loadYAMLDocument(file)
+	if err != nil {
+		return err
+	}
+	root := doc.root()
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s is not a requirements file", file)
+	}
+
+	declared := ""
+	if v := mappingValue(root, "version"); v != nil {
+		declared = v.Value
+	}
+	from := migrateFrom
+	if from == "" {
+		from = declared
+	}
+	switch {
+	case from == "":
+		return fmt.Errorf("%s declares no schema version; pass the one it follows with --from", file)
+	case !slices.Contains(schemaVersions, from):
+		return unknownSchemaError(from)
+	}
+	if compareSchemaVersions(from, to) > 0 {
+		return fmt.Errorf("%s uses schema %s, newer than %s; rqm doesn't downgrade files", file, from, to)
+	}
+
+	fmt.Printf("Migrating %s from schema %s to %s\n", file, from, to)
+	applied := 0
+	for _, m := range schemaMigrations {
+		if compareSchemaVersions(from, m.to) >= 0 || compareSchemaVersions(m.to, to) > 0 {
+			continue
+		}
+		fmt.Printf("\n%s:\n", m.to)
+		changes := m.apply(root)
+		if len(changes) == 0 {
+			fmt.Println("  (nothing to change)")
+		}
+		for _, change := range changes {
+			fmt.Printf("  - %s\n", change)
+		}
+		applied++
+	}
+	if applied == 0 && declared == to {
+		fmt.Println()
+		fmt.Println(okMark(), "Already at schema", to)
+		return nil
+	}
+
+	version := mappingValue(root, "version")
+	if version == nil {
+		version = &yaml.Node{Kind: yaml.ScalarNode}
+		root.Content = append([]*yaml.Node{{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}, version}, root.Content...)
+	}
+	version.Tag, version.Value, version.Style = "!!str", to, yaml.DoubleQuotedStyle
+	fmt.Printf("  - version: %s → \"%s\"\n", displayVersion(declared), to)
+
+	out, err := doc.encode()
+	if err != nil {
+		return err
+	}
+	if _, err := model.Parse(file, out); err != nil {
+		return fmt.Errorf("migrated %s doesn't load, so it was left unchanged: %w", file, err)
+	}
+
+	fmt.Println()
+	if migrateDryRun {
+		fmt.Println("Dry run: nothing was written")
+		return nil
+	}
+	backup := file + ".bak"
+	if _, err := os.Stat(backup); err == nil {
+		return fmt.Errorf("backup %s already exists; move it away and run migrate again", backup)
+	}
+	if err := os.WriteFile(backup, doc.data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", backup, err)
+	}
+	if err := writeRequirementsFile(file, doc.data, out); err != nil {
+		return err
+	}
+	fmt.Printf("%s Migrated %s to schema %s (original kept as %s)\n", okMark(), file, to, backup)
+	return nil
+}
+
+// unknownSchemaError rejects a version that is not a released schema
+func unknownSchemaError(version string) error {
+	return fmt.Errorf("unknown schema version %s: the schema versions are %s", version, strings.Join(schemaVersions, ", "))
+}
+
+// parseSchemaVersion splits a <major>.<minor> schema version
+func parseSchemaVersion(v string) (major, minor int, ok bool) {
+	a, b, found := strings.Cut(v, ".")
+	major, errMajor := strconv.Atoi(a)
+	minor, errMinor := strconv.Atoi(b)
+	return major, minor, found && errMajor == nil && errMinor == nil
+}
+
+// compareSchemaVersions returns a negative number when schema version a is
+// older than b, zero when they're equal and a positive one when it's newer.
+// An empty version, of a file that declares none, is the oldest.
+func compareSchemaVersions(a, b string) int {
+	aMajor, aMinor, _ := parseSchemaVersion(a)
+	bMajor, bMinor, _ := parseSchemaVersion(b)
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return -1
+	case b == "":
+		return 1
+	case aMajor != bMajor:
+		return aMajor - bMajor
+	default:
+		return aMinor - bMinor
+	}
+}
+
+// displayVersion shows the schema version of a file without one as "none"
+func displayVersion(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.Flags().StringVar(&migrateTo, "to", "", "Schema version to migrate to (default: the current one, "+supportedSchema+")")
+	migrateCmd.Flags().StringVar(&migrateFrom, "from", "", "Schema version the file follows, in place of the version it declares")
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "List the transformations without writing the file")
+}
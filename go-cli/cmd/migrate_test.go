@@ -0,0 +1,103 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const currentRequirements = `# Written for schema 1.0
+version: "1.0"
+requirements:
+  - summary: Password login
+    name: AUTH-001
+    deprecated: true
+`
+
+func TestMigrate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	if err := os.WriteFile(file, []byte(currentRequirements), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { migrateTo, migrateFrom = "", "" })
+
+	if err := runMigrate(file); err != nil {
+		t.Errorf("migrating a current file: %v", err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != currentRequirements {
+		t.Errorf("Unexpected change to a current file:\n%s", data)
+	}
+	if _, err := os.Stat(file + ".bak"); !os.IsNotExist(err) {
+		t.Error("Expected no backup for a current file")
+	}
+
+	for _, version := range []string{"0.9", "2.0"} {
+		migrateTo = version
+		if err := runMigrate(file); err == nil || !strings.Contains(err.Error(), "unknown schema version "+version) {
+			t.Errorf("--to %s error = %v", version, err)
+		}
+	}
+}
+
+func TestMigrateUnversioned(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	original := "# Login\nrequirements:\n  - summary: Login\n"
+	if err := os.WriteFile(file, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { migrateFrom, migrateDryRun = "", false })
+
+	if err := runMigrate(file); err == nil || !strings.Contains(err.Error(), "declares no schema version") {
+		t.Errorf("Expected a missing version error, got %v", err)
+	}
+
+	migrateFrom, migrateDryRun = "1.0", true
+	if err := runMigrate(file); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != original {
+		t.Error("--dry-run changed the file")
+	}
+
+	migrateDryRun = false
+	if err := runMigrate(file); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(file + ".bak"); string(data) != original {
+		t.Errorf("backup holds %q, want the original", data)
+	}
+	data, _ := os.ReadFile(file)
+	config, err := model.ParseYAML(data)
+	if err != nil {
+		t.Fatalf("migrated file doesn't parse: %v\n%s", err, data)
+	}
+	if config.Version != "1.0" || !strings.Contains(string(data), "# Login") {
+		t.Errorf("Unexpected migrated file:\n%s", data)
+	}
+}
+
+func TestCompareSchemaVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0", "1.0", 0},
+		{"", "1.0", -1},
+		{"0.9", "1.0", -1},
+		{"1.10", "1.9", 1},
+		{"2.0", "1.5", 1},
+	}
+	for _, tt := range tests {
+		got := compareSchemaVersions(tt.a, tt.b)
+		if (got < 0) != (tt.want < 0) || (got > 0) != (tt.want > 0) {
+			t.Errorf("compareSchemaVersions(%q, %q) = %d, want sign of %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
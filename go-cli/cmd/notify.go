@@ -0,0 +1,327 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// notifyTimeout bounds each notification
+const notifyTimeout = 10 * time.Second
+
+// maxListedRequirements is how many offending requirements a chat message
+// or email names per check; webhooks receive them all
+const maxListedRequirements = 10
+
+// notificationTypes are the destinations a notification can go to
+var notificationTypes = []string{"webhook", "slack", "teams", "email"}
+
+// notificationEvents are the events a destination can subscribe to
+var notificationEvents = []string{"gate_failed", "requirements_changed"}
+
+// NotificationConfig is an entry of the notifications: section of
+// .rqm/config.yml, where rqm ci --notify and rqm gate --notify report a
+// failed gate and rqm notify reports requirement changes
+type NotificationConfig struct {
+	// Type is webhook, slack, teams or email
+	Type string `yaml:"type"`
+	// Events limits the entry to some of notificationEvents; all of them
+	// without it
+	Events []string `yaml:"events,omitempty"`
+	// URL is the webhook, or Slack or Teams incoming webhook URL. URLEnv
+	// names an environment variable holding it instead, which keeps a
+	// secret URL out of the repository.
+	URL    string `yaml:"url,omitempty"`
+	URLEnv string `yaml:"url_env,omitempty"`
+	// Mentions maps owners, as requirements name them, to the handle a
+	// message tags them with, such as <@U024BE7LH> in Slack
+	Mentions map[string]string `yaml:"mentions,omitempty"`
+
+	// Email settings. SMTP is the server as host:port; the credentials
+	// are read from the environment variables named, when set.
+	SMTP        string   `yaml:"smtp,omitempty"`
+	From        string   `yaml:"from,omitempty"`
+	To          []string `yaml:"to,omitempty"`
+	UsernameEnv string   `yaml:"username_env,omitempty"`
+	PasswordEnv string   `yaml:"password_env,omitempty"`
+}
+
+// GateNotification is the JSON body a webhook receives when a gate fails
+type GateNotification struct {
+	// Event is gate_failed
+	Event      string          `json:"event"`
+	Command    string          `json:"command"`
+	File       string          `json:"file"`
+	Repository string          `json:"repository,omitempty"`
+	RunURL     string          `json:"run_url,omitempty"`
+	Violations []GateViolation `json:"violations"`
+}
+
+// GateViolation is a check of the gate that failed, with the requirements
+// that made it fail where they are known
+type GateViolation struct {
+	Check        string   `json:"check"`
+	Result       string   `json:"result"`
+	Details      string   `json:"details"`
+	Requirements []string `json:"requirements,omitempty"`
+}
+
+// notification is an event delivered to the configured destinations:
+// webhooks receive it as JSON, chat and email as text
+type notification interface {
+	event() string
+	subject() string
+	// text is the message, tagging owners found in mentions
+	text(mentions map[string]string) string
+}
+
+// notifier delivers a notification to one destination
+type notifier interface {
+	Notify(ctx context.Context, n notification) error
+}
+
+// checkNotificationConfig checks that a notifications: entry is complete,
+// without looking at the environment it reads secrets from
+func checkNotificationConfig(config NotificationConfig) error {
+	for _, event := range config.Events {
+		if !slices.Contains(notificationEvents, event) {
+			return fmt.Errorf("unknown notification event: %s (expected %s)", event, strings.Join(notificationEvents, " or "))
+		}
+	}
+	switch config.Type {
+	case "webhook", "slack", "teams":
+		if config.URL == "" && config.URLEnv == "" {
+			return fmt.Errorf("%s notification needs url or url_env", config.Type)
+		}
+	case "email":
+		if config.SMTP == "" || config.From == "" || len(config.To) == 0 {
+			return fmt.Errorf("email notification needs smtp, from and to")
+		}
+		if _, _, err := net.SplitHostPort(config.SMTP); err != nil {
+			return fmt.Errorf("email notification smtp must be host:port: %s", config.SMTP)
+		}
+	case "":
+		return fmt.Errorf("notification type is not set (expected %s)", strings.Join(notificationTypes, ", "))
+	default:
+		return fmt.Errorf("unknown notification type: %s (expected %s)", config.Type, strings.Join(notificationTypes, ", "))
+	}
+	return nil
+}
+
+// newNotifier builds the notifier for a notifications: entry
+func newNotifier(config NotificationConfig) (notifier, error) {
+	if err := checkNotificationConfig(config); err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: notifyTimeout}
+
+	url := config.URL
+	if config.URLEnv != "" {
+		if url = os.Getenv(config.URLEnv); url == "" {
+			return nil, fmt.Errorf("notification url variable %s is not set", config.URLEnv)
+		}
+	}
+	switch config.Type {
+	case "webhook":
+		return &webhookNotifier{url: url, client: client}, nil
+	case "slack":
+		return &slackNotifier{url: url, client: client, mentions: config.Mentions}, nil
+	case "teams":
+		return &teamsNotifier{url: url, client: client, mentions: config.Mentions}, nil
+	}
+	return &emailNotifier{config: config}, nil
+}
+
+// sendNotifications delivers n to every destination in the project
+// config that subscribes to its event, returning what couldn't be
+// delivered
+func sendNotifications(project *ProjectConfig, n notification) []error {
+	configs := subscribedNotifications(project, n.event())
+	if len(configs) == 0 {
+		return []error{fmt.Errorf("no notifications for %s configured in .rqm/config.yml", n.event())}
+	}
+	if err := requireOnline("notifications", project); err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, config := range configs {
+		notifier, err := newNotifier(config)
+		if err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+			err = notifier.Notify(ctx, n)
+			cancel()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s notification: %w", config.Type, err))
+		}
+	}
+	return errs
+}
+
+// subscribedNotifications are the entries of the project config that
+// receive event
+func subscribedNotifications(project *ProjectConfig, event string) []NotificationConfig {
+	var configs []NotificationConfig
+	for _, config := range project.Notifications {
+		if len(config.Events) == 0 || slices.Contains(config.Events, event) {
+			configs = append(configs, config)
+		}
+	}
+	return configs
+}
+
+// notifyGateFailure sends the failed checks of command, ci or gate, to the
+// project's notifications, warning about any that can't be delivered
+func notifyGateFailure(command, file string, violations []GateViolation) {
+	project, err := loadProjectConfig(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not sending notifications: %v\n", err)
+		return
+	}
+	n := GateNotification{Event: "gate_failed", Command: command, File: file, Violations: violations}
+	n.Repository, n.RunURL = ciRunEnvironment()
+	for _, err := range sendNotifications(project, n) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// ciRunEnvironment returns the repository and a link to the CI run from
+// the variables GitHub Actions and GitLab CI set
+func ciRunEnvironment() (repository, runURL string) {
+	if repo := os.Getenv("GITHUB_REPOSITORY"); repo != "" {
+		if server, run := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_RUN_ID"); server != "" && run != "" {
+			runURL = server + "/" + repo + "/actions/runs/" + run
+		}
+		return repo, runURL
+	}
+	return os.Getenv("CI_PROJECT_PATH"), os.Getenv("CI_PIPELINE_URL")
+}
+
+func (n GateNotification) event() string { return n.Event }
+
+func (n GateNotification) subject() string {
+	subject := fmt.Sprintf("rqm %s failed for %s", n.Command, n.File)
+	if n.Repository != "" {
+		subject += " in " + n.Repository
+	}
+	return subject
+}
+
+// text lists the failed checks with the requirements that made them fail
+func (n GateNotification) text(map[string]string) string {
+	var b strings.Builder
+	b.WriteString(n.subject() + "\n")
+	for _, v := range n.Violations {
+		fmt.Fprintf(&b, "\n%s: %s (%s)\n", v.Check, v.Result, v.Details)
+		for i, req := range v.Requirements {
+			if i == maxListedRequirements {
+				fmt.Fprintf(&b, "  … and %d more\n", len(v.Requirements)-i)
+				break
+			}
+			fmt.Fprintf(&b, "  - %s\n", req)
+		}
+	}
+	if n.RunURL != "" {
+		fmt.Fprintf(&b, "\n%s\n", n.RunURL)
+	}
+	return b.String()
+}
+
+// webhookNotifier posts the notification as JSON
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, n notification) error {
+	return postJSON(ctx, w.client, w.url, n)
+}
+
+// slackNotifier posts to a Slack incoming webhook
+type slackNotifier struct {
+	url      string
+	client   *http.Client
+	mentions map[string]string
+}
+
+func (s *slackNotifier) Notify(ctx context.Context, n notification) error {
+	return postJSON(ctx, s.client, s.url, map[string]string{"text": n.text(s.mentions)})
+}
+
+// teamsNotifier posts to a Microsoft Teams incoming webhook, whose
+// Markdown only breaks lines at blank lines
+type teamsNotifier struct {
+	url      string
+	client   *http.Client
+	mentions map[string]string
+}
+
+func (t *teamsNotifier) Notify(ctx context.Context, n notification) error {
+	text := strings.ReplaceAll(strings.TrimRight(n.text(t.mentions), "\n"), "\n", "\n\n")
+	return postJSON(ctx, t.client, t.url, map[string]string{"title": n.subject(), "text": text})
+}
+
+// postJSON posts v as JSON to url and fails on any status but 2xx
+func postJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s answered %s", req.URL.Host, resp.Status)
+	}
+	return nil
+}
+
+// emailNotifier sends the notification text by SMTP
+type emailNotifier struct {
+	config NotificationConfig
+}
+
+func (e *emailNotifier) Notify(ctx context.Context, n notification) error {
+	host, _, _ := net.SplitHostPort(e.config.SMTP)
+	var auth smtp.Auth
+	if e.config.UsernameEnv != "" {
+		auth = smtp.PlainAuth("", os.Getenv(e.config.UsernameEnv), os.Getenv(e.config.PasswordEnv), host)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\n", e.config.From, strings.Join(e.config.To, ", "), n.subject())
+	msg.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(n.text(nil), "\n", "\r\n"))
+
+	// net/smtp has no context; the deadline still bounds the whole send
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(e.config.SMTP, auth, e.config.From, e.config.To, []byte(msg.String()))
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,262 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	notifyBase   string
+	notifyDryRun bool
+)
+
+// servePollInterval is how often serve --notify looks for changes to the
+// requirements file
+const servePollInterval = 2 * time.Second
+
+// ChangeNotification is the JSON body a webhook receives when
+// requirements change
+type ChangeNotification struct {
+	// Event is requirements_changed
+	Event      string               `json:"event"`
+	File       string               `json:"file"`
+	Base       string               `json:"base"`
+	Repository string               `json:"repository,omitempty"`
+	RunURL     string               `json:"run_url,omitempty"`
+	Added      []RequirementRef     `json:"added"`
+	Changed    []ChangedRequirement `json:"changed"`
+	Removed    []RequirementRef     `json:"removed"`
+
+	// config resolves owners for mentions
+	config *model.RequirementConfig
+}
+
+// ChangedRequirement is a changed requirement in a ChangeNotification,
+// with its status transition when the status changed
+type ChangedRequirement struct {
+	ID        string   `json:"id,omitempty"`
+	Summary   string   `json:"summary"`
+	Owner     string   `json:"owner,omitempty"`
+	Fields    []string `json:"fields"`
+	OldStatus string   `json:"old_status,omitempty"`
+	NewStatus string   `json:"new_status,omitempty"`
+}
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify [file]",
+	Short: "Post requirement changes to Slack, Teams or webhooks",
+	Long: `Compare a requirements file with its version at the previous commit and
+post the requirements added, changed and removed to the destinations under
+notifications: in .rqm/config.yml. Run it in CI after a push to the main
+branch, or keep rqm serve --notify running to report edits as they are
+saved.
+
+  notifications:
+    - type: slack              # or teams, webhook, email
+      url_env: SLACK_WEBHOOK_URL
+      events: [requirements_changed]
+      mentions:
+        "@alice": "<@U024BE7LH>"
+
+When a requirement's status changes, its owner is tagged with the handle
+mentions maps them to; owners are matched through the file's aliases, so
+@alice, her GitHub handle and her email all find the same entry. Webhooks
+receive the changes as JSON. events: limits an entry to gate_failed (rqm
+ci --notify) or requirements_changed; without it an entry gets both.
+
+Nothing is sent when no requirement changed. --dry-run prints the message
+instead of sending it.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm notify
+  rqm notify requirements.yml --base origin/main
+  rqm notify --dry-run`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		current, changes, err := compareWithRef(notifyBase, file)
+		if err != nil {
+			return err
+		}
+		if len(changes.Added)+len(changes.Changed)+len(changes.Removed) == 0 {
+			fmt.Printf("No requirement changes in %s compared with %s\n", file, notifyBase)
+			return nil
+		}
+
+		n := newChangeNotification(file, notifyBase, current, changes)
+		n.Repository, n.RunURL = ciRunEnvironment()
+		if notifyDryRun {
+			fmt.Print(n.text(nil))
+			return nil
+		}
+		project, err := loadProjectConfig(file)
+		if err != nil {
+			return err
+		}
+		errs := sendNotifications(project, n)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "%s %v\n", failMark(), err)
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("%d notification(s) could not be delivered", len(errs))
+		}
+		fmt.Printf("%s Notified %d destination(s) of %s\n", okMark(), len(subscribedNotifications(project, n.Event)), n.subject())
+		return nil
+	},
+}
+
+// newChangeNotification describes changes to file since base, where
+// current is the file now
+func newChangeNotification(file, base string, current *model.RequirementConfig, changes requirementChanges) ChangeNotification {
+	diff := newDiffResponse(file, base, changes)
+	n := ChangeNotification{
+		Event:   "requirements_changed",
+		File:    file,
+		Base:    base,
+		Added:   diff.Added,
+		Changed: []ChangedRequirement{},
+		Removed: diff.Removed,
+		config:  current,
+	}
+	for _, c := range changes.Changed {
+		changed := ChangedRequirement{ID: c.New.Name, Summary: c.New.Summary, Owner: c.New.Owner, Fields: c.Fields}
+		if c.Old.Status != c.New.Status {
+			changed.OldStatus, changed.NewStatus = orUnset(c.Old.Status), orUnset(c.New.Status)
+		}
+		n.Changed = append(n.Changed, changed)
+	}
+	return n
+}
+
+func (n ChangeNotification) event() string { return n.Event }
+
+func (n ChangeNotification) subject() string {
+	subject := fmt.Sprintf("Requirements changed in %s", n.File)
+	if n.Repository != "" {
+		subject += " in " + n.Repository
+	}
+	return subject
+}
+
+// text lists the changes, tagging the owner of each requirement whose
+// status changed
+func (n ChangeNotification) text(mentions map[string]string) string {
+	config := n.config
+	if config == nil {
+		config = &model.RequirementConfig{}
+	}
+	byOwner := make(map[string]string, len(mentions))
+	for owner, handle := range mentions {
+		byOwner[config.OwnerKey(owner)] = handle
+	}
+	label := func(id, summary string) string {
+		return requirementLabel(&model.RequirementDetail{Name: id, Summary: summary})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s since %s: %d added, %d changed, %d removed\n", n.subject(), n.Base, len(n.Added), len(n.Changed), len(n.Removed))
+	writeList := func(heading string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s:\n", heading)
+		for i, item := range items {
+			if i == maxListedRequirements {
+				fmt.Fprintf(&b, "  … and %d more\n", len(items)-i)
+				break
+			}
+			fmt.Fprintf(&b, "  - %s\n", item)
+		}
+	}
+
+	var added, changed, removed []string
+	for _, req := range n.Added {
+		added = append(added, label(req.ID, req.Summary))
+	}
+	// Status changes come first, as they are what owners are tagged for
+	var statusChanges, others []string
+	for _, c := range n.Changed {
+		if c.OldStatus == "" {
+			others = append(others, fmt.Sprintf("%s (%s)", label(c.ID, c.Summary), strings.Join(c.Fields, ", ")))
+			continue
+		}
+		line := fmt.Sprintf("%s: %s → %s", label(c.ID, c.Summary), c.OldStatus, c.NewStatus)
+		if c.Owner != "" {
+			owner := displayOwner(config.Aliases, c.Owner)
+			if handle, ok := byOwner[config.OwnerKey(c.Owner)]; ok {
+				owner = handle
+			}
+			line += " — " + owner
+		}
+		statusChanges = append(statusChanges, line)
+	}
+	changed = append(statusChanges, others...)
+	for _, req := range n.Removed {
+		removed = append(removed, label(req.ID, req.Summary))
+	}
+	writeList("Added", added)
+	writeList("Changed", changed)
+	writeList("Removed", removed)
+
+	if n.RunURL != "" {
+		fmt.Fprintf(&b, "\n%s\n", n.RunURL)
+	}
+	return b.String()
+}
+
+// watchForNotifications is serve --notify: it polls file, and the files it
+// includes, and sends the changes between each saved version and the
+// previous one. Versions that don't parse are skipped, so a half-finished
+// edit isn't reported.
+func watchForNotifications(file string, project *ProjectConfig) {
+	previousHash, err := requirementsTreeHash(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: notifications stopped: %v\n", err)
+		return
+	}
+	previous, _ := model.Load(file)
+	for range time.Tick(servePollInterval) {
+		hash, err := requirementsTreeHash(file)
+		if err != nil || hash == previousHash {
+			continue
+		}
+		current, err := model.Load(file)
+		if err != nil {
+			continue
+		}
+		old := previous
+		previous, previousHash = current, hash
+		if old == nil {
+			continue
+		}
+
+		changes := diffRequirements(old, current)
+		if len(changes.Added)+len(changes.Changed)+len(changes.Removed) == 0 {
+			continue
+		}
+		n := newChangeNotification(file, "the previous save", current, changes)
+		for _, err := range sendNotifications(project, n) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.Flags().StringVar(&notifyBase, "base", "HEAD~1", "Git ref to compare against")
+	notifyCmd.Flags().BoolVar(&notifyDryRun, "dry-run", false, "Print the message instead of sending it")
+}
@@ -0,0 +1,189 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestCheckNotificationConfig(t *testing.T) {
+	tests := []struct {
+		config      NotificationConfig
+		errContains string
+	}{
+		{config: NotificationConfig{Type: "webhook", URL: "https://example.com/hook"}},
+		{config: NotificationConfig{Type: "slack", URLEnv: "SLACK_WEBHOOK_URL"}},
+		{config: NotificationConfig{Type: "email", SMTP: "smtp.example.com:587", From: "rqm@example.com", To: []string{"team@example.com"}}},
+		{config: NotificationConfig{Type: "slack"}, errContains: "needs url or url_env"},
+		{config: NotificationConfig{Type: "email", SMTP: "smtp.example.com", From: "a@b", To: []string{"c@d"}}, errContains: "host:port"},
+		{config: NotificationConfig{Type: "pager"}, errContains: "unknown notification type: pager"},
+		{config: NotificationConfig{}, errContains: "type is not set"},
+	}
+	for _, tt := range tests {
+		err := checkNotificationConfig(tt.config)
+		if tt.errContains == "" && err != nil {
+			t.Errorf("checkNotificationConfig(%+v): %v", tt.config, err)
+		}
+		if tt.errContains != "" && (err == nil || !strings.Contains(err.Error(), tt.errContains)) {
+			t.Errorf("checkNotificationConfig(%+v): expected error containing %q, got %v", tt.config, tt.errContains, err)
+		}
+	}
+}
+
+func TestNotifyCIFailure(t *testing.T) {
+	var webhook GateNotification
+	var slack map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/hook":
+			json.NewDecoder(r.Body).Decode(&webhook)
+		case "/slack":
+			json.NewDecoder(r.Body).Decode(&slack)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".rqm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := fmt.Sprintf("notifications:\n  - type: webhook\n    url: %s/hook\n  - type: slack\n    url_env: TEST_SLACK_URL\n", server.URL)
+	if err := os.WriteFile(filepath.Join(tmpDir, ".rqm", "config.yml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_SLACK_URL", server.URL+"/slack")
+	t.Setenv("GITHUB_REPOSITORY", "acme/widgets")
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_RUN_ID", "42")
+	t.Setenv("RQM_OFFLINE", "")
+
+	file := filepath.Join(tmpDir, "requirements.yml")
+	steps := []ciStep{
+		{Name: "validate", Result: "pass", Details: "0 error(s), 0 warning(s)"},
+		{Name: "coverage", Result: "fail", Details: "1/3 requirement(s) verified (33%), below 80%", Requirements: []string{"AUTH-001  Login", "Audit log"}},
+	}
+	notifyCIFailure(file, steps)
+
+	if webhook.Event != "gate_failed" || webhook.RunURL != "https://github.com/acme/widgets/actions/runs/42" {
+		t.Errorf("Unexpected webhook payload %+v", webhook)
+	}
+	if len(webhook.Violations) != 1 || webhook.Violations[0].Check != "coverage" || len(webhook.Violations[0].Requirements) != 2 {
+		t.Errorf("Expected only the coverage violation with its requirements, got %+v", webhook.Violations)
+	}
+	for _, want := range []string{"rqm ci failed", "in acme/widgets", "coverage: fail", "  - Audit log"} {
+		if !strings.Contains(slack["text"], want) {
+			t.Errorf("Expected %q in the Slack message:\n%s", want, slack["text"])
+		}
+	}
+}
+
+func TestSendNotificationsOffline(t *testing.T) {
+	t.Setenv("RQM_OFFLINE", "1")
+	project := &ProjectConfig{Notifications: []NotificationConfig{{Type: "webhook", URL: "http://127.0.0.1:1/hook"}}}
+	errs := sendNotifications(project, GateNotification{})
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "offline mode") {
+		t.Errorf("Expected offline mode to stop notifications, got %v", errs)
+	}
+}
+
+func TestChangeNotificationText(t *testing.T) {
+	old, err := model.ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: draft
+    owner: "@alice"
+  - summary: Audit log
+    description: Old wording
+  - summary: Backups
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := model.ParseYAML([]byte(`version: "1.0"
+aliases:
+  - alias: alice
+    name: Alice Smith
+    email: alice@example.com
+    github: asmith
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: approved
+    owner: "@alice"
+  - summary: Audit log
+    description: New wording
+  - summary: Rate limiting
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := newChangeNotification("requirements.yml", "HEAD~1", current, diffRequirements(old, current))
+	text := n.text(map[string]string{"@asmith": "<@U024BE7LH>"})
+	for _, want := range []string{
+		"Requirements changed in requirements.yml since HEAD~1: 1 added, 2 changed, 1 removed",
+		"  - AUTH-001  Login: draft → approved — <@U024BE7LH>",
+		"  - Audit log (description)",
+		"Added:\n  - Rate limiting",
+		"Removed:\n  - Backups",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+
+	// Without a mention the owner is named through the aliases
+	if text := n.text(nil); !strings.Contains(text, "approved — Alice Smith <alice@example.com>") {
+		t.Errorf("Expected the owner's alias in:\n%s", text)
+	}
+}
+
+func TestChangeNotificationDelivery(t *testing.T) {
+	var teams map[string]string
+	hookCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/teams":
+			json.NewDecoder(r.Body).Decode(&teams)
+		case "/hook":
+			hookCalls++
+		}
+	}))
+	defer server.Close()
+	t.Setenv("RQM_OFFLINE", "")
+
+	// The webhook only subscribes to failed gates
+	project := &ProjectConfig{Notifications: []NotificationConfig{
+		{Type: "teams", URL: server.URL + "/teams"},
+		{Type: "webhook", URL: server.URL + "/hook", Events: []string{"gate_failed"}},
+	}}
+	n := ChangeNotification{Event: "requirements_changed", File: "requirements.yml", Base: "HEAD~1", Added: []RequirementRef{{Summary: "Login"}}}
+	if errs := sendNotifications(project, n); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if teams["title"] != "Requirements changed in requirements.yml" || !strings.Contains(teams["text"], "Added:\n\n  - Login") {
+		t.Errorf("Unexpected Teams message %+v", teams)
+	}
+	if hookCalls != 0 {
+		t.Errorf("Expected the webhook not to receive requirements_changed, got %d call(s)", hookCalls)
+	}
+
+	project.Notifications = project.Notifications[1:]
+	if errs := sendNotifications(project, n); len(errs) != 1 || !strings.Contains(errs[0].Error(), "no notifications for requirements_changed") {
+		t.Errorf("Expected an error without subscribed notifications, got %v", errs)
+	}
+}
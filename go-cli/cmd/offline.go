@@ -0,0 +1,33 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/viper"
+)
+
+// offlineMode reports whether network access is disabled: by RQM_OFFLINE,
+// by offline: true in ~/.rqm.yaml, or by offline: true in the project
+// config, which may be nil
+func offlineMode(project *ProjectConfig) bool {
+	if on, err := strconv.ParseBool(os.Getenv("RQM_OFFLINE")); err == nil {
+		return on
+	}
+	return viper.GetBool("offline") || project != nil && project.Offline
+}
+
+// requireOnline fails features that would reach the network, naming the
+// feature, when offlineMode is on. Every network access goes through it,
+// so an offline installation never tries to connect anywhere.
+func requireOnline(feature string, project *ProjectConfig) error {
+	if !offlineMode(project) {
+		return nil
+	}
+	return fmt.Errorf("%s needs network access, which is disabled in offline mode (offline: true or RQM_OFFLINE)", feature)
+}
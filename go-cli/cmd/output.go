@@ -0,0 +1,303 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// outputSchemaVersion is the latest version of the JSON output contract.
+// It is bumped only for incompatible changes; adding optional fields is
+// not one.
+const outputSchemaVersion = 1
+
+// supportedAPIVersions are the output versions --api-version accepts
+var supportedAPIVersions = []int{1}
+
+// deprecatedAPIVersions maps versions still accepted but scheduled for
+// removal to the warning shown when they are used
+var deprecatedAPIVersions = map[int]string{}
+
+var (
+	apiVersionFlag int
+
+	// apiVersion is the output version in effect for this run
+	apiVersion = outputSchemaVersion
+
+	jsonOutput  bool
+	quietOutput bool
+
+	// restoreOutput undoes the --quiet redirection of stdout and stderr
+	restoreOutput = func() {}
+)
+
+// applyOutputMode applies the global --json and --quiet flags. --json is
+// shorthand for --format json on commands with a JSON output schema;
+// --quiet discards all output so only the exit code is left.
+func applyOutputMode(cmd *cobra.Command) error {
+	if jsonOutput && quietOutput {
+		return fmt.Errorf("--json and --quiet cannot be used together")
+	}
+
+	if jsonOutput {
+		format := cmd.Flags().Lookup("format")
+		if cmd.Parent() != cmd.Root() || format == nil || !slices.Contains(outputSchemaCommands(), cmd.Name()) {
+			return fmt.Errorf("--json is not supported by rqm %s", cmd.Name())
+		}
+		if format.Changed && format.Value.String() != "json" {
+			return fmt.Errorf("--json conflicts with --format %s", format.Value.String())
+		}
+		if err := format.Value.Set("json"); err != nil {
+			return err
+		}
+	}
+
+	if quietOutput {
+		devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+		if err != nil {
+			return err
+		}
+		cmd.Root().SilenceErrors = true
+		cmd.Root().SilenceUsage = true
+		stdout, stderr := os.Stdout, os.Stderr
+		os.Stdout, os.Stderr = devNull, devNull
+		restoreOutput = func() {
+			os.Stdout, os.Stderr = stdout, stderr
+			devNull.Close()
+			restoreOutput = func() {}
+		}
+	}
+	return nil
+}
+
+// resolveAPIVersion applies --api-version, falling back to RQM_API_VERSION
+// and then the latest version. A deprecated version still works but prints
+// a warning to stderr so scripts notice before it is removed.
+func resolveAPIVersion(requested int) (int, error) {
+	if requested == 0 {
+		if env := os.Getenv("RQM_API_VERSION"); env != "" {
+			n, err := strconv.Atoi(env)
+			if err != nil {
+				return 0, fmt.Errorf("invalid RQM_API_VERSION: %s", env)
+			}
+			requested = n
+		}
+	}
+	if requested == 0 {
+		return outputSchemaVersion, nil
+	}
+
+	if !slices.Contains(supportedAPIVersions, requested) {
+		versions := make([]string, len(supportedAPIVersions))
+		for i, v := range supportedAPIVersions {
+			versions[i] = strconv.Itoa(v)
+		}
+		return 0, fmt.Errorf("unsupported --api-version %d (supported: %s)", requested, strings.Join(versions, ", "))
+	}
+	if msg, ok := deprecatedAPIVersions[requested]; ok {
+		fmt.Fprintf(os.Stderr, "%s API version %d is deprecated: %s\n", warnMark(), requested, msg)
+	}
+	return requested, nil
+}
+
+// outputSchemas holds the JSON Schemas for each command's JSON output,
+// named <command>.v<version>.json
+//
+//go:embed schemas/output/*.json
+var outputSchemas embed.FS
+
+// ListOutput is the JSON payload of rqm list --format json
+type ListOutput struct {
+	SchemaVersion int                       `json:"schema_version"`
+	File          string                    `json:"file,omitempty"` // set by list -r
+	Version       string                    `json:"version"`
+	Aliases       []model.PersonAlias       `json:"aliases,omitempty"`
+	Requirements  []model.RequirementDetail `json:"requirements"`
+}
+
+// ValidateOutput is the JSON payload of rqm validate --format json
+type ValidateOutput struct {
+	SchemaVersion int      `json:"schema_version"`
+	File          string   `json:"file"`
+	Valid         bool     `json:"valid"`
+	Errors        []string `json:"errors"`
+	Warnings      []string `json:"warnings"`
+
+	// WarningDetails are the categorized warnings among Warnings, and
+	// WarningCounts their number per category
+	WarningDetails []ValidationWarning `json:"warning_details,omitempty"`
+	WarningCounts  map[string]int      `json:"warning_counts,omitempty"`
+}
+
+// CheckOutput is the JSON payload of rqm check --format json
+type CheckOutput struct {
+	SchemaVersion    int               `json:"schema_version"`
+	File             string            `json:"file"`
+	HasCycles        bool              `json:"has_cycles"`
+	Cycles           [][]string        `json:"cycles"`
+	InactiveOwners   []InactiveOwner   `json:"inactive_owners,omitempty"`
+	CoverageGaps     []CoverageGap     `json:"coverage_gaps,omitempty"`
+	ExternalProblems []ExternalProblem `json:"external_problems,omitempty"`
+}
+
+// GraphOutput is the JSON payload of rqm graph --format json
+type GraphOutput struct {
+	SchemaVersion int                         `json:"schema_version"`
+	File          string                      `json:"file"`
+	Graph         map[string][]string         `json:"graph"`
+	Relations     map[string][]model.Relation `json:"relations,omitempty"`
+	// External maps summaries to their external references
+	External map[string][]model.ExternalReference `json:"external,omitempty"`
+	// Deprecated lists the summaries of deprecated requirements
+	Deprecated []string   `json:"deprecated,omitempty"`
+	HasCycles  bool       `json:"has_cycles"`
+	Cycles     [][]string `json:"cycles"`
+}
+
+// DedupeOutput is the JSON payload of rqm dedupe --format json
+type DedupeOutput struct {
+	SchemaVersion int             `json:"schema_version"`
+	File          string          `json:"file"`
+	Threshold     float64         `json:"threshold"`
+	Duplicates    []DuplicatePair `json:"duplicates"`
+}
+
+// ReviewOutput is the JSON payload of rqm review --format json
+type ReviewOutput struct {
+	SchemaVersion int    `json:"schema_version"`
+	File          string `json:"file"`
+	// Mode is llm, or heuristics with --heuristics-only
+	Mode     string          `json:"mode"`
+	Reviewed int             `json:"reviewed"`
+	Findings []ReviewFinding `json:"findings"`
+}
+
+// ShowOutput is the JSON payload of rqm show --format json
+type ShowOutput struct {
+	SchemaVersion int                      `json:"schema_version"`
+	File          string                   `json:"file"`
+	Requirement   *model.RequirementDetail `json:"requirement"`
+	Parent        *RelatedRequirement      `json:"parent,omitempty"`
+	Children      []RelatedRequirement     `json:"children"`
+	ReferencedBy  []IncomingReference      `json:"referenced_by"`
+	Linked        []LinkedArtifact         `json:"linked"`
+}
+
+// StatsOutput is the JSON payload of rqm stats --format json
+type StatsOutput struct {
+	SchemaVersion int                 `json:"schema_version"`
+	File          string              `json:"file"`
+	Requirements  int                 `json:"requirements"`
+	ByStatus      map[string]int      `json:"by_status"`
+	ByPriority    map[string]int      `json:"by_priority"`
+	Estimate      EstimateTotals      `json:"estimate"`
+	Subtrees      []SubtreeEstimate   `json:"subtrees"`
+	Milestones    []MilestoneEstimate `json:"milestones"`
+}
+
+// HashOutput is the JSON payload of rqm hash --format json
+type HashOutput struct {
+	SchemaVersion int               `json:"schema_version"`
+	File          string            `json:"file"`
+	Algorithm     string            `json:"algorithm"`
+	Requirements  []RequirementHash `json:"requirements"`
+}
+
+// writeJSON prints v as indented JSON on stdout
+func writeJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// nonNil keeps empty lists as [] rather than null in JSON output
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// outputSchema returns the schema for a command's JSON output at the given version
+func outputSchema(command string, version int) ([]byte, error) {
+	data, err := outputSchemas.ReadFile(fmt.Sprintf("schemas/output/%s.v%d.json", command, version))
+	if err != nil {
+		return nil, fmt.Errorf("no v%d output schema for command: %s (available: %s)",
+			version, command, strings.Join(outputSchemaCommands(), ", "))
+	}
+	return data, nil
+}
+
+// outputSchemaCommands lists the commands that have a JSON output schema
+func outputSchemaCommands() []string {
+	entries, _ := fs.ReadDir(outputSchemas, "schemas/output")
+	seen := make(map[string]bool)
+	var commands []string
+	for _, entry := range entries {
+		command, _, _ := strings.Cut(strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())), ".")
+		if !seen[command] {
+			seen[command] = true
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print JSON Schemas used by rqm",
+	Long:  `Print the JSON Schemas that describe rqm's machine-readable formats.`,
+}
+
+var schemaOutputCmd = &cobra.Command{
+	Use:   "output [command]",
+	Short: "Print the JSON Schema for a command's --format json output",
+	Long: `Print the JSON Schema for a command's --format json output.
+
+Every JSON payload carries a schema_version field matching the schema's
+version, so parsers can check they are reading a format they understand.
+The schema printed is the one for --api-version (the latest by default).
+Without a command, lists the commands that have an output schema.`,
+	Example: `  rqm schema output validate
+  rqm schema output list > list.schema.json`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return outputSchemaCommands(), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			for _, command := range outputSchemaCommands() {
+				fmt.Println(command)
+			}
+			return nil
+		}
+
+		data, err := outputSchema(args[0], apiVersion)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.AddCommand(schemaOutputCmd)
+}
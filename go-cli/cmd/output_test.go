@@ -0,0 +1,266 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// TestOutputSchemasMatchPayloads checks every JSON payload against its
+// published schema: required keys are present, no undeclared keys are
+// emitted and schema_version matches the schema's const
+func TestOutputSchemasMatchPayloads(t *testing.T) {
+	payloads := map[string]interface{}{
+		"list": ListOutput{
+			SchemaVersion: outputSchemaVersion,
+			Version:       "1.0",
+			Aliases:       []model.PersonAlias{{Alias: "alice"}},
+			Requirements:  []model.RequirementDetail{{Summary: "A"}},
+		},
+		"validate": ValidateOutput{SchemaVersion: outputSchemaVersion, File: "r.yml", Valid: true, Errors: []string{}, Warnings: []string{}},
+		"check":    CheckOutput{SchemaVersion: outputSchemaVersion, File: "r.yml", Cycles: [][]string{}},
+		"hash": HashOutput{
+			SchemaVersion: outputSchemaVersion,
+			File:          "r.yml",
+			Algorithm:     "sha256",
+			Requirements:  []RequirementHash{{ID: "A-1", Summary: "A", Hash: "00"}},
+		},
+		"dedupe": DedupeOutput{
+			SchemaVersion: outputSchemaVersion,
+			File:          "r.yml",
+			Threshold:     0.8,
+			Duplicates:    []DuplicatePair{{A: DuplicateRequirement{Summary: "A"}, B: DuplicateRequirement{Summary: "B"}, Similarity: 0.9}},
+		},
+		"show": ShowOutput{
+			SchemaVersion: outputSchemaVersion,
+			File:          "r.yml",
+			Requirement:   &model.RequirementDetail{Summary: "A"},
+			Parent:        &RelatedRequirement{Summary: "P"},
+			Children:      []RelatedRequirement{},
+			ReferencedBy:  []IncomingReference{{RelatedRequirement: RelatedRequirement{Summary: "B"}, Kind: "depends_on"}},
+			Linked:        []LinkedArtifact{{Kind: "verified_by", Target: "a_test.go"}},
+		},
+		"review": ReviewOutput{
+			SchemaVersion: outputSchemaVersion,
+			File:          "r.yml",
+			Mode:          "heuristics",
+			Reviewed:      1,
+			Findings:      []ReviewFinding{{Summary: "A", Kind: "untestable", Field: "description", Excerpt: "fast", Message: "m", Suggestion: "s"}},
+		},
+		"stats": StatsOutput{
+			SchemaVersion: outputSchemaVersion,
+			File:          "r.yml",
+			Requirements:  2,
+			ByStatus:      map[string]int{"draft": 2},
+			ByPriority:    map[string]int{},
+			Estimate:      EstimateTotals{Total: 3, Remaining: 3, Estimated: 1, Unestimated: 1},
+			Subtrees:      []SubtreeEstimate{{Summary: "A", EstimateTotals: EstimateTotals{Total: 3, Remaining: 3, Estimated: 1, Unestimated: 1}}},
+			Milestones:    []MilestoneEstimate{{Milestone: "2.0", EstimateTotals: EstimateTotals{Total: 3, Remaining: 3, Estimated: 1}}},
+		},
+		"diff": DiffResponse{
+			SchemaVersion: outputSchemaVersion,
+			File:          "r.yml",
+			Base:          "HEAD",
+			Added:         []RequirementRef{{ID: "A-1", Summary: "A"}},
+			Changed: []RequirementDiff{{Summary: "B", Fields: []string{"description"}, Text: map[string]TextDiff{
+				"description": {Ops: []wordDiffOp{{Op: "insert", Text: "new"}}, HTML: "<ins>new</ins>"},
+			}}},
+			Removed: []RequirementRef{},
+		},
+		"graph": GraphOutput{
+			SchemaVersion: outputSchemaVersion,
+			File:          "r.yml",
+			Graph:         map[string][]string{"A": {}},
+			Relations:     map[string][]model.Relation{"A": {{Type: "depends_on", Target: "B"}}},
+			Cycles:        [][]string{},
+		},
+	}
+
+	if got, want := outputSchemaCommands(), sortedKeys(payloads); !reflect.DeepEqual(got, want) {
+		t.Errorf("Schemas exist for %v, payloads for %v", got, want)
+	}
+
+	for command, payload := range payloads {
+		t.Run(command, func(t *testing.T) {
+			data, err := outputSchema(command, outputSchemaVersion)
+			if err != nil {
+				t.Fatalf("Missing schema: %v", err)
+			}
+			var schema struct {
+				Required   []string                          `json:"required"`
+				Properties map[string]map[string]interface{} `json:"properties"`
+			}
+			if err := json.Unmarshal(data, &schema); err != nil {
+				t.Fatalf("Schema is not valid JSON: %v", err)
+			}
+
+			encoded, _ := json.Marshal(payload)
+			var fields map[string]interface{}
+			json.Unmarshal(encoded, &fields)
+
+			for _, key := range schema.Required {
+				if _, ok := fields[key]; !ok {
+					t.Errorf("Payload is missing required key %q", key)
+				}
+			}
+			for key := range fields {
+				if _, ok := schema.Properties[key]; !ok {
+					t.Errorf("Payload key %q is not declared in the schema", key)
+				}
+			}
+			if got := schema.Properties["schema_version"]["const"]; got != float64(outputSchemaVersion) {
+				t.Errorf("Schema version const = %v, want %d", got, outputSchemaVersion)
+			}
+		})
+	}
+}
+
+func TestOutputSchemaUnknown(t *testing.T) {
+	if _, err := outputSchema("frobnicate", outputSchemaVersion); err == nil {
+		t.Error("Expected error for unknown command")
+	}
+	if _, err := outputSchema("list", 99); err == nil {
+		t.Error("Expected error for unknown version")
+	}
+}
+
+func TestResolveAPIVersion(t *testing.T) {
+	defer func(saved map[int]string) { deprecatedAPIVersions = saved }(deprecatedAPIVersions)
+	deprecatedAPIVersions = map[int]string{1: "use --api-version 2"}
+
+	tests := []struct {
+		name      string
+		requested int
+		env       string
+		want      int
+		wantErr   bool
+	}{
+		{name: "latest by default", want: outputSchemaVersion},
+		{name: "explicit", requested: 1, want: 1},
+		{name: "from environment", env: "1", want: 1},
+		{name: "flag beats environment", requested: 1, env: "7", want: 1},
+		{name: "unsupported", requested: 99, wantErr: true},
+		{name: "invalid environment", env: "one", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RQM_API_VERSION", tt.env)
+			got, err := resolveAPIVersion(tt.requested)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveAPIVersion(%d) error = %v, wantErr %v", tt.requested, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveAPIVersion(%d) = %d, want %d", tt.requested, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestJSONFlagUsage checks --json names every command with an output
+// schema, and that each of those has a --format flag for it to set
+func TestJSONFlagUsage(t *testing.T) {
+	usage := rootCmd.PersistentFlags().Lookup("json").Usage
+	for _, command := range outputSchemaCommands() {
+		if !strings.Contains(usage, command) {
+			t.Errorf("--json usage %q doesn't name %s", usage, command)
+		}
+		cmd, _, err := rootCmd.Find([]string{command})
+		if err != nil || cmd.Flags().Lookup("format") == nil {
+			t.Errorf("rqm %s has no --format flag: %v", command, err)
+		}
+	}
+}
+
+// TestSupportedAPIVersionsHaveSchemas guards against dropping a schema file
+// for a version that --api-version still accepts
+func TestSupportedAPIVersionsHaveSchemas(t *testing.T) {
+	for _, version := range supportedAPIVersions {
+		for _, command := range outputSchemaCommands() {
+			if _, err := outputSchema(command, version); err != nil {
+				t.Errorf("Version %d: %v", version, err)
+			}
+		}
+	}
+}
+
+// newOutputModeCommand builds root -> name with a --format flag
+func newOutputModeCommand(name string, format string) *cobra.Command {
+	root := &cobra.Command{Use: "rqm"}
+	child := &cobra.Command{Use: name}
+	var value string
+	child.Flags().StringVar(&value, "format", "text", "")
+	if format != "" {
+		child.Flags().Set("format", format)
+	}
+	root.AddCommand(child)
+	return child
+}
+
+func TestApplyOutputModeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		format  string
+		quiet   bool
+		want    string
+	}{
+		{name: "sets format", command: "validate", want: "json"},
+		{name: "explicit json", command: "list", format: "json", want: "json"},
+		{name: "conflicting format", command: "check", format: "text", want: "error: --json conflicts with --format text"},
+		{name: "no json schema", command: "vmatrix", want: "error: --json is not supported by rqm vmatrix"},
+		{name: "with quiet", command: "list", quiet: true, want: "error: --json and --quiet cannot be used together"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonOutput, quietOutput = true, tt.quiet
+			defer func() { jsonOutput, quietOutput = false, false }()
+
+			cmd := newOutputModeCommand(tt.command, tt.format)
+			var got string
+			if err := applyOutputMode(cmd); err != nil {
+				got = "error: " + err.Error()
+			} else {
+				got = cmd.Flags().Lookup("format").Value.String()
+			}
+			if got != tt.want {
+				t.Errorf("Got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOutputModeQuiet(t *testing.T) {
+	quietOutput = true
+	defer func() { quietOutput = false }()
+	stdout := os.Stdout
+
+	cmd := newOutputModeCommand("validate", "")
+	if err := applyOutputMode(cmd); err != nil {
+		t.Fatal(err)
+	}
+	fmt.Println("discarded")
+	redirected := os.Stdout != stdout
+	restoreOutput()
+
+	if !redirected || !cmd.Root().SilenceErrors {
+		t.Errorf("Expected --quiet to discard stdout and silence errors")
+	}
+	if os.Stdout != stdout {
+		t.Errorf("Expected restoreOutput to put stdout back")
+	}
+	if got := cmd.Flags().Lookup("format").Value.String(); got != "text" {
+		t.Errorf("Expected --quiet to leave --format alone")
+	}
+}
@@ -0,0 +1,163 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// ownerAliases are the aliases of the file list is showing, which its
+// owner column and --details resolve owners against
+var ownerAliases []model.PersonAlias
+
+// ownerGroup is the requirements of one resolved owner
+type ownerGroup struct {
+	// key is the normalized owner, as from OwnerKey; "" for requirements
+	// without an owner
+	key     string
+	display string
+	// resolved is set when the owner matches an alias
+	resolved     bool
+	requirements []*model.RequirementDetail
+}
+
+var ownersCmd = &cobra.Command{
+	Use:   "owners [file]",
+	Short: "List requirements grouped by owner",
+	Long: `List the requirements of a file grouped by owner.
+
+Owners are resolved against the aliases: section of the file, so @alice,
+alice's GitHub handle and her email all count as the same person, shown
+as "Name <email>". Owners that match no alias are listed as written and
+marked as unresolved; requirements without an owner come last.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm owners
+  rqm owners requirements.yml`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+		writeOwnerGroups(os.Stdout, groupByOwner(config))
+		return nil
+	},
+}
+
+// groupByOwner groups every requirement of config by its normalized
+// owner, resolved owners first, then unresolved ones, each by name, then
+// the requirements without an owner
+func groupByOwner(config *model.RequirementConfig) []*ownerGroup {
+	byKey := make(map[string]*ownerGroup)
+	var groups []*ownerGroup
+	for _, req := range config.Flatten() {
+		key := ""
+		if req.Owner != "" {
+			key = config.OwnerKey(req.Owner)
+		}
+		group, ok := byKey[key]
+		if !ok {
+			group = &ownerGroup{key: key, display: "(no owner)"}
+			if alias, resolved := config.ResolveOwner(req.Owner); resolved {
+				group.display, group.resolved = alias.String(), true
+			} else if key != "" {
+				group.display = key
+			}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.requirements = append(group.requirements, req)
+	}
+
+	rank := func(g *ownerGroup) int {
+		switch {
+		case g.resolved:
+			return 0
+		case g.key != "":
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if rank(groups[i]) != rank(groups[j]) {
+			return rank(groups[i]) < rank(groups[j])
+		}
+		return groups[i].display < groups[j].display
+	})
+	return groups
+}
+
+// writeOwnerGroups lists each owner with the count and names of their
+// requirements
+func writeOwnerGroups(w io.Writer, groups []*ownerGroup) {
+	if len(groups) == 0 {
+		fmt.Fprintln(w, "No requirements")
+		return
+	}
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		heading := group.display
+		if group.key != "" && !group.resolved {
+			heading += " " + paint(ansiYellow, "(matches no alias)")
+		}
+		fmt.Fprintf(w, "%s — %d requirement(s)\n", paint(ansiBold, heading), len(group.requirements))
+		for _, req := range group.requirements {
+			fmt.Fprintf(w, "  %s %s\n", paint(statusColor(req.Status), getStatusSymbol(req.Status)), displayName(req))
+		}
+	}
+}
+
+// displayOwner shows owner as the name and email of the alias it
+// resolves to among aliases, or as written
+func displayOwner(aliases []model.PersonAlias, owner string) string {
+	config := model.RequirementConfig{Aliases: aliases}
+	if alias, ok := config.ResolveOwner(owner); ok {
+		return alias.String()
+	}
+	return owner
+}
+
+// unknownOwnerWarnings flags owners that match no alias: an @alias that
+// isn't defined, or, in a file that defines aliases, any owner outside
+// them
+func unknownOwnerWarnings(config *model.RequirementConfig, all []*model.RequirementDetail) []ValidationWarning {
+	var warnings []ValidationWarning
+	for _, req := range all {
+		if req.Owner == "" {
+			continue
+		}
+		if _, ok := config.ResolveOwner(req.Owner); ok {
+			continue
+		}
+		if len(config.Aliases) == 0 && req.Owner[0] != '@' {
+			continue
+		}
+		warnings = append(warnings, ValidationWarning{
+			Category:    "consistency",
+			Requirement: req.Summary,
+			Message:     fmt.Sprintf("Requirement '%s' has the owner '%s', which matches no alias", req.Summary, req.Owner),
+		})
+	}
+	return warnings
+}
+
+func init() {
+	rootCmd.AddCommand(ownersCmd)
+}
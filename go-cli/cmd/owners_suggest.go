@@ -0,0 +1,304 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var ownersSuggestWrite bool
+
+// codeOwnersLocations are where GitHub looks for CODEOWNERS, in order
+var codeOwnersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// ownerSuggestion is a proposed owner for a requirement without one
+type ownerSuggestion struct {
+	req   *model.RequirementDetail
+	owner string
+	// reason says where the owner comes from
+	reason string
+}
+
+// codeOwnersRule is a line of CODEOWNERS: a gitignore-style pattern and
+// the owners of the paths it matches
+type codeOwnersRule struct {
+	pattern ignoreRules
+	owners  []string
+}
+
+var ownersSuggestCmd = &cobra.Command{
+	Use:   "suggest [file]",
+	Short: "Propose owners for requirements that have none",
+	Long: `Propose an owner for every requirement without one, from the code it
+links to.
+
+The files a requirement links to are the paths among its verified_by,
+acceptance_test_link and further_information entries, relative to the
+requirements file or the repository root; a "::TestName", ":line" or
+"#anchor" suffix is ignored. The suggested owner is:
+  1. the CODEOWNERS owner of most of those files, from .github/CODEOWNERS,
+     CODEOWNERS or docs/CODEOWNERS, or else
+  2. whoever made the most commits to them, by git log.
+
+Owners that match an alias of the file are written as @alias. Nothing is
+changed unless --write is given, which sets the owner of each requirement
+a suggestion was found for.`,
+	Example: `  rqm owners suggest
+  rqm owners suggest requirements.yml --write`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+
+		suggestions, unowned := suggestOwners(config, file)
+		if unowned == 0 {
+			fmt.Printf("%s Every requirement has an owner\n", okMark())
+			return nil
+		}
+		for _, s := range suggestions {
+			fmt.Printf("%s → %s (%s)\n", displayName(s.req), s.owner, s.reason)
+		}
+		if missing := unowned - len(suggestions); missing > 0 {
+			fmt.Printf("%s %d requirement(s) without an owner link to no code to suggest one from\n", warnMark(), missing)
+		}
+		if len(suggestions) == 0 || !ownersSuggestWrite {
+			return nil
+		}
+
+		edits := make([]requirementEdit, 0, len(suggestions))
+		for _, s := range suggestions {
+			edits = append(edits, requirementEdit{Summary: s.req.Summary, Set: map[string]string{"owner": s.owner}})
+		}
+		if err := applyRequirementEdits(file, edits); err != nil {
+			return err
+		}
+		fmt.Printf("%s Set the owner of %d requirement(s) in %s\n", okMark(), len(edits), file)
+		return nil
+	},
+}
+
+// suggestOwners proposes owners for the requirements of config without
+// one, returning the suggestions and how many requirements lack an owner
+func suggestOwners(config *model.RequirementConfig, file string) ([]ownerSuggestion, int) {
+	dirs := []string{filepath.Dir(file)}
+	root, err := gitOutput("rev-parse", "--show-toplevel")
+	if err == nil {
+		dirs = append(dirs, root)
+	} else {
+		root = filepath.Dir(file)
+	}
+	root = canonicalPath(root)
+	codeOwners := loadCodeOwners(root)
+
+	var suggestions []ownerSuggestion
+	unowned := 0
+	for _, req := range config.Flatten() {
+		if req.Owner != "" {
+			continue
+		}
+		unowned++
+		files := referencedFiles(req, dirs)
+		if len(files) == 0 {
+			continue
+		}
+		if owner, file := codeOwnersFor(codeOwners, files); owner != "" {
+			suggestions = append(suggestions, ownerSuggestion{req, config.OwnerKey(owner), "CODEOWNERS of " + relativeTo(root, file)})
+		} else if owner, commits := topCommitter(files); owner != "" {
+			suggestions = append(suggestions, ownerSuggestion{req, config.OwnerKey(owner), fmt.Sprintf("%d commit(s) to the linked files", commits)})
+		}
+	}
+	return suggestions, unowned
+}
+
+// referencedFiles are the existing files and directories that req's
+// verified_by, acceptance_test_link and further_information entries name,
+// looked up in each of dirs
+func referencedFiles(req *model.RequirementDetail, dirs []string) []string {
+	refs := append([]string{req.AcceptanceTestLink}, req.VerifiedBy...)
+	refs = append(refs, req.FurtherInformation...)
+
+	var files []string
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		path := referencePath(ref)
+		if path == "" {
+			continue
+		}
+		for _, dir := range dirs {
+			full := filepath.Join(dir, filepath.FromSlash(path))
+			if _, err := os.Stat(full); err == nil {
+				if abs := canonicalPath(full); !seen[abs] {
+					seen[abs] = true
+					files = append(files, abs)
+				}
+				break
+			}
+		}
+	}
+	return files
+}
+
+// referencePath is the path part of a reference such as
+// pkg/auth_test.go::TestLogin, docs/spec.md#login or main.go:42, or ""
+// for URLs
+func referencePath(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.Contains(ref, "://") {
+		return ""
+	}
+	if i := strings.Index(ref, "::"); i >= 0 {
+		ref = ref[:i]
+	}
+	if i := strings.Index(ref, "#"); i >= 0 {
+		ref = ref[:i]
+	}
+	if i := strings.LastIndex(ref, ":"); i >= 0 && strings.Trim(ref[i+1:], "0123456789") == "" {
+		ref = ref[:i]
+	}
+	return ref
+}
+
+// loadCodeOwners reads the first CODEOWNERS file GitHub would use under
+// root, or returns nil without one
+func loadCodeOwners(root string) []codeOwnersRule {
+	for _, location := range codeOwnersLocations {
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(location)))
+		if err != nil {
+			continue
+		}
+		var rules []codeOwnersRule
+		scanner := bufio.NewScanner(strings.NewReader(string(data)))
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+				continue
+			}
+			// A comment can follow the owners
+			owners := fields[1:]
+			for i, owner := range owners {
+				if strings.HasPrefix(owner, "#") {
+					owners = owners[:i]
+					break
+				}
+			}
+			rules = append(rules, codeOwnersRule{pattern: parseIgnoreRules(root, []byte(fields[0])), owners: owners})
+		}
+		return rules
+	}
+	return nil
+}
+
+// codeOwnersFor finds the CODEOWNERS owner of the most of files, with one
+// of the files they own. The last rule matching a file, or a directory
+// above it, decides its owners; the first owner listed counts.
+func codeOwnersFor(rules []codeOwnersRule, files []string) (owner, file string) {
+	counts := make(map[string]int)
+	example := make(map[string]string)
+	for _, f := range files {
+		var owners []string
+		for _, rule := range rules {
+			if codeOwnersMatch(rule.pattern, f) {
+				owners = rule.owners
+			}
+		}
+		if len(owners) > 0 {
+			counts[owners[0]]++
+			if example[owners[0]] == "" {
+				example[owners[0]] = f
+			}
+		}
+	}
+	owner = mostCounted(counts)
+	return owner, example[owner]
+}
+
+// codeOwnersMatch reports whether pattern matches path or a directory
+// above it
+func codeOwnersMatch(pattern ignoreRules, path string) bool {
+	info, err := os.Stat(path)
+	isDir := err == nil && info.IsDir()
+	for {
+		if matched, _ := pattern.match(path, isDir); matched {
+			return true
+		}
+		parent := filepath.Dir(path)
+		if parent == path || !isWithin(parent, pattern.dir) {
+			return false
+		}
+		path, isDir = parent, true
+	}
+}
+
+// topCommitter is the email of whoever made the most commits to files,
+// with their number of commits
+func topCommitter(files []string) (string, int) {
+	out, err := gitOutput(append([]string{"log", "--no-merges", "--format=%aE", "--"}, files...)...)
+	if err != nil || out == "" {
+		return "", 0
+	}
+	counts := make(map[string]int)
+	for _, email := range strings.Split(out, "\n") {
+		counts[strings.ToLower(email)]++
+	}
+	email := mostCounted(counts)
+	return email, counts[email]
+}
+
+// mostCounted is the key with the highest count, the first by name on a
+// tie so suggestions are stable
+func mostCounted(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	best := ""
+	for _, key := range keys {
+		if best == "" || counts[key] > counts[best] {
+			best = key
+		}
+	}
+	return best
+}
+
+// canonicalPath makes path absolute and resolves symlinks, so paths from
+// git and from the requirements file compare equal
+func canonicalPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return path
+}
+
+// relativeTo shows path relative to root when it's below it
+func relativeTo(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil && isWithin(path, root) {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+func init() {
+	ownersCmd.AddCommand(ownersSuggestCmd)
+	ownersSuggestCmd.Flags().BoolVar(&ownersSuggestWrite, "write", false, "Set the suggested owners in the requirements file")
+}
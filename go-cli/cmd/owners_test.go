@@ -0,0 +1,170 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const ownersYAML = `version: "1.0"
+aliases:
+  - alias: alice
+    name: Alice Smith
+    email: alice@example.com
+    github: asmith
+requirements:
+  - summary: Login
+    name: AUTH-001
+    owner: "@alice"
+    requirements:
+      - summary: Password hashing
+        owner: Alice@Example.com
+  - summary: Sessions expire
+    owner: "@asmith"
+  - summary: Audit log
+    owner: carol@example.com
+  - summary: Rate limiting
+    owner: "@bob"
+  - summary: Backups
+`
+
+func TestGroupByOwner(t *testing.T) {
+	config, err := model.ParseYAML([]byte(ownersYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, group := range groupByOwner(config) {
+		got = append(got, group.display+":"+strings.Join(summaries(group.requirements), ","))
+	}
+	want := []string{
+		"Alice Smith <alice@example.com>:Login,Password hashing,Sessions expire",
+		"@bob:Rate limiting",
+		"carol@example.com:Audit log",
+		"(no owner):Backups",
+	}
+	if strings.Join(got, " | ") != strings.Join(want, " | ") {
+		t.Errorf("groupByOwner() = %q, want %q", got, want)
+	}
+
+	var out bytes.Buffer
+	writeOwnerGroups(&out, groupByOwner(config))
+	for _, line := range []string{"Alice Smith <alice@example.com> — 3 requirement(s)", "@bob (matches no alias) — 1 requirement(s)", "[AUTH-001] Login"} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("Expected %q in:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestUnknownOwnerWarnings(t *testing.T) {
+	config, err := model.ParseYAML([]byte(ownersYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings := unknownOwnerWarnings(config, config.Flatten())
+	var got []string
+	for _, w := range warnings {
+		got = append(got, w.Requirement)
+	}
+	if strings.Join(got, ",") != "Audit log,Rate limiting" {
+		t.Errorf("Expected warnings for the owners outside the aliases, got %+v", warnings)
+	}
+
+	// Without aliases only @references can be wrong
+	config.Aliases = nil
+	warnings = unknownOwnerWarnings(config, config.Flatten())
+	if len(warnings) != 3 {
+		t.Errorf("Expected warnings for the three @owners, got %+v", warnings)
+	}
+}
+
+func TestDisplayOwner(t *testing.T) {
+	aliases := []model.PersonAlias{{Alias: "alice", Name: "Alice Smith", Email: "alice@example.com"}}
+	if got := displayOwner(aliases, "@alice"); got != "Alice Smith <alice@example.com>" {
+		t.Errorf("displayOwner(@alice) = %q", got)
+	}
+	if got := displayOwner(aliases, "bob@example.com"); got != "bob@example.com" {
+		t.Errorf("displayOwner(bob@example.com) = %q", got)
+	}
+}
+
+func summaries(reqs []*model.RequirementDetail) []string {
+	var out []string
+	for _, req := range reqs {
+		out = append(out, req.Summary)
+	}
+	return out
+}
+
+func TestSuggestOwners(t *testing.T) {
+	gitTestRepo(t)
+	gitCommitFiles(t, "add code", map[string]string{
+		".github/CODEOWNERS":    "* @fallback\n/auth/ @asmith  # auth team\n",
+		"auth/login_test.go":    "package auth\n",
+		"billing/invoice.go":    "package billing\n",
+		"docs/requirements.yml": "",
+	})
+
+	config, err := model.ParseYAML([]byte(`version: "1.0"
+aliases:
+  - alias: alice
+    name: Alice Smith
+    github: asmith
+requirements:
+  - summary: Login
+    verified_by: [auth/login_test.go::TestLogin]
+  - summary: Invoices
+    further_information: [billing/invoice.go:12]
+  - summary: Owned
+    owner: "@alice"
+    verified_by: [auth/login_test.go]
+  - summary: Unlinked
+    verified_by: [https://example.com/tests]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	suggestions, unowned := suggestOwners(config, filepath.Join("docs", "requirements.yml"))
+	if unowned != 3 {
+		t.Errorf("Expected 3 requirements without an owner, got %d", unowned)
+	}
+	var got []string
+	for _, s := range suggestions {
+		got = append(got, s.req.Summary+"="+s.owner+" ("+s.reason+")")
+	}
+	want := []string{"Login=@alice (CODEOWNERS of auth/login_test.go)", "Invoices=@fallback (CODEOWNERS of billing/invoice.go)"}
+	if strings.Join(got, " | ") != strings.Join(want, " | ") {
+		t.Errorf("suggestOwners() = %q, want %q", got, want)
+	}
+
+	// Without CODEOWNERS the most frequent committer is suggested
+	os.Remove(filepath.Join(".github", "CODEOWNERS"))
+	suggestions, _ = suggestOwners(config, filepath.Join("docs", "requirements.yml"))
+	if len(suggestions) != 2 || suggestions[1].owner != "test@example.com" || suggestions[1].reason != "1 commit(s) to the linked files" {
+		t.Errorf("Expected the committer to be suggested, got %+v", suggestions)
+	}
+}
+
+func TestReferencePath(t *testing.T) {
+	for ref, want := range map[string]string{
+		"pkg/auth_test.go::TestLogin": "pkg/auth_test.go",
+		"docs/spec.md#login":          "docs/spec.md",
+		"main.go:42":                  "main.go",
+		"https://example.com/spec":    "",
+		"TestLogin":                   "TestLogin",
+	} {
+		if got := referencePath(ref); got != want {
+			t.Errorf("referencePath(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prSummaryBase   string
+	prSummaryOutput string
+)
+
+// prSummaryMarker starts every summary so CI can find and update its
+// earlier comment instead of posting a new one
+const prSummaryMarker = "<!-- rqm-pr-summary -->"
+
+// requirementChange is a requirement present on both sides whose content
+// differs. Fields lists the changed fields by their YAML key.
+type requirementChange struct {
+	Old, New *model.RequirementDetail
+	Fields   []string
+}
+
+// requirementChanges is the difference between two versions of a file
+type requirementChanges struct {
+	Added   []*model.RequirementDetail
+	Changed []requirementChange
+	Removed []*model.RequirementDetail
+}
+
+var prSummaryCmd = &cobra.Command{
+	Use:   "pr-summary [file]",
+	Short: "Summarize requirement changes against a git ref as a PR comment",
+	Long: `Compare a requirements file with its version at a git ref and print a
+Markdown summary of the requirements added, changed and removed, ready to
+post as a pull request comment.
+
+Requirements are matched by uid (see rqm id assign), then by ID (name),
+then by summary, so a requirement whose ID and summary were both changed
+still shows as one change rather than a removal and an addition. They're
+compared with the normalized content rqm hash uses, so reformatting
+and reordering tags don't count as changes. Status transitions are shown
+as "draft → implemented". A file that doesn't exist at the ref counts as
+entirely added.
+
+The summary starts with the hidden marker ` + prSummaryMarker + `,
+so a CI job can find and update its earlier comment.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm pr-summary --base origin/main
+  rqm pr-summary requirements.yml --base main -o summary.md
+  gh pr comment "$PR" --body "$(rqm pr-summary --base origin/main)"`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		changes, err := changesSince(prSummaryBase, file)
+		if err != nil {
+			return err
+		}
+
+		var out bytes.Buffer
+		writePRSummary(&out, file, prSummaryBase, changes)
+		if prSummaryOutput == "" {
+			_, err := io.Copy(os.Stdout, &out)
+			return err
+		}
+		if err := os.WriteFile(prSummaryOutput, out.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", prSummaryOutput, err)
+		}
+		fmt.Fprintf(os.Stderr, "%s Wrote PR summary to %s\n", okMark(), prSummaryOutput)
+		return nil
+	},
+}
+
+// requirementsFileArg is the requirements file named in args or, without
+// one, .rqm/requirements.yml found by walking up from the current directory
+func requirementsFileArg(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	file := findRequirementsFile(cwd)
+	if file == "" {
+		return "", fmt.Errorf("no .rqm/requirements.yml found; pass the requirements file to compare")
+	}
+	return file, nil
+}
+
+// changesSince compares file with its version at the git ref base
+func changesSince(base, file string) (requirementChanges, error) {
+	_, changes, err := compareWithRef(base, file)
+	return changes, err
+}
+
+// compareWithRef loads file, with the files it includes, and compares it
+// with its version at the git ref base; the changed requirements point
+// into the returned config
+func compareWithRef(base, file string) (*model.RequirementConfig, requirementChanges, error) {
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		return nil, requirementChanges{}, fmt.Errorf("file does not exist: %s", file)
+	}
+	current, err := model.Load(file)
+	if err != nil {
+		return nil, requirementChanges{}, err
+	}
+	old, err := requirementsAtRef(base, file)
+	if err != nil {
+		return nil, requirementChanges{}, err
+	}
+	return current, diffRequirements(old, current), nil
+}
+
+// requirementsAtRef loads file, with the files it includes, as they were
+// at the git ref. A file that doesn't exist at ref gives an empty config.
+func requirementsAtRef(ref, file string) (*model.RequirementConfig, error) {
+	if _, found, err := fileAtRef(ref, file); err != nil || !found {
+		return &model.RequirementConfig{}, err
+	}
+	config, err := model.LoadWith(file, func(path string) ([]byte, error) {
+		data, found, err := fileAtRef(ref, path)
+		if err == nil && !found {
+			err = fmt.Errorf("%s does not exist", path)
+		}
+		return data, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s at %s: %w", file, ref, err)
+	}
+	return config, nil
+}
+
+// fileAtRef reads file as it was at the git ref, reporting whether it
+// existed there
+func fileAtRef(ref, file string) ([]byte, bool, error) {
+	if _, err := gitOutput("rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return nil, false, fmt.Errorf("unknown git ref: %s", ref)
+	}
+
+	// git show resolves ./ paths against the current directory
+	path := file
+	if filepath.IsAbs(path) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, false, err
+		}
+		if path, err = filepath.Rel(cwd, file); err != nil {
+			return nil, false, err
+		}
+	}
+	spec := ref + ":./" + filepath.ToSlash(path)
+	if _, err := gitOutput("cat-file", "-e", spec); err != nil {
+		return nil, false, nil
+	}
+	data, err := gitOutput("show", spec)
+	if err != nil {
+		return nil, false, err
+	}
+	return []byte(data), true, nil
+}
+
+// diffRequirements compares two versions of a file. Requirements are
+// matched by uid, then by name and then by summary, so renaming a
+// requirement with a uid or an ID is one change rather than a removal and
+// an addition.
+func diffRequirements(old, current *model.RequirementConfig) requirementChanges {
+	byUID := make(map[string]*model.RequirementDetail)
+	byName := make(map[string]*model.RequirementDetail)
+	bySummary := make(map[string]*model.RequirementDetail)
+	for _, req := range old.Flatten() {
+		if req.UID != "" {
+			byUID[req.UID] = req
+		}
+		if req.Name != "" {
+			byName[req.Name] = req
+		}
+		bySummary[req.Summary] = req
+	}
+
+	var changes requirementChanges
+	matched := make(map[*model.RequirementDetail]bool)
+	// Requirements that both have a uid are the same only if it's equal,
+	// however their IDs and summaries match
+	candidate := func(req, previous *model.RequirementDetail) *model.RequirementDetail {
+		if previous != nil && previous.UID != "" && req.UID != "" && previous.UID != req.UID {
+			return nil
+		}
+		return previous
+	}
+	for _, req := range current.Flatten() {
+		previous := byUID[req.UID]
+		if previous == nil {
+			previous = candidate(req, byName[req.Name])
+		}
+		if previous == nil {
+			previous = candidate(req, bySummary[req.Summary])
+		}
+		if previous == nil || matched[previous] {
+			changes.Added = append(changes.Added, req)
+			continue
+		}
+		matched[previous] = true
+		if fields := changedFields(previous, req); len(fields) > 0 {
+			changes.Changed = append(changes.Changed, requirementChange{Old: previous, New: req, Fields: fields})
+		}
+	}
+	for _, req := range old.Flatten() {
+		if !matched[req] {
+			changes.Removed = append(changes.Removed, req)
+		}
+	}
+	return changes
+}
+
+// changedFields lists the fields whose normalized content differs, in
+// declaration order
+func changedFields(old, current *model.RequirementDetail) []string {
+	var before, after map[string]json.RawMessage
+	json.Unmarshal(canonicalRequirement(old), &before)
+	json.Unmarshal(canonicalRequirement(current), &after)
+
+	var fields []string
+	for _, field := range requirementFieldOrder {
+		if !bytes.Equal(before[field], after[field]) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// requirementFieldOrder is the order of model.RequirementDetail's fields
+var requirementFieldOrder = []string{
+	"summary", "name", "uid", "description", "justification", "acceptance_test",
+	"acceptance_test_link", "acceptance_criteria", "covers", "verification", "verified_by", "environments", "owner", "priority",
+	"estimate", "status", "deprecated", "superseded_by", "tags", "relations", "approvals", "open_questions", "attachments", "external_refs", "links",
+	"attributes", "further_information", "requirements",
+}
+
+// writePRSummary writes the Markdown summary of changes to file since base
+func writePRSummary(w io.Writer, file, base string, changes requirementChanges) {
+	fmt.Fprintf(w, "%s\n## Requirements changes\n\n", prSummaryMarker)
+	if len(changes.Added)+len(changes.Changed)+len(changes.Removed) == 0 {
+		fmt.Fprintf(w, "No requirement changes in `%s` compared with `%s`.\n", file, base)
+		return
+	}
+	fmt.Fprintf(w, "`%s` compared with `%s`: %d added, %d changed, %d removed\n",
+		file, base, len(changes.Added), len(changes.Changed), len(changes.Removed))
+
+	if len(changes.Added) > 0 {
+		fmt.Fprintf(w, "\n### Added (%d)\n\n| ID | Summary | Status |\n|----|---------|--------|\n", len(changes.Added))
+		for _, req := range changes.Added {
+			fmt.Fprintf(w, "| %s | %s | %s |\n", markdownCell(req.Name), markdownCell(req.Summary), markdownCell(req.Status))
+		}
+	}
+	if len(changes.Changed) > 0 {
+		fmt.Fprintf(w, "\n### Changed (%d)\n\n| ID | Summary | Status | Changed |\n|----|---------|--------|---------|\n", len(changes.Changed))
+		for _, c := range changes.Changed {
+			status := c.New.Status
+			if c.Old.Status != c.New.Status {
+				status = fmt.Sprintf("%s → **%s**", orNone(c.Old.Status), orNone(c.New.Status))
+			}
+			summary := markdownCell(c.New.Summary)
+			if c.Old.Summary != c.New.Summary {
+				summary = fmt.Sprintf("~~%s~~ %s", markdownCell(c.Old.Summary), summary)
+			}
+			fmt.Fprintf(w, "| %s | %s | %s | %s |\n", markdownCell(c.New.Name), summary, status, strings.Join(c.Fields, ", "))
+		}
+	}
+	if len(changes.Removed) > 0 {
+		fmt.Fprintf(w, "\n### Removed (%d)\n\n| ID | Summary | Status |\n|----|---------|--------|\n", len(changes.Removed))
+		for _, req := range changes.Removed {
+			fmt.Fprintf(w, "| %s | %s | %s |\n", markdownCell(req.Name), markdownCell(req.Summary), markdownCell(req.Status))
+		}
+	}
+}
+
+// orNone shows an unset value in a status transition
+func orNone(s string) string {
+	if s == "" {
+		return "_none_"
+	}
+	return markdownCell(s)
+}
+
+func init() {
+	rootCmd.AddCommand(prSummaryCmd)
+	prSummaryCmd.Flags().StringVar(&prSummaryBase, "base", "origin/main", "Git ref to compare against, usually the pull request's base branch")
+	prSummaryCmd.Flags().StringVarP(&prSummaryOutput, "output", "o", "", "Write the summary to this file instead of stdout")
+}
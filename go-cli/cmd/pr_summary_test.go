@@ -0,0 +1,152 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const prSummaryBaseYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: draft
+    tags: [auth, security]
+  - summary: Password reset
+    name: AUTH-002
+  - summary: Audit log
+`
+
+const prSummaryHeadYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: implemented
+    tags: [security, auth]
+  - summary: Reset a forgotten password
+    name: AUTH-002
+  - summary: Rate limiting
+    status: draft
+`
+
+func TestDiffRequirements(t *testing.T) {
+	base, _ := model.ParseYAML([]byte(prSummaryBaseYAML))
+	head, _ := model.ParseYAML([]byte(prSummaryHeadYAML))
+	changes := diffRequirements(base, head)
+
+	if len(changes.Added) != 1 || changes.Added[0].Summary != "Rate limiting" {
+		t.Errorf("Added = %v, want Rate limiting", changes.Added)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0].Summary != "Audit log" {
+		t.Errorf("Removed = %v, want Audit log", changes.Removed)
+	}
+	if len(changes.Changed) != 2 {
+		t.Fatalf("Changed = %v, want Login and AUTH-002", changes.Changed)
+	}
+	// Reordered tags are not a change
+	if got := changes.Changed[0].Fields; !reflect.DeepEqual(got, []string{"status"}) {
+		t.Errorf("Login changed fields = %v, want [status]", got)
+	}
+	if got := changes.Changed[1].Fields; !reflect.DeepEqual(got, []string{"summary"}) {
+		t.Errorf("AUTH-002 changed fields = %v, want [summary]", got)
+	}
+}
+
+func TestDiffRequirementsByUID(t *testing.T) {
+	base, _ := model.ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-1
+    uid: 15c615d9ea3a
+  - summary: Logout
+    uid: 96b775ef9dc8
+`))
+	// Login is renamed in both ID and summary, and a new requirement takes
+	// the summary Logout had
+	head, _ := model.ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: Sign in
+    name: AUTH-001
+    uid: 15c615d9ea3a
+  - summary: Logout
+    uid: 2305810f54ee
+`))
+	changes := diffRequirements(base, head)
+	if len(changes.Changed) != 1 || changes.Changed[0].Old.Name != "AUTH-1" {
+		t.Fatalf("Changed = %v, want Login renamed", changes.Changed)
+	}
+	if got := changes.Changed[0].Fields; !reflect.DeepEqual(got, []string{"summary", "name"}) {
+		t.Errorf("renamed fields = %v, want [summary name]", got)
+	}
+	if len(changes.Added) != 1 || changes.Added[0].UID != "2305810f54ee" || len(changes.Removed) != 1 || changes.Removed[0].UID != "96b775ef9dc8" {
+		t.Errorf("Added = %v, Removed = %v, want the two Logouts", changes.Added, changes.Removed)
+	}
+}
+
+func TestWritePRSummary(t *testing.T) {
+	base, _ := model.ParseYAML([]byte(prSummaryBaseYAML))
+	head, _ := model.ParseYAML([]byte(prSummaryHeadYAML))
+
+	var out strings.Builder
+	writePRSummary(&out, "requirements.yml", "origin/main", diffRequirements(base, head))
+	summary := out.String()
+	for _, want := range []string{
+		prSummaryMarker,
+		"1 added, 2 changed, 1 removed",
+		"| AUTH-001 | Login | draft → **implemented** | status |",
+		"| AUTH-002 | ~~Password reset~~ Reset a forgotten password |  | summary |",
+		"|  | Rate limiting | draft |",
+		"### Removed (1)",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary missing %q:\n%s", want, summary)
+		}
+	}
+
+	out.Reset()
+	writePRSummary(&out, "requirements.yml", "origin/main", diffRequirements(base, base))
+	if !strings.Contains(out.String(), "No requirement changes") {
+		t.Errorf("Expected no changes, got:\n%s", out.String())
+	}
+}
+
+func TestRequirementsAtRef(t *testing.T) {
+	gitTestRepo(t)
+	gitCommitFiles(t, "empty", nil)
+
+	// A file that didn't exist at the ref is entirely new
+	config, err := requirementsAtRef("HEAD", "requirements.yml")
+	if err != nil || len(config.Requirements) != 0 {
+		t.Errorf("Expected empty config, got %v, %v", config, err)
+	}
+
+	gitCommitFiles(t, "add requirements", map[string]string{"requirements.yml": prSummaryBaseYAML})
+	config, err = requirementsAtRef("HEAD", "requirements.yml")
+	if err != nil || len(config.Requirements) != 3 {
+		t.Errorf("Expected 3 requirements at HEAD, got %v, %v", config, err)
+	}
+
+	if _, err := requirementsAtRef("no-such-branch", "requirements.yml"); err == nil || !strings.Contains(err.Error(), "unknown git ref") {
+		t.Errorf("Expected unknown ref error, got %v", err)
+	}
+
+	// Included files are read at the ref too
+	gitCommitFiles(t, "split", map[string]string{
+		"main.yml": "version: \"1.0\"\nincludes: [part.yml]\nrequirements:\n  - summary: Login\n",
+		"part.yml": "version: \"1.0\"\nrequirements:\n  - summary: Logout\n",
+	})
+	os.WriteFile("part.yml", []byte("version: \"1.0\"\nrequirements: []\n"), 0644)
+	if config, err = requirementsAtRef("HEAD", "main.yml"); err != nil || len(config.Flatten()) != 2 {
+		t.Errorf("Expected both requirements at HEAD, got %v, %v", config, err)
+	}
+	if _, changes, err := compareWithRef("HEAD", "main.yml"); err != nil || len(changes.Removed) != 1 {
+		t.Errorf("Expected the requirement removed from the included file, got %+v, %v", changes, err)
+	}
+}
@@ -0,0 +1,68 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+var (
+	profileCPU string
+	profileMem string
+
+	// cpuProfileFile is open while a CPU profile is being recorded
+	cpuProfileFile *os.File
+)
+
+// startProfiling begins CPU profiling when --profile-cpu is set
+func startProfiling() error {
+	if profileCPU == "" {
+		return nil
+	}
+
+	f, err := os.Create(profileCPU)
+	if err != nil {
+		return fmt.Errorf("failed to create CPU profile: %w", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to start CPU profile: %w", err)
+	}
+	cpuProfileFile = f
+	return nil
+}
+
+// stopProfiling finishes the CPU profile and writes the heap profile.
+// It is safe to call when profiling was never started.
+func stopProfiling() {
+	if cpuProfileFile != nil {
+		pprof.StopCPUProfile()
+		cpuProfileFile.Close()
+		fmt.Fprintf(os.Stderr, "CPU profile written to %s\n", profileCPU)
+		cpuProfileFile = nil
+	}
+
+	if profileMem == "" {
+		return
+	}
+
+	f, err := os.Create(profileMem)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create memory profile: %v\n", err)
+		return
+	}
+	defer f.Close()
+
+	// Collect garbage first so the profile reflects live memory
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write memory profile: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Memory profile written to %s\n", profileMem)
+}
@@ -0,0 +1,208 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+)
+
+var (
+	queryRawOutput     bool
+	queryCompactOutput bool
+	queryExitStatus    bool
+	// queryArgs are the name=value pairs of --arg
+	queryArgs []string
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query [file] EXPRESSION",
+	Short: "Run a jq expression over the requirements",
+	Long: `Run a jq expression over the parsed requirements and print its results, for
+scripts that would otherwise pipe rqm's JSON through jq:
+
+  rqm query '.requirements[] | select(.status=="draft") | .name'
+
+The input is the requirements file as JSON, with includes resolved:
+version, aliases and the requirements tree, each requirement's
+sub-requirements under its requirements key. .requirements[] is the
+top-level requirements only; the requirements function gives every
+requirement below its input at any depth, in file order, leaving out
+references to requirements defined elsewhere:
+
+  rqm query '[requirements | select(.priority=="critical")] | length'
+
+The language is jq's, as implemented by gojq: paths, pipes, select, map,
+reduce, try/catch, def, path updates such as |= and +=, string
+interpolation, the @csv, @tsv, @json and @html formats and the rest of
+jq's builtins. Evaluation is lazy, so first(...) and limit(n; ...) stop
+as soon as they have their values. Unlike jq, object keys are printed in
+sorted order, and test and match use Go's regular expression syntax.
+
+Results are printed as JSON, indented unless --compact-output;
+--raw-output prints strings without quotes, one per line. --arg
+name=value sets $name to a string.
+
+With --exit-status the exit code is 1 when the last result is false or
+null, or there is none, so a query can gate a CI job.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm query '.requirements[] | select(.status=="draft") | .name'
+  rqm query requirements.yml -r 'requirements | select(.owner==$who) | .summary' --arg who=@alice
+  rqm query -r 'requirements | [.name, .status, .priority] | @csv' > status.csv
+  rqm query '[requirements | .status] | group_by(.) | map({(.[0] // "none"): length}) | add'
+  rqm query -e '[requirements | select(.status=="draft" and .priority=="critical")] | length == 0'`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var names []string
+		var values []any
+		for _, arg := range queryArgs {
+			name, value, ok := strings.Cut(arg, "=")
+			if !ok || name == "" {
+				return fmt.Errorf("invalid --arg %q (expected name=value)", arg)
+			}
+			names = append(names, "$"+strings.TrimPrefix(name, "$"))
+			values = append(values, value)
+		}
+		code, err := compileQuery(args[len(args)-1], names)
+		if err != nil {
+			return fmt.Errorf("invalid query: %w", err)
+		}
+
+		file, err := requirementsFileArg(args[:len(args)-1])
+		if err != nil {
+			return err
+		}
+		return withFileArg(file, func(file string) error {
+			config, _, err := loadRequirements(file)
+			if err != nil {
+				return err
+			}
+			out := bufio.NewWriter(os.Stdout)
+			err = runQuery(out, code, config, values...)
+			if flushErr := out.Flush(); err == nil && flushErr != nil {
+				err = fmt.Errorf("failed to write output: %w", flushErr)
+			}
+			return err
+		})
+	},
+}
+
+// compileQuery parses a jq expression with the requirements function and
+// the --arg variables names
+func compileQuery(expr string, names []string) (*gojq.Code, error) {
+	q, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	return gojq.Compile(q,
+		gojq.WithVariables(names),
+		gojq.WithIterFunction("requirements", 0, 0, func(in any, _ []any) gojq.Iter {
+			return gojq.NewIter(queryRequirements(in)...)
+		}),
+	)
+}
+
+// runQuery prints each result of code over config as it is produced, so
+// a query that stops early, like first(...), doesn't compute the rest.
+// values are those of the variables code was compiled with.
+func runQuery(w io.Writer, code *gojq.Code, config *model.RequirementConfig, values ...any) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode requirements: %w", err)
+	}
+	var input any
+	if err := json.Unmarshal(data, &input); err != nil {
+		return fmt.Errorf("failed to encode requirements: %w", err)
+	}
+
+	var last any
+	results := 0
+	iter := code.Run(input, values...)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			var halt *gojq.HaltError
+			if errors.As(err, &halt) && halt.Value() == nil {
+				break
+			}
+			return fmt.Errorf("query failed: %w", err)
+		}
+		last = v
+		results++
+		if s, ok := v.(string); ok && queryRawOutput {
+			fmt.Fprintln(w, s)
+			continue
+		}
+		out, _ := gojq.Marshal(v)
+		if !queryCompactOutput {
+			var indented bytes.Buffer
+			if json.Indent(&indented, out, "", "  ") == nil {
+				out = indented.Bytes()
+			}
+		}
+		fmt.Fprintf(w, "%s\n", out)
+	}
+
+	if queryExitStatus {
+		switch {
+		case results == 0:
+			return validationError("the query has no results")
+		case last == nil || last == false:
+			out, _ := gojq.Marshal(last)
+			return validationError("the query's last result is %s", out)
+		}
+	}
+	return nil
+}
+
+// queryRequirements is the requirements function of rqm query: every
+// requirement below the input, at any depth, in file order. References by
+// summary or to other repositories are strings and external entries in the
+// JSON, not requirements, so they are left out.
+func queryRequirements(in any) []any {
+	var out []any
+	var walk func(v any)
+	walk = func(v any) {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return
+		}
+		list, _ := obj["requirements"].([]any)
+		for _, child := range list {
+			if c, ok := child.(map[string]any); ok {
+				if _, ok := c["summary"]; ok {
+					out = append(out, child)
+					walk(child)
+				}
+			}
+		}
+	}
+	walk(in)
+	return out
+}
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+	queryCmd.Flags().BoolVarP(&queryRawOutput, "raw-output", "r", false, "Print string results without JSON quotes")
+	queryCmd.Flags().BoolVarP(&queryCompactOutput, "compact-output", "c", false, "Print each result on one line")
+	queryCmd.Flags().BoolVarP(&queryExitStatus, "exit-status", "e", false, "Exit with 1 when the last result is false or null, or there is none")
+	queryCmd.Flags().StringArrayVar(&queryArgs, "arg", nil, "Set $name to a string value, as name=value (repeatable)")
+}
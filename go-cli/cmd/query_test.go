@@ -0,0 +1,115 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestRunQuery(t *testing.T) {
+	config := &model.RequirementConfig{Version: "1.0", Requirements: []model.RequirementDetail{
+		{Summary: "Login", Name: "AUTH-1", Status: "approved", Requirements: []model.RequirementReference{
+			{Full: &model.RequirementDetail{Summary: "Lockout", Name: "AUTH-2", Status: "draft"}},
+			{Reference: "Reports"},
+		}},
+		{Summary: "Reports", Name: "REP-1", Status: "draft"},
+	}}
+	defer func() { queryRawOutput, queryCompactOutput, queryExitStatus = false, false, false }()
+
+	tests := []struct {
+		expr         string
+		raw, compact bool
+		want         string
+	}{
+		{`.requirements[] | select(.status=="draft") | .name`, false, false, "\"REP-1\"\n"},
+		{`requirements | select(.status=="draft") | .name`, true, false, "AUTH-2\nREP-1\n"},
+		{`.requirements[0] | {name, children: [requirements | .name]}`, false, false, "{\n  \"children\": [\n    \"AUTH-2\"\n  ],\n  \"name\": \"AUTH-1\"\n}\n"},
+		{`[requirements | .name]`, false, true, "[\"AUTH-1\",\"AUTH-2\",\"REP-1\"]\n"},
+		{`requirements | select(.name == $id) | .summary`, true, false, "Lockout\n"},
+		{`reduce requirements as $r ({}; .[$r.status] += 1)`, false, true, "{\"approved\":1,\"draft\":2}\n"},
+		{`.requirements[0].requirements[1] | try error("not a requirement: \(.)") catch .`, true, false, "not a requirement: Reports\n"},
+		{`"<\(.version)>" | @html`, true, false, "&lt;1.0&gt;\n"},
+	}
+	for _, tt := range tests {
+		code, err := compileQuery(tt.expr, []string{"$id"})
+		if err != nil {
+			t.Fatalf("compileQuery(%s): %v", tt.expr, err)
+		}
+		queryRawOutput, queryCompactOutput = tt.raw, tt.compact
+		var buf bytes.Buffer
+		if err := runQuery(&buf, code, config, "AUTH-2"); err != nil {
+			t.Errorf("runQuery(%s): %v", tt.expr, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("runQuery(%s) printed:\n%s\nwant:\n%s", tt.expr, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestRunQueryStopsEarly(t *testing.T) {
+	config := &model.RequirementConfig{Version: "1.0"}
+	queryCompactOutput = true
+	defer func() { queryCompactOutput = false }()
+
+	// Generators are lazy, so taking a few values of a huge range is quick
+	start := time.Now()
+	for expr, want := range map[string]string{
+		`limit(3; range(100000000))`:                                    "0\n1\n2\n",
+		`first(range(100000000))`:                                       "0\n",
+		`[limit(2; repeat("x"))]`:                                       "[\"x\",\"x\"]\n",
+		`label $out | range(10) | if . == 2 then break $out else . end`: "0\n1\n",
+	} {
+		code, err := compileQuery(expr, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := runQuery(&buf, code, config); err != nil || buf.String() != want {
+			t.Errorf("runQuery(%s) = %q, %v; want %q", expr, buf.String(), err, want)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("queries took %s", elapsed)
+	}
+}
+
+func TestRunQueryExitStatus(t *testing.T) {
+	config := &model.RequirementConfig{Version: "1.0", Requirements: []model.RequirementDetail{{Summary: "Login", Status: "draft"}}}
+	queryExitStatus = true
+	defer func() { queryExitStatus = false }()
+
+	for expr, wantErr := range map[string]bool{
+		`.requirements | length > 0`:                      false,
+		`.requirements[0].name`:                           true,
+		`.requirements[] | select(.status == "approved")`: true,
+		`.version`: false,
+	} {
+		code, err := compileQuery(expr, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		err = runQuery(&buf, code, config)
+		if (err != nil) != wantErr {
+			t.Errorf("runQuery(%s): expected error %v, got %v", expr, wantErr, err)
+		}
+	}
+
+	// Results before an error are printed
+	code, _ := compileQuery(`.requirements[] | .summary, .summary.x`, nil)
+	var buf bytes.Buffer
+	if err := runQuery(&buf, code, config); err == nil || buf.String() != "\"Login\"\n" {
+		t.Errorf("Expected the results before the error, got %q, %v", buf.String(), err)
+	}
+
+	if _, err := compileQuery(`.a |`, nil); err == nil || !strings.Contains(err.Error(), "unexpected EOF") {
+		t.Errorf("Expected a parse error, got %v", err)
+	}
+}
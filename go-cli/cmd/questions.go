@@ -0,0 +1,205 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	questionsOwner  string
+	questionsFormat string
+)
+
+// openQuestion is a question on a requirement that has no answer yet
+type openQuestion struct {
+	req      *model.RequirementDetail
+	question model.Question
+}
+
+var questionsCmd = &cobra.Command{
+	Use:   "questions [file]",
+	Short: "List the open questions on requirements",
+	Long: `List the questions requirements are waiting on that have no answer yet,
+sorted by due date, with overdue ones marked.
+
+Requirements record their clarifications under open_questions:
+
+  open_questions:
+    - question: Which SSO providers must be supported at launch?
+      owner: "@alice"
+      due: "2025-03-01"
+    - question: Is a 30 minute session timeout acceptable?
+      answer: Yes, confirmed with security on 2025-02-10
+
+A question is resolved once it has an answer. rqm validate fails for an
+approved (or later) requirement that still has open questions.
+
+Use --owner to limit the report to one person; aliases, emails and GitHub
+handles that refer to the same person all match.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm questions
+  rqm questions requirements.yml --owner alice
+  rqm questions --format markdown > questions.md`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+		open, err := findOpenQuestions(config, questionsOwner)
+		if err != nil {
+			return err
+		}
+
+		switch questionsFormat {
+		case "text":
+			writeOpenQuestions(os.Stdout, config, open, time.Now())
+		case "markdown":
+			writeOpenQuestionsMarkdown(os.Stdout, config, open, questionsOwner, time.Now())
+		default:
+			return fmt.Errorf("unknown output format: %s", questionsFormat)
+		}
+		return nil
+	},
+}
+
+// findOpenQuestions collects the unanswered questions, optionally only
+// those owner is to answer, sorted by due date with undated ones last
+func findOpenQuestions(config *model.RequirementConfig, owner string) ([]openQuestion, error) {
+	var open []openQuestion
+	for _, req := range config.Flatten() {
+		for _, q := range req.OpenQuestions {
+			if strings.TrimSpace(q.Answer) != "" {
+				continue
+			}
+			if owner != "" && !sameOwner(config, q.Owner, owner) {
+				continue
+			}
+			if q.Due != "" {
+				if _, err := time.Parse(dueDateLayout, q.Due); err != nil {
+					return nil, fmt.Errorf("invalid due date %q on a question of %s (expected YYYY-MM-DD)", q.Due, displayName(req))
+				}
+			}
+			open = append(open, openQuestion{req: req, question: q})
+		}
+	}
+
+	sort.SliceStable(open, func(i, j int) bool {
+		a, b := open[i].question.Due, open[j].question.Due
+		if a == "" || b == "" {
+			return b == "" && a != ""
+		}
+		return a < b
+	})
+	return open, nil
+}
+
+// questionDue shows a due date, marked when it has passed
+func questionDue(due string, now time.Time) string {
+	switch {
+	case due == "":
+		return ""
+	case due < now.Format(dueDateLayout):
+		return paint(ansiRed, "due "+due+" (overdue)")
+	}
+	return "due " + due
+}
+
+// writeOpenQuestions lists the open questions, each with its requirement
+func writeOpenQuestions(w io.Writer, config *model.RequirementConfig, open []openQuestion, now time.Time) {
+	if len(open) == 0 {
+		fmt.Fprintf(w, "%s No open questions\n", okMark())
+		return
+	}
+	reqs := make(map[*model.RequirementDetail]bool)
+	for _, q := range open {
+		reqs[q.req] = true
+		var details []string
+		if q.question.Owner != "" {
+			details = append(details, displayOwner(config.Aliases, q.question.Owner))
+		}
+		if due := questionDue(q.question.Due, now); due != "" {
+			details = append(details, due)
+		}
+		line := fmt.Sprintf("%s %s: %s", paint(ansiYellow, "?"), displayName(q.req), q.question.Question)
+		if len(details) > 0 {
+			line += " — " + strings.Join(details, ", ")
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "\n%d open question(s) on %d requirement(s)\n", len(open), len(reqs))
+}
+
+// writeOpenQuestionsMarkdown writes the open questions as a table, for
+// email or chat
+func writeOpenQuestionsMarkdown(w io.Writer, config *model.RequirementConfig, open []openQuestion, owner string, now time.Time) {
+	if owner != "" {
+		fmt.Fprintf(w, "# Open questions for %s\n\n", owner)
+	} else {
+		fmt.Fprint(w, "# Open questions\n\n")
+	}
+	if len(open) == 0 {
+		fmt.Fprintln(w, "No questions are open.")
+		return
+	}
+
+	today := now.Format(dueDateLayout)
+	fmt.Fprint(w, "| ID | Summary | Status | Question | Owner | Due |\n|----|---------|--------|----------|-------|-----|\n")
+	for _, q := range open {
+		due := q.question.Due
+		switch {
+		case due == "":
+			due = "none"
+		case due < today:
+			due += " (overdue)"
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s |\n",
+			markdownCell(q.req.Name), markdownCell(q.req.Summary), markdownCell(q.req.Status),
+			markdownCell(q.question.Question), markdownCell(displayOwner(config.Aliases, q.question.Owner)), due)
+	}
+}
+
+// openQuestionErrors are the validation errors for requirements approved,
+// or further along, while questions on them are still open
+func openQuestionErrors(config *model.RequirementConfig) []string {
+	var errs []string
+	for _, req := range config.Flatten() {
+		if !pastStatus(req.Status, "approved") {
+			continue
+		}
+		open := 0
+		for _, q := range req.OpenQuestions {
+			if strings.TrimSpace(q.Answer) == "" {
+				open++
+			}
+		}
+		if open > 0 {
+			errs = append(errs, fmt.Sprintf("Requirement '%s' is %s but has %d open question(s); answer them or move it back to proposed", req.Summary, req.Status, open))
+		}
+	}
+	return errs
+}
+
+func init() {
+	rootCmd.AddCommand(questionsCmd)
+	questionsCmd.Flags().StringVar(&questionsOwner, "owner", "", "Only list questions for this person (alias, email or @github)")
+	questionsCmd.Flags().StringVarP(&questionsFormat, "format", "f", "text", "Output format (text, markdown)")
+}
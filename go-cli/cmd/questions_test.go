@@ -0,0 +1,109 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const questionsYAML = `version: "1.0"
+aliases:
+  - alias: alice
+    name: Alice Smith
+    email: alice@example.com
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: proposed
+    open_questions:
+      - question: Which SSO providers?
+        owner: "@alice"
+        due: "2025-03-01"
+      - question: Session timeout?
+        answer: 30 minutes
+  - summary: Audit log
+    status: approved
+    open_questions:
+      - question: How long is the retention?
+        owner: bob@example.com
+        due: "2025-02-01"
+  - summary: Backups
+    status: verified
+    open_questions:
+      - question: Offsite?
+        answer: "Yes"
+`
+
+func TestFindOpenQuestions(t *testing.T) {
+	config, err := model.ParseYAML([]byte(questionsYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for owner, want := range map[string]string{
+		"":                  "How long is the retention?,Which SSO providers?",
+		"alice@example.com": "Which SSO providers?",
+		"carol":             "",
+	} {
+		open, err := findOpenQuestions(config, owner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, q := range open {
+			got = append(got, q.question.Question)
+		}
+		if strings.Join(got, ",") != want {
+			t.Errorf("findOpenQuestions(%q) = %v, want %s", owner, got, want)
+		}
+	}
+
+	config.Requirements[0].OpenQuestions[0].Due = "March"
+	if _, err := findOpenQuestions(config, ""); err == nil || !strings.Contains(err.Error(), "invalid due date") {
+		t.Errorf("Expected an invalid due date error, got %v", err)
+	}
+}
+
+func TestWriteOpenQuestions(t *testing.T) {
+	config, err := model.ParseYAML([]byte(questionsYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	open, _ := findOpenQuestions(config, "")
+	now := time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)
+
+	var text bytes.Buffer
+	writeOpenQuestions(&text, config, open, now)
+	for _, want := range []string{
+		"? Audit log: How long is the retention? — bob@example.com, due 2025-02-01 (overdue)",
+		"? [AUTH-001] Login: Which SSO providers? — Alice Smith <alice@example.com>, due 2025-03-01",
+		"2 open question(s) on 2 requirement(s)",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Expected %q in:\n%s", want, text.String())
+		}
+	}
+
+	var markdown bytes.Buffer
+	writeOpenQuestionsMarkdown(&markdown, config, open, "", now)
+	if !strings.Contains(markdown.String(), "| AUTH-001 | Login | proposed | Which SSO providers? | Alice Smith <alice@example.com> | 2025-03-01 |") {
+		t.Errorf("Unexpected markdown:\n%s", markdown.String())
+	}
+}
+
+func TestOpenQuestionErrors(t *testing.T) {
+	config, err := model.ParseYAML([]byte(questionsYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := openQuestionErrors(config)
+	if len(errs) != 1 || !strings.Contains(errs[0], "'Audit log' is approved but has 1 open question(s)") {
+		t.Errorf("Expected an error for the approved requirement only, got %v", errs)
+	}
+}
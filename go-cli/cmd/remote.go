@@ -0,0 +1,260 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// remoteTimeout bounds the download of a remote requirements file
+const remoteTimeout = 30 * time.Second
+
+// maxRemoteSize is the largest remote requirements file rqm downloads
+const maxRemoteSize = 64 << 20
+
+// remoteFile is a file argument naming a requirements file on a server:
+// an http(s) URL, or a file in a git repository as
+// git::<repository>//<path>?ref=<ref>. Either may be pinned to its content
+// with checksum=sha256:<hex>.
+type remoteFile struct {
+	// arg is the argument as given, shown in place of the downloaded copy
+	arg string
+	// url is the URL of the file, or of the repository of a git file
+	url string
+	// path is the file in the repository, for git files
+	path string
+	// ref is the branch, tag or commit of a git file; HEAD when empty
+	ref string
+	// checksum is the pinned SHA-256 of the content in hex, or ""
+	checksum string
+}
+
+// isRemoteFile tells whether a file argument names a remote file
+func isRemoteFile(arg string) bool {
+	return strings.HasPrefix(arg, "https://") || strings.HasPrefix(arg, "http://") || strings.HasPrefix(arg, "git::")
+}
+
+// parseRemoteFile splits a remote file argument into its parts
+func parseRemoteFile(arg string) (*remoteFile, error) {
+	r := &remoteFile{arg: arg}
+	source, query, _ := strings.Cut(strings.TrimPrefix(arg, "git::"), "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote file %s: %w", arg, err)
+	}
+	if checksum := params.Get("checksum"); checksum != "" {
+		sum, ok := strings.CutPrefix(checksum, "sha256:")
+		if _, err := hex.DecodeString(sum); !ok || err != nil || len(sum) != 2*sha256.Size {
+			return nil, fmt.Errorf("invalid checksum %s: expected sha256:<64 hex digits>", checksum)
+		}
+		r.checksum = strings.ToLower(sum)
+	}
+	params.Del("checksum")
+
+	if !strings.HasPrefix(arg, "git::") {
+		// Other query parameters belong to the server
+		r.url = source
+		if len(params) > 0 {
+			r.url += "?" + params.Encode()
+		}
+		return r, nil
+	}
+
+	r.ref = params.Get("ref")
+	if strings.HasPrefix(r.ref, "-") {
+		return nil, fmt.Errorf("invalid git ref: %s", r.ref)
+	}
+	// The repository ends at the first // after the scheme's
+	start := 0
+	if i := strings.Index(source, "://"); i >= 0 {
+		start = i + len("://")
+	}
+	i := strings.Index(source[start:], "//")
+	if i < 0 || strings.Trim(source[start+i+2:], "/") == "" {
+		return nil, fmt.Errorf("%s names no file in the repository: add //path/to/requirements.yml", arg)
+	}
+	r.url, r.path = source[:start+i], strings.Trim(source[start+i+2:], "/")
+	if start == 0 && !strings.HasPrefix(r.url, "git@") {
+		r.url = "https://" + r.url
+	}
+	return r, nil
+}
+
+// cachePath is where the downloaded copy of r is kept, named after the
+// file so its extension still gives the format
+func (r *remoteFile) cachePath(dir string) string {
+	key := sha256.Sum256([]byte(r.url + "\x00" + r.path + "\x00" + r.ref))
+	name := path.Base(r.path)
+	if r.path == "" {
+		if u, err := url.Parse(r.url); err == nil {
+			name = path.Base(u.Path)
+		}
+	}
+	if name == "" || name == "/" || name == "." {
+		name = "requirements.yml"
+	}
+	return filepath.Join(dir, hex.EncodeToString(key[:8])+"-"+name)
+}
+
+// remoteCacheDir is where remote files are cached: RQM_REMOTE_CACHE_DIR,
+// or rqm/remote in the user's cache directory
+func remoteCacheDir() (string, error) {
+	if dir := os.Getenv("RQM_REMOTE_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("no cache directory for remote files: %w", err)
+	}
+	return filepath.Join(dir, "rqm", "remote"), nil
+}
+
+// fetchRemoteFile downloads the remote file arg names, returning the path
+// of its cached copy. A pinned file already in the cache isn't downloaded
+// again; an unpinned one is, falling back to the cached copy when the
+// server can't be reached.
+func fetchRemoteFile(arg string) (string, error) {
+	r, err := parseRemoteFile(arg)
+	if err != nil {
+		return "", err
+	}
+	dir, err := remoteCacheDir()
+	if err != nil {
+		return "", err
+	}
+	cached := r.cachePath(dir)
+	fileLabels[cached] = arg
+	if data, err := os.ReadFile(cached); err == nil && r.checksum != "" && sha256Hex(data) == r.checksum {
+		return cached, nil
+	}
+
+	// The project config of the current directory decides offline mode
+	project, _ := loadProjectConfig("requirements.yml")
+	data, err := func() ([]byte, error) {
+		if err := requireOnline("fetching "+arg, project); err != nil {
+			return nil, err
+		}
+		if r.path != "" {
+			return r.fetchGit()
+		}
+		return r.fetchHTTP()
+	}()
+	if err != nil {
+		if _, statErr := os.Stat(cached); statErr == nil && r.checksum == "" {
+			fmt.Fprintf(os.Stderr, "Warning: using the cached copy of %s: %v\n", arg, err)
+			return cached, nil
+		}
+		return "", err
+	}
+	if sum := sha256Hex(data); r.checksum != "" && sum != r.checksum {
+		return "", fmt.Errorf("checksum mismatch for %s: got sha256:%s, want sha256:%s", arg, sum, r.checksum)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), cached)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to cache %s: %w", arg, err)
+	}
+	return cached, nil
+}
+
+// fetchHTTP downloads an http(s) file
+func (r *remoteFile) fetchHTTP() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %s: %w", r.arg, err)
+	}
+	req.Header.Set("User-Agent", "rqm/"+rootCmd.Version)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", r.arg, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", r.arg, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", r.arg, err)
+	}
+	if len(data) > maxRemoteSize {
+		return nil, fmt.Errorf("%s is larger than %s", r.arg, formatByteSize(maxRemoteSize))
+	}
+	return data, nil
+}
+
+// fetchGit fetches the ref of a git file's repository, only as deep as
+// needed, and reads the file from it
+func (r *remoteFile) fetchGit() ([]byte, error) {
+	dir, err := os.MkdirTemp("", "rqm-remote-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+	git := func(args ...string) ([]byte, error) {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = dir
+		// Credentials come from the user's git setup; never prompt for them
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		out, err := cmd.Output()
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && len(exit.Stderr) > 0 {
+			return nil, fmt.Errorf("failed to fetch %s: git %s: %s", r.arg, args[0], strings.TrimSpace(string(exit.Stderr)))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: git %s: %w", r.arg, args[0], err)
+		}
+		return out, nil
+	}
+
+	ref := r.ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if _, err := git("init", "-q"); err != nil {
+		return nil, err
+	}
+	if _, err := git("fetch", "-q", "--depth", "1", "--", r.url, ref); err != nil {
+		return nil, err
+	}
+	return git("show", "FETCH_HEAD:"+r.path)
+}
+
+// sha256Hex is the SHA-256 of data in hex
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
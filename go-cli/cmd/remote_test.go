@@ -0,0 +1,175 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestParseRemoteFile(t *testing.T) {
+	sum := strings.Repeat("ab", 32)
+	tests := []struct {
+		arg                      string
+		url, path, ref, checksum string
+		wantErr                  string
+	}{
+		{arg: "https://example.com/requirements.yml", url: "https://example.com/requirements.yml"},
+		{arg: "https://example.com/r.yml?token=x&checksum=sha256:" + sum, url: "https://example.com/r.yml?token=x", checksum: sum},
+		{arg: "git::github.com/org/repo//.rqm/requirements.yml?ref=v1.2", url: "https://github.com/org/repo", path: ".rqm/requirements.yml", ref: "v1.2"},
+		{arg: "git::https://git.example.com/repo.git//docs/r.toml", url: "https://git.example.com/repo.git", path: "docs/r.toml"},
+		{arg: "git::git@github.com:org/repo.git//r.yml", url: "git@github.com:org/repo.git", path: "r.yml"},
+		{arg: "git::github.com/org/repo", wantErr: "names no file"},
+		{arg: "git::github.com/org/repo//r.yml?ref=--upload-pack=x", wantErr: "invalid git ref"},
+		{arg: "https://example.com/r.yml?checksum=md5:abc", wantErr: "invalid checksum"},
+	}
+	for _, tt := range tests {
+		r, err := parseRemoteFile(tt.arg)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseRemoteFile(%s) error = %v, want %q", tt.arg, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseRemoteFile(%s): %v", tt.arg, err)
+			continue
+		}
+		if r.url != tt.url || r.path != tt.path || r.ref != tt.ref || r.checksum != tt.checksum {
+			t.Errorf("parseRemoteFile(%s) = %+v", tt.arg, r)
+		}
+	}
+}
+
+func TestFetchRemoteFileHTTP(t *testing.T) {
+	t.Setenv("RQM_REMOTE_CACHE_DIR", t.TempDir())
+	t.Setenv("RQM_OFFLINE", "")
+	content := "version: \"1.0\"\nrequirements:\n  - summary: Upstream\n"
+	up := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	arg := server.URL + "/requirements.yml"
+	path, err := fetchRemoteFile(arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != content || filepath.Ext(path) != ".yml" {
+		t.Errorf("cached copy %s holds %q", path, data)
+	}
+	if fileLabel(path) != arg {
+		t.Errorf("fileLabel() = %q, want %q", fileLabel(path), arg)
+	}
+
+	// An unpinned file falls back to its cached copy
+	up = false
+	if _, err := fetchRemoteFile(arg); err != nil {
+		t.Errorf("fetch with the server down: %v", err)
+	}
+
+	// A pinned file whose cached copy matches isn't downloaded again
+	pinned := arg + "?checksum=sha256:" + sha256Hex([]byte(content))
+	if _, err := fetchRemoteFile(pinned); err != nil {
+		t.Errorf("pinned file in the cache: %v", err)
+	}
+
+	up = true
+	wrong := arg + "?checksum=sha256:" + strings.Repeat("0", 64)
+	if _, err := fetchRemoteFile(wrong); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("fetch with a wrong checksum = %v", err)
+	}
+
+	t.Setenv("RQM_OFFLINE", "1")
+	if _, err := fetchRemoteFile(server.URL + "/other.yml"); err == nil || !strings.Contains(err.Error(), "offline mode") {
+		t.Errorf("fetch in offline mode = %v", err)
+	}
+}
+
+func TestFetchRemoteFileGit(t *testing.T) {
+	t.Setenv("RQM_REMOTE_CACHE_DIR", t.TempDir())
+	t.Setenv("RQM_OFFLINE", "")
+	repo := gitTestRepo(t)
+	write := func(summary string) {
+		gitCommitFiles(t, summary, map[string]string{".rqm/requirements.yml": "version: \"1.0\"\nrequirements:\n  - summary: " + summary + "\n"})
+	}
+	write("Baseline")
+	mustGit(t, "tag", "v1")
+	write("Later")
+
+	for ref, want := range map[string]string{"v1": "Baseline", "": "Later"} {
+		arg := "git::file://" + filepath.ToSlash(repo) + "//.rqm/requirements.yml"
+		if ref != "" {
+			arg += "?ref=" + ref
+		}
+		path, err := fetchRemoteFile(arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data, _ := os.ReadFile(path); !strings.Contains(string(data), want) {
+			t.Errorf("%s holds %q, want %s", arg, data, want)
+		}
+	}
+}
+
+func TestFindBrokenExternal(t *testing.T) {
+	t.Setenv("RQM_REMOTE_CACHE_DIR", t.TempDir())
+	t.Setenv("RQM_OFFLINE", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/platform.yml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("version: \"1.0\"\nrequirements:\n  - summary: Payments\n    name: PLAT-42\n"))
+	}))
+	defer server.Close()
+	t.Chdir(t.TempDir())
+
+	config, err := model.ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: Checkout
+    requirements:
+      - external: {repo: "` + server.URL + `/platform.yml", id: PLAT-42}
+      - external: {repo: "` + server.URL + `/platform.yml", id: PLAT-99}
+      - external: {repo: "` + server.URL + `/missing.yml", id: PLAT-1}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	broken, unreachable := findBrokenExternal(config)
+	if len(broken) != 1 || broken[0].ID != "PLAT-99" || broken[0].Reason != "not_found" {
+		t.Errorf("broken = %+v, want PLAT-99 not found", broken)
+	}
+	if len(unreachable) != 1 || unreachable[0].ID != "PLAT-1" || unreachable[0].Error == "" {
+		t.Errorf("unreachable = %+v, want PLAT-1", unreachable)
+	}
+	if err := checkFailure(&CycleCheckResult{}, nil, nil, broken, len(unreachable)); exitCode(err) != exitValidation {
+		t.Errorf("checkFailure() = %v, want a validation error", err)
+	}
+}
+
+func TestExternalSource(t *testing.T) {
+	tests := map[model.ExternalReference]string{
+		{Repo: "github.com/acme/platform", ID: "X"}:              "git::github.com/acme/platform//.rqm/requirements.yml",
+		{Repo: "github.com/acme/platform", ID: "X", Ref: "v2.1"}: "git::github.com/acme/platform//.rqm/requirements.yml?ref=v2.1",
+		{Repo: "https://example.com/r.yml", ID: "X"}:             "https://example.com/r.yml",
+	}
+	for ext, want := range tests {
+		if got := externalSource(&ext); got != want {
+			t.Errorf("externalSource(%+v) = %s, want %s", ext, got, want)
+		}
+	}
+}
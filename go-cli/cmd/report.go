@@ -0,0 +1,279 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	approvalsApprover string
+	approvalsFormat   string
+	approvalsOutput   string
+)
+
+// dueDateLayout is the format of approval due dates
+const dueDateLayout = "2006-01-02"
+
+// pendingApproval is one approval still awaiting sign-off
+type pendingApproval struct {
+	Summary string
+	Name    string
+	// UID is the requirement's uid, when it has one
+	UID      string
+	Approver string
+	// Email is the approver's address when it can be resolved
+	Email string
+	// Due is empty when the approval has no due date
+	Due string
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports from a requirements file",
+}
+
+var approvalsPendingCmd = &cobra.Command{
+	Use:   "approvals-pending [file]",
+	Short: "List requirements awaiting approval, as markdown or an iCalendar file",
+	Long: `List approvals that are still pending, sorted by due date.
+
+Use --approver to limit the report to one person; aliases, emails and
+GitHub handles that refer to the same person all match.
+
+Formats:
+  - markdown: a table with overdue approvals marked, for email or chat
+  - ics: one all-day event per approval on its due date with a reminder
+    the day before, for import into calendar tooling. Approvals without
+    a due date are skipped with a warning.`,
+	Example: `  rqm report approvals-pending requirements.yml --approver alice
+  rqm report approvals-pending requirements.yml --approver alice --format ics -o alice.ics`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, _, err := loadRequirements(args[0])
+		if err != nil {
+			return err
+		}
+		pending, err := findPendingApprovals(config, approvalsApprover)
+		if err != nil {
+			return err
+		}
+
+		out := io.Writer(os.Stdout)
+		if approvalsOutput != "" {
+			f, err := os.Create(approvalsOutput)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", approvalsOutput, err)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		switch approvalsFormat {
+		case "markdown":
+			return writeApprovalsMarkdown(out, pending, approvalsApprover, time.Now())
+		case "ics":
+			var undated []string
+			for _, p := range pending {
+				if p.Due == "" {
+					undated = append(undated, displayName(&model.RequirementDetail{Name: p.Name, Summary: p.Summary}))
+				}
+			}
+			if len(undated) > 0 {
+				fmt.Fprintf(os.Stderr, "%s Skipped approval(s) without a due date: %s\n", warnMark(), strings.Join(undated, ", "))
+			}
+			return writeApprovalsICS(out, args[0], pending, time.Now())
+		default:
+			return fmt.Errorf("unknown output format: %s", approvalsFormat)
+		}
+	},
+}
+
+// findPendingApprovals collects pending approvals, optionally only those for
+// approver, sorted by due date with undated approvals last
+func findPendingApprovals(config *model.RequirementConfig, approver string) ([]pendingApproval, error) {
+	var pending []pendingApproval
+	for _, req := range config.Flatten() {
+		for _, approval := range req.Approvals {
+			if approval.Status != "" && approval.Status != "pending" {
+				continue
+			}
+			if approver != "" && !sameOwner(config, approval.Approver, approver) {
+				continue
+			}
+			if approval.Due != "" {
+				if _, err := time.Parse(dueDateLayout, approval.Due); err != nil {
+					return nil, fmt.Errorf("invalid due date %q on %s (expected YYYY-MM-DD)", approval.Due, displayName(req))
+				}
+			}
+			pending = append(pending, pendingApproval{
+				Summary:  req.Summary,
+				Name:     req.Name,
+				UID:      req.UID,
+				Approver: approval.Approver,
+				Email:    ownerEmail(config, approval.Approver),
+				Due:      approval.Due,
+			})
+		}
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		a, b := pending[i].Due, pending[j].Due
+		if a == "" || b == "" {
+			return b == "" && a != ""
+		}
+		return a < b
+	})
+	return pending, nil
+}
+
+// sameOwner reports whether two owner references name the same person
+func sameOwner(config *model.RequirementConfig, a, b string) bool {
+	if strings.EqualFold(strings.TrimPrefix(a, "@"), strings.TrimPrefix(b, "@")) {
+		return true
+	}
+	emailA, emailB := ownerEmail(config, a), ownerEmail(config, b)
+	return emailA != "" && strings.EqualFold(emailA, emailB)
+}
+
+func writeApprovalsMarkdown(out io.Writer, pending []pendingApproval, approver string, now time.Time) error {
+	var b strings.Builder
+	if approver != "" {
+		fmt.Fprintf(&b, "# Pending approvals for %s\n\n", approver)
+	} else {
+		b.WriteString("# Pending approvals\n\n")
+	}
+	if len(pending) == 0 {
+		b.WriteString("No approvals are pending.\n")
+		_, err := io.WriteString(out, b.String())
+		return err
+	}
+
+	today := now.Format(dueDateLayout)
+	b.WriteString("| ID | Summary | Approver | Due |\n|----|---------|----------|-----|\n")
+	for _, p := range pending {
+		due := p.Due
+		switch {
+		case due == "":
+			due = "none"
+		case due < today:
+			due += " (overdue)"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+			markdownCell(p.Name), markdownCell(p.Summary), markdownCell(p.Approver), due)
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// markdownCell escapes a value for a markdown table cell
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// writeApprovalsICS writes an RFC 5545 calendar with one all-day event per
+// dated approval in the requirements file. UIDs are derived from the
+// requirement and approver so re-importing an updated report replaces
+// events instead of duplicating them.
+func writeApprovalsICS(out io.Writer, file string, pending []pendingApproval, now time.Time) error {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(foldICSLine(s))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//RQM//Requirements Management in Code//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	stamp := now.UTC().Format("20060102T150405Z")
+	if abs, err := filepath.Abs(file); err == nil {
+		file = abs
+	}
+	for _, p := range pending {
+		if p.Due == "" {
+			continue
+		}
+		due, _ := time.Parse(dueDateLayout, p.Due)
+		// A requirement's uid is unique and survives edits to its summary;
+		// a summary is only unique within its file
+		key := "uid:" + p.UID
+		if p.UID == "" {
+			key = "file:" + file + "\x00" + p.Summary
+		}
+		sum := sha1.Sum([]byte(key + "\x00" + p.Approver))
+		title := "Approve " + displayName(&model.RequirementDetail{Name: p.Name, Summary: p.Summary})
+
+		line("BEGIN:VEVENT")
+		line("UID:" + hex.EncodeToString(sum[:8]) + "@rqm")
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + due.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + due.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeICSText(title))
+		line("DESCRIPTION:" + escapeICSText("Approval requested from "+p.Approver+" for requirement: "+p.Summary))
+		if p.Email != "" {
+			line(fmt.Sprintf("ATTENDEE;CN=\"%s\";ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION:mailto:%s",
+				strings.ReplaceAll(p.Approver, `"`, ""), p.Email))
+		}
+		line("BEGIN:VALARM")
+		line("ACTION:DISPLAY")
+		line("TRIGGER:-P1D")
+		line("DESCRIPTION:" + escapeICSText(title))
+		line("END:VALARM")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// escapeICSText escapes an iCalendar TEXT value
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldICSLine splits content lines longer than 75 octets, continuing with a
+// leading space and never splitting a UTF-8 sequence
+func foldICSLine(s string) string {
+	const limit = 75
+	var b strings.Builder
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		width = limit - 1
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(approvalsPendingCmd)
+	approvalsPendingCmd.Flags().StringVar(&approvalsApprover, "approver", "", "Only report approvals for this person (alias, email or @github)")
+	approvalsPendingCmd.Flags().StringVarP(&approvalsFormat, "format", "f", "markdown", "Output format (markdown, ics)")
+	approvalsPendingCmd.Flags().StringVarP(&approvalsOutput, "output", "o", "", "Write the report to this file instead of stdout")
+}
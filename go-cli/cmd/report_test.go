@@ -0,0 +1,186 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func approvalsTestConfig() *model.RequirementConfig {
+	return &model.RequirementConfig{
+		Aliases: []model.PersonAlias{{Alias: "alice", Email: "alice@example.com", GitHub: "alicedev"}},
+		Requirements: []model.RequirementDetail{
+			{Summary: "Login", Name: "AUTH-001", Approvals: []model.Approval{
+				{Approver: "alice", Due: "2025-03-01"},
+				{Approver: "bob@example.com", Due: "2025-01-15"},
+			}},
+			{Summary: "Logout", Name: "AUTH-002", Approvals: []model.Approval{
+				{Approver: "@alicedev"},
+				{Approver: "alice@example.com", Due: "2025-02-01", Status: "approved"},
+			}},
+			{Summary: "Audit", Approvals: []model.Approval{{Approver: "alice@example.com", Due: "2025-02-10", Status: "pending"}}},
+		},
+	}
+}
+
+func TestFindPendingApprovals(t *testing.T) {
+	tests := []struct {
+		approver string
+		want     []string
+	}{
+		{"", []string{"Login/bob@example.com", "Audit/alice@example.com", "Login/alice", "Logout/@alicedev"}},
+		{"alice", []string{"Audit/alice@example.com", "Login/alice", "Logout/@alicedev"}},
+		{"@alicedev", []string{"Audit/alice@example.com", "Login/alice", "Logout/@alicedev"}},
+		{"bob@example.com", []string{"Login/bob@example.com"}},
+		{"carol", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.approver, func(t *testing.T) {
+			pending, err := findPendingApprovals(approvalsTestConfig(), tt.approver)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, p := range pending {
+				got = append(got, p.Summary+"/"+p.Approver)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindPendingApprovalsRejectsBadDate(t *testing.T) {
+	config := &model.RequirementConfig{Requirements: []model.RequirementDetail{
+		{Summary: "Login", Approvals: []model.Approval{{Approver: "alice", Due: "03/01/2025"}}},
+	}}
+	_, err := findPendingApprovals(config, "")
+	if err == nil || !strings.Contains(err.Error(), "invalid due date") {
+		t.Errorf("Expected invalid due date error, got %v", err)
+	}
+}
+
+func TestWriteApprovalsMarkdown(t *testing.T) {
+	pending, _ := findPendingApprovals(approvalsTestConfig(), "alice")
+	var b strings.Builder
+	if err := writeApprovalsMarkdown(&b, pending, "alice", time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"# Pending approvals for alice",
+		"|  | Audit | alice@example.com | 2025-02-10 (overdue) |",
+		"| AUTH-001 | Login | alice | 2025-03-01 |",
+		"| AUTH-002 | Logout | @alicedev | none |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteApprovalsICS(t *testing.T) {
+	pending, _ := findPendingApprovals(approvalsTestConfig(), "alice")
+	var b strings.Builder
+	if err := writeApprovalsICS(&b, "requirements.yml", pending, time.Date(2025, 2, 20, 9, 30, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+
+	if strings.Count(out, "BEGIN:VEVENT") != 2 {
+		t.Errorf("Expected events only for the two dated approvals:\n%s", out)
+	}
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTAMP:20250220T093000Z\r\n",
+		"DTSTART;VALUE=DATE:20250301\r\nDTEND;VALUE=DATE:20250302\r\n",
+		"SUMMARY:Approve [AUTH-001] Login\r\n",
+		"mailto:alice@example.com\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Line longer than 75 octets: %q", line)
+		}
+	}
+
+	var again strings.Builder
+	writeApprovalsICS(&again, "requirements.yml", pending, time.Date(2025, 2, 20, 9, 30, 0, 0, time.UTC))
+	if again.String() != out {
+		t.Error("Expected identical output (stable UIDs) for the same input")
+	}
+}
+
+func TestApprovalsICSUIDs(t *testing.T) {
+	day := time.Date(2025, 2, 20, 9, 30, 0, 0, time.UTC)
+	uids := func(file string, pending ...pendingApproval) []string {
+		var b strings.Builder
+		writeApprovalsICS(&b, file, pending, day)
+		var out []string
+		for _, line := range strings.Split(b.String(), "\r\n") {
+			if uid, ok := strings.CutPrefix(line, "UID:"); ok {
+				out = append(out, uid)
+			}
+		}
+		return out
+	}
+
+	// The same summary in two files gives two events
+	login := pendingApproval{Summary: "Login", Approver: "alice", Due: "2025-03-01"}
+	a, b := uids("web/requirements.yml", login), uids("api/requirements.yml", login)
+	if len(a) != 1 || len(b) != 1 || a[0] == b[0] {
+		t.Errorf("Expected different UIDs for the same summary in two files, got %v and %v", a, b)
+	}
+
+	// A requirement's uid keeps its event across files and summary edits,
+	// and tells requirements with the same summary apart
+	withUID := login
+	withUID.UID = "3f2a9c1e0b7d"
+	renamed := withUID
+	renamed.Summary = "Sign in"
+	other := login
+	other.UID = "8c41d07e2a95"
+	got := uids("web/requirements.yml", withUID, other)
+	if again := uids("api/requirements.yml", renamed); len(got) != 2 || got[0] == got[1] || again[0] != got[0] {
+		t.Errorf("Expected UIDs to follow the requirement's uid, got %v and %v", got, again)
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"short", "SUMMARY:hi", "SUMMARY:hi"},
+		{"fold", strings.Repeat("a", 80), strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 5)},
+		{"utf8", strings.Repeat("a", 74) + "é", strings.Repeat("a", 74) + "\r\n é"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := foldICSLine(tt.in); got != tt.want {
+				t.Errorf("foldICSLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeICSText(t *testing.T) {
+	if got := escapeICSText("a,b;c\\d\ne"); got != `a\,b\;c\\d\ne` {
+		t.Errorf("escapeICSText() = %q", got)
+	}
+}
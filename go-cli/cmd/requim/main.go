@@ -0,0 +1,14 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+// Command requim is rqm under its alternative name, for installing with
+// go install github.com/238855/rqm/go-cli/cmd/requim@latest. A copy of
+// or symlink to the rqm binary named requim behaves the same.
+package main
+
+import "github.com/238855/rqm/go-cli/cmd"
+
+func main() {
+	cmd.Execute()
+}
@@ -0,0 +1,333 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	reviewRequirement    string
+	reviewHeuristicsOnly bool
+	reviewFormat         string
+)
+
+// Kinds of review findings
+const (
+	findingAmbiguity         = "ambiguity"
+	findingMissingAcceptance = "missing_acceptance"
+	findingUntestable        = "untestable"
+)
+
+// findingKinds lists the kinds of review findings in display order
+var findingKinds = []string{findingAmbiguity, findingUntestable, findingMissingAcceptance}
+
+// vagueWords leave a requirement open to interpretation: who decides what
+// is appropriate, or how many several is?
+var vagueWords = []string{
+	"appropriate", "appropriately", "adequate", "as needed", "as required",
+	"as appropriate", "if possible", "where possible", "if necessary", "etc",
+	"and so on", "and/or", "some", "several", "various", "normally", "usually",
+	"typically", "generally", "reasonable", "reasonably", "sufficient",
+	"sufficiently", "approximately", "tbd", "to be determined",
+}
+
+// unmeasurableWords name a quality without a criterion to test it by; they
+// are fine next to a number, as in "fast (under 200 ms)"
+var unmeasurableWords = []string{
+	"fast", "quick", "quickly", "easy", "easily", "user-friendly", "intuitive",
+	"simple", "seamless", "seamlessly", "robust", "efficient", "efficiently",
+	"scalable", "state-of-the-art", "optimal", "minimal", "maximize", "minimize",
+	"flexible", "modern", "secure",
+}
+
+// passiveVoice matches "is stored" and the like; a passive sentence
+// without "by" hides who is meant to act
+var passiveVoice = regexp.MustCompile(`(?i)\b(?:is|are|was|were|be|been|being)\s+(\w+ed|shown|given|sent|made|done|taken|written|known|seen|built|kept|held|set|put|read|told|found|chosen|hidden|run)\b`)
+
+// ReviewFinding is a problem review found in a requirement
+type ReviewFinding struct {
+	Summary string `json:"summary"`
+	Name    string `json:"name,omitempty"`
+	// Kind is ambiguity, untestable or missing_acceptance
+	Kind string `json:"kind"`
+	// Field is the requirement field the finding is in, such as description
+	Field string `json:"field,omitempty"`
+	// Excerpt is the phrase the finding is about
+	Excerpt    string `json:"excerpt,omitempty"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// reviewSystemPrompt asks the model for findings in the shape of
+// ReviewFinding
+const reviewSystemPrompt = `You review software requirements for quality. For the requirement given as YAML, report problems as a JSON object {"findings": [...]}, each finding an object with these keys:
+- "kind": "ambiguity" for wording open to interpretation, "untestable" for statements no test could confirm or refute, "missing_acceptance" when nothing says how to tell the requirement is met
+- "field": the requirement field the problem is in, such as summary, description or acceptance_test
+- "excerpt": the exact words the problem is about, if any
+- "message": the problem, in one sentence
+- "suggestion": a concrete rewording or addition that fixes it
+Report only real problems; a clear, testable requirement has no findings and gets {"findings": []}.`
+
+var reviewCmd = &cobra.Command{
+	Use:   "review [file]",
+	Short: "Flag vague or untestable requirements",
+	Long: `Review requirements for ambiguity, untestable phrasing and missing
+acceptance criteria, and report each problem as a finding with the field
+and words it is about, and a suggested fix.
+
+By default each requirement is sent to the LLM configured under llm: (see
+rqm draft --help), one request per requirement with its summary,
+description, justification and acceptance criteria. --heuristics-only
+reviews locally with fixed rules instead, and works offline:
+
+  ambiguity           vague words such as "appropriate", "several" or "etc",
+                      and passive voice without "by", which hides who acts
+  untestable          qualities such as "fast" or "user-friendly" with no
+                      number in the same sentence to test them by
+  missing_acceptance  no acceptance_test, acceptance_test_link or
+                      acceptance_criteria
+
+--requirement reviews one requirement and its sub-requirements only.
+Deprecated requirements are skipped.`,
+	Example: `  rqm review
+  rqm review requirements.yml --heuristics-only
+  rqm review requirements.yml --requirement AUTH-001 --format json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		if reviewFormat != "text" && reviewFormat != "json" {
+			return fmt.Errorf("unknown output format: %s", reviewFormat)
+		}
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist: %s", file)
+		}
+		config, err := model.Load(file)
+		if err != nil {
+			return err
+		}
+
+		reqs := config.Flatten()
+		if reviewRequirement != "" {
+			root, ok := config.Find(reviewRequirement)
+			if !ok {
+				return fmt.Errorf("requirement not found: %s", reviewRequirement)
+			}
+			reqs = root.Flatten()
+		}
+		reqs = slices.DeleteFunc(reqs, (*model.RequirementDetail).IsDeprecated)
+
+		mode := "heuristics"
+		var findings []ReviewFinding
+		if reviewHeuristicsOnly {
+			for _, req := range reqs {
+				findings = append(findings, reviewHeuristics(req)...)
+			}
+		} else {
+			mode = "llm"
+			project, err := loadProjectConfig(file)
+			if err != nil {
+				return err
+			}
+			client, err := newLLMClient("rqm review", project)
+			if err != nil {
+				return fmt.Errorf("%w (--heuristics-only reviews without an LLM)", err)
+			}
+			for i, req := range reqs {
+				fmt.Fprintf(os.Stderr, "Reviewing %d/%d: %s\n", i+1, len(reqs), displayName(req))
+				found, err := reviewWithLLM(cmd.Context(), client, req)
+				if err != nil {
+					return err
+				}
+				findings = append(findings, found...)
+			}
+		}
+
+		if reviewFormat == "json" {
+			return writeJSON(ReviewOutput{
+				SchemaVersion: apiVersion,
+				File:          file,
+				Mode:          mode,
+				Reviewed:      len(reqs),
+				Findings:      nonNil(findings),
+			})
+		}
+		displayFindings(file, mode, len(reqs), findings)
+		return nil
+	},
+}
+
+// reviewHeuristics checks req with the local rules of --heuristics-only
+func reviewHeuristics(req *model.RequirementDetail) []ReviewFinding {
+	var findings []ReviewFinding
+	add := func(kind, field, excerpt, message, suggestion string) {
+		findings = append(findings, ReviewFinding{
+			Summary: req.Summary, Name: req.Name, Kind: kind, Field: field,
+			Excerpt: excerpt, Message: message, Suggestion: suggestion,
+		})
+	}
+
+	fields := []struct{ name, text string }{
+		{"summary", req.Summary},
+		{"description", req.Description},
+		{"acceptance_test", req.AcceptanceTest},
+	}
+	for _, c := range req.AcceptanceCriteria {
+		fields = append(fields, struct{ name, text string }{"acceptance_criteria " + c.ID, c.Criterion})
+	}
+	for _, f := range fields {
+		for _, sentence := range sentences(f.text) {
+			for _, word := range findWords(sentence, vagueWords) {
+				add(findingAmbiguity, f.name, word,
+					fmt.Sprintf("'%s' is open to interpretation", word),
+					"Say exactly what is meant, with a number or a list where one applies")
+			}
+			if !strings.ContainsFunc(sentence, unicode.IsDigit) {
+				for _, word := range findWords(sentence, unmeasurableWords) {
+					add(findingUntestable, f.name, word,
+						fmt.Sprintf("'%s' can't be tested without a measurable criterion", word),
+						"Add the threshold that makes it "+word+", such as a time, count or rate")
+				}
+			}
+			// Acceptance tests describe steps, where passive voice is common
+			// and harmless
+			if f.name == "summary" || f.name == "description" {
+				lower := strings.ToLower(sentence)
+				if m := passiveVoice.FindString(sentence); m != "" && !strings.Contains(lower, " by ") {
+					add(findingAmbiguity, f.name, m,
+						fmt.Sprintf("'%s' is passive, so it doesn't say who or what acts", m),
+						"Name the actor: \"the system ...\", \"an administrator ...\"")
+				}
+			}
+		}
+	}
+
+	if req.AcceptanceTest == "" && req.AcceptanceTestLink == "" && len(req.AcceptanceCriteria) == 0 {
+		add(findingMissingAcceptance, "", "",
+			"Nothing says how to tell the requirement is met",
+			"Add an acceptance_test with Given/When/Then steps, or acceptance_criteria")
+	}
+	return findings
+}
+
+// sentences splits text at sentence ends and line breaks
+func sentences(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return r == '.' || r == '!' || r == '?' || r == ';' || r == '\n'
+	})
+}
+
+// findWords returns the words and phrases of list that occur in sentence
+// as whole words, in list order
+func findWords(sentence string, list []string) []string {
+	lower := " " + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '/' {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, sentence) + " "
+	lower = strings.Join(strings.Fields(lower), " ")
+	var found []string
+	for _, word := range list {
+		if strings.Contains(" "+lower+" ", " "+word+" ") {
+			found = append(found, word)
+		}
+	}
+	return found
+}
+
+// reviewWithLLM asks the model to review req; findings of unknown kinds
+// are dropped
+func reviewWithLLM(ctx context.Context, client *llmClient, req *model.RequirementDetail) ([]ReviewFinding, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	prompt, err := yaml.Marshal(model.RequirementDetail{
+		Summary:            req.Summary,
+		Description:        req.Description,
+		Justification:      req.Justification,
+		AcceptanceTest:     req.AcceptanceTest,
+		AcceptanceTestLink: req.AcceptanceTestLink,
+		AcceptanceCriteria: req.AcceptanceCriteria,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var answer struct {
+		Findings []ReviewFinding `json:"findings"`
+	}
+	if err := client.completeJSON(ctx, reviewSystemPrompt, string(prompt), &answer); err != nil {
+		return nil, fmt.Errorf("reviewing '%s': %w", req.Summary, err)
+	}
+	var findings []ReviewFinding
+	for _, f := range answer.Findings {
+		if !slices.Contains(findingKinds, f.Kind) || f.Message == "" {
+			continue
+		}
+		f.Summary, f.Name = req.Summary, req.Name
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
+
+// displayFindings prints the findings for humans, grouped by requirement
+func displayFindings(file, mode string, reviewed int, findings []ReviewFinding) {
+	fmt.Printf("Reviewing %s (%s)...\n\n", file, mode)
+	if len(findings) == 0 {
+		fmt.Printf("%s No findings in %d requirement(s)\n", okMark(), reviewed)
+		return
+	}
+
+	counts := make(map[string]int)
+	requirements := 0
+	for i, f := range findings {
+		if i == 0 || f.Summary != findings[i-1].Summary {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(displayName(&model.RequirementDetail{Name: f.Name, Summary: f.Summary}))
+			requirements++
+		}
+		where := f.Kind
+		if f.Field != "" {
+			where += " in " + f.Field
+		}
+		fmt.Printf("  %s %s: %s\n", warnMark(), where, f.Message)
+		if f.Suggestion != "" {
+			fmt.Printf("    %s\n", paint(ansiDim, "→ "+f.Suggestion))
+		}
+		counts[f.Kind]++
+	}
+
+	var parts []string
+	for _, kind := range findingKinds {
+		if counts[kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+	fmt.Printf("\n%d finding(s) in %d of %d requirement(s): %s\n", len(findings), requirements, reviewed, strings.Join(parts, ", "))
+}
+
+func init() {
+	rootCmd.AddCommand(reviewCmd)
+	reviewCmd.Flags().StringVar(&reviewRequirement, "requirement", "", "Summary or ID of the requirement to review, with its sub-requirements")
+	reviewCmd.RegisterFlagCompletionFunc("requirement", completeRequirementIDFlag)
+	reviewCmd.Flags().BoolVar(&reviewHeuristicsOnly, "heuristics-only", false, "Review with local rules only, without an LLM")
+	reviewCmd.Flags().StringVarP(&reviewFormat, "format", "f", "text", "Output format (text, json)")
+}
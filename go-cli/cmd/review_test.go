@@ -0,0 +1,106 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestReviewHeuristics(t *testing.T) {
+	tests := []struct {
+		name string
+		req  model.RequirementDetail
+		want []string
+	}{
+		{
+			name: "clear and testable",
+			req: model.RequirementDetail{
+				Summary:        "Lock accounts after failed logins",
+				Description:    "The system locks an account after 5 failed logins within 10 minutes.",
+				AcceptanceTest: "Given an account\nWhen 5 logins fail\nThen the account is locked\n",
+			},
+		},
+		{
+			name: "vague and unmeasurable",
+			req: model.RequirementDetail{
+				Summary:     "Fast search",
+				Description: "Search must be fast and user-friendly. It shows several results etc.",
+			},
+			want: []string{
+				"untestable summary fast",
+				"untestable description fast",
+				"untestable description user-friendly",
+				"ambiguity description etc",
+				"ambiguity description several",
+				"missing_acceptance  ",
+			},
+		},
+		{
+			name: "numbers make a quality testable",
+			req: model.RequirementDetail{
+				Summary:            "Search",
+				Description:        "Search is fast: results appear within 200 ms.",
+				AcceptanceCriteria: []model.Criterion{{ID: "AC1", Criterion: "Results are shown in an appropriate order"}},
+			},
+			want: []string{"ambiguity acceptance_criteria AC1 appropriate"},
+		},
+		{
+			name: "passive voice without an actor",
+			req: model.RequirementDetail{
+				Summary:        "Audit log",
+				Description:    "Every login is recorded. Exports are signed by the audit service.",
+				AcceptanceTest: "Given a login\nThen it is recorded\n",
+			},
+			want: []string{"ambiguity description is recorded"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range reviewHeuristics(&tt.req) {
+				got = append(got, f.Kind+" "+f.Field+" "+f.Excerpt)
+			}
+			slices.Sort(got)
+			slices.Sort(tt.want)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("findings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewWithLLM(t *testing.T) {
+	t.Setenv("RQM_OFFLINE", "")
+	t.Setenv("TEST_LLM_KEY", "secret")
+	var body map[string]interface{}
+	srv := llmServer(t, `{"findings": [
+  {"kind": "untestable", "field": "description", "excerpt": "quickly", "message": "No time limit is given.", "suggestion": "Say within 2 seconds."},
+  {"kind": "style", "message": "Unknown kinds are dropped."}
+]}`, &body)
+	userLLMConfig(t, LLMConfig{URL: srv.URL + "/v1", Model: "test-model", APIKeyEnv: "TEST_LLM_KEY"})
+	client, err := newLLMClient("rqm review", &ProjectConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &model.RequirementDetail{Summary: "Search", Name: "SRCH-1", Description: "Results appear quickly.", Owner: "@alice"}
+	findings, err := reviewWithLLM(context.Background(), client, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 1 || findings[0].Name != "SRCH-1" || findings[0].Excerpt != "quickly" {
+		t.Errorf("findings = %+v", findings)
+	}
+	messages, _ := body["messages"].([]interface{})
+	prompt := messages[1].(map[string]interface{})["content"].(string)
+	if !strings.Contains(prompt, "description: Results appear quickly.") || strings.Contains(prompt, "alice") {
+		t.Errorf("prompt = %q", prompt)
+	}
+}
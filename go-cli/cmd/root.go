@@ -7,6 +7,7 @@ package cmd
 import (
     "fmt"
     "os"
+    "strings"
 
     "github.com/spf13/cobra"
     "github.com/spf13/viper"
@@ -25,15 +26,45 @@ It provides powerful features for:
   - Defining requirements with full traceability
   - Building requirement trees with circular reference detection
   - Validating requirements against a JSON schema
-  - Querying and visualizing requirement relationships`,
+  - Querying and visualizing requirement relationships
+
+Command aliases defined under aliases: in ~/.rqm.yaml or .rqm/config.yml
+expand to the command line they stand for, e.g. "ls: list --format table".`,
     Version: "0.1.0",
+    PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+        if err := configureColor(colorMode); err != nil {
+            return err
+        }
+        version, err := resolveAPIVersion(apiVersionFlag)
+        if err != nil {
+            return err
+        }
+        apiVersion = version
+        if err := applyOutputMode(cmd); err != nil {
+            return err
+        }
+        if err := applyMemoryBudget(cmd); err != nil {
+            return err
+        }
+        return startProfiling()
+    },
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
-    err := rootCmd.Execute()
+    rootCmd.Use = programName(os.Args[0])
+    args, err := aliasedArgs(os.Args[1:])
+    if err != nil {
+        fmt.Fprintln(os.Stderr, "Error:", err)
+        os.Exit(exitCode(err))
+    }
+    rootCmd.SetArgs(args)
+
+    err = rootCmd.Execute()
+    stopProfiling()
+    restoreOutput()
     if err != nil {
-        os.Exit(1)
+        os.Exit(exitCode(err))
     }
 }
 
@@ -42,6 +73,14 @@ func init() {
 
     rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.rqm.yaml)")
     rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+    rootCmd.PersistentFlags().StringVar(&profileCPU, "profile-cpu", "", "write a pprof CPU profile of the command to this file")
+    rootCmd.PersistentFlags().StringVar(&profileMem, "profile-mem", "", "write a pprof heap profile to this file when the command finishes")
+    rootCmd.PersistentFlags().StringVar(&colorMode, "color", "auto", "colorize output: auto, always or never (NO_COLOR disables auto)")
+    rootCmd.PersistentFlags().IntVar(&apiVersionFlag, "api-version", 0, "pin the JSON output version (default latest, or RQM_API_VERSION)")
+    rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "machine-readable output (same as --format json) for "+strings.Join(outputSchemaCommands(), ", "))
+    rootCmd.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false, "print nothing; report the result through the exit code only")
+    rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "run the validator even when .rqm/cache has its result for the file")
+    rootCmd.PersistentFlags().StringVar(&maxMemoryFlag, "max-memory", "", "memory budget (e.g. 256MB); list and stats then stream the requirements instead of loading the whole file")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -0,0 +1,316 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"go.yaml.in/yaml/v3"
+)
+
+// schemaNode is a JSON Schema, or a part of one, as decoded
+type schemaNode = map[string]interface{}
+
+// undeclaredField is a field used in requirements files but missing from
+// the schema
+type undeclaredField struct {
+	// Field is the schema location, e.g. requirement.component for a
+	// component key on a requirement
+	Field string
+	Uses  int
+	// First is where it is first used, as file: path
+	First string
+}
+
+// schemaSync compares the fields of requirements files with a schema
+type schemaSync struct {
+	schema schemaNode
+	// declared holds the fields the schema declares, by location
+	declared map[string]bool
+	used     map[string]bool
+	// undeclared holds the fields used but not declared, by location
+	undeclared map[string]*undeclaredField
+}
+
+// newSchemaSync reads the schema at path and collects its fields
+func newSchemaSync(path string) (*schemaSync, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema %s: %w", path, err)
+	}
+	var schema schemaNode
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse schema %s: %w", path, err)
+	}
+
+	s := &schemaSync{
+		schema:     schema,
+		declared:   make(map[string]bool),
+		used:       make(map[string]bool),
+		undeclared: make(map[string]*undeclaredField),
+	}
+	s.collectFields("", schema)
+	defs, _ := schema["$defs"].(schemaNode)
+	for _, name := range sortedKeys(defs) {
+		if def, ok := defs[name].(schemaNode); ok {
+			s.collectFields(name, def)
+		}
+	}
+	return s, nil
+}
+
+// collectFields records the properties of an object schema at location,
+// and those of inline object schemas below them. Referenced definitions
+// are collected under their own name.
+func (s *schemaSync) collectFields(location string, node schemaNode) {
+	props, _ := node["properties"].(schemaNode)
+	for name, prop := range props {
+		field := joinField(location, name)
+		s.declared[field] = true
+		propSchema, _ := prop.(schemaNode)
+		if items, ok := propSchema["items"].(schemaNode); ok {
+			propSchema = items
+		}
+		if _, isRef := propSchema["$ref"]; !isRef && propSchema != nil {
+			s.collectFields(field, propSchema)
+		}
+	}
+}
+
+// checkFile walks the requirements file at path alongside the schema
+func (s *schemaSync) checkFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist: %s", path)
+		}
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if data, err = model.ToYAML(path, data); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	s.walk(path, "", "", doc, s.schema)
+	return nil
+}
+
+// walk records the fields of value, found at docPath, against the schema
+// node at location
+func (s *schemaSync) walk(file, docPath, location string, value interface{}, node schemaNode) {
+	node, location = s.resolve(node, location, value)
+	if node == nil {
+		return
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		props, _ := node["properties"].(schemaNode)
+		// A map of free keys, such as attributes, declares none of them
+		additional, hasAdditional := node["additionalProperties"]
+		freeKeys := props == nil || hasAdditional && additional != false
+		for _, key := range sortedKeys(v) {
+			field := joinField(location, key)
+			prop, declared := props[key].(schemaNode)
+			if declared {
+				s.used[field] = true
+				s.walk(file, joinField(docPath, key), field, v[key], prop)
+				continue
+			}
+			if freeKeys {
+				continue
+			}
+			if u, ok := s.undeclared[field]; ok {
+				u.Uses++
+			} else {
+				s.undeclared[field] = &undeclaredField{Field: field, Uses: 1, First: file + ": " + joinField(docPath, key)}
+			}
+		}
+	case []interface{}:
+		items, _ := node["items"].(schemaNode)
+		for i, item := range v {
+			s.walk(file, fmt.Sprintf("%s[%d]", docPath, i), location, item, items)
+		}
+	}
+}
+
+// resolve follows $ref and picks the oneOf or anyOf branch for the kind
+// of value, returning the schema that applies and its location. A
+// definition's fields are located by its name.
+func (s *schemaSync) resolve(node schemaNode, location string, value interface{}) (schemaNode, string) {
+	for node != nil {
+		if ref, ok := node["$ref"].(string); ok {
+			name, found := strings.CutPrefix(ref, "#/$defs/")
+			defs, _ := s.schema["$defs"].(schemaNode)
+			def, _ := defs[name].(schemaNode)
+			if !found || def == nil {
+				return nil, ""
+			}
+			node, location = def, name
+			continue
+		}
+		branches, _ := node["oneOf"].([]interface{})
+		if branches == nil {
+			branches, _ = node["anyOf"].([]interface{})
+		}
+		if branches == nil {
+			return node, location
+		}
+		var next schemaNode
+		for _, branch := range branches {
+			b, _ := branch.(schemaNode)
+			if resolved, _ := s.resolve(b, location, value); resolved != nil && schemaAccepts(resolved, value) {
+				next = b
+				break
+			}
+		}
+		node = next
+	}
+	return nil, ""
+}
+
+// schemaAccepts reports whether the type of node admits the kind of
+// value: an object, an array or any scalar
+func schemaAccepts(node schemaNode, value interface{}) bool {
+	kind := "scalar"
+	switch value.(type) {
+	case map[string]interface{}:
+		kind = "object"
+	case []interface{}:
+		kind = "array"
+	}
+	matches := func(t interface{}) bool {
+		if kind == "scalar" {
+			return t != "object" && t != "array"
+		}
+		return t == kind
+	}
+	switch t := node["type"].(type) {
+	case string:
+		return matches(t)
+	case []interface{}:
+		for _, item := range t {
+			if matches(item) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// unused lists the declared fields no file used, sorted
+func (s *schemaSync) unused() []string {
+	var fields []string
+	for field := range s.declared {
+		if !s.used[field] {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// undeclaredFields lists the fields used but not declared, sorted
+func (s *schemaSync) undeclaredFields() []*undeclaredField {
+	var fields []*undeclaredField
+	for _, field := range sortedKeys(s.undeclared) {
+		fields = append(fields, s.undeclared[field])
+	}
+	return fields
+}
+
+// joinField appends a key to a location or document path
+func joinField(location, key string) string {
+	if location == "" {
+		return key
+	}
+	return location + "." + key
+}
+
+// activeSchemaFile is the schema --schema-sync compares with: --schema,
+// a schema.json in the .rqm directory, or the one found from the current
+// directory or the validator
+func activeSchemaFile() (string, error) {
+	if checkSchema != "" {
+		return checkSchema, nil
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		if path := findInRQMDir(cwd, "schema.json"); path != "" {
+			return path, nil
+		}
+	}
+	if path := findSchemaFile(findValidatorBinary()); path != "" {
+		return path, nil
+	}
+	return "", fmt.Errorf("schema.json not found; pass --schema")
+}
+
+// runSchemaSync is check --schema-sync: every field used in the files
+// must be declared in the schema, and schema fields no file uses are
+// warnings
+func runSchemaSync(args []string) error {
+	if err := checkFailOn(); err != nil {
+		return err
+	}
+	if checkFormat != "text" {
+		return fmt.Errorf("--schema-sync supports text output only")
+	}
+	schemaFile, err := activeSchemaFile()
+	if err != nil {
+		return err
+	}
+	files, err := recursiveFileArgs(args)
+	if err != nil {
+		return err
+	}
+	sync, err := newSchemaSync(schemaFile)
+	if err != nil {
+		return err
+	}
+	// A file that doesn't parse is the validator's to report; it is
+	// skipped with a warning so one broken file doesn't hide the drift
+	var skipped []error
+	for _, file := range files {
+		if err := sync.checkFile(file); err != nil {
+			skipped = append(skipped, err)
+		}
+	}
+
+	undeclared, unused := sync.undeclaredFields(), sync.unused()
+	fmt.Printf("Checking %d file(s) against %s...\n\n", len(files), schemaFile)
+	for _, err := range skipped {
+		fmt.Printf("%s Skipped: %v\n", warnMark(), err)
+	}
+	if len(skipped) > 0 {
+		fmt.Println()
+	}
+	if len(undeclared) == 0 {
+		fmt.Println(okMark(), "Every field used is declared in the schema")
+	} else {
+		fmt.Printf("%s %d field(s) used but not declared in the schema:\n", failMark(), len(undeclared))
+		for _, u := range undeclared {
+			fmt.Printf("  - %s (%d use(s), first at %s)\n", u.Field, u.Uses, u.First)
+		}
+	}
+	if len(unused) > 0 {
+		fmt.Printf("\n%s %d schema field(s) never used:\n", warnMark(), len(unused))
+		for _, field := range unused {
+			fmt.Printf("  - %s\n", field)
+		}
+	}
+
+	var failure error
+	if len(undeclared) > 0 {
+		failure = validationError("%d field(s) not declared in %s", len(undeclared), schemaFile)
+	}
+	return applyFailOn(failure, len(unused)+len(skipped))
+}
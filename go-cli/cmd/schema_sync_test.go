@@ -0,0 +1,94 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const schemaSyncSchema = `{
+  "type": "object",
+  "required": ["version", "requirements"],
+  "properties": {
+    "version": { "type": "string" },
+    "requirements": { "type": "array", "items": { "$ref": "#/$defs/requirement_reference" } }
+  },
+  "$defs": {
+    "requirement_reference": {
+      "oneOf": [{ "type": "string" }, { "$ref": "#/$defs/requirement" }]
+    },
+    "requirement": {
+      "type": "object",
+      "properties": {
+        "summary": { "type": "string" },
+        "owner": { "type": "string" },
+        "created_at": { "type": "string" },
+        "attributes": { "type": "object", "additionalProperties": { "type": "string" } },
+        "review": {
+          "type": "object",
+          "properties": { "by": { "type": "string" }, "due": { "type": "string" } },
+          "additionalProperties": false
+        },
+        "requirements": { "type": "array", "items": { "$ref": "#/$defs/requirement_reference" } }
+      },
+      "additionalProperties": false
+    }
+  }
+}`
+
+func TestSchemaSync(t *testing.T) {
+	tmpDir := t.TempDir()
+	schemaFile := filepath.Join(tmpDir, "schema.json")
+	os.WriteFile(schemaFile, []byte(schemaSyncSchema), 0644)
+	a := filepath.Join(tmpDir, "a.yml")
+	os.WriteFile(a, []byte(`version: "1.0"
+requirements:
+  - summary: Login
+    owner: "@alice"
+    component: auth
+    attributes:
+      team: identity
+    review:
+      by: bob
+      when: soon
+    requirements:
+      - Logout
+      - summary: Password hashing
+        component: auth
+`), 0644)
+	b := filepath.Join(tmpDir, "b.yml")
+	os.WriteFile(b, []byte(`version: "1.0"
+requirements:
+  - summary: Backups
+`), 0644)
+
+	sync, err := newSchemaSync(schemaFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{a, b} {
+		if err := sync.checkFile(file); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var undeclared []string
+	for _, u := range sync.undeclaredFields() {
+		undeclared = append(undeclared, u.Field)
+	}
+	if strings.Join(undeclared, " ") != "requirement.component requirement.review.when" {
+		t.Errorf("Expected component and review.when to be undeclared, got %v", undeclared)
+	}
+	component := sync.undeclaredFields()[0]
+	if component.Uses != 2 || component.First != a+": requirements[0].component" {
+		t.Errorf("Unexpected uses of component: %+v", component)
+	}
+	if got := strings.Join(sync.unused(), " "); got != "requirement.created_at requirement.review.due" {
+		t.Errorf("unused() = %q", got)
+	}
+}
@@ -8,11 +8,15 @@ import (
 	"embed"
 	"fmt"
 	"io/fs"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"time"
 
+	"github.com/238855/rqm/go-cli/pkg/model"
 	"github.com/spf13/cobra"
 )
 
@@ -20,8 +24,17 @@ import (
 var webUI embed.FS
 
 var (
-	servePort string
-	serveOpen bool
+	servePort     string
+	serveHost     string
+	serveOpen     bool
+	serveNotify   bool
+	serveRef      string
+	serveBaseline string
+	serveBasePath string
+	// serveProjectDir is --project-dir
+	serveProjectDir string
+	serveDaemonMode bool
+	serveInterval   time.Duration
 )
 
 var serveCmd = &cobra.Command{
@@ -36,21 +49,189 @@ including:
   - Requirement details and relationships
   - Search and filter capabilities
 
-If a requirements file is provided, it will be automatically loaded.`,
+The server listens on 127.0.0.1 unless --host names another address,
+such as 0.0.0.0 for every interface; it has no authentication, and the
+web UI can change the requirements file, so only expose it on networks
+whose users may.
+
+If a requirements file is provided, it will be automatically loaded, and
+README badges for it are served at /badge.svg?metric=<metric> (see
+rqm badge for the metrics).
+
+/api/graph/layout?algorithm=force|layered returns node positions computed
+on the server, so large graphs don't have to be laid out in the browser.
+Layouts are cached until the file changes, and after an edit the force
+layout starts from the previous positions.
+
+/api/graph?format=d3|cytoscape returns the requirement graph for an
+interactive view: nodes with their status and priority, sub-requirement
+and relation edges, and the circular references rqm check reports, with
+the nodes and edges in each marked.
+
+/api/search?q=<words>&status=<status>&tag=<tag> searches the
+requirements on the server, a page at a time: every word of q must appear
+in the summary, ID, description or a tag, and status, priority, owner, tag
+and verification must each have one of the values given (repeat a
+parameter for several). filter takes an expression as for rqm bulk, such
+as priority>=high && !(tag==legacy). page and page_size (50 by default,
+at most 500) pick the page; include_deprecated=true also finds deprecated
+requirements.
+
+/api/diff?base=<ref> compares the file with its version at a git ref,
+HEAD by default, with changed wording diffed word by word (see rqm diff).
+
+POST /api/requirement creates or edits a requirement from the web UI's
+form, as JSON with the fields summary, name, description, justification,
+acceptance_test, status, priority, owner, verification and tags, plus
+original, the summary or ID of the requirement to edit, or parent, that
+of the requirement to create one under. The change is validated first,
+and the answer carries the validation result and a diff of the file;
+with "preview": true nothing is saved. Send the ETag of
+/api/requirements as If-Match to refuse the change if the file changed
+since it was read. Saved changes go through the same lock and history as
+the CLI's, so rqm undo reverts them. POSTs must be sent as
+application/json, and one with an Origin header from another site is
+refused, so a page elsewhere can't make a browser change the file.
+
+/api/attachment?path=<path> serves a file attached to a requirement,
+relative to the requirements file. Only attached paths are served.
+
+/api/requirements/<id>/comments lists the comments on a requirement, by
+ID or summary, and POST adds one as {"author": ..., "text": ...}.
+Comments are kept in .rqm/comments.yml, beside the history. With
+?viewer=<name> a GET also marks the viewer as having the requirement open
+and lists the others who do, so reviewers see who else is looking.
+
+With --notify, every saved change to the file is posted to the
+notifications in .rqm/config.yml that receive requirements_changed events
+(see rqm notify).
+
+--ref serves the file as it was at a git ref instead, and --baseline at
+the ref a baseline names in .rqm/config.yml:
+
+  baselines:
+    design-review: v1.0
+
+Such a snapshot is read-only: the UI carries a banner naming the ref and
+commit, requests that would change anything are refused, and
+/api/snapshot tells the UI which version it shows. /api/diff isn't
+available for a snapshot.
+
+/metrics exposes Prometheus gauges of requirement health for Grafana
+dashboards: rqm_requirements by status and priority, rqm_cycles,
+rqm_valid, rqm_validation_errors and rqm_validation_warnings (absent when
+the validator isn't available), and rqm_file_parsed, each labeled with
+the file. With --project-dir, every requirements file under the
+directory, found as by rqm list -r, is reported, and files added or
+removed while the server runs are picked up at the next scrape; without
+a file argument, the directory's .rqm/requirements.yml is served.
+
+--base-path /rqm/ serves the UI and every endpoint under a prefix, for a
+reverse proxy that forwards a subpath with the prefix kept, such as
+nginx's location /rqm/ { proxy_pass http://127.0.0.1:3000; } with
+proxy_set_header Host $host, so edits pass the Origin check. The UI's
+index.html is pointed at the prefix, so its assets and API calls go
+through the proxy: /rqm/api/search rather than /api/search.
+
+--daemon turns the server into a small requirements service for a team:
+every --interval (5m by default) it pulls the git checkout of the file,
+or of --project-dir, with --ff-only, revalidates every requirements file
+it reports in /metrics, and posts to the notifications in
+.rqm/config.yml: requirements_changed for the requirements a pull (or an
+edit in place) changed, and gate_failed when a file stops passing rqm
+validate. POST /hooks/git makes it pull right away; point a GitHub or
+GitLab push webhook at it, with --host set so the server can be reached. Set RQM_WEBHOOK_SECRET to the webhook's secret
+so only signed deliveries (GitHub's X-Hub-Signature-256, GitLab's
+X-Gitlab-Token) are accepted. /metrics then also carries
+rqm_daemon_runs_total by result and
+rqm_daemon_last_run_timestamp_seconds.`,
 	Example: `  rqm serve
   rqm serve requirements.yml
   rqm serve --port 8080
-  rqm serve --open requirements.yml`,
+  rqm serve --open requirements.yml
+  rqm serve --notify requirements.yml
+  rqm serve --ref v1.0 requirements.yml
+  rqm serve --baseline design-review requirements.yml
+  rqm serve --base-path /rqm/ requirements.yml
+  rqm serve --project-dir .
+  RQM_WEBHOOK_SECRET=... rqm serve --daemon --interval 10m --project-dir . --host 0.0.0.0`,
 	RunE: runServe,
 }
 
 func init() {
 	rootCmd.AddCommand(serveCmd)
 	serveCmd.Flags().StringVarP(&servePort, "port", "p", "3000", "Port to run the server on")
+	serveCmd.Flags().StringVar(&serveHost, "host", "127.0.0.1", "Address to listen on (0.0.0.0 for every interface)")
 	serveCmd.Flags().BoolVarP(&serveOpen, "open", "o", false, "Open browser automatically")
+	serveCmd.Flags().BoolVar(&serveNotify, "notify", false, "Post changes to the requirements file to the configured notifications")
+	serveCmd.Flags().StringVar(&serveRef, "ref", "", "Serve the requirements file as it was at this git ref, read-only")
+	serveCmd.Flags().StringVar(&serveBasePath, "base-path", "/", "Path prefix to serve the UI and API under, for a reverse proxy at a subpath")
+	serveCmd.Flags().StringVar(&serveProjectDir, "project-dir", "", "Report every requirements file under this directory in /metrics, and serve its .rqm/requirements.yml by default")
+	serveCmd.Flags().BoolVar(&serveDaemonMode, "daemon", false, "Periodically pull the git checkout, revalidate and notify, and pull on POST /hooks/git")
+	serveCmd.Flags().DurationVar(&serveInterval, "interval", 5*time.Minute, "How often --daemon pulls and revalidates")
+	serveCmd.Flags().StringVar(&serveBaseline, "baseline", "", "Serve the requirements file at the git ref of this baseline in .rqm/config.yml, read-only")
 }
 
 func runServe(cmd *cobra.Command, args []string) error {
+	basePath, err := normalizeBasePath(serveBasePath)
+	if err != nil {
+		return err
+	}
+	// /metrics reports the project's files, or the one served
+	metricsFiles := func() (map[string]string, error) { return map[string]string{}, nil }
+	if serveProjectDir != "" {
+		if info, err := os.Stat(serveProjectDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("--project-dir %s is not a directory", serveProjectDir)
+		}
+		if len(args) == 0 {
+			if file := findRequirementsFile(serveProjectDir); file != "" {
+				args = []string{file}
+			}
+		}
+		metricsFiles = func() (map[string]string, error) { return projectMetricsFiles(serveProjectDir) }
+	}
+	var snapshot *serveSnapshot
+	if serveRef != "" || serveBaseline != "" {
+		switch {
+		case serveRef != "" && serveBaseline != "":
+			return fmt.Errorf("--ref and --baseline both choose the version to serve; give one")
+		case len(args) == 0:
+			return fmt.Errorf("--ref and --baseline need a requirements file")
+		case serveNotify:
+			return fmt.Errorf("--notify watches the file for changes, which a snapshot doesn't have")
+		case serveDaemonMode:
+			return fmt.Errorf("--daemon keeps the checkout current, which a snapshot doesn't follow")
+		}
+		if snapshot, err = loadServeSnapshot(args[0], serveRef, serveBaseline); err != nil {
+			return err
+		}
+		defer os.Remove(snapshot.path)
+	}
+
+	var daemon *serveDaemon
+	if serveDaemonMode {
+		if daemon, err = newDaemonFromFlags(args); err != nil {
+			return err
+		}
+	}
+
+	if serveNotify {
+		if len(args) == 0 {
+			return fmt.Errorf("--notify needs a requirements file")
+		}
+		project, err := loadProjectConfig(args[0])
+		if err != nil {
+			return err
+		}
+		if err := requireOnline("notifications", project); err != nil {
+			return err
+		}
+		if len(subscribedNotifications(project, "requirements_changed")) == 0 {
+			return fmt.Errorf("no notifications for requirements_changed configured in .rqm/config.yml")
+		}
+		go watchForNotifications(args[0], project)
+	}
+
 	// Get the embedded filesystem
 	webFS, err := fs.Sub(webUI, "web-dist")
 	if err != nil {
@@ -58,34 +239,150 @@ func runServe(cmd *cobra.Command, args []string) error {
 	}
 
 	// Serve static files
-	http.Handle("/", http.FileServer(http.FS(webFS)))
+	http.Handle("/", uiIndex(webFS, snapshot, basePath))
+	http.HandleFunc("/api/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		serveSnapshotInfo(w, snapshot)
+	})
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		serveMetrics(w, r, metricsFiles, daemon)
+	})
 
-	// If a requirements file was provided, serve it at /api/requirements
+	// If a requirements file was provided, serve it at /api/requirements.
+	// The UI reads YAML, so TOML and JSON files are converted.
 	if len(args) > 0 {
 		reqFile := args[0]
+		if snapshot != nil {
+			reqFile = snapshot.path
+		}
+		if serveProjectDir == "" {
+			metricsFiles = func() (map[string]string, error) { return map[string]string{reqFile: args[0]}, nil }
+		}
 		http.HandleFunc("/api/requirements", func(w http.ResponseWriter, r *http.Request) {
 			data, err := os.ReadFile(reqFile)
+			// The ETag is the hash of the file on disk, so a client can
+			// tell whether it changed since it was read
+			etag := `"` + contentSHA256(data) + `"`
+			if err == nil {
+				data, err = model.ToYAML(reqFile, data)
+			}
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 				return
 			}
 			w.Header().Set("Content-Type", "application/x-yaml")
+			w.Header().Set("ETag", etag)
 			w.Write(data)
 		})
-		fmt.Printf("📄 Serving requirements from: %s\n", reqFile)
+		layouts := newLayoutCache()
+		http.HandleFunc("/api/graph/layout", func(w http.ResponseWriter, r *http.Request) {
+			serveGraphLayout(w, r, layouts, reqFile)
+		})
+		http.HandleFunc("/api/graph", func(w http.ResponseWriter, r *http.Request) {
+			serveGraph(w, r, reqFile)
+		})
+		http.HandleFunc("/api/search", func(w http.ResponseWriter, r *http.Request) {
+			serveSearch(w, r, reqFile)
+		})
+		http.HandleFunc("/badge.svg", func(w http.ResponseWriter, r *http.Request) {
+			serveBadge(w, r, reqFile)
+		})
+		// Attachments are served from the working tree, next to the file
+		// given, even for a snapshot
+		http.HandleFunc("/api/attachment", func(w http.ResponseWriter, r *http.Request) {
+			serveAttachment(w, r, reqFile, args[0])
+		})
+		// Comments too are kept next to the file given; a snapshot shows
+		// today's, read-only
+		presence := newCommentPresence()
+		http.HandleFunc("/api/requirements/{id}/comments", func(w http.ResponseWriter, r *http.Request) {
+			serveComments(w, r, presence, reqFile, args[0])
+		})
+		if snapshot == nil {
+			http.HandleFunc("/api/diff", func(w http.ResponseWriter, r *http.Request) {
+				serveDiff(w, r, reqFile)
+			})
+			http.HandleFunc("/api/requirement", func(w http.ResponseWriter, r *http.Request) {
+				serveRequirementWrite(w, r, reqFile)
+			})
+			fmt.Printf("📄 Serving requirements from: %s\n", reqFile)
+		} else {
+			fmt.Printf("🕰  Serving a read-only snapshot: %s\n", snapshot.label())
+		}
 	}
 
-	addr := fmt.Sprintf(":%s", servePort)
+	if daemon != nil {
+		secret := os.Getenv(webhookSecretEnv)
+		if secret == "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s isn't set, so /hooks/git accepts unsigned deliveries\n", webhookSecretEnv)
+		}
+		http.HandleFunc("/hooks/git", func(w http.ResponseWriter, r *http.Request) {
+			serveGitHook(w, r, daemon, secret)
+		})
+		// The files are those of /metrics, settled by now
+		daemon.files = metricsFiles
+		fmt.Printf("🔁 Pulling %s every %s and on POST /hooks/git\n", daemon.dir, serveInterval)
+		go daemon.start(serveInterval)
+	}
+
+	addr := net.JoinHostPort(serveHost, servePort)
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(browseHost(serveHost), servePort), basePath)
 	fmt.Printf("🚀 RQM Web UI starting...\n")
-	fmt.Printf("📍 Server running at: http://localhost%s\n", addr)
+	fmt.Printf("📍 Server running at: %s\n", url)
 	fmt.Printf("Press Ctrl+C to stop\n\n")
 
 	// Open browser if requested
 	if serveOpen {
-		openBrowser(fmt.Sprintf("http://localhost%s", addr))
+		openBrowser(url)
+	}
+
+	var handler http.Handler = http.DefaultServeMux
+	if snapshot != nil {
+		handler = readOnlySnapshot(handler, snapshot)
 	}
+	handler = underBasePath(handler, basePath)
+	return http.ListenAndServe(addr, handler)
+}
 
-	return http.ListenAndServe(addr, nil)
+// browseHost is the host to open in a browser for a server listening on
+// host: localhost for the loopback and unspecified addresses
+func browseHost(host string) string {
+	if ip := net.ParseIP(host); host == "" || (ip != nil && (ip.IsLoopback() || ip.IsUnspecified())) {
+		return "localhost"
+	}
+	return host
+}
+
+// newDaemonFromFlags checks the --daemon flags and returns the daemon for
+// the checkout of --project-dir or of the file served
+func newDaemonFromFlags(args []string) (*serveDaemon, error) {
+	switch {
+	case serveNotify:
+		return nil, fmt.Errorf("--daemon already notifies of changes; drop --notify")
+	case serveInterval <= 0:
+		return nil, fmt.Errorf("--interval must be positive, got %s", serveInterval)
+	}
+	dir := serveProjectDir
+	var project *ProjectConfig
+	switch {
+	case len(args) > 0:
+		var err error
+		if project, err = loadProjectConfig(args[0]); err != nil {
+			return nil, err
+		}
+		if dir == "" {
+			dir = filepath.Dir(args[0])
+		}
+	case dir == "":
+		return nil, fmt.Errorf("--daemon needs a requirements file or --project-dir")
+	}
+	// Pulling reaches the remote
+	if err := requireOnline("serve --daemon", project); err != nil {
+		return nil, err
+	}
+	if _, err := gitOutput("-C", dir, "rev-parse", "--abbrev-ref", "@{upstream}"); err != nil {
+		return nil, fmt.Errorf("--daemon pulls the git checkout of %s, which has no upstream branch to pull from: %w", dir, err)
+	}
+	return newServeDaemon(dir, nil), nil
 }
 
 func openBrowser(url string) {
@@ -108,3 +405,20 @@ func openBrowser(url string) {
 		fmt.Printf("Please open your browser to: %s\n", url)
 	}
 }
+
+// serveBadge renders the badge named by the metric and label query
+// parameters, recomputed on every request so it is always current
+func serveBadge(w http.ResponseWriter, r *http.Request, reqFile string) {
+	metric := r.URL.Query().Get("metric")
+	if metric == "" {
+		metric = "implemented-percent"
+	}
+	svg, err := requirementsBadge(reqFile, metric, r.URL.Query().Get("label"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write([]byte(svg))
+}
@@ -0,0 +1,110 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// rootRelativeURL matches the src and href attributes of index.html that
+// start at the site root, and not another host's //
+var rootRelativeURL = regexp.MustCompile(`(\s(?:src|href)=["'])/([^/])`)
+
+// normalizeBasePath checks --base-path and gives it a slash at both ends:
+// rqm/ and /rqm are /rqm/
+func normalizeBasePath(base string) (string, error) {
+	if base == "" || base == "/" {
+		return "/", nil
+	}
+	if strings.ContainsAny(base, "?#") || strings.Contains(base, "//") {
+		return "", fmt.Errorf("invalid --base-path %q: give a path such as /rqm/", base)
+	}
+	clean := path.Clean("/" + base)
+	if clean != strings.TrimSuffix("/"+strings.TrimPrefix(base, "/"), "/") {
+		return "", fmt.Errorf("invalid --base-path %q: give a path such as /rqm/", base)
+	}
+	return clean + "/", nil
+}
+
+// underBasePath serves next at base instead of the root, for a reverse
+// proxy that forwards a subpath with the prefix kept: /rqm/api/search
+// reaches next as /api/search. The base path without its trailing slash
+// redirects to it, and anything outside it isn't found.
+func underBasePath(next http.Handler, base string) http.Handler {
+	if base == "/" {
+		return next
+	}
+	prefix := strings.TrimSuffix(base, "/")
+	stripped := http.StripPrefix(prefix, next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == prefix:
+			target := base
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+		case strings.HasPrefix(r.URL.Path, base):
+			stripped.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// withBasePath points index.html at base: a <base> element for the UI's
+// relative asset and API URLs, and base in place of / at the start of
+// root-relative src and href attributes
+func withBasePath(page []byte, base string) []byte {
+	if base == "/" {
+		return page
+	}
+	page = rootRelativeURL.ReplaceAll(page, []byte("${1}"+base+"${2}"))
+	return insertAfterTag(page, "<head", []byte(`<base href="`+html.EscapeString(base)+`">`))
+}
+
+// insertAfterTag inserts content right after the first tag of page
+// opening with tag, such as "<body", or at its start without one
+func insertAfterTag(page []byte, tag string, content []byte) []byte {
+	i := bytes.Index(page, []byte(tag))
+	if i < 0 {
+		return append(content, page...)
+	}
+	end := bytes.IndexByte(page[i:], '>')
+	if end < 0 {
+		return append(content, page...)
+	}
+	at := i + end + 1
+	return append(page[:at:at], append(content, page[at:]...)...)
+}
+
+// uiIndex serves the UI's index.html for base, with the historical banner
+// of snapshot when there is one, and everything else from files unchanged
+func uiIndex(files fs.FS, snapshot *serveSnapshot, base string) http.Handler {
+	fileServer := http.FileServer(http.FS(files))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" && r.URL.Path != "/index.html" {
+			fileServer.ServeHTTP(w, r)
+			return
+		}
+		page, err := fs.ReadFile(files, "index.html")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if snapshot != nil {
+			page = withSnapshotBanner(page, snapshot)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(withBasePath(page, base))
+	})
+}
@@ -0,0 +1,64 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNormalizeBasePath(t *testing.T) {
+	for base, want := range map[string]string{"": "/", "/": "/", "rqm": "/rqm/", "/rqm": "/rqm/", "/tools/rqm/": "/tools/rqm/"} {
+		if got, err := normalizeBasePath(base); err != nil || got != want {
+			t.Errorf("%q: got %q, %v; want %q", base, got, err, want)
+		}
+	}
+	for _, base := range []string{"/rqm/../admin", "//evil.example", "/rqm?x=1", "/./rqm"} {
+		if _, err := normalizeBasePath(base); err == nil {
+			t.Errorf("%q: expected an error", base)
+		}
+	}
+}
+
+func TestUnderBasePath(t *testing.T) {
+	files := fstest.MapFS{
+		"index.html":      {Data: []byte(`<html><head><link rel="icon" href="/vite.svg"><script src="/assets/index.js"></script><a href="//cdn.example/x">x</a></head><body></body></html>`)},
+		"assets/index.js": {Data: []byte("console.log(1)")},
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", uiIndex(files, nil, "/rqm/"))
+	mux.HandleFunc("/api/requirements/{id}/comments", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("comments on " + r.PathValue("id")))
+	})
+	handler := underBasePath(mux, "/rqm/")
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	page := get("/rqm/").Body.String()
+	for _, want := range []string{`<head><base href="/rqm/">`, `href="/rqm/vite.svg"`, `src="/rqm/assets/index.js"`, `href="//cdn.example/x"`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("index.html lacks %s:\n%s", want, page)
+		}
+	}
+	if rec := get("/rqm/assets/index.js"); rec.Code != 200 || rec.Body.String() != "console.log(1)" {
+		t.Errorf("asset: %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := get("/rqm/api/requirements/Audit%20log/comments"); rec.Body.String() != "comments on Audit log" {
+		t.Errorf("API: %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := get("/rqm?x=1"); rec.Code != http.StatusMovedPermanently || rec.Header().Get("Location") != "/rqm/?x=1" {
+		t.Errorf("prefix without slash: %d %v", rec.Code, rec.Header())
+	}
+	if rec := get("/api/requirements/Login/comments"); rec.Code != http.StatusNotFound {
+		t.Errorf("outside the prefix: %d", rec.Code)
+	}
+}
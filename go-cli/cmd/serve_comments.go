@@ -0,0 +1,217 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"go.yaml.in/yaml/v3"
+)
+
+// commentsFile is the sidecar of the .rqm directory comments made in rqm
+// serve are kept in
+const commentsFile = "comments.yml"
+
+// maxCommentLength is the longest comment accepted, in bytes
+const maxCommentLength = 10000
+
+// presenceTimeout is how long a viewer counts as present after the web UI
+// last asked for a requirement's comments on their behalf
+const presenceTimeout = 30 * time.Second
+
+// requirementComment is a comment on a requirement
+type requirementComment struct {
+	Author string    `json:"author" yaml:"author"`
+	Time   time.Time `json:"time" yaml:"time"`
+	Text   string    `json:"text" yaml:"text"`
+}
+
+// commentLog is .rqm/comments.yml: the comments on each requirement,
+// oldest first, by ID or, for requirements without one, summary
+type commentLog struct {
+	Comments map[string][]requirementComment `yaml:"comments"`
+}
+
+// CommentsResponse is the answer to /api/requirements/{id}/comments: the
+// comments on the requirement and who else has it open
+type CommentsResponse struct {
+	Requirement string               `json:"requirement"`
+	Comments    []requirementComment `json:"comments"`
+	Viewers     []string             `json:"viewers"`
+}
+
+// commentPresence tracks who has which requirement open in the web UI. It
+// is kept in memory only: presence doesn't outlive the server.
+type commentPresence struct {
+	mu   sync.Mutex
+	seen map[string]map[string]time.Time
+	now  func() time.Time
+}
+
+func newCommentPresence() *commentPresence {
+	return &commentPresence{seen: make(map[string]map[string]time.Time), now: time.Now}
+}
+
+// visit records viewer as having key open, and returns the others seen on
+// it within presenceTimeout, sorted
+func (p *commentPresence) visit(key, viewer string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	viewers := p.seen[key]
+	if viewers == nil {
+		viewers = make(map[string]time.Time)
+		p.seen[key] = viewers
+	}
+	if viewer != "" {
+		viewers[viewer] = now
+	}
+	others := []string{}
+	for name, last := range viewers {
+		switch {
+		case now.Sub(last) > presenceTimeout:
+			delete(viewers, name)
+		case name != viewer:
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	return others
+}
+
+// commentsPath is the comments sidecar of a requirements file
+func commentsPath(file string) string {
+	return filepath.Join(rqmStateDir(file), commentsFile)
+}
+
+// commentKey is what a requirement's comments are filed under: its ID,
+// which survives rewording, or its summary
+func commentKey(req *model.RequirementDetail) string {
+	if req.Name != "" {
+		return req.Name
+	}
+	return req.Summary
+}
+
+// loadComments reads the comments sidecar at path; a missing one is empty
+func loadComments(path string) (*commentLog, error) {
+	stored := &commentLog{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, stored); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if stored.Comments == nil {
+		stored.Comments = make(map[string][]requirementComment)
+	}
+	return stored, nil
+}
+
+// addComment appends comment to the requirement key in the sidecar at
+// path, holding its lock so concurrent servers and commands don't lose
+// each other's comments
+func addComment(path, key string, comment requirementComment) error {
+	// The lock is kept in the .rqm directory, which must exist to be found
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	unlock, err := lockRequirementsFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	stored, err := loadComments(path)
+	if err != nil {
+		return err
+	}
+	stored.Comments[key] = append(stored.Comments[key], comment)
+	data, err := yaml.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte("# Comments made in rqm serve, by requirement\n"), data...), 0644)
+}
+
+// serveComments answers /api/requirements/{id}/comments for the
+// requirement of reqFile id names. GET lists its comments, and with
+// ?viewer=<name> records the viewer as present and lists the others; POST
+// adds a comment, {"author": ..., "text": ...}. Comments are kept in
+// .rqm/comments.yml next to baseFile, the file given on the command line.
+func serveComments(w http.ResponseWriter, r *http.Request, presence *commentPresence, reqFile, baseFile string) {
+	config, err := model.Load(reqFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	req, ok := config.Find(r.PathValue("id"))
+	if !ok {
+		http.Error(w, "requirement not found: "+r.PathValue("id"), http.StatusNotFound)
+		return
+	}
+	key := commentKey(req)
+	path := commentsPath(baseFile)
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPost:
+		if status, err := writeRequestError(r); err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+		var comment requirementComment
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&comment); err != nil {
+			http.Error(w, fmt.Sprintf("invalid comment: %v", err), http.StatusBadRequest)
+			return
+		}
+		comment.Author, comment.Text = strings.TrimSpace(comment.Author), strings.TrimSpace(comment.Text)
+		switch {
+		case comment.Author == "":
+			http.Error(w, "author is required", http.StatusBadRequest)
+			return
+		case comment.Text == "":
+			http.Error(w, "text is required", http.StatusBadRequest)
+			return
+		case len(comment.Text) > maxCommentLength:
+			http.Error(w, fmt.Sprintf("comment is longer than %d bytes", maxCommentLength), http.StatusBadRequest)
+			return
+		}
+		comment.Time = presence.now().UTC().Truncate(time.Second)
+		if err := addComment(path, key, comment); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "use GET or POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	stored, err := loadComments(path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Method == http.MethodPost {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(CommentsResponse{
+		Requirement: key,
+		Comments:    nonNil(stored.Comments[key]),
+		Viewers:     presence.visit(key, strings.TrimSpace(r.URL.Query().Get("viewer"))),
+	})
+}
@@ -0,0 +1,110 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func commentsServer(t *testing.T) (*http.ServeMux, *commentPresence, string) {
+	t.Helper()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "requirements.yml")
+	os.WriteFile(file, []byte(`version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+  - summary: Audit log
+`), 0o644)
+	presence := newCommentPresence()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/requirements/{id}/comments", func(w http.ResponseWriter, r *http.Request) {
+		serveComments(w, r, presence, file, file)
+	})
+	return mux, presence, dir
+}
+
+func commentsRequest(t *testing.T, mux *http.ServeMux, method, target, body string) (int, CommentsResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if method == "POST" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	mux.ServeHTTP(rec, r)
+	var response CommentsResponse
+	if rec.Code < 300 {
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return rec.Code, response
+}
+
+func TestServeComments(t *testing.T) {
+	mux, presence, dir := commentsServer(t)
+	presence.now = func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	code, response := commentsRequest(t, mux, "POST", "/api/requirements/Login/comments", `{"author": " Alice ", "text": "Should this cover SSO?"}`)
+	if code != 201 || response.Requirement != "AUTH-001" || len(response.Comments) != 1 {
+		t.Fatalf("post: %d %+v", code, response)
+	}
+	code, response = commentsRequest(t, mux, "GET", "/api/requirements/AUTH-001/comments", "")
+	if code != 200 || len(response.Comments) != 1 || response.Comments[0].Author != "Alice" || response.Comments[0].Text != "Should this cover SSO?" {
+		t.Errorf("get: %d %+v", code, response)
+	}
+
+	// Comments are filed under the ID, in the .rqm directory beside the file
+	data, err := os.ReadFile(filepath.Join(dir, ".rqm", "comments.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "AUTH-001:\n        - author: Alice\n          time: 2025-03-01T12:00:00Z\n") {
+		t.Errorf("comments.yml:\n%s", data)
+	}
+
+	for _, tt := range []struct {
+		target, body string
+		code         int
+	}{
+		{"/api/requirements/Nope/comments", `{"author": "Alice", "text": "Hi"}`, 404},
+		{"/api/requirements/Login/comments", `{"author": "", "text": "Hi"}`, 400},
+		{"/api/requirements/Login/comments", `{"author": "Alice", "text": "  "}`, 400},
+		{"/api/requirements/Login/comments", `not json`, 400},
+	} {
+		if code, _ := commentsRequest(t, mux, "POST", tt.target, tt.body); code != tt.code {
+			t.Errorf("%s %s: %d, want %d", tt.target, tt.body, code, tt.code)
+		}
+	}
+}
+
+func TestCommentPresence(t *testing.T) {
+	mux, presence, _ := commentsServer(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	presence.now = func() time.Time { return now }
+
+	commentsRequest(t, mux, "GET", "/api/requirements/Audit%20log/comments?viewer=Bob", "")
+	commentsRequest(t, mux, "GET", "/api/requirements/Audit%20log/comments?viewer=Carol", "")
+	_, response := commentsRequest(t, mux, "GET", "/api/requirements/Audit%20log/comments?viewer=Alice", "")
+	if strings.Join(response.Viewers, ",") != "Bob,Carol" {
+		t.Errorf("viewers: %q", response.Viewers)
+	}
+
+	// Bob left; Carol is still polling
+	now = now.Add(20 * time.Second)
+	commentsRequest(t, mux, "GET", "/api/requirements/Audit%20log/comments?viewer=Carol", "")
+	now = now.Add(20 * time.Second)
+	_, response = commentsRequest(t, mux, "GET", "/api/requirements/Audit%20log/comments?viewer=Alice", "")
+	if strings.Join(response.Viewers, ",") != "Carol" {
+		t.Errorf("viewers after timeout: %q", response.Viewers)
+	}
+}
@@ -0,0 +1,250 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// webhookSecretEnv names the variable holding the secret /hooks/git
+// checks deliveries against
+const webhookSecretEnv = "RQM_WEBHOOK_SECRET"
+
+// maxWebhookBody is the largest push event /hooks/git reads, GitHub's
+// own limit
+const maxWebhookBody = 25 << 20
+
+// serveDaemon keeps a checkout current for serve --daemon: every interval,
+// or when /hooks/git is told of a push, it pulls, revalidates the
+// requirements files and notifies of what changed
+type serveDaemon struct {
+	// dir is the git working tree to pull
+	dir string
+	// files lists the requirements files to check, labeled as in /metrics
+	files   func() (map[string]string, error)
+	trigger chan string
+	now     func() time.Time
+
+	mu sync.Mutex
+	// checked is what each file was at the last run; a file's first run
+	// only records it
+	checked map[string]daemonFile
+	lastRun time.Time
+	// runs counts the runs by result, ok or error
+	runs map[string]int
+}
+
+// daemonFile is a requirements file as a daemon run found it
+type daemonFile struct {
+	// hash covers the file and those it includes
+	hash string
+	// config is nil when the file didn't load
+	config *model.RequirementConfig
+	// valid is nil when the file couldn't be validated
+	valid *bool
+}
+
+func newServeDaemon(dir string, files func() (map[string]string, error)) *serveDaemon {
+	return &serveDaemon{
+		dir:     dir,
+		files:   files,
+		trigger: make(chan string, 1),
+		now:     time.Now,
+		checked: make(map[string]daemonFile),
+		runs:    map[string]int{"ok": 0, "error": 0},
+	}
+}
+
+// start runs the daemon now and then every interval or on a trigger,
+// whichever comes first
+func (d *serveDaemon) start(interval time.Duration) {
+	d.run("startup")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			d.run("schedule")
+		case reason := <-d.trigger:
+			d.run(reason)
+		}
+	}
+}
+
+// queue asks for a run as soon as the current one is done. Triggers
+// arriving meanwhile are one run: it pulls whatever was pushed by then.
+func (d *serveDaemon) queue(reason string) {
+	select {
+	case d.trigger <- reason:
+	default:
+	}
+}
+
+// run pulls the working tree and checks every requirements file. A failed
+// pull is reported, and the files are still checked as they are.
+func (d *serveDaemon) run(reason string) {
+	before, _ := gitOutput("-C", d.dir, "rev-parse", "HEAD")
+	_, pullErr := gitOutput("-C", d.dir, "pull", "--ff-only", "--quiet")
+	after, _ := gitOutput("-C", d.dir, "rev-parse", "HEAD")
+	if pullErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", pullErr)
+	} else if before != after {
+		fmt.Printf("🔄 Pulled %.7s..%.7s (%s)\n", before, after, reason)
+	}
+
+	// Changes come since the commit pulled over, or since the last run
+	// for edits made in place
+	base := "the last check"
+	if before != "" && before != after {
+		base = fmt.Sprintf("commit %.7s", before)
+	}
+	labels, err := d.files()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	for _, file := range sortedKeys(labels) {
+		d.check(file, labels[file], base)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lastRun = d.now()
+	if pullErr != nil || err != nil {
+		d.runs["error"]++
+	} else {
+		d.runs["ok"]++
+	}
+}
+
+// check revalidates file and notifies of the requirements changed since
+// the last run and of the file turning invalid
+func (d *serveDaemon) check(file, label, base string) {
+	hash, err := requirementsTreeHash(file)
+	if err != nil {
+		return
+	}
+	current := daemonFile{hash: hash}
+	current.config, _ = model.Load(file)
+	result, err := validateFile(file)
+	if err == nil {
+		current.valid = &result.Valid
+	}
+	d.mu.Lock()
+	previous, seen := d.checked[file]
+	d.checked[file] = current
+	d.mu.Unlock()
+	if !seen {
+		return
+	}
+
+	var notifications []notification
+	if previous.hash != hash && previous.config != nil && current.config != nil {
+		if changes := diffRequirements(previous.config, current.config); len(changes.Added)+len(changes.Changed)+len(changes.Removed) > 0 {
+			notifications = append(notifications, newChangeNotification(label, base, current.config, changes))
+		}
+	}
+	if previous.valid != nil && *previous.valid && current.valid != nil && !*current.valid {
+		notifications = append(notifications, GateNotification{
+			Event:   "gate_failed",
+			Command: "serve --daemon",
+			File:    label,
+			Violations: []GateViolation{{
+				Check:        "validate",
+				Result:       "fail",
+				Details:      fmt.Sprintf("%d error(s), %d warning(s)", len(result.Errors), len(result.Warnings)),
+				Requirements: validationRequirements(file, result),
+			}},
+		})
+	}
+	if len(notifications) == 0 {
+		return
+	}
+
+	project, err := loadProjectConfig(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not sending notifications: %v\n", err)
+		return
+	}
+	for _, n := range notifications {
+		// Notifications are optional for the daemon, so a file whose
+		// project has none for the event is skipped quietly
+		if len(subscribedNotifications(project, n.event())) == 0 {
+			continue
+		}
+		for _, err := range sendNotifications(project, n) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
+
+// writeMetrics adds the daemon's own gauges to /metrics
+func (d *serveDaemon) writeMetrics(w io.Writer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fmt.Fprintf(w, "# HELP rqm_daemon_runs_total Runs of serve --daemon by result: error when the pull or listing the files failed.\n# TYPE rqm_daemon_runs_total counter\n")
+	for _, result := range sortedKeys(d.runs) {
+		fmt.Fprintf(w, "rqm_daemon_runs_total{result=\"%s\"} %d\n", result, d.runs[result])
+	}
+	if d.lastRun.IsZero() {
+		return
+	}
+	fmt.Fprintf(w, "# HELP rqm_daemon_last_run_timestamp_seconds When serve --daemon last pulled and revalidated.\n# TYPE rqm_daemon_last_run_timestamp_seconds gauge\n")
+	fmt.Fprintf(w, "rqm_daemon_last_run_timestamp_seconds %d\n", d.lastRun.Unix())
+}
+
+// serveGitHook answers /hooks/git, where a git host's push webhook asks
+// the daemon to pull now. With secret set, a delivery must be signed
+// with it as GitHub does or carry it as GitLab's token.
+func serveGitHook(w http.ResponseWriter, r *http.Request, d *serveDaemon, secret string) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if secret != "" && !webhookAuthorized(r.Header, body, secret) {
+		http.Error(w, "invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+	// GitHub pings a new webhook to check it is reachable
+	if r.Header.Get("X-GitHub-Event") == "ping" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	d.queue("webhook")
+	w.WriteHeader(http.StatusAccepted)
+	fmt.Fprintln(w, "Pull and revalidation queued")
+}
+
+// webhookAuthorized checks a delivery against secret: GitHub's
+// X-Hub-Signature-256 HMAC of the body, or GitLab's X-Gitlab-Token
+func webhookAuthorized(header http.Header, body []byte, secret string) bool {
+	if signature, ok := strings.CutPrefix(header.Get("X-Hub-Signature-256"), "sha256="); ok {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		want := hex.EncodeToString(mac.Sum(nil))
+		return hmac.Equal([]byte(signature), []byte(want))
+	}
+	if token := header.Get("X-Gitlab-Token"); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+	}
+	return false
+}
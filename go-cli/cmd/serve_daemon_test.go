@@ -0,0 +1,166 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestServeDaemonRun(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	fakeValidator(t, true)
+	t.Setenv("RQM_OFFLINE", "")
+
+	var mu sync.Mutex
+	var changes []ChangeNotification
+	var gates []GateNotification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event struct{ Event string }
+		body := new(bytes.Buffer)
+		body.ReadFrom(r.Body)
+		json.Unmarshal(body.Bytes(), &event)
+		mu.Lock()
+		defer mu.Unlock()
+		switch event.Event {
+		case "requirements_changed":
+			var n ChangeNotification
+			json.Unmarshal(body.Bytes(), &n)
+			changes = append(changes, n)
+		case "gate_failed":
+			var n GateNotification
+			json.Unmarshal(body.Bytes(), &n)
+			gates = append(gates, n)
+		}
+	}))
+	defer server.Close()
+
+	// upstream pushes to origin, which the daemon's checkout pulls from
+	root := t.TempDir()
+	origin, upstream, checkout := filepath.Join(root, "origin.git"), filepath.Join(root, "upstream"), filepath.Join(root, "checkout")
+	git := func(args ...string) {
+		t.Helper()
+		if _, err := gitOutput(args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q", "--bare", origin)
+	git("clone", "-q", origin, upstream)
+	git("-C", upstream, "config", "user.email", "test@example.com")
+	git("-C", upstream, "config", "user.name", "Test")
+	os.Mkdir(filepath.Join(upstream, ".rqm"), 0755)
+	os.WriteFile(filepath.Join(upstream, ".rqm", "config.yml"), []byte("notifications:\n  - type: webhook\n    url: "+server.URL+"\n"), 0644)
+	os.WriteFile(filepath.Join(upstream, ".gitignore"), []byte(".rqm/cache/\n"), 0644)
+	os.WriteFile(filepath.Join(upstream, "requirements.yml"), []byte("version: \"1.0\"\nrequirements:\n  - summary: Login\n"), 0644)
+	git("-C", upstream, "add", ".")
+	git("-C", upstream, "commit", "-q", "-m", "add requirements")
+	git("-C", upstream, "push", "-q", "origin", "HEAD")
+	git("clone", "-q", origin, checkout)
+
+	d := newServeDaemon(checkout, func() (map[string]string, error) { return projectMetricsFiles(checkout) })
+	d.now = func() time.Time { return time.Unix(1760000000, 0) }
+	d.run("startup")
+	if len(changes)+len(gates) != 0 {
+		t.Fatalf("Expected the first run to only record the files, got %v and %v", changes, gates)
+	}
+
+	os.WriteFile(filepath.Join(upstream, "requirements.yml"), []byte("version: \"1.0\"\nrequirements:\n  - summary: Login\n  - summary: Logout\n"), 0644)
+	git("-C", upstream, "commit", "-q", "-am", "add logout")
+	git("-C", upstream, "push", "-q", "origin", "HEAD")
+	fakeValidator(t, false)
+	d.run("webhook")
+
+	if data, _ := os.ReadFile(filepath.Join(checkout, "requirements.yml")); !strings.Contains(string(data), "Logout") {
+		t.Fatalf("Expected the checkout to be pulled, got:\n%s", data)
+	}
+	if len(changes) != 1 || changes[0].File != "requirements.yml" || !strings.HasPrefix(changes[0].Base, "commit ") || len(changes[0].Added) != 1 || changes[0].Added[0].Summary != "Logout" {
+		t.Errorf("Expected Logout to be reported as added by the pull, got %+v", changes)
+	}
+	if len(gates) != 1 || gates[0].Command != "serve --daemon" || gates[0].Violations[0].Check != "validate" || gates[0].Violations[0].Result != "fail" {
+		t.Errorf("Expected the file turning invalid to fail the gate, got %+v", gates)
+	}
+
+	// Still invalid and unchanged: nothing more to say
+	d.run("schedule")
+	if len(changes) != 1 || len(gates) != 1 {
+		t.Errorf("Expected no notifications for an unchanged file, got %d and %d", len(changes), len(gates))
+	}
+
+	var metrics bytes.Buffer
+	d.writeMetrics(&metrics)
+	for _, want := range []string{
+		`rqm_daemon_runs_total{result="error"} 0` + "\n",
+		`rqm_daemon_runs_total{result="ok"} 3` + "\n",
+		"rqm_daemon_last_run_timestamp_seconds 1760000000\n",
+	} {
+		if !strings.Contains(metrics.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, metrics.String())
+		}
+	}
+}
+
+func TestServeGitHook(t *testing.T) {
+	body := `{"ref": "refs/heads/main"}`
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name   string
+		method string
+		header map[string]string
+		secret string
+		status int
+		queued bool
+	}{
+		{"unsigned without a secret", "POST", nil, "", http.StatusAccepted, true},
+		{"GitHub signature", "POST", map[string]string{"X-Hub-Signature-256": signature}, "s3cret", http.StatusAccepted, true},
+		{"GitLab token", "POST", map[string]string{"X-Gitlab-Token": "s3cret"}, "s3cret", http.StatusAccepted, true},
+		{"wrong signature", "POST", map[string]string{"X-Hub-Signature-256": "sha256=00"}, "s3cret", http.StatusUnauthorized, false},
+		{"wrong token", "POST", map[string]string{"X-Gitlab-Token": "guess"}, "s3cret", http.StatusUnauthorized, false},
+		{"unsigned with a secret", "POST", nil, "s3cret", http.StatusUnauthorized, false},
+		{"ping", "POST", map[string]string{"X-GitHub-Event": "ping"}, "", http.StatusOK, false},
+		{"GET", "GET", nil, "", http.StatusMethodNotAllowed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newServeDaemon(t.TempDir(), nil)
+			r := httptest.NewRequest(tt.method, "/hooks/git", strings.NewReader(body))
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+			serveGitHook(rec, r, d, tt.secret)
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if queued := len(d.trigger) == 1; queued != tt.queued {
+				t.Errorf("Expected queued %v, got %v", tt.queued, queued)
+			}
+		})
+	}
+
+	// Deliveries while a run is pending are one run
+	d := newServeDaemon(t.TempDir(), nil)
+	d.queue("webhook")
+	d.queue("webhook")
+	if len(d.trigger) != 1 {
+		t.Errorf("Expected one queued run, got %d", len(d.trigger))
+	}
+}
@@ -0,0 +1,279 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"go.yaml.in/yaml/v3"
+)
+
+// RequirementForm is the body of POST /api/requirement: the fields of the
+// web UI's requirement form. Original names the requirement to edit by
+// summary or ID; without it a requirement is created, under Parent when
+// that is set.
+type RequirementForm struct {
+	Original       string   `json:"original,omitempty"`
+	Parent         string   `json:"parent,omitempty"`
+	Summary        string   `json:"summary"`
+	Name           string   `json:"name,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	Justification  string   `json:"justification,omitempty"`
+	AcceptanceTest string   `json:"acceptance_test,omitempty"`
+	Status         string   `json:"status,omitempty"`
+	Priority       string   `json:"priority,omitempty"`
+	Owner          string   `json:"owner,omitempty"`
+	Verification   string   `json:"verification,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	// Preview answers with the diff and validation result without saving
+	Preview bool `json:"preview,omitempty"`
+}
+
+// RequirementWriteResponse is the answer to POST /api/requirement: the
+// change to the file, line by line and requirement by requirement, and
+// the validation result of the file with the change. Saved is set when
+// the change was written, and ETag is then that of the new file.
+type RequirementWriteResponse struct {
+	Saved    bool         `json:"saved"`
+	Diff     []wordDiffOp `json:"diff"`
+	Changes  DiffResponse `json:"changes"`
+	Valid    bool         `json:"valid"`
+	Errors   []string     `json:"errors"`
+	Warnings []string     `json:"warnings"`
+	ETag     string       `json:"etag"`
+}
+
+// scalarFields are the scalar fields the form edits, with their values
+func (f *RequirementForm) scalarFields() [][2]string {
+	return [][2]string{
+		{"summary", f.Summary},
+		{"name", f.Name},
+		{"description", f.Description},
+		{"justification", f.Justification},
+		{"acceptance_test", f.AcceptanceTest},
+		{"status", f.Status},
+		{"priority", f.Priority},
+		{"owner", f.Owner},
+		{"verification", f.Verification},
+	}
+}
+
+// apply makes the change the form describes to doc. An edit only touches
+// the fields the form has: an empty one is removed, and relations,
+// sub-requirements and the other fields are left as they are.
+func (f *RequirementForm) apply(doc *yamlDocument) error {
+	if f.Original == "" {
+		return appendRequirement(doc, f.Parent, &model.RequirementDetail{
+			Summary:        f.Summary,
+			Name:           f.Name,
+			Description:    f.Description,
+			Justification:  f.Justification,
+			AcceptanceTest: f.AcceptanceTest,
+			Status:         f.Status,
+			Priority:       f.Priority,
+			Owner:          f.Owner,
+			Verification:   f.Verification,
+			Tags:           f.Tags,
+		})
+	}
+
+	config, err := model.Parse(doc.file, doc.data)
+	if err != nil {
+		return err
+	}
+	var node *yaml.Node
+	if req, ok := config.Find(f.Original); ok {
+		node = doc.requirementNodes()[req.Summary]
+	}
+	if node == nil {
+		return fmt.Errorf("requirement not found: %s", f.Original)
+	}
+	for _, field := range f.scalarFields() {
+		switch {
+		case field[1] == "":
+			deleteMappingKey(node, field[0])
+		case mappingValue(node, field[0]) == nil:
+			addRequirementField(node, field[0], &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"})
+			fallthrough
+		default:
+			setMappingScalar(node, field[0], field[1])
+		}
+	}
+	if len(f.Tags) == 0 {
+		deleteMappingKey(node, "tags")
+		return nil
+	}
+	tags := mappingValue(node, "tags")
+	if tags == nil {
+		tags = &yaml.Node{}
+		addRequirementField(node, "tags", tags)
+	}
+	tags.Kind, tags.Tag, tags.Content = yaml.SequenceNode, "!!seq", nil
+	for _, tag := range f.Tags {
+		tags.Content = append(tags.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: tag})
+	}
+	return nil
+}
+
+// addRequirementField adds key to a requirement mapping where the field
+// goes in the model's order, after the last field present before it
+func addRequirementField(node *yaml.Node, key string, value *yaml.Node) {
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	after := ""
+	for _, field := range requirementFieldOrder {
+		if field == key {
+			break
+		}
+		if mappingValue(node, field) != nil {
+			after = field
+		}
+	}
+	if after == "" {
+		node.Content = append([]*yaml.Node{keyNode, value}, node.Content...)
+		return
+	}
+	insertAfterKey(node, after, keyNode, value)
+}
+
+// validateContent validates data as the new content of file. It is
+// written next to file, so .rqm/config.yml and attachment paths resolve
+// as they will once it is saved.
+func validateContent(file string, data []byte) (*ValidationResult, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(file), "."+strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))+".*"+filepath.Ext(file))
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return validateFile(tmp.Name())
+}
+
+// writeRequestError refuses a write another web site could make the
+// browser of someone running rqm serve send: browsers send form and
+// text/plain POSTs across origins without asking, but not JSON ones, and
+// they name the page's origin in Origin. The status goes with the error.
+func writeRequestError(r *http.Request) (int, error) {
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		return http.StatusUnsupportedMediaType, fmt.Errorf("Content-Type must be application/json")
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// Not a browser, or a same-origin request of an old one
+		return 0, nil
+	}
+	// Behind a reverse proxy that doesn't keep Host, the browser's host is
+	// in X-Forwarded-Host, which a page can't set without a CORS preflight
+	// the server never allows
+	u, err := url.Parse(origin)
+	forwarded := r.Header.Get("X-Forwarded-Host")
+	if err != nil || u.Host == "" || (u.Host != r.Host && u.Host != forwarded) {
+		return http.StatusForbidden, fmt.Errorf("cross-origin request from %s refused", origin)
+	}
+	return 0, nil
+}
+
+// serveRequirementWrite answers POST /api/requirement, creating or
+// editing a requirement in reqFile from a RequirementForm. The change is
+// validated before it is saved, and a file that fails validation with it
+// is left alone. An If-Match header with the ETag of /api/requirements
+// refuses the change when the file changed since the form was filled in.
+func serveRequirementWrite(w http.ResponseWriter, r *http.Request, reqFile string) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if status, err := writeRequestError(r); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	var form RequirementForm
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&form); err != nil {
+		http.Error(w, fmt.Sprintf("invalid requirement form: %v", err), http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(form.Summary) == "" {
+		http.Error(w, "summary is required", http.StatusBadRequest)
+		return
+	}
+	if err := editableFile(reqFile); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := os.ReadFile(reqFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	etag := `"` + contentSHA256(data) + `"`
+	if match := r.Header.Get("If-Match"); match != "" && match != "*" && match != etag {
+		http.Error(w, reqFile+" "+errFileChanged.Error()+"; reload it and make the change again", http.StatusPreconditionFailed)
+		return
+	}
+
+	doc, err := parseYAMLDocument(reqFile, data)
+	if err == nil {
+		err = form.apply(doc)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	out, err := doc.encode()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := RequirementWriteResponse{Diff: lineDiff(string(data), string(out)), ETag: etag}
+	before, errBefore := model.Parse(reqFile, data)
+	after, errAfter := model.Parse(reqFile, out)
+	if errBefore == nil && errAfter == nil {
+		response.Changes = newDiffResponse(reqFile, "disk", diffRequirements(before, after))
+	}
+	result, err := validateContent(reqFile, out)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response.Valid, response.Errors, response.Warnings = result.Valid, nonNil(result.Errors), nonNil(result.Warnings)
+
+	status := http.StatusOK
+	switch {
+	case !result.Valid:
+		status = http.StatusUnprocessableEntity
+	case !form.Preview:
+		if err := writeRequirementsFile(reqFile, data, out); err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, errFileChanged) {
+				code = http.StatusPreconditionFailed
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+		response.Saved = true
+		response.ETag = `"` + contentSHA256(out) + `"`
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,199 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const editFormYAML = `version: "1.0"
+requirements:
+  # Authentication
+  - summary: Login
+    name: AUTH-001
+    status: draft
+    priority: high
+    tags: [auth]
+    requirements:
+      - Logout
+
+  - summary: Logout
+    name: AUTH-002
+`
+
+// fakeValidator points RQM_VALIDATOR at a script answering with valid
+func fakeValidator(t *testing.T, valid bool) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake validator is a shell script")
+	}
+	if validatorKind() == "embedded" {
+		t.Skip("embedded validator doesn't run rqm-validator")
+	}
+	result := `{"valid":true,"errors":[],"warnings":[]}`
+	if !valid {
+		result = `{"valid":false,"errors":["Duplicate summary: Login"],"warnings":[]}`
+	}
+	validator := filepath.Join(t.TempDir(), "rqm-validator")
+	if err := os.WriteFile(validator, []byte("#!/bin/sh\necho '"+result+"'\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("RQM_VALIDATOR", validator)
+}
+
+func postRequirementForm(t *testing.T, file string, form RequirementForm, ifMatch string) (*httptest.ResponseRecorder, RequirementWriteResponse) {
+	t.Helper()
+	body, _ := json.Marshal(form)
+	r := httptest.NewRequest(http.MethodPost, "/api/requirement", strings.NewReader(string(body)))
+	r.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		r.Header.Set("If-Match", ifMatch)
+	}
+	rec := httptest.NewRecorder()
+	serveRequirementWrite(rec, r, file)
+	var response RequirementWriteResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	return rec, response
+}
+
+func TestServeRequirementWrite(t *testing.T) {
+	fakeValidator(t, true)
+	dir := t.TempDir()
+	os.Mkdir(filepath.Join(dir, ".rqm"), 0o755)
+	file := filepath.Join(dir, "requirements.yml")
+	os.WriteFile(file, []byte(editFormYAML), 0o644)
+	etag := `"` + contentSHA256([]byte(editFormYAML)) + `"`
+
+	edit := RequirementForm{
+		Original:    "AUTH-001",
+		Summary:     "Login",
+		Name:        "AUTH-001",
+		Description: "Users sign in\nwith a password.",
+		Status:      "proposed",
+		Tags:        []string{"auth", "security"},
+		Preview:     true,
+	}
+	rec, response := postRequirementForm(t, file, edit, etag)
+	if rec.Code != http.StatusOK || response.Saved || !response.Valid {
+		t.Fatalf("preview: %d %s", rec.Code, rec.Body.String())
+	}
+	if data, _ := os.ReadFile(file); string(data) != editFormYAML {
+		t.Errorf("preview changed the file:\n%s", data)
+	}
+	if len(response.Changes.Changed) != 1 || strings.Join(response.Changes.Changed[0].Fields, ",") != "description,priority,status,tags" {
+		t.Errorf("changes = %+v", response.Changes)
+	}
+	if formatted := formatWordDiff(response.Diff); !strings.Contains(formatted, "[-    status: draft\n    priority: high\n    tags: [auth]\n-]") {
+		t.Errorf("diff:\n%s", formatted)
+	}
+
+	edit.Preview = false
+	rec, response = postRequirementForm(t, file, edit, etag)
+	if rec.Code != http.StatusOK || !response.Saved || response.ETag == etag {
+		t.Fatalf("save: %d %s", rec.Code, rec.Body.String())
+	}
+	data, _ := os.ReadFile(file)
+	want := `version: "1.0"
+requirements:
+  # Authentication
+  - summary: Login
+    name: AUTH-001
+    description: |-
+      Users sign in
+      with a password.
+    status: proposed
+    tags: [auth, security]
+    requirements:
+      - Logout
+
+  - summary: Logout
+    name: AUTH-002
+`
+	if string(data) != want {
+		t.Errorf("saved:\n%s\nwant:\n%s", data, want)
+	}
+	if entries, _ := readHistory(historyDir(file)); len(entries) != 1 {
+		t.Errorf("%d history entries, want 1 for rqm undo", len(entries))
+	}
+
+	// The form was filled in against the old content
+	rec, _ = postRequirementForm(t, file, edit, etag)
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("stale If-Match: %d %s", rec.Code, rec.Body.String())
+	}
+
+	create := RequirementForm{Parent: "AUTH-001", Summary: "Remember me", Status: "draft"}
+	rec, _ = postRequirementForm(t, file, create, response.ETag)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("create: %d %s", rec.Code, rec.Body.String())
+	}
+	if data, _ := os.ReadFile(file); !strings.Contains(string(data), "      - Logout\n      - summary: Remember me\n        status: draft\n") {
+		t.Errorf("created:\n%s", data)
+	}
+
+	rec, _ = postRequirementForm(t, file, RequirementForm{Original: "AUTH-999", Summary: "Nope"}, "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown requirement: %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestServeRequirementWriteInvalid(t *testing.T) {
+	fakeValidator(t, false)
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(editFormYAML), 0o644)
+
+	rec, response := postRequirementForm(t, file, RequirementForm{Summary: "Login"}, "")
+	if rec.Code != http.StatusUnprocessableEntity || response.Saved || len(response.Errors) != 1 {
+		t.Fatalf("invalid change: %d %s", rec.Code, rec.Body.String())
+	}
+	if data, _ := os.ReadFile(file); string(data) != editFormYAML {
+		t.Errorf("invalid change was saved:\n%s", data)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(file)); len(entries) != 1 {
+		t.Errorf("validation left files behind: %v", entries)
+	}
+}
+
+func TestWriteRequestError(t *testing.T) {
+	for _, tt := range []struct {
+		contentType, origin, forwardedHost string
+		status                             int
+	}{
+		{"application/json", "", "", 0},
+		{"application/json; charset=utf-8", "http://localhost:3000", "", 0},
+		{"application/json", "https://rqm.example.com", "rqm.example.com", 0},
+		{"text/plain", "https://evil.example", "", http.StatusUnsupportedMediaType},
+		{"", "", "", http.StatusUnsupportedMediaType},
+		{"application/json", "https://evil.example", "", http.StatusForbidden},
+		{"application/json", "null", "", http.StatusForbidden},
+	} {
+		r := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/requirement", nil)
+		r.Header.Set("Content-Type", tt.contentType)
+		r.Header.Set("Origin", tt.origin)
+		r.Header.Set("X-Forwarded-Host", tt.forwardedHost)
+		if status, _ := writeRequestError(r); status != tt.status {
+			t.Errorf("%q from %q: status %d, want %d", tt.contentType, tt.origin, status, tt.status)
+		}
+	}
+
+	// A cross-origin text/plain POST leaves the file alone
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte("version: \"1.0\"\nrequirements:\n  - summary: Login\n"), 0o644)
+	r := httptest.NewRequest(http.MethodPost, "/api/requirement", strings.NewReader(`{"summary": "Injected"}`))
+	r.Header.Set("Content-Type", "text/plain")
+	r.Header.Set("Origin", "https://evil.example")
+	rec := httptest.NewRecorder()
+	serveRequirementWrite(rec, r, file)
+	if data, _ := os.ReadFile(file); rec.Code != http.StatusUnsupportedMediaType || strings.Contains(string(data), "Injected") {
+		t.Errorf("cross-origin write: %d\n%s", rec.Code, data)
+	}
+}
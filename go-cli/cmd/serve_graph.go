@@ -0,0 +1,177 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/rqm/graph"
+)
+
+// graphFormats are the values of the format query parameter of /api/graph
+var graphFormats = map[string]bool{"cytoscape": true, "d3": true}
+
+// GraphNode is a requirement in /api/graph. Cycle is the index in Cycles
+// of the cycle the requirement is part of.
+type GraphNode struct {
+	ID         string `json:"id"`
+	Name       string `json:"name,omitempty"`
+	Summary    string `json:"summary"`
+	Status     string `json:"status,omitempty"`
+	Priority   string `json:"priority,omitempty"`
+	Deprecated bool   `json:"deprecated,omitempty"`
+	Cycle      *int   `json:"cycle,omitempty"`
+}
+
+// GraphLink is a connection in /api/graph: a sub-requirement (child) or a
+// typed relation. InCycle marks the links that close a circular
+// reference.
+type GraphLink struct {
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	Kind    string `json:"kind"`
+	InCycle bool   `json:"in_cycle"`
+}
+
+// D3Graph is /api/graph?format=d3, the shape d3-force takes
+type D3Graph struct {
+	Nodes  []GraphNode `json:"nodes"`
+	Links  []GraphLink `json:"links"`
+	Cycles [][]string  `json:"cycles"`
+}
+
+// CytoscapeGraph is /api/graph?format=cytoscape, elements for
+// cytoscape.js with in-cycle and deprecated classes to style by
+type CytoscapeGraph struct {
+	Elements struct {
+		Nodes []CytoscapeElement `json:"nodes"`
+		Edges []CytoscapeElement `json:"edges"`
+	} `json:"elements"`
+	Cycles [][]string `json:"cycles"`
+}
+
+// CytoscapeElement is a node or edge of a CytoscapeGraph
+type CytoscapeElement struct {
+	Data    interface{} `json:"data"`
+	Classes string      `json:"classes,omitempty"`
+}
+
+// cytoscapeEdgeData is the data of a cytoscape edge, which needs an ID
+type cytoscapeEdgeData struct {
+	ID string `json:"id"`
+	GraphLink
+}
+
+// dependencyGraph is the requirement graph of config with each
+// requirement's cycle, if any, and the links that are part of one
+func dependencyGraph(config *model.RequirementConfig) D3Graph {
+	g := requirementGraph(config)
+	ordering, _ := config.OrderingGraph()
+	cycles := ordering.Cycles()
+	cycleOf := make(map[string]int)
+	for i, cycle := range cycles {
+		for _, id := range cycle {
+			cycleOf[id] = i
+		}
+	}
+
+	details := make(map[string]*model.RequirementDetail)
+	for _, req := range config.Flatten() {
+		if _, ok := details[req.Summary]; !ok {
+			details[req.Summary] = req
+		}
+	}
+	result := D3Graph{Nodes: []GraphNode{}, Links: []GraphLink{}, Cycles: nonNil(cycles)}
+	for _, node := range g.Nodes() {
+		req := details[node.ID]
+		n := GraphNode{ID: node.ID, Name: req.Name, Summary: req.Summary, Status: req.Status, Priority: req.Priority, Deprecated: req.IsDeprecated()}
+		if i, ok := cycleOf[node.ID]; ok {
+			n.Cycle = &i
+		}
+		result.Nodes = append(result.Nodes, n)
+	}
+	for _, edge := range g.Edges() {
+		from, inFrom := cycleOf[edge.From]
+		to, inTo := cycleOf[edge.To]
+		counts := edge.Kind == graph.KindChild || edge.Kind == graph.KindDependsOn || edge.Kind == graph.KindBlocks
+		result.Links = append(result.Links, GraphLink{
+			Source:  edge.From,
+			Target:  edge.To,
+			Kind:    string(edge.Kind),
+			InCycle: counts && inFrom && inTo && from == to,
+		})
+	}
+	return result
+}
+
+// cytoscape converts the graph to cytoscape.js elements
+func (d D3Graph) cytoscape() CytoscapeGraph {
+	var c CytoscapeGraph
+	c.Cycles = d.Cycles
+	c.Elements.Nodes = []CytoscapeElement{}
+	c.Elements.Edges = []CytoscapeElement{}
+	for _, node := range d.Nodes {
+		var classes []string
+		if node.Cycle != nil {
+			classes = append(classes, "in-cycle")
+		}
+		if node.Deprecated {
+			classes = append(classes, "deprecated")
+		}
+		c.Elements.Nodes = append(c.Elements.Nodes, CytoscapeElement{Data: node, Classes: strings.Join(classes, " ")})
+	}
+	for i, link := range d.Links {
+		classes := link.Kind
+		if link.InCycle {
+			classes += " in-cycle"
+		}
+		c.Elements.Edges = append(c.Elements.Edges, CytoscapeElement{
+			Data:    cytoscapeEdgeData{ID: fmt.Sprintf("e%d", i), GraphLink: link},
+			Classes: classes,
+		})
+	}
+	return c
+}
+
+// serveGraph answers /api/graph?format=cytoscape|d3 with the requirement
+// graph of reqFile, cycles marked
+func serveGraph(w http.ResponseWriter, r *http.Request, reqFile string) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "d3"
+	}
+	if !graphFormats[format] {
+		http.Error(w, fmt.Sprintf("unknown graph format: %s (expected cytoscape or d3)", format), http.StatusBadRequest)
+		return
+	}
+	hash, err := requirementsTreeHash(reqFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	etag := `"` + format + "-" + hash + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	config, err := model.Load(reqFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	g := dependencyGraph(config)
+	w.Header().Set("Content-Type", "application/json")
+	if format == "cytoscape" {
+		json.NewEncoder(w).Encode(g.cytoscape())
+		return
+	}
+	json.NewEncoder(w).Encode(g)
+}
@@ -0,0 +1,123 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const cycleGraphYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: approved
+    priority: high
+    requirements:
+      - Sessions
+    relations:
+      - type: blocks
+        target: AUTH-002
+  - summary: Sessions
+    name: AUTH-002
+    relations:
+      - type: relates_to
+        target: Audit log
+  - summary: Audit log
+    relations:
+      - type: relates_to
+        target: AUTH-002
+`
+
+func TestDependencyGraph(t *testing.T) {
+	config, err := model.ParseYAML([]byte(cycleGraphYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := dependencyGraph(config)
+
+	// Login has Sessions as a sub-requirement, which waits on Login since
+	// Login blocks it
+	if len(g.Cycles) != 1 || len(g.Cycles[0]) != 2 {
+		t.Fatalf("cycles = %v", g.Cycles)
+	}
+	for _, node := range g.Nodes {
+		inCycle := node.ID != "Audit log"
+		if (node.Cycle != nil) != inCycle {
+			t.Errorf("%s: cycle = %v", node.ID, node.Cycle)
+		}
+	}
+	if n := g.Nodes[0]; n.Name != "AUTH-001" || n.Status != "approved" || n.Priority != "high" {
+		t.Errorf("node = %+v", n)
+	}
+
+	want := []GraphLink{
+		{Source: "Login", Target: "Sessions", Kind: "child", InCycle: true},
+		{Source: "Login", Target: "Sessions", Kind: "blocks", InCycle: true},
+		{Source: "Sessions", Target: "Audit log", Kind: "relates_to"},
+		{Source: "Audit log", Target: "Sessions", Kind: "relates_to"},
+	}
+	if len(g.Links) != len(want) {
+		t.Fatalf("links = %+v", g.Links)
+	}
+	for i := range want {
+		if g.Links[i] != want[i] {
+			t.Errorf("link %d = %+v, want %+v", i, g.Links[i], want[i])
+		}
+	}
+}
+
+func TestServeGraph(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(cycleGraphYAML), 0o644)
+
+	rec := httptest.NewRecorder()
+	serveGraph(rec, httptest.NewRequest(http.MethodGet, "/api/graph?format=cytoscape", nil), file)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+	}
+	var c struct {
+		Elements struct {
+			Nodes []struct {
+				Data    map[string]interface{} `json:"data"`
+				Classes string                 `json:"classes"`
+			} `json:"nodes"`
+			Edges []struct {
+				Data    map[string]interface{} `json:"data"`
+				Classes string                 `json:"classes"`
+			} `json:"edges"`
+		} `json:"elements"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &c); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Elements.Nodes) != 3 || c.Elements.Nodes[0].Classes != "in-cycle" || c.Elements.Nodes[2].Classes != "" {
+		t.Errorf("nodes = %+v", c.Elements.Nodes)
+	}
+	if e := c.Elements.Edges[1]; e.Data["id"] != "e1" || e.Data["source"] != "Login" || e.Classes != "blocks in-cycle" {
+		t.Errorf("edge = %+v", e)
+	}
+
+	etag := rec.Header().Get("ETag")
+	rec = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/graph?format=cytoscape", nil)
+	r.Header.Set("If-None-Match", etag)
+	serveGraph(rec, r, file)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("unchanged file: status %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	serveGraph(rec, httptest.NewRequest(http.MethodGet, "/api/graph?format=graphml", nil), file)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown format: status %d", rec.Code)
+	}
+}
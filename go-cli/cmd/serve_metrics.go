@@ -0,0 +1,154 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// fileMetrics are the /metrics gauges of one requirements file
+type fileMetrics struct {
+	file   string
+	parsed bool
+	// counts are the requirements by status and priority
+	counts map[[2]string]int
+	cycles int
+	// validation is nil when the file couldn't be validated, such as
+	// without the validator
+	validation *ValidationResult
+}
+
+// collectFileMetrics reads and validates file for /metrics, labeled as
+// label. Validation results are cached by content, so a scrape only runs
+// the validator on files that changed since the last.
+func collectFileMetrics(file, label string) fileMetrics {
+	m := fileMetrics{file: label, counts: make(map[[2]string]int)}
+	config, err := model.Load(file)
+	if err != nil {
+		return m
+	}
+	m.parsed = true
+	for _, req := range config.Flatten() {
+		m.counts[[2]string{metricLabel(req.Status), metricLabel(req.Priority)}]++
+	}
+	ordering, _ := config.OrderingGraph()
+	m.cycles = len(ordering.Cycles())
+	if result, err := validateFile(file); err == nil {
+		m.validation = result
+	}
+	return m
+}
+
+// metricLabel is the label value of a field, none when unset
+func metricLabel(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
+
+// escapeLabel escapes a label value for the Prometheus text format
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// writeMetrics writes the gauges of files in the Prometheus text
+// exposition format
+func writeMetrics(w io.Writer, files []fileMetrics) {
+	gauge := func(name, help string, values func(m fileMetrics) []string) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		for _, m := range files {
+			for _, line := range values(m) {
+				fmt.Fprintf(w, "%s%s\n", name, line)
+			}
+		}
+	}
+	bool01 := func(b bool) int {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	gauge("rqm_file_parsed", "Whether the requirements file could be read and parsed (1) or not (0).", func(m fileMetrics) []string {
+		return []string{fmt.Sprintf(`{file="%s"} %d`, escapeLabel(m.file), bool01(m.parsed))}
+	})
+	gauge("rqm_requirements", "Requirements by status and priority; none when unset.", func(m fileMetrics) []string {
+		keys := make([][2]string, 0, len(m.counts))
+		for key := range m.counts {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i][0] < keys[j][0] || keys[i][0] == keys[j][0] && keys[i][1] < keys[j][1]
+		})
+		var lines []string
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf(`{file="%s",status="%s",priority="%s"} %d`, escapeLabel(m.file), escapeLabel(key[0]), escapeLabel(key[1]), m.counts[key]))
+		}
+		return lines
+	})
+	gauge("rqm_cycles", "Circular references between requirements, as rqm check reports them.", func(m fileMetrics) []string {
+		if !m.parsed {
+			return nil
+		}
+		return []string{fmt.Sprintf(`{file="%s"} %d`, escapeLabel(m.file), m.cycles)}
+	})
+	validated := func(value func(v *ValidationResult) int) func(m fileMetrics) []string {
+		return func(m fileMetrics) []string {
+			if m.validation == nil {
+				return nil
+			}
+			return []string{fmt.Sprintf(`{file="%s"} %d`, escapeLabel(m.file), value(m.validation))}
+		}
+	}
+	gauge("rqm_valid", "Whether the requirements file passes rqm validate (1) or not (0).", validated(func(v *ValidationResult) int { return bool01(v.Valid) }))
+	gauge("rqm_validation_errors", "Errors rqm validate reports for the requirements file.", validated(func(v *ValidationResult) int { return len(v.Errors) }))
+	gauge("rqm_validation_warnings", "Warnings rqm validate reports for the requirements file.", validated(func(v *ValidationResult) int { return len(v.Warnings) }))
+}
+
+// serveMetrics answers /metrics with the gauges of the requirements files
+// files lists, found anew on every scrape, and those of daemon when
+// serve --daemon runs one
+func serveMetrics(w http.ResponseWriter, r *http.Request, files func() (map[string]string, error), daemon *serveDaemon) {
+	labels, err := files()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var metrics []fileMetrics
+	for _, file := range sortedKeys(labels) {
+		metrics = append(metrics, collectFileMetrics(file, labels[file]))
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writeMetrics(w, metrics)
+	if daemon != nil {
+		daemon.writeMetrics(w)
+	}
+}
+
+// projectMetricsFiles lists the requirements files under dir for
+// /metrics, labeled by their path relative to it
+func projectMetricsFiles(dir string) (map[string]string, error) {
+	files, err := findRequirementsFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]string, len(files))
+	for _, file := range files {
+		label, err := filepath.Rel(dir, file)
+		if err != nil {
+			label = file
+		}
+		labels[file] = filepath.ToSlash(label)
+	}
+	return labels, nil
+}
@@ -0,0 +1,63 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestServeMetrics(t *testing.T) {
+	if runtime.GOOS == "windows" || validatorKind() == "embedded" {
+		t.Skip("needs a fake validator script")
+	}
+	fakeValidator(t, true)
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "requirements.yml"), []byte(cycleGraphYAML), 0o644)
+	os.Mkdir(filepath.Join(dir, "billing"), 0o755)
+	os.WriteFile(filepath.Join(dir, "billing", "requirements.yml"), []byte(`version: "1.0"
+requirements:
+  - summary: "Invoices"
+    priority: high
+    status: approved
+  - summary: Refunds
+    priority: high
+    status: approved
+  - summary: Credit notes
+`), 0o644)
+	os.WriteFile(filepath.Join(dir, "broken.yml"), []byte("requirements: [\n"), 0o644)
+
+	rec := httptest.NewRecorder()
+	serveMetrics(rec, httptest.NewRequest("GET", "/metrics", nil), func() (map[string]string, error) { return projectMetricsFiles(dir) }, nil)
+	body := rec.Body.String()
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type: %s", ct)
+	}
+	for _, want := range []string{
+		"# TYPE rqm_requirements gauge\n",
+		`rqm_requirements{file="billing/requirements.yml",status="approved",priority="high"} 2` + "\n",
+		`rqm_requirements{file="billing/requirements.yml",status="none",priority="none"} 1` + "\n",
+		`rqm_requirements{file="requirements.yml",status="approved",priority="high"} 1` + "\n",
+		`rqm_cycles{file="billing/requirements.yml"} 0` + "\n",
+		`rqm_cycles{file="requirements.yml"} 1` + "\n",
+		`rqm_file_parsed{file="broken.yml"} 0` + "\n",
+		`rqm_valid{file="requirements.yml"} 1` + "\n",
+		`rqm_validation_errors{file="requirements.yml"} 0` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+}
+
+func TestEscapeLabel(t *testing.T) {
+	if got := escapeLabel("a\"b\\c\nd"); got != `a\"b\\c\nd` {
+		t.Errorf("got %s", got)
+	}
+}
@@ -0,0 +1,191 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// searchFields are the query parameters of /api/search that match a
+// field exactly; a repeated one matches any of its values
+var searchFields = []string{"status", "priority", "owner", "tag", "verification"}
+
+// searchTextFields are the fields the words of q are looked for in
+var searchTextFields = []string{"summary", "name", "description", "tag"}
+
+const (
+	defaultSearchPageSize = 50
+	maxSearchPageSize     = 500
+)
+
+// SearchResult is a requirement found by /api/search, with the summaries
+// of its parents from the top level down
+type SearchResult struct {
+	Summary     string   `json:"summary"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	Priority    string   `json:"priority,omitempty"`
+	Owner       string   `json:"owner,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty"`
+	Parents     []string `json:"parents"`
+}
+
+// SearchResponse is a page of /api/search results. Total counts every
+// match, on all Pages.
+type SearchResponse struct {
+	Total    int            `json:"total"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+	Pages    int            `json:"pages"`
+	Results  []SearchResult `json:"results"`
+}
+
+// searchFilter compiles the query of /api/search: every word of q must
+// appear in the summary, name, description or a tag, ignoring case; filter
+// is an expression as for rqm bulk; and each of searchFields must have one
+// of its values. Without any of them everything matches.
+func searchFilter(query url.Values) (requirementFilter, error) {
+	var filters []requirementFilter
+	for _, word := range strings.Fields(query.Get("q")) {
+		needle := strings.ToLower(word)
+		filters = append(filters, func(req *model.RequirementDetail) bool {
+			for _, field := range searchTextFields {
+				if slices.ContainsFunc(filterFieldValues(req, field), func(v string) bool { return strings.Contains(strings.ToLower(v), needle) }) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	if expr := query.Get("filter"); strings.TrimSpace(expr) != "" {
+		filter, err := parseFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	for _, field := range searchFields {
+		values := query[field]
+		if len(values) == 0 {
+			continue
+		}
+		filters = append(filters, func(req *model.RequirementDetail) bool {
+			return slices.ContainsFunc(filterFieldValues(req, field), func(v string) bool { return slices.Contains(values, v) })
+		})
+	}
+	return func(req *model.RequirementDetail) bool {
+		for _, filter := range filters {
+			if !filter(req) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// searchPage reads page and page_size, 1 and defaultSearchPageSize by
+// default
+func searchPage(query url.Values) (page, size int, err error) {
+	page, size = 1, defaultSearchPageSize
+	if v := query.Get("page"); v != "" {
+		if page, err = strconv.Atoi(v); err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("invalid page: %s", v)
+		}
+	}
+	if v := query.Get("page_size"); v != "" {
+		if size, err = strconv.Atoi(v); err != nil || size < 1 || size > maxSearchPageSize {
+			return 0, 0, fmt.Errorf("invalid page_size: %s (1 to %d)", v, maxSearchPageSize)
+		}
+	}
+	return page, size, nil
+}
+
+// searchRequirements returns the requirements of config filter matches,
+// in file order
+func searchRequirements(config *model.RequirementConfig, filter requirementFilter) []SearchResult {
+	results := []SearchResult{}
+	var visit func(reqs []*model.RequirementDetail, parents []string)
+	visit = func(reqs []*model.RequirementDetail, parents []string) {
+		for _, req := range reqs {
+			if filter(req) {
+				results = append(results, SearchResult{
+					Summary:     req.Summary,
+					Name:        req.Name,
+					Description: req.Description,
+					Status:      req.Status,
+					Priority:    req.Priority,
+					Owner:       req.Owner,
+					Tags:        req.Tags,
+					Deprecated:  req.IsDeprecated(),
+					Parents:     slices.Clone(nonNil(parents)),
+				})
+			}
+			visit(req.Children(), append(parents, req.Summary))
+		}
+	}
+	visit(config.Roots(), nil)
+	return results
+}
+
+// serveSearch answers /api/search with a page of the requirements of
+// reqFile matching the query. Deprecated requirements, and those under
+// them, are left out unless include_deprecated is true, as for rqm list.
+func serveSearch(w http.ResponseWriter, r *http.Request, reqFile string) {
+	query := r.URL.Query()
+	filter, err := searchFilter(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, size, err := searchPage(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	hash, err := requirementsTreeHash(reqFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// Results only change with the file and those it includes; caches key
+	// them by the query
+	etag := `"` + hash + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	config, err := model.Load(reqFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if query.Get("include_deprecated") != "true" {
+		hideDeprecated(config)
+	}
+
+	results := searchRequirements(config, filter)
+	// A page past the last is empty rather than an error, so a client
+	// can narrow the query without resetting its page first
+	start, end := min((page-1)*size, len(results)), min(page*size, len(results))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(SearchResponse{
+		Total:    len(results),
+		Page:     page,
+		PageSize: size,
+		Pages:    max(1, (len(results)+size-1)/size),
+		Results:  results[start:end],
+	})
+}
@@ -0,0 +1,129 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const searchYAML = `version: "1.0"
+requirements:
+  - summary: User authentication
+    name: AUTH-001
+    status: approved
+    priority: high
+    tags: [security]
+    requirements:
+      - summary: Password reset
+        name: AUTH-002
+        description: Users reset a forgotten password by email
+        status: proposed
+        priority: medium
+      - summary: Legacy login
+        status: deprecated
+        requirements:
+          - summary: Legacy password hashing
+            status: proposed
+  - summary: Audit log
+    status: proposed
+    priority: low
+    tags: [security, compliance]
+`
+
+func searchRequest(t *testing.T, file, query string) SearchResponse {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	serveSearch(rec, httptest.NewRequest("GET", "/api/search?"+query, nil), file)
+	if rec.Code != 200 {
+		t.Fatalf("%s: %d %s", query, rec.Code, rec.Body.String())
+	}
+	var response SearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	return response
+}
+
+func TestServeSearch(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(searchYAML), 0o644)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "User authentication, Password reset, Audit log"},
+		{"q=PASSWORD", "Password reset"},
+		{"q=forgotten+email", "Password reset"},
+		{"q=auth-001", "User authentication"},
+		{"q=compliance", "Audit log"},
+		{"status=proposed", "Password reset, Audit log"},
+		{"status=proposed&status=approved&tag=security", "User authentication, Audit log"},
+		{"filter=priority>=medium+%26%26+status!=approved", "Password reset"},
+		{"q=password&include_deprecated=true", "Password reset, Legacy password hashing"},
+	}
+	for _, tt := range tests {
+		response := searchRequest(t, file, tt.query)
+		var got []string
+		for _, result := range response.Results {
+			got = append(got, result.Summary)
+		}
+		if strings.Join(got, ", ") != tt.want || response.Total != len(got) {
+			t.Errorf("%q: %d results %q, want %q", tt.query, response.Total, strings.Join(got, ", "), tt.want)
+		}
+	}
+
+	response := searchRequest(t, file, "q=password")
+	if parents := response.Results[0].Parents; len(parents) != 1 || parents[0] != "User authentication" {
+		t.Errorf("parents: %q", parents)
+	}
+}
+
+func TestServeSearchIncludes(t *testing.T) {
+	file := writeIncludeTree(t)
+	if response := searchRequest(t, file, ""); response.Total != 2 {
+		t.Errorf("Expected the included requirement too, got %+v", response)
+	}
+
+	// The ETag follows the included file
+	rec := httptest.NewRecorder()
+	serveSearch(rec, httptest.NewRequest("GET", "/api/search", nil), file)
+	etag := rec.Header().Get("ETag")
+	os.WriteFile(filepath.Join(filepath.Dir(file), "part.yml"), []byte("version: \"1.0\"\nrequirements: []\n"), 0o644)
+	req := httptest.NewRequest("GET", "/api/search", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	serveSearch(rec, req, file)
+	if rec.Code != 200 || rec.Header().Get("ETag") == etag {
+		t.Errorf("Expected fresh results after the included file changed, got %d with ETag %s", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestServeSearchPages(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(searchYAML), 0o644)
+
+	response := searchRequest(t, file, "page=2&page_size=2")
+	if response.Total != 3 || response.Pages != 2 || len(response.Results) != 1 || response.Results[0].Summary != "Audit log" {
+		t.Errorf("page 2: %+v", response)
+	}
+	response = searchRequest(t, file, "page=3&page_size=2")
+	if response.Total != 3 || response.Results == nil || len(response.Results) != 0 {
+		t.Errorf("past the last page: %+v", response)
+	}
+
+	for _, query := range []string{"page=0", "page_size=501", "filter=status=="} {
+		rec := httptest.NewRecorder()
+		serveSearch(rec, httptest.NewRequest("GET", "/api/search?"+query, nil), file)
+		if rec.Code != 400 {
+			t.Errorf("%s: %d %s", query, rec.Code, rec.Body.String())
+		}
+	}
+}
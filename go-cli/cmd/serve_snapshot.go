@@ -0,0 +1,136 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// serveSnapshot is the version of the requirements file at a git ref that
+// rqm serve --ref or --baseline shows instead of the working copy
+type serveSnapshot struct {
+	File     string `json:"file"`
+	Ref      string `json:"ref"`
+	Baseline string `json:"baseline,omitempty"`
+	Commit   string `json:"commit"`
+	Date     string `json:"date"`
+	// path is a temporary copy of the file at Ref, as YAML
+	path string
+}
+
+// snapshotInfo is the response of /api/snapshot
+type snapshotInfo struct {
+	Historical bool `json:"historical"`
+	*serveSnapshot
+}
+
+// label describes the snapshot for people
+func (s *serveSnapshot) label() string {
+	ref := s.Ref
+	if s.Baseline != "" {
+		ref = fmt.Sprintf("baseline %s (%s)", s.Baseline, s.Ref)
+	}
+	return fmt.Sprintf("%s at %s, commit %.7s of %s", filepath.Base(s.File), ref, s.Commit, s.Date)
+}
+
+// baselineRef is the git ref of a baseline named in .rqm/config.yml
+func baselineRef(file, baseline string) (string, error) {
+	project, err := loadProjectConfig(file)
+	if err != nil {
+		return "", err
+	}
+	ref, ok := project.Baselines[baseline]
+	if !ok {
+		if len(project.Baselines) == 0 {
+			return "", fmt.Errorf("unknown baseline: %s (.rqm/config.yml defines no baselines)", baseline)
+		}
+		return "", fmt.Errorf("unknown baseline: %s (defined: %s)", baseline, strings.Join(sortedKeys(project.Baselines), ", "))
+	}
+	return ref, nil
+}
+
+// loadServeSnapshot copies file as it was at ref, or at the git ref of the
+// named baseline in .rqm/config.yml, to a temporary YAML file
+func loadServeSnapshot(file, ref, baseline string) (*serveSnapshot, error) {
+	if baseline != "" {
+		var err error
+		if ref, err = baselineRef(file, baseline); err != nil {
+			return nil, err
+		}
+	}
+	// The ref is passed to git, which mustn't take it for an option
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid git ref: %s", ref)
+	}
+
+	data, found, err := fileAtRef(ref, file)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%s doesn't exist at %s", file, ref)
+	}
+	if data, err = model.ToYAML(file, data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s at %s: %w", file, ref, err)
+	}
+	commit, err := gitOutput("rev-parse", ref+"^{commit}")
+	if err != nil {
+		return nil, err
+	}
+	date, err := gitOutput("show", "-s", "--format=%cs", commit)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "rqm-snapshot-*.yml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer tmp.Close()
+	if _, err := tmp.Write(data); err != nil {
+		os.Remove(tmp.Name())
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	return &serveSnapshot{File: file, Ref: ref, Baseline: baseline, Commit: commit, Date: date, path: tmp.Name()}, nil
+}
+
+// serveSnapshotInfo answers /api/snapshot, telling the UI whether it shows
+// a historical version; snapshot is nil for the working copy
+func serveSnapshotInfo(w http.ResponseWriter, snapshot *serveSnapshot) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(snapshotInfo{Historical: snapshot != nil, serveSnapshot: snapshot})
+}
+
+// readOnlySnapshot labels every response as coming from snapshot and
+// rejects requests that would change anything, since the past can't be
+// edited
+func readOnlySnapshot(next http.Handler, snapshot *serveSnapshot) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RQM-Snapshot", snapshot.Ref)
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+		default:
+			w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+			http.Error(w, "read-only snapshot of "+snapshot.label(), http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// withSnapshotBanner inserts the historical banner at the start of the
+// page's body
+func withSnapshotBanner(page []byte, snapshot *serveSnapshot) []byte {
+	banner := fmt.Sprintf(`<div id="rqm-snapshot-banner" role="status" style="position:sticky;top:0;z-index:1000;padding:6px 12px;background:#fff4e5;border-bottom:1px solid #f0b429;font:14px sans-serif">Historical snapshot: %s. Read-only.</div>`,
+		html.EscapeString(snapshot.label()))
+	return insertAfterTag(page, "<body", []byte(banner))
+}
@@ -0,0 +1,84 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadServeSnapshot(t *testing.T) {
+	gitTestRepo(t)
+	os.Mkdir(".rqm", 0755)
+	os.WriteFile(".rqm/config.yml", []byte("baselines:\n  design-review: v1.0\n"), 0644)
+	gitCommitFiles(t, "agree on requirements", map[string]string{"requirements.json": `{"version": "1.0", "requirements": [{"summary": "Login"}]}`})
+	mustGit(t, "tag", "v1.0")
+	os.WriteFile("requirements.json", []byte(`{"version": "1.0", "requirements": [{"summary": "Passkey login"}]}`), 0644)
+
+	snapshot, err := loadServeSnapshot("requirements.json", "", "design-review")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(snapshot.path)
+	data, _ := os.ReadFile(snapshot.path)
+	if !strings.Contains(string(data), "summary: Login") {
+		t.Errorf("Expected the agreed version as YAML, got:\n%s", data)
+	}
+	if snapshot.Ref != "v1.0" || len(snapshot.Commit) != 40 || !strings.HasPrefix(snapshot.label(), "requirements.json at baseline design-review (v1.0), commit ") {
+		t.Errorf("Unexpected snapshot %+v, labeled %q", snapshot, snapshot.label())
+	}
+
+	if _, err := loadServeSnapshot("requirements.json", "", "launch"); err == nil || !strings.Contains(err.Error(), "unknown baseline: launch (defined: design-review)") {
+		t.Errorf("Expected an error for an unknown baseline, got %v", err)
+	}
+	if _, err := loadServeSnapshot("requirements.json", "v2.0", ""); err == nil || !strings.Contains(err.Error(), "unknown git ref: v2.0") {
+		t.Errorf("Expected an error for an unknown ref, got %v", err)
+	}
+}
+
+func TestReadOnlySnapshot(t *testing.T) {
+	snapshot := &serveSnapshot{File: "requirements.yml", Ref: "v1.0", Commit: "0123456789abcdef", Date: "2025-06-01"}
+	files := fstest.MapFS{"index.html": {Data: []byte(`<html><body class="app"><div id="root"></div></body></html>`)}}
+	mux := http.NewServeMux()
+	mux.Handle("/", uiIndex(files, snapshot, "/"))
+	mux.HandleFunc("/api/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		serveSnapshotInfo(w, snapshot)
+	})
+	handler := readOnlySnapshot(mux, snapshot)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	want := `<body class="app"><div id="rqm-snapshot-banner"`
+	if !strings.Contains(rec.Body.String(), want) || !strings.Contains(rec.Body.String(), "Historical snapshot: requirements.yml at v1.0, commit 0123456 of 2025-06-01. Read-only.") {
+		t.Errorf("Expected the banner after <body>, got:\n%s", rec.Body.String())
+	}
+	if rec.Header().Get("X-RQM-Snapshot") != "v1.0" {
+		t.Errorf("Expected the X-RQM-Snapshot header, got %v", rec.Header())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/requirements", strings.NewReader("version: x")))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected edits to be refused, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/snapshot", nil))
+	var info map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil || info["historical"] != true || info["ref"] != "v1.0" {
+		t.Errorf("Unexpected /api/snapshot response %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	serveSnapshotInfo(rec, nil)
+	if strings.TrimSpace(rec.Body.String()) != `{"historical":false}` {
+		t.Errorf("Expected a live server to report no snapshot, got %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,423 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/render"
+	"github.com/spf13/cobra"
+)
+
+var showFormat string
+
+// RelatedRequirement is a parent, child or referencing requirement of the
+// one show prints
+type RelatedRequirement struct {
+	Summary string `json:"summary"`
+	Name    string `json:"name,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
+
+// IncomingReference is a requirement referencing the shown one
+type IncomingReference struct {
+	RelatedRequirement
+	// Kind is child for a child reference, superseded_by, or the type of
+	// a relation
+	Kind string `json:"kind"`
+}
+
+// LinkedArtifact is code, a test or an API operation a requirement links to
+type LinkedArtifact struct {
+	// Kind is acceptance_test_link, verified_by or openapi
+	Kind   string `json:"kind"`
+	Target string `json:"target"`
+}
+
+// requirementView is everything show prints about a requirement
+type requirementView struct {
+	req          *model.RequirementDetail
+	parent       *model.RequirementDetail
+	children     []*model.RequirementDetail
+	referencedBy []IncomingReference
+	linked       []LinkedArtifact
+}
+
+var showCmd = &cobra.Command{
+	Use:   "show <requirement> [file]",
+	Short: "Show everything about one requirement",
+	Long: `Print the full detail of one requirement, named by its ID or summary:
+its description, justification and acceptance test in full, its
+acceptance criteria, relations and approvals, its parent and
+sub-requirements, the requirements that reference it (as a child, a
+relation or superseded_by), and the code, tests and API operations it
+links to (acceptance_test_link, verified_by and links.openapi).
+
+Formats are tree (the default, for the terminal), term (tree with the
+description, justification and further information rendered as Markdown:
+headings, emphasis, lists, code and links styled and wrapped to the
+terminal), markdown (for pasting into an issue or a pull request) and
+json. A Markdown link to #AUTH-001 refers to that requirement, and rqm
+validate warns about such links to requirements that don't exist.`,
+	Example: `  rqm show AUTH-001
+  rqm show "Password reset" requirements.yml
+  rqm show AUTH-001 --format term
+  rqm show AUTH-001 --format markdown
+  rqm show AUTH-001 --format json`,
+	Args: cobra.RangeArgs(1, 2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			return completeRequirementIDs(cmd, args, toComplete)
+		case 1:
+			return completeRequirementsFile(cmd, nil, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args[1:])
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist: %s", file)
+		}
+		config, err := model.Load(file)
+		if err != nil {
+			return err
+		}
+		view, err := newRequirementView(config, args[0])
+		if err != nil {
+			return err
+		}
+		ownerAliases = config.Aliases
+
+		switch showFormat {
+		case "tree":
+			view.writeText(os.Stdout, 0)
+			return nil
+		case "term":
+			width := stdoutTerminalWidth()
+			if width <= 0 {
+				width = defaultMarkdownWidth
+			}
+			view.writeText(os.Stdout, width)
+			return nil
+		case "markdown":
+			view.writeMarkdown(os.Stdout)
+			return nil
+		case "json":
+			return writeJSON(view.output(file))
+		default:
+			return fmt.Errorf("unknown output format: %s", showFormat)
+		}
+	},
+}
+
+// newRequirementView gathers what show prints about the requirement ref
+// names in config
+func newRequirementView(config *model.RequirementConfig, ref string) (*requirementView, error) {
+	req, ok := config.Find(ref)
+	if !ok {
+		return nil, fmt.Errorf("requirement not found: %s", ref)
+	}
+	view := &requirementView{req: req, children: req.Children()}
+
+	for _, other := range config.Flatten() {
+		for _, child := range other.Children() {
+			if child == req {
+				view.parent = other
+			}
+		}
+		refersTo := func(target string) bool {
+			found, ok := config.Find(target)
+			return ok && found == req && other != req
+		}
+		var kinds []string
+		for _, child := range other.Requirements {
+			if child.Full == nil && child.External == nil && refersTo(child.Reference) {
+				kinds = append(kinds, "child")
+			}
+		}
+		for _, rel := range other.Relations {
+			if refersTo(rel.Target) {
+				kinds = append(kinds, rel.Type)
+			}
+		}
+		if other.SupersededBy != "" && refersTo(other.SupersededBy) {
+			kinds = append(kinds, "superseded_by")
+		}
+		for _, kind := range kinds {
+			view.referencedBy = append(view.referencedBy, IncomingReference{RelatedRequirement: relatedRequirement(other), Kind: kind})
+		}
+	}
+	sort.SliceStable(view.referencedBy, func(i, j int) bool {
+		return view.referencedBy[i].Kind < view.referencedBy[j].Kind
+	})
+
+	if req.AcceptanceTestLink != "" {
+		view.linked = append(view.linked, LinkedArtifact{"acceptance_test_link", req.AcceptanceTestLink})
+	}
+	for _, v := range req.VerifiedBy {
+		view.linked = append(view.linked, LinkedArtifact{"verified_by", v})
+	}
+	if req.Links != nil {
+		for _, op := range req.Links.OpenAPI {
+			view.linked = append(view.linked, LinkedArtifact{"openapi", op})
+		}
+	}
+	return view, nil
+}
+
+// relatedRequirement identifies req in show output
+func relatedRequirement(req *model.RequirementDetail) RelatedRequirement {
+	return RelatedRequirement{Summary: req.Summary, Name: req.Name, Status: req.Status}
+}
+
+// output is the JSON payload of the view
+func (v *requirementView) output(file string) ShowOutput {
+	out := ShowOutput{
+		SchemaVersion: apiVersion,
+		File:          fileLabel(file),
+		Requirement:   v.req,
+		Children:      []RelatedRequirement{},
+		ReferencedBy:  nonNil(v.referencedBy),
+		Linked:        nonNil(v.linked),
+	}
+	if v.parent != nil {
+		parent := relatedRequirement(v.parent)
+		out.Parent = &parent
+	}
+	for _, child := range v.children {
+		out.Children = append(out.Children, relatedRequirement(child))
+	}
+	return out
+}
+
+// fields are the scalar fields of the requirement with a value, as
+// label and value pairs
+func (v *requirementView) fields() [][2]string {
+	req := v.req
+	var fields [][2]string
+	add := func(label, value string) {
+		if value != "" {
+			fields = append(fields, [2]string{label, value})
+		}
+	}
+	add("ID", req.Name)
+	add("UID", req.UID)
+	add("Status", req.Status)
+	add("Priority", req.Priority)
+	add("Estimate", formatOptionalEstimate(req.Estimate))
+	if req.Owner != "" {
+		add("Owner", displayOwner(ownerAliases, req.Owner))
+	}
+	add("Tags", strings.Join(req.Tags, ", "))
+	add("Verification", req.Verification)
+	add("Environments", strings.Join(req.Environments, ", "))
+	add("Covers", strings.Join(req.Covers, ", "))
+	if note := deprecationNote(req); note != "" {
+		add("Deprecated", strings.Trim(note, "()"))
+	}
+	if v.parent != nil {
+		add("Parent", displayName(v.parent))
+	}
+	for _, key := range sortedKeys(req.Attributes) {
+		add(key, fmt.Sprint(req.Attributes[key]))
+	}
+	return fields
+}
+
+// sections are the multi-line parts of the requirement with a value, as
+// title and lines pairs
+func (v *requirementView) sections() []struct {
+	title string
+	lines []string
+} {
+	req := v.req
+	var sections []struct {
+		title string
+		lines []string
+	}
+	add := func(title string, lines []string) {
+		if len(lines) > 0 {
+			sections = append(sections, struct {
+				title string
+				lines []string
+			}{title, lines})
+		}
+	}
+	text := func(s string) []string {
+		if s = strings.TrimRight(s, "\n "); s == "" {
+			return nil
+		}
+		return strings.Split(s, "\n")
+	}
+
+	add("Description", text(req.Description))
+	add("Justification", text(req.Justification))
+	add("Acceptance test", text(req.AcceptanceTest))
+	var lines []string
+	for _, c := range req.AcceptanceCriteria {
+		lines = append(lines, c.ID+": "+c.Criterion)
+	}
+	add("Acceptance criteria", lines)
+
+	lines = nil
+	for _, rel := range req.Relations {
+		lines = append(lines, rel.Type+": "+rel.Target)
+	}
+	add("Relations", lines)
+	lines = nil
+	for _, child := range req.Requirements {
+		switch {
+		case child.External != nil:
+			lines = append(lines, child.External.String()+" (external)")
+		case child.Full == nil:
+			lines = append(lines, child.Reference)
+		}
+	}
+	add("Referenced sub-requirements", lines)
+	lines = nil
+	for _, a := range req.Approvals {
+		line := a.Approver + ": " + approvalState(a)
+		if a.Due != "" {
+			line += " (due " + a.Due + ")"
+		}
+		lines = append(lines, line)
+	}
+	add("Approvals", lines)
+	lines = nil
+	for _, q := range req.OpenQuestions {
+		if q.Answer == "" {
+			lines = append(lines, q.Question)
+		} else {
+			lines = append(lines, q.Question+" → "+q.Answer)
+		}
+	}
+	add("Questions", lines)
+	lines = nil
+	for _, a := range req.Attachments {
+		target := a.Path
+		if target == "" {
+			target = a.URL
+		}
+		lines = append(lines, a.Title+": "+target)
+	}
+	add("Attachments", lines)
+
+	lines = nil
+	for _, ref := range v.referencedBy {
+		lines = append(lines, displayName(&model.RequirementDetail{Name: ref.Name, Summary: ref.Summary})+" ("+ref.Kind+")")
+	}
+	add("Referenced by", lines)
+	lines = nil
+	for _, l := range v.linked {
+		lines = append(lines, l.Kind+": "+l.Target)
+	}
+	add("Linked code and tests", lines)
+	add("Further information", req.FurtherInformation)
+	return sections
+}
+
+// approvalState is the status of an approval, pending when it has none
+func approvalState(a model.Approval) string {
+	if a.Status == "" {
+		return "pending"
+	}
+	return a.Status
+}
+
+// writeText prints the view for the terminal, with the sub-requirements
+// as a tree. With a markdownWidth the description, justification and
+// further information are rendered as Markdown wrapped to that width,
+// rather than printed as written.
+func (v *requirementView) writeText(w io.Writer, markdownWidth int) {
+	tree := requirementTree(w, false)
+	fmt.Fprintln(w, tree.Line(v.req))
+	for _, f := range v.fields() {
+		fmt.Fprintf(w, "  %-13s %s\n", f[0]+":", f[1])
+	}
+	for _, s := range v.sections() {
+		fmt.Fprintf(w, "\n%s:\n", s.title)
+		lines := s.lines
+		if markdownWidth > 0 {
+			switch s.title {
+			case "Description", "Justification":
+				lines = renderMarkdown(strings.Join(lines, "\n"), markdownWidth-2)
+			case "Further information":
+				lines = nil
+				for _, item := range s.lines {
+					lines = append(lines, renderMarkdown("- "+item, markdownWidth-2)...)
+				}
+			}
+		}
+		for _, line := range lines {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+	if len(v.children) > 0 {
+		var b strings.Builder
+		tree.W = &b
+		render.Walk(v.children, (*model.RequirementDetail).Children, tree)
+		fmt.Fprintln(w, "\nSub-requirements:")
+		for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+}
+
+// writeMarkdown prints the view as a Markdown document
+func (v *requirementView) writeMarkdown(w io.Writer) {
+	fmt.Fprintf(w, "# %s\n\n", displayName(v.req))
+	if fields := v.fields(); len(fields) > 0 {
+		fmt.Fprintln(w, "| Field | Value |")
+		fmt.Fprintln(w, "|-------|-------|")
+		for _, f := range fields {
+			fmt.Fprintf(w, "| %s | %s |\n", markdownCell(f[0]), markdownCell(f[1]))
+		}
+		fmt.Fprintln(w)
+	}
+	for _, s := range v.sections() {
+		fmt.Fprintf(w, "## %s\n\n", s.title)
+		switch s.title {
+		case "Description", "Justification":
+			fmt.Fprintf(w, "%s\n\n", strings.Join(s.lines, "\n"))
+		case "Acceptance test":
+			fmt.Fprintf(w, "```gherkin\n%s\n```\n\n", strings.Join(s.lines, "\n"))
+		default:
+			for _, line := range s.lines {
+				fmt.Fprintf(w, "- %s\n", line)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	if len(v.children) > 0 {
+		fmt.Fprint(w, "## Sub-requirements\n\n")
+		render.Walk(v.children, (*model.RequirementDetail).Children, render.VisitorFunc[*model.RequirementDetail](func(req *model.RequirementDetail, pos render.Position) error {
+			_, err := fmt.Fprintf(w, "%s- %s (%s)\n", strings.Repeat("  ", pos.Depth), displayName(req), statusOrNone(req.Status))
+			return err
+		}))
+		fmt.Fprintln(w)
+	}
+}
+
+// statusOrNone is a requirement's status for display
+func statusOrNone(status string) string {
+	if status == "" {
+		return "no status"
+	}
+	return status
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+	showCmd.Flags().StringVarP(&showFormat, "format", "f", "tree", "Output format (tree, term, markdown, json)")
+}
@@ -0,0 +1,106 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const showYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    status: implemented
+    description: |
+      Users sign in with email and password.
+      Sessions last 30 minutes, which is far longer than a single line of list --details shows.
+    acceptance_test: |
+      Given a registered user
+      When they sign in
+      Then they see the dashboard
+    acceptance_test_link: tests/acceptance/test_login.sh
+    verified_by: [cmd/login_test.go]
+    links:
+      openapi: [createSession]
+    requirements:
+      - summary: Password hashing
+        status: draft
+      - Session timeout
+  - summary: Session timeout
+    name: AUTH-002
+    relations:
+      - type: depends_on
+        target: AUTH-001
+  - summary: Legacy login
+    superseded_by: AUTH-001
+`
+
+func TestRequirementView(t *testing.T) {
+	config, err := model.ParseYAML([]byte(showYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newRequirementView(config, "AUTH-404"); err == nil {
+		t.Error("showing an unknown requirement succeeded")
+	}
+
+	view, err := newRequirementView(config, "AUTH-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := view.output("r.yml")
+	if out.Parent != nil || len(out.Children) != 1 || out.Children[0].Summary != "Password hashing" {
+		t.Errorf("parent %+v, children %+v", out.Parent, out.Children)
+	}
+	var refs []string
+	for _, ref := range out.ReferencedBy {
+		refs = append(refs, ref.Summary+" "+ref.Kind)
+	}
+	if got := strings.Join(refs, ", "); got != "Session timeout depends_on, Legacy login superseded_by" {
+		t.Errorf("referenced by %s", got)
+	}
+	if len(out.Linked) != 3 || out.Linked[2] != (LinkedArtifact{"openapi", "createSession"}) {
+		t.Errorf("linked %+v", out.Linked)
+	}
+
+	var text strings.Builder
+	view.writeText(&text, 0)
+	for _, want := range []string{
+		"  Sessions last 30 minutes, which is far longer than a single line of list --details shows.\n",
+		"Acceptance test:\n  Given a registered user\n  When they sign in\n  Then they see the dashboard\n",
+		"Referenced sub-requirements:\n  Session timeout\n",
+		"  verified_by: cmd/login_test.go\n",
+		"Sub-requirements:\n  ◯ [unnamed] Password hashing",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text output missing %q:\n%s", want, text.String())
+		}
+	}
+
+	var md strings.Builder
+	view.writeMarkdown(&md)
+	for _, want := range []string{
+		"# [AUTH-001] Login\n",
+		"| Status | implemented |\n",
+		"```gherkin\nGiven a registered user\n",
+		"- [AUTH-002] Session timeout (depends_on)\n",
+		"## Sub-requirements\n\n- Password hashing (draft)\n",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("markdown output missing %q:\n%s", want, md.String())
+		}
+	}
+
+	child, err := newRequirementView(config, "Password hashing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.parent == nil || child.parent.Name != "AUTH-001" {
+		t.Errorf("parent of Password hashing = %+v", child.parent)
+	}
+}
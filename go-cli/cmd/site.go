@@ -0,0 +1,97 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// siteDataFile is the data bundle of a static site, next to its index.html
+const siteDataFile = "data.json"
+
+// SiteData is the data bundle of rqm export --format site: what the web
+// UI would otherwise ask rqm serve for, rendered ahead of time
+type SiteData struct {
+	SchemaVersion int                      `json:"schema_version"`
+	File          string                   `json:"file"`
+	Requirements  *model.RequirementConfig `json:"requirements"`
+	Graph         D3Graph                  `json:"graph"`
+	Layout        *GraphLayout             `json:"layout"`
+}
+
+// siteData renders the data bundle for config, read from file
+func siteData(config *model.RequirementConfig, file string) *SiteData {
+	return &SiteData{
+		SchemaVersion: apiVersion,
+		File:          filepath.Base(file),
+		Requirements:  config,
+		Graph:         dependencyGraph(config),
+		Layout:        graphLayout(config, "layered", nil),
+	}
+}
+
+// withSiteData points index.html at the data bundle, which tells the UI
+// it is a static site with no server to ask
+func withSiteData(page []byte) []byte {
+	return insertAfterTag(page, "<head", []byte(`<meta name="rqm-data" content="`+html.EscapeString(siteDataFile)+`">`))
+}
+
+// runSiteExport writes the web UI in files and the data bundle of config
+// to dir as a static site, which any static host, GitHub Pages included,
+// can serve
+func runSiteExport(config *model.RequirementConfig, file, dir string, files fs.FS) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	written := 0
+	err := fs.WalkDir(files, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if d.IsDir() {
+			return os.MkdirAll(path, 0755)
+		}
+		data, err := fs.ReadFile(files, name)
+		if err != nil {
+			return err
+		}
+		if name == "index.html" {
+			data = withSiteData(data)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(siteData(config, file), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, siteDataFile), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", siteDataFile, err)
+	}
+	// Without it GitHub Pages runs the site through Jekyll, which drops
+	// files starting with an underscore
+	if err := os.WriteFile(filepath.Join(dir, ".nojekyll"), nil, 0644); err != nil {
+		return fmt.Errorf("failed to write .nojekyll: %w", err)
+	}
+
+	fmt.Printf("%s Wrote a static site of %d requirement(s) to %s (%d file(s) plus %s)\n", okMark(), len(config.Flatten()), dir, written, siteDataFile)
+	return nil
+}
@@ -0,0 +1,60 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestRunSiteExport(t *testing.T) {
+	config, err := model.ParseYAML([]byte(cycleGraphYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := fstest.MapFS{
+		"index.html":      {Data: []byte(`<html><head><script src="./assets/index.js"></script></head><body></body></html>`)},
+		"assets/index.js": {Data: []byte("console.log(1)")},
+	}
+	dir := filepath.Join(t.TempDir(), "public")
+	if err := runSiteExport(config, "requirements.yml", dir, files); err != nil {
+		t.Fatal(err)
+	}
+
+	page, _ := os.ReadFile(filepath.Join(dir, "index.html"))
+	if !strings.Contains(string(page), `<head><meta name="rqm-data" content="data.json"><script src="./assets/index.js">`) {
+		t.Errorf("index.html:\n%s", page)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "assets", "index.js")); string(data) != "console.log(1)" {
+		t.Errorf("assets/index.js: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".nojekyll")); err != nil {
+		t.Error(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bundle SiteData
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		t.Fatal(err)
+	}
+	if bundle.File != "requirements.yml" || len(bundle.Requirements.Requirements) != 3 {
+		t.Errorf("requirements: %s, %+v", bundle.File, bundle.Requirements)
+	}
+	if len(bundle.Graph.Nodes) != 3 || len(bundle.Graph.Cycles) != 1 {
+		t.Errorf("graph: %+v", bundle.Graph)
+	}
+	if bundle.Layout.Algorithm != "layered" || len(bundle.Layout.Nodes) != 3 {
+		t.Errorf("layout: %+v", bundle.Layout)
+	}
+}
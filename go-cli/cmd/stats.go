@@ -0,0 +1,259 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var statsFormat string
+
+// EstimateTotals adds up the estimates of a set of requirements. Remaining
+// is the part of Total not yet implemented.
+type EstimateTotals struct {
+	Total       float64 `json:"total"`
+	Remaining   float64 `json:"remaining"`
+	Estimated   int     `json:"estimated"`
+	Unestimated int     `json:"unestimated"`
+}
+
+// add counts req, which may have no estimate, into t
+func (t *EstimateTotals) add(req *model.RequirementDetail) {
+	if req.Estimate == 0 {
+		t.Unestimated++
+		return
+	}
+	t.Estimated++
+	t.Total += req.Estimate
+	if !pastStatus(req.Status, "implemented") {
+		t.Remaining += req.Estimate
+	}
+}
+
+// SubtreeEstimate is the estimate rollup of a top-level requirement and
+// all its sub-requirements
+type SubtreeEstimate struct {
+	Summary string `json:"summary"`
+	Name    string `json:"name,omitempty"`
+	EstimateTotals
+}
+
+// MilestoneEstimate is the estimate rollup of the requirements tagged for
+// a milestone
+type MilestoneEstimate struct {
+	Milestone string `json:"milestone"`
+	EstimateTotals
+}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [file]",
+	Short: "Count requirements and roll up their estimates per subtree and milestone",
+	Long: `Count the requirements of a file by status and priority, and add up
+their estimate fields for planning:
+
+  - summary: PDF export
+    status: approved
+    estimate: 5
+    tags: [milestone:2.0]
+
+Estimates are in whatever unit the project plans in, story points or
+days. Totals are given for the whole file, for each top-level
+requirement with all its sub-requirements, and for each milestone, the
+requirements tagged milestone:<name> as for rqm gate. Each total comes
+with the part not yet implemented (remaining) and the number of
+requirements without an estimate, which the total leaves out. Deprecated
+requirements are counted by status but left out of the estimates.
+
+With --max-memory, requirements are streamed from the parser and counted
+one top-level requirement at a time instead of loading the whole file.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm stats
+  rqm stats requirements.yml --format json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		var stats StatsOutput
+		if memoryBudget > 0 {
+			if stats, err = streamRequirementStats(file); err != nil {
+				return err
+			}
+		} else {
+			config, _, err := loadRequirements(file)
+			if err != nil {
+				return err
+			}
+			stats = requirementStats(config)
+		}
+		stats.File = fileLabel(file)
+
+		switch statsFormat {
+		case "text":
+			writeStats(os.Stdout, stats)
+			return nil
+		case "json":
+			return writeJSON(stats)
+		default:
+			return fmt.Errorf("unknown output format: %s", statsFormat)
+		}
+	},
+}
+
+// requirementStats counts the requirements of config and rolls up their
+// estimates
+func requirementStats(config *model.RequirementConfig) StatsOutput {
+	counter := newStatsCounter()
+	for _, root := range config.Roots() {
+		counter.add(root)
+	}
+	return counter.result()
+}
+
+// streamRequirementStats is requirementStats for --max-memory: the
+// requirements of file are counted one top-level requirement at a time as
+// they are decoded, instead of loading the whole file first
+func streamRequirementStats(file string) (StatsOutput, error) {
+	counter := newStatsCounter()
+	err := streamRequirementsFile(file, nil, func(root *model.RequirementDetail) error {
+		counter.add(root)
+		return nil
+	})
+	return counter.result(), err
+}
+
+// statsCounter adds up StatsOutput a top-level requirement at a time
+type statsCounter struct {
+	stats      StatsOutput
+	milestones map[string]*EstimateTotals
+}
+
+func newStatsCounter() *statsCounter {
+	return &statsCounter{
+		stats: StatsOutput{
+			SchemaVersion: apiVersion,
+			ByStatus:      make(map[string]int),
+			ByPriority:    make(map[string]int),
+			Subtrees:      []SubtreeEstimate{},
+			Milestones:    []MilestoneEstimate{},
+		},
+		milestones: make(map[string]*EstimateTotals),
+	}
+}
+
+// add counts root and its sub-requirements, rolling up their estimates
+// into root's subtree
+func (c *statsCounter) add(root *model.RequirementDetail) {
+	subtree := SubtreeEstimate{Summary: root.Summary, Name: root.Name}
+	for _, req := range root.Flatten() {
+		c.stats.Requirements++
+		c.stats.ByStatus[statusOrNone(req.Status)]++
+		if req.Priority != "" {
+			c.stats.ByPriority[req.Priority]++
+		}
+		if req.IsDeprecated() {
+			continue
+		}
+		c.stats.Estimate.add(req)
+		subtree.add(req)
+		for _, tag := range req.Tags {
+			if name, ok := strings.CutPrefix(tag, milestoneTagPrefix); ok {
+				if c.milestones[name] == nil {
+					c.milestones[name] = &EstimateTotals{}
+				}
+				c.milestones[name].add(req)
+			}
+		}
+	}
+	c.stats.Subtrees = append(c.stats.Subtrees, subtree)
+}
+
+// result returns the counts of the requirements added so far
+func (c *statsCounter) result() StatsOutput {
+	stats := c.stats
+	stats.Milestones = []MilestoneEstimate{}
+	for _, name := range sortedKeys(c.milestones) {
+		stats.Milestones = append(stats.Milestones, MilestoneEstimate{Milestone: name, EstimateTotals: *c.milestones[name]})
+	}
+	return stats
+}
+
+// writeStats prints stats for the terminal
+func writeStats(w io.Writer, stats StatsOutput) {
+	fmt.Fprintf(w, "%s: %d requirement(s)\n", stats.File, stats.Requirements)
+
+	fmt.Fprintln(w, "\nBy status:")
+	for _, status := range append(requirementStatuses, "no status") {
+		if n := stats.ByStatus[status]; n > 0 {
+			fmt.Fprintf(w, "  %-13s %d\n", status, n)
+		}
+	}
+	if len(stats.ByPriority) > 0 {
+		fmt.Fprintln(w, "\nBy priority:")
+		for _, priority := range []string{"critical", "high", "medium", "low"} {
+			if n := stats.ByPriority[priority]; n > 0 {
+				fmt.Fprintf(w, "  %-13s %d\n", priority, n)
+			}
+		}
+	}
+
+	if stats.Estimate.Estimated == 0 {
+		fmt.Fprintln(w, "\nNo requirement has an estimate.")
+		return
+	}
+	fmt.Fprintf(w, "\nEstimate: %s\n", formatTotals(stats.Estimate))
+	fmt.Fprintln(w, "\nBy subtree:")
+	for _, s := range stats.Subtrees {
+		if s.Estimated > 0 {
+			label := displayName(&model.RequirementDetail{Summary: s.Summary, Name: s.Name})
+			fmt.Fprintf(w, "  %s: %s\n", label, formatTotals(s.EstimateTotals))
+		}
+	}
+	if len(stats.Milestones) > 0 {
+		fmt.Fprintln(w, "\nBy milestone:")
+		for _, m := range stats.Milestones {
+			fmt.Fprintf(w, "  %s: %s\n", m.Milestone, formatTotals(m.EstimateTotals))
+		}
+	}
+}
+
+// formatTotals describes an estimate rollup on one line
+func formatTotals(t EstimateTotals) string {
+	line := fmt.Sprintf("%s total, %s remaining", formatEstimate(t.Total), formatEstimate(t.Remaining))
+	if t.Unestimated > 0 {
+		line += fmt.Sprintf(" (%d of %d requirement(s) unestimated)", t.Unestimated, t.Estimated+t.Unestimated)
+	}
+	return line
+}
+
+// formatEstimate shows an estimate without trailing zeros
+func formatEstimate(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatOptionalEstimate shows a requirement's estimate, or nothing when
+// it has none
+func formatOptionalEstimate(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return formatEstimate(v)
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().StringVarP(&statsFormat, "format", "f", "text", "Output format (text, json)")
+}
@@ -0,0 +1,104 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const statsYAML = `version: "1.0"
+requirements:
+  - summary: Export
+    name: EXP
+    status: approved
+    priority: high
+    estimate: 2
+    requirements:
+      - summary: PDF export
+        status: implemented
+        estimate: 5
+        tags: [milestone:2.0]
+      - summary: DOCX export
+        status: draft
+        estimate: 3.5
+        tags: [milestone:2.0]
+      - summary: RTF export
+        deprecated: true
+        estimate: 8
+  - summary: Dark mode
+    priority: low
+    tags: [milestone:3.0]
+`
+
+func TestRequirementStats(t *testing.T) {
+	config, err := model.ParseYAML([]byte(statsYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := requirementStats(config)
+	if stats.Requirements != 5 || stats.ByStatus["no status"] != 2 || stats.ByPriority["high"] != 1 {
+		t.Errorf("counts = %d, %v, %v", stats.Requirements, stats.ByStatus, stats.ByPriority)
+	}
+	// The deprecated RTF export is left out, and Dark mode has no estimate
+	if want := (EstimateTotals{Total: 10.5, Remaining: 5.5, Estimated: 3, Unestimated: 1}); stats.Estimate != want {
+		t.Errorf("estimate = %+v, want %+v", stats.Estimate, want)
+	}
+	if len(stats.Subtrees) != 2 || stats.Subtrees[0].Total != 10.5 || stats.Subtrees[1].Unestimated != 1 {
+		t.Errorf("subtrees = %+v", stats.Subtrees)
+	}
+	if len(stats.Milestones) != 2 || stats.Milestones[0] != (MilestoneEstimate{"2.0", EstimateTotals{Total: 8.5, Remaining: 3.5, Estimated: 2}}) {
+		t.Errorf("milestones = %+v", stats.Milestones)
+	}
+
+	var out strings.Builder
+	writeStats(&out, stats)
+	for _, want := range []string{
+		"  approved      1\n",
+		"Estimate: 10.5 total, 5.5 remaining (1 of 4 requirement(s) unestimated)\n",
+		"  [EXP] Export: 10.5 total, 5.5 remaining\n",
+		"  2.0: 8.5 total, 3.5 remaining\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("stats missing %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Dark mode") {
+		t.Errorf("stats list a subtree without estimates:\n%s", out.String())
+	}
+}
+
+func TestStreamRequirementStats(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	if err := os.WriteFile(file, []byte(statsYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := model.ParseYAML([]byte(statsYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	streamed, err := streamRequirementStats(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := requirementStats(config); !reflect.DeepEqual(streamed, want) {
+		t.Errorf("streamed stats = %+v, want %+v", streamed, want)
+	}
+}
+
+func TestEstimateColumn(t *testing.T) {
+	column := builtinColumns["estimate"]
+	if got := column.value(&model.RequirementDetail{Estimate: 2.5}); got != "2.5" {
+		t.Errorf("estimate column = %q", got)
+	}
+	if got := column.value(&model.RequirementDetail{}); got != "" {
+		t.Errorf("estimate column without an estimate = %q", got)
+	}
+}
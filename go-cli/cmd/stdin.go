@@ -0,0 +1,104 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// stdinArg is the file argument that reads the requirements from stdin
+const stdinArg = "-"
+
+// stdinFilename is the --filename of validate, list and check: the name
+// requirements read from stdin are shown under
+var stdinFilename string
+
+// fileLabels maps the copies of stdin and of remote files to the names
+// shown for them
+var fileLabels = make(map[string]string)
+
+// fileLabel returns the name to show for file: the --filename of a copy of
+// stdin, the argument naming a remote file, or file itself
+func fileLabel(file string) string {
+	if label, ok := fileLabels[file]; ok {
+		return label
+	}
+	return file
+}
+
+// labeledError shows the name of a copy of stdin or of a remote file in
+// place of its path, keeping the exit code of the error it wraps
+type labeledError struct {
+	err   error
+	path  string
+	label string
+}
+
+func (e *labeledError) Error() string {
+	return strings.ReplaceAll(e.err.Error(), e.path, e.label)
+}
+
+func (e *labeledError) Unwrap() error { return e.err }
+
+// withFileArg runs fn on the file a file argument names: file itself, the
+// downloaded copy of a remote file, or a copy of stdin when file is "-".
+// Commands read requirements by path, so stdin is copied to a hidden file
+// in the directory of --filename, or the current directory, where
+// .rqm/config.yml and includes resolve as they would for the real file.
+// The extension of --filename gives the format, YAML by default.
+func withFileArg(file string, fn func(string) error) error {
+	if isRemoteFile(file) {
+		path, err := fetchRemoteFile(file)
+		if err != nil {
+			return err
+		}
+		if err := fn(path); err != nil {
+			return &labeledError{err: err, path: path, label: file}
+		}
+		return nil
+	}
+	if file != stdinArg {
+		return fn(file)
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read stdin: %w", err)
+	}
+
+	label, ext, dir := "<stdin>", ".yml", "."
+	if stdinFilename != "" {
+		label, dir = stdinFilename, filepath.Dir(stdinFilename)
+		if e := filepath.Ext(stdinFilename); e != "" {
+			ext = e
+		}
+	}
+	tmp, err := os.CreateTemp(dir, ".rqm-stdin-*"+ext)
+	if err != nil {
+		// A read-only checkout still validates, without its project config
+		if tmp, err = os.CreateTemp("", "rqm-stdin-*"+ext); err != nil {
+			return fmt.Errorf("failed to create temp file: %w", err)
+		}
+	}
+	path := tmp.Name()
+	defer os.Remove(path)
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	fileLabels[path] = label
+	defer delete(fileLabels, path)
+	if err := fn(path); err != nil {
+		return &labeledError{err: err, path: path, label: label}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setStdin makes os.Stdin read content for the rest of the test
+func setStdin(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		f.Close()
+	})
+}
+
+func TestWithFileArg(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	content := "version: \"1.0\"\nrequirements:\n  - summary: Piped\n"
+
+	t.Run("plain file", func(t *testing.T) {
+		var got string
+		if err := withFileArg("requirements.yml", func(file string) error {
+			got = file
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if got != "requirements.yml" {
+			t.Errorf("fn got %q, want the file itself", got)
+		}
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		setStdin(t, content)
+		stdinFilename = ""
+		var path string
+		err := withFileArg("-", func(file string) error {
+			path = file
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			if string(data) != content {
+				t.Errorf("copy holds %q, want %q", data, content)
+			}
+			if filepath.Ext(file) != ".yml" || filepath.Dir(file) != "." {
+				t.Errorf("copy is %s, want a .yml file in the current directory", file)
+			}
+			if label := fileLabel(file); label != "<stdin>" {
+				t.Errorf("fileLabel() = %q, want <stdin>", label)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("copy %s wasn't removed", path)
+		}
+		if _, ok := fileLabels[path]; ok {
+			t.Errorf("label of %s wasn't removed", path)
+		}
+	})
+
+	t.Run("filename", func(t *testing.T) {
+		if err := os.Mkdir("docs", 0755); err != nil {
+			t.Fatal(err)
+		}
+		setStdin(t, `{"version": "1.0", "requirements": []}`)
+		stdinFilename = filepath.Join("docs", "requirements.json")
+		t.Cleanup(func() { stdinFilename = "" })
+
+		err := withFileArg("-", func(file string) error {
+			if filepath.Ext(file) != ".json" || filepath.Dir(file) != "docs" {
+				t.Errorf("copy is %s, want a .json file in docs", file)
+			}
+			return validationError("%s is invalid", file)
+		})
+		if err == nil || !strings.HasPrefix(err.Error(), stdinFilename+" is invalid") {
+			t.Errorf("error = %v, want it to name %s", err, stdinFilename)
+		}
+		if code := exitCode(err); code != exitValidation {
+			t.Errorf("exit code = %d, want %d", code, exitValidation)
+		}
+	})
+}
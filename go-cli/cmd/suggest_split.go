@@ -0,0 +1,247 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	splitMax   int
+	splitWrite bool
+)
+
+// splitPart is a file a top-level requirement and its subtree would move to
+type splitPart struct {
+	root *model.RequirementDetail
+	// path is relative to the file being split, with forward slashes, as
+	// written in its includes
+	path  string
+	count int
+}
+
+var suggestSplitCmd = &cobra.Command{
+	Use:   "suggest-split [file]",
+	Short: "Propose how to split a file that is over its requirement budget",
+	Long: `Propose how to split a requirements file with more requirements than its
+budget into included files, one per top-level requirement.
+
+The budget is max_requirements_per_file in .rqm/config.yml, or --max; once
+it is set, rqm validate warns (style) about every file over it. Each
+top-level requirement moves, with its subtree, to a file named after its ID
+or summary in a directory named after the file, e.g. requirements.yml's
+"Authentication" goes to requirements/authentication.yml. The file keeps
+its aliases and lists the new files under includes:, so every command that
+loads it, validate, check and graph included, still sees all
+requirements, and references between the parts resolve through it. A
+part on its own only holds its subtree, so run those commands on the
+split file rather than on a part.
+
+Nothing is changed unless --write is given, which creates the files and
+the includes. Existing files are never overwritten.`,
+	Example: `  rqm suggest-split
+  rqm suggest-split requirements.yml --max 150
+  rqm suggest-split requirements.yml --write`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		project, err := loadProjectConfig(file)
+		if err != nil {
+			return err
+		}
+		budget := splitMax
+		if budget == 0 {
+			budget = project.MaxRequirements
+		}
+		if budget <= 0 {
+			return fmt.Errorf("no requirement budget: set max_requirements_per_file in .rqm/config.yml or pass --max")
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("file does not exist: %s", file)
+			}
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+		config, err := model.Parse(file, data)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		count := len(config.Flatten())
+		if count <= budget {
+			fmt.Printf("%s %s has %d requirement(s), within the budget of %d\n", okMark(), file, count, budget)
+			return nil
+		}
+
+		parts := planSplit(file, config)
+		fmt.Printf("%s has %d requirement(s), over the budget of %d.\n", file, count, budget)
+		fmt.Printf("Proposed split into %d file(s), one per top-level requirement:\n\n", len(parts))
+		for _, part := range parts {
+			line := fmt.Sprintf("  %s  %s (%d requirement(s))", part.path, displayName(part.root), part.count)
+			if part.count > budget {
+				line += " " + paint(ansiYellow, "over budget; split its subtree further")
+			}
+			fmt.Println(line)
+		}
+		fmt.Printf("\n%s would keep its aliases and include the new files.\n", filepath.Base(file))
+		if !splitWrite {
+			fmt.Println("Run with --write to create them.")
+			return nil
+		}
+
+		if err := writeSplit(file, data, parts); err != nil {
+			return err
+		}
+		fmt.Printf("%s Moved %d requirement(s) of %s into %d included file(s)\n", okMark(), count, file, len(parts))
+		return nil
+	},
+}
+
+// requirementBudgetWarning is the style warning for a file with more
+// requirements than budget, when a budget is set
+func requirementBudgetWarning(file string, config *model.RequirementConfig, budget int) (ValidationWarning, bool) {
+	count := len(config.Flatten())
+	if budget <= 0 || count <= budget {
+		return ValidationWarning{}, false
+	}
+	return ValidationWarning{
+		Category: "style",
+		Message:  fmt.Sprintf("%s has %d requirements, over the budget of %d per file; rqm suggest-split proposes how to split it", filepath.Base(file), count, budget),
+	}, true
+}
+
+// planSplit assigns each top-level requirement of config to its own file
+// in a directory named after file
+func planSplit(file string, config *model.RequirementConfig) []splitPart {
+	ext := filepath.Ext(file)
+	dir := strings.TrimSuffix(filepath.Base(file), ext)
+	used := make(map[string]bool)
+	var parts []splitPart
+	for _, root := range config.Roots() {
+		name := splitFileName(root)
+		unique := name
+		for i := 2; used[unique]; i++ {
+			unique = fmt.Sprintf("%s-%d", name, i)
+		}
+		used[unique] = true
+		parts = append(parts, splitPart{root: root, path: dir + "/" + unique + ext, count: len(root.Flatten())})
+	}
+	return parts
+}
+
+// splitFileName is the file name, without extension, for a top-level
+// requirement: its ID or summary in lower case, with runs of other
+// characters than letters and digits as dashes
+func splitFileName(req *model.RequirementDetail) string {
+	source := req.Name
+	if source == "" {
+		source = req.Summary
+	}
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(source) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return "requirements"
+	}
+	return b.String()
+}
+
+// writeSplit moves each top-level requirement of file, whose content is
+// data, to the file of its part and adds the parts to the file's includes.
+// The requirements are moved as YAML nodes so their comments and key
+// order survive.
+func writeSplit(file string, data []byte, parts []splitPart) error {
+	if err := editableFile(file); err != nil {
+		return err
+	}
+	doc, err := parseYAMLDocument(file, data)
+	if err != nil {
+		return err
+	}
+	root := doc.root()
+	list := mappingValue(root, "requirements")
+	if list == nil || list.Kind != yaml.SequenceNode || len(list.Content) != len(parts) {
+		return fmt.Errorf("%s has no list of top-level requirements to split", file)
+	}
+	version := "1.0"
+	if v := mappingValue(root, "version"); v != nil {
+		version = v.Value
+	}
+
+	// Nothing is written unless every part can be
+	outputs := make([][]byte, len(parts))
+	for i, part := range parts {
+		path := filepath.Join(filepath.Dir(file), filepath.FromSlash(part.path))
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+		partDoc := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: version, Style: yaml.DoubleQuotedStyle},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "requirements"},
+			{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{list.Content[i]}},
+		}}
+		out, err := doc.encodeNode(partDoc)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", path, err)
+		}
+		outputs[i] = out
+	}
+
+	list.Content = nil
+	list.Style = yaml.FlowStyle
+	includes := mappingValue(root, "includes")
+	if includes == nil {
+		includes = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		insertBeforeKey(root, "requirements", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "includes"}, includes)
+	}
+	for _, part := range parts {
+		includes.Content = append(includes.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part.path})
+	}
+	main, err := doc.encode()
+	if err != nil {
+		return err
+	}
+
+	for i, part := range parts {
+		path := filepath.Join(filepath.Dir(file), filepath.FromSlash(part.path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, outputs[i], 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return writeRequirementsFile(file, data, main)
+}
+
+func init() {
+	rootCmd.AddCommand(suggestSplitCmd)
+	suggestSplitCmd.Flags().IntVar(&splitMax, "max", 0, "Requirement budget per file (default: max_requirements_per_file in .rqm/config.yml)")
+	suggestSplitCmd.Flags().BoolVar(&splitWrite, "write", false, "Create the included files and move the requirements into them")
+}
@@ -0,0 +1,165 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const splitYAML = `version: "1.0"
+aliases:
+  - alias: alice
+    email: alice@example.com
+requirements:
+  # Sign-in and sessions
+  - summary: Authentication
+    name: AUTH-001
+    owner: "@alice"
+    requirements:
+      - summary: Password hashing
+      - Audit log
+  - summary: Audit log
+  - summary: Audit log!
+`
+
+func TestPlanSplit(t *testing.T) {
+	config, err := model.ParseYAML([]byte(splitYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, part := range planSplit(filepath.Join("docs", "requirements.yml"), config) {
+		got = append(got, part.path+"="+part.root.Summary)
+	}
+	want := []string{"requirements/auth-001.yml=Authentication", "requirements/audit-log.yml=Audit log", "requirements/audit-log-2.yml=Audit log!"}
+	if strings.Join(got, " | ") != strings.Join(want, " | ") {
+		t.Errorf("planSplit() = %q, want %q", got, want)
+	}
+
+	if _, over := requirementBudgetWarning("requirements.yml", config, 4); over {
+		t.Error("Expected no warning for a file within its budget")
+	}
+	if w, over := requirementBudgetWarning("requirements.yml", config, 3); !over || w.Category != "style" || !strings.Contains(w.Message, "4 requirements, over the budget of 3") {
+		t.Errorf("Expected a budget warning, got %+v", w)
+	}
+}
+
+func TestWriteSplit(t *testing.T) {
+	validator := findValidatorBinary()
+	if validator == "" {
+		t.Skip("rqm-validator not built")
+	}
+	t.Setenv("RQM_VALIDATOR", validator)
+
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	if err := os.WriteFile(file, []byte(splitYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := model.ParseYAML([]byte(splitYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := planSplit(file, config)
+	if err := writeSplit(file, []byte(splitYAML), parts); err != nil {
+		t.Fatal(err)
+	}
+
+	main, _ := os.ReadFile(file)
+	if !strings.Contains(string(main), "includes:\n  - requirements/auth-001.yml\n") || !strings.Contains(string(main), "requirements: []") {
+		t.Errorf("Expected the file to include the parts:\n%s", main)
+	}
+	part, _ := os.ReadFile(filepath.Join(filepath.Dir(file), "requirements", "auth-001.yml"))
+	if !strings.Contains(string(part), "# Sign-in and sessions") || !strings.Contains(string(part), "- Audit log") {
+		t.Errorf("Expected the subtree with its comment:\n%s", part)
+	}
+
+	// Loading the file brings the parts back, with references between them
+	loaded, _, err := loadRequirements(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(summaries(loaded.Flatten()), ","); got != "Authentication,Password hashing,Audit log,Audit log!" {
+		t.Errorf("Expected every requirement after the split, got %s", got)
+	}
+	if _, err := loaded.Cycles(); err != nil {
+		t.Errorf("Expected the reference to resolve across files: %v", err)
+	}
+
+	// Parts are never overwritten
+	if err := writeSplit(file, []byte(splitYAML), parts); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected an error for existing parts, got %v", err)
+	}
+}
+
+func TestResolveIncludesCycle(t *testing.T) {
+	validator := findValidatorBinary()
+	if validator == "" {
+		t.Skip("rqm-validator not built")
+	}
+	t.Setenv("RQM_VALIDATOR", validator)
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.yml": "version: \"1.0\"\nincludes: [b.yml]\nrequirements: []\n",
+		"b.yml": "version: \"1.0\"\nincludes: [a.yml]\nrequirements: []\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, err := loadRequirements(filepath.Join(dir, "a.yml")); err == nil || !strings.Contains(err.Error(), "includes it back") {
+		t.Errorf("Expected an include cycle error, got %v", err)
+	}
+}
+
+func TestCheckSplitCycle(t *testing.T) {
+	const cyclic = `version: "1.0"
+requirements:
+  - summary: Alpha
+    requirements:
+      - Beta
+  - summary: Beta
+    requirements:
+      - summary: Gamma
+        requirements:
+          - Beta
+`
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	if err := os.WriteFile(file, []byte(cyclic), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := model.ParseYAML([]byte(cyclic))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeSplit(file, []byte(cyclic), planSplit(file, config)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The cycle now runs through two included files, and check, graph and
+	// validate still see it on the file that includes them
+	result, err := checkCycles(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.HasCycles || len(result.Cycles) != 1 || strings.Join(result.Cycles[0], ",") != "Beta,Gamma" {
+		t.Errorf("Expected the Beta/Gamma cycle after the split, got %+v", result)
+	}
+	merged, err := model.Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(summaries(merged.Flatten()), ","); got != "Alpha,Beta,Gamma" {
+		t.Errorf("Expected every requirement in the graph, got %s", got)
+	}
+	if errs := includeErrors(file); len(errs) != 1 || errs[0] != "circular reference through the included files among Beta, Gamma" {
+		t.Errorf("Unexpected validation errors: %q", errs)
+	}
+}
@@ -0,0 +1,266 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+var (
+	listColumns []string
+	noTruncate  bool
+)
+
+// defaultColumns is the column set used when --columns isn't given
+var defaultColumns = []string{"id", "summary", "owner", "priority", "status"}
+
+// fallbackTerminalWidth is used when stdout isn't a terminal and COLUMNS is unset
+const fallbackTerminalWidth = 120
+
+// minColumnWidth is the narrowest a column is shrunk to when fitting the terminal
+const minColumnWidth = 8
+
+// tableColumn describes one column of the list table
+type tableColumn struct {
+	header string
+	// width is the preferred width when rows aren't known up front
+	width int
+	value func(req *model.RequirementDetail) string
+	// color picks an ANSI color for the cell, nil for uncolored columns
+	color func(req *model.RequirementDetail) string
+}
+
+// builtinColumns are the requirement fields available to --columns. Any other
+// name is looked up in the requirement's custom attributes.
+var builtinColumns = map[string]tableColumn{
+	"id":          {header: "ID", width: 20, value: func(r *model.RequirementDetail) string { return r.Name }},
+	"summary":     {header: "Summary", width: 50, value: func(r *model.RequirementDetail) string { return r.Summary }},
+	"description": {header: "Description", width: 50, value: func(r *model.RequirementDetail) string { return r.Description }},
+	"owner":       {header: "Owner", width: 15, value: func(r *model.RequirementDetail) string { return displayOwner(ownerAliases, r.Owner) }},
+	"priority": {
+		header: "Priority", width: 12,
+		value: func(r *model.RequirementDetail) string { return r.Priority },
+		color: func(r *model.RequirementDetail) string { return priorityColor(r.Priority) },
+	},
+	"status": {
+		header: "Status", width: 15,
+		value: func(r *model.RequirementDetail) string { return r.Status },
+		color: func(r *model.RequirementDetail) string { return statusColor(r.Status) },
+	},
+	"tags":         {header: "Tags", width: 20, value: func(r *model.RequirementDetail) string { return strings.Join(r.Tags, ",") }},
+	"estimate":     {header: "Estimate", width: 8, value: func(r *model.RequirementDetail) string { return formatOptionalEstimate(r.Estimate) }},
+	"verification": {header: "Verification", width: 14, value: func(r *model.RequirementDetail) string { return r.Verification }},
+	"hash":         {header: "Hash", width: shortHashLength, value: func(r *model.RequirementDetail) string { return requirementHash(r)[:shortHashLength] }},
+}
+
+// requirementTable renders requirements as aligned columns
+type requirementTable struct {
+	columns  []tableColumn
+	widths   []int
+	truncate bool
+	// line, cells and colors are reused from row to row, so printing a
+	// row allocates nothing of its own
+	line          []byte
+	cells, colors []string
+}
+
+// newRequirementTable builds a table from --columns, followed by any
+// --attributes columns that aren't already listed
+func newRequirementTable() (*requirementTable, error) {
+	names := listColumns
+	if len(names) == 0 {
+		names = defaultColumns
+	}
+	for _, attr := range listAttributes {
+		if !containsFold(names, attr) {
+			names = append(names, attr)
+		}
+	}
+
+	t := &requirementTable{truncate: !noTruncate}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("empty column name in --columns")
+		}
+		column, ok := builtinColumns[strings.ToLower(name)]
+		if !ok {
+			column = attributeColumn(name)
+		}
+		t.columns = append(t.columns, column)
+		t.widths = append(t.widths, max(column.width, len(column.header)))
+	}
+	return t, nil
+}
+
+// attributeColumn shows a custom attribute, e.g. due_date
+func attributeColumn(name string) tableColumn {
+	return tableColumn{
+		header: name,
+		width:  15,
+		value: func(r *model.RequirementDetail) string {
+			return formatAttribute(r.Attributes[name])
+		},
+	}
+}
+
+// fit sizes each column to its content and then, unless truncation is
+// disabled, shrinks the widest columns until the table fits the terminal
+func (t *requirementTable) fit(reqs []*model.RequirementDetail, terminalWidth int) {
+	if reqs != nil {
+		for i, column := range t.columns {
+			width := utf8.RuneCountInString(column.header)
+			for _, req := range reqs {
+				width = max(width, utf8.RuneCountInString(cellValue(column, req)))
+			}
+			t.widths[i] = width
+		}
+	}
+
+	if !t.truncate || terminalWidth <= 0 {
+		return
+	}
+	for t.totalWidth() > terminalWidth {
+		widest := 0
+		for i, width := range t.widths {
+			if width > t.widths[widest] {
+				widest = i
+			}
+		}
+		if t.widths[widest] <= minColumnWidth {
+			return
+		}
+		t.widths[widest]--
+	}
+}
+
+// totalWidth is the rendered line length, including single-space separators
+func (t *requirementTable) totalWidth() int {
+	total := len(t.widths) - 1
+	for _, width := range t.widths {
+		total += width
+	}
+	return total
+}
+
+func (t *requirementTable) printHeader(w io.Writer) {
+	cells := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		cells[i] = column.header
+	}
+	t.printCells(w, cells, nil)
+	fmt.Fprintln(w, strings.Repeat("-", t.totalWidth()))
+}
+
+func (t *requirementTable) printRow(w io.Writer, req *model.RequirementDetail) {
+	if t.cells == nil {
+		t.cells = make([]string, len(t.columns))
+		t.colors = make([]string, len(t.columns))
+	}
+	for i, column := range t.columns {
+		t.cells[i] = cellValue(column, req)
+		t.colors[i] = ""
+		if column.color != nil {
+			t.colors[i] = column.color(req)
+		}
+	}
+	t.printCells(w, t.cells, t.colors)
+}
+
+// printCells pads each cell before coloring it so escape codes don't
+// count towards the column width. The line is written in one go.
+func (t *requirementTable) printCells(w io.Writer, cells, colors []string) {
+	line := t.line[:0]
+	for i, cell := range cells {
+		if t.truncate {
+			cell = truncateCell(cell, t.widths[i])
+		}
+		if i > 0 {
+			line = append(line, ' ')
+		}
+		padding := ""
+		if i < len(cells)-1 {
+			padding = blanks(t.widths[i] - utf8.RuneCountInString(cell))
+		}
+		// paint(color, cell+padding), without joining the two
+		color := ""
+		if colors != nil && colorEnabled {
+			color = colors[i]
+		}
+		if color != "" {
+			line = append(line, color...)
+		}
+		line = append(line, cell...)
+		line = append(line, padding...)
+		if color != "" {
+			line = append(line, ansiReset...)
+		}
+	}
+	line = append(line, '\n')
+	w.Write(line)
+	t.line = line
+}
+
+// spaces backs blanks for the usual column widths
+const spaces = "                                                                                "
+
+// blanks returns n spaces, or "" for n <= 0
+func blanks(n int) string {
+	switch {
+	case n <= 0:
+		return ""
+	case n <= len(spaces):
+		return spaces[:n]
+	}
+	return strings.Repeat(" ", n)
+}
+
+// cellValue returns the column value, or "-" when it's empty
+func cellValue(column tableColumn, req *model.RequirementDetail) string {
+	if value := column.value(req); value != "" {
+		return value
+	}
+	return "-"
+}
+
+// truncateCell shortens s to width runes, marking the cut with "..."
+func truncateCell(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// terminalWidth returns the width available for table output. COLUMNS
+// takes precedence over the size of the terminal attached to stdout.
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if width := stdoutTerminalWidth(); width > 0 {
+		return width
+	}
+	return fallbackTerminalWidth
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(strings.TrimSpace(value), target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestRequirementTableColumns(t *testing.T) {
+	defer func() { listColumns, listAttributes, noTruncate = nil, nil, false }()
+
+	reqs := []*model.RequirementDetail{
+		{
+			Name:       "REQ-1",
+			Summary:    "A summary that is rather long for a narrow terminal window",
+			Status:     "draft",
+			Tags:       []string{"api", "security"},
+			Attributes: map[string]interface{}{"due_date": "2025-06-01", "risk": "high"},
+		},
+		{Name: "REQ-2", Summary: "Short"},
+	}
+
+	tests := []struct {
+		name       string
+		columns    []string
+		attributes []string
+		noTruncate bool
+		width      int
+		wantHeader []string
+		want       []string
+		notWant    []string
+	}{
+		{
+			name:       "selected columns with attribute",
+			columns:    []string{"id", "status", "tags", "due_date"},
+			width:      200,
+			wantHeader: []string{"ID", "Status", "Tags", "due_date"},
+			want:       []string{"api,security", "2025-06-01", "REQ-2 -"},
+			notWant:    []string{"Summary"},
+		},
+		{
+			name:       "attributes are appended once",
+			columns:    []string{"id", "risk"},
+			attributes: []string{"risk", "due_date"},
+			width:      200,
+			wantHeader: []string{"ID", "risk", "due_date"},
+		},
+		{
+			name:    "shrinks to terminal width",
+			columns: []string{"id", "summary"},
+			width:   30,
+			want:    []string{"..."},
+			notWant: []string{"narrow terminal window"},
+		},
+		{
+			name:       "no truncate",
+			columns:    []string{"id", "summary"},
+			noTruncate: true,
+			width:      30,
+			want:       []string{"narrow terminal window"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listColumns, listAttributes, noTruncate = tt.columns, tt.attributes, tt.noTruncate
+
+			table, err := newRequirementTable()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			table.fit(reqs, tt.width)
+
+			var buf bytes.Buffer
+			table.printHeader(&buf)
+			for _, req := range reqs {
+				table.printRow(&buf, req)
+			}
+			output := buf.String()
+			header := strings.SplitN(output, "\n", 2)[0]
+
+			if !tt.noTruncate {
+				for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+					if len([]rune(line)) > tt.width {
+						t.Errorf("Line exceeds %d columns: %q", tt.width, line)
+					}
+				}
+			}
+			for _, want := range tt.wantHeader {
+				if !strings.Contains(header, want) {
+					t.Errorf("Expected header %q in %q", want, header)
+				}
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(strings.Join(strings.Fields(output), " "), want) {
+					t.Errorf("Expected %q in output:\n%s", want, output)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(output, notWant) {
+					t.Errorf("Did not expect %q in output:\n%s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
+func TestRequirementTableEmptyColumn(t *testing.T) {
+	defer func() { listColumns = nil }()
+
+	listColumns = []string{"id", " "}
+	if _, err := newRequirementTable(); err == nil {
+		t.Error("Expected error for empty column name")
+	}
+}
+
+func TestTruncateCell(t *testing.T) {
+	tests := []struct {
+		input string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly10!", 10, "exactly10!"},
+		{"much longer value", 10, "much lo..."},
+		{"héllo wörld", 8, "héllo..."},
+		{"abcdef", 3, "abc"},
+	}
+
+	for _, tt := range tests {
+		if got := truncateCell(tt.input, tt.width); got != tt.want {
+			t.Errorf("truncateCell(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// minTypoTagLength is the shortest tag compared with others for typos;
+// shorter ones like ui and ux differ by a letter on purpose
+const minTypoTagLength = 5
+
+// tagUsage is a tag and the number of requirements using it
+type tagUsage struct {
+	tag   string
+	count int
+}
+
+var tagsCmd = &cobra.Command{
+	Use:   "tags [file]",
+	Short: "List the tags in use with how many requirements use each",
+	Long: `List every tag used in a requirements file, most used first, with the
+number of requirements using it.
+
+A project can declare its tag taxonomy in .rqm/config.yml. Tags may be
+hierarchical, separated by slashes, and declaring security/authz declares
+security too. An entry ending in * allows every tag starting with what
+comes before it:
+
+  tags:
+    - security/authz
+    - security/crypto
+    - ui
+    - milestone:*
+
+With a taxonomy, rqm validate reports a tag it doesn't declare as an
+error, suggesting the declared tag it's closest to, and rqm tags marks it.
+Whether or not there's a taxonomy, tags that differ from a more used one
+only in case, separators or a single typo (Security, sec-urity,
+securty) are consistency warnings, and rqm tags marks them as variants.
+Siblings in a hierarchy (security/authn, security/authz), numbers (v1,
+v2) and prefix:value tags (milestone:2.0) aren't taken for typos.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm tags
+  rqm tags requirements.yml`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+		project, err := loadProjectConfig(file)
+		if err != nil {
+			return err
+		}
+		writeTags(os.Stdout, tagUsages(config.Flatten()), project.Tags)
+		return nil
+	},
+}
+
+// tagUsages counts the requirements using each tag, most used first
+func tagUsages(all []*model.RequirementDetail) []tagUsage {
+	counts := make(map[string]int)
+	for _, req := range all {
+		seen := make(map[string]bool)
+		for _, tag := range req.Tags {
+			if !seen[tag] {
+				counts[tag]++
+				seen[tag] = true
+			}
+		}
+	}
+	usages := make([]tagUsage, 0, len(counts))
+	for _, tag := range sortedKeys(counts) {
+		usages = append(usages, tagUsage{tag, counts[tag]})
+	}
+	sort.SliceStable(usages, func(i, j int) bool { return usages[i].count > usages[j].count })
+	return usages
+}
+
+// writeTags lists usages, marking tags the taxonomy doesn't declare and
+// variants of more used tags
+func writeTags(w io.Writer, usages []tagUsage, taxonomy []string) {
+	if len(usages) == 0 {
+		fmt.Fprintln(w, "No requirement has tags")
+		return
+	}
+	variants := tagVariants(usages)
+	width := 0
+	for _, u := range usages {
+		width = max(width, len(u.tag))
+	}
+	for _, u := range usages {
+		var notes []string
+		if len(taxonomy) > 0 && !tagDeclared(u.tag, taxonomy) {
+			notes = append(notes, "not declared")
+		}
+		if canonical, ok := variants[u.tag]; ok {
+			notes = append(notes, "variant of "+canonical)
+		}
+		line := fmt.Sprintf("%5d  %-*s", u.count, width, u.tag)
+		if len(notes) > 0 {
+			line += "  " + paint(ansiYellow, "("+strings.Join(notes, ", ")+")")
+		}
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+	fmt.Fprintf(w, "\n%d tag(s)\n", len(usages))
+}
+
+// tagDeclared reports whether the taxonomy declares tag: listed, the
+// ancestor of a listed tag, or matched by an entry ending in *
+func tagDeclared(tag string, taxonomy []string) bool {
+	for _, entry := range taxonomy {
+		if prefix, ok := strings.CutSuffix(entry, "*"); ok {
+			if strings.HasPrefix(tag, prefix) {
+				return true
+			}
+			continue
+		}
+		if tag == entry || strings.HasPrefix(entry, tag+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// tagVariants maps each tag that looks like a variant of a more used one,
+// differing in case, separators or a single typo, to that tag. Usages are
+// most used first, so the first of a group is the one the others vary.
+func tagVariants(usages []tagUsage) map[string]string {
+	variants := make(map[string]string)
+	for i, u := range usages {
+		if _, ok := variants[u.tag]; ok {
+			continue
+		}
+		for _, other := range usages[i+1:] {
+			if _, ok := variants[other.tag]; !ok && similarTags(u.tag, other.tag) {
+				variants[other.tag] = u.tag
+			}
+		}
+	}
+	return variants
+}
+
+// similarTags reports whether two distinct tags are likely the same one
+// spelled differently. Tags are compared a hierarchy segment at a time:
+// segments may differ in case or separators, and one segment by a single
+// typo, except the last of a hierarchy, where security/authn and
+// security/authz are siblings rather than a typo. Segments that only
+// differ in digits, such as v1 and v2, are different tags, and so are
+// prefix:value tags such as milestone:2.0 and milestone:2.1.
+func similarTags(a, b string) bool {
+	if a == b || strings.Contains(a, ":") || strings.Contains(b, ":") {
+		return false
+	}
+	sa, sb := strings.Split(normalizeTag(a), "/"), strings.Split(normalizeTag(b), "/")
+	if len(sa) != len(sb) {
+		return false
+	}
+	typos := 0
+	for i := range sa {
+		if sa[i] == sb[i] {
+			continue
+		}
+		leaf := len(sa) > 1 && i == len(sa)-1
+		if leaf || typos > 0 || !typoVariant(sa[i], sb[i]) {
+			return false
+		}
+		typos++
+	}
+	return true
+}
+
+// typoVariant reports whether two normalized tag segments differ by a
+// single typo, other than in their digits
+func typoVariant(a, b string) bool {
+	if len(a) < minTypoTagLength || len(b) < minTypoTagLength || editDistance(a, b) != 1 {
+		return false
+	}
+	dropDigits := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+	return dropDigits(a) != dropDigits(b)
+}
+
+// normalizeTag lowercases a tag and drops separators inside its words,
+// keeping the slashes of a hierarchy
+func normalizeTag(tag string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == ' ' || r == '.' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(tag))
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+// tagTaxonomyErrors reports the tags of requirements the taxonomy doesn't
+// declare, with the closest declared tag when there's one
+func tagTaxonomyErrors(config *model.RequirementConfig, taxonomy []string) []string {
+	var errs []string
+	for _, req := range config.Flatten() {
+		for _, tag := range req.Tags {
+			if tagDeclared(tag, taxonomy) {
+				continue
+			}
+			msg := fmt.Sprintf("Requirement '%s' has the tag '%s', which the tags: taxonomy in .rqm/config.yml doesn't declare", req.Summary, tag)
+			for _, entry := range taxonomy {
+				if !strings.HasSuffix(entry, "*") && similarTags(entry, tag) {
+					msg += fmt.Sprintf(" (did you mean '%s'?)", entry)
+					break
+				}
+			}
+			errs = append(errs, msg)
+		}
+	}
+	return errs
+}
+
+// tagVariantWarnings flags requirements using a variant of a more used
+// tag
+func tagVariantWarnings(all []*model.RequirementDetail) []ValidationWarning {
+	usages := tagUsages(all)
+	variants := tagVariants(usages)
+	if len(variants) == 0 {
+		return nil
+	}
+	counts := make(map[string]int, len(usages))
+	for _, u := range usages {
+		counts[u.tag] = u.count
+	}
+	var warnings []ValidationWarning
+	for _, req := range all {
+		for _, tag := range req.Tags {
+			if canonical, ok := variants[tag]; ok {
+				warnings = append(warnings, ValidationWarning{
+					Category:    "consistency",
+					Requirement: req.Summary,
+					Message:     fmt.Sprintf("Requirement '%s' has the tag '%s', which looks like a variant of '%s' (used by %d requirement(s))", req.Summary, tag, canonical, counts[canonical]),
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+}
@@ -0,0 +1,105 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const tagsYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    tags: [security, security/authz]
+  - summary: Password hashing
+    tags: [security, crypto]
+  - summary: Audit log
+    tags: [Security, milestone:2.0]
+  - summary: Session timeout
+    tags: [securty, ui]
+`
+
+func TestTagUsages(t *testing.T) {
+	config, _ := model.ParseYAML([]byte(tagsYAML))
+	usages := tagUsages(config.Flatten())
+	if usages[0] != (tagUsage{"security", 2}) || len(usages) != 7 {
+		t.Errorf("usages = %v", usages)
+	}
+	variants := tagVariants(usages)
+	if len(variants) != 2 || variants["Security"] != "security" || variants["securty"] != "security" {
+		t.Errorf("variants = %v", variants)
+	}
+
+	var out strings.Builder
+	writeTags(&out, usages, []string{"security/authz", "ui", "milestone:*"})
+	for _, want := range []string{
+		"    2  security\n",
+		"    1  Security        (not declared, variant of security)\n",
+		"    1  crypto          (not declared)\n",
+		"    1  milestone:2.0\n",
+		"\n7 tag(s)\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("tags missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestSimilarTags(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want bool
+	}{
+		{"security", "Security", true},
+		{"front-end", "frontend", true},
+		{"security", "securty", true},
+		{"security/authz", "security/authn", false},
+		{"securty/authz", "security/authz", true},
+		{"Security/Auth-Z", "security/authz", true},
+		{"securty/authn", "security/authz", false},
+		{"security/authz", "security", false},
+		{"milestone:2.1", "milestone:2.0", false},
+		{"Milestone:2.0", "milestone:2.0", false},
+		{"release2024", "release2025", false},
+		{"release1", "releases1", true},
+		{"ui", "ux", false},
+		{"security", "secure", false},
+		{"api", "api", false},
+	} {
+		if got := similarTags(tc.a, tc.b); got != tc.want {
+			t.Errorf("similarTags(%q, %q) = %v", tc.a, tc.b, got)
+		}
+	}
+}
+
+func TestTagTaxonomyErrors(t *testing.T) {
+	config, _ := model.ParseYAML([]byte(tagsYAML))
+	errs := tagTaxonomyErrors(config, []string{"security/authz", "crypto", "ui", "milestone:*"})
+	want := []string{
+		"Requirement 'Audit log' has the tag 'Security', which the tags: taxonomy in .rqm/config.yml doesn't declare",
+		"Requirement 'Session timeout' has the tag 'securty', which the tags: taxonomy in .rqm/config.yml doesn't declare",
+	}
+	if len(errs) != 2 || errs[0] != want[0] || errs[1] != want[1] {
+		t.Errorf("errors = %q", errs)
+	}
+	errs = tagTaxonomyErrors(config, []string{"security", "security/authz", "crypto", "ui", "milestone:*"})
+	if len(errs) != 2 || !strings.HasSuffix(errs[1], "(did you mean 'security'?)") {
+		t.Errorf("errors with security declared = %q", errs)
+	}
+}
+
+func TestTagVariantWarnings(t *testing.T) {
+	config, _ := model.ParseYAML([]byte(tagsYAML))
+	warnings := collectWarnings(config, map[string]bool{"consistency": true})
+	var got []string
+	for _, w := range warnings {
+		got = append(got, w.Message)
+	}
+	if len(got) != 2 || got[0] != "Requirement 'Audit log' has the tag 'Security', which looks like a variant of 'security' (used by 2 requirement(s))" {
+		t.Errorf("warnings = %q", got)
+	}
+}
@@ -0,0 +1,12 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+//go:build !unix && !windows
+
+package cmd
+
+// stdoutTerminalWidth is unknown on this platform
+func stdoutTerminalWidth() int {
+	return 0
+}
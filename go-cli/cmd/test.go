@@ -0,0 +1,336 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testFormat  string
+	testOutput  string
+	testTimeout time.Duration
+	testEnv     string
+)
+
+// acceptanceResult is the outcome of running one requirement's
+// acceptance_test_link
+type acceptanceResult struct {
+	req    *model.RequirementDetail
+	script string
+	// Result is pass, fail, error (the script couldn't run) or skip (the
+	// link is a URL, or the requirement isn't for this environment)
+	Result   string
+	Message  string
+	Output   string
+	Duration time.Duration
+}
+
+var testCmd = &cobra.Command{
+	Use:   "test [file]",
+	Short: "Run the acceptance test scripts of requirements",
+	Long: `Run the acceptance_test_link script of every requirement that has one,
+and report which passed. A script passes when it exits with status 0.
+
+Links are looked up relative to the requirements file, then to the root of
+its git repository, and run from the directory they were found in, with
+RQM_REQUIREMENT_ID, RQM_REQUIREMENT_SUMMARY and RQM_ENVIRONMENT set. Links
+that are URLs document a test rather than run one, and are skipped.
+
+--environment names the platform the tests run on (the OS by default).
+Requirements whose environments field doesn't list it are skipped.
+
+--format junit writes JUnit XML with one test case per requirement, which
+CI systems show as test results; with --output the results are printed as
+well. The XML records the environment and requirement of each case, so
+rqm envmatrix can combine the results of several platforms.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm test
+  rqm test requirements.yml --timeout 2m
+  rqm test --format junit -o rqm-junit.xml
+  rqm test --environment arm64 --format junit -o junit-arm64.xml`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	// Failing tests are results; usage would only hide them
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if testFormat != "text" && testFormat != "junit" {
+			return fmt.Errorf("unknown output format: %s", testFormat)
+		}
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+
+		results := runAcceptanceTests(config, file, func(result acceptanceResult) {
+			if testFormat == "text" || testOutput != "" {
+				writeAcceptanceResult(os.Stdout, result)
+			}
+		})
+
+		if testFormat == "junit" {
+			out := io.Writer(os.Stdout)
+			if testOutput != "" {
+				f, err := os.Create(testOutput)
+				if err != nil {
+					return fmt.Errorf("failed to create %s: %w", testOutput, err)
+				}
+				defer f.Close()
+				out = f
+			}
+			if err := writeJUnit(out, file, testEnv, results); err != nil {
+				return err
+			}
+		}
+
+		counts := make(map[string]int)
+		for _, result := range results {
+			counts[result.Result]++
+		}
+		if testFormat == "text" || testOutput != "" {
+			fmt.Printf("\n%d acceptance test(s): %d passed, %d failed, %d could not run, %d skipped\n",
+				len(results), counts["pass"], counts["fail"], counts["error"], counts["skip"])
+		}
+		if failed := counts["fail"] + counts["error"]; failed > 0 {
+			return validationError("%d acceptance test(s) failed", failed)
+		}
+		return nil
+	},
+}
+
+// runAcceptanceTests runs the acceptance test scripts of the requirements
+// of file that apply to --environment, passing each result to report as it
+// comes in
+func runAcceptanceTests(config *model.RequirementConfig, file string, report func(acceptanceResult)) []acceptanceResult {
+	dirs := acceptanceTestDirs(file)
+	var results []acceptanceResult
+	for _, req := range config.Flatten() {
+		if req.AcceptanceTestLink == "" {
+			continue
+		}
+		result := acceptanceResult{req: req, script: req.AcceptanceTestLink, Result: "skip", Message: "not required on " + testEnv}
+		if len(req.Environments) == 0 || slices.Contains(req.Environments, testEnv) {
+			result = runAcceptanceTest(req, dirs, testTimeout)
+		}
+		results = append(results, result)
+		report(result)
+	}
+	return results
+}
+
+// acceptanceTestDirs are the directories links are looked up in: the
+// requirements file's, then the root of the git repository it is in
+func acceptanceTestDirs(file string) []string {
+	dirs := []string{filepath.Dir(file)}
+	if root, err := gitOutput("-C", filepath.Dir(file), "rev-parse", "--show-toplevel"); err == nil {
+		dirs = append(dirs, root)
+	}
+	return dirs
+}
+
+// runAcceptanceTest runs req's acceptance_test_link, found in the first of
+// dirs that has it, killing it after timeout when that's non-zero
+func runAcceptanceTest(req *model.RequirementDetail, dirs []string, timeout time.Duration) acceptanceResult {
+	result := acceptanceResult{req: req, script: req.AcceptanceTestLink}
+	if strings.Contains(req.AcceptanceTestLink, "://") {
+		result.Result, result.Message = "skip", "acceptance_test_link is a URL, not a script"
+		return result
+	}
+
+	var script, dir string
+	for _, d := range dirs {
+		path := filepath.Join(d, filepath.FromSlash(req.AcceptanceTestLink))
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			script, dir = path, d
+			break
+		}
+	}
+	if script == "" {
+		result.Result, result.Message = "error", "script not found: "+req.AcceptanceTestLink
+		return result
+	}
+	if abs, err := filepath.Abs(script); err == nil {
+		script = abs
+	}
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var output bytes.Buffer
+	run := exec.CommandContext(ctx, script)
+	run.Dir = dir
+	run.Env = append(os.Environ(), "RQM_REQUIREMENT_ID="+req.Name, "RQM_REQUIREMENT_SUMMARY="+req.Summary, "RQM_ENVIRONMENT="+testEnv)
+	run.Stdout, run.Stderr = &output, &output
+	// Children of a killed script can hold its output open
+	run.WaitDelay = time.Second
+
+	start := time.Now()
+	err := run.Run()
+	result.Duration = time.Since(start)
+	result.Output = output.String()
+
+	var exit *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		result.Result, result.Message = "fail", fmt.Sprintf("timed out after %s", timeout)
+	case errors.As(err, &exit):
+		result.Result, result.Message = "fail", fmt.Sprintf("exit status %d", exit.ExitCode())
+	case err != nil:
+		result.Result, result.Message = "error", err.Error()
+	default:
+		result.Result = "pass"
+	}
+	return result
+}
+
+// writeAcceptanceResult prints one line per result, followed by the
+// script's output when it failed
+func writeAcceptanceResult(w io.Writer, result acceptanceResult) {
+	switch result.Result {
+	case "pass":
+		fmt.Fprintf(w, "%s %s (%s)\n", okMark(), displayName(result.req), result.Duration.Round(time.Millisecond))
+	case "skip":
+		fmt.Fprintf(w, "%s %s skipped: %s\n", warnMark(), displayName(result.req), result.Message)
+	default:
+		fmt.Fprintf(w, "%s %s: %s\n", failMark(), displayName(result.req), result.Message)
+		for _, line := range strings.Split(strings.TrimRight(result.Output, "\n"), "\n") {
+			if line != "" {
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+		}
+	}
+}
+
+// JUnit XML, in the subset of the format CI systems agree on
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitMessage   `xml:"failure,omitempty"`
+	Error      *junitMessage   `xml:"error,omitempty"`
+	Skipped    *junitMessage   `xml:"skipped,omitempty"`
+	SystemOut  string          `xml:"system-out,omitempty"`
+}
+
+// junitProperty is a name-value pair of a suite or case. rqm test records
+// the environment of a suite as rqm.environment, and the requirement of a
+// case as rqm.requirement.
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// propertyValue returns the value of the named property, or ""
+func propertyValue(properties []junitProperty, name string) string {
+	for _, p := range properties {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes results as one JUnit test suite named after file, with
+// a test case per requirement
+func writeJUnit(w io.Writer, file, env string, results []acceptanceResult) error {
+	suite := junitTestSuite{Name: file, Properties: []junitProperty{{Name: "rqm.environment", Value: env}}}
+	var total time.Duration
+	for _, result := range results {
+		total += result.Duration
+		tc := junitTestCase{
+			Name:      displayName(result.req),
+			ClassName: result.script,
+			Time:      junitSeconds(result.Duration),
+		}
+		ref := result.req.Name
+		if ref == "" {
+			ref = result.req.Summary
+		}
+		tc.Properties = []junitProperty{{Name: "rqm.requirement", Value: ref}}
+		message := &junitMessage{Message: result.Message, Text: result.Output}
+		switch result.Result {
+		case "fail":
+			suite.Failures++
+			tc.Failure = message
+		case "error":
+			suite.Errors++
+			tc.Error = message
+		case "skip":
+			suite.Skipped++
+			tc.Skipped = &junitMessage{Message: result.Message}
+		default:
+			tc.SystemOut = result.Output
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Tests = len(results)
+	suite.Time = junitSeconds(total)
+
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to write JUnit XML: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, data)
+	return err
+}
+
+// junitSeconds formats d the way JUnit times are given
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+	testCmd.Flags().StringVarP(&testFormat, "format", "f", "text", "Output format (text, junit)")
+	testCmd.Flags().StringVarP(&testOutput, "output", "o", "", "Write the JUnit XML to this file instead of stdout")
+	testCmd.Flags().StringVar(&testEnv, "environment", runtime.GOOS, "Environment the tests run on, recorded in the results")
+	testCmd.Flags().DurationVar(&testTimeout, "timeout", 10*time.Minute, "Fail a script that runs longer than this (0 for no limit)")
+}
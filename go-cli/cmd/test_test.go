@@ -0,0 +1,92 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func TestRunAcceptanceTest(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("acceptance tests are shell scripts")
+	}
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "tests"), 0755)
+	os.WriteFile(filepath.Join(dir, "tests", "pass.sh"), []byte("#!/bin/sh\necho \"checking $RQM_REQUIREMENT_ID\"\n"), 0755)
+	os.WriteFile(filepath.Join(dir, "tests", "fail.sh"), []byte("#!/bin/sh\necho 'login rejected'\nexit 3\n"), 0755)
+	os.WriteFile(filepath.Join(dir, "tests", "slow.sh"), []byte("#!/bin/sh\nsleep 5\n"), 0755)
+
+	tests := []struct {
+		link, result, message, output string
+	}{
+		{"tests/pass.sh", "pass", "", "checking AUTH-001\n"},
+		{"tests/fail.sh", "fail", "exit status 3", "login rejected\n"},
+		{"tests/slow.sh", "fail", "timed out after 200ms", ""},
+		{"tests/missing.sh", "error", "script not found: tests/missing.sh", ""},
+		{"https://example.com/tests/auth", "skip", "acceptance_test_link is a URL, not a script", ""},
+	}
+	for _, tt := range tests {
+		req := &model.RequirementDetail{Summary: "Login", Name: "AUTH-001", AcceptanceTestLink: tt.link}
+		result := runAcceptanceTest(req, []string{t.TempDir(), dir}, 200*time.Millisecond)
+		if result.Result != tt.result || result.Message != tt.message || result.Output != tt.output {
+			t.Errorf("runAcceptanceTest(%s) = %s %q %q, want %s %q %q", tt.link, result.Result, result.Message, result.Output, tt.result, tt.message, tt.output)
+		}
+	}
+}
+
+func TestAcceptanceTestDirs(t *testing.T) {
+	repo := gitTestRepo(t)
+	file := filepath.Join(repo, "docs", "requirements.yml")
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run from another repository, as with rqm test ../other/reqs.yml
+	gitTestRepo(t)
+	dirs := acceptanceTestDirs(file)
+	root, err := filepath.EvalSymlinks(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dirs) != 2 || dirs[0] != filepath.Dir(file) || filepath.Clean(dirs[1]) != root {
+		t.Errorf("acceptanceTestDirs() = %v, want %s and %s", dirs, filepath.Dir(file), root)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	results := []acceptanceResult{
+		{req: &model.RequirementDetail{Summary: "Login", Name: "AUTH-001"}, script: "tests/login.sh", Result: "pass", Duration: 1500 * time.Millisecond},
+		{req: &model.RequirementDetail{Summary: "Lockout"}, script: "tests/lockout.sh", Result: "fail", Message: "exit status 1", Output: "still unlocked <after 5>\n"},
+		{req: &model.RequirementDetail{Summary: "Audit"}, script: "https://example.com", Result: "skip", Message: "acceptance_test_link is a URL, not a script"},
+	}
+	var out bytes.Buffer
+	if err := writeJUnit(&out, "requirements.yml", "linux", results); err != nil {
+		t.Fatal(err)
+	}
+
+	var parsed junitTestSuites
+	if err := xml.Unmarshal(out.Bytes(), &parsed); err != nil {
+		t.Fatalf("JUnit output is not well-formed XML: %v\n%s", err, out.String())
+	}
+	suite := parsed.Suites[0]
+	if suite.Tests != 3 || suite.Failures != 1 || suite.Skipped != 1 || suite.Time != "1.500" {
+		t.Errorf("Unexpected suite %+v", suite)
+	}
+	if suite.Cases[0].Name != "[AUTH-001] Login" || suite.Cases[0].Time != "1.500" {
+		t.Errorf("Unexpected first case %+v", suite.Cases[0])
+	}
+	if f := suite.Cases[1].Failure; f == nil || f.Message != "exit status 1" || !strings.Contains(f.Text, "still unlocked <after 5>") {
+		t.Errorf("Unexpected failure %+v", f)
+	}
+}
@@ -0,0 +1,262 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var traceFormat string
+
+// openAPIMethods are the operation keys of an OpenAPI path item, in the
+// order operations of one path are listed
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// openAPIOperation is an operation of an OpenAPI spec
+type openAPIOperation struct {
+	// ID is the operationId, empty when the spec gives none
+	ID     string
+	Method string
+	Path   string
+}
+
+// label names the operation by method and path, with its operationId
+func (op openAPIOperation) label() string {
+	label := strings.ToUpper(op.Method) + " " + op.Path
+	if op.ID != "" {
+		label += " (" + op.ID + ")"
+	}
+	return label
+}
+
+// openAPILink is a links.openapi entry of a requirement
+type openAPILink struct {
+	req         *model.RequirementDetail
+	operationID string
+}
+
+// openAPITrace is the result of tracing requirements to a spec
+type openAPITrace struct {
+	operations []openAPIOperation
+	// covering holds the requirements linked to each operationId
+	covering map[string][]*model.RequirementDetail
+	// missing are links to operations the spec doesn't have
+	missing []openAPILink
+	// uncovered are the operations no requirement links to
+	uncovered []openAPIOperation
+}
+
+var traceCmd = &cobra.Command{
+	Use:   "trace",
+	Short: "Trace requirements to what implements them",
+}
+
+var traceOpenAPICmd = &cobra.Command{
+	Use:   "openapi <spec> [file]",
+	Short: "Check requirements against the operations of an OpenAPI spec",
+	Long: `Trace requirements to the API operations they cover. Requirements name
+operations by operationId under links:
+
+  - summary: List users
+    links:
+      openapi:
+        - listUsers
+        - getUser
+
+The spec is an OpenAPI 3 or Swagger 2 document in YAML or JSON. Every
+operation is listed with the requirements covering it. A link to an
+operationId the spec doesn't have is an error; an operation no
+requirement links to is a warning, which fails the command only with
+--fail-on warning.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory.`,
+	Example: `  rqm trace openapi api/openapi.yaml
+  rqm trace openapi api/openapi.json requirements.yml --fail-on warning
+  rqm trace openapi api/openapi.yaml --format markdown > api-coverage.md`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkFailOn(); err != nil {
+			return err
+		}
+		file, err := requirementsFileArg(args[1:])
+		if err != nil {
+			return err
+		}
+		operations, err := loadOpenAPIOperations(args[0])
+		if err != nil {
+			return err
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+
+		trace := traceOpenAPI(config, operations)
+		switch traceFormat {
+		case "text":
+			writeOpenAPITrace(os.Stdout, trace, args[0])
+		case "markdown":
+			writeOpenAPITraceMarkdown(os.Stdout, trace)
+		default:
+			return fmt.Errorf("unknown output format: %s", traceFormat)
+		}
+
+		var failure error
+		if len(trace.missing) > 0 {
+			failure = validationError("%d link(s) to operations not in %s", len(trace.missing), args[0])
+		}
+		return applyFailOn(failure, len(trace.uncovered))
+	},
+}
+
+// loadOpenAPIOperations reads the operations of the OpenAPI spec at path,
+// sorted by path and then method
+func loadOpenAPIOperations(path string) ([]openAPIOperation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("file does not exist: %s", path)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var spec struct {
+		OpenAPI string                            `json:"openapi" yaml:"openapi"`
+		Swagger string                            `json:"swagger" yaml:"swagger"`
+		Paths   map[string]map[string]interface{} `json:"paths" yaml:"paths"`
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &spec)
+	} else {
+		err = yaml.Unmarshal(data, &spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if spec.OpenAPI == "" && spec.Swagger == "" {
+		return nil, fmt.Errorf("%s is not an OpenAPI spec: it has no openapi or swagger version", path)
+	}
+
+	var operations []openAPIOperation
+	for _, p := range sortedKeys(spec.Paths) {
+		for _, method := range openAPIMethods {
+			op, ok := spec.Paths[p][method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			id, _ := op["operationId"].(string)
+			operations = append(operations, openAPIOperation{ID: id, Method: method, Path: p})
+		}
+	}
+	return operations, nil
+}
+
+// traceOpenAPI matches the links.openapi entries of config's requirements
+// with operations
+func traceOpenAPI(config *model.RequirementConfig, operations []openAPIOperation) openAPITrace {
+	trace := openAPITrace{operations: operations, covering: make(map[string][]*model.RequirementDetail)}
+	known := make(map[string]bool)
+	for _, op := range operations {
+		if op.ID != "" {
+			known[op.ID] = true
+		}
+	}
+
+	for _, req := range config.Flatten() {
+		if req.Links == nil {
+			continue
+		}
+		for _, id := range req.Links.OpenAPI {
+			if !known[id] {
+				trace.missing = append(trace.missing, openAPILink{req: req, operationID: id})
+				continue
+			}
+			if !slices.Contains(trace.covering[id], req) {
+				trace.covering[id] = append(trace.covering[id], req)
+			}
+		}
+	}
+
+	for _, op := range operations {
+		if op.ID == "" || len(trace.covering[op.ID]) == 0 {
+			trace.uncovered = append(trace.uncovered, op)
+		}
+	}
+	sort.SliceStable(trace.missing, func(i, j int) bool {
+		return trace.missing[i].operationID < trace.missing[j].operationID
+	})
+	return trace
+}
+
+// writeOpenAPITrace lists every operation with its requirements, then the
+// links to operations spec doesn't have
+func writeOpenAPITrace(w io.Writer, trace openAPITrace, spec string) {
+	for _, op := range trace.operations {
+		reqs := trace.covering[op.ID]
+		if op.ID == "" || len(reqs) == 0 {
+			fmt.Fprintf(w, "%s %s has no requirement\n", warnMark(), op.label())
+			continue
+		}
+		var names []string
+		for _, req := range reqs {
+			names = append(names, displayName(req))
+		}
+		fmt.Fprintf(w, "%s %s ← %s\n", okMark(), op.label(), strings.Join(names, ", "))
+	}
+	for _, link := range trace.missing {
+		fmt.Fprintf(w, "%s %s links to operation %s, which isn't in %s\n", failMark(), displayName(link.req), link.operationID, spec)
+	}
+
+	covered := len(trace.operations) - len(trace.uncovered)
+	fmt.Fprintf(w, "\n%d operation(s): %d covered, %d without a requirement", len(trace.operations), covered, len(trace.uncovered))
+	if len(trace.missing) > 0 {
+		fmt.Fprintf(w, "; %d link(s) to missing operations", len(trace.missing))
+	}
+	fmt.Fprintln(w)
+}
+
+// writeOpenAPITraceMarkdown writes the operations and their requirements
+// as a table
+func writeOpenAPITraceMarkdown(w io.Writer, trace openAPITrace) {
+	fmt.Fprint(w, "# API coverage\n\n")
+	fmt.Fprint(w, "| Method | Path | Operation | Requirements |\n|--------|------|-----------|--------------|\n")
+	for _, op := range trace.operations {
+		var names []string
+		for _, req := range trace.covering[op.ID] {
+			names = append(names, displayName(req))
+		}
+		reqs := strings.Join(names, ", ")
+		if reqs == "" {
+			reqs = "none"
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s |\n", strings.ToUpper(op.Method), markdownCell(op.Path), markdownCell(op.ID), markdownCell(reqs))
+	}
+
+	if len(trace.missing) > 0 {
+		fmt.Fprint(w, "\n## Links to missing operations\n\n")
+		for _, link := range trace.missing {
+			fmt.Fprintf(w, "- %s: %s\n", markdownCell(displayName(link.req)), link.operationID)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(traceCmd)
+	traceCmd.AddCommand(traceOpenAPICmd)
+	traceOpenAPICmd.Flags().StringVarP(&traceFormat, "format", "f", "text", "Output format (text, markdown)")
+	traceOpenAPICmd.Flags().StringVar(&failOn, "fail-on", "error", "Lowest severity that fails the command (error, warning, none)")
+}
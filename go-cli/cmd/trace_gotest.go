@@ -0,0 +1,462 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	traceGoTestFile string
+	traceGoTestFrom string
+)
+
+// rqmTestingImport is the package with rqm.Covers
+const rqmTestingImport = "github.com/238855/rqm/go-cli/pkg/rqm"
+
+// goTestDirective is a "// rqm: AUTH-001, AUTH-002" comment
+var goTestDirective = regexp.MustCompile(`^//\s*rqm:\s*(.+)$`)
+
+// goTest is a test function that covers requirements
+type goTest struct {
+	// Package is the import path go test -json reports the test under
+	Package string
+	Name    string
+	File    string
+	Line    int
+	Covers  []string
+	// Result is pass, fail or skip, or empty when the test didn't run
+	Result string
+}
+
+// label names the test with its package
+func (t *goTest) label() string {
+	return t.Package + "." + t.Name
+}
+
+// requirementTests are the Go tests of one requirement
+type requirementTests struct {
+	req   *model.RequirementDetail
+	tests []*goTest
+}
+
+// counts tallies the results of the tests
+func (r requirementTests) counts() (passed, failed, notRun int) {
+	for _, test := range r.tests {
+		switch test.Result {
+		case "pass":
+			passed++
+		case "fail":
+			failed++
+		default:
+			notRun++
+		}
+	}
+	return passed, failed, notRun
+}
+
+// unknownCover is a test covering a requirement the file doesn't have
+type unknownCover struct {
+	test *goTest
+	ref  string
+}
+
+var traceGoTestCmd = &cobra.Command{
+	Use:   "gotest [packages]",
+	Short: "Run Go tests and report results per requirement",
+	Long: `Run the Go tests of the given packages (./... by default) and report
+which requirements they verify, and whether those tests passed.
+
+A test names the requirements it covers, by ID or summary, with a comment
+directive on the function or in its body:
+
+  // rqm: AUTH-001, AUTH-002
+  func TestLogin(t *testing.T) {
+
+or by calling Covers from the rqm package:
+
+  import "github.com/238855/rqm/go-cli/pkg/rqm"
+
+  func TestLogin(t *testing.T) {
+      rqm.Covers(t, "AUTH-001")
+
+Test files are read, not run, to find these, so the IDs must be literals.
+The tests are then run with go test -json; --from reads the output of an
+earlier go test -json run instead ("-" for stdin).
+
+A requirement fails when any of its tests fails. Tests that didn't run,
+and tests covering requirements the file doesn't have, are warnings,
+which fail the command only with --fail-on warning.
+
+Without --requirements, .rqm/requirements.yml is found by walking up from
+the current directory.`,
+	Example: `  rqm trace gotest ./...
+  rqm trace gotest ./internal/... --requirements docs/requirements.yml
+  go test -json ./... > results.json; rqm trace gotest --from results.json`,
+	// Failing tests are results; usage would only hide them
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkFailOn(); err != nil {
+			return err
+		}
+		var fileArgs []string
+		if traceGoTestFile != "" {
+			fileArgs = []string{traceGoTestFile}
+		}
+		file, err := requirementsFileArg(fileArgs)
+		if err != nil {
+			return err
+		}
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+
+		patterns := args
+		if len(patterns) == 0 {
+			patterns = []string{"./..."}
+		}
+		tests, err := findGoTests(patterns)
+		if err != nil {
+			return err
+		}
+
+		var events io.Reader
+		switch traceGoTestFrom {
+		case "":
+			if events, err = runGoTestJSON(patterns); err != nil {
+				return err
+			}
+		case "-":
+			events = os.Stdin
+		default:
+			f, err := os.Open(traceGoTestFrom)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", traceGoTestFrom, err)
+			}
+			defer f.Close()
+			events = f
+		}
+		if err := applyGoTestEvents(events, tests); err != nil {
+			return err
+		}
+
+		results, unknown := traceGoTests(config, tests)
+		switch traceFormat {
+		case "text":
+			writeGoTestTrace(os.Stdout, config, results, unknown, file)
+		case "markdown":
+			writeGoTestTraceMarkdown(os.Stdout, results)
+		default:
+			return fmt.Errorf("unknown output format: %s", traceFormat)
+		}
+
+		failed, warnings := 0, len(unknown)
+		for _, r := range results {
+			_, f, notRun := r.counts()
+			if f > 0 {
+				failed++
+			}
+			warnings += notRun
+		}
+		var failure error
+		if failed > 0 {
+			failure = validationError("%d requirement(s) with failing tests", failed)
+		}
+		return applyFailOn(failure, warnings)
+	},
+}
+
+// findGoTests lists the test files of the packages matching patterns and
+// collects the tests in them that cover requirements
+func findGoTests(patterns []string) ([]*goTest, error) {
+	listArgs := append([]string{"list", "-f", `{{.ImportPath}}{{"\t"}}{{.Dir}}{{"\t"}}{{join .TestGoFiles " "}} {{join .XTestGoFiles " "}}`}, patterns...)
+	out, err := exec.Command("go", listArgs...).Output()
+	if err != nil {
+		if exit, ok := err.(*exec.ExitError); ok && len(exit.Stderr) > 0 {
+			return nil, fmt.Errorf("go list: %s", strings.TrimSpace(string(exit.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to run go list: %w", err)
+	}
+
+	var tests []*goTest
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		for _, name := range strings.Fields(fields[2]) {
+			found, err := parseGoTestFile(filepath.Join(fields[1], name), fields[0])
+			if err != nil {
+				return nil, err
+			}
+			tests = append(tests, found...)
+		}
+	}
+	return tests, nil
+}
+
+// parseGoTestFile collects the tests in the Go test file at path that
+// cover requirements, by directive or rqm.Covers call
+func parseGoTestFile(path, pkg string) ([]*goTest, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	// The name the file imports the rqm testing package under, if at all
+	helper := ""
+	for _, imp := range f.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p == rqmTestingImport {
+			helper = "rqm"
+			if imp.Name != nil {
+				helper = imp.Name.Name
+			}
+		}
+	}
+
+	var tests []*goTest
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil || !strings.HasPrefix(fn.Name.Name, "Test") || fn.Name.Name == "TestMain" {
+			continue
+		}
+		test := &goTest{Package: pkg, Name: fn.Name.Name, File: path, Line: fset.Position(fn.Pos()).Line}
+
+		var comments []*ast.Comment
+		if fn.Doc != nil {
+			comments = append(comments, fn.Doc.List...)
+		}
+		for _, group := range f.Comments {
+			if group.Pos() > fn.Body.Lbrace && group.End() < fn.Body.Rbrace {
+				comments = append(comments, group.List...)
+			}
+		}
+		for _, c := range comments {
+			if match := goTestDirective.FindStringSubmatch(c.Text); match != nil {
+				test.Covers = append(test.Covers, splitCovers(match[1])...)
+			}
+		}
+
+		if helper != "" {
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "Covers" {
+					return true
+				}
+				if x, ok := sel.X.(*ast.Ident); !ok || x.Name != helper {
+					return true
+				}
+				if len(call.Args) < 2 {
+					return true
+				}
+				for _, arg := range call.Args[1:] {
+					if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						if id, err := strconv.Unquote(lit.Value); err == nil {
+							test.Covers = append(test.Covers, id)
+						}
+					}
+				}
+				return true
+			})
+		}
+
+		if len(test.Covers) > 0 {
+			tests = append(tests, test)
+		}
+	}
+	return tests, nil
+}
+
+// splitCovers splits the requirements of a directive at commas
+func splitCovers(s string) []string {
+	var ids []string
+	for _, id := range strings.Split(s, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// runGoTestJSON runs go test -json on patterns. Failing tests make go
+// test exit non-zero, so only a run without any output is an error.
+func runGoTestJSON(patterns []string) (io.Reader, error) {
+	var stdout, stderr bytes.Buffer
+	run := exec.Command("go", append([]string{"test", "-json"}, patterns...)...)
+	run.Stdout, run.Stderr = &stdout, &stderr
+	if err := run.Run(); err != nil && stdout.Len() == 0 {
+		if stderr.Len() > 0 {
+			return nil, fmt.Errorf("go test: %s", strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("failed to run go test: %w", err)
+	}
+	return &stdout, nil
+}
+
+// applyGoTestEvents sets the results of tests from go test -json output.
+// Subtests count toward the test they belong to, which fails with them.
+func applyGoTestEvents(r io.Reader, tests []*goTest) error {
+	byName := make(map[string]*goTest)
+	for _, test := range tests {
+		byName[test.label()] = test
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var event struct {
+			Action  string
+			Package string
+			Test    string
+		}
+		if json.Unmarshal(scanner.Bytes(), &event) != nil {
+			// go test mixes in plain lines, such as build errors
+			continue
+		}
+		switch event.Action {
+		case "pass", "fail", "skip":
+			if test := byName[event.Package+"."+event.Test]; test != nil {
+				test.Result = event.Action
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read go test output: %w", err)
+	}
+	return nil
+}
+
+// traceGoTests groups tests by the requirements of config they cover, in
+// file order
+func traceGoTests(config *model.RequirementConfig, tests []*goTest) ([]requirementTests, []unknownCover) {
+	byReq := make(map[*model.RequirementDetail][]*goTest)
+	var unknown []unknownCover
+	for _, test := range tests {
+		for _, ref := range test.Covers {
+			req, ok := config.Find(ref)
+			if !ok {
+				unknown = append(unknown, unknownCover{test: test, ref: ref})
+				continue
+			}
+			byReq[req] = append(byReq[req], test)
+		}
+	}
+
+	var results []requirementTests
+	for _, req := range config.Flatten() {
+		if len(byReq[req]) > 0 {
+			results = append(results, requirementTests{req: req, tests: byReq[req]})
+		}
+	}
+	return results, unknown
+}
+
+// goTestNames lists the names of the tests with the given result
+func goTestNames(tests []*goTest, result string) string {
+	var names []string
+	for _, test := range tests {
+		if test.Result == result {
+			names = append(names, test.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// writeGoTestTrace reports the test results of each requirement
+func writeGoTestTrace(w io.Writer, config *model.RequirementConfig, results []requirementTests, unknown []unknownCover, file string) {
+	failedReqs := 0
+	for _, r := range results {
+		passed, failed, notRun := r.counts()
+		var parts []string
+		mark := okMark()
+		if failed > 0 {
+			mark = failMark()
+			failedReqs++
+			parts = append(parts, fmt.Sprintf("%d failed (%s)", failed, goTestNames(r.tests, "fail")))
+		}
+		if passed > 0 {
+			parts = append(parts, fmt.Sprintf("%d passed", passed))
+		}
+		if notRun > 0 {
+			if failed == 0 {
+				mark = warnMark()
+			}
+			var names []string
+			for _, test := range r.tests {
+				if test.Result != "pass" && test.Result != "fail" {
+					names = append(names, test.Name)
+				}
+			}
+			parts = append(parts, fmt.Sprintf("%d not run (%s)", notRun, strings.Join(names, ", ")))
+		}
+		fmt.Fprintf(w, "%s %s: %s\n", mark, displayName(r.req), strings.Join(parts, ", "))
+	}
+	for _, u := range unknown {
+		fmt.Fprintf(w, "%s %s (%s:%d) covers %s, which isn't in %s\n", warnMark(), u.test.Name, u.test.File, u.test.Line, u.ref, file)
+	}
+
+	untested := len(config.Flatten()) - len(results)
+	fmt.Fprintf(w, "\n%d requirement(s) with Go tests, %d failing; %d without\n", len(results), failedReqs, untested)
+}
+
+// writeGoTestTraceMarkdown writes the requirements and their test results
+// as a table
+func writeGoTestTraceMarkdown(w io.Writer, results []requirementTests) {
+	fmt.Fprint(w, "# Go test results by requirement\n\n")
+	if len(results) == 0 {
+		fmt.Fprintln(w, "No Go tests cover a requirement.")
+		return
+	}
+	fmt.Fprint(w, "| ID | Summary | Result | Tests |\n|----|---------|--------|-------|\n")
+	for _, r := range results {
+		_, failed, notRun := r.counts()
+		result := "pass"
+		switch {
+		case failed > 0:
+			result = "fail"
+		case notRun > 0:
+			result = "not run"
+		}
+		var names []string
+		for _, test := range r.tests {
+			status := test.Result
+			if status == "" {
+				status = "not run"
+			}
+			names = append(names, fmt.Sprintf("%s (%s)", test.Name, status))
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s |\n", markdownCell(r.req.Name), markdownCell(r.req.Summary), result, markdownCell(strings.Join(names, ", ")))
+	}
+}
+
+func init() {
+	traceCmd.AddCommand(traceGoTestCmd)
+	traceGoTestCmd.Flags().StringVar(&traceGoTestFile, "requirements", "", "Requirements file (default: the nearest .rqm/requirements.yml)")
+	traceGoTestCmd.Flags().StringVar(&traceGoTestFrom, "from", "", "Read go test -json output from this file (- for stdin) instead of running the tests")
+	traceGoTestCmd.Flags().StringVarP(&traceFormat, "format", "f", "text", "Output format (text, markdown)")
+	traceGoTestCmd.Flags().StringVar(&failOn, "fail-on", "error", "Lowest severity that fails the command (error, warning, none)")
+}
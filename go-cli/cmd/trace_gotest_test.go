@@ -0,0 +1,119 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const goTestSource = `package auth
+
+import (
+	"testing"
+
+	req "github.com/238855/rqm/go-cli/pkg/rqm"
+)
+
+// rqm: AUTH-001, Lockout
+func TestLogin(t *testing.T) {
+	t.Run("wrong password", func(t *testing.T) {
+		// rqm: AUTH-003
+	})
+}
+
+func TestLockout(t *testing.T) {
+	req.Covers(t, "Lockout", "AUTH-404")
+}
+
+func TestHelper(t *testing.T) {
+	req.Covers()
+	req.Covers(t)
+}
+`
+
+const goTestEvents = `{"Action":"run","Package":"example.com/auth","Test":"TestLogin"}
+{"Action":"fail","Package":"example.com/auth","Test":"TestLogin/wrong_password","Elapsed":0}
+{"Action":"fail","Package":"example.com/auth","Test":"TestLogin","Elapsed":0.01}
+# example.com/other
+{"Action":"pass","Package":"example.com/auth","Test":"TestLockout","Elapsed":0}
+{"Action":"fail","Package":"example.com/auth","Elapsed":0.02}
+`
+
+const goTestYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+  - summary: Lockout
+    name: AUTH-002
+  - summary: Password reset
+    name: AUTH-003
+  - summary: Sessions
+`
+
+func TestParseGoTestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth_test.go")
+	os.WriteFile(path, []byte(goTestSource), 0644)
+	tests, err := parseGoTestFile(path, "example.com/auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, test := range tests {
+		got = append(got, test.Name+":"+strings.Join(test.Covers, ","))
+	}
+	want := "TestLogin:AUTH-001,Lockout,AUTH-003|TestLockout:Lockout,AUTH-404"
+	if strings.Join(got, "|") != want {
+		t.Errorf("parseGoTestFile() = %q, want %q", got, want)
+	}
+}
+
+func TestTraceGoTests(t *testing.T) {
+	config, err := model.ParseYAML([]byte(goTestYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []*goTest{
+		{Package: "example.com/auth", Name: "TestLogin", Covers: []string{"AUTH-001", "Lockout"}},
+		{Package: "example.com/auth", Name: "TestLockout", Covers: []string{"Lockout", "AUTH-404"}},
+		{Package: "example.com/auth", Name: "TestReset", Covers: []string{"AUTH-003"}},
+	}
+	if err := applyGoTestEvents(strings.NewReader(goTestEvents), tests); err != nil {
+		t.Fatal(err)
+	}
+	if tests[0].Result != "fail" || tests[1].Result != "pass" || tests[2].Result != "" {
+		t.Errorf("Unexpected results %q, %q, %q", tests[0].Result, tests[1].Result, tests[2].Result)
+	}
+
+	results, unknown := traceGoTests(config, tests)
+	if len(results) != 3 || len(unknown) != 1 || unknown[0].ref != "AUTH-404" {
+		t.Fatalf("Expected 3 requirements with tests and AUTH-404 unknown, got %d, %+v", len(results), unknown)
+	}
+
+	var text bytes.Buffer
+	writeGoTestTrace(&text, config, results, unknown, "requirements.yml")
+	for _, want := range []string{
+		"[AUTH-001] Login: 1 failed (TestLogin)",
+		"[AUTH-002] Lockout: 1 failed (TestLogin), 1 passed",
+		"[AUTH-003] Password reset: 1 not run (TestReset)",
+		"TestLockout (:0) covers AUTH-404, which isn't in requirements.yml",
+		"3 requirement(s) with Go tests, 2 failing; 1 without",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Expected %q in:\n%s", want, text.String())
+		}
+	}
+
+	var markdown bytes.Buffer
+	writeGoTestTraceMarkdown(&markdown, results)
+	if !strings.Contains(markdown.String(), "| AUTH-003 | Password reset | not run | TestReset (not run) |") {
+		t.Errorf("Unexpected markdown:\n%s", markdown.String())
+	}
+}
@@ -0,0 +1,122 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const traceSpecYAML = `openapi: 3.0.3
+info:
+  title: Users
+  version: "1"
+paths:
+  /users:
+    parameters:
+      - name: limit
+        in: query
+    post:
+      operationId: createUser
+    get:
+      operationId: listUsers
+  /users/{id}:
+    get:
+      operationId: getUser
+    delete:
+      summary: No operationId
+`
+
+const traceYAML = `version: "1.0"
+requirements:
+  - summary: User directory
+    name: API-001
+    links:
+      openapi: [listUsers, getUser]
+    requirements:
+      - summary: Look up a user
+        links:
+          openapi: [getUser]
+  - summary: Remove users
+    links:
+      openapi: [removeUser]
+`
+
+func TestLoadOpenAPIOperations(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "openapi.yaml")
+	os.WriteFile(spec, []byte(traceSpecYAML), 0644)
+	operations, err := loadOpenAPIOperations(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, op := range operations {
+		got = append(got, op.label())
+	}
+	want := "GET /users (listUsers)|POST /users (createUser)|GET /users/{id} (getUser)|DELETE /users/{id}"
+	if strings.Join(got, "|") != want {
+		t.Errorf("loadOpenAPIOperations() = %q, want %q", got, want)
+	}
+
+	swagger := filepath.Join(dir, "swagger.json")
+	os.WriteFile(swagger, []byte(`{"swagger": "2.0", "paths": {"/ping": {"get": {"operationId": "ping"}}}}`), 0644)
+	if operations, err := loadOpenAPIOperations(swagger); err != nil || len(operations) != 1 || operations[0].ID != "ping" {
+		t.Errorf("Expected the Swagger operation, got %v, %v", operations, err)
+	}
+
+	other := filepath.Join(dir, "config.yml")
+	os.WriteFile(other, []byte("theme: dark\n"), 0644)
+	if _, err := loadOpenAPIOperations(other); err == nil || !strings.Contains(err.Error(), "is not an OpenAPI spec") {
+		t.Errorf("Expected an error for a file that isn't a spec, got %v", err)
+	}
+}
+
+func TestTraceOpenAPI(t *testing.T) {
+	config, err := model.ParseYAML([]byte(traceYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	operations := []openAPIOperation{
+		{ID: "listUsers", Method: "get", Path: "/users"},
+		{ID: "createUser", Method: "post", Path: "/users"},
+		{ID: "getUser", Method: "get", Path: "/users/{id}"},
+		{Method: "delete", Path: "/users/{id}"},
+	}
+	trace := traceOpenAPI(config, operations)
+	if len(trace.covering["getUser"]) != 2 || len(trace.covering["listUsers"]) != 1 {
+		t.Errorf("Expected getUser covered twice and listUsers once, got %v", trace.covering)
+	}
+	if len(trace.missing) != 1 || trace.missing[0].operationID != "removeUser" {
+		t.Errorf("Expected the link to removeUser to be missing, got %+v", trace.missing)
+	}
+	if len(trace.uncovered) != 2 || trace.uncovered[0].ID != "createUser" || trace.uncovered[1].Method != "delete" {
+		t.Errorf("Expected createUser and the unnamed delete uncovered, got %+v", trace.uncovered)
+	}
+
+	var text bytes.Buffer
+	writeOpenAPITrace(&text, trace, "openapi.yaml")
+	for _, want := range []string{
+		"GET /users/{id} (getUser) ← [API-001] User directory, Look up a user",
+		"POST /users (createUser) has no requirement",
+		"Remove users links to operation removeUser, which isn't in openapi.yaml",
+		"4 operation(s): 2 covered, 2 without a requirement; 1 link(s) to missing operations",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Expected %q in:\n%s", want, text.String())
+		}
+	}
+
+	var markdown bytes.Buffer
+	writeOpenAPITraceMarkdown(&markdown, trace)
+	if !strings.Contains(markdown.String(), "| POST | /users | createUser | none |") {
+		t.Errorf("Unexpected markdown:\n%s", markdown.String())
+	}
+}
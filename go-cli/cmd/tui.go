@@ -0,0 +1,605 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// requirementStatuses is the status cycle used by quick status changes,
+// in the order the schema lists them
+var requirementStatuses = []string{"draft", "proposed", "approved", "implemented", "verified", "deprecated"}
+
+// ANSI reverse video highlights the selected line in the TUI
+const ansiReverse = "\033[7m"
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [file]",
+	Short: "Browse requirements in an interactive terminal UI",
+	Long: `Browse requirements in an interactive terminal UI.
+
+Navigate the requirement tree with the arrow keys, expand and collapse
+branches, fuzzy search by ID or summary, and read the selected
+requirement's details in the side pane. Mark several requirements to
+change them together; every change is written back to the file straight
+away, all marked requirements in one atomic write.
+
+Keys:
+  ↑/↓ or k/j     move
+  →/l  ←/h       expand / collapse (or jump to child / parent)
+  enter, space   toggle expand
+  /              fuzzy search (enter keeps the filter, esc clears it)
+  x              mark / unmark and move down
+  a              mark every visible requirement
+  u              clear marks
+  s              cycle status
+  1-6            set status: draft, proposed, approved, implemented,
+                 verified, deprecated
+  t              add a tag
+  o              reassign owner
+  q, ctrl+c      quit
+
+s, 1-6, t and o act on the marked requirements, or on the one under the
+cursor when nothing is marked.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := args[0]
+
+		config, _, err := loadRequirements(file)
+		if err != nil {
+			return err
+		}
+
+		_, err = tea.NewProgram(newTUIModel(file, config), tea.WithAltScreen()).Run()
+		return err
+	},
+}
+
+// tuiNode is a requirement in the TUI tree
+type tuiNode struct {
+	req      *model.RequirementDetail
+	depth    int
+	parent   *tuiNode
+	children []*tuiNode
+	expanded bool
+}
+
+// tuiModel is the bubbletea model behind rqm tui
+type tuiModel struct {
+	file    string
+	aliases []model.PersonAlias
+	roots   []*tuiNode
+	// all holds every node depth-first, the order search results use
+	all     []*tuiNode
+	visible []*tuiNode
+
+	cursor int
+	offset int
+	width  int
+	height int
+
+	searching bool
+	query     string
+	message   string
+
+	// marked holds the requirements selected for bulk actions
+	marked map[*tuiNode]bool
+	// prompt names the value being typed for t/o ("tag" or "owner"),
+	// empty when not prompting
+	prompt string
+	input  string
+}
+
+func newTUIModel(file string, config *model.RequirementConfig) *tuiModel {
+	m := &tuiModel{file: file, aliases: config.Aliases, width: 100, height: 24, marked: make(map[*tuiNode]bool)}
+	for i := range config.Requirements {
+		m.roots = append(m.roots, m.addNode(&config.Requirements[i], nil, 0))
+	}
+	// Start with the top level open so the first screen isn't a single line
+	for _, root := range m.roots {
+		root.expanded = true
+	}
+	m.refresh()
+	return m
+}
+
+func (m *tuiModel) addNode(req *model.RequirementDetail, parent *tuiNode, depth int) *tuiNode {
+	node := &tuiNode{req: req, depth: depth, parent: parent}
+	m.all = append(m.all, node)
+	for _, child := range req.Requirements {
+		// String references point at requirements shown elsewhere
+		if child.Full == nil {
+			continue
+		}
+		node.children = append(node.children, m.addNode(child.Full, node, depth+1))
+	}
+	return node
+}
+
+// refresh rebuilds the visible lines from the tree or the search query,
+// keeping the cursor on the same requirement where possible
+func (m *tuiModel) refresh() {
+	selected := m.selected()
+
+	m.visible = m.visible[:0]
+	if m.query != "" {
+		for _, node := range m.all {
+			if fuzzyMatch(m.query, node.req.Name+" "+node.req.Summary) {
+				m.visible = append(m.visible, node)
+			}
+		}
+	} else {
+		var walk func(nodes []*tuiNode)
+		walk = func(nodes []*tuiNode) {
+			for _, node := range nodes {
+				m.visible = append(m.visible, node)
+				if node.expanded {
+					walk(node.children)
+				}
+			}
+		}
+		walk(m.roots)
+	}
+
+	m.cursor = 0
+	if i := slices.Index(m.visible, selected); i >= 0 {
+		m.cursor = i
+	}
+	m.scroll()
+}
+
+func (m *tuiModel) selected() *tuiNode {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return nil
+	}
+	return m.visible[m.cursor]
+}
+
+func (m *tuiModel) moveTo(node *tuiNode) {
+	if i := slices.Index(m.visible, node); i >= 0 {
+		m.cursor = i
+		m.scroll()
+	}
+}
+
+// bodyHeight is the number of tree lines that fit between header and footer
+func (m *tuiModel) bodyHeight() int {
+	return max(m.height-3, 1)
+}
+
+// scroll keeps the cursor inside the visible window
+func (m *tuiModel) scroll() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.bodyHeight() {
+		m.offset = m.cursor - m.bodyHeight() + 1
+	}
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+	case tea.KeyMsg:
+		if m.prompt != "" {
+			return m, m.updatePrompt(msg)
+		}
+		if m.searching {
+			return m, m.updateSearch(msg)
+		}
+		return m, m.updateBrowse(msg)
+	}
+	return m, nil
+}
+
+func (m *tuiModel) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return tea.Quit
+	case tea.KeyEnter:
+		m.searching = false
+	case tea.KeyEsc:
+		m.searching = false
+		m.query = ""
+		m.refresh()
+	case tea.KeyBackspace:
+		if runes := []rune(m.query); len(runes) > 0 {
+			m.query = string(runes[:len(runes)-1])
+			m.refresh()
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		// A space arrives as KeySpace with the space in Runes
+		m.query += string(msg.Runes)
+		m.refresh()
+	}
+	return nil
+}
+
+// updatePrompt reads the tag or owner for t/o and applies it on enter
+func (m *tuiModel) updatePrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return tea.Quit
+	case tea.KeyEnter:
+		value := strings.TrimSpace(m.input)
+		prompt := m.prompt
+		m.prompt, m.input = "", ""
+		if value == "" {
+			break
+		}
+		if prompt == "tag" {
+			m.addTag(value)
+		} else {
+			m.setOwner(value)
+		}
+	case tea.KeyEsc:
+		m.prompt, m.input = "", ""
+	case tea.KeyBackspace:
+		if runes := []rune(m.input); len(runes) > 0 {
+			m.input = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		// A space arrives as KeySpace with the space in Runes
+		m.input += string(msg.Runes)
+	}
+	return nil
+}
+
+func (m *tuiModel) updateBrowse(msg tea.KeyMsg) tea.Cmd {
+	m.message = ""
+	node := m.selected()
+
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+			m.scroll()
+		}
+	case "down", "j":
+		if m.cursor < len(m.visible)-1 {
+			m.cursor++
+			m.scroll()
+		}
+	case "home", "g":
+		m.cursor = 0
+		m.scroll()
+	case "end", "G":
+		m.cursor = max(len(m.visible)-1, 0)
+		m.scroll()
+	case "right", "l":
+		if node == nil || len(node.children) == 0 || m.query != "" {
+			break
+		}
+		if node.expanded {
+			m.moveTo(node.children[0])
+		} else {
+			node.expanded = true
+			m.refresh()
+		}
+	case "left", "h":
+		if node == nil || m.query != "" {
+			break
+		}
+		if node.expanded && len(node.children) > 0 {
+			node.expanded = false
+			m.refresh()
+		} else if node.parent != nil {
+			m.moveTo(node.parent)
+		}
+	case "enter", " ":
+		if node != nil && len(node.children) > 0 && m.query == "" {
+			node.expanded = !node.expanded
+			m.refresh()
+		}
+	case "/":
+		m.searching = true
+	case "esc":
+		if m.query != "" {
+			m.query = ""
+			m.refresh()
+		}
+	case "x":
+		if node != nil {
+			if m.marked[node] {
+				delete(m.marked, node)
+			} else {
+				m.marked[node] = true
+			}
+			if m.cursor < len(m.visible)-1 {
+				m.cursor++
+				m.scroll()
+			}
+		}
+	case "a":
+		for _, n := range m.visible {
+			m.marked[n] = true
+		}
+	case "u":
+		clear(m.marked)
+	case "s":
+		if node != nil {
+			// Marked requirements all move to the status after the cursor's
+			i := slices.Index(requirementStatuses, node.req.Status)
+			m.setStatus(requirementStatuses[(i+1)%len(requirementStatuses)])
+		}
+	case "1", "2", "3", "4", "5", "6":
+		m.setStatus(requirementStatuses[msg.String()[0]-'1'])
+	case "t":
+		if len(m.targets()) > 0 {
+			m.prompt = "tag"
+		}
+	case "o":
+		if len(m.targets()) > 0 {
+			m.prompt = "owner"
+		}
+	}
+	return nil
+}
+
+// targets are the requirements an action applies to: the marked ones in tree
+// order, or the one under the cursor when nothing is marked
+func (m *tuiModel) targets() []*tuiNode {
+	var nodes []*tuiNode
+	for _, node := range m.all {
+		if m.marked[node] {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		if node := m.selected(); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// applyToTargets saves one edit per target in a single all-or-nothing write
+// before updating the view, so the screen never shows a change that wasn't
+// saved
+func (m *tuiModel) applyToTargets(label string, edit requirementEdit, update func(req *model.RequirementDetail)) {
+	nodes := m.targets()
+	if len(nodes) == 0 {
+		return
+	}
+	edits := make([]requirementEdit, len(nodes))
+	for i, node := range nodes {
+		edits[i] = edit
+		edits[i].Summary = node.req.Summary
+	}
+	if err := applyRequirementEdits(m.file, edits); err != nil {
+		m.message = fmt.Sprintf("%s %v", failMark(), err)
+		return
+	}
+
+	for _, node := range nodes {
+		update(node.req)
+	}
+	if len(nodes) == 1 {
+		m.message = fmt.Sprintf("%s %s → %s", okMark(), displayName(nodes[0].req), label)
+	} else {
+		m.message = fmt.Sprintf("%s %d requirements → %s", okMark(), len(nodes), label)
+	}
+}
+
+func (m *tuiModel) setStatus(status string) {
+	m.applyToTargets(status, requirementEdit{Set: map[string]string{"status": status}}, func(req *model.RequirementDetail) {
+		req.Status = status
+	})
+}
+
+func (m *tuiModel) addTag(tag string) {
+	m.applyToTargets("tag "+tag, requirementEdit{AddTags: []string{tag}}, func(req *model.RequirementDetail) {
+		if !slices.Contains(req.Tags, tag) {
+			req.Tags = append(req.Tags, tag)
+		}
+	})
+}
+
+func (m *tuiModel) setOwner(owner string) {
+	m.applyToTargets("owner "+owner, requirementEdit{Set: map[string]string{"owner": owner}}, func(req *model.RequirementDetail) {
+		req.Owner = owner
+	})
+}
+
+func (m *tuiModel) View() string {
+	leftWidth := max(m.width*55/100, 20)
+	rightWidth := max(m.width-leftWidth-3, 10)
+
+	title := "RQM  " + m.file
+	if m.searching || m.query != "" {
+		title += "  /" + m.query
+		if m.searching {
+			title += "█"
+		}
+	}
+
+	left := m.treeLines(leftWidth)
+	right := m.detailLines(rightWidth)
+
+	var b strings.Builder
+	b.WriteString(truncateCell(title, m.width) + "\n")
+	for i := 0; i < m.bodyHeight(); i++ {
+		line := ""
+		if i < len(left) {
+			line = left[i]
+		} else {
+			line = strings.Repeat(" ", leftWidth)
+		}
+		if i < len(right) {
+			line += " │ " + right[i]
+		} else {
+			line += " │"
+		}
+		b.WriteString(line + "\n")
+	}
+
+	footer := m.message
+	switch {
+	case m.prompt != "":
+		footer = fmt.Sprintf("%s for %d requirement(s): %s█  (enter apply, esc cancel)", m.prompt, len(m.targets()), m.input)
+	case footer == "" && len(m.marked) > 0:
+		footer = fmt.Sprintf("%d marked  s/1-6 status  t tag  o owner  x mark  u clear  q quit", len(m.marked))
+	case footer == "":
+		footer = "↑/↓ move  ←/→ collapse/expand  / search  x mark  s status  t tag  o owner  q quit"
+	}
+	b.WriteString(truncateCell(footer, m.width))
+	return b.String()
+}
+
+// treeLines renders the window of visible nodes padded to width
+func (m *tuiModel) treeLines(width int) []string {
+	end := min(m.offset+m.bodyHeight(), len(m.visible))
+	var lines []string
+	for i := m.offset; i < end; i++ {
+		node := m.visible[i]
+
+		toggle := "  "
+		if len(node.children) > 0 && m.query == "" {
+			toggle = "▸ "
+			if node.expanded {
+				toggle = "▾ "
+			}
+		}
+		indent := ""
+		if m.query == "" {
+			indent = strings.Repeat("  ", node.depth)
+		}
+		marker := "  "
+		if i == m.cursor {
+			marker = "> "
+		}
+		if m.marked[node] {
+			marker = marker[:1] + "*"
+		}
+
+		line := fmt.Sprintf("%s%s%s%s %s", marker, indent, toggle, getStatusSymbol(node.req.Status), displayName(node.req))
+		line = truncateCell(line, width)
+		line += strings.Repeat(" ", max(width-len([]rune(line)), 0))
+		if i == m.cursor {
+			line = paint(ansiReverse, line)
+		}
+		lines = append(lines, line)
+	}
+	if len(m.visible) == 0 {
+		lines = append(lines, truncateCell("  (no matching requirements)", width))
+	}
+	return lines
+}
+
+// detailLines describes the selected requirement, wrapped to width
+func (m *tuiModel) detailLines(width int) []string {
+	node := m.selected()
+	if node == nil {
+		return nil
+	}
+	req := node.req
+
+	var lines []string
+	field := func(label, value string) {
+		if value != "" {
+			lines = append(lines, wrapText(label+": "+value, width)...)
+		}
+	}
+	lines = append(lines, wrapText(req.Summary, width)...)
+	lines = append(lines, "")
+	field("ID", req.Name)
+	field("Status", req.Status)
+	field("Priority", req.Priority)
+	field("Owner", displayOwner(m.aliases, req.Owner))
+	field("Tags", strings.Join(req.Tags, ", "))
+	field("Verification", req.Verification)
+	for _, rel := range req.Relations {
+		field(rel.Type, rel.Target)
+	}
+	if req.Description != "" {
+		lines = append(lines, "")
+		for _, paragraph := range strings.Split(strings.TrimSpace(req.Description), "\n") {
+			lines = append(lines, wrapText(paragraph, width)...)
+		}
+	}
+	return lines
+}
+
+// displayName labels a requirement by ID and summary
+func displayName(req *model.RequirementDetail) string {
+	if req.Name == "" {
+		return req.Summary
+	}
+	return fmt.Sprintf("[%s] %s", req.Name, req.Summary)
+}
+
+// fuzzyMatch reports whether the characters of query appear in s in order,
+// ignoring case and spaces in the query
+func fuzzyMatch(query, s string) bool {
+	target := []rune(strings.ToLower(s))
+	pos := 0
+	for _, r := range strings.ToLower(query) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		for pos < len(target) && target[pos] != r {
+			pos++
+		}
+		if pos == len(target) {
+			return false
+		}
+		pos++
+	}
+	return true
+}
+
+// wrapText breaks s into lines of at most width runes at word boundaries
+func wrapText(s string, width int) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	line := ""
+	for _, word := range words {
+		for len([]rune(word)) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			lines = append(lines, string([]rune(word)[:width]))
+			word = string([]rune(word)[width:])
+		}
+		switch {
+		case line == "":
+			line = word
+		case len([]rune(line))+1+len([]rune(word)) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
@@ -0,0 +1,207 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newTestTUIModel(t *testing.T) *tuiModel {
+	t.Helper()
+	file := writeEditTestFile(t)
+	data, _ := os.ReadFile(file)
+	config, err := model.ParseYAML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newTUIModel(file, config)
+}
+
+// press sends key presses to the model; multi-rune strings are typed rune
+// by rune, a space as KeySpace the way bubbletea reports it
+func press(m *tuiModel, keys ...string) {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		default:
+			for _, r := range key {
+				if r == ' ' {
+					m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}})
+					continue
+				}
+				m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			}
+			continue
+		}
+		m.Update(msg)
+	}
+}
+
+func TestTUINavigation(t *testing.T) {
+	m := newTestTUIModel(t)
+
+	if len(m.visible) != 2 {
+		t.Fatalf("Expected top level expanded (2 lines), got %d", len(m.visible))
+	}
+
+	press(m, "left")
+	if len(m.visible) != 1 {
+		t.Errorf("Expected collapse to leave 1 line, got %d", len(m.visible))
+	}
+
+	press(m, "right", "right")
+	if got := m.selected().req.Summary; got != "Child" {
+		t.Errorf("Expected expand then move to child, selected %q", got)
+	}
+
+	press(m, "left")
+	if got := m.selected().req.Summary; got != "Parent" {
+		t.Errorf("Expected left on a leaf to move to parent, selected %q", got)
+	}
+
+	press(m, "down", "down", "up")
+	if got := m.selected().req.Summary; got != "Parent" {
+		t.Errorf("Expected cursor to stay in bounds, selected %q", got)
+	}
+}
+
+func TestTUISearch(t *testing.T) {
+	m := newTestTUIModel(t)
+	press(m, "left", "/", "p11")
+
+	if len(m.visible) != 1 || m.selected().req.Summary != "Child" {
+		t.Fatalf("Expected fuzzy search to find the collapsed child, got %d line(s)", len(m.visible))
+	}
+
+	press(m, "enter")
+	if m.searching || m.query != "p11" {
+		t.Errorf("Expected enter to keep the filter, searching=%v query=%q", m.searching, m.query)
+	}
+
+	press(m, "esc")
+	if m.query != "" || len(m.visible) != 1 {
+		t.Errorf("Expected esc to restore the tree, query %q, %d line(s)", m.query, len(m.visible))
+	}
+
+	press(m, "/", "p 1")
+	if m.query != "p 1" {
+		t.Errorf("Expected one space typed, query %q", m.query)
+	}
+}
+
+func TestTUIStatusChange(t *testing.T) {
+	m := newTestTUIModel(t)
+
+	press(m, "s")
+	if got := m.selected().req.Status; got != "proposed" {
+		t.Errorf("Expected s to advance draft to proposed, got %q", got)
+	}
+	press(m, "down", "4")
+
+	data, _ := os.ReadFile(m.file)
+	config, err := model.ParseYAML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := config.Requirements[0].Status; got != "proposed" {
+		t.Errorf("Expected parent status saved as proposed, got %q", got)
+	}
+	if got := config.Requirements[0].Requirements[0].Full.Status; got != "implemented" {
+		t.Errorf("Expected child status saved as implemented, got %q", got)
+	}
+
+	if view := m.View(); !strings.Contains(view, "implemented") {
+		t.Errorf("Expected the detail pane to show the new status:\n%s", view)
+	}
+}
+
+func TestTUIBulkActions(t *testing.T) {
+	m := newTestTUIModel(t)
+
+	press(m, "x", "x")
+	if len(m.marked) != 2 || len(m.targets()) != 2 {
+		t.Fatalf("Expected both requirements marked, got %d", len(m.marked))
+	}
+	if view := m.View(); !strings.Contains(view, "2 marked") {
+		t.Errorf("Expected the footer to count marks:\n%s", view)
+	}
+
+	press(m, "3", "t", "security", "enter", "o", "bob", "enter")
+	if m.prompt != "" {
+		t.Errorf("Expected enter to close the prompt, still prompting for %q", m.prompt)
+	}
+
+	data, _ := os.ReadFile(m.file)
+	config, err := model.ParseYAML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range []model.RequirementDetail{config.Requirements[0], *config.Requirements[0].Requirements[0].Full} {
+		if req.Status != "approved" || req.Owner != "bob" || !slices.Contains(req.Tags, "security") {
+			t.Errorf("Expected %s approved, owned by bob and tagged security, got %q %q %v",
+				req.Summary, req.Status, req.Owner, req.Tags)
+		}
+	}
+	if got := m.visible[1].req.Owner; got != "bob" {
+		t.Errorf("Expected the in-memory child updated too, owner %q", got)
+	}
+
+	press(m, "t", "two words")
+	if m.input != "two words" {
+		t.Errorf("Expected one space typed, input %q", m.input)
+	}
+	press(m, "esc", "t", "ignored", "esc", "u")
+	if len(m.marked) != 0 || len(m.targets()) != 1 {
+		t.Errorf("Expected u to clear marks and fall back to the cursor")
+	}
+	if m.selected().req.Tags[len(m.selected().req.Tags)-1] == "ignored" {
+		t.Errorf("Expected esc to cancel the tag prompt")
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query, s string
+		want     bool
+	}{
+		{"auth", "AUTH-001 User Authentication", true},
+		{"uauth", "AUTH-001 User Authentication", true},
+		{"usr auth", "AUTH-001 User Authentication", true},
+		{"htua", "AUTH-001 User Authentication", false},
+		{"", "anything", true},
+	}
+
+	for _, tt := range tests {
+		if got := fuzzyMatch(tt.query, tt.s); got != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.query, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	got := wrapText("the quick brown fox jumps", 10)
+	want := []string{"the quick", "brown fox", "jumps"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("wrapText() = %q, want %q", got, want)
+	}
+}
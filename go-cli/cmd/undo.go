@@ -0,0 +1,285 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// maxHistoryEntries is how many changes the history keeps; older ones are
+// dropped as new ones are recorded
+const maxHistoryEntries = 100
+
+var (
+	undoForce bool
+	undoList  bool
+)
+
+// historyEntry records one change to a requirements file: the command
+// that made it, the content it replaced, and a hash of the content it
+// wrote so undo can tell whether the file changed since
+type historyEntry struct {
+	Time        time.Time `json:"time"`
+	Command     string    `json:"command"`
+	File        string    `json:"file"`
+	Before      string    `json:"before"`
+	AfterSHA256 string    `json:"after_sha256"`
+
+	// path is the entry's file in the history directory
+	path string
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [file]",
+	Short: "Restore a requirements file to before the last command that changed it",
+	Long: `Undo the last change an rqm command made to a requirements file.
+
+Every command that rewrites a requirements file (bulk, draft, id assign,
+migrate, suggest-split, and edits from the TUI and MCP server) first
+records what the file held in .rqm/history, in the nearest .rqm directory
+above the file or a new one next to it. rqm undo puts the newest content recorded
+for the file back and drops the entry, so running it again undoes the
+change before. --list shows the history, newest first; the last 100 changes are
+kept.
+
+If the file was edited by other means after the change, undo refuses
+rather than throw those edits away; --force restores it anyway.
+
+The history directory is found from the file; without a file,
+.rqm/requirements.yml is found by walking up from the current directory.`,
+	Example: `  rqm undo
+  rqm undo --list
+  rqm undo requirements.yml --force`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		dir := historyDir(file)
+		if undoList {
+			entries, err := readHistory(dir)
+			if err != nil {
+				return err
+			}
+			writeHistory(os.Stdout, entries)
+			return nil
+		}
+		entry, err := undoLastChange(dir, file, undoForce)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s Restored %s to before: %s (%s)\n", okMark(), entry.File, entry.Command, entry.Time.Local().Format("2006-01-02 15:04:05"))
+		return nil
+	},
+}
+
+// historyDir is the history directory for a requirements file, in its
+// .rqm directory
+func historyDir(file string) string {
+	return filepath.Join(rqmStateDir(file), "history")
+}
+
+// writeRequirementsFile replaces a requirements file the caller read as
+// original with data. It holds the file's edit lock while it checks the
+// file still holds original, so a change made since by another command or
+// an editor isn't silently overwritten, records original in the history
+// so rqm undo can restore it, and writes data with writeFileAtomic.
+func writeRequirementsFile(file string, original, data []byte) error {
+	unlock, err := lockRequirementsFile(file)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(current, original) {
+		return fmt.Errorf("%s %w; nothing was written, run the command again to apply it to the new content", file, errFileChanged)
+	}
+	if bytes.Equal(current, data) {
+		return nil
+	}
+	entry, err := recordHistory(historyDir(file), file, current, data)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(file, data); err != nil {
+		os.Remove(entry)
+		return err
+	}
+	return nil
+}
+
+// recordHistory writes a history entry for replacing before with after in
+// file, dropping the oldest entries beyond maxHistoryEntries, and returns
+// the entry's path
+func recordHistory(dir, file string, before, after []byte) (string, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create history directory: %w", err)
+	}
+	// Keep the history out of version control without touching .gitignore
+	ignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		os.WriteFile(ignore, []byte("*\n"), 0644)
+	}
+
+	now := time.Now().UTC()
+	content, err := json.MarshalIndent(historyEntry{
+		Time:        now,
+		Command:     commandLine(),
+		File:        abs,
+		Before:      string(before),
+		AfterSHA256: contentSHA256(after),
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	// Names start with the time, so they sort oldest first
+	f, err := os.CreateTemp(dir, now.Format("20060102T150405.000000000Z")+"-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to record history: %w", err)
+	}
+	_, err = f.Write(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to record history: %w", err)
+	}
+
+	names, _ := historyNames(dir)
+	for len(names) > maxHistoryEntries {
+		os.Remove(filepath.Join(dir, names[0]))
+		names = names[1:]
+	}
+	return f.Name(), nil
+}
+
+// historyNames lists the entry files of dir, oldest first
+func historyNames(dir string) ([]string, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), ".json") {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// readHistory reads the entries of dir, newest first
+func readHistory(dir string) ([]historyEntry, error) {
+	names, err := historyNames(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]historyEntry, 0, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		path := filepath.Join(dir, names[i])
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var entry historyEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("invalid history entry %s: %w", path, err)
+		}
+		entry.path = path
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// undoLastChange restores file to the content recorded by its newest
+// entry in dir and drops the entry. The history is shared by the files
+// of a .rqm directory, so entries of other files are skipped. Unless
+// force is set it refuses when the file no longer holds what the change
+// wrote.
+func undoLastChange(dir, file string, force bool) (historyEntry, error) {
+	entries, err := readHistory(dir)
+	if err != nil {
+		return historyEntry{}, err
+	}
+	target := canonicalPath(file)
+	i := slices.IndexFunc(entries, func(e historyEntry) bool { return canonicalPath(e.File) == target })
+	if i < 0 {
+		return historyEntry{}, fmt.Errorf("nothing to undo: no changes to %s recorded in %s", file, dir)
+	}
+	entry := entries[i]
+
+	unlock, err := lockRequirementsFile(entry.File)
+	if err != nil {
+		return historyEntry{}, err
+	}
+	defer unlock()
+	current, err := os.ReadFile(entry.File)
+	if err != nil {
+		return historyEntry{}, err
+	}
+	if !force && contentSHA256(current) != entry.AfterSHA256 {
+		return historyEntry{}, fmt.Errorf("%s has changed since %s; use --force to restore it anyway, discarding those changes", entry.File, entry.Command)
+	}
+	if err := writeFileAtomic(entry.File, []byte(entry.Before)); err != nil {
+		return historyEntry{}, err
+	}
+	if err := os.Remove(entry.path); err != nil {
+		return historyEntry{}, fmt.Errorf("restored %s but failed to drop the history entry: %w", entry.File, err)
+	}
+	return entry, nil
+}
+
+// writeHistory lists entries for the terminal
+func writeHistory(w io.Writer, entries []historyEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No changes recorded")
+		return
+	}
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s  %s\n    %s\n", entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Command, paint(ansiDim, entry.File))
+	}
+}
+
+// commandLine is the command being run, as recorded in the history
+func commandLine() string {
+	return strings.Join(append([]string{"rqm"}, os.Args[1:]...), " ")
+}
+
+// contentSHA256 is the hex SHA-256 of data
+func contentSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	undoCmd.Flags().BoolVar(&undoForce, "force", false, "Restore even if the file was changed since the last recorded change")
+	undoCmd.Flags().BoolVar(&undoList, "list", false, "List the recorded changes, newest first, without undoing any")
+}
@@ -0,0 +1,150 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUndoLastChange(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".rqm"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(root, "requirements.yml")
+	original := "version: \"1.0\"\nrequirements:\n  - summary: Login\n    status: draft\n"
+	if err := os.WriteFile(file, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := historyDir(file)
+	if dir != filepath.Join(root, ".rqm", "history") {
+		t.Fatalf("historyDir = %s", dir)
+	}
+	if _, err := undoLastChange(dir, file, false); err == nil || !strings.Contains(err.Error(), "nothing to undo") {
+		t.Errorf("undo with no history: %v", err)
+	}
+
+	if err := applyRequirementEdits(file, []requirementEdit{{Summary: "Login", Set: map[string]string{"status": "approved"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyRequirementEdits(file, []requirementEdit{{Summary: "Login", AddTags: []string{"auth"}}}); err != nil {
+		t.Fatal(err)
+	}
+	approved, _ := os.ReadFile(file)
+	entries, err := readHistory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].Before != original || entries[0].File != file {
+		t.Fatalf("history = %+v", entries)
+	}
+
+	// The newest change goes first, then the one before it
+	if _, err := undoLastChange(dir, file, false); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(file); strings.Contains(string(data), "auth") || !strings.Contains(string(data), "status: approved") {
+		t.Errorf("after one undo:\n%s", data)
+	}
+	if _, err := undoLastChange(dir, file, false); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != original {
+		t.Errorf("after two undos:\n%s", data)
+	}
+	if entries, _ := readHistory(dir); len(entries) != 0 {
+		t.Errorf("%d entries left", len(entries))
+	}
+
+	// Hand edits since the change aren't thrown away without --force
+	if err := writeRequirementsFile(file, []byte(original), approved); err != nil {
+		t.Fatal(err)
+	}
+	edited := string(approved) + "# hand edit\n"
+	os.WriteFile(file, []byte(edited), 0o644)
+	if _, err := undoLastChange(dir, file, false); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("undo over a hand edit: %v", err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != edited {
+		t.Errorf("refused undo changed the file:\n%s", data)
+	}
+	if _, err := undoLastChange(dir, file, true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != original {
+		t.Errorf("after forced undo:\n%s", data)
+	}
+}
+
+func TestUndoTwoFiles(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".rqm"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	a, b := filepath.Join(root, "a.yml"), filepath.Join(root, "b.yml")
+	original := "version: \"1.0\"\nrequirements:\n  - summary: Login\n    status: draft\n"
+	for _, file := range []string{a, b} {
+		if err := os.WriteFile(file, []byte(original), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// b changes first, so a's change is the newest in the shared history
+	for _, file := range []string{b, a} {
+		if err := applyRequirementEdits(file, []requirementEdit{{Summary: "Login", Set: map[string]string{"status": "approved"}}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dir := historyDir(b)
+	if dir != historyDir(a) {
+		t.Fatalf("expected one history for both files")
+	}
+
+	entry, err := undoLastChange(dir, b, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.File != b {
+		t.Errorf("undid a change to %s", entry.File)
+	}
+	if data, _ := os.ReadFile(b); string(data) != original {
+		t.Errorf("b after undo:\n%s", data)
+	}
+	if data, _ := os.ReadFile(a); !strings.Contains(string(data), "status: approved") {
+		t.Errorf("a was restored too:\n%s", data)
+	}
+	if _, err := undoLastChange(dir, b, false); err == nil || !strings.Contains(err.Error(), "nothing to undo") {
+		t.Errorf("second undo of b: %v", err)
+	}
+	if _, err := undoLastChange(dir, a, false); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(a); string(data) != original {
+		t.Errorf("a after undo:\n%s", data)
+	}
+}
+
+func TestRecordHistoryPrunes(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "history")
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	for i := 0; i < maxHistoryEntries+3; i++ {
+		if _, err := recordHistory(dir, file, []byte("before"), []byte("after")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	names, err := historyNames(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != maxHistoryEntries {
+		t.Errorf("%d entries kept, want %d", len(names), maxHistoryEntries)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".gitignore")); err != nil {
+		t.Errorf("no .gitignore: %v", err)
+	}
+}
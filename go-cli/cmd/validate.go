@@ -6,11 +6,16 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"sync"
 
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/238855/rqm/go-cli/pkg/rqmcore"
 	"github.com/spf13/cobra"
 )
 
@@ -21,15 +26,28 @@ var embeddedValidator interface {
 	Available() bool
 }
 
+var (
+	validateFormat    string
+	validateJobs      int
+	validateRecursive bool
+)
+
 type ValidationResult struct {
 	Valid    bool     `json:"valid"`
 	Errors   []string `json:"errors"`
 	Warnings []string `json:"warnings"`
+	// Details break the validator's error down into its problems; see
+	// rqmcore.ErrorDetail
+	Details []rqmcore.ErrorDetail `json:"details,omitempty"`
+
+	// Categorized are the warnings of applyProjectChecks, which are also
+	// in Warnings; the validator's own warnings have no category
+	Categorized []ValidationWarning `json:"-"`
 }
 
 var validateCmd = &cobra.Command{
-	Use:   "validate [file]",
-	Short: "Validate a requirements YAML file",
+	Use:   "validate <file|pattern>...",
+	Short: "Validate requirements YAML files",
 	Long: `Validate a requirements YAML file against the JSON schema.
     
 This command checks:
@@ -37,103 +55,575 @@ This command checks:
   - File conforms to the requirements schema
   - All summaries are unique
   - Owner references are valid
-  - Circular references are detected`,
-	Args: cobra.ExactArgs(1),
+  - Circular references are detected
+  - Custom attributes match their declarations in .rqm/config.yml
+
+It also warns about requirements that are valid but likely wrong, in four
+categories: style (summary length and punctuation), completeness (missing
+description, owner or acceptance test), consistency (verified without a
+verification method, repeated tags or IDs, relations to self) and
+traceability (implemented or verified without verified_by or
+acceptance_test_link). All but completeness are on by default; turn
+categories on and off with --warn and --no-warn ("all" for every one), or
+under warnings: in .rqm/config.yml.
+
+Validator errors are shown as messages naming the requirement involved,
+with a suggested fix, in the language of RQM_LANG or LANG (en, de);
+--verbose adds the validator's original text.
+
+With --format json the result is printed as JSON, with the validator's
+original messages; see
+'rqm schema output validate' for its schema. --format github prints
+GitHub Actions workflow commands, so errors and warnings show up as
+annotations on the offending lines of a pull request.
+
+Inside a project with a .rqm directory, the validator's result is cached
+in .rqm/cache by the SHA-256 of the file, so validating unchanged content
+again (from hooks, for instance) doesn't rerun it. --no-cache always runs
+the validator.
+
+Several files, or glob patterns such as 'docs/**/*.rqm.yml' (quoted, so
+rqm expands them; ** matches any number of directories), are validated
+concurrently by --jobs workers and reported together: a line per file
+followed by a summary, a JSON array with one result per file, or the
+annotations of every file. With -r, directories are searched for
+requirements files (YAML files with a top-level requirements: key),
+skipping paths matched by the gitignore-style patterns of any .rqmignore
+on the way.
+
+A file of - reads the requirements from stdin, for editor plugins and
+pipelines that generate them. --filename names them in messages (default
+<stdin>); its directory is where .rqm/config.yml is looked up and its
+extension gives the format (YAML by default).
+
+Files can also be remote, so a repository can validate against an
+upstream baseline: an http(s) URL, or a file in a git repository as
+git::github.com/org/repo//.rqm/requirements.yml?ref=v1.2. Downloads are
+cached; add checksum=sha256:<hex> to the query to pin the content, which
+is then only downloaded when the cached copy doesn't match.
+
+Exits with 1 when validation fails, or 3 when a file can't be checked
+at all. --fail-on warning also fails on warnings; --fail-on none only
+reports.`,
+	Example: `  rqm validate requirements.yml
+  rqm validate 'docs/**/*.rqm.yml' --jobs 8
+  rqm validate -r .
+  cat requirements.yml | rqm validate - --filename requirements.yml
+  rqm validate 'git::github.com/org/platform//.rqm/requirements.yml?ref=v1.2'`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		file := args[0]
-		return runValidation(file)
+		if validateRecursive {
+			files, err := recursiveFileArgs(args)
+			if err != nil {
+				return err
+			}
+			return runValidations(files)
+		}
+		if len(args) == 1 && !isGlob(args[0]) {
+			return withFileArg(args[0], runValidation)
+		}
+		files, err := expandFileArgs(args)
+		if err != nil {
+			return err
+		}
+		return runValidations(files)
 	},
 }
 
 // runValidation performs the validation logic
 func runValidation(file string) error {
+	if err := checkFailOn(); err != nil {
+		return err
+	}
+
 	// Check if file exists
 	if _, err := os.Stat(file); os.IsNotExist(err) {
 		return fmt.Errorf("file does not exist: %s", file)
 	}
 
-	// Try embedded validator first (if available via CGO)
+	if validateFormat != "text" && validateFormat != "json" && validateFormat != "github" {
+		return fmt.Errorf("unknown output format: %s", validateFormat)
+	}
+
+	if validateFormat == "text" {
+		fmt.Printf("Validating %s (using %s validator)...\n", fileLabel(file), validatorKind())
+	}
+
+	result, err := validateFile(file)
+	if err != nil {
+		return err
+	}
+
+	switch validateFormat {
+	case "json":
+		return writeValidationJSON(file, result)
+	case "github":
+		writeValidationAnnotations(os.Stdout, file, result)
+		return validationFailure(result)
+	}
+	return displayValidationResult(file, result)
+}
+
+// fileValidation is the outcome of validating one of several files: its
+// result, or the error that kept it from being checked
+type fileValidation struct {
+	file   string
+	result *ValidationResult
+	err    error
+}
+
+// runValidations validates files concurrently and reports on all of them
+// once every one is done, in the order given
+func runValidations(files []string) error {
+	if err := checkFailOn(); err != nil {
+		return err
+	}
+	if validateFormat != "text" && validateFormat != "json" && validateFormat != "github" {
+		return fmt.Errorf("unknown output format: %s", validateFormat)
+	}
+	jobs := validateJobs
+	switch {
+	case jobs < 0:
+		return fmt.Errorf("--jobs must not be negative")
+	case jobs == 0:
+		jobs = runtime.NumCPU()
+	}
+
+	if validateFormat == "text" {
+		fmt.Printf("Validating %d files (using %s validator)...\n", len(files), validatorKind())
+	}
+	validations := validateFiles(files, jobs)
+
+	var failed, unchecked, warnings int
+	for _, v := range validations {
+		switch {
+		case v.err != nil:
+			unchecked++
+		case !v.result.Valid:
+			failed++
+		}
+		if v.result != nil {
+			warnings += len(v.result.Warnings)
+		}
+	}
+
+	switch validateFormat {
+	case "json":
+		outputs := make([]ValidateOutput, len(validations))
+		for i, v := range validations {
+			outputs[i] = validateOutput(v.file, v.checked())
+		}
+		if err := writeJSON(outputs); err != nil {
+			return err
+		}
+	case "github":
+		for _, v := range validations {
+			writeValidationAnnotations(os.Stdout, v.file, v.checked())
+		}
+	default:
+		for _, v := range validations {
+			result := v.checked()
+			mark := okMark()
+			if !result.Valid {
+				mark = failMark()
+			}
+			fmt.Println(mark, v.file)
+			if v.err != nil {
+				fmt.Printf("  - %v\n", v.err)
+				continue
+			}
+			printValidationErrors(v.file, result)
+			printValidationWarnings(v.file, result)
+		}
+		fmt.Printf("\n%d file(s): %d passed, %d failed", len(files), len(files)-failed-unchecked, failed)
+		if unchecked > 0 {
+			fmt.Printf(", %d could not be checked", unchecked)
+		}
+		fmt.Printf(", %d warning(s)\n", warnings)
+	}
+
+	if unchecked > 0 {
+		return fmt.Errorf("%d file(s) could not be validated", unchecked)
+	}
+	var failure error
+	if failed > 0 {
+		failure = validationError("validation failed in %d of %d file(s)", failed, len(files))
+	}
+	return applyFailOn(failure, warnings)
+}
+
+// validateFiles runs validateFile over files with a pool of jobs workers.
+// The validations are in the order of files.
+func validateFiles(files []string, jobs int) []fileValidation {
+	validations := make([]fileValidation, len(files))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(jobs, len(files)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				result, err := validateFile(files[i])
+				validations[i] = fileValidation{file: files[i], result: result, err: err}
+			}
+		}()
+	}
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return validations
+}
+
+// checked is the validation result, with the error that prevented
+// checking the file as its only error
+func (v fileValidation) checked() *ValidationResult {
+	if v.err != nil {
+		return &ValidationResult{Errors: []string{v.err.Error()}}
+	}
+	return v.result
+}
+
+// validateFile runs the embedded validator when it is linked in, or the
+// rqm-validator binary otherwise, followed by the project checks
+func validateFile(file string) (*ValidationResult, error) {
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		return nil, fmt.Errorf("file does not exist: %s", file)
+	}
+	result, err := cachedValidation(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyProjectChecks(file, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// cachedValidation returns the validator's result for file from
+// .rqm/cache when the same content was validated before, and runs the
+// validator and caches its result otherwise. The project checks aren't
+// cached: they depend on .rqm/config.yml and are cheap.
+func cachedValidation(file string) (*ValidationResult, error) {
+	cache := openValidationCache(file)
+	validator := "embedded"
+	if validatorKind() != "embedded" {
+		if validator = validatorID(findValidatorBinary()); validator == "" {
+			cache = nil
+		}
+	}
+
+	var key string
+	if cache != nil {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		key = cache.key(content, validator, "result")
+		if data, ok := cache.get(key); ok {
+			var result ValidationResult
+			if json.Unmarshal(data, &result) == nil {
+				return &result, nil
+			}
+		}
+	}
+
+	var result *ValidationResult
+	var err error
+	if validatorKind() == "embedded" {
+		result, err = runEmbeddedValidation(file)
+	} else {
+		result, err = runExternalValidation(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(result); err == nil {
+		cache.put(key, data)
+	}
+	return result, nil
+}
+
+// validatorKind names the validator validateFile uses
+func validatorKind() string {
 	if embeddedValidator != nil && embeddedValidator.Available() {
-		return runEmbeddedValidation(file)
+		return "embedded"
 	}
+	return "external"
+}
 
-	// Fall back to external validator binary
-	return runExternalValidation(file)
+// writeValidationJSON prints the result as a ValidateOutput payload
+func writeValidationJSON(file string, result *ValidationResult) error {
+	if err := writeJSON(validateOutput(file, result)); err != nil {
+		return err
+	}
+	return validationFailure(result)
 }
 
-// runEmbeddedValidation uses the CGO-linked Rust validator
-func runEmbeddedValidation(file string) error {
-	fmt.Printf("Validating %s (using embedded validator)...\n", file)
+// validateOutput is the JSON payload for the result of validating file
+func validateOutput(file string, result *ValidationResult) ValidateOutput {
+	output := ValidateOutput{
+		SchemaVersion: apiVersion,
+		File:          fileLabel(file),
+		Valid:         result.Valid,
+		Errors:        nonNil(result.Errors),
+		Warnings:      nonNil(result.Warnings),
+	}
+	if len(result.Categorized) > 0 {
+		output.WarningDetails = result.Categorized
+		output.WarningCounts = warningCounts(result.Categorized)
+	}
+	return output
+}
 
+// validationFailure is the error validate exits with under --fail-on
+func validationFailure(result *ValidationResult) error {
+	var failure error
+	if !result.Valid {
+		failure = validationError("validation failed with %d error(s)", len(result.Errors))
+	}
+	return applyFailOn(failure, len(result.Warnings))
+}
+
+// runEmbeddedValidation uses the CGO-linked Rust validator
+func runEmbeddedValidation(file string) (*ValidationResult, error) {
 	// Read file content
 	content, err := os.ReadFile(file)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if content, err = model.ToYAML(file, content); err != nil {
+		return coreResult(rqmcore.ParseFailure(err)), nil
 	}
 
 	// Call embedded Rust validator
 	result, err := embeddedValidator.ValidateYAML(string(content))
 	if err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	return displayValidationResult(result)
+	return result, nil
+}
+
+// coreResult converts a result of the rqmcore package
+func coreResult(result *rqmcore.ValidationResult) *ValidationResult {
+	return &ValidationResult{
+		Valid:    result.Valid,
+		Errors:   result.Errors,
+		Warnings: result.Warnings,
+		Details:  result.Details,
+	}
 }
 
 // runExternalValidation uses the separate rqm-validator binary
-func runExternalValidation(file string) error {
+func runExternalValidation(file string) (*ValidationResult, error) {
 	// Find the rqm-validator binary
 	validatorPath := findValidatorBinary()
 	if validatorPath == "" {
-		return fmt.Errorf("rqm-validator binary not found\nPlease run: cd rust-core && cargo build --release --bin rqm-validator")
+		return nil, fmt.Errorf("rqm-validator binary not found\nPlease run: cd rust-core && cargo build --release --bin rqm-validator")
 	}
 
-	// Call rust-core validator
-	fmt.Printf("Validating %s (using external validator)...\n", file)
+	input, cleanup, err := validatorInput(file)
+	var syntaxErr *model.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return coreResult(rqmcore.ParseFailure(err)), nil
+	}
+	if err != nil {
+		return &ValidationResult{Valid: false, Errors: []string{err.Error()}}, nil
+	}
+	defer cleanup()
 
-	validatorCmd := exec.Command(validatorPath, file)
+	// Call rust-core validator
+	validatorCmd := exec.Command(validatorPath, input)
 	output, _ := validatorCmd.CombinedOutput()
 
 	// Parse JSON output
 	var result ValidationResult
 	if jsonErr := json.Unmarshal(output, &result); jsonErr != nil {
-		return fmt.Errorf("failed to parse validator output: %w\nOutput: %s", jsonErr, string(output))
+		return nil, fmt.Errorf("failed to parse validator output: %w\nOutput: %s", jsonErr, string(output))
+	}
+
+	return &result, nil
+}
+
+// validatorInput gives the path to run the validator binary on for file.
+// The validator reads YAML, so a TOML or JSON file is converted to a
+// temporary YAML file that cleanup removes. A syntax error in the file is
+// model.ToYAML's *model.SyntaxError.
+func validatorInput(file string) (string, func(), error) {
+	if model.FormatOf(file) == model.FormatYAML {
+		return file, func() {}, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if data, err = model.ToYAML(file, data); err != nil {
+		return "", nil, err
+	}
+	f, err := os.CreateTemp("", "rqm-*.yml")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.Remove(f.Name()) }
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		cleanup()
+		return "", nil, err
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return f.Name(), cleanup, nil
+}
+
+// applyProjectChecks adds the checks driven by .rqm/config.yml, and the
+// other rules the Rust validator doesn't know about, to result
+func applyProjectChecks(file string, result *ValidationResult) error {
+	project, err := loadProjectConfig(file)
+	if err != nil {
+		return err
+	}
+	enabled, err := enabledWarnings(project)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	// Syntax errors are already reported by the Rust validator
+	config, err := model.Parse(file, content)
+	if err != nil {
+		config = nil
 	}
 
-	return displayValidationResult(&result)
+	if len(project.Attributes) > 0 {
+		errs := validateAttributeDefinitions(project.Attributes)
+		if len(errs) == 0 && config != nil {
+			errs = validateAttributes(config, project.Attributes)
+		}
+		if len(errs) > 0 {
+			result.Valid = false
+			result.Errors = append(result.Errors, errs...)
+		}
+	}
+
+	if config != nil {
+		if len(config.Includes) > 0 {
+			if errs := includeErrors(file); len(errs) > 0 {
+				result.Valid = false
+				result.Errors = append(result.Errors, errs...)
+			}
+		}
+		if errs := openQuestionErrors(config); len(errs) > 0 {
+			result.Valid = false
+			result.Errors = append(result.Errors, errs...)
+		}
+		if errs := deprecationErrors(config); len(errs) > 0 {
+			result.Valid = false
+			result.Errors = append(result.Errors, errs...)
+		}
+		if errs := uidErrors(config); len(errs) > 0 {
+			result.Valid = false
+			result.Errors = append(result.Errors, errs...)
+		}
+		if errs := attachmentErrors(file, config); len(errs) > 0 {
+			result.Valid = false
+			result.Errors = append(result.Errors, errs...)
+		}
+		if len(project.Tags) > 0 {
+			if errs := tagTaxonomyErrors(config, project.Tags); len(errs) > 0 {
+				result.Valid = false
+				result.Errors = append(result.Errors, errs...)
+			}
+		}
+		result.Categorized = collectWarnings(config, enabled)
+		if w, over := requirementBudgetWarning(file, config, project.MaxRequirements); over && enabled["style"] {
+			result.Categorized = append(result.Categorized, w)
+		}
+		for _, w := range result.Categorized {
+			result.Warnings = append(result.Warnings, w.Message)
+		}
+	}
+	return nil
 }
 
-// displayValidationResult shows the validation results to the user
-func displayValidationResult(result *ValidationResult) error {
+// displayValidationResult shows the validation results to the user, with
+// validator messages rewritten by localizeValidationErrors. --verbose
+// also shows the validator's own text.
+func displayValidationResult(file string, result *ValidationResult) error {
 	// Display results
 	if result.Valid {
-		fmt.Println("✓ YAML syntax valid")
-		fmt.Println("✓ Schema validation passed")
-		fmt.Println("✓ All summaries unique")
-		fmt.Println("✓ Owner references valid")
+		fmt.Println(okMark(), model.FormatOf(file), "syntax valid")
+		fmt.Println(okMark(), "Schema validation passed")
+		fmt.Println(okMark(), "All summaries unique")
+		fmt.Println(okMark(), "Owner references valid")
 		fmt.Println("\nValidation successful!")
-		return nil
-	}
-
-	// Display errors
-	fmt.Println("\n✗ Validation failed:")
-	for _, errMsg := range result.Errors {
-		fmt.Printf("  - %s\n", errMsg)
+	} else {
+		fmt.Printf("\n%s Validation failed:\n", failMark())
+		printValidationErrors(file, result)
 	}
 
-	// Display warnings if any
 	if len(result.Warnings) > 0 {
 		fmt.Println("\nWarnings:")
-		for _, warning := range result.Warnings {
-			fmt.Printf("  ⚠ %s\n", warning)
+		printValidationWarnings(file, result)
+		if counts := formatWarningCounts(result.Categorized); counts != "" {
+			fmt.Printf("\n%d warning(s): %s\n", len(result.Warnings), counts)
 		}
 	}
 
-	return fmt.Errorf("validation failed with %d error(s)", len(result.Errors))
+	return validationFailure(result)
 }
 
-// findValidatorBinary locates the rqm-validator binary
+// printValidationErrors lists the errors of result
+func printValidationErrors(file string, result *ValidationResult) {
+	for _, msg := range localizeValidationErrors(file, result) {
+		fmt.Printf("  - %s\n", msg.Text)
+		printMessageDetails(msg)
+	}
+}
+
+// printValidationWarnings lists the warnings of result, the validator's
+// first and then by category
+func printValidationWarnings(file string, result *ValidationResult) {
+	uncategorized := result.Warnings[:len(result.Warnings)-len(result.Categorized)]
+	for _, msg := range plainMessages(uncategorized) {
+		fmt.Printf("  %s %s\n", warnMark(), msg.Text)
+		printMessageDetails(msg)
+	}
+	for _, w := range result.Categorized {
+		fmt.Printf("  %s [%s] %s\n", warnMark(), w.Category, w.Message)
+	}
+}
+
+// printMessageDetails prints the fix under a message, and the validator's
+// original text with --verbose
+func printMessageDetails(msg friendlyMessage) {
+	if msg.Fix != "" {
+		fmt.Printf("    %s: %s\n", msg.FixLabel, msg.Fix)
+	}
+	if verbose, _ := rootCmd.PersistentFlags().GetBool("verbose"); verbose && msg.Raw != msg.Text {
+		fmt.Printf("    (%s)\n", msg.Raw)
+	}
+}
+
+// findValidatorBinary locates the rqm-validator binary. RQM_VALIDATOR, when
+// set, names it explicitly.
 func findValidatorBinary() string {
+	if path := os.Getenv("RQM_VALIDATOR"); path != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			return abs
+		}
+		return path
+	}
+
 	// Get current working directory to help construct relative paths
 	cwd, _ := os.Getwd()
 
@@ -174,4 +664,11 @@ func findValidatorBinary() string {
 
 func init() {
 	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().StringVarP(&validateFormat, "format", "f", "text", "Output format (text, json, github)")
+	validateCmd.Flags().IntVarP(&validateJobs, "jobs", "j", 0, "Number of files to validate at once (default: the number of CPUs)")
+	validateCmd.Flags().BoolVarP(&validateRecursive, "recursive", "r", false, "Validate the requirements files found under directory arguments, honoring .rqmignore")
+	validateCmd.Flags().StringVar(&stdinFilename, "filename", "", "Name to show for requirements read from stdin (-); its extension gives their format")
+	validateCmd.Flags().StringVar(&failOn, "fail-on", "error", "Lowest severity that fails the command (error, warning, none)")
+	validateCmd.Flags().StringSliceVar(&warnCategories, "warn", nil, "Warning categories to enable (style, completeness, consistency, traceability, all)")
+	validateCmd.Flags().StringSliceVar(&noWarnCategories, "no-warn", nil, "Warning categories to disable (style, completeness, consistency, traceability, all)")
 }
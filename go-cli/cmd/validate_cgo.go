@@ -18,11 +18,7 @@ func (v *validatorWrapper) ValidateYAML(content string) (*ValidationResult, erro
 	if err != nil {
 		return nil, err
 	}
-	return &ValidationResult{
-		Valid:    result.Valid,
-		Errors:   result.Errors,
-		Warnings: result.Warnings,
-	}, nil
+	return coreResult(result), nil
 }
 
 func (v *validatorWrapper) Available() bool {
@@ -3,6 +3,8 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -163,3 +165,52 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestValidateFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake validator is a shell script")
+	}
+	if validatorKind() == "embedded" {
+		t.Skip("embedded validator doesn't run rqm-validator")
+	}
+
+	// The fake validator fails files whose name contains "bad"
+	tmpDir := t.TempDir()
+	validator := filepath.Join(tmpDir, "rqm-validator")
+	script := `#!/bin/sh
+case "$1" in
+*bad*) echo '{"valid":false,"errors":["broken"],"warnings":[]}' ;;
+*) echo '{"valid":true,"errors":[],"warnings":[]}' ;;
+esac
+`
+	if err := os.WriteFile(validator, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("RQM_VALIDATOR", validator)
+
+	var files []string
+	for _, name := range []string{"a.yml", "bad.yml", "c.yml", "d.yml"} {
+		file := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(file, []byte("version: \"1.0\"\nrequirements: []\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+	files = append(files, filepath.Join(tmpDir, "missing.yml"))
+
+	validations := validateFiles(files, 3)
+	for i, v := range validations {
+		if v.file != files[i] {
+			t.Fatalf("Validation %d is for %s, want %s", i, v.file, files[i])
+		}
+	}
+	if !validations[0].result.Valid || validations[1].result.Valid {
+		t.Errorf("Expected only bad.yml to fail, got %v and %v", validations[0].result, validations[1].result)
+	}
+	if err := validations[4].err; err == nil || !strings.Contains(err.Error(), "file does not exist") {
+		t.Errorf("Expected missing.yml to fail to validate, got %v", err)
+	}
+	if got := validations[4].checked(); got.Valid || len(got.Errors) != 1 {
+		t.Errorf("Expected the error as the result of missing.yml, got %v", got)
+	}
+}
@@ -0,0 +1,261 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	verifyContext string
+	verifyFormat  string
+)
+
+// Policy mirrors .rqm/policy.yml: the gates rqm verify runs in each
+// context, such as pre-commit, pr or release
+type Policy struct {
+	Contexts map[string]PolicyContext `yaml:"contexts"`
+
+	// path is the file the policy was read from
+	path string
+}
+
+// PolicyContext is the set of gates of one context and their thresholds
+type PolicyContext struct {
+	Gates []string `yaml:"gates"`
+	// FailOn is the --fail-on of every gate, error by default
+	FailOn string `yaml:"fail_on,omitempty"`
+	// MinCoverage is the percentage of verified requirements the coverage
+	// gate requires
+	MinCoverage float64  `yaml:"min_coverage,omitempty"`
+	Warn        []string `yaml:"warn,omitempty"`
+	NoWarn      []string `yaml:"no_warn,omitempty"`
+}
+
+// policyGates are the gates a policy can name, in the order rqm verify
+// documents them
+var policyGates = []string{"validate", "check", "coverage", "child-coverage", "owners-active", "test"}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [file]",
+	Short: "Run the gates .rqm/policy.yml defines for a context",
+	Long: `Run the gates that .rqm/policy.yml lists for --context, with that
+context's thresholds, and print a summary table like rqm ci. Keeping the
+gates of every pipeline in one file saves copying flags between hooks and
+CI jobs:
+
+  contexts:
+    pre-commit:
+      gates: [validate, check]
+    pr:
+      gates: [validate, check, coverage]
+      min_coverage: 60
+    release:
+      gates: [validate, check, coverage, child-coverage, test]
+      fail_on: warning
+      min_coverage: 90
+      warn: [all]
+
+Gates:
+  validate        rqm validate, with the context's warn and no_warn
+                  categories
+  check           circular references, as rqm check
+  coverage        fail below min_coverage percent of requirements verified
+  child-coverage  rqm check --child-coverage
+  owners-active   rqm check --owners-active
+  test            rqm test, running the acceptance test scripts
+
+fail_on (error, warning or none) applies to every gate of the context.
+Gates run in the order listed, every one even after a failure, and the
+exit code is the most severe result, as for rqm ci.
+
+Without a file, .rqm/requirements.yml is found by walking up from the
+current directory; .rqm/policy.yml is found the same way from the file.`,
+	Example: `  rqm verify --context pre-commit
+  rqm verify requirements.yml --context release
+  rqm verify --context pr --format github`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	// Failures are check results; usage would only clutter the job log
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := verifyFormat
+		if format == "" {
+			format = "text"
+			if os.Getenv("GITHUB_ACTIONS") == "true" {
+				format = "github"
+			}
+		}
+		if format != "text" && format != "github" {
+			return fmt.Errorf("unknown output format: %s", format)
+		}
+		file, err := requirementsFileArg(args)
+		if err != nil {
+			return err
+		}
+		policy, err := loadPolicy(file)
+		if err != nil {
+			return err
+		}
+		context, err := policy.context(verifyContext)
+		if err != nil {
+			return err
+		}
+
+		failOn, warnCategories, noWarnCategories = context.FailOn, context.Warn, context.NoWarn
+		if failOn == "" {
+			failOn = "error"
+		}
+		if err := checkFailOn(); err != nil {
+			return fmt.Errorf("%s: context %s: %w", policy.path, verifyContext, err)
+		}
+
+		var annotations io.Writer = io.Discard
+		if format == "github" {
+			annotations = os.Stdout
+		}
+		var steps []ciStep
+		for _, gate := range context.Gates {
+			steps = append(steps, runPolicyGate(gate, file, context, annotations))
+		}
+
+		fmt.Printf("\nrqm verify: %s (context %s)\n\n", file, verifyContext)
+		writeCISummary(os.Stdout, steps)
+		return stepsFailure(steps)
+	},
+}
+
+// loadPolicy reads the .rqm/policy.yml that applies to a requirements file
+func loadPolicy(file string) (*Policy, error) {
+	path := findInRQMDir(filepath.Dir(file), "policy.yml")
+	if path == "" {
+		return nil, fmt.Errorf("no .rqm/policy.yml found for %s", file)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var policy Policy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+	policy.path = path
+
+	for _, name := range sortedKeys(policy.Contexts) {
+		for _, gate := range policy.Contexts[name].Gates {
+			if !slices.Contains(policyGates, gate) {
+				return nil, fmt.Errorf("%s: context %s: unknown gate %s (expected one of %s)", path, name, gate, strings.Join(policyGates, ", "))
+			}
+		}
+	}
+	return &policy, nil
+}
+
+// context returns the named context of the policy
+func (p *Policy) context(name string) (PolicyContext, error) {
+	if name == "" {
+		return PolicyContext{}, fmt.Errorf("no --context given (%s defines: %s)", p.path, strings.Join(sortedKeys(p.Contexts), ", "))
+	}
+	context, ok := p.Contexts[name]
+	if !ok {
+		return PolicyContext{}, fmt.Errorf("unknown context: %s (%s defines: %s)", name, p.path, strings.Join(sortedKeys(p.Contexts), ", "))
+	}
+	return context, nil
+}
+
+// runPolicyGate runs one gate of context on file
+func runPolicyGate(gate, file string, context PolicyContext, annotations io.Writer) ciStep {
+	switch gate {
+	case "validate":
+		return validateStep(file, annotations)
+	case "check":
+		return checkStep(file, annotations)
+	case "coverage":
+		return coverageStep(file, annotations, context.MinCoverage)
+	case "child-coverage":
+		return childCoverageStep(file, annotations)
+	case "owners-active":
+		return ownersActiveStep(file, annotations)
+	default:
+		return acceptanceTestStep(file)
+	}
+}
+
+// childCoverageStep checks that children cover the acceptance criteria of
+// their parents
+func childCoverageStep(file string, annotations io.Writer) ciStep {
+	step := ciStep{Name: "child-coverage"}
+	config, _, err := loadRequirements(file)
+	if err != nil {
+		step.Result, step.Details, step.err = "error", err.Error(), err
+		return step
+	}
+	gaps := findCoverageGaps(config)
+	writeCheckAnnotations(annotations, file, &CycleCheckResult{}, nil, gaps, nil)
+	step.err = checkFailure(&CycleCheckResult{}, nil, gaps, nil, 0)
+	step.Result = ciResult(step.err)
+	step.Details = fmt.Sprintf("%d problem(s) with child coverage", len(gaps))
+	for _, gap := range gaps {
+		step.Requirements = appendUnique(step.Requirements, gap.Summary)
+	}
+	return step
+}
+
+// ownersActiveStep checks that owners are active in the directory of
+// .rqm/config.yml
+func ownersActiveStep(file string, annotations io.Writer) ciStep {
+	step := ciStep{Name: "owners-active"}
+	inactive, unresolved, err := findInactiveOwners(file)
+	if err != nil {
+		step.Result, step.Details, step.err = "error", err.Error(), err
+		return step
+	}
+	writeCheckAnnotations(annotations, file, &CycleCheckResult{}, inactive, nil, nil)
+	step.err = checkFailure(&CycleCheckResult{}, inactive, nil, nil, len(unresolved))
+	step.Result = ciResult(step.err)
+	step.Details = fmt.Sprintf("%d inactive owner(s), %d not looked up", len(inactive), len(unresolved))
+	for _, owner := range inactive {
+		step.Requirements = appendUnique(step.Requirements, owner.Summary)
+	}
+	return step
+}
+
+// acceptanceTestStep runs the acceptance test scripts, as rqm test does
+func acceptanceTestStep(file string) ciStep {
+	step := ciStep{Name: "test"}
+	config, _, err := loadRequirements(file)
+	if err != nil {
+		step.Result, step.Details, step.err = "error", err.Error(), err
+		return step
+	}
+	counts := make(map[string]int)
+	results := runAcceptanceTests(config, file, func(result acceptanceResult) {
+		counts[result.Result]++
+		if result.Result == "fail" || result.Result == "error" {
+			step.Requirements = append(step.Requirements, requirementLabel(result.req))
+		}
+	})
+	step.Details = fmt.Sprintf("%d test(s): %d passed, %d failed, %d could not run, %d skipped",
+		len(results), counts["pass"], counts["fail"], counts["error"], counts["skip"])
+	if failed := counts["fail"] + counts["error"]; failed > 0 {
+		step.err = applyFailOn(validationError("%d acceptance test(s) failed", failed), 0)
+	}
+	step.Result = ciResult(step.err)
+	return step
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+	verifyCmd.Flags().StringVar(&verifyContext, "context", "", "Context of .rqm/policy.yml whose gates to run (e.g. pre-commit, pr, release)")
+	verifyCmd.Flags().StringVarP(&verifyFormat, "format", "f", "", "Output format (text, github; default github under GitHub Actions)")
+}
@@ -0,0 +1,101 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "requirements.yml")
+	os.WriteFile(file, []byte("version: \"1.0\"\nrequirements: []\n"), 0644)
+	if _, err := loadPolicy(file); err == nil || !strings.Contains(err.Error(), "no .rqm/policy.yml") {
+		t.Errorf("loadPolicy() without a policy = %v, want an error", err)
+	}
+
+	os.MkdirAll(filepath.Join(dir, ".rqm"), 0755)
+	writePolicy := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, ".rqm", "policy.yml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writePolicy(`contexts:
+  pre-commit:
+    gates: [validate, check]
+  release:
+    gates: [validate, coverage, test]
+    fail_on: warning
+    min_coverage: 90
+    no_warn: [style]
+`)
+	policy, err := loadPolicy(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release, err := policy.context("release")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(release.Gates, ",") != "validate,coverage,test" || release.FailOn != "warning" || release.MinCoverage != 90 || strings.Join(release.NoWarn, ",") != "style" {
+		t.Errorf("release context = %+v", release)
+	}
+	if _, err := policy.context("nightly"); err == nil || !strings.Contains(err.Error(), "defines: pre-commit, release") {
+		t.Errorf("context(nightly) = %v, want an error listing the contexts", err)
+	}
+	if _, err := policy.context(""); err == nil {
+		t.Error("context(\"\") should fail")
+	}
+
+	writePolicy("contexts:\n  pr:\n    gates: [validate, lint]\n")
+	if _, err := loadPolicy(file); err == nil || !strings.Contains(err.Error(), "unknown gate lint") {
+		t.Errorf("loadPolicy() with an unknown gate = %v", err)
+	}
+}
+
+func TestRunPolicyGates(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	os.WriteFile(file, []byte(`version: "1.0"
+requirements:
+  - summary: Login
+    verification: test
+    acceptance_criteria:
+      - id: AC1
+        criterion: Users sign in
+    requirements:
+      - summary: Password sign-in
+  - summary: Logout
+`), 0644)
+	failOn = "error"
+	context := PolicyContext{Gates: []string{"coverage", "child-coverage"}, MinCoverage: 50}
+
+	coverage := runPolicyGate("coverage", file, context, io.Discard)
+	if coverage.Result != "fail" || !strings.Contains(coverage.Details, "1/3 requirement(s) verified") {
+		t.Errorf("coverage gate = %s: %s", coverage.Result, coverage.Details)
+	}
+	if exitCode(coverage.err) != exitValidation {
+		t.Errorf("coverage gate exit code = %d, want %d", exitCode(coverage.err), exitValidation)
+	}
+
+	children := runPolicyGate("child-coverage", file, context, io.Discard)
+	if children.Result != "fail" || strings.Join(children.Requirements, ",") != "Login" {
+		t.Errorf("child-coverage gate = %s %v: %s", children.Result, children.Requirements, children.Details)
+	}
+
+	tests := runPolicyGate("test", file, context, io.Discard)
+	if tests.Result != "pass" || !strings.HasPrefix(tests.Details, "0 test(s)") {
+		t.Errorf("test gate = %s: %s", tests.Result, tests.Details)
+	}
+
+	if err := stepsFailure([]ciStep{coverage, children, tests}); exitCode(err) != exitValidation {
+		t.Errorf("stepsFailure() = %v, want a validation failure", err)
+	}
+}
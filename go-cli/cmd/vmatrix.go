@@ -0,0 +1,179 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	vmatrixFormat string
+	vmatrixOutput string
+)
+
+// verificationMethods are the allowed values of the verification field, in column order
+var verificationMethods = []string{"test", "analysis", "inspection", "demonstration"}
+
+// vmatrixRow is one requirement line of the verification matrix
+type vmatrixRow struct {
+	ID        string
+	Summary   string
+	Status    string
+	Method    string
+	Artifacts []string
+	// Hash is the requirement's canonical hash, see rqm hash
+	Hash string
+}
+
+var vmatrixCmd = &cobra.Command{
+	Use:   "vmatrix [file]",
+	Short: "Produce a verification cross-reference matrix",
+	Long: `Produce a verification cross-reference matrix (V&V matrix) mapping every
+requirement to its verification method and the artifacts that verify it.
+
+Columns:
+  - ID, Summary, Status
+  - Test / Analysis / Inspection / Demonstration (X marks the method)
+  - Verified By (verified_by entries plus the acceptance_test_link)
+  - Hash (the requirement's 'rqm hash', to tell when a verified
+    requirement has changed since)
+
+Requirements without a verification method are listed with empty method
+columns so gaps are visible in the matrix.`,
+	Example: `  rqm vmatrix requirements.yml
+  rqm vmatrix requirements.yml --format html -o vmatrix.html`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRequirementsFile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, _, err := loadRequirements(args[0])
+		if err != nil {
+			return err
+		}
+
+		out := io.Writer(os.Stdout)
+		if vmatrixOutput != "" {
+			f, err := os.Create(vmatrixOutput)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", vmatrixOutput, err)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		rows := buildVMatrix(config)
+		switch vmatrixFormat {
+		case "csv":
+			return writeVMatrixCSV(out, rows)
+		case "html":
+			return writeVMatrixHTML(out, rows)
+		default:
+			return fmt.Errorf("unknown output format: %s", vmatrixFormat)
+		}
+	},
+}
+
+// buildVMatrix collects one row per requirement in tree order
+func buildVMatrix(config *model.RequirementConfig) []vmatrixRow {
+	var rows []vmatrixRow
+	for _, req := range config.Flatten() {
+		artifacts := append([]string{}, req.VerifiedBy...)
+		if req.AcceptanceTestLink != "" && !slices.Contains(artifacts, req.AcceptanceTestLink) {
+			artifacts = append(artifacts, req.AcceptanceTestLink)
+		}
+		rows = append(rows, vmatrixRow{
+			ID:        req.Name,
+			Summary:   req.Summary,
+			Status:    req.Status,
+			Method:    req.Verification,
+			Artifacts: artifacts,
+			Hash:      requirementHash(req),
+		})
+	}
+	return rows
+}
+
+func vmatrixHeader() []string {
+	header := []string{"ID", "Summary", "Status"}
+	for _, method := range verificationMethods {
+		header = append(header, strings.ToUpper(method[:1])+method[1:])
+	}
+	return append(header, "Verified By", "Hash")
+}
+
+// methodMarks returns an "X" in the column of the row's verification method
+func (r vmatrixRow) methodMarks() []string {
+	marks := make([]string, len(verificationMethods))
+	for i, method := range verificationMethods {
+		if r.Method == method {
+			marks[i] = "X"
+		}
+	}
+	return marks
+}
+
+func writeVMatrixCSV(out io.Writer, rows []vmatrixRow) error {
+	w := csv.NewWriter(out)
+	w.Write(vmatrixHeader())
+	for _, row := range rows {
+		record := []string{row.ID, row.Summary, row.Status}
+		record = append(record, row.methodMarks()...)
+		record = append(record, strings.Join(row.Artifacts, "; "), row.Hash)
+		w.Write(record)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func writeVMatrixHTML(out io.Writer, rows []vmatrixRow) error {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Verification Matrix</title>\n")
+	b.WriteString("<style>table{border-collapse:collapse}th,td{border:1px solid #ccc;padding:4px 8px}td.mark{text-align:center}tr.unverified{background:#fff4e5}</style>\n")
+	b.WriteString("</head>\n<body>\n<h1>Verification Matrix</h1>\n<table>\n<tr>")
+	for _, col := range vmatrixHeader() {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(col))
+	}
+	b.WriteString("</tr>\n")
+
+	for _, row := range rows {
+		if row.Method == "" {
+			b.WriteString("<tr class=\"unverified\">")
+		} else {
+			b.WriteString("<tr>")
+		}
+		fmt.Fprintf(&b, "<td>%s</td><td>%s</td><td>%s</td>",
+			html.EscapeString(row.ID), html.EscapeString(row.Summary), html.EscapeString(row.Status))
+		for _, mark := range row.methodMarks() {
+			fmt.Fprintf(&b, "<td class=\"mark\">%s</td>", mark)
+		}
+		var links []string
+		for _, artifact := range row.Artifacts {
+			if strings.HasPrefix(artifact, "http://") || strings.HasPrefix(artifact, "https://") {
+				links = append(links, fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(artifact), html.EscapeString(artifact)))
+			} else {
+				links = append(links, html.EscapeString(artifact))
+			}
+		}
+		fmt.Fprintf(&b, "<td>%s</td><td><code>%s</code></td></tr>\n", strings.Join(links, "<br>"), row.Hash[:shortHashLength])
+	}
+
+	b.WriteString("</table>\n</body>\n</html>\n")
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(vmatrixCmd)
+	vmatrixCmd.Flags().StringVarP(&vmatrixFormat, "format", "f", "csv", "Output format (csv, html)")
+	vmatrixCmd.Flags().StringVarP(&vmatrixOutput, "output", "o", "", "Write the matrix to this file instead of stdout")
+}
@@ -0,0 +1,79 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+func testVMatrixConfig() *model.RequirementConfig {
+	return &model.RequirementConfig{
+		Version: "1.0",
+		Requirements: []model.RequirementDetail{
+			{
+				Summary:            "Login",
+				Name:               "AUTH-001",
+				Status:             "implemented",
+				Verification:       "test",
+				VerifiedBy:         []string{"tests/login_test.go"},
+				AcceptanceTestLink: "https://example.com/tests/login",
+				Requirements: []model.RequirementReference{
+					{Full: &model.RequirementDetail{Summary: "Password <policy>", Name: "AUTH-002"}},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildVMatrix(t *testing.T) {
+	rows := buildVMatrix(testVMatrixConfig())
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if got := rows[0].Artifacts; len(got) != 2 || got[1] != "https://example.com/tests/login" {
+		t.Errorf("Expected verified_by plus acceptance link, got %v", got)
+	}
+	if marks := rows[0].methodMarks(); marks[0] != "X" || marks[1] != "" {
+		t.Errorf("Expected test column marked, got %v", marks)
+	}
+	if rows[1].Method != "" {
+		t.Errorf("Expected child without method, got %q", rows[1].Method)
+	}
+}
+
+func TestWriteVMatrix(t *testing.T) {
+	rows := buildVMatrix(testVMatrixConfig())
+
+	var csvOut bytes.Buffer
+	if err := writeVMatrixCSV(&csvOut, rows); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if lines[0] != "ID,Summary,Status,Test,Analysis,Inspection,Demonstration,Verified By,Hash" {
+		t.Errorf("Unexpected header: %s", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "AUTH-001,Login,implemented,X,,,,") {
+		t.Errorf("Unexpected row: %s", lines[1])
+	}
+
+	var htmlOut bytes.Buffer
+	if err := writeVMatrixHTML(&htmlOut, rows); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := htmlOut.String()
+	if !strings.Contains(output, "Password &lt;policy&gt;") {
+		t.Errorf("Expected escaped summary in HTML output")
+	}
+	if !strings.Contains(output, `<a href="https://example.com/tests/login">`) {
+		t.Errorf("Expected linked artifact in HTML output")
+	}
+	if !strings.Contains(output, `class="unverified"`) {
+		t.Errorf("Expected unverified row to be highlighted")
+	}
+}
@@ -0,0 +1,210 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+var (
+	warnCategories   []string
+	noWarnCategories []string
+)
+
+// warningCategories are the kinds of warning validate reports, in the
+// order summaries list them
+var warningCategories = []string{"style", "completeness", "consistency", "traceability"}
+
+// defaultWarnings are the categories enabled without --warn or a
+// warnings: section in .rqm/config.yml. Completeness warnings are opt-in
+// because early drafts rarely have every field filled in.
+var defaultWarnings = map[string]bool{
+	"style":        true,
+	"completeness": false,
+	"consistency":  true,
+	"traceability": true,
+}
+
+// styleSummaryLength is the longest summary that doesn't get a style warning
+const styleSummaryLength = 80
+
+// ValidationWarning is a warning with its category and the requirement it
+// is about
+type ValidationWarning struct {
+	Category    string `json:"category"`
+	Requirement string `json:"requirement,omitempty"`
+	Message     string `json:"message"`
+}
+
+// requirementWarnings are the checks of each category, run on every
+// requirement. They return one message per problem.
+var requirementWarnings = map[string]func(req *model.RequirementDetail) []string{
+	"style": func(req *model.RequirementDetail) []string {
+		var msgs []string
+		if strings.TrimSpace(req.Summary) != req.Summary {
+			msgs = append(msgs, fmt.Sprintf("Requirement '%s' has a summary with leading or trailing spaces", req.Summary))
+		}
+		if n := len([]rune(req.Summary)); n > styleSummaryLength {
+			msgs = append(msgs, fmt.Sprintf("Requirement '%s' has a summary of %d characters; keep it under %d", req.Summary, n, styleSummaryLength))
+		}
+		if strings.HasSuffix(req.Summary, ".") {
+			msgs = append(msgs, fmt.Sprintf("Requirement '%s' has a summary ending in a period", req.Summary))
+		}
+		return msgs
+	},
+	"completeness": func(req *model.RequirementDetail) []string {
+		var msgs []string
+		if req.Description == "" {
+			msgs = append(msgs, fmt.Sprintf("Requirement '%s' has no description", req.Summary))
+		}
+		if req.Owner == "" {
+			msgs = append(msgs, fmt.Sprintf("Requirement '%s' has no owner", req.Summary))
+		}
+		if pastStatus(req.Status, "approved") && req.AcceptanceTest == "" && req.AcceptanceTestLink == "" {
+			msgs = append(msgs, fmt.Sprintf("Requirement '%s' is %s but has no acceptance test", req.Summary, req.Status))
+		}
+		return msgs
+	},
+	"consistency": func(req *model.RequirementDetail) []string {
+		var msgs []string
+		if req.Status == "verified" && req.Verification == "" {
+			msgs = append(msgs, fmt.Sprintf("Requirement '%s' is verified but has no verification method", req.Summary))
+		}
+		seen := make(map[string]bool)
+		for _, tag := range req.Tags {
+			if seen[tag] {
+				msgs = append(msgs, fmt.Sprintf("Requirement '%s' has the tag '%s' more than once", req.Summary, tag))
+			}
+			seen[tag] = true
+		}
+		for _, rel := range req.Relations {
+			if rel.Target == req.Summary || req.Name != "" && rel.Target == req.Name {
+				msgs = append(msgs, fmt.Sprintf("Requirement '%s' has a %s relation to itself", req.Summary, rel.Type))
+			}
+		}
+		return msgs
+	},
+	"traceability": func(req *model.RequirementDetail) []string {
+		if pastStatus(req.Status, "implemented") && len(req.VerifiedBy) == 0 && req.AcceptanceTestLink == "" {
+			return []string{fmt.Sprintf("Requirement '%s' is %s but nothing traces to its verification (no verified_by or acceptance_test_link)", req.Summary, req.Status)}
+		}
+		return nil
+	},
+}
+
+// requirementLifecycle orders the statuses a requirement moves through
+var requirementLifecycle = []string{"draft", "proposed", "approved", "implemented", "verified"}
+
+// pastStatus reports whether status is at or beyond stage in the lifecycle
+func pastStatus(status, stage string) bool {
+	i := slices.Index(requirementLifecycle, status)
+	return i >= 0 && i >= slices.Index(requirementLifecycle, stage)
+}
+
+// collectWarnings runs the checks of the enabled categories on config.
+// Warnings that span requirements, like a shared ID or an owner outside
+// the aliases, are consistency warnings too.
+func collectWarnings(config *model.RequirementConfig, enabled map[string]bool) []ValidationWarning {
+	var warnings []ValidationWarning
+	all := config.Flatten()
+	for _, category := range warningCategories {
+		if !enabled[category] {
+			continue
+		}
+		for _, req := range all {
+			for _, msg := range requirementWarnings[category](req) {
+				warnings = append(warnings, ValidationWarning{Category: category, Requirement: req.Summary, Message: msg})
+			}
+		}
+		if category == "consistency" {
+			warnings = append(warnings, duplicateIDWarnings(all)...)
+			warnings = append(warnings, unknownOwnerWarnings(config, all)...)
+			warnings = append(warnings, tagVariantWarnings(all)...)
+			warnings = append(warnings, brokenLinkWarnings(config, all)...)
+		}
+	}
+	return warnings
+}
+
+// duplicateIDWarnings flags IDs (names) used by more than one requirement
+func duplicateIDWarnings(all []*model.RequirementDetail) []ValidationWarning {
+	var warnings []ValidationWarning
+	first := make(map[string]string)
+	for _, req := range all {
+		if req.Name == "" {
+			continue
+		}
+		if other, ok := first[req.Name]; ok {
+			warnings = append(warnings, ValidationWarning{
+				Category:    "consistency",
+				Requirement: req.Summary,
+				Message:     fmt.Sprintf("Requirement '%s' has the ID '%s', which '%s' already uses", req.Summary, req.Name, other),
+			})
+			continue
+		}
+		first[req.Name] = req.Summary
+	}
+	return warnings
+}
+
+// enabledWarnings combines the defaults, the warnings: section of the
+// project config and --warn/--no-warn, in that order of precedence
+func enabledWarnings(project *ProjectConfig) (map[string]bool, error) {
+	enabled := make(map[string]bool, len(warningCategories))
+	for category, on := range defaultWarnings {
+		enabled[category] = on
+	}
+	for _, category := range sortedKeys(project.Warnings) {
+		if !slices.Contains(warningCategories, category) {
+			return nil, fmt.Errorf("unknown warning category in %s: %s (supported: %s)", project.path, category, strings.Join(warningCategories, ", "))
+		}
+		enabled[category] = project.Warnings[category]
+	}
+
+	for _, flag := range []struct {
+		categories []string
+		on         bool
+	}{{warnCategories, true}, {noWarnCategories, false}} {
+		for _, category := range flag.categories {
+			switch {
+			case category == "all":
+				for _, c := range warningCategories {
+					enabled[c] = flag.on
+				}
+			case slices.Contains(warningCategories, category):
+				enabled[category] = flag.on
+			default:
+				return nil, fmt.Errorf("unknown warning category: %s (supported: %s, all)", category, strings.Join(warningCategories, ", "))
+			}
+		}
+	}
+	return enabled, nil
+}
+
+// warningCounts counts warnings per category
+func warningCounts(warnings []ValidationWarning) map[string]int {
+	counts := make(map[string]int)
+	for _, w := range warnings {
+		counts[w.Category]++
+	}
+	return counts
+}
+
+// formatWarningCounts lists the non-zero counts, as in "2 style, 1
+// traceability", or "" without any categorized warnings
+func formatWarningCounts(warnings []ValidationWarning) string {
+	counts := warningCounts(warnings)
+	var parts []string
+	for _, category := range warningCategories {
+		if counts[category] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[category], category))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,132 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+const warningsYAML = `version: "1.0"
+requirements:
+  - summary: Users can log in.
+    name: REQ-1
+    status: implemented
+    tags: [auth, auth]
+    relations:
+      - type: depends_on
+        target: REQ-1
+  - summary: Sessions expire
+    name: REQ-1
+    description: After 30 minutes of inactivity
+    owner: alice@example.com
+    status: verified
+    verified_by: [TestSessionExpiry]
+    acceptance_test: idle for 31 minutes, then request a page
+`
+
+func TestCollectWarnings(t *testing.T) {
+	config, err := model.ParseYAML([]byte(warningsYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := map[string]bool{"style": true, "completeness": true, "consistency": true, "traceability": true}
+	warnings := collectWarnings(config, all)
+	counts := warningCounts(warnings)
+	want := map[string]int{"style": 1, "completeness": 3, "consistency": 4, "traceability": 1}
+	for category, n := range want {
+		if counts[category] != n {
+			t.Errorf("%s: got %d warning(s), want %d: %+v", category, counts[category], n, warnings)
+		}
+	}
+	if got := formatWarningCounts(warnings); got != "1 style, 3 completeness, 4 consistency, 1 traceability" {
+		t.Errorf("formatWarningCounts() = %q", got)
+	}
+
+	some := collectWarnings(config, map[string]bool{"traceability": true})
+	if len(some) != 1 || some[0].Requirement != "Users can log in." {
+		t.Errorf("Expected only the traceability warning, got %+v", some)
+	}
+}
+
+func TestEnabledWarnings(t *testing.T) {
+	defer func() { warnCategories, noWarnCategories = nil, nil }()
+
+	tests := []struct {
+		name        string
+		config      map[string]bool
+		warn        []string
+		noWarn      []string
+		want        []string
+		errContains string
+	}{
+		{name: "defaults", want: []string{"style", "consistency", "traceability"}},
+		{name: "config", config: map[string]bool{"completeness": true, "style": false}, want: []string{"completeness", "consistency", "traceability"}},
+		{name: "flags override config", config: map[string]bool{"style": false}, warn: []string{"style"}, noWarn: []string{"traceability"}, want: []string{"style", "consistency"}},
+		{name: "all", noWarn: []string{"all"}, want: nil},
+		{name: "all but one", warn: []string{"all"}, noWarn: []string{"style"}, want: []string{"completeness", "consistency", "traceability"}},
+		{name: "unknown flag", warn: []string{"grammar"}, errContains: "unknown warning category: grammar"},
+		{name: "unknown config", config: map[string]bool{"grammar": true}, errContains: "unknown warning category"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnCategories, noWarnCategories = tt.warn, tt.noWarn
+			enabled, err := enabledWarnings(&ProjectConfig{Warnings: tt.config})
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, category := range warningCategories {
+				if enabled[category] {
+					got = append(got, category)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Enabled %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyProjectChecksWarnings(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".rqm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "warnings:\n  style: false\n  consistency: false\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".rqm", "config.yml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(tmpDir, "requirements.yml")
+	if err := os.WriteFile(file, []byte(warningsYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := &ValidationResult{Valid: true, Warnings: []string{"from the validator"}}
+	if err := applyProjectChecks(file, result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Categorized) != 1 || result.Categorized[0].Category != "traceability" {
+		t.Fatalf("Expected one traceability warning, got %+v", result.Categorized)
+	}
+	if len(result.Warnings) != 2 || result.Warnings[1] != result.Categorized[0].Message {
+		t.Errorf("Expected the warning appended to Warnings, got %v", result.Warnings)
+	}
+	if !result.Valid {
+		t.Error("Warnings should not make the result invalid")
+	}
+}
@@ -0,0 +1,231 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// maxWordDiffCells bounds the table of the word diff; texts with more
+// token pairs after trimming their common ends are shown as replaced
+// outright
+const maxWordDiffCells = 4 << 20
+
+// wordDiffFields are the free-text fields diffed word by word, with the
+// value of each
+var wordDiffFields = []struct {
+	name  string
+	value func(*model.RequirementDetail) string
+}{
+	{"summary", func(r *model.RequirementDetail) string { return r.Summary }},
+	{"description", func(r *model.RequirementDetail) string { return r.Description }},
+	{"justification", func(r *model.RequirementDetail) string { return r.Justification }},
+	{"acceptance_test", func(r *model.RequirementDetail) string { return r.AcceptanceTest }},
+}
+
+// wordDiffOp is a run of text kept, deleted or inserted between two
+// versions of a field
+type wordDiffOp struct {
+	Op   string `json:"op"` // equal, delete or insert
+	Text string `json:"text"`
+}
+
+// wordDiff compares two texts word by word. Deletions come before the
+// insertions that replace them, and whitespace between two changes is
+// folded into them, so a reworded phrase reads as one replacement.
+func wordDiff(old, current string) []wordDiffOp {
+	return coalesceWordDiff(diffTokenLists(diffTokens(old), diffTokens(current)))
+}
+
+// lineDiff compares two texts line by line, as runs of whole lines kept,
+// deleted or inserted, for a preview of a change to a file
+func lineDiff(old, current string) []wordDiffOp {
+	lines := func(s string) []string {
+		split := strings.SplitAfter(s, "\n")
+		// Text ending in a newline doesn't have an empty line after it
+		if split[len(split)-1] == "" {
+			split = split[:len(split)-1]
+		}
+		return split
+	}
+	return coalesceWordDiff(diffTokenLists(lines(old), lines(current)))
+}
+
+// diffTokenLists diffs two token lists, one op per token
+func diffTokenLists(a, b []string) []wordDiffOp {
+	// The common ends don't need the table
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var tokens []wordDiffOp
+	for _, token := range a[:prefix] {
+		tokens = append(tokens, wordDiffOp{"equal", token})
+	}
+	tokens = append(tokens, middleDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, token := range a[len(a)-suffix:] {
+		tokens = append(tokens, wordDiffOp{"equal", token})
+	}
+	return tokens
+}
+
+// middleDiff diffs the differing middle of two token lists by longest
+// common subsequence, one op per token
+func middleDiff(a, b []string) []wordDiffOp {
+	var out []wordDiffOp
+	if len(a)*len(b) > maxWordDiffCells {
+		for _, token := range a {
+			out = append(out, wordDiffOp{"delete", token})
+		}
+		for _, token := range b {
+			out = append(out, wordDiffOp{"insert", token})
+		}
+		return out
+	}
+
+	// lcs[i][j] is the length of the common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			out = append(out, wordDiffOp{"equal", a[i]})
+			i++
+			j++
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, wordDiffOp{"delete", a[i]})
+			i++
+		default:
+			out = append(out, wordDiffOp{"insert", b[j]})
+			j++
+		}
+	}
+	return out
+}
+
+// coalesceWordDiff turns the per-token ops of wordDiff into runs:
+// whitespace between changes is folded into them and each stretch of
+// changes becomes one deletion and one insertion
+func coalesceWordDiff(tokens []wordDiffOp) []wordDiffOp {
+	isChange := func(i int) bool { return i >= 0 && i < len(tokens) && tokens[i].Op != "equal" }
+
+	var ops []wordDiffOp
+	var deleted, inserted strings.Builder
+	flush := func() {
+		if deleted.Len() > 0 {
+			ops = append(ops, wordDiffOp{"delete", deleted.String()})
+		}
+		if inserted.Len() > 0 {
+			ops = append(ops, wordDiffOp{"insert", inserted.String()})
+		}
+		deleted.Reset()
+		inserted.Reset()
+	}
+	for i, token := range tokens {
+		switch {
+		case token.Op == "delete":
+			deleted.WriteString(token.Text)
+		case token.Op == "insert":
+			inserted.WriteString(token.Text)
+		case isChange(i-1) && isChange(i+1) && strings.TrimSpace(token.Text) == "":
+			deleted.WriteString(token.Text)
+			inserted.WriteString(token.Text)
+		default:
+			flush()
+			if n := len(ops); n > 0 && ops[n-1].Op == "equal" {
+				ops[n-1].Text += token.Text
+			} else {
+				ops = append(ops, token)
+			}
+		}
+	}
+	flush()
+	return ops
+}
+
+// diffTokens splits text into words, runs of whitespace and single other
+// characters such as punctuation
+func diffTokens(text string) []string {
+	var tokens []string
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		end := size
+		var same func(rune) bool
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			same = func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+		case unicode.IsSpace(r):
+			same = unicode.IsSpace
+		}
+		for same != nil && end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if !same(r) {
+				break
+			}
+			end += size
+		}
+		tokens = append(tokens, text[:end])
+		text = text[end:]
+	}
+	return tokens
+}
+
+// formatWordDiff renders ops for the terminal like git diff --word-diff:
+// [-deleted-]{+inserted+}, also in red and green when color is on
+func formatWordDiff(ops []wordDiffOp) string {
+	var b strings.Builder
+	for _, op := range ops {
+		switch op.Op {
+		case "delete":
+			b.WriteString(paint(ansiRed, "[-"+op.Text+"-]"))
+		case "insert":
+			b.WriteString(paint(ansiGreen, "{+"+op.Text+"+}"))
+		default:
+			b.WriteString(op.Text)
+		}
+	}
+	return b.String()
+}
+
+// htmlWordDiff renders ops as escaped HTML with <del> and <ins> around
+// the changes
+func htmlWordDiff(ops []wordDiffOp) string {
+	var b strings.Builder
+	for _, op := range ops {
+		text := html.EscapeString(op.Text)
+		switch op.Op {
+		case "delete":
+			b.WriteString("<del>" + text + "</del>")
+		case "insert":
+			b.WriteString("<ins>" + text + "</ins>")
+		default:
+			b.WriteString(text)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestWordDiff(t *testing.T) {
+	tests := []struct {
+		old, current string
+		want         string
+	}{
+		{"same text", "same text", "same text"},
+		{"The system must provide login.", "The system shall offer login.", "The system [-must provide-]{+shall offer+} login."},
+		{"Log in with a password", "Log in with a password and MFA", "Log in with a password{+ and MFA+}"},
+		{"Users may export data", "Users export data", "Users [-may -]export data"},
+		{"Respond within 200 ms.", "Respond within 100 ms!", "Respond within [-200-]{+100+} ms[-.-]{+!+}"},
+		{"", "New text", "{+New text+}"},
+		{"Old text", "", "[-Old text-]"},
+		{"Größe prüfen", "Größe messen", "Größe [-prüfen-]{+messen+}"},
+	}
+	for _, tt := range tests {
+		if got := formatWordDiff(wordDiff(tt.old, tt.current)); got != tt.want {
+			t.Errorf("wordDiff(%q, %q) = %q, want %q", tt.old, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestWordDiffReassembles(t *testing.T) {
+	old := "The service must encrypt data at rest and in transit, using TLS 1.2."
+	current := "The service shall encrypt all data in transit using TLS 1.3 or later."
+	var before, after string
+	for _, op := range wordDiff(old, current) {
+		if op.Op != "insert" {
+			before += op.Text
+		}
+		if op.Op != "delete" {
+			after += op.Text
+		}
+	}
+	if before != old || after != current {
+		t.Errorf("Ops don't reassemble the texts:\n%q\n%q", before, after)
+	}
+}
+
+func TestHTMLWordDiff(t *testing.T) {
+	got := htmlWordDiff(wordDiff("Use <b>bold</b> text", "Use <i>italic</i> text"))
+	want := "Use &lt;<del>b</del><ins>i</ins>&gt;<del>bold</del><ins>italic</ins>&lt;/<del>b</del><ins>i</ins>&gt; text"
+	if got != want {
+		t.Errorf("htmlWordDiff = %q, want %q", got, want)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	old := "a: 1\nb: 2\nc: 3\n"
+	current := "a: 1\nb: 20\nc: 3\nd: 4\n"
+	got := formatWordDiff(lineDiff(old, current))
+	want := "a: 1\n[-b: 2\n-]{+b: 20\n+}c: 3\n{+d: 4\n+}"
+	if got != want {
+		t.Errorf("lineDiff = %q, want %q", got, want)
+	}
+}
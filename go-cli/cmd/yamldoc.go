@@ -0,0 +1,471 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// defaultYAMLIndent is the indent of requirements files rqm writes from
+// scratch, and of files whose indent can't be told
+const defaultYAMLIndent = 2
+
+// yamlDocument is a YAML requirements file parsed for editing, the layer
+// every command that rewrites one goes through. Edits change the node
+// tree, which keeps comments, anchors and aliases, key order and the
+// quoting and block style of each scalar. yaml.v3 drops the rest of the
+// layout when it encodes a tree, so encode puts it back: the file's
+// indent, a leading --- and the blank lines between entries, including
+// around their comments.
+type yamlDocument struct {
+	file string
+	// data is the file as read, which save checks is still on disk
+	data []byte
+	doc  yaml.Node
+
+	indent      int
+	startMarker bool
+	// gaps are the blank and comment lines above a mapping key or
+	// sequence item, for those with a blank line among them
+	gaps map[*yaml.Node][]string
+	// parsed are the nodes read from the file, so encode can tell the
+	// ones edits added
+	parsed map[*yaml.Node]bool
+}
+
+// loadYAMLDocument reads a requirements file for editing
+func loadYAMLDocument(file string) (*yamlDocument, error) {
+	if err := editableFile(file); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return parseYAMLDocument(file, data)
+}
+
+// parseYAMLDocument parses data, the content of file, for editing
+func parseYAMLDocument(file string, data []byte) (*yamlDocument, error) {
+	d := &yamlDocument{
+		file:   file,
+		data:   data,
+		indent: detectYAMLIndent(data),
+		gaps:   make(map[*yaml.Node][]string),
+		parsed: make(map[*yaml.Node]bool),
+	}
+	if err := yaml.Unmarshal(data, &d.doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if len(d.doc.Content) == 0 {
+		return nil, fmt.Errorf("%s is empty", file)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		d.startMarker = trimmed == "---" || strings.HasPrefix(trimmed, "--- ")
+		break
+	}
+	walkYAMLEntries(&d.doc, func(node *yaml.Node) {
+		d.parsed[node] = true
+		if gap := gapAbove(lines, node.Line); slices.Contains(gap, "") {
+			d.gaps[node] = gap
+		}
+	})
+	return d, nil
+}
+
+// root is the top-level mapping of the document
+func (d *yamlDocument) root() *yaml.Node {
+	return d.doc.Content[0]
+}
+
+// requirementNodes indexes the requirement mappings of the document, at
+// any depth, by summary
+func (d *yamlDocument) requirementNodes() map[string]*yaml.Node {
+	bySummary := make(map[string]*yaml.Node)
+	indexRequirementNodes(d.root(), bySummary)
+	return bySummary
+}
+
+// encode writes the edited document back in the file's layout
+func (d *yamlDocument) encode() ([]byte, error) {
+	out, err := d.encodeNode(&d.doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", d.file, err)
+	}
+	if d.startMarker && !bytes.HasPrefix(out, []byte("---")) {
+		out = append([]byte("---\n"), out...)
+	}
+	return out, nil
+}
+
+// encodeNode encodes node, the document or a part of it, in the file's
+// indent with the blank lines recorded for its entries
+func (d *yamlDocument) encodeNode(node *yaml.Node) ([]byte, error) {
+	d.inheritGaps(node)
+	out, err := encodeYAML(node, d.indent)
+	if err != nil {
+		return nil, err
+	}
+	return d.restoreGaps(node, out), nil
+}
+
+// save writes the edited document to the file with writeRequirementsFile,
+// which fails if the file changed since it was read
+func (d *yamlDocument) save() error {
+	out, err := d.encode()
+	if err != nil {
+		return err
+	}
+	return writeRequirementsFile(d.file, d.data, out)
+}
+
+// inheritGaps separates sequence items edits added from the item before
+// them as that item is separated, so a requirement appended to a list
+// with blank lines between requirements gets one too
+func (d *yamlDocument) inheritGaps(node *yaml.Node) {
+	walkYAMLNodes(node, func(n *yaml.Node) {
+		if n.Kind != yaml.SequenceNode {
+			return
+		}
+		for i := 1; i < len(n.Content); i++ {
+			item, prev := n.Content[i], n.Content[i-1]
+			if d.parsed[item] || d.gaps[item] != nil || d.gaps[prev] == nil {
+				continue
+			}
+			// The blank lines that separate prev from the entry before it,
+			// not those between its comments
+			blanks := []string{""}
+			for len(blanks) < len(d.gaps[prev]) && d.gaps[prev][len(blanks)] == "" {
+				blanks = append(blanks, "")
+			}
+			d.gaps[item] = blanks
+		}
+	})
+}
+
+// restoreGaps puts the recorded gaps of node's entries back into out, the
+// encoder's output for node. out is parsed again to find where each entry
+// ended up; should its shape not match node's, out is returned unchanged.
+func (d *yamlDocument) restoreGaps(node *yaml.Node, out []byte) []byte {
+	var encoded yaml.Node
+	if err := yaml.Unmarshal(out, &encoded); err != nil {
+		return out
+	}
+	if node.Kind != yaml.DocumentNode {
+		if len(encoded.Content) == 0 {
+			return out
+		}
+		encoded = *encoded.Content[0]
+	}
+	var edited, reread []*yaml.Node
+	walkYAMLEntries(node, func(n *yaml.Node) { edited = append(edited, n) })
+	walkYAMLEntries(&encoded, func(n *yaml.Node) { reread = append(reread, n) })
+	if len(edited) != len(reread) {
+		return out
+	}
+	// Lines hold both a sequence item and its first key, with one gap
+	gaps := make(map[int][]string)
+	for i, n := range edited {
+		if gap, ok := d.gaps[n]; ok {
+			gaps[reread[i].Line] = gap
+		}
+	}
+	if len(gaps) == 0 {
+		return out
+	}
+
+	lines := strings.Split(string(out), "\n")
+	lineNumbers := make([]int, 0, len(gaps))
+	for line := range gaps {
+		lineNumbers = append(lineNumbers, line)
+	}
+	// Last first, so splicing doesn't move the lines still to do
+	slices.Sort(lineNumbers)
+	slices.Reverse(lineNumbers)
+	for _, line := range lineNumbers {
+		gap := gapAbove(lines, line)
+		start := line - 1 - len(gap)
+		lines = slices.Replace(lines, start, line-1, mergeGap(gaps[line], gap)...)
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// mergeGap lays out the encoder's gap, comment lines only, like the
+// file's: the file's blank lines between the encoder's comments when they
+// have as many comments, otherwise the file's blank lines above them
+func mergeGap(file, encoded []string) []string {
+	var comments []string
+	for _, line := range encoded {
+		if line != "" {
+			comments = append(comments, line)
+		}
+	}
+	var merged []string
+	fileComments := 0
+	for _, line := range file {
+		if line != "" {
+			fileComments++
+		}
+	}
+	if fileComments == len(comments) {
+		for _, line := range file {
+			if line == "" {
+				merged = append(merged, "")
+			} else {
+				merged = append(merged, comments[0])
+				comments = comments[1:]
+			}
+		}
+		return merged
+	}
+	for _, line := range file {
+		if line == "" {
+			merged = append(merged, "")
+		}
+	}
+	return append(merged, comments...)
+}
+
+// gapAbove returns the blank and comment lines right above the 1-based
+// line, with blank lines as ""
+func gapAbove(lines []string, line int) []string {
+	start := line - 1
+	for start > 0 {
+		trimmed := strings.TrimSpace(lines[start-1])
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		start--
+	}
+	gap := make([]string, 0, line-1-start)
+	for _, l := range lines[start : line-1] {
+		if strings.TrimSpace(l) == "" {
+			l = ""
+		}
+		gap = append(gap, l)
+	}
+	return gap
+}
+
+// detectYAMLIndent is the indent of data: the smallest indentation of a
+// line that has one
+func detectYAMLIndent(data []byte) int {
+	indent := 0
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || len(trimmed) == len(line) {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent == 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent < 2 || indent > 8 {
+		return defaultYAMLIndent
+	}
+	return indent
+}
+
+// walkYAMLEntries calls fn on every mapping key and sequence item under
+// node, in document order: the nodes that start a line of their own
+func walkYAMLEntries(node *yaml.Node, fn func(entry *yaml.Node)) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			fn(node.Content[i])
+			walkYAMLEntries(node.Content[i+1], fn)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			fn(item)
+			walkYAMLEntries(item, fn)
+		}
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			walkYAMLEntries(child, fn)
+		}
+	}
+}
+
+// walkYAMLNodes calls fn on node and every node under it
+func walkYAMLNodes(node *yaml.Node, fn func(n *yaml.Node)) {
+	fn(node)
+	for _, child := range node.Content {
+		walkYAMLNodes(child, fn)
+	}
+}
+
+// mappingValue returns the value node for key in a mapping, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingScalar sets key to a string value, appending the key if missing
+func setMappingScalar(node *yaml.Node, key, value string) {
+	// Text over several lines reads best as a literal block
+	var style yaml.Style
+	if strings.Contains(strings.TrimRight(value, "\n"), "\n") {
+		style = yaml.LiteralStyle
+	}
+	if existing := mappingValue(node, key); existing != nil {
+		existing.Kind = yaml.ScalarNode
+		existing.Tag = "!!str"
+		existing.Value = value
+		existing.Content = nil
+		if style != 0 {
+			existing.Style = style
+		}
+		return
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: style})
+}
+
+// addSequenceValue appends value to the list under key unless it's already there
+func addSequenceValue(node *yaml.Node, key, value string) {
+	list := mappingValue(node, key)
+	if list == nil {
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, list)
+	}
+	for _, item := range list.Content {
+		if item.Value == value {
+			return
+		}
+	}
+	list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+}
+
+// removeSequenceValue removes value from the list under key, and the key
+// when the list is left empty
+func removeSequenceValue(node *yaml.Node, key, value string) {
+	list := mappingValue(node, key)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return
+	}
+	list.Content = slices.DeleteFunc(list.Content, func(item *yaml.Node) bool { return item.Value == value })
+	if len(list.Content) == 0 {
+		deleteMappingKey(node, key)
+	}
+}
+
+// insertAfterKey inserts key and value into a mapping after the entry for
+// after, or at the end when there's none
+func insertAfterKey(node *yaml.Node, after string, key, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == after {
+			node.Content = append(node.Content[:i+2], append([]*yaml.Node{key, value}, node.Content[i+2:]...)...)
+			return
+		}
+	}
+	node.Content = append(node.Content, key, value)
+}
+
+// insertBeforeKey adds key and value to a mapping ahead of before, or at
+// the end when before is missing
+func insertBeforeKey(node *yaml.Node, before string, key, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == before {
+			node.Content = append(node.Content[:i], append([]*yaml.Node{key, value}, node.Content[i:]...)...)
+			return
+		}
+	}
+	node.Content = append(node.Content, key, value)
+}
+
+// renameMappingKey renames key in a mapping, keeping its position
+func renameMappingKey(node *yaml.Node, key, to string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i].Value = to
+			return
+		}
+	}
+}
+
+// deleteMappingKey removes key and its value from a mapping
+func deleteMappingKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// indexRequirementNodes records every requirement mapping under node's
+// requirements list, recursing into sub-requirements
+func indexRequirementNodes(node *yaml.Node, bySummary map[string]*yaml.Node) {
+	list := mappingValue(node, "requirements")
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, item := range list.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		if summary := mappingValue(item, "summary"); summary != nil {
+			bySummary[summary.Value] = item
+		}
+		indexRequirementNodes(item, bySummary)
+	}
+}
+
+// walkRequirementNodes calls fn on every requirement mapping under node's
+// requirements list, parents before their children
+func walkRequirementNodes(node *yaml.Node, fn func(req *yaml.Node)) {
+	list := mappingValue(node, "requirements")
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, item := range list.Content {
+		if item.Kind != yaml.MappingNode || mappingValue(item, "summary") == nil {
+			continue
+		}
+		fn(item)
+		walkRequirementNodes(item, fn)
+	}
+}
+
+// encodeYAML encodes node with indent. Merge keys lose their !!merge tag,
+// which yaml.v3 would otherwise write out as !!merge <<.
+func encodeYAML(node *yaml.Node, indent int) ([]byte, error) {
+	walkYAMLNodes(node, func(n *yaml.Node) {
+		if n.Kind == yaml.ScalarNode && n.Tag == "!!merge" {
+			n.Tag = ""
+		}
+	})
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,138 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// editLayoutYAML exercises what yaml.v3 loses on its own: a document marker,
+// four-space indent, blank lines around comments and a merge key
+const editLayoutYAML = `---
+# Project requirements
+version: "1.0"
+
+defaults: &defaults
+    owner: '@alice'
+
+requirements:
+    # Auth first
+    - summary: Login
+      <<: *defaults
+      status: 'draft'   # not yet
+      tags: [auth, ui]
+
+    # Then logout
+
+    - summary: Logout
+      description: |
+          Multi
+          line
+      requirements:
+          - summary: Sub
+# trailing
+`
+
+func TestYAMLDocumentRoundTrip(t *testing.T) {
+	files := []string{"../../examples/sample-requirements.yml", "../../.rqm/requirements.yml", "demo/requirements.yml"}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc, err := parseYAMLDocument(file, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := doc.encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != string(data) {
+			t.Errorf("%s changed by a round trip:\n%s", file, out)
+		}
+	}
+}
+
+func TestYAMLDocumentKeepsLayout(t *testing.T) {
+	doc, err := parseYAMLDocument("requirements.yml", []byte(editLayoutYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.indent != 4 || !doc.startMarker {
+		t.Errorf("indent %d, start marker %v", doc.indent, doc.startMarker)
+	}
+	nodes := doc.requirementNodes()
+	setMappingScalar(nodes["Login"], "status", "approved")
+	addSequenceValue(nodes["Logout"], "tags", "auth")
+	list := mappingValue(doc.root(), "requirements")
+	list.Content = append(list.Content, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "summary"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "Sessions"},
+	}})
+
+	out, err := doc.encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `---
+# Project requirements
+version: "1.0"
+
+defaults: &defaults
+    owner: '@alice'
+
+requirements:
+    # Auth first
+    - summary: Login
+      <<: *defaults
+      status: 'approved' # not yet
+      tags: [auth, ui]
+
+    # Then logout
+
+    - summary: Logout
+      description: |
+        Multi
+        line
+      requirements:
+        - summary: Sub
+      tags:
+        - auth
+
+    - summary: Sessions
+# trailing
+`
+	if string(out) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestYAMLDocumentSave(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+	if err := os.WriteFile(file, []byte(editLayoutYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := loadYAMLDocument(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	setMappingScalar(doc.requirementNodes()["Sub"], "status", "draft")
+
+	// Someone else saves the file in between
+	os.WriteFile(file, []byte(strings.Replace(editLayoutYAML, "Multi", "Many", 1)), 0o644)
+	if err := doc.save(); err == nil || !strings.Contains(err.Error(), "changed on disk") {
+		t.Errorf("save over a newer file: %v", err)
+	}
+
+	if _, err := loadYAMLDocument(filepath.Join(t.TempDir(), "requirements.toml")); err == nil {
+		t.Error("loaded a TOML file for editing")
+	}
+}
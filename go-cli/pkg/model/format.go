@@ -0,0 +1,145 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// Requirements files are YAML, or TOML or JSON for toolchains that
+// generate them. The format follows the file extension, and TOML and JSON
+// are converted to YAML so the validator and everything else read one
+// form.
+
+// Format names the format of a requirements file
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+	FormatJSON Format = "json"
+)
+
+// String gives the format's name as people write it, such as TOML
+func (f Format) String() string {
+	return strings.ToUpper(string(f))
+}
+
+// FormatOf gives the format of the requirements file at path by its
+// extension; anything that isn't .toml or .json is YAML
+func FormatOf(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return FormatTOML
+	case ".json":
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// IsRequirementsExt reports whether ext, with its dot, is the extension
+// of a format requirements files can be written in
+func IsRequirementsExt(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".yml", ".yaml", ".toml", ".json":
+		return true
+	}
+	return false
+}
+
+// SyntaxError is a TOML or JSON requirements file that doesn't parse. It
+// reads "invalid TOML at line 3: ...", or without the line when the
+// parser doesn't give one.
+type SyntaxError struct {
+	Format Format
+	// Line is the 1-based line of the error, or 0 when unknown
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("invalid %s: %s", e.Format, e.Msg)
+	}
+	return fmt.Sprintf("invalid %s at line %d: %s", e.Format, e.Line, e.Msg)
+}
+
+// ToYAML converts the contents of the requirements file at path to YAML.
+// YAML is returned as it is. Syntax errors are a *SyntaxError.
+func ToYAML(path string, data []byte) ([]byte, error) {
+	var doc interface{}
+	switch FormatOf(path) {
+	case FormatTOML:
+		var table map[string]interface{}
+		if err := toml.Unmarshal(data, &table); err != nil {
+			var decodeErr *toml.DecodeError
+			if errors.As(err, &decodeErr) {
+				line, _ := decodeErr.Position()
+				return nil, &SyntaxError{Format: FormatTOML, Line: line, Msg: strings.TrimPrefix(decodeErr.Error(), "toml: ")}
+			}
+			return nil, &SyntaxError{Format: FormatTOML, Msg: strings.TrimPrefix(err.Error(), "toml: ")}
+		}
+		doc = table
+	case FormatJSON:
+		if err := json.Unmarshal(data, &doc); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				line := bytes.Count(data[:syntaxErr.Offset], []byte("\n")) + 1
+				return nil, &SyntaxError{Format: FormatJSON, Line: line, Msg: syntaxErr.Error()}
+			}
+			return nil, &SyntaxError{Format: FormatJSON, Msg: err.Error()}
+		}
+	default:
+		return data, nil
+	}
+	out, err := yaml.Marshal(plainValues(doc))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to YAML: %w", err)
+	}
+	return out, nil
+}
+
+// plainValues replaces TOML's date and time values with the strings the
+// schema expects
+func plainValues(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = plainValues(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = plainValues(item)
+		}
+	case toml.LocalDate:
+		return v.String()
+	case toml.LocalDateTime:
+		return v.String()
+	case toml.LocalTime:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+	return value
+}
+
+// Parse decodes the contents of the requirements file at path in the
+// format of its extension
+func Parse(path string, data []byte) (*RequirementConfig, error) {
+	data, err := ToYAML(path, data)
+	if err != nil {
+		return nil, err
+	}
+	return ParseYAML(data)
+}
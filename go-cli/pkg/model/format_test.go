@@ -0,0 +1,87 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testTOML = `version = "1.0"
+
+[[requirements]]
+summary = "Login"
+name = "AUTH-001"
+requirements = ["Session timeout"]
+
+  [[requirements.open_questions]]
+  question = "Which SSO providers?"
+  due = 2025-03-01
+
+[[requirements]]
+summary = "Session timeout"
+name = "AUTH-003"
+`
+
+const testJSONFile = `{
+	"version": "1.0",
+	"requirements": [
+		{"summary": "Login", "name": "AUTH-001", "requirements": ["Session timeout"],
+		 "open_questions": [{"question": "Which SSO providers?", "due": "2025-03-01"}]},
+		{"summary": "Session timeout", "name": "AUTH-003"}
+	]
+}`
+
+func TestParseFormats(t *testing.T) {
+	want, err := ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    requirements:
+      - Session timeout
+    open_questions:
+      - question: Which SSO providers?
+        due: "2025-03-01"
+  - summary: Session timeout
+    name: AUTH-003
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, data := range map[string]string{"requirements.toml": testTOML, "requirements.JSON": testJSONFile} {
+		config, err := Parse(path, []byte(data))
+		if err != nil {
+			t.Fatalf("Parse(%s): %v", path, err)
+		}
+		if !reflect.DeepEqual(config, want) {
+			t.Errorf("Parse(%s) = %+v, want %+v", path, config, want)
+		}
+	}
+}
+
+func TestToYAMLErrors(t *testing.T) {
+	for path, data := range map[string]string{
+		"requirements.toml": "version = \"1.0\"\n\n[[requirements]\n",
+		"requirements.json": "{\n  \"version\": \"1.0\",\n  \"requirements\": [,\n}",
+	} {
+		_, err := ToYAML(path, []byte(data))
+		want := "invalid " + FormatOf(path).String() + " at line 3: "
+		if err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("ToYAML(%s) = %v, want an error starting %q", path, err, want)
+		}
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Format != FormatOf(path) || syntaxErr.Line != 3 {
+			t.Errorf("ToYAML(%s) = %#v, want a *SyntaxError at line 3", path, err)
+		}
+	}
+
+	data := []byte("version: \"1.0\"\n")
+	if out, err := ToYAML("requirements.yml", data); err != nil || string(out) != string(data) {
+		t.Errorf("Expected YAML to be returned as it is, got %q, %v", out, err)
+	}
+}
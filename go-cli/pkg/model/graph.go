@@ -0,0 +1,128 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/238855/rqm/go-cli/pkg/rqm/graph"
+)
+
+// Adjacency maps each requirement's summary to the summaries of its
+// sub-requirements, inline or referenced, in file order; external
+// references are left out. Requirements
+// without sub-requirements map to an empty list.
+func (c *RequirementConfig) Adjacency() map[string][]string {
+	adjacency := make(map[string][]string)
+	for _, req := range c.Flatten() {
+		children := []string{}
+		for _, child := range req.Requirements {
+			switch {
+			case child.External != nil:
+				// Requirements of other repositories aren't nodes of this graph
+			case child.Full != nil:
+				children = append(children, child.Full.Summary)
+			default:
+				children = append(children, child.Reference)
+			}
+		}
+		adjacency[req.Summary] = children
+	}
+	return adjacency
+}
+
+// RelationMap maps the summary of each requirement with typed relations to
+// those relations
+func (c *RequirementConfig) RelationMap() map[string][]Relation {
+	relations := make(map[string][]Relation)
+	for _, req := range c.Flatten() {
+		if len(req.Relations) > 0 {
+			relations[req.Summary] = req.Relations
+		}
+	}
+	return relations
+}
+
+// Cycles returns the circular references among the requirements: the
+// groups of requirements of OrderingGraph that lead back to each other,
+// each listing its summaries in file order. Other relation types can't
+// form a cycle. A reference or relation target that doesn't exist is an
+// error.
+func (c *RequirementConfig) Cycles() ([][]string, error) {
+	g, err := c.OrderingGraph()
+	if err != nil {
+		return nil, err
+	}
+	return g.Cycles(), nil
+}
+
+// OrderingGraph returns the graph of the order requirements can be done
+// in, which rqm check looks for circular references in. Its nodes are the
+// requirements by summary, in file order, and it has an edge from each
+// parent to its sub-requirements (graph.KindChild), from a requirement to
+// what it depends_on (graph.KindDependsOn), and from what it blocks to it
+// (graph.KindBlocks), since the target waits on the requirement.
+// References and relation targets are resolved by summary, then by name.
+// One that doesn't exist is left out and the first is returned as the
+// error, along with the rest of the graph.
+func (c *RequirementConfig) OrderingGraph() (*graph.Graph, error) {
+	all := c.Flatten()
+	g := graph.New()
+	byName := make(map[string]string)
+	for _, req := range all {
+		g.AddNode(req.Summary, req.Name)
+		if req.Name != "" {
+			byName[req.Name] = req.Summary
+		}
+	}
+
+	// References name a requirement by summary, or else by name
+	resolve := func(ref string) (string, bool) {
+		if _, ok := g.Node(ref); ok {
+			return ref, true
+		}
+		summary, ok := byName[ref]
+		return summary, ok
+	}
+
+	var missing error
+	for _, req := range all {
+		for _, child := range req.Requirements {
+			if child.External != nil {
+				// Another repository's requirements can't lead back here
+				continue
+			}
+			if child.Full != nil {
+				g.AddEdge(req.Summary, child.Full.Summary, graph.KindChild)
+				continue
+			}
+			target, ok := resolve(child.Reference)
+			if !ok {
+				if missing == nil {
+					missing = fmt.Errorf("requirement '%s' references non-existent '%s'", req.Summary, child.Reference)
+				}
+				continue
+			}
+			g.AddEdge(req.Summary, target, graph.KindChild)
+		}
+
+		for _, rel := range req.Relations {
+			target, ok := resolve(rel.Target)
+			if !ok {
+				if missing == nil {
+					missing = fmt.Errorf("requirement '%s' has a %s relation to non-existent '%s'", req.Summary, rel.Type, rel.Target)
+				}
+				continue
+			}
+			switch rel.Type {
+			case "depends_on":
+				g.AddEdge(req.Summary, target, graph.KindDependsOn)
+			case "blocks":
+				g.AddEdge(target, req.Summary, graph.KindBlocks)
+			}
+		}
+	}
+	return g, missing
+}
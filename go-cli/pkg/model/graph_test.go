@@ -0,0 +1,196 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAdjacencyAndRelationMap(t *testing.T) {
+	config, err := ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: A
+    requirements:
+      - summary: B
+      - C
+  - summary: C
+    relations:
+      - type: relates_to
+        target: A
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{"A": {"B", "C"}, "B": {}, "C": {}}
+	if got := config.Adjacency(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Adjacency() = %v, want %v", got, want)
+	}
+	if got := config.RelationMap(); !reflect.DeepEqual(got, map[string][]Relation{"C": {{Type: "relates_to", Target: "A"}}}) {
+		t.Errorf("RelationMap() = %v", got)
+	}
+}
+
+func TestCycles(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want [][]string
+	}{
+		{
+			name: "acyclic",
+			yaml: `
+  - summary: A
+    requirements:
+      - summary: B
+      - C
+  - summary: C`,
+		},
+		{
+			name: "self reference",
+			yaml: `
+  - summary: A
+    requirements:
+      - A`,
+			want: [][]string{{"A"}},
+		},
+		{
+			name: "child reference",
+			yaml: `
+  - summary: A
+    requirements:
+      - summary: B
+        requirements:
+          - summary: C
+            requirements:
+              - A`,
+			want: [][]string{{"A", "B", "C"}},
+		},
+		{
+			name: "child reference by name",
+			yaml: `
+  - summary: A
+    name: R-1
+    requirements:
+      - summary: B
+        requirements:
+          - R-1`,
+			want: [][]string{{"A", "B"}},
+		},
+		{
+			name: "depends_on by name",
+			yaml: `
+  - summary: A
+    name: R-1
+    requirements:
+      - summary: B
+        relations:
+          - type: depends_on
+            target: R-1`,
+			want: [][]string{{"A", "B"}},
+		},
+		{
+			name: "blocks points back",
+			yaml: `
+  - summary: A
+    requirements:
+      - summary: B
+        relations:
+          - type: blocks
+            target: A`,
+		},
+		{
+			name: "blocks cycle",
+			yaml: `
+  - summary: A
+    relations:
+      - type: depends_on
+        target: B
+  - summary: B
+    relations:
+      - type: blocks
+        target: A
+      - type: depends_on
+        target: A`,
+			want: [][]string{{"A", "B"}},
+		},
+		{
+			name: "other relations ignored",
+			yaml: `
+  - summary: A
+    requirements:
+      - summary: B
+        relations:
+          - type: relates_to
+            target: A
+          - type: derives_from
+            target: A`,
+		},
+		{
+			name: "overlapping loops",
+			yaml: `
+  - summary: A
+    relations:
+      - type: depends_on
+        target: C
+  - summary: B
+    relations:
+      - type: depends_on
+        target: A
+  - summary: C
+    relations:
+      - type: depends_on
+        target: B
+      - type: depends_on
+        target: A`,
+			want: [][]string{{"A", "B", "C"}},
+		},
+		{
+			name: "separate cycles",
+			yaml: `
+  - summary: A
+    requirements:
+      - A
+  - summary: B
+    requirements:
+      - summary: C
+        requirements:
+          - B`,
+			want: [][]string{{"A"}, {"B", "C"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseYAML([]byte("version: \"1.0\"\nrequirements:" + tt.yaml + "\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := config.Cycles()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Cycles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCyclesMissingTarget(t *testing.T) {
+	for _, yaml := range []string{
+		"  - summary: A\n    requirements:\n      - Missing\n",
+		"  - summary: A\n    relations:\n      - type: blocks\n        target: Missing\n",
+	} {
+		config, err := ParseYAML([]byte("version: \"1.0\"\nrequirements:\n" + yaml))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := config.Cycles(); err == nil || !strings.Contains(err.Error(), "non-existent 'Missing'") {
+			t.Errorf("Expected a missing target error, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ResolveIncludes adds the requirements and aliases of the files that file
+// includes to config, following includes of included files, so config is
+// the whole tree. load reads each included file. An alias the including
+// file defines wins over an included one.
+func ResolveIncludes(file string, config *RequirementConfig, load func(path string) (*RequirementConfig, error)) error {
+	return resolveIncludes(file, config, os.ReadFile, load, nil)
+}
+
+// LoadWith is Load with the files read by read rather than from disk, as
+// when loading a file and its includes from a git revision
+func LoadWith(path string, read func(path string) ([]byte, error)) (*RequirementConfig, error) {
+	load := func(path string) (*RequirementConfig, error) {
+		data, err := read(path)
+		if err != nil {
+			return nil, err
+		}
+		config, err := Parse(path, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return config, nil
+	}
+	config, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveIncludes(path, config, read, load, nil); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// IncludedFiles returns the files file includes, directly or through other
+// included files, in the order ResolveIncludes adds them
+func IncludedFiles(file string) ([]string, error) {
+	var files []string
+	err := ResolveIncludes(file, &RequirementConfig{}, func(path string) (*RequirementConfig, error) {
+		files = append(files, path)
+		return &RequirementConfig{}, nil
+	})
+	return files, err
+}
+
+// resolveIncludes is ResolveIncludes with the files read by read, and the
+// files being loaded on stack, to catch a file that includes itself
+func resolveIncludes(file string, config *RequirementConfig, read func(string) ([]byte, error), load func(string) (*RequirementConfig, error), stack []string) error {
+	// config may come from the validator's JSON, which doesn't carry
+	// includes, so they are read from the file itself
+	data, err := read(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	if data, err = ToYAML(file, data); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	var directives struct {
+		Includes []string `yaml:"includes"`
+	}
+	if err := yaml.Unmarshal(data, &directives); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	config.Includes = directives.Includes
+
+	stack = append(stack, canonicalPath(file))
+	for _, include := range directives.Includes {
+		path := filepath.Join(filepath.Dir(file), filepath.FromSlash(include))
+		if slices.Contains(stack, canonicalPath(path)) {
+			return fmt.Errorf("%s includes %s, which includes it back", file, include)
+		}
+		included, err := load(path)
+		if err != nil {
+			return fmt.Errorf("failed to load %s, included by %s: %w", include, file, err)
+		}
+		if err := resolveIncludes(path, included, read, load, stack); err != nil {
+			return err
+		}
+
+		config.Requirements = append(config.Requirements, included.Requirements...)
+		for _, alias := range included.Aliases {
+			if !slices.ContainsFunc(config.Aliases, func(a PersonAlias) bool { return a.Alias == alias.Alias }) {
+				config.Aliases = append(config.Aliases, alias)
+			}
+		}
+	}
+	return nil
+}
+
+// canonicalPath makes path absolute and resolves symlinks, so two paths to
+// one file compare equal
+func canonicalPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return path
+}
@@ -0,0 +1,303 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+// Package model holds the requirements file model shared by the rqm
+// commands and the Go API: the types a file decodes into from YAML (or
+// TOML and JSON, see Parse), or from the JSON the Rust validator produces,
+// and functions for walking the requirement tree.
+//
+//	config, err := model.ParseYAML(data)
+//	if err != nil {
+//		return err
+//	}
+//	for _, req := range config.Flatten() {
+//		fmt.Println(req.Summary)
+//	}
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// RequirementConfig is a requirements file. Includes lists further
+// requirements files, relative to this one, whose requirements and aliases
+// load as part of it.
+type RequirementConfig struct {
+	Version      string              `json:"version" yaml:"version"`
+	Aliases      []PersonAlias       `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	Includes     []string            `json:"includes,omitempty" yaml:"includes,omitempty"`
+	Requirements []RequirementDetail `json:"requirements" yaml:"requirements"`
+}
+
+// PersonAlias names a person that owner fields can refer to as @alias
+type PersonAlias struct {
+	Alias  string `json:"alias" yaml:"alias"`
+	Name   string `json:"name" yaml:"name,omitempty"`
+	Email  string `json:"email" yaml:"email,omitempty"`
+	GitHub string `json:"github,omitempty" yaml:"github,omitempty"`
+}
+
+// RequirementDetail is a single requirement and its sub-requirements
+type RequirementDetail struct {
+	Summary            string                 `json:"summary" yaml:"summary"`
+	Name               string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	UID                string                 `json:"uid,omitempty" yaml:"uid,omitempty"`
+	Description        string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Justification      string                 `json:"justification,omitempty" yaml:"justification,omitempty"`
+	AcceptanceTest     string                 `json:"acceptance_test,omitempty" yaml:"acceptance_test,omitempty"`
+	AcceptanceTestLink string                 `json:"acceptance_test_link,omitempty" yaml:"acceptance_test_link,omitempty"`
+	AcceptanceCriteria []Criterion            `json:"acceptance_criteria,omitempty" yaml:"acceptance_criteria,omitempty"`
+	Covers             []string               `json:"covers,omitempty" yaml:"covers,omitempty"`
+	Verification       string                 `json:"verification,omitempty" yaml:"verification,omitempty"`
+	VerifiedBy         []string               `json:"verified_by,omitempty" yaml:"verified_by,omitempty"`
+	Environments       []string               `json:"environments,omitempty" yaml:"environments,omitempty"`
+	Owner              string                 `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Priority           string                 `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Estimate           float64                `json:"estimate,omitempty" yaml:"estimate,omitempty"`
+	Status             string                 `json:"status,omitempty" yaml:"status,omitempty"`
+	Deprecated         bool                   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	SupersededBy       string                 `json:"superseded_by,omitempty" yaml:"superseded_by,omitempty"`
+	Tags               []string               `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Relations          []Relation             `json:"relations,omitempty" yaml:"relations,omitempty"`
+	Approvals          []Approval             `json:"approvals,omitempty" yaml:"approvals,omitempty"`
+	OpenQuestions      []Question             `json:"open_questions,omitempty" yaml:"open_questions,omitempty"`
+	Attachments        []Attachment           `json:"attachments,omitempty" yaml:"attachments,omitempty"`
+	ExternalRefs       []ExternalRef          `json:"external_refs,omitempty" yaml:"external_refs,omitempty"`
+	Links              *Links                 `json:"links,omitempty" yaml:"links,omitempty"`
+	Attributes         map[string]interface{} `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	FurtherInformation []string               `json:"further_information,omitempty" yaml:"further_information,omitempty"`
+	Requirements       []RequirementReference `json:"requirements,omitempty" yaml:"requirements,omitempty"`
+}
+
+// IsDeprecated reports whether the requirement is retired: marked
+// deprecated, with the deprecated status, or superseded by another
+func (r *RequirementDetail) IsDeprecated() bool {
+	return r.Deprecated || r.SupersededBy != "" || r.Status == "deprecated"
+}
+
+// Criterion is an acceptance criterion of a requirement. Children name
+// the criteria of their parent they cover by ID, under covers.
+type Criterion struct {
+	ID        string `json:"id" yaml:"id"`
+	Criterion string `json:"criterion" yaml:"criterion"`
+}
+
+// Relation is a typed link to another requirement, referenced by summary or name
+type Relation struct {
+	Type   string `json:"type" yaml:"type"`
+	Target string `json:"target" yaml:"target"`
+}
+
+// Approval is a sign-off required from an approver; an empty status means pending
+type Approval struct {
+	Approver string `json:"approver" yaml:"approver"`
+	Due      string `json:"due,omitempty" yaml:"due,omitempty"`
+	Status   string `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// Question is a clarification a requirement is waiting on; it is open
+// until it has an answer
+type Question struct {
+	Question string `json:"question" yaml:"question"`
+	Owner    string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Due      string `json:"due,omitempty" yaml:"due,omitempty"`
+	Answer   string `json:"answer,omitempty" yaml:"answer,omitempty"`
+}
+
+// Attachment is a document a requirement refers to: a file in the
+// repository, relative to the requirements file, or a URL. A checksum,
+// written sha256:<hex>, pins the version of a file the requirement was
+// written against.
+type Attachment struct {
+	Path     string `json:"path,omitempty" yaml:"path,omitempty"`
+	URL      string `json:"url,omitempty" yaml:"url,omitempty"`
+	Title    string `json:"title" yaml:"title"`
+	Checksum string `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+}
+
+// ExternalRef is the ID a requirement had in a tool it was imported from,
+// such as a Jira issue key, kept so it can be traced back after migration
+type ExternalRef struct {
+	System string `json:"system" yaml:"system"`
+	ID     string `json:"id" yaml:"id"`
+	URL    string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// Links point a requirement at what implements it outside the requirements
+// tree
+type Links struct {
+	// OpenAPI lists the operationIds of the API operations it covers
+	OpenAPI []string `json:"openapi,omitempty" yaml:"openapi,omitempty"`
+}
+
+// RequirementReference is an entry under a requirement's requirements
+// list: an inline sub-requirement (Full), the summary or name of a
+// requirement defined elsewhere in the file (Reference), or a requirement
+// of another repository (External)
+type RequirementReference struct {
+	Full      *RequirementDetail
+	Reference string
+	External  *ExternalReference
+}
+
+// ExternalReference points at a requirement in another repository, written
+// as external: {repo: github.com/org/repo, id: REQ-42}. Repo is a git
+// repository, whose .rqm/requirements.yml holds the requirement, or a
+// remote requirements file; Ref picks a branch, tag or commit of a
+// repository.
+type ExternalReference struct {
+	Repo string `json:"repo" yaml:"repo"`
+	ID   string `json:"id" yaml:"id"`
+	Ref  string `json:"ref,omitempty" yaml:"ref,omitempty"`
+}
+
+// String names the requirement and where it lives, as REQ-42@repo
+func (e ExternalReference) String() string {
+	s := e.ID + "@" + e.Repo
+	if e.Ref != "" {
+		s += "?ref=" + e.Ref
+	}
+	return s
+}
+
+// externalEntry is the form of an external reference in a requirements list
+type externalEntry struct {
+	External *ExternalReference `json:"external" yaml:"external"`
+}
+
+// ParseYAML decodes a requirements file. It checks syntax only; the
+// schema and semantic rules are the validator's.
+func ParseYAML(data []byte) (*RequirementConfig, error) {
+	var config RequirementConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Load reads and parses the requirements file at path, in the format of
+// its extension, along with the files it includes: the result is the
+// whole requirement tree, as validate, check and graph see it
+func Load(path string) (*RequirementConfig, error) {
+	config, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := ResolveIncludes(path, config, LoadFile); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// LoadFile reads and parses the requirements file at path alone, without
+// the files it includes, for commands that edit that file
+func LoadFile(path string) (*RequirementConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := Parse(path, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ParseJSON decodes a requirements file in the JSON form the validator
+// prints with --format json-full
+func ParseJSON(data []byte) (*RequirementConfig, error) {
+	var config RequirementConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// UnmarshalJSON handles full requirements, string references and external
+// references
+func (r *RequirementReference) UnmarshalJSON(data []byte) error {
+	// Try to unmarshal as string first
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		r.Reference = str
+		return nil
+	}
+
+	var entry externalEntry
+	if err := json.Unmarshal(data, &entry); err == nil && entry.External != nil {
+		r.External = entry.External
+		return nil
+	}
+
+	// Otherwise, unmarshal as full requirement
+	var req RequirementDetail
+	if err := json.Unmarshal(data, &req); err != nil {
+		return err
+	}
+	r.Full = &req
+	return nil
+}
+
+// MarshalJSON writes string references as plain strings
+func (r RequirementReference) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.External != nil:
+		return json.Marshal(externalEntry{External: r.External})
+	case r.Full == nil:
+		return json.Marshal(r.Reference)
+	}
+	return json.Marshal(r.Full)
+}
+
+// UnmarshalYAML handles full requirements, string references and external
+// references
+func (r *RequirementReference) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&r.Reference)
+	}
+	if isExternalEntry(node) {
+		var entry externalEntry
+		if err := node.Decode(&entry); err != nil {
+			return err
+		}
+		r.External = entry.External
+		return nil
+	}
+
+	var req RequirementDetail
+	if err := node.Decode(&req); err != nil {
+		return err
+	}
+	r.Full = &req
+	return nil
+}
+
+// isExternalEntry tells whether node is an external: mapping rather than an
+// inline requirement
+func isExternalEntry(node *yaml.Node) bool {
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == "external" {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalYAML writes string references as plain scalars
+func (r RequirementReference) MarshalYAML() (interface{}, error) {
+	switch {
+	case r.External != nil:
+		return externalEntry{External: r.External}, nil
+	case r.Full == nil:
+		return r.Reference, nil
+	}
+	return r.Full, nil
+}
@@ -0,0 +1,202 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/render"
+	"go.yaml.in/yaml/v3"
+)
+
+const testYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    requirements:
+      - summary: Password hashing
+        name: AUTH-002
+        requirements:
+          - summary: Salt per user
+      - Session timeout
+  - summary: Session timeout
+    name: AUTH-003
+`
+
+func summaries(reqs []*RequirementDetail) []string {
+	var out []string
+	for _, req := range reqs {
+		out = append(out, req.Summary)
+	}
+	return out
+}
+
+func TestParseYAMLAndJSON(t *testing.T) {
+	config, err := ParseYAML([]byte(testYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref := config.Requirements[0].Requirements[1]; ref.Full != nil || ref.Reference != "Session timeout" {
+		t.Errorf("Expected a string reference, got %+v", ref)
+	}
+
+	// String references survive a JSON round trip as plain strings
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := ParseJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromJSON, config) {
+		t.Errorf("JSON round trip changed the config:\n%s", data)
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYAML, err := ParseYAML(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromYAML, config) {
+		t.Errorf("YAML round trip changed the config:\n%s", out)
+	}
+
+	if _, err := ParseYAML([]byte("requirements: [\n")); err == nil {
+		t.Error("Expected a parse error")
+	}
+}
+
+func TestExternalReference(t *testing.T) {
+	config, err := ParseYAML([]byte(`version: "1.0"
+requirements:
+  - summary: Checkout
+    requirements:
+      - external:
+          repo: github.com/acme/platform
+          id: PLAT-42
+          ref: v2.1
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := config.Requirements[0].Requirements[0]
+	want := &ExternalReference{Repo: "github.com/acme/platform", ID: "PLAT-42", Ref: "v2.1"}
+	if !reflect.DeepEqual(ref.External, want) || ref.Full != nil || ref.Reference != "" {
+		t.Fatalf("Expected an external reference, got %+v", ref)
+	}
+	if s := ref.External.String(); s != "PLAT-42@github.com/acme/platform?ref=v2.1" {
+		t.Errorf("String() = %q", s)
+	}
+	if n := len(config.Flatten()); n != 1 {
+		t.Errorf("Flatten() returned %d requirements, want the external one left out", n)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := ParseJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromJSON, config) {
+		t.Errorf("JSON round trip changed the config:\n%s", data)
+	}
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYAML, err := ParseYAML(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromYAML, config) {
+		t.Errorf("YAML round trip changed the config:\n%s", out)
+	}
+}
+
+func TestFlattenAndFind(t *testing.T) {
+	config, err := ParseYAML([]byte(testYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"Login", "Password hashing", "Salt per user", "Session timeout"}
+	if got := summaries(config.Flatten()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten() = %v, want %v", got, want)
+	}
+	if got := summaries(config.Requirements[0].Children()); !reflect.DeepEqual(got, []string{"Password hashing"}) {
+		t.Errorf("Children() = %v", got)
+	}
+	if got := summaries(config.Requirements[0].Children()[0].Flatten()); !reflect.DeepEqual(got, []string{"Password hashing", "Salt per user"}) {
+		t.Errorf("RequirementDetail.Flatten() = %v", got)
+	}
+
+	if req, ok := config.Find("AUTH-002"); !ok || req.Summary != "Password hashing" {
+		t.Errorf("Find(AUTH-002) = %v, %v", req, ok)
+	}
+	if req, ok := config.Find("Session timeout"); !ok || req.Name != "AUTH-003" {
+		t.Errorf("Find(Session timeout) = %v, %v", req, ok)
+	}
+	if _, ok := config.Find("missing"); ok {
+		t.Error("Find(missing) succeeded")
+	}
+}
+
+func TestWalk(t *testing.T) {
+	config, err := ParseYAML([]byte(testYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var visited []string
+	err = config.Walk(render.VisitorFunc[*RequirementDetail](func(req *RequirementDetail, pos render.Position) error {
+		visited = append(visited, req.Summary)
+		if req.Name == "AUTH-002" {
+			return render.SkipChildren
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Login", "Password hashing", "Session timeout"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("Walk visited %v, want %v", visited, want)
+	}
+}
+
+func TestResolveOwner(t *testing.T) {
+	config := &RequirementConfig{Aliases: []PersonAlias{
+		{Alias: "alice", Name: "Alice Smith", Email: "alice@example.com", GitHub: "asmith"},
+		{Alias: "bob", Email: "bob@example.com"},
+	}}
+
+	tests := []struct {
+		owner, key, display string
+	}{
+		{"@alice", "@alice", "Alice Smith <alice@example.com>"},
+		{"alice", "@alice", "Alice Smith <alice@example.com>"},
+		{"@ASmith", "@alice", "Alice Smith <alice@example.com>"},
+		{"Alice@Example.com", "@alice", "Alice Smith <alice@example.com>"},
+		{"@bob", "@bob", "bob@example.com"},
+		{"Carol@Example.com", "carol@example.com", ""},
+		{"@dave", "@dave", ""},
+	}
+	for _, tt := range tests {
+		alias, ok := config.ResolveOwner(tt.owner)
+		if ok != (tt.display != "") || ok && alias.String() != tt.display {
+			t.Errorf("ResolveOwner(%q) = %v, %v, want %q", tt.owner, alias, ok, tt.display)
+		}
+		if key := config.OwnerKey(tt.owner); key != tt.key {
+			t.Errorf("OwnerKey(%q) = %q, want %q", tt.owner, key, tt.key)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package model
+
+import "strings"
+
+// ResolveOwner finds the alias an owner field refers to: @alias, a bare
+// alias, the GitHub handle of an alias, with or without @, or the email of
+// an alias
+func (c *RequirementConfig) ResolveOwner(owner string) (PersonAlias, bool) {
+	owner = strings.TrimSpace(owner)
+	if owner == "" {
+		return PersonAlias{}, false
+	}
+	name := strings.TrimPrefix(owner, "@")
+	for _, alias := range c.Aliases {
+		switch {
+		case alias.Alias == name,
+			alias.GitHub != "" && strings.EqualFold(alias.GitHub, name),
+			alias.Email != "" && strings.EqualFold(alias.Email, owner):
+			return alias, true
+		}
+	}
+	return PersonAlias{}, false
+}
+
+// OwnerKey normalizes an owner so that every way of referring to the same
+// person compares equal: the @alias of a resolved owner, or the trimmed
+// owner with an email lowercased
+func (c *RequirementConfig) OwnerKey(owner string) string {
+	if alias, ok := c.ResolveOwner(owner); ok {
+		return "@" + alias.Alias
+	}
+	owner = strings.TrimSpace(owner)
+	if strings.Contains(owner, "@") && !strings.HasPrefix(owner, "@") {
+		return strings.ToLower(owner)
+	}
+	return owner
+}
+
+// String shows the person as "Name <email>", or whichever of the two is
+// set, falling back to @alias
+func (a PersonAlias) String() string {
+	switch {
+	case a.Name != "" && a.Email != "":
+		return a.Name + " <" + a.Email + ">"
+	case a.Name != "":
+		return a.Name
+	case a.Email != "":
+		return a.Email
+	}
+	return "@" + a.Alias
+}
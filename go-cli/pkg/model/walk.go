@@ -0,0 +1,67 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package model
+
+import "github.com/238855/rqm/go-cli/pkg/render"
+
+// Roots returns pointers to the top-level requirements
+func (c *RequirementConfig) Roots() []*RequirementDetail {
+	roots := make([]*RequirementDetail, len(c.Requirements))
+	for i := range c.Requirements {
+		roots[i] = &c.Requirements[i]
+	}
+	return roots
+}
+
+// Children returns the inline sub-requirements of r. String references
+// point at requirements that appear elsewhere in the tree.
+func (r *RequirementDetail) Children() []*RequirementDetail {
+	if len(r.Requirements) == 0 {
+		return nil
+	}
+	children := make([]*RequirementDetail, 0, len(r.Requirements))
+	for _, child := range r.Requirements {
+		if child.Full != nil {
+			children = append(children, child.Full)
+		}
+	}
+	return children
+}
+
+// Walk visits every inline requirement depth first, parents before their
+// children. Output formats can pass a render.Tree or render.Markdown.
+func (c *RequirementConfig) Walk(v render.Visitor[*RequirementDetail]) error {
+	return render.Walk(c.Roots(), (*RequirementDetail).Children, v)
+}
+
+// Flatten returns every inline requirement in the order Walk visits them
+func (c *RequirementConfig) Flatten() []*RequirementDetail {
+	return render.Flatten(c.Roots(), (*RequirementDetail).Children)
+}
+
+// All is Flatten under the name pkg/rqmcore published it with
+func (c *RequirementConfig) All() []*RequirementDetail {
+	return c.Flatten()
+}
+
+// Flatten returns r and its inline descendants, parents first
+func (r *RequirementDetail) Flatten() []*RequirementDetail {
+	return render.Flatten([]*RequirementDetail{r}, (*RequirementDetail).Children)
+}
+
+// Find returns the requirement whose name or summary is ref, preferring a
+// name match
+func (c *RequirementConfig) Find(ref string) (*RequirementDetail, bool) {
+	var bySummary *RequirementDetail
+	for _, req := range c.Flatten() {
+		if req.Name == ref {
+			return req, true
+		}
+		if req.Summary == ref && bySummary == nil {
+			bySummary = req
+		}
+	}
+	return bySummary, bySummary != nil
+}
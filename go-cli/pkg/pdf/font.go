@@ -0,0 +1,134 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package pdf
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Font is one of the standard fonts every PDF reader has
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+	HelveticaOblique
+	Courier
+)
+
+// fonts describes the standard fonts by Font. Widths are in thousandths
+// of the font size, for the printable ASCII characters from the space
+// on; other characters are taken as wide as the font's digits.
+var fonts = []struct {
+	name   string
+	widths []int
+}{
+	Helvetica: {"Helvetica", helveticaWidths},
+	HelveticaBold: {"Helvetica-Bold", []int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}},
+	// The oblique font has the widths of the upright one
+	HelveticaOblique: {"Helvetica-Oblique", helveticaWidths},
+	Courier:          {"Courier", nil},
+}
+
+var helveticaWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// winAnsi maps the characters of Windows-1252 outside Latin-1 to their
+// code
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// encode converts s to Windows-1252, the encoding of the standard fonts.
+// Tabs become spaces and other characters the fonts lack become "?".
+func encode(s string) []byte {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			b = append(b, ' ')
+		case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+			b = append(b, byte(r))
+		case winAnsi[r] != 0:
+			b = append(b, winAnsi[r])
+		default:
+			b = append(b, '?')
+		}
+	}
+	return b
+}
+
+// Width is the width of s in points when drawn in font at size
+func Width(font Font, size float64, s string) float64 {
+	widths := fonts[font].widths
+	total := 0
+	for _, c := range encode(s) {
+		switch {
+		case widths == nil:
+			total += 600
+		case c >= 0x20 && c < 0x7F:
+			total += widths[c-0x20]
+		default:
+			total += widths['0'-0x20]
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Wrap breaks text into lines no wider than width, at spaces where it
+// can and within words longer than a line. Line breaks in text are kept.
+func Wrap(font Font, size float64, text string, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		// Leading spaces indent, as in lists and code
+		indent := paragraph[:len(paragraph)-len(strings.TrimLeftFunc(paragraph, unicode.IsSpace))]
+		line := indent
+		for _, word := range strings.Fields(paragraph) {
+			candidate := line + word
+			if strings.TrimSpace(line) != "" {
+				candidate = line + " " + word
+			}
+			if Width(font, size, candidate) <= width {
+				line = candidate
+				continue
+			}
+			if strings.TrimSpace(line) != "" {
+				lines = append(lines, line)
+				line = indent
+			}
+			// Split a word that doesn't fit on a line of its own
+			for len([]rune(word)) > 1 && Width(font, size, line+word) > width {
+				runes := []rune(word)
+				n := 1
+				for n < len(runes) && Width(font, size, line+string(runes[:n+1])) <= width {
+					n++
+				}
+				lines = append(lines, line+string(runes[:n]))
+				word = string(runes[n:])
+				line = indent
+			}
+			line += word
+		}
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+	return lines
+}
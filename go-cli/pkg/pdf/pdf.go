@@ -0,0 +1,401 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+// Package pdf writes simple PDF documents with the standard library only:
+// text in the PDF standard fonts, lines and filled rectangles, links
+// between pages and an outline (bookmarks).
+//
+// Coordinates are in points (1/72 inch) from the top-left corner of the
+// page, y growing downwards, and text is placed by its baseline. The
+// standard fonts need no embedding, so text is limited to the Windows-1252
+// character set; other characters print as "?".
+//
+//	doc := pdf.New(pdf.A4)
+//	page := doc.AddPage()
+//	page.Text(72, 72, pdf.HelveticaBold, 18, "Requirements")
+//	doc.Bookmark("Requirements", 0, page, 72)
+//	_, err := doc.WriteTo(w)
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// Size is a page size in points
+type Size struct {
+	Width, Height float64
+}
+
+var (
+	// A4 is 210 × 297 mm
+	A4 = Size{595.28, 841.89}
+	// Letter is 8.5 × 11 inches
+	Letter = Size{612, 792}
+)
+
+// Color is an RGB color, each component from 0 to 1
+type Color struct {
+	R, G, B float64
+}
+
+// Black is the color everything is drawn in until SetColor
+var Black = Color{}
+
+// Gray is a shade of gray, from 0 (black) to 1 (white)
+func Gray(level float64) Color {
+	return Color{level, level, level}
+}
+
+// Document is a PDF document being built in memory
+type Document struct {
+	// Title, Author and Subject go into the document information
+	Title, Author, Subject string
+
+	size    Size
+	pages   []*Page
+	outline []*bookmark
+}
+
+// New starts an empty document whose pages have the given size
+func New(size Size) *Document {
+	return &Document{size: size}
+}
+
+// Size is the size of the document's pages
+func (d *Document) Size() Size {
+	return d.size
+}
+
+// Page is a page of a Document. Drawing on it appends to its content
+// stream, so later drawing covers earlier drawing.
+type Page struct {
+	// Number is the page's 1-based position in the document
+	Number int
+
+	doc     *Document
+	content bytes.Buffer
+	links   []link
+}
+
+// link is a clickable area of a page leading to a position on another
+type link struct {
+	x, y, w, h float64
+	target     *Page
+	top        float64
+}
+
+// bookmark is an entry of the document outline
+type bookmark struct {
+	title    string
+	level    int
+	page     *Page
+	top      float64
+	children []*bookmark
+}
+
+// AddPage appends a page to the document
+func (d *Document) AddPage() *Page {
+	page := &Page{Number: len(d.pages) + 1, doc: d}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// Pages lists the document's pages in order
+func (d *Document) Pages() []*Page {
+	return d.pages
+}
+
+// Bookmark adds an outline entry leading to top on page. Level 0 entries
+// are top-level; an entry of a deeper level nests under the last entry
+// of a lower one.
+func (d *Document) Bookmark(title string, level int, page *Page, top float64) {
+	item := &bookmark{title: title, level: level, page: page, top: top}
+	items := &d.outline
+	for len(*items) > 0 && (*items)[len(*items)-1].level < level {
+		parent := (*items)[len(*items)-1]
+		items = &parent.children
+	}
+	*items = append(*items, item)
+}
+
+// SetColor sets the color of the text and shapes drawn after it
+func (p *Page) SetColor(c Color) {
+	fmt.Fprintf(&p.content, "%s %s %s rg %[1]s %[2]s %[3]s RG\n", num(c.R), num(c.G), num(c.B))
+}
+
+// Text draws s with its baseline starting at x, y
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /F%d %s Tf %s %s Td %s Tj ET\n", font+1, num(size), num(x), num(p.doc.size.Height-y), literal(encode(s)))
+}
+
+// Line draws a line from x1, y1 to x2, y2
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	h := p.doc.size.Height
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n", num(width), num(x1), num(h-y1), num(x2), num(h-y2))
+}
+
+// FillRect fills the rectangle whose top-left corner is x, y in color,
+// leaving the color of what is drawn after it as it was
+func (p *Page) FillRect(x, y, w, h float64, color Color) {
+	fmt.Fprintf(&p.content, "q %s %s %s rg %s %s %s %s re f Q\n", num(color.R), num(color.G), num(color.B), num(x), num(p.doc.size.Height-y-h), num(w), num(h))
+}
+
+// StrokeRect draws the outline of the rectangle whose top-left corner is
+// x, y
+func (p *Page) StrokeRect(x, y, w, h, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n", num(width), num(x), num(p.doc.size.Height-y-h), num(w), num(h))
+}
+
+// Link makes the rectangle whose top-left corner is x, y a link to top on
+// target
+func (p *Page) Link(x, y, w, h float64, target *Page, top float64) {
+	p.links = append(p.links, link{x: x, y: y, w: w, h: h, target: target, top: top})
+}
+
+// WriteTo writes the document as a PDF file
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	out := &pdfWriter{w: bufio.NewWriter(w)}
+
+	// Objects are numbered up front, as pages, links and the outline
+	// refer to each other: the catalog, the page tree, the document
+	// information, the fonts, then a page and its content per page, then
+	// the outline
+	const catalog, pageTree, info, firstFont = 1, 2, 3, 4
+	firstPage := firstFont + len(fonts)
+	pageObj := func(p *Page) int { return firstPage + 2*(p.Number-1) }
+	outlineRoot := 0
+	if len(d.outline) > 0 {
+		outlineRoot = firstPage + 2*len(d.pages)
+	}
+
+	out.header()
+
+	out.object(catalog)
+	if outlineRoot > 0 {
+		out.printf("<< /Type /Catalog /Pages %d 0 R /Outlines %d 0 R /PageMode /UseOutlines >>", pageTree, outlineRoot)
+	} else {
+		out.printf("<< /Type /Catalog /Pages %d 0 R >>", pageTree)
+	}
+	out.end()
+
+	out.object(pageTree)
+	out.printf("<< /Type /Pages /MediaBox [0 0 %s %s] /Count %d /Kids [", num(d.size.Width), num(d.size.Height), len(d.pages))
+	for _, p := range d.pages {
+		out.printf(" %d 0 R", pageObj(p))
+	}
+	out.printf(" ] >>")
+	out.end()
+
+	out.object(info)
+	out.printf("<< /Producer %s", textString("rqm"))
+	for _, field := range []struct{ key, value string }{{"Title", d.Title}, {"Author", d.Author}, {"Subject", d.Subject}} {
+		if field.value != "" {
+			out.printf(" /%s %s", field.key, textString(field.value))
+		}
+	}
+	out.printf(" >>")
+	out.end()
+
+	for i, font := range fonts {
+		out.object(firstFont + i)
+		out.printf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font.name)
+		out.end()
+	}
+
+	var resources strings.Builder
+	resources.WriteString("<< /Font <<")
+	for i := range fonts {
+		fmt.Fprintf(&resources, " /F%d %d 0 R", i+1, firstFont+i)
+	}
+	resources.WriteString(" >> >>")
+
+	for _, p := range d.pages {
+		out.object(pageObj(p))
+		out.printf("<< /Type /Page /Parent %d 0 R /Resources %s /Contents %d 0 R", pageTree, resources.String(), pageObj(p)+1)
+		if len(p.links) > 0 {
+			out.printf(" /Annots [")
+			for _, l := range p.links {
+				h := d.size.Height
+				out.printf(" << /Type /Annot /Subtype /Link /Border [0 0 0] /Rect [%s %s %s %s] /Dest [%d 0 R /XYZ 0 %s 0] >>",
+					num(l.x), num(h-l.y-l.h), num(l.x+l.w), num(h-l.y), pageObj(l.target), num(h-l.top))
+			}
+			out.printf(" ]")
+		}
+		out.printf(" >>")
+		out.end()
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		zw.Write(p.content.Bytes())
+		zw.Close()
+		out.object(pageObj(p) + 1)
+		out.printf("<< /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+		out.write(compressed.Bytes())
+		out.printf("\nendstream")
+		out.end()
+	}
+
+	if outlineRoot > 0 {
+		d.writeOutline(out, outlineRoot, pageObj)
+	}
+
+	out.trailer(catalog, info)
+	if out.err == nil {
+		out.err = out.w.Flush()
+	}
+	return out.n, out.err
+}
+
+// writeOutline writes the outline root as object root and its entries
+// as the objects after it, depth first
+func (d *Document) writeOutline(out *pdfWriter, root int, pageObj func(*Page) int) {
+	// Number the entries depth first, so an entry's children follow it
+	ids := make(map[*bookmark]int)
+	next := root + 1
+	var number func(items []*bookmark) int
+	number = func(items []*bookmark) int {
+		count := 0
+		for _, item := range items {
+			ids[item] = next
+			next++
+			count += 1 + number(item.children)
+		}
+		return count
+	}
+	total := number(d.outline)
+
+	out.object(root)
+	out.printf("<< /Type /Outlines /First %d 0 R /Last %d 0 R /Count %d >>", ids[d.outline[0]], ids[d.outline[len(d.outline)-1]], total)
+	out.end()
+
+	var write func(items []*bookmark, parent int)
+	write = func(items []*bookmark, parent int) {
+		for i, item := range items {
+			out.object(ids[item])
+			out.printf("<< /Title %s /Parent %d 0 R /Dest [%d 0 R /XYZ 0 %s 0]", textString(item.title), parent, pageObj(item.page), num(d.size.Height-item.top))
+			if i > 0 {
+				out.printf(" /Prev %d 0 R", ids[items[i-1]])
+			}
+			if i < len(items)-1 {
+				out.printf(" /Next %d 0 R", ids[items[i+1]])
+			}
+			if len(item.children) > 0 {
+				// A negative count leaves the entry collapsed
+				out.printf(" /First %d 0 R /Last %d 0 R /Count -%d", ids[item.children[0]], ids[item.children[len(item.children)-1]], len(item.children))
+			}
+			out.printf(" >>")
+			out.end()
+			write(item.children, ids[item])
+		}
+	}
+	write(d.outline, root)
+}
+
+// pdfWriter writes the objects of a PDF file, recording their offsets
+// for the cross-reference table. The first error stops all writing.
+type pdfWriter struct {
+	w       *bufio.Writer
+	n       int64
+	offsets map[int]int64
+	err     error
+}
+
+func (w *pdfWriter) write(b []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.Write(b)
+	w.n += int64(n)
+	w.err = err
+}
+
+func (w *pdfWriter) printf(format string, args ...interface{}) {
+	w.write([]byte(fmt.Sprintf(format, args...)))
+}
+
+func (w *pdfWriter) header() {
+	// The binary comment marks the file as binary for transfer tools
+	w.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+}
+
+func (w *pdfWriter) object(id int) {
+	if w.offsets == nil {
+		w.offsets = make(map[int]int64)
+	}
+	w.offsets[id] = w.n
+	w.printf("%d 0 obj\n", id)
+}
+
+func (w *pdfWriter) end() {
+	w.printf("\nendobj\n")
+}
+
+func (w *pdfWriter) trailer(root, info int) {
+	xref := w.n
+	size := len(w.offsets) + 1
+	w.printf("xref\n0 %d\n0000000000 65535 f \n", size)
+	for id := 1; id < size; id++ {
+		w.printf("%010d 00000 n \n", w.offsets[id])
+	}
+	w.printf("trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, root, info, xref)
+}
+
+// num formats a coordinate or size with at most two decimals
+func num(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
+
+// literal is a PDF literal string of the bytes of s
+func literal(s []byte) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, c := range s {
+		switch c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// textString is a PDF text string, as used by the outline and the
+// document information: ASCII as it is, anything else as UTF-16
+func textString(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r >= 0x80 || r < 0x20 {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return literal([]byte(s))
+	}
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	b.WriteByte('>')
+	return b.String()
+}
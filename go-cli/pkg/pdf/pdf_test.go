@@ -0,0 +1,112 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWidth(t *testing.T) {
+	if got := Width(Helvetica, 10, "Hi"); got != 9.44 {
+		t.Errorf("Expected 9.44, got %v", got)
+	}
+	if got := Width(Courier, 10, "Hi €"); got != 24 {
+		t.Errorf("Expected 24 for four monospaced characters, got %v", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width float64
+		want  []string
+	}{
+		{"fits", "one two", 100, []string{"one two"}},
+		{"breaks at spaces", "one two three", 40, []string{"one two", "three"}},
+		{"keeps line breaks", "one\n\ntwo", 100, []string{"one", "", "two"}},
+		{"keeps indentation", "- one two three", 45, []string{"- one two", "three"}},
+		{"splits long words", "abcdefghij", 25, []string{"abcd", "efghij"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wrap(Helvetica, 10, tt.text, tt.width); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	if got := string(encode("Café – “ok”\t✓")); got != "Caf\xe9 \x96 \x93ok\x94 ?" {
+		t.Errorf("Unexpected encoding: %q", got)
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	doc := New(A4)
+	doc.Title = "Spécification"
+	first := doc.AddPage()
+	first.Text(72, 72, HelveticaBold, 18, "Contents (draft)")
+	second := doc.AddPage()
+	first.Link(72, 60, 200, 16, second, 72)
+	doc.Bookmark("1 Login", 0, second, 72)
+	doc.Bookmark("1.1 Lockout", 1, second, 200)
+	doc.Bookmark("2 Reporting", 0, second, 400)
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data := buf.Bytes()
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatalf("Not a PDF file:\n%s", data)
+	}
+
+	// Every cross-reference entry points at its object
+	start, err := strconv.Atoi(regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(string(data))[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllStringSubmatch(string(data[start:]), -1)
+	if len(entries) != 15 {
+		t.Errorf("Expected 15 objects, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(entry[1])
+		if want := strconv.Itoa(i+1) + " 0 obj"; !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Errorf("Object %d: offset %d points at %q", i+1, offset, data[offset:offset+10])
+		}
+	}
+
+	for _, want := range []string{
+		"/Count 2 /Kids [ 8 0 R 10 0 R ]",
+		"/Subtype /Link /Border [0 0 0] /Rect [72 765.89 272 781.89] /Dest [10 0 R /XYZ 0 769.89 0]",
+		"/Title <FEFF0053007000E900630069006600690063006100740069006F006E>",
+		"/Type /Outlines /First 13 0 R /Last 15 0 R /Count 3",
+		"/Title (1 Login) /Parent 12 0 R /Dest [10 0 R /XYZ 0 769.89 0] /Next 15 0 R /First 14 0 R /Last 14 0 R /Count -1",
+		"/Title (2 Reporting) /Parent 12 0 R /Dest [10 0 R /XYZ 0 441.89 0] /Prev 13 0 R",
+	} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("Expected %q in:\n%s", want, data)
+		}
+	}
+
+	stream := regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindSubmatch(data)[1]
+	zr, err := zlib.NewReader(bytes.NewReader(stream))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(zr)
+	if want := `BT /F2 18 Tf 72 769.89 Td (Contents \(draft\)) Tj ET`; !strings.Contains(string(content), want) {
+		t.Errorf("Expected %q in the page content, got:\n%s", want, content)
+	}
+}
@@ -0,0 +1,49 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Markdown renders items as a document outline: each item is a heading one
+// level below its parent's, followed by its body.
+//
+//	## [AUTH-001] Login
+//
+//	Users sign in with email and password.
+//
+//	### [AUTH-002] Password hashing
+type Markdown[T any] struct {
+	W io.Writer
+	// Level is the heading level of top-level items (default 1). Headings
+	// deeper than 6 are written as bold paragraphs, which Markdown can't nest.
+	Level int
+	// Heading is the item's heading text
+	Heading func(T) string
+	// Body returns the Markdown under the heading; nil for none
+	Body func(T) string
+}
+
+// Visit writes item's heading and body
+func (m *Markdown[T]) Visit(item T, pos Position) error {
+	level := max(m.Level, 1) + pos.Depth
+	heading := strings.Join(strings.Fields(m.Heading(item)), " ")
+	var err error
+	if level <= 6 {
+		_, err = fmt.Fprintf(m.W, "%s %s\n\n", strings.Repeat("#", level), heading)
+	} else {
+		_, err = fmt.Fprintf(m.W, "**%s**\n\n", heading)
+	}
+	if err != nil || m.Body == nil {
+		return err
+	}
+	if body := strings.TrimSpace(m.Body(item)); body != "" {
+		_, err = fmt.Fprintf(m.W, "%s\n\n", body)
+	}
+	return err
+}
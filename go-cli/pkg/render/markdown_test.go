@@ -0,0 +1,43 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	var out strings.Builder
+	md := &Markdown[*node]{
+		W:       &out,
+		Level:   2,
+		Heading: func(n *node) string { return "Requirement " + n.name },
+		Body: func(n *node) string {
+			if n.name == "a" {
+				return "\nFirst line.\n\n"
+			}
+			return ""
+		},
+	}
+	if err := Walk(testTree(), children, md); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "## Requirement a\n\nFirst line.\n\n### Requirement b\n\n#### Requirement c\n\n### Requirement d\n\n## Requirement e\n\n"
+	if out.String() != want {
+		t.Errorf("Markdown output:\n%q\nwant:\n%q", out.String(), want)
+	}
+}
+
+func TestMarkdownDeepHeadings(t *testing.T) {
+	var out strings.Builder
+	md := &Markdown[*node]{W: &out, Level: 6, Heading: func(n *node) string { return n.name }}
+	Walk([]*node{parent("top", leaf("deep"))}, children, md)
+
+	if want := "###### top\n\n**deep**\n\n"; out.String() != want {
+		t.Errorf("Markdown output %q, want %q", out.String(), want)
+	}
+}
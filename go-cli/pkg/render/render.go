@@ -0,0 +1,144 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+// Package render walks a requirement tree for output formats.
+//
+// Renderers are Visitors: Walk calls Visit for every item, parents before
+// their children, with the item's Position. The position carries what
+// formats need to lay items out: the depth for indentation and heading
+// levels, and whether the item and its ancestors are last among their
+// siblings for drawing tree guides. The package is generic over the item
+// type, so it works with any requirement model.
+//
+//	children := func(r *Req) []*Req { return r.Children }
+//	tree := &render.Tree[*Req]{W: os.Stdout, Line: func(r *Req) string { return r.Summary }}
+//	err := render.Walk(roots, children, tree)
+package render
+
+import "errors"
+
+// SkipChildren can be returned by Visit to leave out the item's children.
+// Walk does not return it as an error.
+var SkipChildren = errors.New("skip children")
+
+// Position locates an item in the tree
+type Position struct {
+	// Depth is 0 for top-level items
+	Depth int
+	// Index is the item's position among its siblings
+	Index int
+	// Last reports whether the item is the last of its siblings
+	Last bool
+
+	// ancestors holds Last for each ancestor below the top level
+	ancestors []bool
+}
+
+// Guides returns the box-drawing prefixes for an item in an indented
+// tree: line goes before the item's own line, body before anything
+// printed under it. Both are empty for top-level items.
+func (p Position) Guides() (line, body string) {
+	if p.Depth == 0 {
+		return "", ""
+	}
+	// Both prefixes share one allocation, as they are needed for every item
+	both := p.appendGuide(nil, false)
+	split := len(both)
+	both = p.appendGuide(both, true)
+	return string(both[:split]), string(both[split:])
+}
+
+// appendGuide appends the line prefix of Guides to dst, or the body
+// prefix when body is set
+func (p Position) appendGuide(dst []byte, body bool) []byte {
+	if p.Depth == 0 {
+		return dst
+	}
+	dst = append(dst, "  "...)
+	for _, last := range p.ancestors {
+		if last {
+			dst = append(dst, "   "...)
+		} else {
+			dst = append(dst, "│  "...)
+		}
+	}
+	switch {
+	case body && p.Last:
+		return append(dst, "   "...)
+	case body:
+		return append(dst, "│  "...)
+	case p.Last:
+		return append(dst, "└─ "...)
+	}
+	return append(dst, "├─ "...)
+}
+
+// Visitor receives the items of a tree from Walk
+type Visitor[T any] interface {
+	Visit(item T, pos Position) error
+}
+
+// VisitorFunc adapts a function to the Visitor interface
+type VisitorFunc[T any] func(item T, pos Position) error
+
+// Visit calls f(item, pos)
+func (f VisitorFunc[T]) Visit(item T, pos Position) error {
+	return f(item, pos)
+}
+
+// MaxDepth limits v to the top depth levels of the tree: items at the
+// last level are visited but their children aren't, so Walk never asks
+// for them. A depth of 0 or less leaves v unlimited.
+func MaxDepth[T any](v Visitor[T], depth int) Visitor[T] {
+	if depth <= 0 {
+		return v
+	}
+	return VisitorFunc[T](func(item T, pos Position) error {
+		if err := v.Visit(item, pos); err != nil {
+			return err
+		}
+		if pos.Depth >= depth-1 {
+			return SkipChildren
+		}
+		return nil
+	})
+}
+
+// Walk visits roots and, through children, all their descendants depth
+// first. It stops at the first error from Visit other than SkipChildren.
+func Walk[T any](roots []T, children func(T) []T, v Visitor[T]) error {
+	return walk(roots, children, v, 0, nil)
+}
+
+func walk[T any](items []T, children func(T) []T, v Visitor[T], depth int, ancestors []bool) error {
+	for i, item := range items {
+		pos := Position{Depth: depth, Index: i, Last: i == len(items)-1, ancestors: ancestors}
+		err := v.Visit(item, pos)
+		if errors.Is(err, SkipChildren) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		next := ancestors
+		if depth > 0 {
+			next = append(append([]bool(nil), ancestors...), pos.Last)
+		}
+		if err := walk(children(item), children, v, depth+1, next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flatten returns every item of the tree in the order Walk visits them
+func Flatten[T any](roots []T, children func(T) []T) []T {
+	var all []T
+	Walk(roots, children, VisitorFunc[T](func(item T, _ Position) error {
+		all = append(all, item)
+		return nil
+	}))
+	return all
+}
@@ -0,0 +1,141 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package render
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// node is a minimal tree item for tests
+type node struct {
+	name     string
+	children []*node
+}
+
+func children(n *node) []*node { return n.children }
+
+func leaf(name string) *node { return &node{name: name} }
+
+func parent(name string, children ...*node) *node { return &node{name: name, children: children} }
+
+// testTree is
+//
+//	a
+//	├─ b
+//	│  └─ c
+//	└─ d
+//	e
+func testTree() []*node {
+	return []*node{parent("a", parent("b", leaf("c")), leaf("d")), leaf("e")}
+}
+
+func TestWalk(t *testing.T) {
+	var got []string
+	err := Walk(testTree(), children, VisitorFunc[*node](func(n *node, pos Position) error {
+		got = append(got, fmt.Sprintf("%s:%d:%d:%v", n.name, pos.Depth, pos.Index, pos.Last))
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a:0:0:false", "b:1:0:false", "c:2:0:true", "d:1:1:true", "e:0:1:true"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Walk visited %v, want %v", got, want)
+	}
+}
+
+func TestWalkSkipChildren(t *testing.T) {
+	var got []string
+	err := Walk(testTree(), children, VisitorFunc[*node](func(n *node, pos Position) error {
+		got = append(got, n.name)
+		if n.name == "b" {
+			return SkipChildren
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("SkipChildren returned as error: %v", err)
+	}
+	if want := []string{"a", "b", "d", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Walk visited %v, want %v", got, want)
+	}
+}
+
+func TestWalkStopsOnError(t *testing.T) {
+	stop := errors.New("stop")
+	visited := 0
+	err := Walk(testTree(), children, VisitorFunc[*node](func(n *node, pos Position) error {
+		visited++
+		if n.name == "c" {
+			return stop
+		}
+		return nil
+	}))
+	if !errors.Is(err, stop) || visited != 3 {
+		t.Errorf("Walk returned %v after %d visits, want stop after 3", err, visited)
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	expanded := map[string]bool{}
+	childrenOf := func(n *node) []*node {
+		expanded[n.name] = true
+		return n.children
+	}
+
+	var got []string
+	visit := VisitorFunc[*node](func(n *node, pos Position) error {
+		got = append(got, n.name)
+		return nil
+	})
+	if err := Walk(testTree(), childrenOf, MaxDepth[*node](visit, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "d", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Walk visited %v, want %v", got, want)
+	}
+	if expanded["b"] || expanded["d"] {
+		t.Errorf("Children below the depth limit were expanded: %v", expanded)
+	}
+
+	got = nil
+	Walk(testTree(), children, MaxDepth[*node](visit, 0))
+	if len(got) != 5 {
+		t.Errorf("MaxDepth 0 visited %v, want the whole tree", got)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	var names []string
+	for _, n := range Flatten(testTree(), children) {
+		names = append(names, n.name)
+	}
+	if got := strings.Join(names, ","); got != "a,b,c,d,e" {
+		t.Errorf("Flatten = %s, want a,b,c,d,e", got)
+	}
+}
+
+func TestGuides(t *testing.T) {
+	var got []string
+	Walk(testTree(), children, VisitorFunc[*node](func(n *node, pos Position) error {
+		line, body := pos.Guides()
+		got = append(got, fmt.Sprintf("%q %q", line, body))
+		return nil
+	}))
+	want := []string{
+		`"" ""`,
+		`"  ├─ " "  │  "`,
+		`"  │  └─ " "  │     "`,
+		`"  └─ " "     "`,
+		`"" ""`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Guides = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package render
+
+import "io"
+
+// Tree renders items as an indented tree with box-drawing guides:
+//
+//	✓ [AUTH-001] Login
+//	  Owner: alice
+//	  ├─ ✓ [AUTH-002] Password hashing
+//	  └─ ◯ [AUTH-003] Session timeout
+type Tree[T any] struct {
+	W io.Writer
+	// Line is the text of the item's own line
+	Line func(T) string
+	// AppendLine, when set, is used instead of Line: it appends the text
+	// to dst, which saves building a string for every item of a big tree
+	AppendLine func(dst []byte, item T) []byte
+	// Details returns extra lines printed under the item; nil for none
+	Details func(T) []string
+
+	// buf holds the line being written, reused from item to item
+	buf []byte
+}
+
+// Visit writes item's line and details
+func (t *Tree[T]) Visit(item T, pos Position) error {
+	line := pos.appendGuide(t.buf[:0], false)
+	if t.AppendLine != nil {
+		line = t.AppendLine(line, item)
+	} else {
+		line = append(line, t.Line(item)...)
+	}
+	if err := t.write(line, ""); err != nil {
+		return err
+	}
+	if t.Details == nil {
+		return nil
+	}
+	for _, detail := range t.Details(item) {
+		if err := t.write(append(pos.appendGuide(t.buf[:0], true), "  "...), detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write writes text after prefix, the start of t.buf, as one line: a
+// single Write of a buffer kept for the next line, where writing the
+// parts one by one would cost a call each
+func (t *Tree[T]) write(prefix []byte, text string) error {
+	t.buf = append(append(prefix, text...), '\n')
+	_, err := t.W.Write(t.buf)
+	return err
+}
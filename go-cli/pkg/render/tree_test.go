@@ -0,0 +1,53 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTree(t *testing.T) {
+	var out strings.Builder
+	tree := &Tree[*node]{
+		W:    &out,
+		Line: func(n *node) string { return n.name },
+		Details: func(n *node) []string {
+			if n.name == "b" {
+				return []string{"Owner: bob"}
+			}
+			return nil
+		},
+	}
+	if err := Walk(testTree(), children, tree); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `a
+  ├─ b
+  │    Owner: bob
+  │  └─ c
+  └─ d
+e
+`
+	if out.String() != want {
+		t.Errorf("Tree output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestTreeAppendLine(t *testing.T) {
+	var out strings.Builder
+	tree := &Tree[*node]{
+		W:          &out,
+		Line:       func(n *node) string { return "unused" },
+		AppendLine: func(dst []byte, n *node) []byte { return append(append(dst, '<'), n.name+">"...) },
+	}
+	if err := Walk(testTree(), children, tree); err != nil {
+		t.Fatal(err)
+	}
+	if want := "<a>\n  ├─ <b>\n  │  └─ <c>\n  └─ <d>\n<e>\n"; out.String() != want {
+		t.Errorf("Tree output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,33 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+// Package rqm ties Go tests to the requirements they verify, for
+// rqm trace gotest:
+//
+//	import "github.com/238855/rqm/go-cli/pkg/rqm"
+//
+//	func TestLogin(t *testing.T) {
+//		rqm.Covers(t, "AUTH-001", "AUTH-002")
+//		...
+//	}
+//
+// rqm trace gotest finds the Covers calls by reading the test source, so
+// the IDs must be string literals. A comment directive does the same
+// without the import:
+//
+//	// rqm: AUTH-001, AUTH-002
+//	func TestLogin(t *testing.T) {
+package rqm
+
+import "testing"
+
+// Covers marks the calling test as verifying the requirements with the
+// given IDs (or summaries). The IDs are logged, so they show in go test -v
+// output next to the test's result.
+func Covers(t testing.TB, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		t.Logf("rqm: covers %s", id)
+	}
+}
@@ -0,0 +1,32 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package rqm
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// recorder keeps what a test logs
+type recorder struct {
+	testing.TB
+	logs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Logf(format string, args ...any) {
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+func TestCovers(t *testing.T) {
+	r := &recorder{TB: t}
+	Covers(r, "AUTH-001", "Password hashing")
+	want := []string{"rqm: covers AUTH-001", "rqm: covers Password hashing"}
+	if !reflect.DeepEqual(r.logs, want) {
+		t.Errorf("Covers logged %q, want %q", r.logs, want)
+	}
+}
@@ -0,0 +1,314 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+// Package graph exposes the requirement dependency graph to Go programs.
+//
+// Nodes are requirements keyed by summary, the same key the rqm-validator
+// uses. Edges are directed and carry a Kind: a parent points at each of its
+// sub-requirements with KindChild, and typed relations keep their relation
+// name (a depends_on edge points from the dependent requirement at its
+// dependency). All queries return results in a stable order so they can be
+// compared in tests and printed deterministically.
+//
+//	g := graph.New()
+//	g.AddNode("User Authentication", "AUTH-001")
+//	g.AddNode("Password Hashing", "AUTH-002")
+//	g.AddEdge("User Authentication", "Password Hashing", graph.KindChild)
+//	order, err := g.TopoSort()
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Kind describes why two requirements are connected
+type Kind string
+
+const (
+	// KindChild links a requirement to one of its sub-requirements
+	KindChild Kind = "child"
+	// KindDependsOn links a requirement to one it depends on
+	KindDependsOn Kind = "depends_on"
+	// KindBlocks links a requirement to one it blocks
+	KindBlocks Kind = "blocks"
+)
+
+// ErrCycle is returned by TopoSort when the graph contains a cycle
+var ErrCycle = errors.New("graph contains a cycle")
+
+// Node is a requirement in the graph
+type Node struct {
+	// ID is the requirement summary
+	ID string
+	// Name is the optional short identifier, e.g. AUTH-001
+	Name string
+}
+
+// Edge is a directed connection between two requirements
+type Edge struct {
+	From string
+	To   string
+	Kind Kind
+}
+
+// Graph is a directed requirement graph. The zero value is not usable; call New.
+type Graph struct {
+	nodes map[string]*Node
+	order []string
+	out   map[string][]Edge
+	in    map[string][]Edge
+}
+
+// New returns an empty graph
+func New() *Graph {
+	return &Graph{
+		nodes: make(map[string]*Node),
+		out:   make(map[string][]Edge),
+		in:    make(map[string][]Edge),
+	}
+}
+
+// FromAdjacency builds a graph from an adjacency map such as the "graph"
+// field of rqm-validator --graph output. Every edge gets kind.
+func FromAdjacency(adjacency map[string][]string, kind Kind) *Graph {
+	g := New()
+	ids := make([]string, 0, len(adjacency))
+	for id := range adjacency {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		g.AddNode(id, "")
+	}
+	for _, id := range ids {
+		for _, target := range adjacency[id] {
+			g.AddNode(target, "")
+			g.AddEdge(id, target, kind)
+		}
+	}
+	return g
+}
+
+// AddNode adds a requirement, or fills in the name of an existing one
+func (g *Graph) AddNode(id, name string) *Node {
+	if node, ok := g.nodes[id]; ok {
+		if node.Name == "" {
+			node.Name = name
+		}
+		return node
+	}
+	node := &Node{ID: id, Name: name}
+	g.nodes[id] = node
+	g.order = append(g.order, id)
+	return node
+}
+
+// AddEdge connects two existing nodes. Adding the same edge twice is a no-op.
+func (g *Graph) AddEdge(from, to string, kind Kind) error {
+	if _, ok := g.nodes[from]; !ok {
+		return fmt.Errorf("unknown node: %s", from)
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return fmt.Errorf("unknown node: %s", to)
+	}
+
+	edge := Edge{From: from, To: to, Kind: kind}
+	if slices.Contains(g.out[from], edge) {
+		return nil
+	}
+	g.out[from] = append(g.out[from], edge)
+	g.in[to] = append(g.in[to], edge)
+	return nil
+}
+
+// Node returns the node with the given ID
+func (g *Graph) Node(id string) (*Node, bool) {
+	node, ok := g.nodes[id]
+	return node, ok
+}
+
+// Nodes returns all nodes in the order they were added
+func (g *Graph) Nodes() []*Node {
+	nodes := make([]*Node, len(g.order))
+	for i, id := range g.order {
+		nodes[i] = g.nodes[id]
+	}
+	return nodes
+}
+
+// Edges returns all edges, grouped by source node in insertion order
+func (g *Graph) Edges() []Edge {
+	var edges []Edge
+	for _, id := range g.order {
+		edges = append(edges, g.out[id]...)
+	}
+	return edges
+}
+
+// From returns the edges leaving id
+func (g *Graph) From(id string) []Edge {
+	return slices.Clone(g.out[id])
+}
+
+// To returns the edges arriving at id
+func (g *Graph) To(id string) []Edge {
+	return slices.Clone(g.in[id])
+}
+
+// Descendants returns every node reachable from id, nearest first
+func (g *Graph) Descendants(id string) []string {
+	return g.walk(id, func(e Edge) string { return e.To }, g.out)
+}
+
+// Ancestors returns every node that can reach id, nearest first
+func (g *Graph) Ancestors(id string) []string {
+	return g.walk(id, func(e Edge) string { return e.From }, g.in)
+}
+
+// walk does a breadth-first search from id, excluding id itself
+func (g *Graph) walk(id string, next func(Edge) string, edges map[string][]Edge) []string {
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	var result []string
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, edge := range edges[current] {
+			n := next(edge)
+			if seen[n] {
+				continue
+			}
+			seen[n] = true
+			result = append(result, n)
+			queue = append(queue, n)
+		}
+	}
+	return result
+}
+
+// TopoSort orders nodes so every edge points from an earlier node to a later
+// one. Ties are broken by insertion order. It returns ErrCycle if no such
+// order exists.
+func (g *Graph) TopoSort() ([]string, error) {
+	indegree := make(map[string]int, len(g.order))
+	for _, id := range g.order {
+		for _, edge := range g.out[id] {
+			indegree[edge.To]++
+		}
+	}
+
+	var ready []string
+	for _, id := range g.order {
+		if indegree[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	position := make(map[string]int, len(g.order))
+	for i, id := range g.order {
+		position[id] = i
+	}
+
+	sorted := make([]string, 0, len(g.order))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, id)
+
+		for _, edge := range g.out[id] {
+			indegree[edge.To]--
+			if indegree[edge.To] == 0 {
+				ready = append(ready, edge.To)
+			}
+		}
+		sort.SliceStable(ready, func(i, j int) bool { return position[ready[i]] < position[ready[j]] })
+	}
+
+	if len(sorted) != len(g.order) {
+		return nil, ErrCycle
+	}
+	return sorted, nil
+}
+
+// HasCycles reports whether any cycle exists
+func (g *Graph) HasCycles() bool {
+	return len(g.Cycles()) > 0
+}
+
+// Cycles returns the strongly connected components that contain a cycle,
+// including self-loops. Each component lists its members in insertion order.
+func (g *Graph) Cycles() [][]string {
+	// Tarjan's algorithm, iterating nodes in insertion order
+	index := 0
+	indices := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var connect func(id string)
+	connect = func(id string) {
+		indices[id] = index
+		lowlink[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, edge := range g.out[id] {
+			if _, visited := indices[edge.To]; !visited {
+				connect(edge.To)
+				lowlink[id] = min(lowlink[id], lowlink[edge.To])
+			} else if onStack[edge.To] {
+				lowlink[id] = min(lowlink[id], indices[edge.To])
+			}
+		}
+
+		if lowlink[id] != indices[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || g.hasSelfLoop(id) {
+			components = append(components, component)
+		}
+	}
+
+	for _, id := range g.order {
+		if _, visited := indices[id]; !visited {
+			connect(id)
+		}
+	}
+
+	position := make(map[string]int, len(g.order))
+	for i, id := range g.order {
+		position[id] = i
+	}
+	for _, component := range components {
+		sort.Slice(component, func(i, j int) bool { return position[component[i]] < position[component[j]] })
+	}
+	sort.Slice(components, func(i, j int) bool { return position[components[i][0]] < position[components[j][0]] })
+	return components
+}
+
+func (g *Graph) hasSelfLoop(id string) bool {
+	for _, edge := range g.out[id] {
+		if edge.To == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// newTestGraph builds a graph of nodes joined by {from, to} child edges
+func newTestGraph(t *testing.T, nodes []string, edges ...[2]string) *Graph {
+	t.Helper()
+	g := New()
+	for _, id := range nodes {
+		g.AddNode(id, "")
+	}
+	for _, e := range edges {
+		if err := g.AddEdge(e[0], e[1], KindChild); err != nil {
+			t.Fatalf("AddEdge(%s, %s): %v", e[0], e[1], err)
+		}
+	}
+	return g
+}
+
+func TestTopoSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   []string
+		edges   [][2]string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "tree",
+			nodes: []string{"root", "a", "b", "c"},
+			edges: [][2]string{{"root", "a"}, {"root", "b"}, {"a", "c"}},
+			want:  []string{"root", "a", "b", "c"},
+		},
+		{
+			name:  "dependency across branches",
+			nodes: []string{"api", "auth", "db"},
+			edges: [][2]string{{"db", "auth"}, {"auth", "api"}},
+			want:  []string{"db", "auth", "api"},
+		},
+		{
+			name:    "cycle",
+			nodes:   []string{"a", "b"},
+			edges:   [][2]string{{"a", "b"}, {"b", "a"}},
+			wantErr: ErrCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGraph(t, tt.nodes, tt.edges...)
+			got, err := g.TopoSort()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TopoSort() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TopoSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCycles(t *testing.T) {
+	g := newTestGraph(t, []string{"a", "b", "c", "d", "e"},
+		[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"},
+		[2]string{"c", "d"}, [2]string{"e", "e"})
+
+	want := [][]string{{"a", "b", "c"}, {"e"}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles() = %v, want %v", got, want)
+	}
+	if !g.HasCycles() {
+		t.Error("HasCycles() = false, want true")
+	}
+
+	acyclic := newTestGraph(t, []string{"a", "b"}, [2]string{"a", "b"})
+	if acyclic.HasCycles() {
+		t.Errorf("Unexpected cycles: %v", acyclic.Cycles())
+	}
+}
+
+func TestAncestorsDescendants(t *testing.T) {
+	g := newTestGraph(t, []string{"root", "a", "b", "c"},
+		[2]string{"root", "a"}, [2]string{"root", "b"}, [2]string{"a", "c"}, [2]string{"b", "c"})
+
+	if got, want := g.Descendants("root"), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Descendants(root) = %v, want %v", got, want)
+	}
+	if got, want := g.Ancestors("c"), []string{"a", "b", "root"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Ancestors(c) = %v, want %v", got, want)
+	}
+	if got := g.Descendants("c"); len(got) != 0 {
+		t.Errorf("Descendants(c) = %v, want none", got)
+	}
+}
+
+func TestAddEdge(t *testing.T) {
+	g := New()
+	g.AddNode("a", "A-1")
+	g.AddNode("b", "")
+
+	if err := g.AddEdge("a", "missing", KindDependsOn); err == nil {
+		t.Error("Expected error for unknown node")
+	}
+	g.AddEdge("a", "b", KindDependsOn)
+	g.AddEdge("a", "b", KindDependsOn)
+	g.AddEdge("a", "b", KindChild)
+
+	if got := len(g.Edges()); got != 2 {
+		t.Errorf("Expected 2 edges after duplicate add, got %d", got)
+	}
+	if got := g.To("b"); len(got) != 2 || got[0].Kind != KindDependsOn {
+		t.Errorf("Unexpected incoming edges: %v", got)
+	}
+	if node, ok := g.Node("a"); !ok || node.Name != "A-1" {
+		t.Errorf("Node(a) = %v, %v", node, ok)
+	}
+}
+
+func TestFromAdjacency(t *testing.T) {
+	g := FromAdjacency(map[string][]string{
+		"b": {"c"},
+		"a": {"b", "c"},
+	}, KindChild)
+
+	order, err := g.TopoSort()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("TopoSort() = %v, want %v", order, want)
+	}
+}
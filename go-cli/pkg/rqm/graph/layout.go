@@ -0,0 +1,291 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Point is a node position in layout space. Layouts start at (0, 0) and
+// grow towards positive X and Y.
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// LayoutOptions tune ForceLayout
+type LayoutOptions struct {
+	// Iterations is the number of simulation steps (default 100)
+	Iterations int
+	// Spacing is the preferred distance between connected nodes (default 80)
+	Spacing float64
+	// Initial seeds node positions, typically from an earlier layout of
+	// the same graph. Nodes without one start next to a positioned
+	// neighbour, so a small change to a large graph only moves a little.
+	Initial map[string]Point
+	// Seed makes the placement of new nodes reproducible
+	Seed int64
+}
+
+// Layer spacing used by LayeredLayout
+const (
+	layerSpacingX = 180
+	layerSpacingY = 120
+)
+
+// ForceLayout positions nodes with a Fruchterman-Reingold simulation:
+// connected nodes attract, all nodes repel. Repulsion is only computed
+// between nodes in neighbouring grid cells, which keeps each step linear
+// in the number of nodes and makes graphs of thousands of requirements
+// practical.
+func (g *Graph) ForceLayout(opts LayoutOptions) map[string]Point {
+	if opts.Iterations <= 0 {
+		opts.Iterations = 100
+	}
+	if opts.Spacing <= 0 {
+		opts.Spacing = 80
+	}
+	k := opts.Spacing
+	n := len(g.order)
+	if n == 0 {
+		return map[string]Point{}
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	side := k * math.Sqrt(float64(n))
+	pos := make(map[string]Point, n)
+	for _, id := range g.order {
+		if p, ok := opts.Initial[id]; ok {
+			pos[id] = p
+		}
+	}
+	known := len(pos)
+	for _, id := range g.order {
+		if _, ok := pos[id]; !ok {
+			pos[id] = g.placeNear(id, pos, side, k, rng)
+		}
+	}
+
+	// The simulation works on slices indexed by insertion order
+	index := make(map[string]int, n)
+	points := make([]Point, n)
+	for i, id := range g.order {
+		index[id] = i
+		points[i] = pos[id]
+	}
+	var edges [][2]int
+	for i, id := range g.order {
+		for _, edge := range g.out[id] {
+			if edge.To != id {
+				edges = append(edges, [2]int{i, index[edge.To]})
+			}
+		}
+	}
+
+	// Start hot for a fresh layout; an incremental one only needs to
+	// settle the nodes that are new
+	temperature := side / 10
+	if known > 0 {
+		temperature = k / 2
+	}
+	cooling := temperature / float64(opts.Iterations+1)
+
+	cell := 2 * k
+	type cellKey struct{ x, y int }
+	cellOf := func(p Point) cellKey {
+		return cellKey{int(math.Floor(p.X / cell)), int(math.Floor(p.Y / cell))}
+	}
+	disp := make([]Point, n)
+	grid := make(map[cellKey][]int, n)
+	for range opts.Iterations {
+		clear(grid)
+		for i, p := range points {
+			key := cellOf(p)
+			grid[key] = append(grid[key], i)
+		}
+
+		for i, p := range points {
+			var d Point
+			c := cellOf(p)
+			for dx := -1; dx <= 1; dx++ {
+				for dy := -1; dy <= 1; dy++ {
+					for _, j := range grid[cellKey{c.x + dx, c.y + dy}] {
+						if j == i {
+							continue
+						}
+						vx, vy := p.X-points[j].X, p.Y-points[j].Y
+						dist2 := vx*vx + vy*vy
+						if dist2 > cell*cell {
+							continue
+						}
+						if dist2 < 0.0001 {
+							vx, vy = rng.Float64()-0.5, rng.Float64()-0.5
+							dist2 = vx*vx + vy*vy + 0.0001
+						}
+						force := k * k / dist2
+						d.X += vx * force
+						d.Y += vy * force
+					}
+				}
+			}
+			disp[i] = d
+		}
+
+		for _, e := range edges {
+			vx, vy, dist := delta(points[e[0]], points[e[1]], rng)
+			force := dist / k
+			disp[e[0]].X -= vx * force
+			disp[e[0]].Y -= vy * force
+			disp[e[1]].X += vx * force
+			disp[e[1]].Y += vy * force
+		}
+
+		for i, d := range disp {
+			length := math.Hypot(d.X, d.Y)
+			if length == 0 {
+				continue
+			}
+			step := math.Min(length, temperature)
+			points[i].X += d.X / length * step
+			points[i].Y += d.Y / length * step
+		}
+		temperature = math.Max(temperature-cooling, 1)
+	}
+
+	for i, id := range g.order {
+		pos[id] = points[i]
+	}
+	return normalize(pos)
+}
+
+// placeNear returns a starting point for id: beside the first positioned
+// neighbour, or anywhere in the layout area when it has none
+func (g *Graph) placeNear(id string, pos map[string]Point, side, k float64, rng *rand.Rand) Point {
+	var neighbours []string
+	for _, edge := range g.in[id] {
+		neighbours = append(neighbours, edge.From)
+	}
+	for _, edge := range g.out[id] {
+		neighbours = append(neighbours, edge.To)
+	}
+	for _, other := range neighbours {
+		if p, ok := pos[other]; ok {
+			angle := rng.Float64() * 2 * math.Pi
+			return Point{p.X + k*math.Cos(angle), p.Y + k*math.Sin(angle)}
+		}
+	}
+	return Point{rng.Float64() * side, rng.Float64() * side}
+}
+
+// delta returns the vector from b to a and its length, nudging apart
+// nodes that sit exactly on top of each other
+func delta(a, b Point, rng *rand.Rand) (vx, vy, dist float64) {
+	vx, vy = a.X-b.X, a.Y-b.Y
+	dist = math.Hypot(vx, vy)
+	if dist < 0.01 {
+		vx, vy = rng.Float64()-0.5, rng.Float64()-0.5
+		dist = math.Max(math.Hypot(vx, vy), 0.01)
+	}
+	return vx, vy, dist
+}
+
+// LayeredLayout arranges nodes in rows by their distance from the roots,
+// so parents sit above their sub-requirements. Nodes that are only
+// reachable through a cycle start a row of their own. Within a row nodes
+// are ordered by the average position of their parents to reduce crossings.
+func (g *Graph) LayeredLayout() map[string]Point {
+	layer := make(map[string]int, len(g.order))
+	var queue []string
+	visit := func(roots []string) {
+		for _, id := range roots {
+			if _, ok := layer[id]; !ok {
+				layer[id] = 0
+				queue = append(queue, id)
+			}
+		}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			for _, edge := range g.out[id] {
+				if _, ok := layer[edge.To]; !ok {
+					layer[edge.To] = layer[id] + 1
+					queue = append(queue, edge.To)
+				}
+			}
+		}
+	}
+
+	var roots []string
+	for _, id := range g.order {
+		if len(g.in[id]) == 0 {
+			roots = append(roots, id)
+		}
+	}
+	visit(roots)
+	for _, id := range g.order {
+		visit([]string{id})
+	}
+
+	var rows [][]string
+	for _, id := range g.order {
+		l := layer[id]
+		for len(rows) <= l {
+			rows = append(rows, nil)
+		}
+		rows[l] = append(rows[l], id)
+	}
+
+	column := make(map[string]float64, len(g.order))
+	for i, row := range rows {
+		if i > 0 {
+			barycenter := make(map[string]float64, len(row))
+			for j, id := range row {
+				sum, count := 0.0, 0
+				for _, edge := range g.in[id] {
+					if layer[edge.From] == i-1 {
+						sum += column[edge.From]
+						count++
+					}
+				}
+				barycenter[id] = float64(j)
+				if count > 0 {
+					barycenter[id] = sum / float64(count)
+				}
+			}
+			sort.SliceStable(row, func(a, b int) bool { return barycenter[row[a]] < barycenter[row[b]] })
+		}
+		for j, id := range row {
+			column[id] = float64(j)
+		}
+	}
+
+	widest := 0
+	for _, row := range rows {
+		widest = max(widest, len(row))
+	}
+	pos := make(map[string]Point, len(g.order))
+	for i, row := range rows {
+		// Center each row under the widest one
+		offset := float64(widest-len(row)) / 2
+		for j, id := range row {
+			pos[id] = Point{(offset + float64(j)) * layerSpacingX, float64(i) * layerSpacingY}
+		}
+	}
+	return pos
+}
+
+// normalize shifts positions so the smallest X and Y are zero
+func normalize(pos map[string]Point) map[string]Point {
+	minX, minY := math.Inf(1), math.Inf(1)
+	for _, p := range pos {
+		minX, minY = math.Min(minX, p.X), math.Min(minY, p.Y)
+	}
+	for id, p := range pos {
+		pos[id] = Point{math.Round(p.X - minX), math.Round(p.Y - minY)}
+	}
+	return pos
+}
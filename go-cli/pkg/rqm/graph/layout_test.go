@@ -0,0 +1,119 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package graph
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// newTreeGraph builds a tree of n nodes where node i is a child of node (i-1)/fanout
+func newTreeGraph(t testing.TB, n, fanout int) *Graph {
+	t.Helper()
+	g := New()
+	for i := range n {
+		g.AddNode(fmt.Sprintf("R%d", i), "")
+		if i > 0 {
+			if err := g.AddEdge(fmt.Sprintf("R%d", (i-1)/fanout), fmt.Sprintf("R%d", i), KindChild); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return g
+}
+
+func TestForceLayout(t *testing.T) {
+	g := newTreeGraph(t, 40, 3)
+	pos := g.ForceLayout(LayoutOptions{})
+	if len(pos) != 40 {
+		t.Fatalf("got %d positions, want 40", len(pos))
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	for id, p := range pos {
+		minX, minY = math.Min(minX, p.X), math.Min(minY, p.Y)
+		for other, q := range pos {
+			if id != other && p == q {
+				t.Errorf("%s and %s share position %v", id, other, p)
+			}
+		}
+	}
+	if minX != 0 || minY != 0 {
+		t.Errorf("layout starts at (%g, %g), want (0, 0)", minX, minY)
+	}
+
+	// Connected nodes should end up closer than the average pair
+	edgeLength, edges := 0.0, 0
+	for _, e := range g.Edges() {
+		edgeLength += distance(pos[e.From], pos[e.To])
+		edges++
+	}
+	pairLength, pairs := 0.0, 0
+	for _, a := range g.Nodes() {
+		for _, b := range g.Nodes() {
+			if a.ID < b.ID {
+				pairLength += distance(pos[a.ID], pos[b.ID])
+				pairs++
+			}
+		}
+	}
+	if edgeLength/float64(edges) >= pairLength/float64(pairs) {
+		t.Errorf("mean edge length %.0f is not below mean pair distance %.0f", edgeLength/float64(edges), pairLength/float64(pairs))
+	}
+
+	if again := g.ForceLayout(LayoutOptions{}); !reflect.DeepEqual(pos, again) {
+		t.Error("ForceLayout is not deterministic")
+	}
+}
+
+func TestForceLayoutIncremental(t *testing.T) {
+	g := newTreeGraph(t, 60, 3)
+	before := g.ForceLayout(LayoutOptions{})
+
+	g.AddNode("New", "")
+	g.AddEdge("R10", "New", KindChild)
+	after := g.ForceLayout(LayoutOptions{Initial: before, Iterations: 30})
+
+	if d := distance(after["New"], after["R10"]); d > 300 {
+		t.Errorf("new node placed %.0f away from its parent", d)
+	}
+	moved := 0.0
+	for id, p := range before {
+		moved += distance(p, after[id])
+	}
+	if mean := moved / float64(len(before)); mean > 80 {
+		t.Errorf("existing nodes moved %.0f on average after adding one node", mean)
+	}
+}
+
+func TestLayeredLayout(t *testing.T) {
+	g := newTestGraph(t, []string{"root", "a", "b", "c", "x", "y"},
+		[2]string{"root", "a"}, [2]string{"root", "b"}, [2]string{"b", "c"},
+		[2]string{"x", "y"}, [2]string{"y", "x"})
+	pos := g.LayeredLayout()
+
+	rows := map[string]float64{"root": 0, "a": 1, "b": 1, "c": 2, "x": 0, "y": 1}
+	for id, row := range rows {
+		if pos[id].Y != row*layerSpacingY {
+			t.Errorf("%s is at y=%g, want row %g", id, pos[id].Y, row)
+		}
+	}
+	if pos["a"].X >= pos["b"].X {
+		t.Errorf("a (x=%g) should be left of b (x=%g)", pos["a"].X, pos["b"].X)
+	}
+}
+
+func BenchmarkForceLayout5000(b *testing.B) {
+	g := newTreeGraph(b, 5000, 4)
+	for b.Loop() {
+		g.ForceLayout(LayoutOptions{})
+	}
+}
+
+func distance(a, b Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
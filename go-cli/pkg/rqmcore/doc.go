@@ -0,0 +1,25 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+// Package rqmcore is the Go API for RQM requirements files, for tools that
+// want to read, check and analyze requirements without running the rqm CLI.
+//
+//   - Load and Parse decode a file into typed Config and Requirement values
+//   - Validate and ValidateFile apply the schema and semantic rules, with
+//     the Rust validator linked in (CGO builds) or the rqm-validator binary
+//   - Graph builds the requirement graph for queries such as topological
+//     order, ancestors and descendants; Cycles and CircularReferences
+//     report the circular references rqm check fails on
+//
+// The exported identifiers of this package are stable: they only change
+// in backwards-compatible ways within a major version.
+//
+//	config, err := rqmcore.Load("requirements.yml")
+//	if err != nil {
+//		return err
+//	}
+//	for _, cycle := range rqmcore.Cycles(config) {
+//		fmt.Println("cycle:", strings.Join(cycle, " → "))
+//	}
+package rqmcore
@@ -0,0 +1,20 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+//go:build !cgo
+
+package rqmcore
+
+import "errors"
+
+// ValidateYAML is only available in CGO builds, which link the Rust
+// validator; use Validate, which falls back to rqm-validator
+func ValidateYAML(yamlContent string) (*ValidationResult, error) {
+	return nil, errors.New("embedded validator requires a CGO build")
+}
+
+// Available returns true if the Rust validator is available
+func Available() bool {
+	return false
+}
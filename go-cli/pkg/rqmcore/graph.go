@@ -0,0 +1,70 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package rqmcore
+
+import "github.com/238855/rqm/go-cli/pkg/rqm/graph"
+
+// Graph builds the requirement graph of c. Nodes are keyed by summary;
+// parents point at their sub-requirements, inline or referenced, with
+// graph.KindChild, and relations keep their type. References to
+// requirements that don't exist, or live in other repositories, are left
+// out.
+func Graph(c *Config) *graph.Graph {
+	g := graph.New()
+	all := c.Flatten()
+	byName := make(map[string]string)
+	for _, req := range all {
+		g.AddNode(req.Summary, req.Name)
+		if req.Name != "" {
+			byName[req.Name] = req.Summary
+		}
+	}
+	resolve := func(ref string) (string, bool) {
+		if _, ok := g.Node(ref); ok {
+			return ref, true
+		}
+		summary, ok := byName[ref]
+		return summary, ok
+	}
+
+	for _, req := range all {
+		for _, child := range req.Requirements {
+			if child.External != nil {
+				continue
+			}
+			target := child.Reference
+			if child.Full != nil {
+				target = child.Full.Summary
+			}
+			if to, ok := resolve(target); ok {
+				g.AddEdge(req.Summary, to, graph.KindChild)
+			}
+		}
+		for _, rel := range req.Relations {
+			if to, ok := resolve(rel.Target); ok {
+				g.AddEdge(req.Summary, to, graph.Kind(rel.Type))
+			}
+		}
+	}
+	return g
+}
+
+// Cycles returns the groups of requirements that reference each other in
+// a loop, by summary. Like rqm check, it follows parent/child links,
+// depends_on and blocks, in the order requirements can be done in
+// (Config.OrderingGraph); references to requirements that don't exist are
+// left out.
+func Cycles(c *Config) [][]string {
+	g, _ := c.OrderingGraph()
+	return g.Cycles()
+}
+
+// CircularReferences returns the circular references rqm check reports,
+// each as the summaries along the loop. Parent/child links, depends_on and
+// blocks count; a reference to a requirement that doesn't exist is an
+// error.
+func CircularReferences(c *Config) ([][]string, error) {
+	return c.Cycles()
+}
@@ -0,0 +1,19 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package rqmcore
+
+import "github.com/238855/rqm/go-cli/pkg/model"
+
+// Parse decodes requirements YAML. It checks syntax only; use Validate for
+// the schema and semantic rules.
+func Parse(data []byte) (*Config, error) {
+	return model.ParseYAML(data)
+}
+
+// Load reads and parses the requirements file at path, detecting YAML,
+// TOML or JSON by its extension
+func Load(path string) (*Config, error) {
+	return model.Load(path)
+}
@@ -0,0 +1,25 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package rqmcore
+
+import "github.com/238855/rqm/go-cli/pkg/model"
+
+// The requirements model is pkg/model's; these names are kept for the
+// stable API. Config has Walk, Flatten and Find for traversing the tree.
+type (
+	// Config is a requirements file
+	Config = model.RequirementConfig
+	// PersonAlias names a person that owner fields can refer to as @alias
+	PersonAlias = model.PersonAlias
+	// Requirement is a single requirement and its inline sub-requirements
+	Requirement = model.RequirementDetail
+	// Relation is a typed link to another requirement
+	Relation = model.Relation
+	// Approval is a sign-off required from an approver
+	Approval = model.Approval
+	// Reference is an entry under a requirement's requirements list: an
+	// inline sub-requirement or the summary or name of another one
+	Reference = model.RequirementReference
+)
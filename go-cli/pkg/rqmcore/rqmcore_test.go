@@ -0,0 +1,177 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package rqmcore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/238855/rqm/go-cli/pkg/rqm/graph"
+)
+
+const testYAML = `version: "1.0"
+requirements:
+  - summary: Login
+    name: AUTH-001
+    requirements:
+      - summary: Password hashing
+        name: AUTH-002
+      - Session timeout
+  - summary: Session timeout
+    name: AUTH-003
+    relations:
+      - type: depends_on
+        target: AUTH-001
+`
+
+func writeTestFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "requirements.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	config, err := Load(writeTestFile(t, testYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var summaries []string
+	for _, req := range config.All() {
+		summaries = append(summaries, req.Summary)
+	}
+	if want := []string{"Login", "Password hashing", "Session timeout"}; !reflect.DeepEqual(summaries, want) {
+		t.Errorf("All() = %v, want %v", summaries, want)
+	}
+	if ref := config.Requirements[0].Requirements[1]; ref.Full != nil || ref.Reference != "Session timeout" {
+		t.Errorf("Expected a string reference, got %+v", ref)
+	}
+
+	if req, ok := config.Find("AUTH-002"); !ok || req.Summary != "Password hashing" {
+		t.Errorf("Find(AUTH-002) = %v, %v", req, ok)
+	}
+	if req, ok := config.Find("Session timeout"); !ok || req.Name != "AUTH-003" {
+		t.Errorf("Find(Session timeout) = %v, %v", req, ok)
+	}
+	if _, ok := config.Find("missing"); ok {
+		t.Error("Find(missing) succeeded")
+	}
+
+	if _, err := Load(writeTestFile(t, "requirements: [\n")); err == nil {
+		t.Error("Expected a parse error")
+	}
+}
+
+func TestGraph(t *testing.T) {
+	config, err := Parse([]byte(testYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := Graph(config)
+
+	want := []graph.Edge{
+		{From: "Login", To: "Password hashing", Kind: graph.KindChild},
+		{From: "Login", To: "Session timeout", Kind: graph.KindChild},
+		{From: "Session timeout", To: "Login", Kind: graph.KindDependsOn},
+	}
+	if got := g.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Edges() = %v, want %v", got, want)
+	}
+	if node, _ := g.Node("Login"); node.Name != "AUTH-001" {
+		t.Errorf("Expected node name AUTH-001, got %q", node.Name)
+	}
+
+	if got := Cycles(config); !reflect.DeepEqual(got, [][]string{{"Login", "Session timeout"}}) {
+		t.Errorf("Cycles() = %v", got)
+	}
+	if got, err := CircularReferences(config); err != nil || len(got) != 1 {
+		t.Errorf("CircularReferences() = %v, %v", got, err)
+	}
+}
+
+func TestCyclesMatchCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want int
+	}{
+		{"relates_to loop", "  - summary: A\n    relations:\n      - {type: relates_to, target: B}\n  - summary: B\n    relations:\n      - {type: duplicates, target: A}\n", 0},
+		{"child blocks its parent", "  - summary: A\n    requirements:\n      - summary: B\n        relations:\n          - {type: blocks, target: A}\n", 0},
+		{"parent blocks its child", "  - summary: A\n    requirements:\n      - summary: B\n    relations:\n      - {type: blocks, target: B}\n", 1},
+		{"depends_on loop", "  - summary: A\n    relations:\n      - {type: depends_on, target: B}\n  - summary: B\n    relations:\n      - {type: depends_on, target: A}\n", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse([]byte("version: \"1.0\"\nrequirements:\n" + tt.yaml))
+			if err != nil {
+				t.Fatal(err)
+			}
+			circular, err := CircularReferences(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Cycles(config); len(got) != tt.want || len(circular) != tt.want {
+				t.Errorf("Cycles() = %v, CircularReferences() = %v; want %d cycle(s)", got, circular, tt.want)
+			}
+		})
+	}
+}
+
+// The API as published; changing these signatures breaks callers
+var (
+	_ func([]byte) (*Config, error)              = Parse
+	_ func(string) (*Config, error)              = Load
+	_ func([]byte) (*ValidationResult, error)    = Validate
+	_ func(string) (*ValidationResult, error)    = ValidateFile
+	_ func(*Config) [][]string                   = Cycles
+	_ func(*Config) []*Requirement               = (*Config).All
+	_ func(*Config, string) (*Requirement, bool) = (*Config).Find
+	_ func(*Reference, []byte) error             = (*Reference).UnmarshalJSON
+	_ func(Reference) ([]byte, error)            = Reference.MarshalJSON
+)
+
+func TestValidateWithoutValidator(t *testing.T) {
+	if Available() {
+		t.Skip("embedded validator linked in")
+	}
+	t.Setenv("RQM_VALIDATOR", "")
+	t.Setenv("PATH", t.TempDir())
+
+	if _, err := Validate([]byte(testYAML)); !errors.Is(err, ErrValidatorNotFound) {
+		t.Errorf("Expected ErrValidatorNotFound, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if !Available() {
+		validator, _ := filepath.Abs("../../../rust-core/target/release/rqm-validator")
+		if _, err := os.Stat(validator); err != nil {
+			t.Skip("rqm-validator not built")
+		}
+		t.Setenv("RQM_VALIDATOR", validator)
+	}
+
+	result, err := Validate([]byte("version: \"1.0\"\nrequirements:\n  - summary: Login\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid {
+		t.Errorf("Expected valid, got errors %v", result.Errors)
+	}
+
+	result, err = ValidateFile(writeTestFile(t, "version: \"1.0\"\nrequirements:\n  - summary: A\n  - summary: A\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Valid || len(result.Errors) == 0 {
+		t.Errorf("Expected duplicate summaries to fail, got %+v", result)
+	}
+}
@@ -0,0 +1,138 @@
+// RQM - Requirements Management in Code
+// Copyright (c) 2025
+// SPDX-License-Identifier: MIT
+
+package rqmcore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/238855/rqm/go-cli/pkg/model"
+)
+
+// ValidationResult represents the result of YAML validation
+type ValidationResult struct {
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+	// Details break the error down into its problems, with where each one
+	// is. Validators older than the details field leave it empty.
+	Details []ErrorDetail `json:"details,omitempty"`
+}
+
+// ErrorDetail is one problem of a failed validation, as the validator
+// reports it
+type ErrorDetail struct {
+	// Code is the kind of problem: parse_error, format_error, schema,
+	// duplicate_summary, invalid_owner, circular_reference,
+	// requirement_not_found, invalid_reference, or error for the rest
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Path is the offending value, as in requirements[0].status
+	Path string `json:"path,omitempty"`
+	// Line and Column are 1-based, and 0 when unknown
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+	// Summary is the requirement the problem is about, when known
+	Summary string `json:"summary,omitempty"`
+	// Keyword is the JSON schema keyword a schema problem violates, such
+	// as enum or required
+	Keyword string            `json:"keyword,omitempty"`
+	Params  map[string]string `json:"params,omitempty"`
+}
+
+// ParseFailure is the result for a file that doesn't parse, with err from
+// model.ToYAML
+func ParseFailure(err error) *ValidationResult {
+	msg := fmt.Sprintf("Parse error: %v", err)
+	detail := ErrorDetail{Code: "error", Message: msg}
+	var syntaxErr *model.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		detail = ErrorDetail{
+			Code:    "format_error",
+			Message: msg,
+			Line:    syntaxErr.Line,
+			Params:  map[string]string{"format": syntaxErr.Format.String(), "detail": syntaxErr.Msg},
+		}
+	}
+	return &ValidationResult{Valid: false, Errors: []string{msg}, Details: []ErrorDetail{detail}}
+}
+
+// ErrValidatorNotFound is returned by Validate when neither the embedded
+// validator nor an rqm-validator binary is available
+var ErrValidatorNotFound = errors.New("no rqm validator available: build with CGO enabled, or install rqm-validator on PATH or set RQM_VALIDATOR")
+
+// Validate checks requirements YAML against the schema and the semantic
+// rules (unique summaries, owner references, cycles). CGO builds use the
+// embedded Rust validator; other builds run the rqm-validator binary.
+func Validate(data []byte) (*ValidationResult, error) {
+	if Available() {
+		return ValidateYAML(string(data))
+	}
+
+	f, err := os.CreateTemp("", "rqm-*.yml")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return runValidator(f.Name())
+}
+
+// ValidateFile is Validate for the requirements file at path, which may
+// be TOML or JSON as well as YAML
+func ValidateFile(path string) (*ValidationResult, error) {
+	if Available() || model.FormatOf(path) != model.FormatYAML {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = model.ToYAML(path, data); err != nil {
+			return ParseFailure(err), nil
+		}
+		return Validate(data)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	return runValidator(path)
+}
+
+// ValidatorPath returns the rqm-validator binary Validate runs in builds
+// without CGO: RQM_VALIDATOR when set, otherwise rqm-validator from PATH.
+// It returns "" when there is none.
+func ValidatorPath() string {
+	if path := os.Getenv("RQM_VALIDATOR"); path != "" {
+		return path
+	}
+	path, err := exec.LookPath("rqm-validator")
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// runValidator validates the file at path with the rqm-validator binary
+func runValidator(path string) (*ValidationResult, error) {
+	validator := ValidatorPath()
+	if validator == "" {
+		return nil, ErrValidatorNotFound
+	}
+	// The validator exits non-zero for invalid files; the JSON says why
+	output, _ := exec.Command(validator, path).CombinedOutput()
+	var result ValidationResult
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse validator output: %w\nOutput: %s", err, output)
+	}
+	return &result, nil
+}
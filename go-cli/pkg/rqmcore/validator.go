@@ -1,3 +1,5 @@
+//go:build cgo
+
 package rqmcore
 
 // #cgo CFLAGS: -I${SRCDIR}/../../../rust-core
@@ -14,13 +16,6 @@ import (
 	"unsafe"
 )
 
-// ValidationResult represents the result of YAML validation
-type ValidationResult struct {
-	Valid    bool     `json:"valid"`
-	Errors   []string `json:"errors"`
-	Warnings []string `json:"warnings"`
-}
-
 // ValidateYAML validates YAML content using the embedded Rust validator
 // This function calls into the Rust library via CGO
 func ValidateYAML(yamlContent string) (*ValidationResult, error) {
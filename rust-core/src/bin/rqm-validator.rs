@@ -4,7 +4,7 @@
 //! Designed to be called by the Go CLI and other language bindings.
 
 use rqm_core::types::RequirementReference;
-use rqm_core::{Parser, RequirementGraph, Validator};
+use rqm_core::{ErrorDetail, Parser, RequirementGraph, Validator};
 use serde::{Deserialize, Serialize};
 use std::collections::HashMap;
 use std::env;
@@ -15,6 +15,9 @@ struct ValidationResult {
     valid: bool,
     errors: Vec<String>,
     warnings: Vec<String>,
+    /// The problems behind errors, with their locations
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    details: Vec<ErrorDetail>,
 }
 
 #[derive(Debug, Serialize, Deserialize)]
@@ -22,6 +25,15 @@ struct CycleCheckResult {
     has_cycles: bool,
     cycles: Vec<Vec<String>>,
     graph: HashMap<String, Vec<String>>,
+    #[serde(default, skip_serializing_if = "HashMap::is_empty")]
+    relations: HashMap<String, Vec<RelationEdge>>,
+}
+
+#[derive(Debug, Serialize, Deserialize)]
+struct RelationEdge {
+    #[serde(rename = "type")]
+    relation_type: String,
+    target: String,
 }
 
 fn main() {
@@ -50,6 +62,7 @@ fn main() {
                 valid: false,
                 errors: vec![format!("Parse error: {}", e)],
                 warnings: vec![],
+                details: e.details(),
             };
             println!("{}", serde_json::to_string_pretty(&result).unwrap());
             process::exit(1);
@@ -71,6 +84,7 @@ fn main() {
                     has_cycles: false,
                     cycles: vec![],
                     graph: HashMap::new(),
+                    relations: HashMap::new(),
                 };
                 println!("{}", serde_json::to_string_pretty(&result).unwrap());
                 eprintln!("Error building graph: {}", e);
@@ -87,10 +101,28 @@ fn main() {
             collect_graph_edges(req, &mut adj_map);
         }
 
+        // Typed relations are reported separately so they can be labeled
+        let mut relations: HashMap<String, Vec<RelationEdge>> = HashMap::new();
+        for req in config.all_requirements() {
+            if req.relations.is_empty() {
+                continue;
+            }
+            let edges = req
+                .relations
+                .iter()
+                .map(|relation| RelationEdge {
+                    relation_type: relation.relation_type.as_str().to_string(),
+                    target: relation.target.clone(),
+                })
+                .collect();
+            relations.insert(req.summary.clone(), edges);
+        }
+
         let result = CycleCheckResult {
             has_cycles,
             cycles,
             graph: adj_map,
+            relations,
         };
 
         println!("{}", serde_json::to_string_pretty(&result).unwrap());
@@ -109,6 +141,7 @@ fn main() {
                 valid: false,
                 errors: vec![format!("Validator initialization error: {}", e)],
                 warnings: vec![],
+                details: vec![],
             };
             println!("{}", serde_json::to_string_pretty(&result).unwrap());
             process::exit(1);
@@ -121,11 +154,13 @@ fn main() {
             valid: true,
             errors: vec![],
             warnings: vec![],
+            details: vec![],
         },
         Err(e) => ValidationResult {
             valid: false,
             errors: vec![format!("{}", e)],
             warnings: vec![],
+            details: e.details(),
         },
     };
 
@@ -150,6 +185,7 @@ fn collect_graph_edges(req: &rqm_core::Requirement, adj_map: &mut HashMap<String
             RequirementReference::Reference(summary) => {
                 deps.push(summary.clone());
             }
+            RequirementReference::External { .. } => {}
         }
     }
 
@@ -2,6 +2,8 @@
 // Copyright (c) 2025
 // SPDX-License-Identifier: MIT
 
+use serde::{Deserialize, Serialize};
+use std::collections::BTreeMap;
 use thiserror::Error;
 
 /// Result type for RQM operations
@@ -16,6 +18,15 @@ pub enum Error {
     #[error("JSON schema validation error: {0}")]
     SchemaValidation(String),
 
+    #[error("JSON schema validation error: {}", join_messages(.0))]
+    SchemaViolations(Vec<ErrorDetail>),
+
+    #[error("YAML parsing error: {message}")]
+    Parse {
+        message: String,
+        detail: ErrorDetail,
+    },
+
     #[error("IO error: {0}")]
     IoError(#[from] std::io::Error),
 
@@ -31,8 +42,8 @@ pub enum Error {
     #[error("Duplicate summary: {0}")]
     DuplicateSummary(String),
 
-    #[error("Invalid owner reference: {0}")]
-    InvalidOwner(String),
+    #[error("Invalid owner reference: '{owner}' is not a valid email, GitHub username, or defined alias")]
+    InvalidOwner { owner: String, summary: String },
 
     #[error("Graph error: {0}")]
     GraphError(String),
@@ -41,15 +52,122 @@ pub enum Error {
     Custom(String),
 }
 
+/// One problem an error reports, with where it is and the values
+/// involved, for tools that point at the offending line or requirement
+/// without reading the message
+#[derive(Debug, Clone, Default, PartialEq, Serialize, Deserialize)]
+pub struct ErrorDetail {
+    /// What kind of problem this is, such as parse_error, schema or
+    /// duplicate_summary
+    pub code: String,
+
+    /// The problem as people read it
+    pub message: String,
+
+    /// Path to the offending value, as in requirements[0].status
+    #[serde(default, skip_serializing_if = "String::is_empty")]
+    pub path: String,
+
+    /// 1-based line of the problem, for errors found while parsing
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub line: Option<usize>,
+
+    /// 1-based column of the problem, for errors found while parsing
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub column: Option<usize>,
+
+    /// Summary of the requirement the problem is about, when known
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub summary: Option<String>,
+
+    /// Schema keyword the value violates, such as enum or required
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub keyword: Option<String>,
+
+    /// Values the problem is about, such as the invalid value and the
+    /// allowed ones
+    #[serde(default, skip_serializing_if = "BTreeMap::is_empty")]
+    pub params: BTreeMap<String, String>,
+}
+
+/// The messages of schema violations, as one line
+fn join_messages(details: &[ErrorDetail]) -> String {
+    details
+        .iter()
+        .map(|d| d.message.as_str())
+        .collect::<Vec<_>>()
+        .join("; ")
+}
+
 impl Error {
     /// Create a custom error
     pub fn custom(msg: impl Into<String>) -> Self {
         Error::Custom(msg.into())
     }
 
-    /// Enhance YAML parsing error with helpful context
-    pub fn enhance_yaml_error(err: serde_yaml::Error) -> Self {
+    /// The problems this error reports, one per schema violation and one
+    /// for any other error
+    pub fn details(&self) -> Vec<ErrorDetail> {
+        let base = |code: &str| ErrorDetail {
+            code: code.to_string(),
+            message: self.to_string(),
+            ..Default::default()
+        };
+        let with_param = |code: &str, key: &str, value: &str| ErrorDetail {
+            params: BTreeMap::from([(key.to_string(), value.to_string())]),
+            ..base(code)
+        };
+        match self {
+            Error::Parse { detail, .. } => vec![detail.clone()],
+            Error::SchemaViolations(details) => details.clone(),
+            Error::DuplicateSummary(summary) => vec![ErrorDetail {
+                summary: Some(summary.clone()),
+                ..base("duplicate_summary")
+            }],
+            Error::InvalidOwner { owner, summary } => vec![ErrorDetail {
+                summary: Some(summary.clone()),
+                ..with_param("invalid_owner", "owner", owner)
+            }],
+            Error::CircularReference(cycle) => {
+                vec![with_param("circular_reference", "cycle", cycle)]
+            }
+            Error::RequirementNotFound(reference) => {
+                vec![with_param("requirement_not_found", "reference", reference)]
+            }
+            Error::InvalidReference(reference) => {
+                vec![with_param("invalid_reference", "reference", reference)]
+            }
+            _ => vec![base("error")],
+        }
+    }
+
+    /// Enhance YAML parsing error with helpful context. path is where in
+    /// the document it happened, as in requirements[0].status, or empty.
+    pub fn enhance_yaml_error(err: serde_yaml::Error, path: &str) -> Self {
         let msg = err.to_string();
+
+        // The message without the path and location serde_yaml adds, which
+        // the detail carries on their own
+        let location = err.location();
+        let mut bare = msg.as_str();
+        if let Some(location) = &location {
+            let suffix = format!(" at line {} column {}", location.line(), location.column());
+            bare = bare.strip_suffix(suffix.as_str()).unwrap_or(bare);
+        }
+        if !path.is_empty() {
+            bare = bare
+                .strip_prefix(format!("{}: ", path).as_str())
+                .unwrap_or(bare);
+        }
+        let detail = ErrorDetail {
+            code: "parse_error".to_string(),
+            message: format!("YAML parsing error: {}", msg),
+            path: path.to_string(),
+            line: location.as_ref().map(|l| l.line()),
+            column: location.as_ref().map(|l| l.column()),
+            params: BTreeMap::from([("detail".to_string(), bare.to_string())]),
+            ..Default::default()
+        };
         
         // Detect common error patterns and provide helpful hints
         let enhanced = if msg.contains("RequirementReference") {
@@ -63,6 +181,9 @@ impl Error {
                      - summary: \"Requirement summary\"\n\
                        description: \"Description text\"\n\
                        requirements: [...]  # optional nested requirements\n\
+                  \n\
+                  3. Requirement of another repository:\n\
+                     - external: {{ repo: github.com/org/repo, id: REQ-42 }}\n\
                 \n🔍 Common issues:\n\
                   • Missing 'summary' field in a requirement object\n\
                   • Using a mapping (key: value) instead of a string for reference\n\
@@ -104,8 +225,10 @@ impl Error {
             msg
         };
 
-        // Return enhanced error message wrapped in YamlError variant
-        Error::Custom(format!("YAML parsing error: {}", enhanced))
+        Error::Parse {
+            message: enhanced,
+            detail,
+        }
     }
 }
 
@@ -154,8 +277,29 @@ mod tests {
 
     #[test]
     fn test_invalid_owner_error() {
-        let err = Error::InvalidOwner("@unknown".to_string());
+        let err = Error::InvalidOwner {
+            owner: "@unknown".to_string(),
+            summary: "Login".to_string(),
+        };
         assert!(err.to_string().contains("Invalid owner"));
+
+        let details = err.details();
+        assert_eq!(details.len(), 1);
+        assert_eq!(details[0].code, "invalid_owner");
+        assert_eq!(details[0].summary.as_deref(), Some("Login"));
+        assert_eq!(details[0].params["owner"], "@unknown");
+    }
+
+    #[test]
+    fn test_parse_error_details() {
+        let yaml_err = serde_yaml::from_str::<Vec<u32>>("- 1\n- x\n").unwrap_err();
+        let err = Error::enhance_yaml_error(yaml_err, "[1]");
+        let details = err.details();
+        assert_eq!(details[0].code, "parse_error");
+        assert_eq!(details[0].path, "[1]");
+        assert_eq!(details[0].line, Some(2));
+        assert!(!details[0].params["detail"].contains("line 2"));
+        assert!(err.to_string().starts_with("YAML parsing error: "));
     }
 
     #[test]
@@ -42,7 +42,8 @@ pub unsafe extern "C" fn validate_yaml(yaml_content: *const c_char) -> *mut c_ch
                     serde_json::json!({
                         "valid": false,
                         "errors": [e.to_string()],
-                        "warnings": []
+                        "warnings": [],
+                        "details": e.details()
                     })
                 }
             }
@@ -51,7 +52,8 @@ pub unsafe extern "C" fn validate_yaml(yaml_content: *const c_char) -> *mut c_ch
             serde_json::json!({
                 "valid": false,
                 "errors": [e.to_string()],
-                "warnings": []
+                "warnings": [],
+                "details": e.details()
             })
         }
     };
@@ -2,7 +2,10 @@
 // Copyright (c) 2025
 // SPDX-License-Identifier: MIT
 
-use crate::{types::RequirementReference, Error, Requirement, RequirementConfig, Result};
+use crate::{
+    types::{RelationType, RequirementReference},
+    Error, Requirement, RequirementConfig, Result,
+};
 use petgraph::graph::{DiGraph, NodeIndex};
 
 use std::collections::{HashMap, HashSet};
@@ -31,6 +34,12 @@ impl RequirementGraph {
             requirements.insert(summary, req.clone());
         }
 
+        let name_to_summary: HashMap<String, String> = config
+            .all_requirements()
+            .into_iter()
+            .filter_map(|req| req.name.clone().map(|name| (name, req.summary.clone())))
+            .collect();
+
         // Second pass: create edges
         for req in config.all_requirements() {
             let parent_node = summary_to_node[&req.summary];
@@ -52,6 +61,40 @@ impl RequirementGraph {
                             )));
                         }
                     }
+                    // Other repositories' requirements can't lead back here
+                    RequirementReference::External { .. } => {}
+                }
+            }
+
+            // Ordering relations take part in cycle detection; "blocks"
+            // points the other way since the target waits on this requirement
+            for relation in &req.relations {
+                let target = relation.target.as_str();
+                let target_summary = if summary_to_node.contains_key(target) {
+                    target
+                } else {
+                    name_to_summary
+                        .get(target)
+                        .map(String::as_str)
+                        .unwrap_or(target)
+                };
+                let target_node = *summary_to_node.get(target_summary).ok_or_else(|| {
+                    Error::InvalidReference(format!(
+                        "Requirement '{}' has a {} relation to non-existent '{}'",
+                        req.summary,
+                        relation.relation_type.as_str(),
+                        relation.target
+                    ))
+                })?;
+
+                match relation.relation_type {
+                    RelationType::DependsOn => {
+                        graph.add_edge(parent_node, target_node, ());
+                    }
+                    RelationType::Blocks => {
+                        graph.add_edge(target_node, parent_node, ());
+                    }
+                    _ => {}
                 }
             }
         }
@@ -245,6 +288,7 @@ mod tests {
         RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![req1],
         }
     }
@@ -311,6 +355,7 @@ mod tests {
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![req1_with_ref, req2_with_ref],
         };
 
@@ -330,6 +375,7 @@ mod tests {
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![req],
         };
 
@@ -359,6 +405,7 @@ mod tests {
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![req1, req2],
         };
 
@@ -439,6 +486,7 @@ mod tests {
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![req1, req2, req3, req4],
         };
 
@@ -466,6 +514,7 @@ mod tests {
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![req1, req2, req3],
         };
 
@@ -477,11 +526,85 @@ mod tests {
         assert_eq!(cycles[0].len(), 3);
     }
 
+    #[test]
+    fn test_relation_cycle() {
+        use crate::types::Relation;
+
+        // A is the parent of B, and B depends on A
+        let mut req_a = Requirement::new("A");
+        let mut req_b = Requirement::new("B");
+        req_b.relations.push(Relation {
+            relation_type: RelationType::DependsOn,
+            target: "A".to_string(),
+        });
+        req_a
+            .requirements
+            .push(RequirementReference::Full(Box::new(req_b)));
+
+        let config = RequirementConfig {
+            version: "1.0".to_string(),
+            aliases: vec![],
+            includes: vec![],
+            requirements: vec![req_a],
+        };
+
+        let graph = RequirementGraph::from_config(&config).unwrap();
+        assert!(graph.has_cycles());
+    }
+
+    #[test]
+    fn test_non_ordering_relation_ignored_for_cycles() {
+        use crate::types::Relation;
+
+        let mut req_a = Requirement::new("A");
+        let mut req_b = Requirement::new("B");
+        req_b.name = Some("REQ-B".to_string());
+        req_a.relations.push(Relation {
+            relation_type: RelationType::RelatesTo,
+            target: "REQ-B".to_string(),
+        });
+        req_b.relations.push(Relation {
+            relation_type: RelationType::RelatesTo,
+            target: "A".to_string(),
+        });
+
+        let config = RequirementConfig {
+            version: "1.0".to_string(),
+            aliases: vec![],
+            includes: vec![],
+            requirements: vec![req_a, req_b],
+        };
+
+        let graph = RequirementGraph::from_config(&config).unwrap();
+        assert!(!graph.has_cycles());
+    }
+
+    #[test]
+    fn test_relation_to_missing_target() {
+        use crate::types::Relation;
+
+        let mut req = Requirement::new("A");
+        req.relations.push(Relation {
+            relation_type: RelationType::Blocks,
+            target: "Missing".to_string(),
+        });
+
+        let config = RequirementConfig {
+            version: "1.0".to_string(),
+            aliases: vec![],
+            includes: vec![],
+            requirements: vec![req],
+        };
+
+        assert!(RequirementGraph::from_config(&config).is_err());
+    }
+
     #[test]
     fn test_empty_graph() {
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![],
         };
 
@@ -496,6 +619,7 @@ mod tests {
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![req],
         };
 
@@ -23,11 +23,14 @@ pub mod parser;
 pub mod types;
 pub mod validator;
 
-pub use error::{Error, Result};
+pub use error::{Error, ErrorDetail, Result};
 pub use graph::RequirementGraph;
 pub use metadata::{kebab_case, MetadataStore, ProjectConfig, RequirementMetadata};
 pub use parser::Parser;
-pub use types::{OwnerReference, PersonAlias, Requirement, RequirementConfig};
+pub use types::{
+    Approval, ApprovalStatus, Criterion, ExternalRef, Links, OwnerReference, PersonAlias,
+    Question, Relation, RelationType, Requirement, RequirementConfig, VerificationMethod,
+};
 pub use validator::Validator;
 
 /// Version of the library
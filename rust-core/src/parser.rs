@@ -16,9 +16,17 @@ impl Parser {
         Self::parse_str(&content)
     }
 
-    /// Parse a YAML string into a RequirementConfig
+    /// Parse a YAML string into a RequirementConfig. Errors carry the
+    /// path to the value that failed, as in requirements[0].status.
     pub fn parse_str(content: &str) -> Result<RequirementConfig> {
-        serde_yaml::from_str(content).map_err(Error::enhance_yaml_error)
+        let deserializer = serde_yaml::Deserializer::from_str(content);
+        serde_path_to_error::deserialize(deserializer).map_err(|e| {
+            let path = match e.path().to_string() {
+                root if root == "." => String::new(),
+                path => path,
+            };
+            Error::enhance_yaml_error(e.into_inner(), &path)
+        })
     }
 
     /// Serialize a RequirementConfig to YAML string
@@ -77,6 +85,7 @@ requirements:
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![Requirement::new("Test")],
         };
 
@@ -126,6 +135,7 @@ requirements:
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![req],
         };
 
@@ -15,6 +15,10 @@ pub struct RequirementConfig {
     #[serde(default, skip_serializing_if = "Vec::is_empty")]
     pub aliases: Vec<PersonAlias>,
 
+    /// Further requirements files, relative to this one, loaded with it
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub includes: Vec<String>,
+
     /// Top-level requirements
     pub requirements: Vec<Requirement>,
 }
@@ -109,6 +113,158 @@ pub enum Status {
     Deprecated,
 }
 
+/// How a requirement is verified
+#[derive(Debug, Clone, Copy, Serialize, Deserialize, PartialEq, Eq)]
+#[serde(rename_all = "lowercase")]
+pub enum VerificationMethod {
+    Test,
+    Analysis,
+    Inspection,
+    Demonstration,
+}
+
+/// Kind of typed relation between two requirements
+#[derive(Debug, Clone, Copy, Serialize, Deserialize, PartialEq, Eq, Hash)]
+#[serde(rename_all = "snake_case")]
+pub enum RelationType {
+    DependsOn,
+    Blocks,
+    RelatesTo,
+    Duplicates,
+    DerivesFrom,
+}
+
+impl RelationType {
+    /// Label used for graph edges
+    pub fn as_str(&self) -> &'static str {
+        match self {
+            RelationType::DependsOn => "depends_on",
+            RelationType::Blocks => "blocks",
+            RelationType::RelatesTo => "relates_to",
+            RelationType::Duplicates => "duplicates",
+            RelationType::DerivesFrom => "derives_from",
+        }
+    }
+
+    /// Whether the relation orders work and must therefore stay acyclic
+    pub fn is_ordering(&self) -> bool {
+        matches!(self, RelationType::DependsOn | RelationType::Blocks)
+    }
+}
+
+/// Typed relation from one requirement to another
+#[derive(Debug, Clone, Serialize, Deserialize, PartialEq)]
+pub struct Relation {
+    /// Relation kind
+    #[serde(rename = "type")]
+    pub relation_type: RelationType,
+
+    /// Summary or name of the related requirement
+    pub target: String,
+}
+
+/// Outcome of an approval
+#[derive(Debug, Clone, Copy, Serialize, Deserialize, PartialEq, Eq, Default)]
+#[serde(rename_all = "lowercase")]
+pub enum ApprovalStatus {
+    #[default]
+    Pending,
+    Approved,
+    Rejected,
+}
+
+/// Sign-off required from an approver
+#[derive(Debug, Clone, Serialize, Deserialize, PartialEq)]
+pub struct Approval {
+    /// Owner reference of the approver
+    pub approver: OwnerReference,
+
+    /// Due date (YYYY-MM-DD)
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub due: Option<String>,
+
+    /// Approval outcome, pending when omitted
+    #[serde(default)]
+    pub status: ApprovalStatus,
+}
+
+/// Acceptance criterion of a requirement, covered by children by ID
+#[derive(Debug, Clone, Serialize, Deserialize, PartialEq)]
+pub struct Criterion {
+    /// ID children refer to the criterion by
+    pub id: String,
+
+    /// What must hold for the requirement to be accepted
+    pub criterion: String,
+}
+
+/// Clarification a requirement is waiting on; open until answered
+#[derive(Debug, Clone, Serialize, Deserialize, PartialEq)]
+pub struct Question {
+    /// The question to be clarified
+    pub question: String,
+
+    /// Owner reference of whoever should answer it
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub owner: Option<OwnerReference>,
+
+    /// Due date (YYYY-MM-DD)
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub due: Option<String>,
+
+    /// The answer, which resolves the question
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub answer: Option<String>,
+}
+
+/// Document a requirement refers to: a file in the repository or a URL
+#[derive(Debug, Clone, Serialize, Deserialize, PartialEq)]
+pub struct Attachment {
+    /// File, relative to the requirements file
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub path: Option<String>,
+
+    /// External document
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub url: Option<String>,
+
+    /// What the document is
+    pub title: String,
+
+    /// sha256:<hex> of the document
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub checksum: Option<String>,
+}
+
+/// ID a requirement had in a tool it was imported from
+#[derive(Debug, Clone, Serialize, Deserialize, PartialEq)]
+pub struct ExternalRef {
+    /// Tool the requirement came from, such as jira or doors
+    pub system: String,
+
+    /// The requirement's ID in that tool
+    pub id: String,
+
+    /// Link to the requirement in that tool
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub url: Option<String>,
+}
+
+/// Links from a requirement to what implements it outside the tree
+#[derive(Debug, Clone, Default, Serialize, Deserialize, PartialEq)]
+pub struct Links {
+    /// operationIds of the OpenAPI operations the requirement covers
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub openapi: Vec<String>,
+}
+
+impl Links {
+    /// Whether there are no links
+    pub fn is_empty(&self) -> bool {
+        self.openapi.is_empty()
+    }
+}
+
 /// A single requirement or reference to a requirement
 #[derive(Debug, Clone, Serialize, Deserialize, PartialEq)]
 #[serde(untagged)]
@@ -118,6 +274,26 @@ pub enum RequirementReference {
 
     /// Reference by summary
     Reference(String),
+
+    /// Reference to a requirement in another repository
+    External {
+        /// Where the requirement lives and its ID there
+        external: ExternalReference,
+    },
+}
+
+/// Requirement of another repository, referenced by ID
+#[derive(Debug, Clone, Serialize, Deserialize, PartialEq)]
+pub struct ExternalReference {
+    /// Git repository, or remote requirements file, holding the requirement
+    pub repo: String,
+
+    /// Name of the requirement in that repository
+    pub id: String,
+
+    /// Branch, tag or commit of the repository
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub r#ref: Option<String>,
 }
 
 /// A single requirement
@@ -130,6 +306,10 @@ pub struct Requirement {
     #[serde(skip_serializing_if = "Option::is_none")]
     pub name: Option<String>,
 
+    /// Stable identity from a content hash, kept across renames
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub uid: Option<String>,
+
     /// Detailed description
     #[serde(skip_serializing_if = "Option::is_none")]
     pub description: Option<String>,
@@ -142,10 +322,30 @@ pub struct Requirement {
     #[serde(skip_serializing_if = "Option::is_none")]
     pub acceptance_test: Option<String>,
 
-    /// URL to acceptance test documentation
+    /// URL to acceptance test documentation, or a script run by rqm test
     #[serde(skip_serializing_if = "Option::is_none")]
     pub acceptance_test_link: Option<String>,
 
+    /// Acceptance criteria, which children cover by ID
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub acceptance_criteria: Vec<Criterion>,
+
+    /// IDs of the parent's acceptance criteria this requirement covers
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub covers: Vec<String>,
+
+    /// Verification method
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub verification: Option<VerificationMethod>,
+
+    /// Artifacts (test reports, analyses, procedures) that verify the requirement
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub verified_by: Vec<String>,
+
+    /// Environments (platforms) the requirement must be verified on
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub environments: Vec<String>,
+
     /// Owner reference
     #[serde(skip_serializing_if = "Option::is_none")]
     pub owner: Option<OwnerReference>,
@@ -154,6 +354,34 @@ pub struct Requirement {
     #[serde(default, skip_serializing_if = "Vec::is_empty")]
     pub requirements: Vec<RequirementReference>,
 
+    /// Typed relations to other requirements
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub relations: Vec<Relation>,
+
+    /// Sign-offs required from approvers
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub approvals: Vec<Approval>,
+
+    /// Clarifications the requirement is waiting on
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub open_questions: Vec<Question>,
+
+    /// Documents the requirement refers to
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub attachments: Vec<Attachment>,
+
+    /// IDs the requirement had in the tools it was imported from
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub external_refs: Vec<ExternalRef>,
+
+    /// Links to what implements the requirement outside the tree
+    #[serde(default, skip_serializing_if = "Links::is_empty")]
+    pub links: Links,
+
+    /// Project-defined custom attributes, declared in .rqm/config.yml
+    #[serde(default, skip_serializing_if = "HashMap::is_empty")]
+    pub attributes: HashMap<String, serde_json::Value>,
+
     /// Additional information
     #[serde(default, skip_serializing_if = "Vec::is_empty")]
     pub further_information: Vec<String>,
@@ -166,10 +394,22 @@ pub struct Requirement {
     #[serde(skip_serializing_if = "Option::is_none")]
     pub priority: Option<Priority>,
 
+    /// Estimated effort, in the unit the project plans in
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub estimate: Option<f64>,
+
     /// Current status
     #[serde(skip_serializing_if = "Option::is_none")]
     pub status: Option<Status>,
 
+    /// Retired: hidden from listings and not to be referenced by active requirements
+    #[serde(default, skip_serializing_if = "std::ops::Not::not")]
+    pub deprecated: bool,
+
+    /// Summary or name of the requirement replacing this one
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub superseded_by: Option<String>,
+
     /// Creation timestamp
     #[serde(skip_serializing_if = "Option::is_none")]
     pub created_at: Option<String>,
@@ -185,16 +425,32 @@ impl Requirement {
         Self {
             summary: summary.into(),
             name: None,
+            uid: None,
             description: None,
             justification: None,
             acceptance_test: None,
             acceptance_test_link: None,
+            acceptance_criteria: Vec::new(),
+            covers: Vec::new(),
+            verification: None,
+            verified_by: Vec::new(),
+            environments: Vec::new(),
             owner: None,
             requirements: Vec::new(),
+            relations: Vec::new(),
+            approvals: Vec::new(),
+            open_questions: Vec::new(),
+            attachments: Vec::new(),
+            external_refs: Vec::new(),
+            links: Links::default(),
+            attributes: HashMap::new(),
             further_information: Vec::new(),
             tags: Vec::new(),
             priority: None,
+            estimate: None,
             status: None,
+            deprecated: false,
+            superseded_by: None,
             created_at: None,
             updated_at: None,
         }
@@ -256,6 +512,15 @@ mod tests {
         assert_eq!(flattened[1].summary, "Child");
     }
 
+    #[test]
+    fn test_relation_deserialize() {
+        let relation: Relation = serde_yaml::from_str("type: depends_on\ntarget: Other").unwrap();
+        assert_eq!(relation.relation_type, RelationType::DependsOn);
+        assert_eq!(relation.target, "Other");
+        assert!(relation.relation_type.is_ordering());
+        assert!(!RelationType::RelatesTo.is_ordering());
+    }
+
     #[test]
     fn test_config_alias_map() {
         let config = RequirementConfig {
@@ -266,6 +531,7 @@ mod tests {
                 email: Some("john@example.com".to_string()),
                 github: None,
             }],
+            includes: vec![],
             requirements: vec![],
         };
 
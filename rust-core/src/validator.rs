@@ -2,10 +2,12 @@
 // Copyright (c) 2025
 // SPDX-License-Identifier: MIT
 
+use crate::error::ErrorDetail;
 use crate::{Error, RequirementConfig, Result};
-use jsonschema::JSONSchema;
+use jsonschema::error::{TypeKind, ValidationErrorKind};
+use jsonschema::{JSONSchema, ValidationError};
 use serde_json::Value;
-use std::collections::HashSet;
+use std::collections::{BTreeMap, HashSet};
 
 /// Validator for requirement files
 pub struct Validator {
@@ -33,8 +35,8 @@ impl Validator {
 
         // Validate against schema
         if let Err(errors) = self.schema.validate(&json) {
-            let error_messages: Vec<String> = errors.map(|e| format!("{}", e)).collect();
-            return Err(Error::SchemaValidation(error_messages.join("; ")));
+            let details = errors.map(|e| schema_violation(&json, &e)).collect();
+            return Err(Error::SchemaViolations(details));
         }
 
         // Additional validation
@@ -67,10 +69,10 @@ impl Validator {
 
                 // Check if it's an email, GitHub username, or valid alias
                 if !owner.is_email() && !owner.is_github() && !alias_map.contains_key(owner_str) {
-                    return Err(Error::InvalidOwner(format!(
-                        "'{}' is not a valid email, GitHub username, or defined alias",
-                        owner_str
-                    )));
+                    return Err(Error::InvalidOwner {
+                        owner: owner_str.to_string(),
+                        summary: req.summary.clone(),
+                    });
                 }
             }
         }
@@ -79,6 +81,83 @@ impl Validator {
     }
 }
 
+/// Describes a schema violation in the document json: where it is, the
+/// keyword it violates and the values involved
+fn schema_violation(json: &Value, error: &ValidationError) -> ErrorDetail {
+    let mut params = BTreeMap::new();
+    let value = value_text(&error.instance);
+    match &error.kind {
+        ValidationErrorKind::Enum { options } => {
+            let allowed: Vec<String> = options
+                .as_array()
+                .into_iter()
+                .flatten()
+                .map(value_text)
+                .collect();
+            params.insert("value".to_string(), value);
+            params.insert("options".to_string(), allowed.join(", "));
+        }
+        ValidationErrorKind::Required { property } => {
+            params.insert("property".to_string(), value_text(property));
+        }
+        ValidationErrorKind::AdditionalProperties { unexpected } => {
+            params.insert("unexpected".to_string(), unexpected.join(", "));
+        }
+        ValidationErrorKind::Pattern { pattern } => {
+            params.insert("value".to_string(), value);
+            params.insert("pattern".to_string(), pattern.clone());
+        }
+        ValidationErrorKind::Type { kind } => {
+            params.insert("value".to_string(), error.instance.to_string());
+            if let TypeKind::Single(expected) = kind {
+                params.insert("expected".to_string(), expected.to_string());
+            }
+        }
+        _ => {
+            params.insert("value".to_string(), value);
+        }
+    }
+    let schema_path = error.schema_path.to_string();
+    ErrorDetail {
+        code: "schema".to_string(),
+        message: error.to_string(),
+        path: dotted_path(json, &error.instance_path.to_string()),
+        keyword: schema_path.rsplit('/').next().map(str::to_string),
+        params,
+        ..Default::default()
+    }
+}
+
+/// The dotted path to the value a JSON pointer such as
+/// /requirements/0/status names in json, requirements[0].status, the form
+/// parse errors use
+fn dotted_path(json: &Value, pointer: &str) -> String {
+    let mut path = String::new();
+    let mut node = Some(json);
+    for segment in pointer.split('/').skip(1) {
+        let segment = segment.replace("~1", "/").replace("~0", "~");
+        if let Some(Value::Array(items)) = node {
+            path.push_str(&format!("[{}]", segment));
+            node = segment.parse::<usize>().ok().and_then(|i| items.get(i));
+        } else {
+            if !path.is_empty() {
+                path.push('.');
+            }
+            path.push_str(&segment);
+            node = node.and_then(|n| n.get(segment.as_str()));
+        }
+    }
+    path
+}
+
+/// A JSON value as text: strings as they are, anything else as JSON
+fn value_text(value: &Value) -> String {
+    match value {
+        Value::String(s) => s.clone(),
+        other => other.to_string(),
+    }
+}
+
 impl Default for Validator {
     fn default() -> Self {
         Self::new().expect("Failed to create default validator")
@@ -96,6 +175,7 @@ mod tests {
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![Requirement::new("Test")],
         };
 
@@ -108,6 +188,7 @@ mod tests {
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![Requirement::new("Test"), Requirement::new("Test")],
         };
 
@@ -127,6 +208,7 @@ mod tests {
                 email: None,
                 github: None,
             }],
+            includes: vec![],
             requirements: vec![{
                 let mut req = Requirement::new("Test");
                 req.owner = Some(OwnerReference::String("john".to_string()));
@@ -143,6 +225,7 @@ mod tests {
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![{
                 let mut req = Requirement::new("Test");
                 req.owner = Some(OwnerReference::String("nonexistent".to_string()));
@@ -154,12 +237,41 @@ mod tests {
         assert!(result.is_err());
     }
 
+    #[test]
+    fn test_schema_violation_details() {
+        let validator = Validator::new().unwrap();
+        let config = RequirementConfig {
+            version: "1.0".to_string(),
+            aliases: vec![],
+            includes: vec![],
+            requirements: vec![Requirement::new("Parent"), Requirement::new("")],
+        };
+
+        let details = validator.validate(&config).unwrap_err().details();
+        assert_eq!(details.len(), 1);
+        assert_eq!(details[0].code, "schema");
+        assert_eq!(details[0].path, "requirements[1].summary");
+        assert_eq!(details[0].keyword.as_deref(), Some("minLength"));
+    }
+
+    #[test]
+    fn test_dotted_path() {
+        let json = serde_json::json!({"requirements": [{"tags": ["a", "b"]}], "a/b": {"0": 1}});
+        assert_eq!(
+            dotted_path(&json, "/requirements/0/tags/1"),
+            "requirements[0].tags[1]"
+        );
+        assert_eq!(dotted_path(&json, "/a~1b/0"), "a/b.0");
+        assert_eq!(dotted_path(&json, ""), "");
+    }
+
     #[test]
     fn test_email_owner() {
         let validator = Validator::new().unwrap();
         let config = RequirementConfig {
             version: "1.0".to_string(),
             aliases: vec![],
+            includes: vec![],
             requirements: vec![{
                 let mut req = Requirement::new("Test");
                 req.owner = Some(OwnerReference::String("test@example.com".to_string()));
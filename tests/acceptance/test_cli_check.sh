@@ -80,8 +80,8 @@ YAML
 
 OUTPUT=$($RQM_CMD check "$TEST_DIR/circular.yml" 2>&1)
 EXIT_CODE=$?
-if [ $EXIT_CODE -ne 0 ] && echo "$OUTPUT" | grep -q "✗.*circular reference"; then
-    test_passed "Check detects circular references with exit code 1"
+if [ $EXIT_CODE -eq 2 ] && echo "$OUTPUT" | grep -q "✗.*circular reference"; then
+    test_passed "Check detects circular references with exit code 2"
 else
     test_failed "Check should detect cycles" "Exit: $EXIT_CODE, Output: $OUTPUT"
 fi
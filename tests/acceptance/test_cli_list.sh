@@ -99,7 +99,7 @@ fi
 # Test 6: Priority indicators are displayed
 test_header "Test 6: Priority indicators displayed correctly"
 OUTPUT=$($RQM_CMD list "$PROJECT_ROOT/examples/sample-requirements.yml" 2>&1)
-if echo "$OUTPUT" | grep -q "🔴\|🟠\|🟡\|🟢"; then
+if echo "$OUTPUT" | grep -q "(critical)\|(high)\|(medium)\|(low)"; then
     test_passed "Priority indicators displayed"
 else
     test_failed "Priority indicators should be displayed" "Output: $OUTPUT"
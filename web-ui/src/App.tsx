@@ -2,10 +2,16 @@
 // Web UI - Main Application Component
 // SPDX-License-Identifier: MIT
 
-import { useState } from "react";
+import { useEffect, useState } from "react";
 import { RequirementGraph } from "./components/RequirementGraph";
 import { RequirementBrowser } from "./components/RequirementBrowser";
-import type { RequirementConfig, Requirement } from "./types";
+import { Markdown } from "./components/Markdown";
+import { RequirementEditor } from "./components/RequirementEditor";
+import { DependencyGraph } from "./components/DependencyGraph";
+import { RequirementComments } from "./components/RequirementComments";
+import { attachmentHref, findRequirement } from "./utils/requirements";
+import { loadSiteData } from "./utils/site";
+import type { RequirementConfig, Requirement, SiteData } from "./types";
 
 // Sample data for development
 const sampleConfig: RequirementConfig = {
@@ -70,18 +76,44 @@ const sampleConfig: RequirementConfig = {
   ],
 };
 
-type ViewMode = "graph" | "browser";
+type ViewMode = "graph" | "dependencies" | "browser";
 
 function App() {
   const [selectedNode, setSelectedNode] = useState<string | null>(null);
   const [selectedRequirement, setSelectedRequirement] = useState<Requirement | null>(null);
   const [viewMode, setViewMode] = useState<ViewMode>("graph");
+  // The requirement open in the form: null for a new one, undefined when closed
+  const [editing, setEditing] = useState<Requirement | null>();
+  // The data bundle of a static site; a static site is read-only
+  const [siteData, setSiteData] = useState<SiteData>();
+  const config = siteData?.requirements ?? sampleConfig;
+  const readOnly = siteData !== undefined;
+
+  useEffect(() => {
+    loadSiteData()
+      .then(setSiteData)
+      .catch((e) => console.error(e));
+  }, []);
 
   const handleRequirementSelect = (req: Requirement) => {
     setSelectedRequirement(req);
     setSelectedNode(req.name || req.summary);
   };
 
+  // Show the details of a requirement clicked in a graph, by ID or summary
+  const handleNodeClick = (id: string) => {
+    setSelectedNode(id);
+    setSelectedRequirement(findRequirement(config, id) ?? null);
+  };
+
+  // Follow a Markdown link such as [login](#AUTH-001) to that requirement
+  const handleRequirementLink = (ref: string) => {
+    const req = findRequirement(config, ref);
+    if (req) {
+      handleRequirementSelect(req);
+    }
+  };
+
   return (
     <div className="flex flex-col h-screen bg-gray-50">
       {/* Header */}
@@ -103,6 +135,16 @@ function App() {
               >
                 Graph
               </button>
+              <button
+                onClick={() => setViewMode("dependencies")}
+                className={`px-3 py-2 text-sm font-medium rounded-md ${
+                  viewMode === "dependencies"
+                    ? "bg-blue-600 text-white"
+                    : "bg-white text-gray-700 border border-gray-300 hover:bg-gray-50"
+                }`}
+              >
+                Dependencies
+              </button>
               <button
                 onClick={() => setViewMode("browser")}
                 className={`px-3 py-2 text-sm font-medium rounded-md ${
@@ -114,6 +156,14 @@ function App() {
                 Browser
               </button>
             </div>
+            {!readOnly && (
+              <button
+                onClick={() => setEditing(null)}
+                className="px-4 py-2 text-sm font-medium text-gray-700 bg-white border border-gray-300 rounded-md hover:bg-gray-50"
+              >
+                New Requirement
+              </button>
+            )}
             <button className="px-4 py-2 text-sm font-medium text-gray-700 bg-white border border-gray-300 rounded-md hover:bg-gray-50">
               Load File
             </button>
@@ -128,12 +178,12 @@ function App() {
       <div className="flex flex-1 overflow-hidden">
         {/* Sidebar */}
         <aside className="w-80 bg-white border-r border-gray-200 overflow-hidden flex flex-col">
-          {viewMode === "graph" ? (
+          {viewMode !== "browser" ? (
             <div className="p-4 flex-1 overflow-y-auto">
               <h2 className="text-sm font-semibold text-gray-900 mb-3">Selection</h2>
               <div className="space-y-2">
                 <div className="text-sm text-gray-600">
-                  Total: {sampleConfig.requirements.length}
+                  Total: {config.requirements.length}
                 </div>
                 {selectedRequirement ? (
                   <div className="mt-4 p-4 bg-blue-50 border border-blue-200 rounded">
@@ -142,7 +192,11 @@ function App() {
                     </div>
                     <div className="text-sm text-gray-900 mb-2">{selectedRequirement.summary}</div>
                     {selectedRequirement.description && (
-                      <p className="text-xs text-gray-600">{selectedRequirement.description}</p>
+                      <Markdown
+                        text={selectedRequirement.description}
+                        className="text-xs text-gray-600"
+                        onRequirementLink={handleRequirementLink}
+                      />
                     )}
                     {selectedRequirement.status && (
                       <div className="mt-2 text-xs">
@@ -163,7 +217,7 @@ function App() {
             </div>
           ) : (
             <RequirementBrowser
-              requirements={sampleConfig.requirements}
+              requirements={config.requirements}
               onSelect={handleRequirementSelect}
               selectedId={selectedNode || undefined}
             />
@@ -172,28 +226,21 @@ function App() {
 
         {/* Main View */}
         <main className="flex-1 relative">
-          {viewMode === "graph" ? (
-            <RequirementGraph
-              config={sampleConfig}
-              onNodeClick={(id) => {
-                setSelectedNode(id);
-                // Find the requirement by ID
-                const flatten = (refs: typeof sampleConfig.requirements): Requirement[] => {
-                  const result: Requirement[] = [];
-                  refs.forEach((ref) => {
-                    if (typeof ref === "object" && ref !== null && "summary" in ref) {
-                      result.push(ref);
-                      if (ref.requirements) {
-                        result.push(...flatten(ref.requirements));
-                      }
-                    }
-                  });
-                  return result;
-                };
-                const all = flatten(sampleConfig.requirements);
-                const req = all.find((r) => r.name === id || r.summary === id);
-                setSelectedRequirement(req || null);
-              }}
+          {editing !== undefined ? (
+            <div className="h-full flex justify-center overflow-y-auto bg-white p-6">
+              <RequirementEditor
+                key={editing?.summary ?? ""}
+                requirement={editing ?? undefined}
+                onSaved={() => setEditing(undefined)}
+                onCancel={() => setEditing(undefined)}
+              />
+            </div>
+          ) : viewMode === "graph" ? (
+            <RequirementGraph config={config} onNodeClick={handleNodeClick} />
+          ) : viewMode === "dependencies" ? (
+            <DependencyGraph
+              data={siteData}
+              onSelect={(req) => handleRequirementSelect(findRequirement(config, req.summary) ?? req)}
             />
           ) : (
             <div className="h-full flex items-center justify-center bg-white">
@@ -206,9 +253,61 @@ function App() {
                     </h3>
                     <p className="text-gray-700 mb-4">{selectedRequirement.summary}</p>
                     {selectedRequirement.description && (
-                      <p className="text-gray-600 mb-4">{selectedRequirement.description}</p>
+                      <Markdown
+                        text={selectedRequirement.description}
+                        className="text-gray-600 mb-4 text-left"
+                        onRequirementLink={handleRequirementLink}
+                      />
+                    )}
+                    {selectedRequirement.justification && (
+                      <div className="mb-4 text-left">
+                        <h4 className="text-sm font-semibold text-gray-900 mb-1">Justification</h4>
+                        <Markdown
+                          text={selectedRequirement.justification}
+                          className="text-sm text-gray-600"
+                          onRequirementLink={handleRequirementLink}
+                        />
+                      </div>
                     )}
-                    <div className="flex gap-4 justify-center text-sm">
+                    {selectedRequirement.further_information && selectedRequirement.further_information.length > 0 && (
+                      <div className="mb-4 text-left">
+                        <h4 className="text-sm font-semibold text-gray-900 mb-1">Further information</h4>
+                        <Markdown
+                          text={selectedRequirement.further_information.map((item) => `- ${item}`).join("\n")}
+                          className="text-sm text-gray-600"
+                          onRequirementLink={handleRequirementLink}
+                        />
+                      </div>
+                    )}
+                    {selectedRequirement.attachments && selectedRequirement.attachments.length > 0 && (
+                      <div className="mb-4 text-left">
+                        <h4 className="text-sm font-semibold text-gray-900 mb-1">Attachments</h4>
+                        <ul className="list-disc pl-5 text-sm text-gray-600">
+                          {selectedRequirement.attachments.map((attachment, i) => (
+                            <li key={i}>
+                              <a
+                                href={attachmentHref(attachment)}
+                                target="_blank"
+                                rel="noopener noreferrer"
+                                className="text-blue-600 hover:underline"
+                              >
+                                {attachment.title}
+                              </a>{" "}
+                              <span className="text-gray-400">{attachment.path ?? attachment.url}</span>
+                            </li>
+                          ))}
+                        </ul>
+                      </div>
+                    )}
+                    <div className="flex gap-4 justify-center items-center text-sm">
+                      {!readOnly && (
+                        <button
+                          onClick={() => setEditing(selectedRequirement)}
+                          className="px-3 py-1 text-sm font-medium text-white bg-blue-600 rounded-md hover:bg-blue-700"
+                        >
+                          Edit
+                        </button>
+                      )}
                       {selectedRequirement.status && (
                         <div>
                           <span className="font-medium">Status:</span> {selectedRequirement.status}
@@ -221,6 +320,13 @@ function App() {
                         </div>
                       )}
                     </div>
+                    {!readOnly && (
+                      <RequirementComments
+                        key={selectedRequirement.name || selectedRequirement.summary}
+                        requirementId={selectedRequirement.name || selectedRequirement.summary}
+                        onRequirementLink={handleRequirementLink}
+                      />
+                    )}
                   </div>
                 ) : (
                   <p className="text-gray-500">Select a requirement from the browser</p>
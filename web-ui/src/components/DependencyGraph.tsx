@@ -0,0 +1,132 @@
+// RQM - Requirements Management in Code
+// Dependency Graph with Cycle Highlighting
+// SPDX-License-Identifier: MIT
+
+import { useEffect, useState } from "react";
+import { ReactFlow, type Node, type Edge, Background, Controls, MiniMap, MarkerType } from "reactflow";
+import "reactflow/dist/style.css";
+import type { DependencyGraphData, DependencyNode, LayoutNode, Requirement } from "../types";
+
+interface DependencyGraphProps {
+  /** Graph and layout rendered ahead of time, for a static site without rqm serve */
+  data?: { graph: DependencyGraphData; layout: { nodes: LayoutNode[] } };
+  /** Called with the requirement clicked, as far as the graph knows it */
+  onSelect?: (requirement: Requirement) => void;
+}
+
+/** Spreads the server's layout, spaced for smaller nodes, for 200px wide ones */
+const SCALE = 1.4;
+
+const CYCLE_COLOR = "#dc2626";
+
+/**
+ * Interactive dependency graph of the file rqm serve serves: every
+ * sub-requirement and relation, laid out on the server, with the
+ * requirements and links of circular references in red. Clicking a
+ * requirement opens its details.
+ */
+export function DependencyGraph({ data: bundled, onSelect }: DependencyGraphProps) {
+  const [graph, setGraph] = useState<{ nodes: Node<DependencyNode & { label: string }>[]; edges: Edge[]; cycles: string[][] }>();
+  const [error, setError] = useState<string>();
+
+  useEffect(() => {
+    const load: Promise<[DependencyGraphData, { nodes: LayoutNode[] }]> = bundled
+      ? Promise.resolve([bundled.graph, bundled.layout])
+      : Promise.all([
+          fetch("api/graph?format=d3").then((r) => (r.ok ? r.json() : Promise.reject(new Error(r.statusText)))),
+          fetch("api/graph/layout?algorithm=layered").then((r) => (r.ok ? r.json() : { nodes: [] })),
+        ]);
+    load
+      .then(([data, layout]: [DependencyGraphData, { nodes: LayoutNode[] }]) => {
+        const positions = new Map(layout.nodes.map((n) => [n.id, n]));
+        const nodes: Node<DependencyNode & { label: string }>[] = data.nodes.map((n, i) => {
+          const p = positions.get(n.id);
+          const inCycle = n.cycle !== undefined;
+          return {
+            id: n.id,
+            position: p ? { x: p.x * SCALE, y: p.y * SCALE } : { x: 0, y: i * 80 },
+            // React Flow shows the label of a default node
+            data: { ...n, label: n.name ? `${n.name}: ${n.summary}` : n.summary },
+            style: {
+              background: inCycle ? "#fee2e2" : "#fff",
+              border: inCycle ? `2px solid ${CYCLE_COLOR}` : "1px solid #e5e7eb",
+              borderRadius: "8px",
+              fontSize: "12px",
+              width: 200,
+              ...(n.deprecated && { color: "#9ca3af", textDecoration: "line-through" }),
+            },
+          };
+        });
+        const edges: Edge[] = data.links.map((l, i) => ({
+          id: `e${i}`,
+          source: l.source,
+          target: l.target,
+          label: l.kind === "child" ? undefined : l.kind,
+          animated: l.in_cycle,
+          markerEnd: { type: MarkerType.ArrowClosed, color: l.in_cycle ? CYCLE_COLOR : undefined },
+          style: {
+            ...(l.in_cycle && { stroke: CYCLE_COLOR, strokeWidth: 2 }),
+            ...(l.kind !== "child" && { strokeDasharray: "5 5" }),
+          },
+        }));
+        setGraph({ nodes, edges, cycles: data.cycles });
+      })
+      .catch((e: Error) => setError(`The dependency graph needs rqm serve with a requirements file (${e.message})`));
+  }, [bundled]);
+
+  const select = (id: string) => {
+    const node = graph?.nodes.find((n) => n.id === id);
+    if (node) {
+      const { summary, name, status, priority, deprecated } = node.data;
+      onSelect?.({ summary, name, status, priority, deprecated });
+    }
+  };
+
+  if (error) {
+    return <div className="h-full flex items-center justify-center text-sm text-gray-500">{error}</div>;
+  }
+  if (!graph) {
+    return <div className="h-full flex items-center justify-center text-sm text-gray-500">Loading graph…</div>;
+  }
+  return (
+    <div className="w-full h-full relative">
+      {graph.cycles.length > 0 && (
+        <div className="absolute top-2 left-2 z-10 max-w-md p-3 text-sm bg-red-50 border border-red-200 rounded shadow">
+          <div className="font-semibold text-red-800 mb-1">
+            {graph.cycles.length} circular reference{graph.cycles.length === 1 ? "" : "s"}
+          </div>
+          <ul className="list-disc pl-5 text-red-700">
+            {graph.cycles.map((cycle, i) => (
+              <li key={i}>
+                {cycle.map((id, j) => (
+                  <span key={id}>
+                    {j > 0 && " → "}
+                    <button className="hover:underline" onClick={() => select(id)}>
+                      {id}
+                    </button>
+                  </span>
+                ))}
+              </li>
+            ))}
+          </ul>
+        </div>
+      )}
+      <ReactFlow
+        defaultNodes={graph.nodes}
+        defaultEdges={graph.edges}
+        onNodeClick={(_event, node) => select(node.id)}
+        fitView
+        attributionPosition="bottom-left"
+      >
+        <Background />
+        <Controls />
+        <MiniMap
+          nodeStrokeWidth={3}
+          nodeColor={(node) => (graph.cycles.some((cycle) => cycle.includes(node.id)) ? CYCLE_COLOR : "#e5e7eb")}
+          zoomable
+          pannable
+        />
+      </ReactFlow>
+    </div>
+  );
+}
@@ -0,0 +1,199 @@
+// RQM - Requirements Management in Code
+// Markdown Rendering for Requirement Text
+// SPDX-License-Identifier: MIT
+
+import type { ReactNode } from "react";
+import {
+  MARKDOWN_HEADING as HEADING,
+  MARKDOWN_INLINE as INLINE,
+  MARKDOWN_LIST_ITEM as LIST_ITEM,
+  MARKDOWN_RULE as RULE,
+  requirementLinkTarget,
+} from "../utils/markdown";
+
+interface MarkdownProps {
+  text: string;
+  className?: string;
+  /** Called with the requirement ID or summary of a link such as [login](#AUTH-001) */
+  onRequirementLink?: (ref: string) => void;
+}
+
+const HEADING_CLASSES = [
+  "text-lg font-bold",
+  "text-base font-bold",
+  "text-sm font-semibold",
+  "text-sm font-semibold",
+  "text-sm font-medium",
+  "text-sm font-medium",
+];
+
+/**
+ * Render Markdown from a requirement's description, justification or
+ * further information as React elements. Only a safe subset is
+ * supported and no HTML is passed through.
+ */
+export function Markdown({ text, className = "", onRequirementLink }: MarkdownProps) {
+  let key = 0;
+
+  const inline = (s: string): ReactNode[] => {
+    const nodes: ReactNode[] = [];
+    let last = 0;
+    for (const m of s.matchAll(INLINE)) {
+      const index = m.index ?? 0;
+      if (index > last) {
+        nodes.push(s.slice(last, index));
+      }
+      last = index + m[0].length;
+      const [, code, linkText, target, autolink, bold1, bold2, italic1, italic2] = m;
+      if (code !== undefined) {
+        nodes.push(
+          <code key={key++} className="px-1 rounded bg-gray-100 font-mono text-[0.9em]">
+            {code}
+          </code>,
+        );
+      } else if (target !== undefined) {
+        const ref = requirementLinkTarget(target);
+        if (ref !== null) {
+          nodes.push(
+            <a
+              key={key++}
+              href={target}
+              className="text-blue-600 hover:underline font-medium"
+              onClick={(e) => {
+                if (onRequirementLink) {
+                  e.preventDefault();
+                  onRequirementLink(ref);
+                }
+              }}
+            >
+              {linkText || ref}
+            </a>,
+          );
+        } else {
+          nodes.push(
+            <a
+              key={key++}
+              href={target}
+              target="_blank"
+              rel="noopener noreferrer"
+              className="text-blue-600 hover:underline"
+            >
+              {linkText || target}
+            </a>,
+          );
+        }
+      } else if (autolink !== undefined) {
+        nodes.push(
+          <a
+            key={key++}
+            href={autolink}
+            target="_blank"
+            rel="noopener noreferrer"
+            className="text-blue-600 hover:underline"
+          >
+            {autolink}
+          </a>,
+        );
+      } else if (bold1 !== undefined || bold2 !== undefined) {
+        nodes.push(<strong key={key++}>{inline(bold1 ?? bold2)}</strong>);
+      } else {
+        nodes.push(<em key={key++}>{inline(italic1 ?? italic2)}</em>);
+      }
+    }
+    if (last < s.length) {
+      nodes.push(s.slice(last));
+    }
+    return nodes;
+  };
+
+  const blocks: ReactNode[] = [];
+  let paragraph: string[] = [];
+  // Asserted so TypeScript doesn't narrow it to null: flush resets it
+  let list = null as { ordered: boolean; items: string[] } | null;
+
+  const flush = () => {
+    if (paragraph.length > 0) {
+      blocks.push(<p key={key++}>{inline(paragraph.join(" "))}</p>);
+      paragraph = [];
+    }
+    if (list) {
+      const items = list.items.map((item) => <li key={key++}>{inline(item)}</li>);
+      blocks.push(
+        list.ordered ? (
+          <ol key={key++} className="list-decimal pl-5">
+            {items}
+          </ol>
+        ) : (
+          <ul key={key++} className="list-disc pl-5">
+            {items}
+          </ul>
+        ),
+      );
+      list = null;
+    }
+  };
+
+  const lines = text.replace(/\n+$/, "").split("\n");
+  for (let i = 0; i < lines.length; i++) {
+    const line = lines[i];
+    const trimmed = line.trim();
+    const heading = trimmed.match(HEADING);
+    const item = line.match(LIST_ITEM);
+
+    if (trimmed === "") {
+      flush();
+    } else if (trimmed.startsWith("```") || trimmed.startsWith("~~~")) {
+      flush();
+      const fence = trimmed.slice(0, 3);
+      const code: string[] = [];
+      for (i++; i < lines.length && !lines[i].trim().startsWith(fence); i++) {
+        code.push(lines[i]);
+      }
+      blocks.push(
+        <pre key={key++} className="p-2 rounded bg-gray-100 font-mono text-xs overflow-x-auto">
+          <code>{code.join("\n")}</code>
+        </pre>,
+      );
+    } else if (heading) {
+      flush();
+      blocks.push(
+        <div key={key++} role="heading" aria-level={heading[1].length} className={HEADING_CLASSES[heading[1].length - 1]}>
+          {inline(heading[2])}
+        </div>,
+      );
+    } else if (RULE.test(line)) {
+      flush();
+      blocks.push(<hr key={key++} className="border-gray-200" />);
+    } else if (item) {
+      const ordered = /\d/.test(item[2]);
+      if (paragraph.length > 0 || (list && list.ordered !== ordered)) {
+        flush();
+      }
+      list ??= { ordered, items: [] };
+      list.items.push(item[3]);
+    } else if (trimmed.startsWith(">")) {
+      flush();
+      const quote: string[] = [];
+      for (; i < lines.length && lines[i].trim().startsWith(">"); i++) {
+        quote.push(lines[i].trim().replace(/^>\s?/, ""));
+      }
+      i--;
+      blocks.push(
+        <blockquote key={key++} className="border-l-4 border-gray-300 pl-3 italic text-gray-500">
+          {inline(quote.join(" "))}
+        </blockquote>,
+      );
+    } else if (list && line.startsWith(" ")) {
+      // An indented line continues the list item above it
+      list.items[list.items.length - 1] += " " + trimmed;
+    } else {
+      if (list) {
+        flush();
+      }
+      paragraph.push(trimmed);
+    }
+  }
+  flush();
+
+  return <div className={`space-y-2 ${className}`}>{blocks}</div>;
+}
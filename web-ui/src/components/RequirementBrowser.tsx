@@ -2,15 +2,21 @@
 // Requirement Browser Component
 // SPDX-License-Identifier: MIT
 
-import { useState, useMemo } from "react";
-import type { Requirement, RequirementReference, Priority, Status } from "../types";
+import { useState, useMemo, useEffect } from "react";
+import type { Requirement, RequirementReference, Priority, SearchResponse, Status } from "../types";
 import {
   flattenRequirements,
+  isExternalReference,
   isRequirement,
   getStatusColor,
   getStatusIcon,
   getPriorityIcon,
 } from "../utils/requirements";
+import { markdownToPlainText } from "../utils/markdown";
+import { searchRequirements } from "../utils/search";
+
+/** Wait after the last keystroke before searching on the server */
+const SEARCH_DELAY_MS = 250;
 
 interface RequirementBrowserProps {
   requirements: RequirementReference[];
@@ -31,6 +37,44 @@ export function RequirementBrowser({
   const [priorityFilter, setPriorityFilter] = useState<Priority | "all">("all");
   const [sortField, setSortField] = useState<SortField>("name");
   const [viewMode, setViewMode] = useState<ViewMode>("tree");
+  const [page, setPage] = useState(1);
+  // Results of /api/search; without a server the list is filtered here
+  const [serverResults, setServerResults] = useState<SearchResponse>();
+  const [searchError, setSearchError] = useState<string>();
+
+  // The list view searches on the server, so large files needn't be
+  // filtered in the browser
+  useEffect(() => {
+    if (viewMode !== "list") {
+      return;
+    }
+    const controller = new AbortController();
+    const timer = setTimeout(() => {
+      searchRequirements(
+        {
+          q: searchQuery,
+          status: statusFilter === "all" ? undefined : statusFilter,
+          priority: priorityFilter === "all" ? undefined : priorityFilter,
+          page,
+        },
+        controller.signal,
+      )
+        .then((response) => {
+          setServerResults(response);
+          setSearchError(undefined);
+        })
+        .catch((e) => {
+          if (!controller.signal.aborted) {
+            setServerResults(undefined);
+            setSearchError(e instanceof Error ? e.message : String(e));
+          }
+        });
+    }, SEARCH_DELAY_MS);
+    return () => {
+      clearTimeout(timer);
+      controller.abort();
+    };
+  }, [viewMode, searchQuery, statusFilter, priorityFilter, page]);
 
   const allRequirements = useMemo(() => flattenRequirements(requirements), [requirements]);
 
@@ -61,7 +105,8 @@ export function RequirementBrowser({
   }, [allRequirements, searchQuery, statusFilter, priorityFilter]);
 
   const sortedRequirements = useMemo(() => {
-    return [...filteredRequirements].sort((a, b) => {
+    const listed: Requirement[] = serverResults?.results ?? filteredRequirements;
+    return [...listed].sort((a, b) => {
       switch (sortField) {
         case "name":
           return (a.name || a.summary).localeCompare(b.name || b.summary);
@@ -78,7 +123,7 @@ export function RequirementBrowser({
           return 0;
       }
     });
-  }, [filteredRequirements, sortField]);
+  }, [serverResults, filteredRequirements, sortField]);
 
   return (
     <div className="flex flex-col h-full bg-white">
@@ -89,7 +134,10 @@ export function RequirementBrowser({
           type="text"
           placeholder="Search requirements..."
           value={searchQuery}
-          onChange={(e) => setSearchQuery(e.target.value)}
+          onChange={(e) => {
+            setSearchQuery(e.target.value);
+            setPage(1);
+          }}
           className="w-full px-3 py-2 border border-gray-300 rounded-md text-sm focus:outline-none focus:ring-2 focus:ring-blue-500"
         />
 
@@ -97,7 +145,10 @@ export function RequirementBrowser({
         <div className="flex gap-2">
           <select
             value={statusFilter}
-            onChange={(e) => setStatusFilter(e.target.value as Status | "all")}
+            onChange={(e) => {
+              setStatusFilter(e.target.value as Status | "all");
+              setPage(1);
+            }}
             className="flex-1 px-2 py-1 border border-gray-300 rounded text-xs"
           >
             <option value="all">All Status</option>
@@ -111,7 +162,10 @@ export function RequirementBrowser({
 
           <select
             value={priorityFilter}
-            onChange={(e) => setPriorityFilter(e.target.value as Priority | "all")}
+            onChange={(e) => {
+              setPriorityFilter(e.target.value as Priority | "all");
+              setPage(1);
+            }}
             className="flex-1 px-2 py-1 border border-gray-300 rounded text-xs"
           >
             <option value="all">All Priority</option>
@@ -156,18 +210,44 @@ export function RequirementBrowser({
 
         {/* Results count */}
         <div className="text-xs text-gray-500">
-          {filteredRequirements.length} of {allRequirements.length} requirements
+          {viewMode === "list" && serverResults
+            ? `${serverResults.total} matching requirements`
+            : `${filteredRequirements.length} of ${allRequirements.length} requirements`}
         </div>
+        {viewMode === "list" && searchError && <div className="text-xs text-red-600">{searchError}</div>}
       </div>
 
       {/* Requirement List */}
       <div className="flex-1 overflow-y-auto">
         {viewMode === "list" ? (
-          <RequirementList
-            requirements={sortedRequirements}
-            onSelect={onSelect}
-            selectedId={selectedId}
-          />
+          <>
+            <RequirementList
+              requirements={sortedRequirements}
+              onSelect={onSelect}
+              selectedId={selectedId}
+            />
+            {serverResults && serverResults.pages > 1 && (
+              <div className="flex items-center justify-between p-3 text-xs text-gray-600 border-t border-gray-200">
+                <button
+                  disabled={page <= 1}
+                  onClick={() => setPage(page - 1)}
+                  className="px-2 py-1 rounded bg-gray-200 disabled:opacity-50"
+                >
+                  Previous
+                </button>
+                <span>
+                  Page {serverResults.page} of {serverResults.pages}
+                </span>
+                <button
+                  disabled={page >= serverResults.pages}
+                  onClick={() => setPage(page + 1)}
+                  className="px-2 py-1 rounded bg-gray-200 disabled:opacity-50"
+                >
+                  Next
+                </button>
+              </div>
+            )}
+          </>
         ) : (
           <RequirementTree
             requirements={requirements}
@@ -231,7 +311,7 @@ function RequirementTree({
               className="px-4 py-2 text-sm text-gray-500 italic"
               style={{ paddingLeft: `${depth * 20 + 16}px` }}
             >
-              → {ref}
+              {isExternalReference(ref) ? `↗ ${ref.external.id} (${ref.external.repo})` : `→ ${ref}`}
             </div>
           );
         }
@@ -309,7 +389,9 @@ function RequirementItem({
 
         {/* Description */}
         {requirement.description && (
-          <p className="text-xs text-gray-600 line-clamp-2">{requirement.description}</p>
+          <p className="text-xs text-gray-600 line-clamp-2">
+            {markdownToPlainText(requirement.description)}
+          </p>
         )}
 
         {/* Meta */}
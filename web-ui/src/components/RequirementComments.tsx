@@ -0,0 +1,123 @@
+// RQM - Requirements Management in Code
+// Requirement Comments and Presence
+// SPDX-License-Identifier: MIT
+
+import { useEffect, useState } from "react";
+import type { CommentsResponse } from "../types";
+import { Markdown } from "./Markdown";
+import { COMMENTS_POLL_MS, fetchComments, getViewerName, postComment, setViewerName } from "../utils/comments";
+
+interface RequirementCommentsProps {
+  /** ID, or summary, of the requirement */
+  requirementId: string;
+  onRequirementLink?: (ref: string) => void;
+}
+
+/**
+ * Discussion of a requirement in the detail pane: its comments, who else
+ * has it open, and a form to add a comment. Refreshed while open, so
+ * reviewers see each other's comments without reloading.
+ */
+export function RequirementComments({ requirementId, onRequirementLink }: RequirementCommentsProps) {
+  // The reader is marked present under their name once it is entered
+  const [name, setName] = useState(getViewerName);
+  const [viewer, setViewer] = useState(name);
+  const [data, setData] = useState<CommentsResponse>();
+  const [unavailable, setUnavailable] = useState(false);
+  const [text, setText] = useState("");
+  const [error, setError] = useState<string>();
+  const [busy, setBusy] = useState(false);
+
+  useEffect(() => {
+    const controller = new AbortController();
+    const load = () =>
+      fetchComments(requirementId, viewer, controller.signal)
+        .then((response) => {
+          setData(response);
+          setUnavailable(response === undefined);
+        })
+        .catch(() => {
+          if (!controller.signal.aborted) {
+            setUnavailable(true);
+          }
+        });
+    load();
+    const timer = setInterval(load, COMMENTS_POLL_MS);
+    return () => {
+      clearInterval(timer);
+      controller.abort();
+    };
+  }, [requirementId, viewer]);
+
+  if (unavailable) {
+    return null;
+  }
+
+  const submit = async () => {
+    setBusy(true);
+    setError(undefined);
+    try {
+      setData(await postComment(requirementId, name, text));
+      setText("");
+    } catch (e) {
+      setError(e instanceof Error ? e.message : String(e));
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  return (
+    <div className="mt-6 text-left border-t border-gray-200 pt-4">
+      <div className="flex items-center justify-between mb-2">
+        <h4 className="text-sm font-semibold text-gray-900">Comments ({data?.comments.length ?? 0})</h4>
+        {data && data.viewers.length > 0 && (
+          <span className="text-xs text-green-700" title="Also viewing this requirement">
+            👀 {data.viewers.join(", ")}
+          </span>
+        )}
+      </div>
+
+      <ul className="space-y-3 mb-3">
+        {data?.comments.map((comment, i) => (
+          <li key={i} className="p-2 bg-white border border-gray-200 rounded">
+            <div className="text-xs text-gray-500 mb-1">
+              <span className="font-medium text-gray-700">{comment.author}</span>{" "}
+              {new Date(comment.time).toLocaleString()}
+            </div>
+            <Markdown text={comment.text} className="text-sm text-gray-700" onRequirementLink={onRequirementLink} />
+          </li>
+        ))}
+      </ul>
+
+      <div className="space-y-2">
+        <input
+          className="w-full px-2 py-1 text-sm border border-gray-300 rounded"
+          placeholder="Your name"
+          value={name}
+          onChange={(e) => setName(e.target.value)}
+          onBlur={() => {
+            setViewerName(name);
+            setViewer(name.trim());
+          }}
+        />
+        <textarea
+          className="w-full px-2 py-1 text-sm border border-gray-300 rounded"
+          rows={3}
+          placeholder="Add a comment (Markdown)"
+          value={text}
+          onChange={(e) => setText(e.target.value)}
+        />
+        {error && <div className="text-xs text-red-600">{error}</div>}
+        <div className="flex justify-end">
+          <button
+            disabled={busy || name.trim() === "" || text.trim() === ""}
+            onClick={submit}
+            className="px-3 py-1 text-sm font-medium text-white bg-blue-600 rounded-md hover:bg-blue-700 disabled:opacity-50"
+          >
+            Comment
+          </button>
+        </div>
+      </div>
+    </div>
+  );
+}
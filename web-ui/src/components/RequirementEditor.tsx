@@ -0,0 +1,246 @@
+// RQM - Requirements Management in Code
+// Requirement Create/Edit Form
+// SPDX-License-Identifier: MIT
+
+import { useEffect, useState, type ReactNode } from "react";
+import type { DiffOp, Requirement, RequirementForm, RequirementWriteResponse } from "../types";
+import {
+  PRIORITIES,
+  STATUSES,
+  VERIFICATION_METHODS,
+  fetchRequirementsETag,
+  parseTags,
+  requirementFormFor,
+  submitRequirementForm,
+  validateRequirementForm,
+} from "../utils/requirementForm";
+
+interface RequirementEditorProps {
+  /** Requirement to edit; without one a requirement is created */
+  requirement?: Requirement;
+  /** Summary or ID of the requirement to create one under */
+  parent?: string;
+  onSaved: (response: RequirementWriteResponse) => void;
+  onCancel: () => void;
+}
+
+/** Unchanged lines shown around each change in the preview */
+const DIFF_CONTEXT = 3;
+
+const inputClass = "w-full px-2 py-1 text-sm border border-gray-300 rounded focus:outline-none focus:ring-1 focus:ring-blue-500";
+
+/**
+ * Form creating or editing a requirement in the file rqm serve serves.
+ * The form is checked against the schema as it is filled in; Preview
+ * shows the change to the file and the server's validation of it, and
+ * Save writes it unless the file changed since the form was opened.
+ */
+export function RequirementEditor({ requirement, parent, onSaved, onCancel }: RequirementEditorProps) {
+  const [form, setForm] = useState<RequirementForm>(() => ({ ...requirementFormFor(requirement), parent }));
+  const [tagsText, setTagsText] = useState((requirement?.tags ?? []).join(", "));
+  const [etag, setEtag] = useState<string>();
+  const [result, setResult] = useState<RequirementWriteResponse>();
+  const [serverError, setServerError] = useState<string>();
+  const [busy, setBusy] = useState(false);
+
+  // The ETag of the file as the form was opened guards the save
+  useEffect(() => {
+    fetchRequirementsETag()
+      .then(setEtag)
+      .catch(() => setEtag(undefined));
+  }, []);
+
+  const values: RequirementForm = { ...form, tags: parseTags(tagsText) };
+  const errors = validateRequirementForm(values);
+  const hasErrors = Object.keys(errors).length > 0;
+
+  const update = (field: keyof RequirementForm, value: string) => {
+    setForm({ ...form, [field]: value === "" ? undefined : value });
+    setResult(undefined);
+  };
+
+  const submit = async (preview: boolean) => {
+    setBusy(true);
+    setServerError(undefined);
+    try {
+      const { response, error, conflict } = await submitRequirementForm({ ...values, preview }, etag);
+      if (conflict) {
+        setServerError(`${error} Close the form and open it again to edit the current version.`);
+      } else if (error) {
+        setServerError(error);
+      }
+      setResult(response);
+      if (response?.saved) {
+        onSaved(response);
+      }
+    } catch (e) {
+      setServerError(e instanceof Error ? e.message : String(e));
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  const field = (label: string, name: keyof RequirementForm, input: ReactNode) => (
+    <label className="block">
+      <span className="block text-xs font-medium text-gray-700 mb-1">{label}</span>
+      {input}
+      {errors[name] && <span className="block text-xs text-red-600 mt-1">{errors[name]}</span>}
+    </label>
+  );
+
+  const text = (name: keyof RequirementForm) => (
+    <input
+      className={inputClass}
+      value={(form[name] as string | undefined) ?? ""}
+      onChange={(e) => update(name, e.target.value)}
+    />
+  );
+
+  const textarea = (name: keyof RequirementForm) => (
+    <textarea
+      className={`${inputClass} font-mono`}
+      rows={4}
+      value={(form[name] as string | undefined) ?? ""}
+      onChange={(e) => update(name, e.target.value)}
+    />
+  );
+
+  const select = (name: keyof RequirementForm, options: string[]) => (
+    <select
+      className={inputClass}
+      value={(form[name] as string | undefined) ?? ""}
+      onChange={(e) => update(name, e.target.value)}
+    >
+      <option value="">—</option>
+      {options.map((option) => (
+        <option key={option} value={option}>
+          {option}
+        </option>
+      ))}
+    </select>
+  );
+
+  return (
+    <div className="max-w-2xl w-full p-6 bg-gray-50 rounded-lg text-left space-y-3 overflow-y-auto">
+      <h3 className="text-xl font-bold text-gray-900">
+        {requirement ? `Edit ${requirement.name || requirement.summary}` : "New requirement"}
+        {parent && <span className="text-sm font-normal text-gray-500"> under {parent}</span>}
+      </h3>
+
+      {field("Summary", "summary", text("summary"))}
+      <div className="grid grid-cols-2 gap-3">
+        {field("ID", "name", text("name"))}
+        {field("Owner", "owner", text("owner"))}
+        {field("Status", "status", select("status", STATUSES))}
+        {field("Priority", "priority", select("priority", PRIORITIES))}
+        {field("Verification", "verification", select("verification", VERIFICATION_METHODS))}
+        {field(
+          "Tags",
+          "tags",
+          <input
+            className={inputClass}
+            value={tagsText}
+            placeholder="auth, security"
+            onChange={(e) => {
+              setTagsText(e.target.value);
+              setResult(undefined);
+            }}
+          />,
+        )}
+      </div>
+      {field("Description (Markdown)", "description", textarea("description"))}
+      {field("Justification (Markdown)", "justification", textarea("justification"))}
+      {field("Acceptance test", "acceptance_test", textarea("acceptance_test"))}
+
+      {serverError && <div className="p-2 text-sm text-red-700 bg-red-50 border border-red-200 rounded">{serverError}</div>}
+      {result && (
+        <div className="space-y-2">
+          {result.errors.length > 0 && (
+            <ul className="p-2 text-sm text-red-700 bg-red-50 border border-red-200 rounded list-disc pl-6">
+              {result.errors.map((error, i) => (
+                <li key={i}>{error}</li>
+              ))}
+            </ul>
+          )}
+          {result.warnings.length > 0 && (
+            <ul className="p-2 text-sm text-yellow-800 bg-yellow-50 border border-yellow-200 rounded list-disc pl-6">
+              {result.warnings.map((warning, i) => (
+                <li key={i}>{warning}</li>
+              ))}
+            </ul>
+          )}
+          <DiffPreview ops={result.diff} />
+        </div>
+      )}
+
+      <div className="flex gap-2 justify-end">
+        <button
+          onClick={onCancel}
+          className="px-4 py-2 text-sm font-medium text-gray-700 bg-white border border-gray-300 rounded-md hover:bg-gray-50"
+        >
+          Cancel
+        </button>
+        <button
+          disabled={busy || hasErrors}
+          onClick={() => submit(true)}
+          className="px-4 py-2 text-sm font-medium text-gray-700 bg-white border border-gray-300 rounded-md hover:bg-gray-50 disabled:opacity-50"
+        >
+          Preview
+        </button>
+        <button
+          disabled={busy || hasErrors || !result?.valid}
+          title={result?.valid ? undefined : "Preview the change first"}
+          onClick={() => submit(false)}
+          className="px-4 py-2 text-sm font-medium text-white bg-blue-600 rounded-md hover:bg-blue-700 disabled:opacity-50"
+        >
+          Save
+        </button>
+      </div>
+    </div>
+  );
+}
+
+/**
+ * The change to the file, line by line, with long unchanged stretches
+ * folded away
+ */
+function DiffPreview({ ops }: { ops: DiffOp[] }) {
+  const rows: { op: DiffOp["op"] | "fold"; line: string }[] = [];
+  ops.forEach((op, i) => {
+    const lines = op.text.replace(/\n$/, "").split("\n");
+    if (op.op !== "equal" || lines.length <= 2 * DIFF_CONTEXT) {
+      lines.forEach((line) => rows.push({ op: op.op, line }));
+      return;
+    }
+    const head = i === 0 ? [] : lines.slice(0, DIFF_CONTEXT);
+    const tail = i === ops.length - 1 ? [] : lines.slice(-DIFF_CONTEXT);
+    head.forEach((line) => rows.push({ op: "equal", line }));
+    rows.push({ op: "fold", line: `⋯ ${lines.length - head.length - tail.length} unchanged lines` });
+    tail.forEach((line) => rows.push({ op: "equal", line }));
+  });
+
+  if (!ops.some((op) => op.op !== "equal")) {
+    return <div className="text-sm text-gray-500 italic">No changes to the file</div>;
+  }
+  return (
+    <pre className="p-2 text-xs font-mono bg-white border border-gray-200 rounded overflow-x-auto">
+      {rows.map((row, i) => (
+        <div
+          key={i}
+          className={
+            row.op === "delete"
+              ? "bg-red-50 text-red-800"
+              : row.op === "insert"
+                ? "bg-green-50 text-green-800"
+                : row.op === "fold"
+                  ? "text-gray-400 italic"
+                  : "text-gray-600"
+          }
+        >
+          {row.op === "delete" ? "- " : row.op === "insert" ? "+ " : "  "}
+          {row.line}
+        </div>
+      ))}
+    </pre>
+  );
+}
@@ -18,7 +18,7 @@ import {
 } from "reactflow";
 import "reactflow/dist/style.css";
 import type { RequirementConfig, RequirementReference } from "../types";
-import { isRequirement } from "../utils/requirements";
+import { isDeprecated, isExternalReference, isRequirement } from "../utils/requirements";
 
 interface RequirementGraphProps {
   config: RequirementConfig;
@@ -51,16 +51,19 @@ function requirementsToGraph(
 
   requirements.forEach((ref, index) => {
     if (!isRequirement(ref)) {
-      // String reference - create a simple node
+      // String or external reference - create a simple node, dashed for
+      // requirements of other repositories
       const nodeId = `ref-${parentId}-${index}`;
+      const label = isExternalReference(ref) ? `${ref.external.id} (${ref.external.repo})` : ref;
       nodes.push({
         id: nodeId,
         type: "default",
         position: { x: depth * 250, y: index * 100 },
         data: {
-          label: ref,
-          summary: ref,
+          label,
+          summary: label,
         },
+        ...(isExternalReference(ref) && { style: { borderStyle: "dashed" } }),
       });
 
       if (parentId) {
@@ -78,13 +81,14 @@ function requirementsToGraph(
 
     // Check for circular reference
     const isCircular = visited.has(nodeId);
+    const deprecated = isDeprecated(ref);
 
     nodes.push({
       id: nodeId,
       type: isCircular ? "default" : "default",
       position: { x: depth * 250, y: index * 100 },
       data: {
-        label: ref.name || ref.summary,
+        label: deprecated ? `${ref.name || ref.summary} (deprecated)` : ref.name || ref.summary,
         summary: ref.summary,
         status: ref.status,
         priority: ref.priority,
@@ -96,6 +100,7 @@ function requirementsToGraph(
         padding: "10px",
         fontSize: "12px",
         width: 200,
+        ...(deprecated && { color: "#9ca3af", textDecoration: "line-through" }),
       },
     });
 
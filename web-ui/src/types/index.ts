@@ -12,6 +12,11 @@ export type Priority = "critical" | "high" | "medium" | "low";
  */
 export type Status = "draft" | "proposed" | "approved" | "implemented" | "verified" | "deprecated";
 
+/**
+ * Verification method
+ */
+export type VerificationMethod = "test" | "analysis" | "inspection" | "demonstration";
+
 /**
  * Owner reference - can be email, GitHub handle, or alias
  */
@@ -27,6 +32,101 @@ export interface PersonAlias {
   github?: string;
 }
 
+/**
+ * Typed relation kinds between requirements
+ */
+export type RelationType = "depends_on" | "blocks" | "relates_to" | "duplicates" | "derives_from";
+
+/**
+ * Typed relation to another requirement
+ */
+export interface Relation {
+  type: RelationType;
+
+  /** Summary or name of the related requirement */
+  target: string;
+}
+
+/**
+ * Approval outcome
+ */
+export type ApprovalStatus = "pending" | "approved" | "rejected";
+
+/**
+ * Sign-off required from an approver
+ */
+export interface Approval {
+  approver: Owner;
+
+  /** Due date (YYYY-MM-DD) */
+  due?: string;
+
+  /** Defaults to pending */
+  status?: ApprovalStatus;
+}
+
+/**
+ * Acceptance criterion of a requirement
+ */
+export interface Criterion {
+  /** ID children refer to the criterion by */
+  id: string;
+
+  criterion: string;
+}
+
+/**
+ * Clarification a requirement is waiting on; open until answered
+ */
+export interface Question {
+  question: string;
+
+  /** Whoever should answer it */
+  owner?: Owner;
+
+  /** Due date (YYYY-MM-DD) */
+  due?: string;
+
+  answer?: string;
+}
+
+/**
+ * Document a requirement refers to: a file in the repository or a URL
+ */
+export interface Attachment {
+  /** File, relative to the requirements file */
+  path?: string;
+
+  /** External document */
+  url?: string;
+
+  title: string;
+
+  /** sha256:<hex> of the document */
+  checksum?: string;
+}
+
+/**
+ * ID a requirement had in a tool it was imported from
+ */
+export interface ExternalRef {
+  /** Tool the requirement came from, such as jira or doors */
+  system: string;
+
+  id: string;
+
+  /** Link to the requirement in that tool */
+  url?: string;
+}
+
+/**
+ * Links from a requirement to what implements it
+ */
+export interface Links {
+  /** operationIds of the OpenAPI operations it covers */
+  openapi?: string[];
+}
+
 /**
  * Core Requirement structure
  * Supports both inline requirements and reference-only requirements
@@ -38,6 +138,9 @@ export interface Requirement {
   /** Unique requirement ID (e.g., RQM-001) */
   name?: string;
 
+  /** Stable identity assigned by rqm id assign, kept across renames */
+  uid?: string;
+
   /** Detailed description of the requirement */
   description?: string;
 
@@ -50,15 +153,39 @@ export interface Requirement {
   /** Link to automated acceptance test */
   acceptance_test_link?: string;
 
+  /** Acceptance criteria, which children cover by ID */
+  acceptance_criteria?: Criterion[];
+
+  /** IDs of the parent's acceptance criteria this requirement covers */
+  covers?: string[];
+
+  /** How the requirement is verified */
+  verification?: VerificationMethod;
+
+  /** Verification artifacts (test reports, analyses, procedures) */
+  verified_by?: string[];
+
+  /** Environments the requirement must be verified on (e.g. linux, windows, arm64) */
+  environments?: string[];
+
   /** Owner responsible for the requirement */
   owner?: Owner;
 
   /** Priority level */
   priority?: Priority;
 
+  /** Estimated effort, in the unit the project plans in (story points, days, ...) */
+  estimate?: number;
+
   /** Current status */
   status?: Status;
 
+  /** Retired: hidden from listings, and not to be referenced by active requirements */
+  deprecated?: boolean;
+
+  /** Summary or name of the requirement replacing this one; implies deprecated */
+  superseded_by?: string;
+
   /** Tags for categorization and filtering */
   tags?: string[];
 
@@ -68,15 +195,50 @@ export interface Requirement {
   /** Dependencies on other requirements (by name or summary) */
   dependencies?: string[];
 
+  /** Typed relations to other requirements */
+  relations?: Relation[];
+
+  /** Sign-offs required from approvers */
+  approvals?: Approval[];
+
+  /** Clarifications the requirement is waiting on */
+  open_questions?: Question[];
+
+  /** Documents the requirement refers to */
+  attachments?: Attachment[];
+
+  /** IDs the requirement had in the tools it was imported from */
+  external_refs?: ExternalRef[];
+
+  /** Links to what implements the requirement outside the tree */
+  links?: Links;
+
+  /** Custom attributes declared in .rqm/config.yml */
+  attributes?: Record<string, string | number | boolean | string[]>;
+
   /** Child requirements */
   requirements?: RequirementReference[];
 }
 
 /**
- * Requirement reference - can be either a full requirement object
- * or just a string reference (summary or name)
+ * Requirement of another repository, referenced by ID
+ */
+export interface ExternalReference {
+  external: {
+    /** Git repository, or remote requirements file, holding the requirement */
+    repo: string;
+    /** Name of the requirement in that repository */
+    id: string;
+    /** Branch, tag or commit of the repository */
+    ref?: string;
+  };
+}
+
+/**
+ * Requirement reference - a full requirement object, a string reference
+ * (summary or name), or a requirement of another repository
  */
-export type RequirementReference = Requirement | string;
+export type RequirementReference = Requirement | string | ExternalReference;
 
 /**
  * Top-level requirements configuration
@@ -104,12 +266,54 @@ export interface ValidationResult {
   warnings: string[];
 }
 
+/**
+ * Fields of the requirement form, posted to /api/requirement. With
+ * original set the requirement it names is edited, otherwise one is
+ * created, under parent when that is set.
+ */
+export interface RequirementForm {
+  original?: string;
+  parent?: string;
+  summary: string;
+  name?: string;
+  description?: string;
+  justification?: string;
+  acceptance_test?: string;
+  status?: Status;
+  priority?: Priority;
+  owner?: Owner;
+  verification?: VerificationMethod;
+  tags?: string[];
+  /** Answer with the diff and validation result without saving */
+  preview?: boolean;
+}
+
+/**
+ * Run of lines kept, deleted or inserted in a diff
+ */
+export interface DiffOp {
+  op: "equal" | "delete" | "insert";
+  text: string;
+}
+
+/**
+ * Answer of /api/requirement: the change to the file and the validation
+ * result of the file with it
+ */
+export interface RequirementWriteResponse extends ValidationResult {
+  saved: boolean;
+  diff: DiffOp[];
+  /** ETag of the file, the new one once saved */
+  etag: string;
+}
+
 /**
  * Cycle check result from graph analysis
  */
 export interface CycleCheckResult {
   has_cycles: boolean;
   cycles: string[][];
+  relations?: Record<string, Relation[]>;
 }
 
 /**
@@ -118,6 +322,8 @@ export interface CycleCheckResult {
 export interface GraphEdge {
   from: string;
   to: string;
+  /** Relation type for typed edges; absent for parent/child edges */
+  label?: RelationType;
 }
 
 /**
@@ -127,3 +333,110 @@ export interface GraphData {
   nodes: Requirement[];
   edges: GraphEdge[];
 }
+
+/**
+ * Requirement in the dependency graph of /api/graph
+ */
+export interface DependencyNode {
+  /** The requirement's summary */
+  id: string;
+  name?: string;
+  summary: string;
+  status?: Status;
+  priority?: Priority;
+  deprecated?: boolean;
+  /** Index in cycles of the circular reference it is part of */
+  cycle?: number;
+}
+
+/**
+ * Sub-requirement (child) or relation in the dependency graph
+ */
+export interface DependencyLink {
+  source: string;
+  target: string;
+  kind: "child" | RelationType;
+  /** Part of a circular reference */
+  in_cycle: boolean;
+}
+
+/**
+ * Dependency graph of /api/graph?format=d3, with the circular references
+ * rqm check reports as lists of summaries
+ */
+export interface DependencyGraphData {
+  nodes: DependencyNode[];
+  links: DependencyLink[];
+  cycles: string[][];
+}
+
+/**
+ * Node position computed by /api/graph/layout
+ */
+export interface LayoutNode {
+  id: string;
+  x: number;
+  y: number;
+}
+
+/**
+ * Requirement found by /api/search
+ */
+export interface SearchResult {
+  summary: string;
+  name?: string;
+  description?: string;
+  status?: Status;
+  priority?: Priority;
+  owner?: string;
+  tags?: string[];
+  deprecated?: boolean;
+  /** Summaries of its parents, from the top level down */
+  parents: string[];
+}
+
+/**
+ * Page of /api/search results; total counts the matches on every page
+ */
+export interface SearchResponse {
+  total: number;
+  page: number;
+  page_size: number;
+  pages: number;
+  results: SearchResult[];
+}
+
+/**
+ * Comment on a requirement, made in rqm serve
+ */
+export interface RequirementComment {
+  author: string;
+  /** RFC 3339 time the server received it */
+  time: string;
+  /** Markdown */
+  text: string;
+}
+
+/**
+ * Answer of /api/requirements/{id}/comments: the comments on the
+ * requirement and who else has it open
+ */
+export interface CommentsResponse {
+  /** ID, or summary, the comments are filed under */
+  requirement: string;
+  comments: RequirementComment[];
+  viewers: string[];
+}
+
+/**
+ * Data bundle of a static site from rqm export --format site, standing in
+ * for the API of rqm serve
+ */
+export interface SiteData {
+  schema_version: number;
+  /** Name of the requirements file exported */
+  file: string;
+  requirements: RequirementConfig;
+  graph: DependencyGraphData;
+  layout: { algorithm: string; nodes: LayoutNode[] };
+}
@@ -0,0 +1,58 @@
+// RQM - Requirements Management in Code
+// Requirement Comments and Presence
+// SPDX-License-Identifier: MIT
+
+import type { CommentsResponse } from "../types";
+
+/** How often an open requirement's comments and viewers are refreshed */
+export const COMMENTS_POLL_MS = 10000;
+
+const VIEWER_KEY = "rqm.viewer";
+
+/**
+ * Name the reader comments as, remembered in this browser
+ */
+export function getViewerName(): string {
+  return localStorage.getItem(VIEWER_KEY) ?? "";
+}
+
+export function setViewerName(name: string): void {
+  localStorage.setItem(VIEWER_KEY, name.trim());
+}
+
+function commentsURL(requirementId: string): string {
+  return `api/requirements/${encodeURIComponent(requirementId)}/comments`;
+}
+
+/**
+ * Comments on a requirement and who else has it open. Passing the viewer
+ * marks them as having it open too. Resolves to undefined without a
+ * server to ask.
+ */
+export async function fetchComments(
+  requirementId: string,
+  viewer: string,
+  signal?: AbortSignal,
+): Promise<CommentsResponse | undefined> {
+  const params = viewer ? `?${new URLSearchParams({ viewer })}` : "";
+  const response = await fetch(commentsURL(requirementId) + params, { signal });
+  if (!response.ok || !response.headers.get("Content-Type")?.includes("application/json")) {
+    return undefined;
+  }
+  return (await response.json()) as CommentsResponse;
+}
+
+/**
+ * Add a comment to a requirement; resolves to its comments with the new one
+ */
+export async function postComment(requirementId: string, author: string, text: string): Promise<CommentsResponse> {
+  const response = await fetch(commentsURL(requirementId), {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify({ author, text }),
+  });
+  if (!response.ok) {
+    throw new Error((await response.text()).trim());
+  }
+  return (await response.json()) as CommentsResponse;
+}
@@ -0,0 +1,41 @@
+// RQM - Requirements Management in Code
+// Markdown Helpers for Requirement Text
+// SPDX-License-Identifier: MIT
+
+export const MARKDOWN_HEADING = /^(#{1,6})\s+(.*?)\s*#*\s*$/;
+export const MARKDOWN_LIST_ITEM = /^(\s*)([-*+]|\d+[.)])\s+(.*)$/;
+export const MARKDOWN_RULE = /^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$/;
+// Code spans, links (and images), autolinks, bold, then italic
+export const MARKDOWN_INLINE =
+  /`([^`]+)`|!?\[([^\]]*)\]\(([^)\s]+)\)|<(https?:\/\/[^>\s]+)>|\*\*([^*]+)\*\*|__([^_]+)__|\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b/g;
+
+/**
+ * The requirement a link target such as #AUTH-001 points to: a fragment
+ * naming a requirement by ID or, URL-encoded, by summary
+ */
+export function requirementLinkTarget(target: string): string | null {
+  if (!target.startsWith("#") || target.length === 1) {
+    return null;
+  }
+  try {
+    return decodeURIComponent(target.slice(1));
+  } catch {
+    return target.slice(1);
+  }
+}
+
+/**
+ * Strip Markdown syntax for one-line previews
+ */
+export function markdownToPlainText(text: string): string {
+  return text
+    .split("\n")
+    .map((line) => line.replace(MARKDOWN_HEADING, "$2").replace(MARKDOWN_LIST_ITEM, "$3").replace(/^\s*>\s?/, ""))
+    .join(" ")
+    .replace(MARKDOWN_INLINE, (_m, code, linkText, target, autolink, b1, b2, i1, i2) =>
+      code ?? (target !== undefined ? linkText || target : undefined) ?? autolink ?? b1 ?? b2 ?? i1 ?? i2 ?? "",
+    )
+    .replace(/`{3,}|~{3,}/g, "")
+    .replace(/\s+/g, " ")
+    .trim();
+}
@@ -0,0 +1,125 @@
+// RQM - Requirements Management in Code
+// Requirement Form Validation and Submission
+// SPDX-License-Identifier: MIT
+
+import type {
+  Priority,
+  Requirement,
+  RequirementForm,
+  RequirementWriteResponse,
+  Status,
+  VerificationMethod,
+} from "../types";
+
+export const STATUSES: Status[] = ["draft", "proposed", "approved", "implemented", "verified", "deprecated"];
+export const PRIORITIES: Priority[] = ["critical", "high", "medium", "low"];
+export const VERIFICATION_METHODS: VerificationMethod[] = ["test", "analysis", "inspection", "demonstration"];
+
+/** Longest summary the schema allows */
+const MAX_SUMMARY_LENGTH = 200;
+
+/**
+ * Form fields for a requirement: its own values to edit it, or empty ones
+ * to create a requirement
+ */
+export function requirementFormFor(req?: Requirement): RequirementForm {
+  if (!req) {
+    return { summary: "" };
+  }
+  return {
+    original: req.name || req.summary,
+    summary: req.summary,
+    name: req.name,
+    description: req.description,
+    justification: req.justification,
+    acceptance_test: req.acceptance_test,
+    status: req.status,
+    priority: req.priority,
+    owner: req.owner,
+    verification: req.verification,
+    tags: req.tags,
+  };
+}
+
+/**
+ * Split a comma-separated list of tags
+ */
+export function parseTags(text: string): string[] {
+  return text
+    .split(",")
+    .map((tag) => tag.trim())
+    .filter((tag) => tag !== "");
+}
+
+/**
+ * Check the form against the schema before it is sent, so mistakes show
+ * next to their field. The server validates the whole file again, which
+ * also catches what needs the other requirements, such as a duplicate
+ * summary. Returns an error message per field.
+ */
+export function validateRequirementForm(form: RequirementForm): Partial<Record<keyof RequirementForm, string>> {
+  const errors: Partial<Record<keyof RequirementForm, string>> = {};
+  const summary = form.summary.trim();
+  if (summary === "") {
+    errors.summary = "A summary is required";
+  } else if (summary.length > MAX_SUMMARY_LENGTH) {
+    errors.summary = `At most ${MAX_SUMMARY_LENGTH} characters (${summary.length} now)`;
+  }
+  if (form.status && !STATUSES.includes(form.status)) {
+    errors.status = `Must be one of ${STATUSES.join(", ")}`;
+  }
+  if (form.priority && !PRIORITIES.includes(form.priority)) {
+    errors.priority = `Must be one of ${PRIORITIES.join(", ")}`;
+  }
+  if (form.verification && !VERIFICATION_METHODS.includes(form.verification)) {
+    errors.verification = `Must be one of ${VERIFICATION_METHODS.join(", ")}`;
+  }
+  if (form.owner !== undefined && form.owner !== "" && form.owner.trim() === "") {
+    errors.owner = "An owner can't be blank";
+  }
+  const tags = form.tags ?? [];
+  const duplicate = tags.find((tag, i) => tags.indexOf(tag) !== i);
+  if (duplicate) {
+    errors.tags = `"${duplicate}" is listed twice`;
+  }
+  return errors;
+}
+
+/**
+ * Result of sending the form: the server's answer, or the error it gave
+ * instead. A conflict means the file changed since its ETag was read.
+ */
+export interface SubmitResult {
+  response?: RequirementWriteResponse;
+  error?: string;
+  conflict?: boolean;
+}
+
+/**
+ * Send the form to rqm serve, previewing the change or saving it. The
+ * ETag of the file the form was filled in against makes the server refuse
+ * the change when the file has changed since.
+ */
+export async function submitRequirementForm(form: RequirementForm, etag?: string): Promise<SubmitResult> {
+  const response = await fetch("api/requirement", {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+      ...(etag ? { "If-Match": etag } : {}),
+    },
+    body: JSON.stringify(form),
+  });
+  // A change that fails validation is answered with the errors and diff
+  if (response.ok || response.status === 422) {
+    return { response: (await response.json()) as RequirementWriteResponse };
+  }
+  return { error: (await response.text()).trim(), conflict: response.status === 412 };
+}
+
+/**
+ * ETag of the requirements file served, for the If-Match of an edit
+ */
+export async function fetchRequirementsETag(): Promise<string | undefined> {
+  const response = await fetch("api/requirements", { method: "HEAD" });
+  return response.ok ? (response.headers.get("ETag") ?? undefined) : undefined;
+}
@@ -3,6 +3,8 @@
 // SPDX-License-Identifier: MIT
 
 import type {
+  Attachment,
+  ExternalReference,
   Requirement,
   RequirementReference,
   RequirementConfig,
@@ -17,6 +19,28 @@ export function isRequirement(ref: RequirementReference): ref is Requirement {
   return typeof ref === "object" && ref !== null && "summary" in ref;
 }
 
+/**
+ * Check if a requirement reference points into another repository
+ */
+export function isExternalReference(ref: RequirementReference): ref is ExternalReference {
+  return typeof ref === "object" && ref !== null && "external" in ref;
+}
+
+/**
+ * Link to an attachment: its URL, or the file as served by rqm serve
+ */
+export function attachmentHref(attachment: Attachment): string {
+  return attachment.url ?? `api/attachment?path=${encodeURIComponent(attachment.path ?? "")}`;
+}
+
+/**
+ * Check if a requirement is retired: marked deprecated, with the
+ * deprecated status, or superseded by another
+ */
+export function isDeprecated(req: Requirement): boolean {
+  return !!req.deprecated || !!req.superseded_by || req.status === "deprecated";
+}
+
 /**
  * Flatten nested requirements into a flat array
  */
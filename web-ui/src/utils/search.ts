@@ -0,0 +1,45 @@
+// RQM - Requirements Management in Code
+// Server-side Requirement Search
+// SPDX-License-Identifier: MIT
+
+import type { Priority, SearchResponse, Status } from "../types";
+
+/** Results fetched per page */
+export const SEARCH_PAGE_SIZE = 50;
+
+export interface SearchQuery {
+  q: string;
+  status?: Status;
+  priority?: Priority;
+  page: number;
+}
+
+/**
+ * Search the requirements rqm serve serves. Resolves to undefined when
+ * there is no server to ask, such as in the development build, so the
+ * caller can filter the requirements it has instead.
+ */
+export async function searchRequirements(query: SearchQuery, signal?: AbortSignal): Promise<SearchResponse | undefined> {
+  const params = new URLSearchParams({ q: query.q, page: String(query.page), page_size: String(SEARCH_PAGE_SIZE) });
+  if (query.status) {
+    params.set("status", query.status);
+  }
+  if (query.priority) {
+    params.set("priority", query.priority);
+  }
+  // The deprecated status is only found when asked for
+  if (query.status === "deprecated") {
+    params.set("include_deprecated", "true");
+  }
+  const response = await fetch(`api/search?${params}`, { signal });
+  if (response.status === 404) {
+    return undefined;
+  }
+  if (!response.ok) {
+    throw new Error((await response.text()).trim());
+  }
+  if (!response.headers.get("Content-Type")?.includes("application/json")) {
+    return undefined;
+  }
+  return (await response.json()) as SearchResponse;
+}
@@ -0,0 +1,22 @@
+// RQM - Requirements Management in Code
+// Static Site Data
+// SPDX-License-Identifier: MIT
+
+import type { SiteData } from "../types";
+
+/**
+ * Load the data bundle of a static site, which rqm export --format site
+ * names in a <meta name="rqm-data"> of index.html. Resolves to undefined
+ * when the UI is served by rqm serve instead.
+ */
+export async function loadSiteData(): Promise<SiteData | undefined> {
+  const url = document.querySelector<HTMLMetaElement>('meta[name="rqm-data"]')?.content;
+  if (!url) {
+    return undefined;
+  }
+  const response = await fetch(url);
+  if (!response.ok) {
+    throw new Error(`Failed to load ${url}: ${response.statusText}`);
+  }
+  return (await response.json()) as SiteData;
+}
@@ -4,4 +4,7 @@ import react from "@vitejs/plugin-react";
 // https://vite.dev/config/
 export default defineConfig({
   plugins: [react()],
+  // Assets, like the API URLs the UI fetches, are relative so the UI also
+  // works when rqm serve --base-path puts it under a subpath
+  base: "./",
 });